@@ -0,0 +1,139 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/m3db/m3/src/metrics/aggregation"
+
+	"github.com/spaolacci/murmur3"
+)
+
+const (
+	// hllPrecision is the number of hash bits used to pick a HyperLogLog
+	// register, which yields 2^14 registers and a standard error of ~0.8%.
+	hllPrecision = 14
+	hllRegisters = 1 << hllPrecision
+
+	// maxExactDistinctValues is the number of distinct values tracked exactly
+	// before switching to the HyperLogLog sketch, at which point the sketch
+	// uses about as much memory as the exact set.
+	maxExactDistinctValues = 1024
+)
+
+var hllAlpha = 0.7213 / (1 + 1.079/float64(hllRegisters))
+
+// Distinct aggregates set values into a distinct count. Values are counted
+// exactly until there are too many of them, after which the distinct count
+// is estimated with a HyperLogLog sketch.
+type Distinct struct {
+	Options
+
+	count     int64
+	exact     map[uint64]struct{}
+	registers []uint8
+}
+
+// NewDistinct creates a new distinct aggregation.
+func NewDistinct(opts Options) Distinct {
+	return Distinct{
+		Options: opts,
+		exact:   make(map[uint64]struct{}),
+	}
+}
+
+// Update updates the distinct aggregation with a value.
+func (d *Distinct) Update(value []byte) {
+	d.count++
+	h := murmur3.Sum64(value)
+	if d.registers != nil {
+		d.addToSketch(h)
+		return
+	}
+	d.exact[h] = struct{}{}
+	if len(d.exact) <= maxExactDistinctValues {
+		return
+	}
+	d.registers = make([]uint8, hllRegisters)
+	for h := range d.exact {
+		d.addToSketch(h)
+	}
+	d.exact = nil
+}
+
+// Count returns the number of values received.
+func (d *Distinct) Count() int64 { return d.count }
+
+// Distinct returns the number of distinct values received, which is an
+// estimate once the number of distinct values exceeds the exact limit.
+func (d *Distinct) Distinct() float64 {
+	if d.registers == nil {
+		return float64(len(d.exact))
+	}
+	var (
+		sum   float64
+		zeros int
+	)
+	for _, r := range d.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	m := float64(hllRegisters)
+	estimate := hllAlpha * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// Use linear counting for small cardinalities where the raw
+		// estimate is heavily biased.
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return math.Round(estimate)
+}
+
+// ValueOf returns the value for the aggregation type.
+func (d *Distinct) ValueOf(aggType aggregation.Type) float64 {
+	switch aggType {
+	case aggregation.Count:
+		return float64(d.Count())
+	case aggregation.Distinct:
+		return d.Distinct()
+	default:
+		return 0
+	}
+}
+
+// Close closes the distinct aggregation.
+func (d *Distinct) Close() {
+	d.exact = nil
+	d.registers = nil
+}
+
+func (d *Distinct) addToSketch(h uint64) {
+	idx := h >> (64 - hllPrecision)
+	// The guard bit caps the rank so it fits the remaining hash bits.
+	w := h<<hllPrecision | 1<<(hllPrecision-1)
+	rank := uint8(bits.LeadingZeros64(w) + 1)
+	if rank > d.registers[idx] {
+		d.registers[idx] = rank
+	}
+}
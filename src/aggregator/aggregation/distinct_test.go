@@ -0,0 +1,86 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/m3db/m3/src/metrics/aggregation"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistinctExact(t *testing.T) {
+	d := NewDistinct(NewOptions())
+	require.Equal(t, 0.0, d.Distinct())
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < maxExactDistinctValues; j++ {
+			d.Update([]byte(fmt.Sprintf("user-%d", j)))
+		}
+	}
+	require.Nil(t, d.registers)
+	require.Equal(t, int64(3*maxExactDistinctValues), d.Count())
+	require.Equal(t, float64(maxExactDistinctValues), d.Distinct())
+}
+
+func TestDistinctSketch(t *testing.T) {
+	d := NewDistinct(NewOptions())
+	for _, n := range []int{maxExactDistinctValues + 1, 10000, 100000, 1000000} {
+		for i := 0; i < n; i++ {
+			d.Update([]byte(fmt.Sprintf("user-%d", i)))
+		}
+		require.Nil(t, d.exact)
+		require.NotNil(t, d.registers)
+		require.InEpsilon(t, float64(n), d.Distinct(), 0.02)
+	}
+}
+
+func TestDistinctAggregationTypes(t *testing.T) {
+	d := NewDistinct(NewOptions())
+	for _, v := range []string{"foo", "bar", "foo", "baz", "bar"} {
+		d.Update([]byte(v))
+	}
+
+	for aggType := range aggregation.ValidTypes {
+		v := d.ValueOf(aggType)
+		switch aggType {
+		case aggregation.Count:
+			require.Equal(t, float64(5), v)
+		case aggregation.Distinct:
+			require.Equal(t, float64(3), v)
+		default:
+			require.Equal(t, float64(0), v)
+			require.False(t, aggType.IsValidForSet())
+		}
+	}
+}
+
+func TestDistinctClose(t *testing.T) {
+	d := NewDistinct(NewOptions())
+	for i := 0; i <= maxExactDistinctValues; i++ {
+		d.Update([]byte(fmt.Sprintf("user-%d", i)))
+	}
+	d.Close()
+	require.Nil(t, d.exact)
+	require.Nil(t, d.registers)
+}
@@ -21,6 +21,9 @@
 package aggregator
 
 import (
+	"encoding/binary"
+	"math"
+
 	"github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/metrics/metric/unaggregated"
 )
@@ -54,3 +57,19 @@ type gaugeAggregation struct {
 func newGaugeAggregation(g aggregation.Gauge) gaugeAggregation   { return gaugeAggregation{Gauge: g} }
 func (g *gaugeAggregation) Add(value float64)                    { g.Gauge.Update(value) }
 func (g *gaugeAggregation) AddUnion(mu unaggregated.MetricUnion) { g.Gauge.Update(mu.GaugeVal) }
+
+// setAggregation is a set aggregation.
+type setAggregation struct {
+	aggregation.Distinct
+}
+
+func newSetAggregation(d aggregation.Distinct) setAggregation  { return setAggregation{Distinct: d} }
+func (s *setAggregation) AddUnion(mu unaggregated.MetricUnion) { s.Distinct.Update(mu.SetVal) }
+
+// Add adds a numeric value to the set using its binary representation as the
+// set member.
+func (s *setAggregation) Add(value float64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(value))
+	s.Distinct.Update(b[:])
+}
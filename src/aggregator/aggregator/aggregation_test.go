@@ -105,3 +105,26 @@ func TestGaugeAggregationAddUnion(t *testing.T) {
 	require.Equal(t, int64(3), g.Count())
 	require.Equal(t, 123.456, g.Sum())
 }
+
+func TestSetAggregationAdd(t *testing.T) {
+	s := newSetAggregation(aggregation.NewDistinct(aggregation.NewOptions()))
+	for _, v := range testAggregationValues {
+		s.Add(v)
+	}
+	s.Add(testAggregationValues[0])
+	require.Equal(t, int64(5), s.Count())
+	require.Equal(t, 4.0, s.Distinct.Distinct())
+}
+
+func TestSetAggregationAddUnion(t *testing.T) {
+	s := newSetAggregation(aggregation.NewDistinct(aggregation.NewOptions()))
+	for _, v := range []string{"foo", "bar", "foo"} {
+		s.AddUnion(unaggregated.MetricUnion{
+			Type:   metric.SetType,
+			ID:     testSetID,
+			SetVal: []byte(v),
+		})
+	}
+	require.Equal(t, int64(3), s.Count())
+	require.Equal(t, 2.0, s.Distinct.Distinct())
+}
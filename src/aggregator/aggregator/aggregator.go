@@ -84,7 +84,7 @@ type Aggregator interface {
 }
 
 // aggregator stores aggregations of different types of metrics (e.g., counter,
-// timer, gauges) and periodically flushes them out.
+// timer, gauges, sets) and periodically flushes them out.
 type aggregator struct {
 	sync.RWMutex
 
@@ -496,6 +496,9 @@ func (agg *aggregator) checkMetricType(mu unaggregated.MetricUnion) error {
 	case metric.GaugeType:
 		agg.metrics.gauges.Inc(1)
 		return nil
+	case metric.SetType:
+		agg.metrics.sets.Inc(1)
+		return nil
 	default:
 		return errInvalidMetricType
 	}
@@ -891,6 +894,7 @@ type aggregatorMetrics struct {
 	timers       tally.Counter
 	timerBatches tally.Counter
 	gauges       tally.Counter
+	sets         tally.Counter
 	forwarded    tally.Counter
 	timed        tally.Counter
 	addUntimed   aggregatorAddUntimedMetrics
@@ -919,6 +923,7 @@ func newAggregatorMetrics(
 		timers:       scope.Counter("timers"),
 		timerBatches: scope.Counter("timer-batches"),
 		gauges:       scope.Counter("gauges"),
+		sets:         scope.Counter("sets"),
 		forwarded:    scope.Counter("forwarded"),
 		timed:        scope.Counter("timed"),
 		addUntimed:   newAggregatorAddUntimedMetrics(addUntimedScope, samplingRate),
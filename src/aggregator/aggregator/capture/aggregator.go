@@ -43,6 +43,7 @@ type aggregator struct {
 	countersWithMetadatas        []unaggregated.CounterWithMetadatas
 	batchTimersWithMetadatas     []unaggregated.BatchTimerWithMetadatas
 	gaugesWithMetadatas          []unaggregated.GaugeWithMetadatas
+	setsWithMetadatas            []unaggregated.SetWithMetadatas
 	forwardedMetricsWithMetadata []aggregated.ForwardedMetricWithMetadata
 	timedMetricsWithMetadata     []aggregated.TimedMetricWithMetadata
 }
@@ -84,6 +85,12 @@ func (agg *aggregator) AddUntimed(
 			StagedMetadatas: sm,
 		}
 		agg.gaugesWithMetadatas = append(agg.gaugesWithMetadatas, gp)
+	case metric.SetType:
+		sp := unaggregated.SetWithMetadatas{
+			Set:             mu.Set(),
+			StagedMetadatas: sm,
+		}
+		agg.setsWithMetadatas = append(agg.setsWithMetadatas, sp)
 	default:
 		return fmt.Errorf("unrecognized metric type %v", mu.Type)
 	}
@@ -149,12 +156,14 @@ func (agg *aggregator) Snapshot() SnapshotResult {
 		CountersWithMetadatas:        agg.countersWithMetadatas,
 		BatchTimersWithMetadatas:     agg.batchTimersWithMetadatas,
 		GaugesWithMetadatas:          agg.gaugesWithMetadatas,
+		SetsWithMetadatas:            agg.setsWithMetadatas,
 		ForwardedMetricsWithMetadata: agg.forwardedMetricsWithMetadata,
 		TimedMetricWithMetadata:      agg.timedMetricsWithMetadata,
 	}
 	agg.countersWithMetadatas = nil
 	agg.batchTimersWithMetadatas = nil
 	agg.gaugesWithMetadatas = nil
+	agg.setsWithMetadatas = nil
 	agg.forwardedMetricsWithMetadata = nil
 	agg.timedMetricsWithMetadata = nil
 	agg.numMetricsAdded = 0
@@ -178,6 +187,13 @@ func cloneUntimedMetric(m unaggregated.MetricUnion) unaggregated.MetricUnion {
 		copy(clonedTimerVal, m.BatchTimerVal)
 		mu.BatchTimerVal = clonedTimerVal
 	}
+
+	// Clone set values.
+	if m.Type == metric.SetType {
+		clonedSetVal := make([]byte, len(m.SetVal))
+		copy(clonedSetVal, m.SetVal)
+		mu.SetVal = clonedSetVal
+	}
 	return mu
 }
 
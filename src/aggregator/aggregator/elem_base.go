@@ -373,6 +373,39 @@ func (e *gaugeElemBase) ResetSetData(
 
 func (e *gaugeElemBase) Close() {}
 
+type setElemBase struct{}
+
+func (e setElemBase) Type() metric.Type { return metric.SetType }
+
+func (e setElemBase) FullPrefix(opts Options) []byte { return opts.FullSetPrefix() }
+
+func (e setElemBase) DefaultAggregationTypes(aggTypesOpts maggregation.TypesOptions) maggregation.Types {
+	return aggTypesOpts.DefaultSetAggregationTypes()
+}
+
+func (e setElemBase) TypeStringFor(aggTypesOpts maggregation.TypesOptions, aggType maggregation.Type) []byte {
+	return aggTypesOpts.TypeStringForSet(aggType)
+}
+
+func (e setElemBase) ElemPool(opts Options) SetElemPool { return opts.SetElemPool() }
+
+func (e setElemBase) NewAggregation(_ Options, aggOpts raggregation.Options) setAggregation {
+	return newSetAggregation(raggregation.NewDistinct(aggOpts))
+}
+
+func (e *setElemBase) ResetSetData(
+	_ maggregation.TypesOptions,
+	aggTypes maggregation.Types,
+	_ bool,
+) error {
+	if !aggTypes.IsValidForSet() {
+		return fmt.Errorf("invalid aggregation types %s for set", aggTypes.String())
+	}
+	return nil
+}
+
+func (e *setElemBase) Close() {}
+
 // nolint: maligned
 type parsedPipeline struct {
 	// Whether the source pipeline contains derivative transformations at its head.
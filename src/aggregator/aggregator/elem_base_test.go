@@ -300,6 +300,41 @@ func TestGaugeElemBaseResetSetDataInvalidTypes(t *testing.T) {
 	require.True(t, strings.Contains(err.Error(), "invalid aggregation types P99 for gauge"))
 }
 
+func TestSetElemBase(t *testing.T) {
+	opts := NewOptions()
+	aggTypesOpts := opts.AggregationTypesOptions()
+	e := setElemBase{}
+	require.Equal(t, []byte("stats.sets."), e.FullPrefix(opts))
+	require.Equal(t, maggregation.Types{maggregation.Distinct}, e.DefaultAggregationTypes(aggTypesOpts))
+	require.Equal(t, []byte(nil), e.TypeStringFor(aggTypesOpts, maggregation.Distinct))
+	require.True(t, opts.SetElemPool() == e.ElemPool(opts))
+}
+
+func TestSetElemBaseNewLockedAggregation(t *testing.T) {
+	e := setElemBase{}
+	la := e.NewAggregation(nil, raggregation.Options{})
+	for _, v := range []string{"foo", "bar", "foo"} {
+		la.AddUnion(unaggregated.MetricUnion{
+			Type:   metric.SetType,
+			SetVal: []byte(v),
+		})
+	}
+	require.Equal(t, 2.0, la.ValueOf(maggregation.Distinct))
+	require.Equal(t, 3.0, la.ValueOf(maggregation.Count))
+}
+
+func TestSetElemBaseResetSetData(t *testing.T) {
+	e := setElemBase{}
+	require.NoError(t, e.ResetSetData(nil, maggregation.Types{maggregation.Count, maggregation.Distinct}, false))
+}
+
+func TestSetElemBaseResetSetDataInvalidTypes(t *testing.T) {
+	e := setElemBase{}
+	err := e.ResetSetData(nil, maggregation.Types{maggregation.Sum}, false)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "invalid aggregation types Sum for set"))
+}
+
 func TestParsedPipelineEmptyPipeline(t *testing.T) {
 	p := applied.Pipeline{}
 	pp, err := newParsedPipeline(p)
@@ -46,6 +46,7 @@ var (
 	testCounterID                 = id.RawID("testCounter")
 	testBatchTimerID              = id.RawID("testBatchTimer")
 	testGaugeID                   = id.RawID("testGauge")
+	testSetID                     = id.RawID("testSet")
 	testStoragePolicy             = policy.NewStoragePolicy(10*time.Second, xtime.Second, 6*time.Hour)
 	testAggregationTypes          = maggregation.Types{maggregation.Mean, maggregation.Sum}
 	testAggregationTypesExpensive = maggregation.Types{maggregation.SumSq}
@@ -65,6 +66,11 @@ var (
 		ID:       testGaugeID,
 		GaugeVal: 123.456,
 	}
+	testSet = unaggregated.MetricUnion{
+		Type:   metric.SetType,
+		ID:     testSetID,
+		SetVal: []byte("foo"),
+	}
 	testPipeline = applied.NewPipeline([]applied.OpUnion{
 		{
 			Type:           pipeline.TransformationOpType,
@@ -236,7 +236,7 @@ func (e *Entry) AddUntimed(
 		}
 		return err
 	default:
-		// For counters and gauges, there is a single value in the metric union.
+		// For counters, gauges and sets, there is a single value in the metric union.
 		if err := e.applyValueRateLimit(1, e.metrics.untimed.rateLimit); err != nil {
 			return err
 		}
@@ -534,6 +534,8 @@ func (e *Entry) addNewAggregationKeyWithLock(
 		newElem = e.opts.TimerElemPool().Get()
 	case metric.GaugeType:
 		newElem = e.opts.GaugeElemPool().Get()
+	case metric.SetType:
+		newElem = e.opts.SetElemPool().Get()
 	default:
 		return nil, errInvalidMetricType
 	}
@@ -936,9 +936,10 @@ func TestEntryAddUntimedWithInvalidAggregationType(t *testing.T) {
 	defer ctrl.Finish()
 
 	var (
-		compressedMin   = aggregation.MustCompressTypes(aggregation.Min)
-		compressedLast  = aggregation.MustCompressTypes(aggregation.Last)
-		compressedP9999 = aggregation.MustCompressTypes(aggregation.P9999)
+		compressedMin      = aggregation.MustCompressTypes(aggregation.Min)
+		compressedLast     = aggregation.MustCompressTypes(aggregation.Last)
+		compressedP9999    = aggregation.MustCompressTypes(aggregation.P9999)
+		compressedDistinct = aggregation.MustCompressTypes(aggregation.Distinct)
 	)
 	inputs := []struct {
 		metric        unaggregated.MetricUnion
@@ -975,6 +976,16 @@ func TestEntryAddUntimedWithInvalidAggregationType(t *testing.T) {
 			aggregationID: compressedLast,
 			expectError:   true,
 		},
+		{
+			metric:        testSet,
+			aggregationID: compressedDistinct,
+			expectError:   false,
+		},
+		{
+			metric:        testSet,
+			aggregationID: compressedMin,
+			expectError:   true,
+		},
 	}
 
 	for _, input := range inputs {
@@ -998,6 +1009,32 @@ func TestEntryAddUntimedWithInvalidAggregationType(t *testing.T) {
 	}
 }
 
+func TestEntryAddUntimedSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e, _, now := testEntry(ctrl)
+	*now = time.Unix(105, 0)
+	e.opts = e.opts.SetDefaultStoragePolicies(testDefaultStoragePolicies)
+
+	for _, v := range []string{"foo", "bar", "foo"} {
+		mu := testSet
+		mu.SetVal = []byte(v)
+		require.NoError(t, e.AddUntimed(mu, testDefaultStagedMetadatas))
+	}
+
+	require.Equal(t, 2, len(e.aggregations))
+	for _, key := range testDefaultAggregationKeys {
+		idx := e.aggregations.index(key)
+		require.True(t, idx >= 0)
+		elem := e.aggregations[idx].elem.Value.(*SetElem)
+		require.Equal(t, testSetID, elem.ID())
+		require.Equal(t, 1, len(elem.values))
+		require.Equal(t, 2.0, elem.values[0].lockedAgg.aggregation.ValueOf(aggregation.Distinct))
+		require.Equal(t, 3.0, elem.values[0].lockedAgg.aggregation.ValueOf(aggregation.Count))
+	}
+}
+
 func TestEntryAddUntimedWithInvalidPipeline(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
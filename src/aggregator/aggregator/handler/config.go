@@ -36,6 +36,7 @@ import (
 	"github.com/m3db/m3/src/cluster/kv"
 	"github.com/m3db/m3/src/cluster/services"
 	"github.com/m3db/m3/src/metrics/encoding/msgpack"
+	"github.com/m3db/m3/src/msg/kafka"
 	"github.com/m3db/m3/src/msg/producer"
 	"github.com/m3db/m3/src/msg/producer/config"
 	"github.com/m3db/m3x/instrument"
@@ -113,6 +114,12 @@ func (c FlushHandlerConfiguration) NewHandler(
 				return nil, err
 			}
 			sharderRouters = append(sharderRouters, sharderRouter)
+		case kafkaType:
+			sharderRouter, err := hc.StaticBackend.NewKafkaSharderRouter(store, instrumentOpts)
+			if err != nil {
+				return nil, err
+			}
+			sharderRouters = append(sharderRouters, sharderRouter)
 		default:
 			return nil, fmt.Errorf("unknown backend type %v", hc.StaticBackend.Type)
 		}
@@ -275,6 +282,9 @@ type staticBackendConfiguration struct {
 
 	// TrafficControl configs the traffic controller.
 	TrafficControl *trafficcontrol.Configuration `yaml:"trafficControl"`
+
+	// Kafka configs the Kafka backend.
+	Kafka *kafkaBackendConfiguration `yaml:"kafka"`
 }
 
 func (c *staticBackendConfiguration) Validate() error {
@@ -362,6 +372,53 @@ func (c *staticBackendConfiguration) NewSharderRouter(
 	return sr, nil
 }
 
+// NewKafkaSharderRouter creates a new sharder router that publishes the
+// encoded aggregated metrics to a Kafka topic.
+func (c *staticBackendConfiguration) NewKafkaSharderRouter(
+	store kv.Store,
+	instrumentOpts instrument.Options,
+) (SharderRouter, error) {
+	if c.Kafka == nil {
+		return SharderRouter{}, fmt.Errorf("backend %s configuration has no kafka configuration", c.Name)
+	}
+	scope := instrumentOpts.MetricsScope().Tagged(map[string]string{
+		"backend":   c.Name,
+		"component": "kafka-producer",
+	})
+	p, err := c.Kafka.Producer.NewProducer(instrumentOpts.SetMetricsScope(scope))
+	if err != nil {
+		return SharderRouter{}, err
+	}
+	r := router.NewKafkaRouter(p)
+	if c.TrafficControl != nil {
+		tc, err := c.TrafficControl.NewTrafficController(
+			store,
+			instrumentOpts.SetMetricsScope(scope),
+		)
+		if err != nil {
+			p.Close()
+			return SharderRouter{}, err
+		}
+		r = trafficcontrol.NewRouter(tc, r, scope)
+	}
+	return SharderRouter{
+		SharderID: sharding.NewSharderID(c.Kafka.HashType, c.Kafka.TotalShards),
+		Router:    r,
+	}, nil
+}
+
+type kafkaBackendConfiguration struct {
+	// Hashing function type.
+	HashType sharding.HashType `yaml:"hashType"`
+
+	// Total number of shards, the shards are mapped onto the partitions of
+	// the topic by modulo.
+	TotalShards int `yaml:"totalShards" validate:"nonzero"`
+
+	// Producer configs the Kafka producer.
+	Producer kafka.ProducerConfiguration `yaml:"producer"`
+}
+
 type shardedConfiguration struct {
 	// Hashing function type.
 	HashType sharding.HashType `yaml:"hashType"`
@@ -23,6 +23,9 @@ package handler
 import (
 	"testing"
 
+	"github.com/m3db/m3/src/aggregator/sharding"
+	"github.com/m3db/m3x/instrument"
+
 	"github.com/stretchr/testify/require"
 	yaml "gopkg.in/yaml.v2"
 )
@@ -199,3 +202,32 @@ sharded:
 		require.Equal(t, test.expectedErr, err.Error())
 	}
 }
+
+func TestKafkaBackendConfiguration(t *testing.T) {
+	str := `
+type: kafka
+name: kafka1
+kafka:
+  hashType: murmur32
+  totalShards: 64
+  producer:
+    client:
+      brokers:
+        - 127.0.0.1:9092
+    topic: aggregated_metrics
+`
+
+	var cfg staticBackendConfiguration
+	require.NoError(t, yaml.Unmarshal([]byte(str), &cfg))
+	require.Equal(t, kafkaType, cfg.Type)
+	require.NotNil(t, cfg.Kafka)
+	require.Equal(t, sharding.Murmur32Hash, cfg.Kafka.HashType)
+	require.Equal(t, 64, cfg.Kafka.TotalShards)
+	require.Equal(t, []string{"127.0.0.1:9092"}, cfg.Kafka.Producer.Client.Brokers)
+	require.Equal(t, "aggregated_metrics", cfg.Kafka.Producer.Topic)
+
+	cfg.Kafka = nil
+	_, err := cfg.NewKafkaSharderRouter(nil, instrument.NewOptions())
+	require.Error(t, err)
+	require.Equal(t, "backend kafka1 configuration has no kafka configuration", err.Error())
+}
@@ -33,6 +33,7 @@ const (
 	blackholeType Type = "blackhole"
 	loggingType   Type = "logging"
 	forwardType   Type = "forward"
+	kafkaType     Type = "kafka"
 )
 
 var (
@@ -40,6 +41,7 @@ var (
 		blackholeType,
 		loggingType,
 		forwardType,
+		kafkaType,
 	}
 )
 
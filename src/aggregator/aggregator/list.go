@@ -30,6 +30,7 @@ import (
 
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
+	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
 	metricid "github.com/m3db/m3/src/metrics/metric/id"
 	"github.com/m3db/m3/src/metrics/policy"
@@ -251,7 +252,7 @@ func (l *baseMetricList) Len() int {
 // need to switch to a custom type-specific list implementation.
 func (l *baseMetricList) PushBack(value metricElem) (*list.Element, error) {
 	var (
-		forwardedMetricType         = value.Type()
+		forwardedMetricType         = forwardedMetricTypeFor(value.Type())
 		forwardedID, hasForwardedID = value.ForwardedID()
 		forwardedAggregationKey, _  = value.ForwardedAggregationKey()
 	)
@@ -510,6 +511,16 @@ func (l *baseMetricList) onForwardingElemDiscarded(
 	l.metrics.flushForwarded.onDiscarded.Inc(1)
 }
 
+// forwardedMetricTypeFor returns the type of the metrics forwarded by elements
+// of the given type. Set values have already been turned into distinct counts
+// by the time they are forwarded, so they are forwarded as gauges.
+func forwardedMetricTypeFor(elemType metric.Type) metric.Type {
+	if elemType == metric.SetType {
+		return metric.GaugeType
+	}
+	return elemType
+}
+
 // Standard metrics whose timestamps are earlier than current time can be flushed.
 func standardMetricTargetNanos(nowNanos int64) int64 { return nowNanos }
 
@@ -42,6 +42,7 @@ var (
 	defaultCounterPrefix              = []byte("counts.")
 	defaultTimerPrefix                = []byte("timers.")
 	defaultGaugePrefix                = []byte("gauges.")
+	defaultSetPrefix                  = []byte("sets.")
 	defaultEntryTTL                   = 24 * time.Hour
 	defaultEntryCheckInterval         = time.Hour
 	defaultEntryCheckBatchPercent     = 0.01
@@ -108,6 +109,12 @@ type Options interface {
 	// GaugePrefix returns the prefix for gauges.
 	GaugePrefix() []byte
 
+	// SetSetPrefix sets the prefix for sets.
+	SetSetPrefix(value []byte) Options
+
+	// SetPrefix returns the prefix for sets.
+	SetPrefix() []byte
+
 	// SetTimeLock sets the time lock.
 	SetTimeLock(value *sync.RWMutex) Options
 
@@ -290,6 +297,12 @@ type Options interface {
 	// GaugeElemPool returns the gauge element pool.
 	GaugeElemPool() GaugeElemPool
 
+	// SetSetElemPool sets the set element pool.
+	SetSetElemPool(value SetElemPool) Options
+
+	// SetElemPool returns the set element pool.
+	SetElemPool() SetElemPool
+
 	/// Read-only derived options.
 
 	// FullCounterPrefix returns the full prefix for counters.
@@ -300,6 +313,9 @@ type Options interface {
 
 	// FullGaugePrefix returns the full prefix for gauges.
 	FullGaugePrefix() []byte
+
+	// FullSetPrefix returns the full prefix for sets.
+	FullSetPrefix() []byte
 }
 
 type options struct {
@@ -309,6 +325,7 @@ type options struct {
 	counterPrefix                    []byte
 	timerPrefix                      []byte
 	gaugePrefix                      []byte
+	setPrefix                        []byte
 	timeLock                         *sync.RWMutex
 	clockOpts                        clock.Options
 	instrumentOpts                   instrument.Options
@@ -338,11 +355,13 @@ type options struct {
 	counterElemPool                  CounterElemPool
 	timerElemPool                    TimerElemPool
 	gaugeElemPool                    GaugeElemPool
+	setElemPool                      SetElemPool
 
 	// Derived options.
 	fullCounterPrefix []byte
 	fullTimerPrefix   []byte
 	fullGaugePrefix   []byte
+	fullSetPrefix     []byte
 	timerQuantiles    []float64
 }
 
@@ -351,13 +370,15 @@ func NewOptions() Options {
 	aggTypesOptions := aggregation.NewTypesOptions().
 		SetCounterTypeStringTransformFn(aggregation.EmptyTransform).
 		SetTimerTypeStringTransformFn(aggregation.SuffixTransform).
-		SetGaugeTypeStringTransformFn(aggregation.EmptyTransform)
+		SetGaugeTypeStringTransformFn(aggregation.EmptyTransform).
+		SetSetTypeStringTransformFn(aggregation.EmptyTransform)
 	o := &options{
 		aggTypesOptions:    aggTypesOptions,
 		metricPrefix:       defaultMetricPrefix,
 		counterPrefix:      defaultCounterPrefix,
 		timerPrefix:        defaultTimerPrefix,
 		gaugePrefix:        defaultGaugePrefix,
+		setPrefix:          defaultSetPrefix,
 		timeLock:           &sync.RWMutex{},
 		clockOpts:          clock.NewOptions(),
 		instrumentOpts:     instrument.NewOptions(),
@@ -432,6 +453,17 @@ func (o *options) GaugePrefix() []byte {
 	return o.gaugePrefix
 }
 
+func (o *options) SetSetPrefix(value []byte) Options {
+	opts := *o
+	opts.setPrefix = value
+	opts.computeFullSetPrefix()
+	return &opts
+}
+
+func (o *options) SetPrefix() []byte {
+	return o.setPrefix
+}
+
 func (o *options) SetTimeLock(value *sync.RWMutex) Options {
 	opts := *o
 	opts.timeLock = value
@@ -732,6 +764,16 @@ func (o *options) GaugeElemPool() GaugeElemPool {
 	return o.gaugeElemPool
 }
 
+func (o *options) SetSetElemPool(value SetElemPool) Options {
+	opts := *o
+	opts.setElemPool = value
+	return &opts
+}
+
+func (o *options) SetElemPool() SetElemPool {
+	return o.setElemPool
+}
+
 func (o *options) FullCounterPrefix() []byte {
 	return o.fullCounterPrefix
 }
@@ -744,6 +786,10 @@ func (o *options) FullGaugePrefix() []byte {
 	return o.fullGaugePrefix
 }
 
+func (o *options) FullSetPrefix() []byte {
+	return o.fullSetPrefix
+}
+
 func (o *options) TimerQuantiles() []float64 {
 	return o.timerQuantiles
 }
@@ -769,6 +815,11 @@ func (o *options) initPools() {
 	o.gaugeElemPool.Init(func() *GaugeElem {
 		return MustNewGaugeElem(nil, policy.EmptyStoragePolicy, aggregation.DefaultTypes, applied.DefaultPipeline, 0, WithPrefixWithSuffix, o)
 	})
+
+	o.setElemPool = NewSetElemPool(nil)
+	o.setElemPool.Init(func() *SetElem {
+		return MustNewSetElem(nil, policy.EmptyStoragePolicy, aggregation.DefaultTypes, applied.DefaultPipeline, 0, WithPrefixWithSuffix, o)
+	})
 }
 
 func (o *options) computeAllDerived() {
@@ -779,6 +830,7 @@ func (o *options) computeFullPrefixes() {
 	o.computeFullCounterPrefix()
 	o.computeFullTimerPrefix()
 	o.computeFullGaugePrefix()
+	o.computeFullSetPrefix()
 }
 
 func (o *options) computeFullCounterPrefix() {
@@ -802,6 +854,13 @@ func (o *options) computeFullGaugePrefix() {
 	o.fullGaugePrefix = fullGaugePrefix
 }
 
+func (o *options) computeFullSetPrefix() {
+	fullSetPrefix := make([]byte, len(o.metricPrefix)+len(o.setPrefix))
+	n := copy(fullSetPrefix, o.metricPrefix)
+	copy(fullSetPrefix[n:], o.setPrefix)
+	o.fullSetPrefix = fullSetPrefix
+}
+
 func defaultMaxAllowedForwardingDelayFn(
 	resolution time.Duration,
 	numForwardedTimes int,
@@ -153,6 +153,13 @@ func (c *client) writeUntimedMetricWithMetadatas(
 				Gauge:           mu.Gauge(),
 				StagedMetadatas: sm,
 			}}
+	case metric.SetType:
+		msg = encoding.UnaggregatedMessageUnion{
+			Type: encoding.SetWithMetadatasType,
+			SetWithMetadatas: unaggregated.SetWithMetadatas{
+				Set:             mu.Set(),
+				StagedMetadatas: sm,
+			}}
 	default:
 		return fmt.Errorf("unrecognized metric type %v", mu.Type)
 	}
@@ -253,6 +253,12 @@ func generateTestUntimedMetric(
 			ID:       metricid.RawID(id),
 			GaugeVal: valueGenOpts.gaugeValueGenFn(intervalIdx, idIdx),
 		}
+	case metric.SetType:
+		mu.untimed = unaggregated.MetricUnion{
+			Type:   metricType,
+			ID:     metricid.RawID(id),
+			SetVal: valueGenOpts.setValueGenFn(intervalIdx, idIdx),
+		}
 	default:
 		return metricUnion{}, fmt.Errorf("unrecognized untimed metric type: %v", metricType)
 	}
@@ -388,6 +394,12 @@ func computeExpectedAggregationBuckets(
 						}
 						aggregationOpts.ResetSetData(aggTypes)
 						values = aggregation.NewGauge(aggregationOpts)
+					case metric.SetType:
+						if aggTypes.IsDefault() {
+							aggTypes = aggTypeOpts.DefaultSetAggregationTypes()
+						}
+						aggregationOpts.ResetSetData(aggTypes)
+						values = aggregation.NewDistinct(aggregationOpts)
 					default:
 						return nil, fmt.Errorf("unrecognized metric type %v", mu.Type())
 					}
@@ -433,6 +445,10 @@ func addUntimedMetricToAggregation(
 		v := values.(aggregation.Gauge)
 		v.Update(mu.GaugeVal)
 		return v, nil
+	case metric.SetType:
+		v := values.(aggregation.Distinct)
+		v.Update(mu.SetVal)
+		return v, nil
 	default:
 		return nil, fmt.Errorf("unrecognized untimed metric type %v", mu.Type)
 	}
@@ -600,6 +616,14 @@ func computeExpectedAggregatedMetrics(
 			}
 			fn(opts.FullGaugePrefix(), id, aggTypeOpts.TypeStringForGauge(aggType), timeNanos, metricAgg.ValueOf(aggType), sp)
 		}
+	case aggregation.Distinct:
+		if aggTypes.IsDefault() {
+			aggTypes = aggTypeOpts.DefaultSetAggregationTypes()
+		}
+
+		for _, aggType := range aggTypes {
+			fn(opts.FullSetPrefix(), id, aggTypeOpts.TypeStringForSet(aggType), timeNanos, metricAgg.ValueOf(aggType), sp)
+		}
 	default:
 		return nil, fmt.Errorf("unrecognized aggregation type %T", metricAgg)
 	}
@@ -900,6 +924,7 @@ type testDataset []testData
 type counterValueGenFn func(intervalIdx, idIdx int) int64
 type timerValueGenFn func(intervalIdx, idIdx int) []float64
 type gaugeValueGenFn func(intervalIdx, idIdx int) float64
+type setValueGenFn func(intervalIdx, idIdx int) []byte
 
 func defaultCounterValueGenFn(intervalIdx, _ int) int64 {
 	testCounterVal := int64(123)
@@ -920,16 +945,22 @@ func defaultGaugeValueGenFn(intervalIdx, _ int) float64 {
 	return testGaugeVal + float64(intervalIdx)
 }
 
+func defaultSetValueGenFn(intervalIdx, _ int) []byte {
+	return []byte(fmt.Sprintf("member%d", intervalIdx%3))
+}
+
 type untimedValueGenOpts struct {
 	counterValueGenFn counterValueGenFn
 	timerValueGenFn   timerValueGenFn
 	gaugeValueGenFn   gaugeValueGenFn
+	setValueGenFn     setValueGenFn
 }
 
 var defaultUntimedValueGenOpts = untimedValueGenOpts{
 	counterValueGenFn: defaultCounterValueGenFn,
 	timerValueGenFn:   defaultTimerValueGenFn,
 	gaugeValueGenFn:   defaultGaugeValueGenFn,
+	setValueGenFn:     defaultSetValueGenFn,
 }
 
 type timedValueGenFn func(intervalIdx, idIdx int) float64
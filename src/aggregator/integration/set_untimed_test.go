@@ -0,0 +1,185 @@
+//go:build integration
+// +build integration
+
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package integration
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/placement"
+	maggregation "github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/metadata"
+	"github.com/m3db/m3/src/metrics/metric"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3x/clock"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetUntimedMetrics(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	serverOpts := newTestServerOptions()
+
+	// Clock setup.
+	var lock sync.RWMutex
+	now := time.Now().Truncate(time.Hour)
+	getNowFn := func() time.Time {
+		lock.RLock()
+		t := now
+		lock.RUnlock()
+		return t
+	}
+	setNowFn := func(t time.Time) {
+		lock.Lock()
+		now = t
+		lock.Unlock()
+	}
+	clockOpts := clock.NewOptions().SetNowFn(getNowFn)
+	serverOpts = serverOpts.SetClockOptions(clockOpts)
+
+	// Placement setup.
+	numShards := 1024
+	cfg := placementInstanceConfig{
+		instanceID:          serverOpts.InstanceID(),
+		shardSetID:          serverOpts.ShardSetID(),
+		shardStartInclusive: 0,
+		shardEndExclusive:   uint32(numShards),
+	}
+	instance := cfg.newPlacementInstance()
+	placement := newPlacement(numShards, []placement.Instance{instance})
+	placementKey := serverOpts.PlacementKVKey()
+	placementStore := serverOpts.KVStore()
+	require.NoError(t, setPlacement(placementKey, placementStore, placement))
+
+	// Create server.
+	testServer := newTestServerSetup(t, serverOpts)
+	defer testServer.close()
+
+	// Start the server.
+	log := testServer.aggregatorOpts.InstrumentOptions().Logger()
+	log.Info("test one client sending set metrics")
+	require.NoError(t, testServer.startServer())
+	log.Info("server is now up")
+	require.NoError(t, testServer.waitUntilLeader())
+	log.Info("server is now the leader")
+
+	var (
+		idPrefix = "foo"
+		numIDs   = 10
+		start    = getNowFn()
+		stop     = start.Add(4 * time.Second)
+		interval = 500 * time.Millisecond
+	)
+	client := testServer.newClient()
+	require.NoError(t, client.connect())
+	defer client.close()
+
+	// Each set receives several members per resolution window, some of which
+	// are repeated, so the distinct count differs from the number of values.
+	stagedMetadatas := metadata.StagedMetadatas{
+		{
+			CutoverNanos: 0,
+			Tombstoned:   false,
+			Metadata: metadata.Metadata{
+				Pipelines: []metadata.PipelineMetadata{
+					{
+						AggregationID: maggregation.DefaultID,
+						StoragePolicies: []policy.StoragePolicy{
+							policy.NewStoragePolicy(2*time.Second, xtime.Second, time.Hour),
+						},
+					},
+					{
+						AggregationID: maggregation.MustCompressTypes(maggregation.Count),
+						StoragePolicies: []policy.StoragePolicy{
+							policy.NewStoragePolicy(time.Second, xtime.Second, 6*time.Hour),
+						},
+					},
+				},
+			},
+		},
+	}
+	ids := generateTestIDs(idPrefix, numIDs)
+	dataset := mustGenerateTestDataset(t, datasetGenOpts{
+		start:        start,
+		stop:         stop,
+		interval:     interval,
+		ids:          ids,
+		category:     untimedMetric,
+		typeFn:       func(time.Time, int) metric.Type { return metric.SetType },
+		valueGenOpts: defaultValueGenOpts,
+		metadataFn: func(int) metadataUnion {
+			return metadataUnion{
+				mType:           stagedMetadatasType,
+				stagedMetadatas: stagedMetadatas,
+			}
+		},
+	})
+	for _, data := range dataset {
+		setNowFn(data.timestamp)
+		for _, mm := range data.metricWithMetadatas {
+			require.NoError(t, client.writeUntimedMetricWithMetadatas(mm.metric.untimed, mm.metadata.stagedMetadatas))
+		}
+		require.NoError(t, client.flush())
+
+		// Give server some time to process the incoming packets.
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// Move time forward and wait for ticking to happen. The sleep time
+	// must be the longer than the lowest resolution across all policies.
+	finalTime := stop.Add(time.Second)
+	setNowFn(finalTime)
+	time.Sleep(4 * time.Second)
+
+	// Stop the server.
+	require.NoError(t, testServer.stopServer())
+	log.Info("server is now down")
+
+	// Validate results.
+	expected := mustComputeExpectedResults(t, finalTime, dataset, testServer.aggregatorOpts)
+	actual := testServer.sortedResults()
+	require.Equal(t, expected, actual)
+
+	// Each 2s window holds four values covering members 0, 1 and 2, so the
+	// default distinct aggregation reports three members per window while
+	// the count aggregation over 1s windows reports every value received.
+	var numDistinct, numCount int
+	for _, m := range actual {
+		switch m.StoragePolicy.Resolution().Window {
+		case 2 * time.Second:
+			require.Equal(t, 3.0, m.Value)
+			numDistinct++
+		case time.Second:
+			require.Equal(t, 2.0, m.Value)
+			numCount++
+		}
+	}
+	require.True(t, numDistinct > 0)
+	require.True(t, numCount > 0)
+}
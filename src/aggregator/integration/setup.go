@@ -198,6 +198,12 @@ func newTestServerSetup(t *testing.T, opts testServerOptions) *testServerSetup {
 		return aggregator.MustNewGaugeElem(nil, policy.EmptyStoragePolicy, aggregation.DefaultTypes, applied.DefaultPipeline, 0, aggregator.NoPrefixNoSuffix, aggregatorOpts)
 	})
 
+	setElemPool := aggregator.NewSetElemPool(nil)
+	aggregatorOpts = aggregatorOpts.SetSetElemPool(setElemPool)
+	setElemPool.Init(func() *aggregator.SetElem {
+		return aggregator.MustNewSetElem(nil, policy.EmptyStoragePolicy, aggregation.DefaultTypes, applied.DefaultPipeline, 0, aggregator.NoPrefixNoSuffix, aggregatorOpts)
+	})
+
 	return &testServerSetup{
 		opts:             opts,
 		rawTCPAddr:       opts.RawTCPAddr(),
@@ -68,6 +68,11 @@ var (
 		ID:       []byte("testGauge"),
 		GaugeVal: 456.780,
 	}
+	testSet = unaggregated.MetricUnion{
+		Type:   metric.SetType,
+		ID:     []byte("testSet"),
+		SetVal: []byte("foo"),
+	}
 	testTimed = aggregated.Metric{
 		Type:      metric.CounterType,
 		ID:        []byte("testForwarded"),
@@ -159,6 +164,10 @@ var (
 		Gauge:           testGauge.Gauge(),
 		StagedMetadatas: testDefaultMetadatas,
 	}
+	testSetWithMetadatas = unaggregated.SetWithMetadatas{
+		Set:             testSet.Set(),
+		StagedMetadatas: testCustomMetadatas,
+	}
 	testTimedMetricWithMetadata = aggregated.TimedMetricWithMetadata{
 		Metric:        testTimed,
 		TimedMetadata: testTimedMetadata,
@@ -225,7 +234,8 @@ func testRawTCPServerHandleUnaggregated(
 		if protocol == protobufEncoding {
 			expectedResult.TimedMetricWithMetadata = append(expectedResult.TimedMetricWithMetadata, testTimedMetricWithMetadata)
 			expectedResult.ForwardedMetricsWithMetadata = append(expectedResult.ForwardedMetricsWithMetadata, testForwardedMetricWithMetadata)
-			expectedTotalMetrics += 5
+			expectedResult.SetsWithMetadatas = append(expectedResult.SetsWithMetadatas, testSetWithMetadatas)
+			expectedTotalMetrics += 6
 		} else {
 			expectedTotalMetrics += 3
 		}
@@ -266,6 +276,10 @@ func testRawTCPServerHandleUnaggregated(
 					Type: encoding.ForwardedMetricWithMetadataType,
 					ForwardedMetricWithMetadata: testForwardedMetricWithMetadata,
 				}))
+				require.NoError(t, encoder.EncodeMessage(encoding.UnaggregatedMessageUnion{
+					Type:             encoding.SetWithMetadatasType,
+					SetWithMetadatas: testSetWithMetadatas,
+				}))
 				buf := encoder.Relinquish()
 				stream = buf.Bytes()
 			}
@@ -75,6 +75,9 @@ type AggregatorConfiguration struct {
 	// Gauge metric prefix.
 	GaugePrefix *string `yaml:"gaugePrefix"`
 
+	// Set metric prefix.
+	SetPrefix *string `yaml:"setPrefix"`
+
 	// Stream configuration for computing quantiles.
 	Stream streamConfiguration `yaml:"stream"`
 
@@ -147,6 +150,9 @@ type AggregatorConfiguration struct {
 	// Pool of gauge elements.
 	GaugeElemPool pool.ObjectPoolConfiguration `yaml:"gaugeElemPool"`
 
+	// Pool of set elements.
+	SetElemPool pool.ObjectPoolConfiguration `yaml:"setElemPool"`
+
 	// Pool of entries.
 	EntryPool pool.ObjectPoolConfiguration `yaml:"entryPool"`
 }
@@ -174,6 +180,7 @@ func (c *AggregatorConfiguration) NewAggregatorOptions(
 	opts = setMetricPrefix(opts, c.CounterPrefix, opts.SetCounterPrefix)
 	opts = setMetricPrefix(opts, c.TimerPrefix, opts.SetTimerPrefix)
 	opts = setMetricPrefix(opts, c.GaugePrefix, opts.SetGaugePrefix)
+	opts = setMetricPrefix(opts, c.SetPrefix, opts.SetSetPrefix)
 
 	// Set stream options.
 	scope := instrumentOpts.MetricsScope()
@@ -348,6 +355,15 @@ func (c *AggregatorConfiguration) NewAggregatorOptions(
 		return aggregator.MustNewGaugeElem(nil, policy.EmptyStoragePolicy, aggregation.DefaultTypes, applied.DefaultPipeline, 0, aggregator.NoPrefixNoSuffix, opts)
 	})
 
+	// Set set elem pool.
+	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("set-elem-pool"))
+	setElemPoolOpts := c.SetElemPool.NewObjectPoolOptions(iOpts)
+	setElemPool := aggregator.NewSetElemPool(setElemPoolOpts)
+	opts = opts.SetSetElemPool(setElemPool)
+	setElemPool.Init(func() *aggregator.SetElem {
+		return aggregator.MustNewSetElem(nil, policy.EmptyStoragePolicy, aggregation.DefaultTypes, applied.DefaultPipeline, 0, aggregator.NoPrefixNoSuffix, opts)
+	})
+
 	// Set entry pool.
 	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("entry-pool"))
 	entryPoolOpts := c.EntryPool.NewObjectPoolOptions(iOpts)
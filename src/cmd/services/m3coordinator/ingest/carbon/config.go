@@ -0,0 +1,247 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package carbon
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"sync"
+
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3x/instrument"
+	xserver "github.com/m3db/m3x/server"
+	xsync "github.com/m3db/m3x/sync"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWorkerPoolSize = 1024
+)
+
+var (
+	errNoListenAddress = errors.New("carbon ingester requires at least one listen address")
+)
+
+// Configuration configures the carbon ingester.
+type Configuration struct {
+	// ListenAddress is the carbon plaintext TCP listen address.
+	ListenAddress string `yaml:"listenAddress"`
+
+	// PickleListenAddress is the carbon pickle TCP listen address.
+	PickleListenAddress string `yaml:"pickleListenAddress"`
+
+	// UDPListenAddress is the carbon plaintext UDP listen address.
+	UDPListenAddress string `yaml:"udpListenAddress"`
+
+	// WorkerPoolSize is the number of concurrent writes to storage.
+	WorkerPoolSize int `yaml:"workerPoolSize"`
+
+	// Rules are the ingestion rules, evaluated in order.
+	Rules []RuleConfiguration `yaml:"rules"`
+}
+
+// RuleConfiguration configures a single carbon ingestion rule.
+type RuleConfiguration struct {
+	// Pattern is the regular expression matched against the metric path.
+	Pattern string `yaml:"pattern" validate:"nonzero"`
+
+	// Rewrite rewrites the metric path using regular expression expansion
+	// of the pattern, e.g. `servers.$1.cpu`.
+	Rewrite *string `yaml:"rewrite"`
+
+	// Drop drops matching metrics.
+	Drop bool `yaml:"drop"`
+
+	// Tags are additional tags to add to matching metrics.
+	Tags map[string]string `yaml:"tags"`
+
+	// Policies are the storage policies of the aggregated namespaces to write
+	// matching metrics to, if empty metrics are written unaggregated.
+	Policies []policy.StoragePolicy `yaml:"policies"`
+
+	// Continue continues evaluating subsequent rules after a match.
+	Continue bool `yaml:"continue"`
+}
+
+// NewRule compiles the rule configuration.
+func (c RuleConfiguration) NewRule() (Rule, error) {
+	pattern, err := regexp.Compile(c.Pattern)
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid carbon rule pattern %s: %v", c.Pattern, err)
+	}
+
+	rule := Rule{
+		Pattern:  pattern,
+		Drop:     c.Drop,
+		Continue: c.Continue,
+	}
+
+	if c.Rewrite != nil {
+		rule.Rewrite = []byte(*c.Rewrite)
+	}
+
+	for name, value := range c.Tags {
+		rule.Tags = append(rule.Tags, models.Tag{
+			Name:  []byte(name),
+			Value: []byte(value),
+		})
+	}
+
+	for _, sp := range c.Policies {
+		rule.Attributes = append(rule.Attributes, storage.Attributes{
+			MetricsType: storage.AggregatedMetricsType,
+			Resolution:  sp.Resolution().Window,
+			Retention:   sp.Retention().Duration(),
+		})
+	}
+
+	return rule, nil
+}
+
+// NewIngester creates a new carbon ingester writing to the appender.
+func (c Configuration) NewIngester(
+	appender storage.Appender,
+	tagOptions models.TagOptions,
+	iOpts instrument.Options,
+) (*Ingester, error) {
+	rules := make([]Rule, 0, len(c.Rules))
+	for _, ruleCfg := range c.Rules {
+		rule, err := ruleCfg.NewRule()
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	workerPoolSize := c.WorkerPoolSize
+	if workerPoolSize <= 0 {
+		workerPoolSize = defaultWorkerPoolSize
+	}
+
+	workers, err := xsync.NewPooledWorkerPool(workerPoolSize,
+		xsync.NewPooledWorkerPoolOptions().SetInstrumentOptions(iOpts))
+	if err != nil {
+		return nil, err
+	}
+
+	workers.Init()
+	return NewIngester(Options{
+		Appender:          appender,
+		TagOptions:        tagOptions,
+		Rules:             rules,
+		Workers:           workers,
+		InstrumentOptions: iOpts,
+	})
+}
+
+// NewServer creates a new carbon server for the configured listeners.
+func (c Configuration) NewServer(
+	appender storage.Appender,
+	tagOptions models.TagOptions,
+	iOpts instrument.Options,
+) (*Server, error) {
+	if c.ListenAddress == "" && c.PickleListenAddress == "" && c.UDPListenAddress == "" {
+		return nil, errNoListenAddress
+	}
+
+	ingester, err := c.NewIngester(appender, tagOptions, iOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		cfg:        c,
+		ingester:   ingester,
+		serverOpts: xserver.NewOptions().SetInstrumentOptions(iOpts),
+	}, nil
+}
+
+// Server serves the carbon plaintext, pickle and UDP listeners.
+type Server struct {
+	cfg        Configuration
+	ingester   *Ingester
+	serverOpts xserver.Options
+
+	servers []xserver.Server
+	udp     net.PacketConn
+	wg      sync.WaitGroup
+}
+
+// ListenAndServe starts all the configured listeners.
+func (s *Server) ListenAndServe() error {
+	if addr := s.cfg.ListenAddress; addr != "" {
+		server := xserver.NewServer(addr, s.ingester.PlaintextHandler(), s.serverOpts)
+		if err := server.ListenAndServe(); err != nil {
+			s.Close()
+			return err
+		}
+		s.servers = append(s.servers, server)
+	}
+
+	if addr := s.cfg.PickleListenAddress; addr != "" {
+		server := xserver.NewServer(addr, s.ingester.PickleHandler(), s.serverOpts)
+		if err := server.ListenAndServe(); err != nil {
+			s.Close()
+			return err
+		}
+		s.servers = append(s.servers, server)
+	}
+
+	if addr := s.cfg.UDPListenAddress; addr != "" {
+		conn, err := net.ListenPacket("udp", addr)
+		if err != nil {
+			s.Close()
+			return err
+		}
+
+		s.udp = conn
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			if err := s.ingester.ServeUDP(conn); err != nil {
+				s.ingester.logger.Error("carbon udp listener error", zap.Error(err))
+			}
+		}()
+	}
+
+	return nil
+}
+
+// Close closes all the listeners.
+func (s *Server) Close() {
+	for _, server := range s.servers {
+		server.Close()
+	}
+	s.servers = nil
+
+	if s.udp != nil {
+		s.udp.Close()
+		s.udp = nil
+	}
+
+	s.wg.Wait()
+}
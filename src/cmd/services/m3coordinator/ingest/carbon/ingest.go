@@ -0,0 +1,429 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package carbon
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"sync"
+
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/instrument"
+	xserver "github.com/m3db/m3x/server"
+	xsync "github.com/m3db/m3x/sync"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxPicklePayloadSize bounds a single pickle payload, carbon itself
+	// defaults to a 1MB limit.
+	maxPicklePayloadSize = 1 << 20
+	// maxUDPPacketSize is the largest datagram we'll accept.
+	maxUDPPacketSize = 1 << 16
+	// defaultLineBufferSize is the max carbon plaintext line length.
+	defaultLineBufferSize = 1 << 16
+)
+
+var (
+	errNoAppender            = errors.New("carbon ingester requires an appender")
+	errNoWorkers             = errors.New("carbon ingester requires a worker pool")
+	errPicklePayloadTooLarge = errors.New("carbon pickle payload exceeds max size")
+)
+
+// Rule is a compiled carbon ingestion rule. Rules are evaluated in order
+// and the first matching rule is applied unless it is marked as Continue,
+// in which case evaluation carries on with the (possibly rewritten) path.
+type Rule struct {
+	// Pattern is matched against the metric path.
+	Pattern *regexp.Regexp
+	// Rewrite, if non-nil, is the regexp expansion template used to rewrite
+	// the metric path, e.g. `$1.count`.
+	Rewrite []byte
+	// Drop drops any matching metric.
+	Drop bool
+	// Tags are additional tags added to matching metrics.
+	Tags []models.Tag
+	// Attributes selects the namespaces matching metrics are written to,
+	// writes go to the unaggregated namespace if empty.
+	Attributes []storage.Attributes
+	// Continue continues evaluating rules after this rule matched.
+	Continue bool
+}
+
+// Options configures the carbon ingester.
+type Options struct {
+	Appender          storage.Appender
+	TagOptions        models.TagOptions
+	Rules             []Rule
+	Workers           xsync.PooledWorkerPool
+	InstrumentOptions instrument.Options
+}
+
+// Validate validates the options.
+func (o Options) Validate() error {
+	if o.Appender == nil {
+		return errNoAppender
+	}
+
+	if o.Workers == nil {
+		return errNoWorkers
+	}
+
+	return nil
+}
+
+type ingesterMetrics struct {
+	success   tally.Counter
+	malformed tally.Counter
+	dropped   tally.Counter
+	errors    tally.Counter
+}
+
+func newIngesterMetrics(scope tally.Scope) ingesterMetrics {
+	return ingesterMetrics{
+		success:   scope.Counter("success"),
+		malformed: scope.Counter("malformed"),
+		dropped:   scope.Counter("dropped"),
+		errors:    scope.Counter("write-errors"),
+	}
+}
+
+var (
+	unaggregatedAttributes = []storage.Attributes{
+		{MetricsType: storage.UnaggregatedMetricsType},
+	}
+)
+
+// Ingester ingests carbon plaintext and pickle payloads, converting graphite
+// paths into positional tags and writing them to storage.
+type Ingester struct {
+	appender   storage.Appender
+	tagOptions models.TagOptions
+	rules      []Rule
+	workers    xsync.PooledWorkerPool
+	logger     *zap.Logger
+	metrics    ingesterMetrics
+}
+
+// NewIngester creates a new carbon ingester.
+func NewIngester(opts Options) (*Ingester, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	iOpts := opts.InstrumentOptions
+	if iOpts == nil {
+		iOpts = instrument.NewOptions()
+	}
+
+	tagOptions := opts.TagOptions
+	if tagOptions == nil {
+		tagOptions = models.NewTagOptions()
+	}
+
+	return &Ingester{
+		appender:   opts.Appender,
+		tagOptions: tagOptions,
+		rules:      opts.Rules,
+		workers:    opts.Workers,
+		logger:     iOpts.ZapLogger(),
+		metrics:    newIngesterMetrics(iOpts.MetricsScope()),
+	}, nil
+}
+
+// PlaintextHandler returns a TCP handler for the carbon plaintext protocol.
+func (i *Ingester) PlaintextHandler() xserver.Handler {
+	return &plaintextHandler{ingester: i}
+}
+
+// PickleHandler returns a TCP handler for the carbon pickle protocol.
+func (i *Ingester) PickleHandler() xserver.Handler {
+	return &pickleHandler{ingester: i}
+}
+
+// ServeUDP reads carbon plaintext datagrams from the packet connection until
+// it is closed.
+func (i *Ingester) ServeUDP(conn net.PacketConn) error {
+	var (
+		ctx = context.Background()
+		buf = make([]byte, maxUDPPacketSize)
+		wg  sync.WaitGroup
+	)
+	defer wg.Wait()
+
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if isClosedConnError(err) {
+				return nil
+			}
+			return err
+		}
+
+		for _, line := range bytes.Split(buf[:n], []byte("\n")) {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+
+			i.ingestLine(ctx, line, &wg)
+		}
+	}
+}
+
+// Write applies the ingestion rules to the metric and writes the resulting
+// series to storage synchronously.
+func (i *Ingester) Write(ctx context.Context, metric Metric) error {
+	multiErr := xerrors.NewMultiError()
+	for _, q := range i.writeQueries(metric) {
+		if err := i.appender.Write(ctx, q); err != nil {
+			i.metrics.errors.Inc(1)
+			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		i.metrics.success.Inc(1)
+	}
+
+	return multiErr.FinalError()
+}
+
+func (i *Ingester) ingestLine(ctx context.Context, line []byte, wg *sync.WaitGroup) {
+	metric, err := ParseLine(line)
+	if err != nil {
+		i.metrics.malformed.Inc(1)
+		i.logger.Debug("malformed carbon line",
+			zap.ByteString("line", line), zap.Error(err))
+		return
+	}
+
+	// NB: the parsed name aliases the read buffer, copy it before handing
+	// off to the worker pool.
+	metric.Name = append([]byte(nil), metric.Name...)
+	i.ingestAsync(ctx, metric, wg)
+}
+
+func (i *Ingester) ingestAsync(ctx context.Context, metric Metric, wg *sync.WaitGroup) {
+	wg.Add(1)
+	i.workers.Go(func() {
+		if err := i.Write(ctx, metric); err != nil {
+			i.logger.Error("carbon write error",
+				zap.ByteString("name", metric.Name), zap.Error(err))
+		}
+		wg.Done()
+	})
+}
+
+// writeQueries returns the write queries for a metric after the rules have
+// been applied, returning no queries if the metric is dropped.
+func (i *Ingester) writeQueries(metric Metric) []*storage.WriteQuery {
+	var (
+		name    = metric.Name
+		matched bool
+		queries []*storage.WriteQuery
+	)
+	for _, rule := range i.rules {
+		if !rule.Pattern.Match(name) {
+			continue
+		}
+
+		matched = true
+		if rule.Drop {
+			i.metrics.dropped.Inc(1)
+			return nil
+		}
+
+		if rule.Rewrite != nil {
+			name = rule.Pattern.ReplaceAll(name, rule.Rewrite)
+		}
+
+		attributes := rule.Attributes
+		if len(attributes) == 0 {
+			attributes = unaggregatedAttributes
+		}
+
+		for _, attrs := range attributes {
+			queries = append(queries, i.newWriteQuery(name, rule.Tags, metric, attrs))
+		}
+
+		if !rule.Continue {
+			break
+		}
+	}
+
+	if !matched {
+		queries = append(queries,
+			i.newWriteQuery(name, nil, metric, unaggregatedAttributes[0]))
+	}
+
+	return queries
+}
+
+func (i *Ingester) newWriteQuery(
+	name []byte,
+	extraTags []models.Tag,
+	metric Metric,
+	attrs storage.Attributes,
+) *storage.WriteQuery {
+	nodes := graphite.Nodes(name)
+	tags := models.NewTags(len(nodes)+len(extraTags), i.tagOptions)
+	for idx, node := range nodes {
+		tags.Tags = append(tags.Tags, models.Tag{
+			Name:  graphite.TagName(idx),
+			Value: node,
+		})
+	}
+	tags = tags.AddTags(extraTags)
+
+	unit := xtime.Second
+	if metric.Timestamp.Nanosecond() != 0 {
+		unit = xtime.Nanosecond
+	}
+
+	return &storage.WriteQuery{
+		Tags: tags,
+		Datapoints: ts.Datapoints{
+			{
+				Timestamp: metric.Timestamp,
+				Value:     metric.Value,
+			},
+		},
+		Unit:       unit,
+		Attributes: attrs,
+	}
+}
+
+type plaintextHandler struct {
+	ingester *Ingester
+}
+
+func (h *plaintextHandler) Handle(conn net.Conn) {
+	var (
+		ctx     = context.Background()
+		scanner = bufio.NewScanner(conn)
+		wg      sync.WaitGroup
+	)
+	defer wg.Wait()
+
+	scanner.Buffer(make([]byte, 4096), defaultLineBufferSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		h.ingester.ingestLine(ctx, line, &wg)
+	}
+
+	if err := scanner.Err(); err != nil && !isClosedConnError(err) {
+		h.ingester.logger.Error("carbon plaintext read error",
+			zap.String("remoteAddress", remoteAddress(conn)), zap.Error(err))
+	}
+}
+
+func (h *plaintextHandler) Close() {}
+
+type pickleHandler struct {
+	ingester *Ingester
+}
+
+func (h *pickleHandler) Handle(conn net.Conn) {
+	var (
+		ctx    = context.Background()
+		reader = bufio.NewReader(conn)
+		header = make([]byte, 4)
+		wg     sync.WaitGroup
+	)
+	defer wg.Wait()
+
+	for {
+		payload, err := readPicklePayload(reader, header)
+		if err != nil {
+			if err != io.EOF && !isClosedConnError(err) {
+				h.ingester.logger.Error("carbon pickle read error",
+					zap.String("remoteAddress", remoteAddress(conn)), zap.Error(err))
+			}
+			return
+		}
+
+		metrics, err := ParsePickle(payload)
+		if err != nil {
+			h.ingester.metrics.malformed.Inc(1)
+			h.ingester.logger.Debug("malformed carbon pickle payload", zap.Error(err))
+			continue
+		}
+
+		for _, metric := range metrics {
+			h.ingester.ingestAsync(ctx, metric, &wg)
+		}
+	}
+}
+
+func (h *pickleHandler) Close() {}
+
+// readPicklePayload reads a single length prefixed pickle payload.
+func readPicklePayload(r io.Reader, header []byte) ([]byte, error) {
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header)
+	if size > maxPicklePayloadSize {
+		return nil, errPicklePayloadTooLarge
+	}
+
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, fmt.Errorf("truncated carbon pickle payload: %v", err)
+	}
+
+	return payload, nil
+}
+
+func remoteAddress(conn net.Conn) string {
+	if addr := conn.RemoteAddr(); addr != nil {
+		return addr.String()
+	}
+
+	return "<unknown>"
+}
+
+func isClosedConnError(err error) bool {
+	if opErr, ok := err.(*net.OpError); ok {
+		err = opErr.Err
+	}
+
+	return err == io.ErrClosedPipe ||
+		bytes.Contains([]byte(err.Error()), []byte("use of closed network connection"))
+}
@@ -0,0 +1,316 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package carbon
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"regexp"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3x/instrument"
+	xsync "github.com/m3db/m3x/sync"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memStorage is a minimal in-memory storage that serves FetchTags from
+// the series written to it.
+type memStorage struct {
+	sync.Mutex
+	writes []*storage.WriteQuery
+}
+
+func (s *memStorage) Fetch(
+	context.Context,
+	*storage.FetchQuery,
+	*storage.FetchOptions,
+) (*storage.FetchResult, error) {
+	return nil, nil
+}
+
+func (s *memStorage) FetchBlocks(
+	context.Context,
+	*storage.FetchQuery,
+	*storage.FetchOptions,
+) (block.Result, error) {
+	return block.Result{}, nil
+}
+
+func (s *memStorage) FetchTags(
+	_ context.Context,
+	query *storage.FetchQuery,
+	_ *storage.FetchOptions,
+) (*storage.SearchResults, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	var (
+		seen    = make(map[string]struct{})
+		metrics models.Metrics
+	)
+	for _, w := range s.writes {
+		if !matches(query.TagMatchers, w.Tags) {
+			continue
+		}
+
+		id := w.Tags.ID()
+		if _, ok := seen[id]; ok {
+			continue
+		}
+
+		seen[id] = struct{}{}
+		metrics = append(metrics, models.Metric{ID: id, Tags: w.Tags})
+	}
+
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].ID < metrics[j].ID
+	})
+
+	return &storage.SearchResults{Metrics: metrics}, nil
+}
+
+func matches(matchers models.Matchers, tags models.Tags) bool {
+	for _, m := range matchers {
+		value, _ := tags.Get(m.Name)
+		if !m.Matches(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *memStorage) Write(_ context.Context, query *storage.WriteQuery) error {
+	s.Lock()
+	s.writes = append(s.writes, query)
+	s.Unlock()
+	return nil
+}
+
+func (s *memStorage) numWrites() int {
+	s.Lock()
+	defer s.Unlock()
+	return len(s.writes)
+}
+
+func (s *memStorage) Type() storage.Type { return storage.TypeLocalDC }
+
+func (s *memStorage) Close() error { return nil }
+
+func newTestIngester(t *testing.T, rules []Rule) (*Ingester, *memStorage) {
+	workers, err := xsync.NewPooledWorkerPool(4, xsync.NewPooledWorkerPoolOptions())
+	require.NoError(t, err)
+	workers.Init()
+
+	store := &memStorage{}
+	ingester, err := NewIngester(Options{
+		Appender:          store,
+		Rules:             rules,
+		Workers:           workers,
+		InstrumentOptions: instrument.NewOptions(),
+	})
+	require.NoError(t, err)
+	return ingester, store
+}
+
+func fetchTagIDs(t *testing.T, store *memStorage, matchers ...models.Matcher) []string {
+	result, err := store.FetchTags(context.TODO(), &storage.FetchQuery{
+		TagMatchers: matchers,
+		Start:       time.Unix(0, 0),
+		End:         time.Now(),
+	}, &storage.FetchOptions{})
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(result.Metrics))
+	for _, m := range result.Metrics {
+		ids = append(ids, m.ID)
+	}
+
+	return ids
+}
+
+func mustMatcher(t *testing.T, matchType models.MatchType, name, value string) models.Matcher {
+	m, err := models.NewMatcher(matchType, []byte(name), []byte(value))
+	require.NoError(t, err)
+	return m
+}
+
+func TestPlaintextIngestAndFetchTags(t *testing.T) {
+	ingester, store := newTestIngester(t, nil)
+
+	client, server := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		ingester.PlaintextHandler().Handle(server)
+		close(done)
+	}()
+
+	_, err := client.Write([]byte("foo.bar.baz 1 1500000000\n" +
+		"malformed line\n" +
+		"\n" +
+		"foo.bar.qux 2 1500000000\n" +
+		"foo.other 3 1500000000\n"))
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+	<-done
+
+	require.Equal(t, 3, store.numWrites())
+	ids := fetchTagIDs(t, store,
+		mustMatcher(t, models.MatchEqual, "__g0__", "foo"),
+		mustMatcher(t, models.MatchEqual, "__g1__", "bar"))
+	assert.Equal(t, []string{
+		"__g0__=foo,__g1__=bar,__g2__=baz,",
+		"__g0__=foo,__g1__=bar,__g2__=qux,",
+	}, ids)
+
+	for _, w := range store.writes {
+		assert.Equal(t, storage.UnaggregatedMetricsType, w.Attributes.MetricsType)
+		require.Equal(t, 1, len(w.Datapoints))
+		assert.True(t, time.Unix(1500000000, 0).Equal(w.Datapoints[0].Timestamp))
+	}
+}
+
+func TestPickleIngestAndFetchTags(t *testing.T) {
+	ingester, store := newTestIngester(t, nil)
+
+	client, server := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		ingester.PickleHandler().Handle(server)
+		close(done)
+	}()
+
+	for _, payload := range [][]byte{testPickleProtocol2, testPickleProtocol4} {
+		header := make([]byte, 4)
+		binary.BigEndian.PutUint32(header, uint32(len(payload)))
+		_, err := client.Write(append(header, payload...))
+		require.NoError(t, err)
+	}
+	require.NoError(t, client.Close())
+	<-done
+
+	require.Equal(t, 4, store.numWrites())
+	ids := fetchTagIDs(t, store,
+		mustMatcher(t, models.MatchRegexp, "__g1__", "ba.*"))
+	assert.Equal(t, []string{"__g0__=foo,__g1__=bar,__g2__=baz,"}, ids)
+}
+
+func TestUDPIngestAndFetchTags(t *testing.T) {
+	ingester, store := newTestIngester(t, nil)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		done <- ingester.ServeUDP(conn)
+	}()
+
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	require.NoError(t, err)
+	_, err = client.Write([]byte("foo.udp 1 1500000000\nfoo.udp2 2 1500000000"))
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+
+	for store.numWrites() != 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	require.NoError(t, conn.Close())
+	require.NoError(t, <-done)
+
+	ids := fetchTagIDs(t, store,
+		mustMatcher(t, models.MatchRegexp, "__g1__", "udp.*"))
+	assert.Equal(t, []string{"__g0__=foo,__g1__=udp,", "__g0__=foo,__g1__=udp2,"}, ids)
+}
+
+func TestIngestRules(t *testing.T) {
+	aggregated := storage.Attributes{
+		MetricsType: storage.AggregatedMetricsType,
+		Resolution:  time.Minute,
+		Retention:   40 * 24 * time.Hour,
+	}
+	ingester, store := newTestIngester(t, []Rule{
+		{
+			Pattern: regexp.MustCompile(`^debug\.`),
+			Drop:    true,
+		},
+		{
+			Pattern:  regexp.MustCompile(`^servers\.([^.]+)\.cpu$`),
+			Rewrite:  []byte("hosts.$1.cpu.total"),
+			Tags:     []models.Tag{{Name: []byte("source"), Value: []byte("carbon")}},
+			Continue: true,
+		},
+		{
+			Pattern:    regexp.MustCompile(`^hosts\.`),
+			Attributes: []storage.Attributes{aggregated},
+		},
+	})
+
+	for _, line := range []string{
+		"debug.foo 1 1500000000",
+		"servers.host1.cpu 1 1500000000",
+		"hosts.host2.mem 1 1500000000",
+		"other.metric 1 1500000000",
+	} {
+		metric, err := ParseLine([]byte(line))
+		require.NoError(t, err)
+		require.NoError(t, ingester.Write(context.TODO(), metric))
+	}
+
+	require.Equal(t, 4, store.numWrites())
+
+	written := make(map[string]storage.Attributes, len(store.writes))
+	for _, w := range store.writes {
+		written[w.Tags.ID()] = w.Attributes
+	}
+
+	assert.Equal(t, map[string]storage.Attributes{
+		"__g0__=hosts,__g1__=host1,__g2__=cpu,__g3__=total,source=carbon,": unaggregatedAttributes[0],
+		"__g0__=hosts,__g1__=host1,__g2__=cpu,__g3__=total,":               aggregated,
+		"__g0__=hosts,__g1__=host2,__g2__=mem,":                            aggregated,
+		"__g0__=other,__g1__=metric,":                                      unaggregatedAttributes[0],
+	}, written)
+}
+
+func TestTagNamesArePositional(t *testing.T) {
+	ingester, _ := newTestIngester(t, nil)
+	queries := ingester.writeQueries(Metric{
+		Name:      []byte("a.b.c"),
+		Timestamp: time.Unix(1500000000, 0),
+		Value:     1,
+	})
+
+	require.Equal(t, 1, len(queries))
+	for i, tag := range queries[0].Tags.Tags {
+		assert.Equal(t, string(graphite.TagName(i)), string(tag.Name))
+	}
+}
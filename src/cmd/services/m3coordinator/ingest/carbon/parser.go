@@ -0,0 +1,104 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package carbon
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+var (
+	errInvalidLine      = errors.New("invalid carbon line: expected <path> <value> <timestamp>")
+	errInvalidPath      = errors.New("invalid carbon path: must not be empty or contain empty nodes")
+	errInvalidValue     = errors.New("invalid carbon value")
+	errInvalidTimestamp = errors.New("invalid carbon timestamp")
+)
+
+// Metric is a single parsed carbon datapoint.
+type Metric struct {
+	Name      []byte
+	Timestamp time.Time
+	Value     float64
+}
+
+// ParseLine parses a single carbon plaintext line of the form
+// `<path> <value> <timestamp>`. The returned name aliases the input line.
+func ParseLine(line []byte) (Metric, error) {
+	line = bytes.TrimSpace(line)
+	fields := bytes.Fields(line)
+	if len(fields) != 3 {
+		return Metric{}, errInvalidLine
+	}
+
+	name := fields[0]
+	if err := validatePath(name); err != nil {
+		return Metric{}, err
+	}
+
+	value, err := parseValue(fields[1])
+	if err != nil {
+		return Metric{}, err
+	}
+
+	timestamp, err := parseTimestamp(fields[2])
+	if err != nil {
+		return Metric{}, err
+	}
+
+	return Metric{
+		Name:      name,
+		Timestamp: timestamp,
+		Value:     value,
+	}, nil
+}
+
+func validatePath(path []byte) error {
+	if len(path) == 0 || path[0] == '.' || path[len(path)-1] == '.' ||
+		bytes.Contains(path, []byte("..")) {
+		return errInvalidPath
+	}
+
+	return nil
+}
+
+func parseValue(b []byte) (float64, error) {
+	value, err := strconv.ParseFloat(string(b), 64)
+	if err != nil {
+		return 0, errInvalidValue
+	}
+
+	return value, nil
+}
+
+func parseTimestamp(b []byte) (time.Time, error) {
+	// Carbon timestamps are unix seconds, though some clients emit
+	// fractional seconds so parse as a float and truncate to nanos.
+	secs, err := strconv.ParseFloat(string(b), 64)
+	if err != nil || math.IsNaN(secs) || math.IsInf(secs, 0) || secs < 0 {
+		return time.Time{}, errInvalidTimestamp
+	}
+
+	whole, frac := math.Modf(secs)
+	return time.Unix(int64(whole), int64(frac*float64(time.Second))), nil
+}
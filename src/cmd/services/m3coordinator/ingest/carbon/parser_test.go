@@ -0,0 +1,83 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected Metric
+	}{
+		{
+			line: "foo.bar.baz 1.5 1500000000",
+			expected: Metric{
+				Name:      []byte("foo.bar.baz"),
+				Timestamp: time.Unix(1500000000, 0),
+				Value:     1.5,
+			},
+		},
+		{
+			line: "  foo   -42  1500000000.5 \r",
+			expected: Metric{
+				Name:      []byte("foo"),
+				Timestamp: time.Unix(1500000000, int64(500*time.Millisecond)),
+				Value:     -42,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		metric, err := ParseLine([]byte(tt.line))
+		require.NoError(t, err, tt.line)
+		assert.Equal(t, string(tt.expected.Name), string(metric.Name))
+		assert.True(t, tt.expected.Timestamp.Equal(metric.Timestamp))
+		assert.Equal(t, tt.expected.Value, metric.Value)
+	}
+}
+
+func TestParseLineErrors(t *testing.T) {
+	tests := []struct {
+		line string
+		err  error
+	}{
+		{line: "", err: errInvalidLine},
+		{line: "foo 1", err: errInvalidLine},
+		{line: "foo 1 2 3", err: errInvalidLine},
+		{line: "foo..bar 1 1500000000", err: errInvalidPath},
+		{line: ".foo 1 1500000000", err: errInvalidPath},
+		{line: "foo. 1 1500000000", err: errInvalidPath},
+		{line: "foo bar 1500000000", err: errInvalidValue},
+		{line: "foo 1 bar", err: errInvalidTimestamp},
+		{line: "foo 1 -1", err: errInvalidTimestamp},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseLine([]byte(tt.line))
+		assert.Equal(t, tt.err, err, tt.line)
+	}
+}
@@ -0,0 +1,608 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package carbon
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Pickle opcodes used by the python pickle protocols 0 through 4 that can
+// appear in a carbon pickle payload.
+const (
+	opMark            = '('
+	opStop            = '.'
+	opPop             = '0'
+	opPopMark         = '1'
+	opDup             = '2'
+	opFloat           = 'F'
+	opInt             = 'I'
+	opBinInt          = 'J'
+	opBinInt1         = 'K'
+	opLong            = 'L'
+	opBinInt2         = 'M'
+	opNone            = 'N'
+	opString          = 'S'
+	opBinString       = 'T'
+	opShortBinString  = 'U'
+	opUnicode         = 'V'
+	opBinUnicode      = 'X'
+	opAppend          = 'a'
+	opAppends         = 'e'
+	opGet             = 'g'
+	opBinGet          = 'h'
+	opLongBinGet      = 'j'
+	opList            = 'l'
+	opPut             = 'p'
+	opBinPut          = 'q'
+	opLongBinPut      = 'r'
+	opTuple           = 't'
+	opEmptyList       = ']'
+	opEmptyTuple      = ')'
+	opBinFloat        = 'G'
+	opBinBytes        = 'B'
+	opShortBinBytes   = 'C'
+	opProto           = 0x80
+	opTuple1          = 0x85
+	opTuple2          = 0x86
+	opTuple3          = 0x87
+	opNewTrue         = 0x88
+	opNewFalse        = 0x89
+	opLong1           = 0x8a
+	opShortBinUnicode = 0x8c
+	opBinUnicode8     = 0x8d
+	opMemoize         = 0x94
+	opFrame           = 0x95
+)
+
+const (
+	// maxPickleStringLength bounds the length of a single string in a
+	// pickle payload to protect against malicious or corrupt payloads.
+	maxPickleStringLength = 1 << 16
+)
+
+var (
+	errPickleStackEmpty     = errors.New("pickle: stack underflow")
+	errPickleNoMark         = errors.New("pickle: no mark on stack")
+	errPickleMemoMissing    = errors.New("pickle: memo key not found")
+	errPickleStringTooLong  = errors.New("pickle: string exceeds max length")
+	errPickleInvalidAppend  = errors.New("pickle: append to non-list")
+	errPickleInvalidPayload = errors.New("pickle: payload is not a list of (path, (timestamp, value)) tuples")
+)
+
+type pickleMark struct{}
+
+type pickleList struct {
+	items []interface{}
+}
+
+type pickleTuple []interface{}
+
+type unpickler struct {
+	r     *bufio.Reader
+	stack []interface{}
+	memo  map[int64]interface{}
+}
+
+// ParsePickle decodes a single carbon pickle payload, which is a pickled
+// list of `(path, (timestamp, value))` tuples, into metrics.
+func ParsePickle(payload []byte) ([]Metric, error) {
+	u := unpickler{
+		r:    bufio.NewReader(bytes.NewReader(payload)),
+		memo: make(map[int64]interface{}),
+	}
+
+	obj, err := u.load()
+	if err != nil {
+		return nil, err
+	}
+
+	list, ok := obj.(*pickleList)
+	if !ok {
+		return nil, errPickleInvalidPayload
+	}
+
+	metrics := make([]Metric, 0, len(list.items))
+	for _, item := range list.items {
+		metric, err := pickleItemToMetric(item)
+		if err != nil {
+			return nil, err
+		}
+
+		metrics = append(metrics, metric)
+	}
+
+	return metrics, nil
+}
+
+func pickleItemToMetric(item interface{}) (Metric, error) {
+	outer, ok := item.(pickleTuple)
+	if !ok || len(outer) != 2 {
+		return Metric{}, errPickleInvalidPayload
+	}
+
+	name, ok := pickleString(outer[0])
+	if !ok {
+		return Metric{}, errPickleInvalidPayload
+	}
+
+	if err := validatePath(name); err != nil {
+		return Metric{}, err
+	}
+
+	inner, ok := outer[1].(pickleTuple)
+	if !ok || len(inner) != 2 {
+		return Metric{}, errPickleInvalidPayload
+	}
+
+	secs, ok := pickleNumber(inner[0])
+	if !ok || math.IsNaN(secs) || math.IsInf(secs, 0) || secs < 0 {
+		return Metric{}, errInvalidTimestamp
+	}
+
+	value, ok := pickleNumber(inner[1])
+	if !ok {
+		return Metric{}, errInvalidValue
+	}
+
+	whole, frac := math.Modf(secs)
+	return Metric{
+		Name:      name,
+		Timestamp: time.Unix(int64(whole), int64(frac*float64(time.Second))),
+		Value:     value,
+	}, nil
+}
+
+func pickleString(obj interface{}) ([]byte, bool) {
+	switch v := obj.(type) {
+	case string:
+		return []byte(v), true
+	case []byte:
+		return v, true
+	}
+
+	return nil, false
+}
+
+func pickleNumber(obj interface{}) (float64, bool) {
+	switch v := obj.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		// Some carbon clients send values as strings.
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+
+	return 0, false
+}
+
+func (u *unpickler) load() (interface{}, error) {
+	for {
+		op, err := u.r.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("pickle: unexpected end of payload: %v", err)
+		}
+
+		switch op {
+		case opStop:
+			return u.pop()
+		case opProto:
+			if _, err := u.r.ReadByte(); err != nil {
+				return nil, err
+			}
+		case opFrame:
+			if _, err := u.readN(8); err != nil {
+				return nil, err
+			}
+		case opMark:
+			u.push(pickleMark{})
+		case opPop:
+			if _, err := u.pop(); err != nil {
+				return nil, err
+			}
+		case opPopMark:
+			if _, err := u.popMark(); err != nil {
+				return nil, err
+			}
+		case opDup:
+			top, err := u.top()
+			if err != nil {
+				return nil, err
+			}
+			u.push(top)
+		case opNone:
+			u.push(nil)
+		case opNewTrue:
+			u.push(true)
+		case opNewFalse:
+			u.push(false)
+		case opInt:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			switch string(line) {
+			case "00":
+				u.push(false)
+			case "01":
+				u.push(true)
+			default:
+				v, err := strconv.ParseInt(string(line), 10, 64)
+				if err != nil {
+					return nil, err
+				}
+				u.push(v)
+			}
+		case opLong:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			v, err := strconv.ParseInt(string(bytes.TrimSuffix(line, []byte("L"))), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			u.push(v)
+		case opBinInt:
+			b, err := u.readN(4)
+			if err != nil {
+				return nil, err
+			}
+			u.push(int64(int32(binary.LittleEndian.Uint32(b))))
+		case opBinInt1:
+			b, err := u.r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			u.push(int64(b))
+		case opBinInt2:
+			b, err := u.readN(2)
+			if err != nil {
+				return nil, err
+			}
+			u.push(int64(binary.LittleEndian.Uint16(b)))
+		case opLong1:
+			n, err := u.r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			b, err := u.readN(int(n))
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeLong(b)
+			if err != nil {
+				return nil, err
+			}
+			u.push(v)
+		case opFloat:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			v, err := strconv.ParseFloat(string(line), 64)
+			if err != nil {
+				return nil, err
+			}
+			u.push(v)
+		case opBinFloat:
+			b, err := u.readN(8)
+			if err != nil {
+				return nil, err
+			}
+			u.push(math.Float64frombits(binary.BigEndian.Uint64(b)))
+		case opString:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			v, err := strconv.Unquote(string(line))
+			if err != nil {
+				// Python reprs may use single quotes.
+				if len(line) < 2 {
+					return nil, err
+				}
+				v = string(line[1 : len(line)-1])
+			}
+			u.push(v)
+		case opUnicode:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			u.push(string(line))
+		case opBinString, opBinUnicode, opBinBytes:
+			b, err := u.readN(4)
+			if err != nil {
+				return nil, err
+			}
+			if err := u.pushString(int(binary.LittleEndian.Uint32(b))); err != nil {
+				return nil, err
+			}
+		case opShortBinString, opShortBinUnicode, opShortBinBytes:
+			n, err := u.r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			if err := u.pushString(int(n)); err != nil {
+				return nil, err
+			}
+		case opBinUnicode8:
+			b, err := u.readN(8)
+			if err != nil {
+				return nil, err
+			}
+			n := binary.LittleEndian.Uint64(b)
+			if n > maxPickleStringLength {
+				return nil, errPickleStringTooLong
+			}
+			if err := u.pushString(int(n)); err != nil {
+				return nil, err
+			}
+		case opEmptyList:
+			u.push(&pickleList{})
+		case opList:
+			items, err := u.popMark()
+			if err != nil {
+				return nil, err
+			}
+			u.push(&pickleList{items: items})
+		case opAppend:
+			v, err := u.pop()
+			if err != nil {
+				return nil, err
+			}
+			if err := u.appendToList(v); err != nil {
+				return nil, err
+			}
+		case opAppends:
+			items, err := u.popMark()
+			if err != nil {
+				return nil, err
+			}
+			if err := u.appendToList(items...); err != nil {
+				return nil, err
+			}
+		case opEmptyTuple:
+			u.push(pickleTuple{})
+		case opTuple:
+			items, err := u.popMark()
+			if err != nil {
+				return nil, err
+			}
+			u.push(pickleTuple(items))
+		case opTuple1, opTuple2, opTuple3:
+			n := int(op-opTuple1) + 1
+			if len(u.stack) < n {
+				return nil, errPickleStackEmpty
+			}
+			items := make(pickleTuple, n)
+			copy(items, u.stack[len(u.stack)-n:])
+			u.stack = u.stack[:len(u.stack)-n]
+			u.push(items)
+		case opPut:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			key, err := strconv.ParseInt(string(line), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			if err := u.memoize(key); err != nil {
+				return nil, err
+			}
+		case opBinPut:
+			b, err := u.r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			if err := u.memoize(int64(b)); err != nil {
+				return nil, err
+			}
+		case opLongBinPut:
+			b, err := u.readN(4)
+			if err != nil {
+				return nil, err
+			}
+			if err := u.memoize(int64(binary.LittleEndian.Uint32(b))); err != nil {
+				return nil, err
+			}
+		case opMemoize:
+			if err := u.memoize(int64(len(u.memo))); err != nil {
+				return nil, err
+			}
+		case opGet:
+			line, err := u.readLine()
+			if err != nil {
+				return nil, err
+			}
+			key, err := strconv.ParseInt(string(line), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			if err := u.pushMemo(key); err != nil {
+				return nil, err
+			}
+		case opBinGet:
+			b, err := u.r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			if err := u.pushMemo(int64(b)); err != nil {
+				return nil, err
+			}
+		case opLongBinGet:
+			b, err := u.readN(4)
+			if err != nil {
+				return nil, err
+			}
+			if err := u.pushMemo(int64(binary.LittleEndian.Uint32(b))); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("pickle: unsupported opcode 0x%x", op)
+		}
+	}
+}
+
+func (u *unpickler) push(v interface{}) {
+	u.stack = append(u.stack, v)
+}
+
+func (u *unpickler) top() (interface{}, error) {
+	if len(u.stack) == 0 {
+		return nil, errPickleStackEmpty
+	}
+
+	return u.stack[len(u.stack)-1], nil
+}
+
+func (u *unpickler) pop() (interface{}, error) {
+	v, err := u.top()
+	if err != nil {
+		return nil, err
+	}
+
+	u.stack = u.stack[:len(u.stack)-1]
+	return v, nil
+}
+
+// popMark pops all the items above the topmost mark, and the mark itself.
+func (u *unpickler) popMark() ([]interface{}, error) {
+	for i := len(u.stack) - 1; i >= 0; i-- {
+		if _, ok := u.stack[i].(pickleMark); !ok {
+			continue
+		}
+
+		items := make([]interface{}, len(u.stack)-i-1)
+		copy(items, u.stack[i+1:])
+		u.stack = u.stack[:i]
+		return items, nil
+	}
+
+	return nil, errPickleNoMark
+}
+
+func (u *unpickler) appendToList(items ...interface{}) error {
+	top, err := u.top()
+	if err != nil {
+		return err
+	}
+
+	list, ok := top.(*pickleList)
+	if !ok {
+		return errPickleInvalidAppend
+	}
+
+	list.items = append(list.items, items...)
+	return nil
+}
+
+func (u *unpickler) memoize(key int64) error {
+	top, err := u.top()
+	if err != nil {
+		return err
+	}
+
+	u.memo[key] = top
+	return nil
+}
+
+func (u *unpickler) pushMemo(key int64) error {
+	v, ok := u.memo[key]
+	if !ok {
+		return errPickleMemoMissing
+	}
+
+	u.push(v)
+	return nil
+}
+
+func (u *unpickler) pushString(n int) error {
+	if n > maxPickleStringLength {
+		return errPickleStringTooLong
+	}
+
+	b, err := u.readN(n)
+	if err != nil {
+		return err
+	}
+
+	u.push(string(b))
+	return nil
+}
+
+func (u *unpickler) readN(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(u.r, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (u *unpickler) readLine() ([]byte, error) {
+	line, err := u.r.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimRight(line, "\r\n"), nil
+}
+
+// decodeLong decodes a little endian two's complement integer.
+func decodeLong(b []byte) (int64, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+
+	if len(b) > 8 {
+		return 0, fmt.Errorf("pickle: long of %d bytes overflows int64", len(b))
+	}
+
+	var v uint64
+	for i := len(b) - 1; i >= 0; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+
+	// Sign extend if the most significant bit is set.
+	if b[len(b)-1]&0x80 != 0 && len(b) < 8 {
+		v |= ^uint64(0) << (uint(len(b)) * 8)
+	}
+
+	return int64(v), nil
+}
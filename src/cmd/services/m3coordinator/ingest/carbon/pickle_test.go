@@ -0,0 +1,97 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Payloads generated with python's pickle.dumps of
+// [('foo.bar.baz', (1500000000, 1.5)), ('foo.qux', (1500000001.5, 42))].
+var (
+	testPickleProtocol0 = []byte("(lp0\n(Vfoo.bar.baz\np1\n(I1500000000\nF1.5\ntp2\ntp3\na" +
+		"(Vfoo.qux\np4\n(F1500000001.5\nI42\ntp5\ntp6\na.")
+	testPickleProtocol2 = []byte("\x80\x02\x5d\x71\x00\x28\x58\x0b\x00\x00\x00\x66\x6f\x6f" +
+		"\x2e\x62\x61\x72\x2e\x62\x61\x7a\x71\x01\x4a\x00\x2f\x68\x59\x47\x3f\xf8\x00" +
+		"\x00\x00\x00\x00\x00\x86\x71\x02\x86\x71\x03\x58\x07\x00\x00\x00\x66\x6f\x6f" +
+		"\x2e\x71\x75\x78\x71\x04\x47\x41\xd6\x5a\x0b\xc0\x60\x00\x00\x4b\x2a\x86\x71" +
+		"\x05\x86\x71\x06\x65\x2e")
+	testPickleProtocol4 = []byte("\x80\x04\x95\x3e\x00\x00\x00\x00\x00\x00\x00\x5d\x94\x28" +
+		"\x8c\x0b\x66\x6f\x6f\x2e\x62\x61\x72\x2e\x62\x61\x7a\x94\x4a\x00\x2f\x68\x59" +
+		"\x47\x3f\xf8\x00\x00\x00\x00\x00\x00\x86\x94\x86\x94\x8c\x07\x66\x6f\x6f\x2e" +
+		"\x71\x75\x78\x94\x47\x41\xd6\x5a\x0b\xc0\x60\x00\x00\x4b\x2a\x86\x94\x86\x94" +
+		"\x65\x2e")
+)
+
+func TestParsePickle(t *testing.T) {
+	for _, payload := range [][]byte{
+		testPickleProtocol0,
+		testPickleProtocol2,
+		testPickleProtocol4,
+	} {
+		metrics, err := ParsePickle(payload)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(metrics))
+
+		assert.Equal(t, "foo.bar.baz", string(metrics[0].Name))
+		assert.True(t, time.Unix(1500000000, 0).Equal(metrics[0].Timestamp))
+		assert.Equal(t, 1.5, metrics[0].Value)
+
+		assert.Equal(t, "foo.qux", string(metrics[1].Name))
+		assert.True(t, time.Unix(1500000001, int64(500*time.Millisecond)).Equal(metrics[1].Timestamp))
+		assert.Equal(t, float64(42), metrics[1].Value)
+	}
+}
+
+func TestParsePickleErrors(t *testing.T) {
+	for _, payload := range [][]byte{
+		nil,
+		[]byte("."),
+		[]byte("I1\n."),
+		[]byte("\x80\x02\x5d\x71\x00"),
+		[]byte("\x80\x02\xff."),
+		testPickleProtocol2[:len(testPickleProtocol2)-2],
+	} {
+		_, err := ParsePickle(payload)
+		assert.Error(t, err)
+	}
+}
+
+func TestDecodeLong(t *testing.T) {
+	for _, tt := range []struct {
+		b        []byte
+		expected int64
+	}{
+		{b: nil, expected: 0},
+		{b: []byte{0xff, 0x00}, expected: 255},
+		{b: []byte{0xff}, expected: -1},
+		{b: []byte{0x00, 0xff}, expected: -256},
+		{b: []byte{0x00, 0x2f, 0x68, 0x59, 0x01}, expected: 5794967296},
+	} {
+		v, err := decodeLong(tt.b)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, v)
+	}
+}
@@ -138,14 +138,15 @@ type ingestOp struct {
 
 func (op *ingestOp) ingest() {
 	if err := op.resetWriteQuery(); err != nil {
+		// NB: The metric can't be decoded, retrying won't help.
 		op.m.ingestError.Inc(1)
-		op.callback.Callback(m3msg.OnRetriableError)
+		op.callback.Nack(err.Error())
 		op.p.Put(op)
 		return
 	}
 	if err := op.r.Attempt(op.attemptFn); err != nil {
 		if xerrors.IsNonRetryableError(err) {
-			op.callback.Callback(m3msg.OnNonRetriableError)
+			op.callback.Nack(err.Error())
 		} else {
 			op.callback.Callback(m3msg.OnRetriableError)
 		}
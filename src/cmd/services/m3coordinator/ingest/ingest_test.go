@@ -22,6 +22,7 @@ package ingest
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -33,6 +34,7 @@ import (
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/x/serialize"
+	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/instrument"
 	"github.com/m3db/m3x/pool"
@@ -107,6 +109,60 @@ func TestIngest(t *testing.T) {
 	require.Equal(t, id, op.id)
 }
 
+func TestIngestNackOnNonRetryableError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := Configuration{
+		WorkerPoolSize: 2,
+		OpPool: pool.ObjectPoolConfiguration{
+			Size: 1,
+		},
+	}
+	appender := &mockAppender{
+		expectErr: xerrors.NewNonRetryableError(errors.New("invalid write")),
+	}
+	ingester, err := cfg.NewIngester(appender, instrument.NewOptions())
+	require.NoError(t, err)
+
+	id := newTestID(t, "__name__", "foo", "app", "bar")
+	sp := policy.MustParseStoragePolicy("1m:40d")
+	m := consumer.NewMockMessage(ctrl)
+	callback := m3msg.NewRefCountedCallback(m)
+	callback.IncRef()
+
+	doneCh := make(chan struct{})
+	m.EXPECT().Nack("invalid write").Do(func(string) { close(doneCh) })
+	ingester.Ingest(context.TODO(), id, time.Unix(0, 1234), 1, sp, callback)
+	<-doneCh
+}
+
+func TestIngestNackOnInvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := Configuration{
+		WorkerPoolSize: 2,
+		OpPool: pool.ObjectPoolConfiguration{
+			Size: 1,
+		},
+	}
+	appender := &mockAppender{}
+	ingester, err := cfg.NewIngester(appender, instrument.NewOptions())
+	require.NoError(t, err)
+
+	sp := policy.MustParseStoragePolicy("1m:40d")
+	m := consumer.NewMockMessage(ctrl)
+	callback := m3msg.NewRefCountedCallback(m)
+	callback.IncRef()
+
+	doneCh := make(chan struct{})
+	m.EXPECT().Nack(gomock.Any()).Do(func(string) { close(doneCh) })
+	ingester.Ingest(context.TODO(), []byte("invalid id"), time.Unix(0, 1234), 1, sp, callback)
+	<-doneCh
+	require.Equal(t, 0, appender.cnt())
+}
+
 type mockAppender struct {
 	sync.RWMutex
 
@@ -23,6 +23,7 @@ package m3msg
 import (
 	"github.com/m3db/m3/src/metrics/encoding/msgpack"
 	"github.com/m3db/m3/src/msg/consumer"
+	"github.com/m3db/m3/src/msg/kafka"
 	"github.com/m3db/m3x/instrument"
 	"github.com/m3db/m3x/server"
 )
@@ -68,6 +69,39 @@ func (c Configuration) NewServer(
 	), nil
 }
 
+// KafkaConfiguration configs the ingestion of metrics from a Kafka topic.
+type KafkaConfiguration struct {
+	// Consumer configs the Kafka consumer.
+	Consumer kafka.ConsumerConfiguration `yaml:"consumer"`
+
+	// Handler configs the handler.
+	Handler handlerConfiguration `yaml:"handler"`
+}
+
+// NewSubscriber creates a new Kafka subscriber.
+func (c KafkaConfiguration) NewSubscriber(
+	writeFn WriteFn,
+	iOpts instrument.Options,
+) (kafka.Subscriber, error) {
+	scope := iOpts.MetricsScope().Tagged(map[string]string{"server": "kafka"})
+	hOpts := c.Handler.NewOptions(
+		writeFn,
+		iOpts.SetMetricsScope(scope.Tagged(map[string]string{
+			"handler": "msgpack",
+		})),
+	)
+	msgpackHandler, err := newHandler(hOpts)
+	if err != nil {
+		return nil, err
+	}
+	return c.Consumer.NewSubscriber(
+		msgpackHandler.Handle,
+		iOpts.SetMetricsScope(scope.Tagged(map[string]string{
+			"component": "consumer",
+		})),
+	)
+}
+
 type handlerConfiguration struct {
 	// Msgpack configs the msgpack iterator.
 	Msgpack MsgpackIteratorConfiguration `yaml:"msgpack"`
@@ -36,6 +36,7 @@ import (
 	"github.com/m3db/m3/src/msg/protocol/proto"
 	"github.com/m3db/m3x/instrument"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -93,6 +94,24 @@ func TestM3msgServerHandlerWithMultipleMetricsPerMessage(t *testing.T) {
 	require.Equal(t, 2, m.ingested())
 }
 
+func TestRefCountedCallbackNack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := consumer.NewMockMessage(ctrl)
+	c := NewRefCountedCallback(m)
+	c.IncRef()
+	c.IncRef()
+	c.IncRef()
+
+	c.Callback(OnSuccess)
+	c.Nack("bad tags")
+
+	// The message is nacked with the first reason.
+	m.EXPECT().Nack("bad tags")
+	c.Nack("bad value")
+}
+
 type mockWriter struct {
 	sync.Mutex
 
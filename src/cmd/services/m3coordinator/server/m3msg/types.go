@@ -22,6 +22,7 @@ package m3msg
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -53,10 +54,15 @@ const (
 )
 
 // RefCountedCallback wraps a message with a reference count, the message will
-// be acked once the reference count decrements to zero.
+// be acked once the reference count decrements to zero, or nacked if any of
+// the references has been nacked.
 type RefCountedCallback struct {
-	ref int32
-	msg consumer.Message
+	sync.Mutex
+
+	ref        int32
+	msg        consumer.Message
+	nackReason string
+	nacked     bool
 }
 
 // NewRefCountedCallback creates a RefCountedCallback.
@@ -77,6 +83,13 @@ func (r *RefCountedCallback) IncRef() {
 func (r *RefCountedCallback) decRef() {
 	ref := atomic.AddInt32(&r.ref, -1)
 	if ref == 0 {
+		r.Lock()
+		nacked, reason := r.nacked, r.nackReason
+		r.Unlock()
+		if nacked {
+			r.msg.Nack(reason)
+			return
+		}
 		r.msg.Ack()
 		return
 	}
@@ -92,3 +105,16 @@ func (r *RefCountedCallback) Callback(t CallbackType) {
 		r.decRef()
 	}
 }
+
+// Nack releases a reference with the reason why it could not be processed,
+// the message will be nacked with the first reason once the reference count
+// decrements to zero so it's not retried.
+func (r *RefCountedCallback) Nack(reason string) {
+	r.Lock()
+	if !r.nacked {
+		r.nacked = true
+		r.nackReason = reason
+	}
+	r.Unlock()
+	r.decRef()
+}
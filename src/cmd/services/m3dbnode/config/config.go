@@ -220,6 +220,28 @@ type RepairPolicy struct {
 
 	// The repair check interval.
 	CheckInterval time.Duration `yaml:"checkInterval" validate:"nonzero"`
+
+	// AntiEntropy enables continuous Merkle tree based repair in place of
+	// the periodic metadata comparison when set.
+	AntiEntropy *AntiEntropyRepairPolicy `yaml:"antiEntropy"`
+}
+
+// AntiEntropyRepairPolicy is the continuous anti-entropy repair policy.
+type AntiEntropyRepairPolicy struct {
+	// Enabled or disabled.
+	Enabled bool `yaml:"enabled"`
+
+	// The depth of the Merkle trees compared with peers.
+	MerkleTreeDepth int `yaml:"merkleTreeDepth"`
+
+	// The interval after which Merkle trees are rebuilt.
+	MerkleTreeRefreshInterval time.Duration `yaml:"merkleTreeRefreshInterval"`
+
+	// The throughput limit in Mbps for streaming blocks from peers.
+	ThroughputLimitMbps float64 `yaml:"throughputLimitMbps"`
+
+	// The number of blocks streamed between throughput limit checks.
+	ThroughputCheckEvery int `yaml:"throughputCheckEvery"`
 }
 
 // HashingConfiguration is the configuration for hashing.
@@ -383,6 +383,7 @@ db:
     newFileMode: null
     newDirectoryMode: null
     mmap: null
+    coldStorage: null
   commitlog:
     flushMaxBytes: 524288
     flushEvery: 1s
@@ -397,6 +398,7 @@ db:
     jitter: 1h0m0s
     throttle: 2m0s
     checkInterval: 1m0s
+    antiEntropy: null
   pooling:
     blockAllocSize: 16
     type: simple
@@ -23,6 +23,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 )
 
 const (
@@ -75,6 +76,25 @@ type FilesystemConfiguration struct {
 
 	// Mmap is the mmap options which features are primarily platform dependent
 	Mmap *MmapConfiguration `yaml:"mmap"`
+
+	// ColdStorage is the cold storage configuration, old fileset volumes are
+	// only offloaded when it is set
+	ColdStorage *ColdStorageConfiguration `yaml:"coldStorage"`
+}
+
+// ColdStorageConfiguration is the cold storage configuration.
+type ColdStorageConfiguration struct {
+	// Directory is the directory offloaded fileset files are stored in,
+	// typically a network mount backed by cheaper storage
+	Directory string `yaml:"directory" validate:"nonzero"`
+
+	// OffloadAge is the age after which the fileset volumes of a block are
+	// offloaded to cold storage
+	OffloadAge time.Duration `yaml:"offloadAge" validate:"nonzero"`
+
+	// CacheTTL is how long fileset files fetched back from cold storage are
+	// kept on local disk, uses the default if not set
+	CacheTTL time.Duration `yaml:"cacheTTL"`
 }
 
 // MmapConfiguration is the mmap configuration.
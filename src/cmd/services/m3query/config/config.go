@@ -26,7 +26,11 @@ import (
 	etcdclient "github.com/m3db/m3/src/cluster/client/etcd"
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest/carbon"
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/server/m3msg"
+	"github.com/m3db/m3/src/metrics/rules/validator"
+	"github.com/m3db/m3/src/query/cache"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage/m3"
 	xconfig "github.com/m3db/m3x/config"
@@ -91,13 +95,60 @@ type Configuration struct {
 	// Ingest is the ingest server.
 	Ingest *IngestConfiguration `yaml:"ingest"`
 
+	// Carbon is the carbon ingestion server configuration (optional).
+	Carbon *carbon.Configuration `yaml:"carbon"`
+
 	// Limits specifies limits on per-query resource usage.
 	Limits LimitsConfiguration `yaml:"limits"`
+
+	// ResultsCache is the range query results cache configuration (optional).
+	ResultsCache *cache.Configuration `yaml:"resultsCache"`
+
+	// Rules is the configuration for the rules management endpoints.
+	Rules RulesConfiguration `yaml:"rules"`
+}
+
+// RulesConfiguration is the configuration for the rules management endpoints.
+type RulesConfiguration struct {
+	// NamespacesKey is the KV key that holds the rule namespaces.
+	NamespacesKey string `yaml:"namespacesKey"`
+
+	// RuleSetKeyFmt is the format of the KV key that holds a namespace ruleset.
+	RuleSetKeyFmt string `yaml:"ruleSetKeyFmt"`
+
+	// PropagationDelay is the delay before rule changes take effect.
+	PropagationDelay time.Duration `yaml:"propagationDelay"`
+
+	// NameTag is the tag that holds the metric name when matching metric IDs.
+	NameTag string `yaml:"nameTag"`
+
+	// Validation is the rules validation configuration (optional).
+	Validation *validator.Configuration `yaml:"validation"`
 }
 
 // LimitsConfiguration represents limitations on per-query resource usage. Zero or negative values imply no limit.
 type LimitsConfiguration struct {
 	MaxComputedDatapoints int64 `yaml:"maxComputedDatapoints"`
+
+	// MaxFetchedSeries is the maximum number of series a query may fetch.
+	MaxFetchedSeries int64 `yaml:"maxFetchedSeries"`
+
+	// MaxFetchedDatapoints is the maximum number of datapoints a query may
+	// decompress.
+	MaxFetchedDatapoints int64 `yaml:"maxFetchedDatapoints"`
+
+	// MaxBlockBytes is the maximum number of bytes a query may allocate for
+	// intermediate blocks.
+	MaxBlockBytes int64 `yaml:"maxBlockBytes"`
+}
+
+// CostLimits returns the cost limits enforced on each query.
+func (c LimitsConfiguration) CostLimits() cost.Limits {
+	return cost.Limits{
+		MaxFetchedSeries:     c.MaxFetchedSeries,
+		MaxFetchedDatapoints: c.MaxFetchedDatapoints,
+		MaxBlockBytes:        c.MaxBlockBytes,
+	}
 }
 
 // IngestConfiguration is the configuration for ingestion server.
@@ -107,6 +158,9 @@ type IngestConfiguration struct {
 
 	// M3Msg is the configuration for m3msg server.
 	M3Msg m3msg.Configuration `yaml:"m3msg"`
+
+	// Kafka is the configuration for ingesting from a Kafka topic (optional).
+	Kafka *m3msg.KafkaConfiguration `yaml:"kafka"`
 }
 
 // LocalConfiguration is the local embedded configuration if running
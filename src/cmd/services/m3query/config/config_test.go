@@ -22,7 +22,9 @@ package config
 
 import (
 	"testing"
+	"time"
 
+	"github.com/m3db/m3/src/query/cache"
 	xconfig "github.com/m3db/m3x/config"
 
 	"github.com/stretchr/testify/assert"
@@ -56,6 +58,10 @@ func TestConfigLoading(t *testing.T) {
 	assert.Equal(t, &LimitsConfiguration{
 		MaxComputedDatapoints: 12000,
 	}, &cfg.Limits)
+	assert.Equal(t, &cache.Configuration{
+		Capacity:   5000,
+		BucketSize: 30 * time.Minute,
+	}, cfg.ResultsCache)
 	// TODO: assert on more fields here.
 }
 
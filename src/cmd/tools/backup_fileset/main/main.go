@@ -0,0 +1,123 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/instrument"
+	xlog "github.com/m3db/m3x/log"
+
+	"github.com/pborman/getopt"
+)
+
+func main() {
+	var (
+		optPathPrefix = getopt.StringLong("path-prefix", 'p', "", "Path prefix [e.g. /var/lib/m3db]")
+		optNamespaces = getopt.StringLong("namespaces", 'n', "", "Comma separated namespaces [e.g. metrics,metrics_10s]")
+		optShards     = getopt.StringLong("shards", 's', "", "Comma separated shards, all shards on disk if empty (optional)")
+		optDestDir    = getopt.StringLong("dest-dir", 'd', "", "Destination directory [e.g. /mnt/backups/m3db]")
+		optColdDir    = getopt.StringLong("cold-storage-dir", 'c', "", "Cold storage directory of offloaded filesets (optional)")
+		log           = xlog.NewLogger(os.Stderr)
+	)
+	getopt.Parse()
+
+	if *optPathPrefix == "" || *optNamespaces == "" || *optDestDir == "" {
+		getopt.Usage()
+		os.Exit(1)
+	}
+
+	var shards []uint32
+	if *optShards != "" {
+		for _, s := range strings.Split(*optShards, ",") {
+			shard, err := strconv.ParseUint(strings.TrimSpace(s), 10, 32)
+			if err != nil {
+				log.Fatalf("invalid shard %s: %v", s, err)
+			}
+			shards = append(shards, uint32(shard))
+		}
+	}
+
+	var targets []backup.Target
+	for _, ns := range strings.Split(*optNamespaces, ",") {
+		namespace := ident.StringID(strings.TrimSpace(ns))
+		targetShards := shards
+		if len(targetShards) == 0 {
+			var err error
+			targetShards, err = shardsOnDisk(*optPathPrefix, namespace)
+			if err != nil {
+				log.Fatalf("unable to list shards of namespace %s: %v", namespace.String(), err)
+			}
+		}
+		targets = append(targets, backup.Target{
+			Namespace: namespace,
+			Shards:    targetShards,
+		})
+	}
+
+	opts := backup.NewOptions()
+	opts = opts.
+		SetInstrumentOptions(instrument.NewOptions().SetLogger(log)).
+		SetFilePathPrefix(*optPathPrefix).
+		SetDestination(backup.NewLocalDestination(*optDestDir,
+			opts.FileMode(), opts.DirMode()))
+	if *optColdDir != "" {
+		opts = opts.SetColdStorage(fs.NewLocalColdStorage(*optColdDir,
+			opts.FileMode(), opts.DirMode()))
+	}
+
+	backuper, err := backup.NewBackuper(opts)
+	if err != nil {
+		log.Fatalf("unable to create backuper: %v", err)
+	}
+	manifest, err := backuper.Backup(targets)
+	if err != nil {
+		log.Fatalf("unable to back up: %v", err)
+	}
+
+	log.Infof("successfully backed up %d files to %s", len(manifest.Files), *optDestDir)
+}
+
+func shardsOnDisk(filePathPrefix string, namespace ident.ID) ([]uint32, error) {
+	dirs, err := ioutil.ReadDir(fs.NamespaceDataDirPath(filePathPrefix, namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	var shards []uint32
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		shard, err := strconv.ParseUint(dir.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		shards = append(shards, uint32(shard))
+	}
+	return shards, nil
+}
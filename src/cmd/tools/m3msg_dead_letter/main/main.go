@@ -0,0 +1,231 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	etcdclient "github.com/m3db/m3/src/cluster/client/etcd"
+	"github.com/m3db/m3/src/msg/consumer"
+	"github.com/m3db/m3/src/msg/deadletter"
+	"github.com/m3db/m3/src/msg/generated/proto/msgpb"
+	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3/src/msg/producer/config"
+	"github.com/m3db/m3/src/msg/topic"
+	xconfig "github.com/m3db/m3x/config"
+	"github.com/m3db/m3x/instrument"
+	xlog "github.com/m3db/m3x/log"
+
+	"github.com/pborman/getopt"
+)
+
+const (
+	modeRecord  = "record"
+	modeInspect = "inspect"
+	modeReplay  = "replay"
+)
+
+type configuration struct {
+	// Client configs the etcd client used for the topic and placements.
+	Client etcdclient.Configuration `yaml:"client"`
+
+	// Producer configs the producer used to replay the dead letters.
+	Producer config.ProducerConfiguration `yaml:"producer"`
+
+	// Consumer configs the consumer used to record the dead letters.
+	Consumer consumer.Configuration `yaml:"consumer"`
+}
+
+func main() {
+	var (
+		optMode            = getopt.StringLong("mode", 'm', "", "Mode [record|inspect|replay]")
+		optFile            = getopt.StringLong("file", 'f', "", "Dead letter file [e.g. /var/lib/m3msg/dead_letters.json]")
+		optConfig          = getopt.StringLong("config", 'c', "", "Configuration file, required for record and replay")
+		optListenAddress   = getopt.StringLong("listen-address", 'l', "0.0.0.0:9000", "Address to receive dead letters on in record mode")
+		optConsumerService = getopt.StringLong("consumer-service", 's', "", "Only inspect or replay the dead letters of the consumer service (optional)")
+		log                = xlog.NewLogger(os.Stderr)
+	)
+	getopt.Parse()
+
+	if *optMode == "" || *optFile == "" {
+		getopt.Usage()
+		os.Exit(1)
+	}
+
+	iOpts := instrument.NewOptions().SetLogger(log)
+	switch *optMode {
+	case modeRecord:
+		cfg := mustLoadConfig(log, *optConfig)
+		record(log, cfg, iOpts, *optFile, *optListenAddress)
+	case modeInspect:
+		inspect(log, *optFile, *optConsumerService)
+	case modeReplay:
+		cfg := mustLoadConfig(log, *optConfig)
+		replay(log, cfg, iOpts, *optFile, *optConsumerService)
+	default:
+		getopt.Usage()
+		os.Exit(1)
+	}
+}
+
+func mustLoadConfig(log xlog.Logger, file string) configuration {
+	if file == "" {
+		getopt.Usage()
+		os.Exit(1)
+	}
+	var cfg configuration
+	if err := xconfig.LoadFile(&cfg, file, xconfig.Options{}); err != nil {
+		log.Fatalf("unable to load config %s: %v", file, err)
+	}
+	return cfg
+}
+
+func record(
+	log xlog.Logger,
+	cfg configuration,
+	iOpts instrument.Options,
+	file string,
+	listenAddress string,
+) {
+	fd, err := os.OpenFile(file, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Fatalf("unable to open dead letter file %s: %v", file, err)
+	}
+	defer fd.Close()
+
+	r := deadletter.NewRecorder(fd, log)
+	s := consumer.NewServer(listenAddress, consumer.NewServerOptions().
+		SetConsumerOptions(cfg.Consumer.NewOptions(iOpts)).
+		SetConsumeFn(r.Consume).
+		SetInstrumentOptions(iOpts))
+	if err := s.ListenAndServe(); err != nil {
+		log.Fatalf("unable to serve on %s: %v", listenAddress, err)
+	}
+	log.Infof("recording dead letters from %s to %s", listenAddress, file)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	<-sigCh
+	s.Close()
+}
+
+func inspect(log xlog.Logger, file string, consumerService string) {
+	fd, err := os.Open(file)
+	if err != nil {
+		log.Fatalf("unable to open dead letter file %s: %v", file, err)
+	}
+	defer fd.Close()
+
+	r := deadletter.NewReader(fd)
+	for r.Next() {
+		dl := r.Current()
+		if !matchConsumerService(dl, consumerService) {
+			continue
+		}
+		fmt.Printf("%s consumer_service=%s shard=%d attempts=%d size=%d reason=%q\n",
+			time.Unix(0, dl.DeadLetterNanos).UTC().Format(time.RFC3339Nano),
+			dl.ConsumerService.Name, dl.Shard, dl.Attempts, len(dl.Value), dl.Reason)
+	}
+	if err := r.Err(); err != nil {
+		log.Fatalf("unable to read dead letter file %s: %v", file, err)
+	}
+}
+
+func replay(
+	log xlog.Logger,
+	cfg configuration,
+	iOpts instrument.Options,
+	file string,
+	consumerService string,
+) {
+	if cfg.Producer.Buffer.Disk != nil {
+		log.Fatalf("unable to replay with a disk buffer")
+	}
+	cs, err := cfg.Client.NewClient(iOpts)
+	if err != nil {
+		log.Fatalf("unable to create etcd client: %v", err)
+	}
+	kvOpts, err := cfg.Producer.Writer.TopicServiceOverride.NewOverrideOptions()
+	if err != nil {
+		log.Fatalf("unable to create topic service override options: %v", err)
+	}
+	ts, err := topic.NewService(topic.NewServiceOptions().
+		SetConfigService(cs).
+		SetKVOverrideOptions(kvOpts))
+	if err != nil {
+		log.Fatalf("unable to create topic service: %v", err)
+	}
+	t, err := ts.Get(cfg.Producer.Writer.TopicName)
+	if err != nil {
+		log.Fatalf("unable to get topic %s: %v", cfg.Producer.Writer.TopicName, err)
+	}
+
+	p, err := cfg.Producer.NewProducer(cs, iOpts)
+	if err != nil {
+		log.Fatalf("unable to create producer: %v", err)
+	}
+	if err := p.Init(); err != nil {
+		log.Fatalf("unable to init producer: %v", err)
+	}
+	r := deadletter.NewReplayer(p, t)
+
+	fd, err := os.Open(file)
+	if err != nil {
+		log.Fatalf("unable to open dead letter file %s: %v", file, err)
+	}
+	defer fd.Close()
+
+	var (
+		reader   = deadletter.NewReader(fd)
+		replayed int
+	)
+	for reader.Next() {
+		dl := reader.Current()
+		if !matchConsumerService(dl, consumerService) {
+			continue
+		}
+		if err := r.Replay(dl); err != nil {
+			log.Errorf("unable to replay dead letter from %s: %v", dl.ConsumerService.String(), err)
+			continue
+		}
+		replayed++
+	}
+	if err := reader.Err(); err != nil {
+		log.Errorf("unable to read dead letter file %s: %v", file, err)
+	}
+
+	// NB: Wait for the replayed messages to be consumed before unregistering
+	// the filters.
+	p.Close(producer.WaitForConsumption)
+	r.Close()
+	log.Infof("successfully replayed %d dead letters to topic %s", replayed, t.Name())
+}
+
+func matchConsumerService(dl msgpb.DeadLetter, consumerService string) bool {
+	if dl.ConsumerService == nil {
+		return false
+	}
+	return consumerService == "" || dl.ConsumerService.Name == consumerService
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"os"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+	"github.com/m3db/m3x/instrument"
+	xlog "github.com/m3db/m3x/log"
+
+	"github.com/pborman/getopt"
+)
+
+func main() {
+	var (
+		optPathPrefix = getopt.StringLong("path-prefix", 'p', "", "Path prefix to restore into [e.g. /var/lib/m3db]")
+		optSrcDir     = getopt.StringLong("src-dir", 's', "", "Backup directory [e.g. /mnt/backups/m3db]")
+		log           = xlog.NewLogger(os.Stderr)
+	)
+	getopt.Parse()
+
+	if *optPathPrefix == "" || *optSrcDir == "" {
+		getopt.Usage()
+		os.Exit(1)
+	}
+
+	opts := backup.NewOptions()
+	opts = opts.
+		SetInstrumentOptions(instrument.NewOptions().SetLogger(log)).
+		SetFilePathPrefix(*optPathPrefix).
+		SetDestination(backup.NewLocalDestination(*optSrcDir,
+			opts.FileMode(), opts.DirMode()))
+
+	restorer, err := backup.NewRestorer(opts)
+	if err != nil {
+		log.Fatalf("unable to create restorer: %v", err)
+	}
+	manifest, err := restorer.Restore()
+	if err != nil {
+		log.Fatalf("unable to restore: %v", err)
+	}
+
+	log.Infof("successfully restored %d files from backup created at %v",
+		len(manifest.Files), manifest.CreatedAt)
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/topology"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+)
+
+type aggregateOp struct {
+	request      rpc.AggregateQueryRequest
+	completionFn completionFn
+}
+
+func (a *aggregateOp) Size() int {
+	// Aggregate is always a single op
+	return 1
+}
+
+func (a *aggregateOp) CompletionFn() completionFn {
+	return a.completionFn
+}
+
+type aggregateResultAccumulatorOpts struct {
+	host     topology.Host
+	response *rpc.AggregateQueryResult_
+}
+
+// aggregateResultAccumulator merges the responses of an aggregate query fanned
+// out to every host in the topology, tracking the response consistency per
+// shard the same as a fetchTagged request.
+type aggregateResultAccumulator struct {
+	sync.Mutex
+
+	wg               sync.WaitGroup
+	topoMap          topology.Map
+	majority         int
+	consistencyLevel topology.ReadConsistencyLevel
+	enqueued         []int
+	success          []int
+	errors           xerrors.Errors
+	results          *index.AggregateResults
+	exhaustive       bool
+}
+
+func newAggregateResultAccumulator(
+	nsID ident.ID,
+	topoMap topology.Map,
+	majority int,
+	consistencyLevel topology.ReadConsistencyLevel,
+) *aggregateResultAccumulator {
+	numShards := 1 + int(topoMap.ShardSet().Max())
+	accum := &aggregateResultAccumulator{
+		topoMap:          topoMap,
+		majority:         majority,
+		consistencyLevel: consistencyLevel,
+		enqueued:         make([]int, numShards),
+		success:          make([]int, numShards),
+		results:          index.NewAggregateResults(nsID),
+		exhaustive:       true,
+	}
+	for _, hss := range topoMap.HostShardSets() {
+		for _, hShard := range hss.ShardSet().All() {
+			accum.enqueued[hShard.ID()]++
+		}
+	}
+	return accum
+}
+
+func (accum *aggregateResultAccumulator) completionFn(
+	result interface{},
+	resultErr error,
+) {
+	opts, ok := result.(aggregateResultAccumulatorOpts)
+	accum.Lock()
+	if !ok || opts.host == nil {
+		// should never happen, guarding against incompatible changes to the `client` package.
+		accum.errors = append(accum.errors, xerrors.NewNonRetryableError(
+			fmt.Errorf("[invariant violated] nil host in aggregate completionFn")))
+	} else {
+		accum.addWithLock(opts, resultErr)
+	}
+	accum.Unlock()
+	accum.wg.Done()
+}
+
+func (accum *aggregateResultAccumulator) addWithLock(
+	opts aggregateResultAccumulatorOpts,
+	resultErr error,
+) {
+	host := opts.host
+	hostShardSet, ok := accum.topoMap.LookupHostShardSet(host.ID())
+	if !ok {
+		// should never happen, as we've taken a reference to the
+		// topology when beginning the request, and the var is immutable.
+		accum.errors = append(accum.errors, xerrors.NewNonRetryableError(fmt.Errorf(
+			"[invariant violated] missing host shard in aggregate completionFn: %s", host.ID())))
+		return
+	}
+
+	if resultErr != nil {
+		err := xerrors.NewRenamedError(resultErr,
+			fmt.Errorf("error aggregating from host %s: %v", host.ID(), resultErr))
+		if IsBadRequestError(resultErr) {
+			// Do not retry bad request errors
+			err = xerrors.NewNonRetryableError(err)
+		}
+		accum.errors = append(accum.errors, err)
+		return
+	}
+
+	accum.exhaustive = accum.exhaustive && opts.response.Exhaustive
+	for _, elem := range opts.response.Results {
+		if len(elem.TagValues) == 0 {
+			accum.results.AddField(elem.TagName, elem.Count)
+			continue
+		}
+		remaining := elem.Count
+		for _, value := range elem.TagValues {
+			accum.results.AddFieldValue(elem.TagName, value.TagValue, value.Count)
+			remaining -= value.Count
+		}
+		accum.results.AddField(elem.TagName, remaining)
+	}
+
+	for _, hs := range hostShardSet.ShardSet().All() {
+		// Only accept responses from available shards, the same as fetchTagged.
+		if hs.State() == shard.Available {
+			accum.success[hs.ID()]++
+		}
+	}
+}
+
+// Wait blocks until every enqueued host has responded and returns the merged
+// results. Each host reports the number of distinct series it has indexed for
+// its shards, and every replica of a shard reports the same series, so the
+// counts are summed across hosts and divided by the average number of replicas
+// that responded per shard. The counts are approximate since replicas can
+// disagree and hosts that responded can own different numbers of shards.
+func (accum *aggregateResultAccumulator) Wait() (index.AggregateQueryResult, error) {
+	accum.wg.Wait()
+
+	accum.Lock()
+	defer accum.Unlock()
+
+	if err := xerrors.FirstError(accum.nonRetryableErrorsWithLock()...); err != nil {
+		return index.AggregateQueryResult{}, err
+	}
+
+	var (
+		unsatisfied  int
+		numShards    int64
+		numResponses int64
+	)
+	for _, hShard := range accum.topoMap.ShardSet().All() {
+		id := hShard.ID()
+		if !topology.ReadConsistencyAchieved(accum.consistencyLevel, accum.majority,
+			accum.enqueued[id], accum.success[id]) {
+			unsatisfied++
+		}
+		numShards++
+		numResponses += int64(accum.success[id])
+	}
+	if unsatisfied > 0 {
+		return index.AggregateQueryResult{}, fmt.Errorf(
+			"unable to satisfy consistency requirements for %d shards [ err = %s ]",
+			unsatisfied, accum.errors.Error())
+	}
+
+	perReplica := func(count int64) int64 {
+		if numShards == 0 || numResponses <= numShards {
+			return count
+		}
+		return (count*numShards + numResponses - 1) / numResponses
+	}
+
+	results := index.NewAggregateResults(accum.results.Namespace())
+	for _, field := range accum.results.Fields() {
+		fieldCount := perReplica(field.Count)
+		for _, value := range field.Values {
+			valueCount := perReplica(value.Count)
+			results.AddFieldValue(field.Name, value.Value, valueCount)
+			fieldCount -= valueCount
+		}
+		if fieldCount < 0 {
+			fieldCount = 0
+		}
+		results.AddField(field.Name, fieldCount)
+	}
+
+	return index.AggregateQueryResult{
+		Results:    results,
+		Exhaustive: accum.exhaustive,
+	}, nil
+}
+
+func (accum *aggregateResultAccumulator) nonRetryableErrorsWithLock() []error {
+	var errs []error
+	for _, err := range accum.errors {
+		if xerrors.IsNonRetryableError(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/encoding/valuetype"
 	"github.com/m3db/m3/src/dbnode/environment"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/dbnode/x/tchannel"
@@ -79,6 +80,10 @@ type Configuration struct {
 
 	// HashingConfiguration is the configuration for hashing of IDs to shards.
 	HashingConfiguration HashingConfiguration `yaml:"hashing"`
+
+	// HintedHandoff is the configuration for persisting and replaying writes
+	// that fail to reach a host, hinted handoff is disabled if not set.
+	HintedHandoff *HintedHandoffConfiguration `yaml:"hintedHandoff"`
 }
 
 // HashingConfiguration is the configuration for hashing
@@ -87,6 +92,38 @@ type HashingConfiguration struct {
 	Seed uint32 `yaml:"seed"`
 }
 
+// HintedHandoffConfiguration is the configuration for hinted handoff.
+type HintedHandoffConfiguration struct {
+	// Directory is the directory the hints are persisted to.
+	Directory string `yaml:"directory" validate:"nonzero"`
+
+	// MaxHintAge is the max age of a hint before it is dropped.
+	MaxHintAge time.Duration `yaml:"maxHintAge" validate:"min=0"`
+
+	// MaxHintsBytes is the max size of the hints persisted for a single host.
+	MaxHintsBytes int64 `yaml:"maxHintsBytes" validate:"min=0"`
+
+	// ReplayInterval is the interval at which hints are replayed to hosts.
+	ReplayInterval time.Duration `yaml:"replayInterval" validate:"min=0"`
+}
+
+// NewOptions creates hinted handoff options from the configuration.
+func (c HintedHandoffConfiguration) NewOptions() HintedHandoffOptions {
+	opts := NewHintedHandoffOptions().
+		SetEnabled(true).
+		SetDirectory(c.Directory)
+	if c.MaxHintAge > 0 {
+		opts = opts.SetMaxHintAge(c.MaxHintAge)
+	}
+	if c.MaxHintsBytes > 0 {
+		opts = opts.SetMaxHintsBytes(c.MaxHintsBytes)
+	}
+	if c.ReplayInterval > 0 {
+		opts = opts.SetReplayInterval(c.ReplayInterval)
+	}
+	return opts
+}
+
 // ConfigurationParameters are optional parameters that can be specified
 // when creating a client from configuration, this is specified using
 // a struct so that adding fields do not cause breaking changes to callers.
@@ -197,9 +234,13 @@ func (c Configuration) NewAdminClient(
 
 	v = v.SetReaderIteratorAllocate(func(r io.Reader) encoding.ReaderIterator {
 		intOptimized := m3tsz.DefaultIntOptimizationEnabled
-		return m3tsz.NewReaderIterator(r, intOptimized, encodingOpts)
+		return valuetype.NewReaderIterator(r, intOptimized, encodingOpts)
 	})
 
+	if c.HintedHandoff != nil {
+		v = v.SetHintedHandoffOptions(c.HintedHandoff.NewOptions())
+	}
+
 	// Apply programtic custom options last
 	opts := v.(AdminOptions)
 	for _, opt := range custom {
@@ -56,6 +56,11 @@ backgroundHealthCheckFailLimit: 4
 backgroundHealthCheckFailThrottleFactor: 0.5
 hashing:
   seed: 42
+hintedHandoff:
+  directory: /var/lib/m3db/hints
+  maxHintAge: 1h
+  maxHintsBytes: 1048576
+  replayInterval: 5s
 `
 
 	fd, err := ioutil.TempFile("", "config.yaml")
@@ -97,6 +102,12 @@ hashing:
 		HashingConfiguration: HashingConfiguration{
 			Seed: 42,
 		},
+		HintedHandoff: &HintedHandoffConfiguration{
+			Directory:      "/var/lib/m3db/hints",
+			MaxHintAge:     time.Hour,
+			MaxHintsBytes:  1048576,
+			ReplayInterval: 5 * time.Second,
+		},
 	}
 
 	assert.Equal(t, expected, cfg)
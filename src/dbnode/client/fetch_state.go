@@ -145,8 +145,7 @@ func (f *fetchState) asTaggedIDsIterator(pools fetchTaggedPools) (TaggedIDsItera
 		return nil, false, err
 	}
 
-	limit := f.op.requestLimit(maxInt)
-	return f.tagResultAccumulator.AsTaggedIDsIterator(limit, pools)
+	return f.tagResultAccumulator.AsTaggedIDsIterator(f.resultLimit(), pools)
 }
 
 func (f *fetchState) asEncodingSeriesIterators(pools fetchTaggedPools) (encoding.SeriesIterators, bool, error) {
@@ -161,8 +160,31 @@ func (f *fetchState) asEncodingSeriesIterators(pools fetchTaggedPools) (encoding
 		return nil, false, err
 	}
 
-	limit := f.op.requestLimit(maxInt)
-	return f.tagResultAccumulator.AsEncodingSeriesIterators(limit, pools)
+	return f.tagResultAccumulator.AsEncodingSeriesIterators(f.resultLimit(), pools)
+}
+
+func (f *fetchState) nextPageToken() ([]byte, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if !f.done {
+		return nil, errFetchStateStillProcessing
+	}
+
+	if err := f.err; err != nil {
+		return nil, err
+	}
+
+	return f.tagResultAccumulator.NextPageToken()
+}
+
+func (f *fetchState) resultLimit() int {
+	// NB(r): for paged requests the limit is the page size of each host, the
+	// merged results are bounded by the next page boundary instead.
+	if f.op.request.PageToken != nil {
+		return maxInt
+	}
+	return f.op.requestLimit(maxInt)
 }
 
 // NB(prateek): this is backed by the sessionPools struct, but we're restricting it to a narrow
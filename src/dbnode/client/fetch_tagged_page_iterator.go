@@ -0,0 +1,100 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3x/ident"
+)
+
+type fetchTaggedPageFn func(
+	ns ident.ID, q index.Query, opts index.QueryOptions, pageToken []byte,
+) (encoding.SeriesIterators, bool, []byte, error)
+
+type fetchTaggedPageIterator struct {
+	fetchPageFn fetchTaggedPageFn
+	ns          ident.ID
+	query       index.Query
+	opts        index.QueryOptions
+	pageSize    int
+
+	// NB(r): an empty but non-nil page token requests the first page.
+	pageToken  []byte
+	remaining  int
+	done       bool
+	current    encoding.SeriesIterators
+	exhaustive bool
+	err        error
+}
+
+func newFetchTaggedPageIterator(
+	s *session,
+	ns ident.ID,
+	q index.Query,
+	opts index.QueryOptions,
+	pageSize int,
+) FetchTaggedPageIterator {
+	return &fetchTaggedPageIterator{
+		fetchPageFn: s.fetchTaggedPage,
+		ns:          ns,
+		query:       q,
+		opts:        opts,
+		pageSize:    pageSize,
+		pageToken:   []byte{},
+		remaining:   opts.Limit,
+	}
+}
+
+func (it *fetchTaggedPageIterator) Next() bool {
+	it.current, it.exhaustive = nil, false
+	if it.done || it.err != nil {
+		return false
+	}
+
+	pageOpts := it.opts
+	pageOpts.Limit = it.pageSize
+	if it.opts.Limit > 0 && it.remaining < pageOpts.Limit {
+		pageOpts.Limit = it.remaining
+	}
+
+	iters, exhaustive, nextPageToken, err := it.fetchPageFn(it.ns, it.query, pageOpts, it.pageToken)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.current, it.exhaustive = iters, exhaustive
+	it.pageToken = nextPageToken
+	if it.opts.Limit > 0 {
+		it.remaining -= iters.Len()
+	}
+	it.done = nextPageToken == nil || (it.opts.Limit > 0 && it.remaining <= 0)
+	return true
+}
+
+func (it *fetchTaggedPageIterator) Current() (encoding.SeriesIterators, bool) {
+	return it.current, it.exhaustive
+}
+
+func (it *fetchTaggedPageIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testFetchTaggedPage struct {
+	numSeries     int
+	nextPageToken []byte
+	err           error
+}
+
+type testFetchTaggedPageRequest struct {
+	limit     int
+	pageToken []byte
+}
+
+func newTestFetchTaggedPageIterator(
+	opts index.QueryOptions,
+	pageSize int,
+	pages []testFetchTaggedPage,
+) (*fetchTaggedPageIterator, *[]testFetchTaggedPageRequest) {
+	var requests []testFetchTaggedPageRequest
+	it := &fetchTaggedPageIterator{
+		ns:        ident.StringID("testNs"),
+		opts:      opts,
+		pageSize:  pageSize,
+		pageToken: []byte{},
+		remaining: opts.Limit,
+	}
+	it.fetchPageFn = func(
+		_ ident.ID, _ index.Query, opts index.QueryOptions, pageToken []byte,
+	) (encoding.SeriesIterators, bool, []byte, error) {
+		requests = append(requests, testFetchTaggedPageRequest{
+			limit:     opts.Limit,
+			pageToken: pageToken,
+		})
+		page := pages[len(requests)-1]
+		if page.err != nil {
+			return nil, false, nil, page.err
+		}
+		iters := make([]encoding.SeriesIterator, page.numSeries)
+		return encoding.NewSeriesIterators(iters, nil), true, page.nextPageToken, nil
+	}
+	return it, &requests
+}
+
+func TestFetchTaggedPageIteratorFetchesAllPages(t *testing.T) {
+	it, requests := newTestFetchTaggedPageIterator(index.QueryOptions{}, 2,
+		[]testFetchTaggedPage{
+			{numSeries: 2, nextPageToken: []byte("a")},
+			{numSeries: 2, nextPageToken: []byte("b")},
+			{numSeries: 1},
+		})
+
+	var lens []int
+	for it.Next() {
+		iters, exhaustive := it.Current()
+		require.True(t, exhaustive)
+		lens = append(lens, iters.Len())
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []int{2, 2, 1}, lens)
+	require.Equal(t, []testFetchTaggedPageRequest{
+		{limit: 2, pageToken: []byte{}},
+		{limit: 2, pageToken: []byte("a")},
+		{limit: 2, pageToken: []byte("b")},
+	}, *requests)
+}
+
+func TestFetchTaggedPageIteratorRespectsLimit(t *testing.T) {
+	it, requests := newTestFetchTaggedPageIterator(index.QueryOptions{Limit: 3}, 2,
+		[]testFetchTaggedPage{
+			{numSeries: 2, nextPageToken: []byte("a")},
+			{numSeries: 1, nextPageToken: []byte("b")},
+		})
+
+	numSeries := 0
+	for it.Next() {
+		iters, _ := it.Current()
+		numSeries += iters.Len()
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, 3, numSeries)
+	require.Equal(t, []testFetchTaggedPageRequest{
+		{limit: 2, pageToken: []byte{}},
+		{limit: 1, pageToken: []byte("a")},
+	}, *requests)
+}
+
+func TestFetchTaggedPageIteratorError(t *testing.T) {
+	expectedErr := errors.New("an error")
+	it, _ := newTestFetchTaggedPageIterator(index.QueryOptions{}, 2,
+		[]testFetchTaggedPage{
+			{numSeries: 2, nextPageToken: []byte("a")},
+			{err: expectedErr},
+		})
+
+	require.True(t, it.Next())
+	require.False(t, it.Next())
+	require.Equal(t, expectedErr, it.Err())
+	require.False(t, it.Next())
+}
@@ -29,8 +29,10 @@ import (
 	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
 	"github.com/m3db/m3/src/dbnode/topology"
 	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
 )
 
 type fetchTaggedResultAccumulatorOpts struct {
@@ -56,6 +58,12 @@ type fetchTaggedResultAccumulator struct {
 	responses  fetchTaggedIDResults
 	exhaustive bool
 
+	// NB(r): for paged requests each host returns results up to its own page
+	// boundary, nextPage tracks the earliest of these boundaries as only the
+	// results before it are known to be complete across all replicas.
+	nextPage    convert.FetchTaggedPagePosition
+	hasNextPage bool
+
 	startTime        time.Time
 	endTime          time.Time
 	majority         int
@@ -99,6 +107,11 @@ func (accum *fetchTaggedResultAccumulator) Add(
 	}
 
 	accum.numHostsPending--
+	if resultErr == nil {
+		// A host returning an undecodable page token is treated as a failed
+		// response so its results are not merged with those of the other hosts.
+		resultErr = accum.addNextPageToken(response.NextPageToken)
+	}
 	if resultErr != nil {
 		accum.errors = append(accum.errors, xerrors.NewRenamedError(resultErr,
 			fmt.Errorf("error fetching tagged from host %s: %v", host.ID(), resultErr)))
@@ -181,6 +194,8 @@ func (accum *fetchTaggedResultAccumulator) Clear() {
 	accum.startTime, accum.endTime = time.Time{}, time.Time{}
 	accum.topoMap = nil
 	accum.exhaustive = true
+	accum.nextPage = convert.FetchTaggedPagePosition{}
+	accum.hasNextPage = false
 }
 
 func (accum *fetchTaggedResultAccumulator) Reset(
@@ -212,6 +227,52 @@ func (accum *fetchTaggedResultAccumulator) Reset(
 	}
 }
 
+func (accum *fetchTaggedResultAccumulator) addNextPageToken(token []byte) error {
+	pos, ok, err := convert.ToFetchTaggedPagePosition(token)
+	if err != nil || !ok {
+		return err
+	}
+	if !accum.hasNextPage || pos.Before(accum.nextPage) {
+		accum.nextPage = pos
+		accum.hasNextPage = true
+	}
+	return nil
+}
+
+// NextPageToken returns the token to request the page following the results
+// accumulated, or nil if every host has returned its last page.
+func (accum *fetchTaggedResultAccumulator) NextPageToken() ([]byte, error) {
+	if !accum.hasNextPage {
+		return nil, nil
+	}
+	return convert.ToFetchTaggedPageToken(accum.nextPage)
+}
+
+// trimToNextPage drops the responses ordered after the next page boundary,
+// these are returned again as part of the next page.
+func (accum *fetchTaggedResultAccumulator) trimToNextPage() {
+	if !accum.hasNextPage {
+		return
+	}
+	shardSet := accum.topoMap.ShardSet()
+	n := 0
+	for _, elem := range accum.responses {
+		pos := convert.FetchTaggedPagePosition{
+			Shard: shardSet.Lookup(ident.BytesID(elem.ID)),
+			ID:    elem.ID,
+		}
+		if accum.nextPage.Before(pos) {
+			continue
+		}
+		accum.responses[n] = elem
+		n++
+	}
+	for i := n; i < len(accum.responses); i++ {
+		accum.responses[i] = nil
+	}
+	accum.responses = accum.responses[:n]
+}
+
 func (accum *fetchTaggedResultAccumulator) sliceResponsesAsSeriesIter(
 	pools fetchTaggedPools,
 	elems fetchTaggedIDResults,
@@ -253,6 +314,7 @@ func (accum *fetchTaggedResultAccumulator) sliceResponsesAsSeriesIter(
 func (accum *fetchTaggedResultAccumulator) AsEncodingSeriesIterators(
 	limit int, pools fetchTaggedPools,
 ) (encoding.SeriesIterators, bool, error) {
+	accum.trimToNextPage()
 	results := fetchTaggedIDResultsSortedByID(accum.responses)
 	sort.Sort(results)
 	accum.responses = fetchTaggedIDResults(results)
@@ -288,6 +350,7 @@ func (accum *fetchTaggedResultAccumulator) AsTaggedIDsIterator(
 		count     = 0
 		moreElems = false
 	)
+	accum.trimToNextPage()
 	results := fetchTaggedIDResultsSortedByID(accum.responses)
 	sort.Sort(results)
 	accum.responses = fetchTaggedIDResults(results)
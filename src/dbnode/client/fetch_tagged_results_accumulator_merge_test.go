@@ -180,6 +180,60 @@ func TestFetchTaggedResultsAccumulatorIdsMergeReportsExhaustiveCorrectly(t *test
 	newTestSerieses(1, 15).assertMatchesEncodingIters(t, iters)
 }
 
+func TestFetchTaggedResultsAccumulatorIdsMergePaged(t *testing.T) {
+	// rf=2, a single shard so results are ordered by ID alone
+	topoMap := testutil.MustNewTopologyMap(2, map[string][]shard.Shard{
+		"testhost0": testutil.ShardsRange(0, 0, shard.Available),
+		"testhost1": testutil.ShardsRange(0, 0, shard.Available),
+	})
+
+	th := newTestFetchTaggedHelper(t)
+	pageToken := func(ts testSeries) []byte {
+		token, err := convert.ToFetchTaggedPageToken(convert.FetchTaggedPagePosition{
+			Shard: 0,
+			ID:    ts.id.Bytes(),
+		})
+		require.NoError(t, err)
+		return token
+	}
+
+	ts1 := newTestSeries(1)
+	ts2 := newTestSeries(2)
+	host0Result := testSerieses{ts1, ts2}.toRPCResult(th, testStartTime, true)
+	host0Result.NextPageToken = pageToken(ts2)
+	host1Result := testSerieses{ts1}.toRPCResult(th, testStartTime, true)
+	host1Result.NextPageToken = pageToken(ts1)
+	workflow := testFetchTaggedWorkflow{
+		t:         t,
+		topoMap:   topoMap,
+		level:     topology.ReadConsistencyLevelAll,
+		startTime: testStartTime,
+		endTime:   testEndTime,
+		steps: []testFetchTaggedWorklowStep{
+			testFetchTaggedWorklowStep{
+				hostname: "testhost0",
+				response: host0Result,
+			},
+			testFetchTaggedWorklowStep{
+				hostname:     "testhost1",
+				response:     host1Result,
+				expectedDone: true,
+			},
+		},
+	}
+	accum := workflow.run()
+
+	// ts2 is past the earliest page boundary so is left for the next page
+	resultsIter, _, err := accum.AsTaggedIDsIterator(10, th.pools)
+	require.NoError(t, err)
+	matcher := MustNewTaggedIDsIteratorMatcher(ts1.matcherOption())
+	require.True(t, matcher.Matches(resultsIter))
+
+	nextPageToken, err := accum.NextPageToken()
+	require.NoError(t, err)
+	require.Equal(t, pageToken(ts1), nextPageToken)
+}
+
 func TestFetchTaggedResultsAccumulatorSeriesItersDatapoints(t *testing.T) {
 	// rf=3, 3 identical hosts, with same shards
 	topoMap := testutil.MustNewTopologyMap(3, map[string][]shard.Shard{
@@ -0,0 +1,463 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+)
+
+const (
+	hintSegmentFilePrefix = "hints-"
+	hintSegmentFileSuffix = ".db"
+
+	// hintSegmentMaxBytes is the size at which the segment being appended to
+	// is sealed and a new segment is started, segments are replayed and
+	// removed whole so this also bounds the memory used to replay hints.
+	hintSegmentMaxBytes = 4 << 20
+
+	// hintRecordHeaderLen is the length of the payload length and checksum
+	// that prefix each hint record.
+	hintRecordHeaderLen = 8
+
+	// hintFlagTagged is set for hints of tagged writes.
+	hintFlagTagged = 1 << 0
+
+	hintsDirMode  = os.ModeDir | os.FileMode(0755)
+	hintsFileMode = os.FileMode(0666)
+)
+
+var (
+	errHintQueueClosed     = errors.New("hint queue is closed")
+	errHintRecordCorrupt   = errors.New("hint record is corrupt")
+	errHintSegmentNotFirst = errors.New("hint segment is not the oldest segment")
+)
+
+// hint is a write that failed to reach a host, the encoded tags of the
+// element are only set and written to the host on replay for tagged writes.
+type hint struct {
+	createdAt time.Time
+	namespace []byte
+	tagged    bool
+	element   rpc.WriteTaggedBatchRawRequestElement
+}
+
+type hintSegment struct {
+	index   uint64
+	path    string
+	bytes   int64
+	modTime time.Time
+}
+
+// hintQueue is a bounded on-disk queue of the hints for a single host. Hints
+// are appended to the newest segment file and are replayed a segment at a
+// time starting with the oldest segment.
+type hintQueue struct {
+	sync.Mutex
+
+	dir      string
+	maxBytes int64
+	nowFn    clock.NowFn
+
+	segments  []hintSegment
+	bytes     int64
+	active    *os.File
+	nextIndex uint64
+	buf       []byte
+	closed    bool
+}
+
+func newHintQueue(
+	dir string,
+	maxBytes int64,
+	nowFn clock.NowFn,
+) (*hintQueue, error) {
+	if err := os.MkdirAll(dir, hintsDirMode); err != nil {
+		return nil, err
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &hintQueue{
+		dir:      dir,
+		maxBytes: maxBytes,
+		nowFn:    nowFn,
+	}
+
+	// NB(r): Segments left by a previous process are all sealed, any hints
+	// appended from now on are written to a new segment.
+	for _, f := range files {
+		index, ok := parseHintSegmentFileName(f.Name())
+		if !ok || f.IsDir() {
+			continue
+		}
+		q.segments = append(q.segments, hintSegment{
+			index:   index,
+			path:    filepath.Join(dir, f.Name()),
+			bytes:   f.Size(),
+			modTime: f.ModTime(),
+		})
+		q.bytes += f.Size()
+	}
+	sort.Slice(q.segments, func(i, j int) bool {
+		return q.segments[i].index < q.segments[j].index
+	})
+	if n := len(q.segments); n > 0 {
+		q.nextIndex = q.segments[n-1].index + 1
+	}
+
+	return q, nil
+}
+
+// Bytes returns the size of the hints persisted.
+func (q *hintQueue) Bytes() int64 {
+	q.Lock()
+	v := q.bytes
+	q.Unlock()
+	return v
+}
+
+// Append persists the hints, returning the number of hints that were dropped
+// as the queue reached its max size.
+func (q *hintQueue) Append(hints []hint) (int, error) {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.closed {
+		return len(hints), errHintQueueClosed
+	}
+
+	dropped := 0
+	q.buf = q.buf[:0]
+	for i := range hints {
+		n := len(q.buf)
+		q.buf = appendHintRecord(q.buf, &hints[i])
+		if q.bytes+int64(len(q.buf)) > q.maxBytes {
+			q.buf = q.buf[:n]
+			dropped = len(hints) - i
+			break
+		}
+	}
+	if len(q.buf) == 0 {
+		return dropped, nil
+	}
+
+	if err := q.writeWithLock(q.buf); err != nil {
+		return len(hints), err
+	}
+	return dropped, nil
+}
+
+func (q *hintQueue) writeWithLock(b []byte) error {
+	if q.active == nil {
+		if err := q.openSegmentWithLock(); err != nil {
+			return err
+		}
+	}
+
+	n, err := q.active.Write(b)
+
+	// NB(r): A partial write still takes up space on disk so is accounted
+	// for, the torn record is skipped when the segment is read back.
+	segment := &q.segments[len(q.segments)-1]
+	segment.bytes += int64(n)
+	segment.modTime = q.nowFn()
+	q.bytes += int64(n)
+	if err != nil {
+		return err
+	}
+
+	if segment.bytes >= hintSegmentMaxBytes {
+		return q.sealWithLock()
+	}
+	return nil
+}
+
+func (q *hintQueue) openSegmentWithLock() error {
+	path := filepath.Join(q.dir, hintSegmentFileName(q.nextIndex))
+	fd, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, hintsFileMode)
+	if err != nil {
+		return err
+	}
+
+	q.segments = append(q.segments, hintSegment{
+		index:   q.nextIndex,
+		path:    path,
+		modTime: q.nowFn(),
+	})
+	q.active = fd
+	q.nextIndex++
+	return nil
+}
+
+func (q *hintQueue) sealWithLock() error {
+	if q.active == nil {
+		return nil
+	}
+	err := q.active.Close()
+	q.active = nil
+	return err
+}
+
+// OldestSegment returns the oldest segment to be replayed, sealing the
+// segment being appended to if it is the only segment.
+func (q *hintQueue) OldestSegment() (hintSegment, bool, error) {
+	q.Lock()
+	defer q.Unlock()
+
+	if len(q.segments) == 0 {
+		return hintSegment{}, false, nil
+	}
+	if len(q.segments) == 1 {
+		if err := q.sealWithLock(); err != nil {
+			return hintSegment{}, false, err
+		}
+	}
+	return q.segments[0], true, nil
+}
+
+// RemoveSegment removes the oldest segment once it has been replayed.
+func (q *hintQueue) RemoveSegment(segment hintSegment) error {
+	q.Lock()
+	defer q.Unlock()
+
+	if len(q.segments) == 0 || q.segments[0].index != segment.index {
+		return errHintSegmentNotFirst
+	}
+	return q.removeFirstWithLock()
+}
+
+// RemoveExpiredSegments removes the segments last appended to before the
+// cutoff, returning the number of segments removed.
+func (q *hintQueue) RemoveExpiredSegments(cutoff time.Time) (int, error) {
+	q.Lock()
+	defer q.Unlock()
+
+	removed := 0
+	for len(q.segments) > 0 && q.segments[0].modTime.Before(cutoff) {
+		if len(q.segments) == 1 {
+			if err := q.sealWithLock(); err != nil {
+				return removed, err
+			}
+		}
+		if err := q.removeFirstWithLock(); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+func (q *hintQueue) removeFirstWithLock() error {
+	segment := q.segments[0]
+	if err := os.Remove(segment.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	q.bytes -= segment.bytes
+	q.segments[0] = hintSegment{}
+	q.segments = q.segments[1:]
+	return nil
+}
+
+// Close closes the queue, hints persisted remain on disk to be replayed
+// when the queue is next opened.
+func (q *hintQueue) Close() error {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.closed {
+		return errHintQueueClosed
+	}
+	q.closed = true
+	return q.sealWithLock()
+}
+
+// readHintSegment reads the hints persisted in a segment. If the segment
+// contains a corrupt record the hints preceding it are returned along with
+// errHintRecordCorrupt.
+func readHintSegment(path string) ([]hint, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var hints []hint
+	for len(b) > 0 {
+		if len(b) < hintRecordHeaderLen {
+			return hints, errHintRecordCorrupt
+		}
+		size := int(binary.BigEndian.Uint32(b))
+		checksum := binary.BigEndian.Uint32(b[4:])
+		b = b[hintRecordHeaderLen:]
+		if size > len(b) || digest.Checksum(b[:size]) != checksum {
+			return hints, errHintRecordCorrupt
+		}
+
+		h, err := decodeHintRecord(b[:size])
+		if err != nil {
+			return hints, err
+		}
+		hints = append(hints, h)
+		b = b[size:]
+	}
+	return hints, nil
+}
+
+func appendHintRecord(b []byte, h *hint) []byte {
+	start := len(b)
+	for i := 0; i < hintRecordHeaderLen; i++ {
+		b = append(b, 0)
+	}
+
+	var flags int64
+	if h.tagged {
+		flags |= hintFlagTagged
+	}
+
+	dp := h.element.Datapoint
+	b = appendHintVarint(b, h.createdAt.UnixNano())
+	b = appendHintVarint(b, flags)
+	b = appendHintBytes(b, h.namespace)
+	b = appendHintBytes(b, h.element.ID)
+	b = appendHintBytes(b, h.element.EncodedTags)
+	b = appendHintVarint(b, dp.Timestamp)
+	b = appendHintVarint(b, int64(dp.TimestampTimeType))
+	b = appendHintVarint(b, int64(math.Float64bits(dp.Value)))
+	b = appendHintBytes(b, dp.Annotation)
+
+	payload := b[start+hintRecordHeaderLen:]
+	binary.BigEndian.PutUint32(b[start:], uint32(len(payload)))
+	binary.BigEndian.PutUint32(b[start+4:], digest.Checksum(payload))
+	return b
+}
+
+func appendHintVarint(b []byte, v int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+func appendHintBytes(b []byte, v []byte) []byte {
+	b = appendHintVarint(b, int64(len(v)))
+	return append(b, v...)
+}
+
+func decodeHintRecord(b []byte) (hint, error) {
+	d := hintDecoder{b: b}
+	createdAt := d.varint()
+	flags := d.varint()
+	namespace := d.bytes()
+	id := d.bytes()
+	encodedTags := d.bytes()
+	timestamp := d.varint()
+	timeType := d.varint()
+	value := d.varint()
+	annotation := d.bytes()
+	if d.err != nil {
+		return hint{}, d.err
+	}
+	if len(annotation) == 0 {
+		annotation = nil
+	}
+
+	return hint{
+		createdAt: time.Unix(0, createdAt),
+		namespace: namespace,
+		tagged:    flags&hintFlagTagged != 0,
+		element: rpc.WriteTaggedBatchRawRequestElement{
+			ID:          id,
+			EncodedTags: encodedTags,
+			Datapoint: &rpc.Datapoint{
+				Timestamp:         timestamp,
+				TimestampTimeType: rpc.TimeType(timeType),
+				Value:             math.Float64frombits(uint64(value)),
+				Annotation:        annotation,
+			},
+		},
+	}, nil
+}
+
+type hintDecoder struct {
+	b   []byte
+	err error
+}
+
+func (d *hintDecoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.b)
+	if n <= 0 {
+		d.err = errHintRecordCorrupt
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *hintDecoder) bytes() []byte {
+	n := d.varint()
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > int64(len(d.b)) {
+		d.err = errHintRecordCorrupt
+		return nil
+	}
+	v := d.b[:n:n]
+	d.b = d.b[n:]
+	return v
+}
+
+func hintSegmentFileName(index uint64) string {
+	return fmt.Sprintf("%s%d%s", hintSegmentFilePrefix, index, hintSegmentFileSuffix)
+}
+
+func parseHintSegmentFileName(name string) (uint64, bool) {
+	if !strings.HasPrefix(name, hintSegmentFilePrefix) ||
+		!strings.HasSuffix(name, hintSegmentFileSuffix) {
+		return 0, false
+	}
+	name = strings.TrimPrefix(name, hintSegmentFilePrefix)
+	name = strings.TrimSuffix(name, hintSegmentFileSuffix)
+	index, err := strconv.ParseUint(name, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return index, true
+}
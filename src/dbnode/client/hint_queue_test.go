@@ -0,0 +1,210 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHintQueue(
+	t *testing.T,
+	maxBytes int64,
+	now *time.Time,
+) (*hintQueue, string) {
+	dir, err := ioutil.TempDir("", "hints")
+	require.NoError(t, err)
+
+	nowFn := func() time.Time { return *now }
+	q, err := newHintQueue(dir, maxBytes, nowFn)
+	require.NoError(t, err)
+	return q, dir
+}
+
+func testHints(createdAt time.Time, ids ...string) []hint {
+	hints := make([]hint, 0, len(ids))
+	for i, id := range ids {
+		hints = append(hints, hint{
+			createdAt: createdAt,
+			namespace: []byte("testNs"),
+			tagged:    true,
+			element: rpc.WriteTaggedBatchRawRequestElement{
+				ID:          []byte(id),
+				EncodedTags: testEncode(map[string]string{"id": id}),
+				Datapoint: &rpc.Datapoint{
+					Timestamp:         int64(1000 + i),
+					TimestampTimeType: rpc.TimeType_UNIX_SECONDS,
+					Value:             float64(i),
+				},
+			},
+		})
+	}
+	return hints
+}
+
+func assertHintsEqual(t *testing.T, expected, actual []hint) {
+	require.Equal(t, len(expected), len(actual))
+	for i := range expected {
+		assert.True(t, expected[i].createdAt.Equal(actual[i].createdAt))
+		assert.Equal(t, expected[i].namespace, actual[i].namespace)
+		assert.Equal(t, expected[i].tagged, actual[i].tagged)
+		assert.Equal(t, expected[i].element, actual[i].element)
+	}
+}
+
+func TestHintQueueAppendAndReplay(t *testing.T) {
+	now := time.Unix(0, time.Now().UnixNano())
+	q, dir := newTestHintQueue(t, 1<<20, &now)
+	defer os.RemoveAll(dir)
+
+	hints := testHints(now, "foo", "bar", "baz")
+	dropped, err := q.Append(hints)
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	assert.True(t, q.Bytes() > 0)
+
+	segment, ok, err := q.OldestSegment()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	replayed, err := readHintSegment(segment.path)
+	require.NoError(t, err)
+	assertHintsEqual(t, hints, replayed)
+
+	require.NoError(t, q.RemoveSegment(segment))
+	assert.Equal(t, int64(0), q.Bytes())
+
+	_, ok, err = q.OldestSegment()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, q.Close())
+}
+
+func TestHintQueueAppendDropsAtMaxBytes(t *testing.T) {
+	now := time.Unix(0, time.Now().UnixNano())
+	hints := testHints(now, "foo", "bar", "baz")
+	recordBytes := len(appendHintRecord(nil, &hints[0]))
+
+	q, dir := newTestHintQueue(t, int64(recordBytes), &now)
+	defer os.RemoveAll(dir)
+
+	dropped, err := q.Append(hints)
+	require.NoError(t, err)
+	assert.Equal(t, 2, dropped)
+	assert.Equal(t, int64(recordBytes), q.Bytes())
+
+	dropped, err = q.Append(hints[1:])
+	require.NoError(t, err)
+	assert.Equal(t, 2, dropped)
+
+	require.NoError(t, q.Close())
+}
+
+func TestHintQueueReopenRecoversHints(t *testing.T) {
+	now := time.Unix(0, time.Now().UnixNano())
+	q, dir := newTestHintQueue(t, 1<<20, &now)
+	defer os.RemoveAll(dir)
+
+	first := testHints(now, "foo", "bar")
+	_, err := q.Append(first)
+	require.NoError(t, err)
+	bytes := q.Bytes()
+	require.NoError(t, q.Close())
+
+	q, err = newHintQueue(dir, 1<<20, func() time.Time { return now })
+	require.NoError(t, err)
+	assert.Equal(t, bytes, q.Bytes())
+
+	// Appends after reopening go to a new segment.
+	second := testHints(now, "baz")
+	_, err = q.Append(second)
+	require.NoError(t, err)
+
+	for _, expected := range [][]hint{first, second} {
+		segment, ok, err := q.OldestSegment()
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		replayed, err := readHintSegment(segment.path)
+		require.NoError(t, err)
+		assertHintsEqual(t, expected, replayed)
+		require.NoError(t, q.RemoveSegment(segment))
+	}
+
+	require.NoError(t, q.Close())
+}
+
+func TestHintQueueReadCorruptRecord(t *testing.T) {
+	now := time.Unix(0, time.Now().UnixNano())
+	q, dir := newTestHintQueue(t, 1<<20, &now)
+	defer os.RemoveAll(dir)
+
+	hints := testHints(now, "foo", "bar")
+	_, err := q.Append(hints)
+	require.NoError(t, err)
+
+	segment, ok, err := q.OldestSegment()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Tear the last record.
+	require.NoError(t, os.Truncate(segment.path, segment.bytes-1))
+
+	replayed, err := readHintSegment(segment.path)
+	assert.Equal(t, errHintRecordCorrupt, err)
+	assertHintsEqual(t, hints[:1], replayed)
+
+	require.NoError(t, q.Close())
+}
+
+func TestHintQueueRemoveExpiredSegments(t *testing.T) {
+	start := time.Unix(0, time.Now().UnixNano())
+	now := start
+	q, dir := newTestHintQueue(t, 1<<20, &now)
+	defer os.RemoveAll(dir)
+
+	_, err := q.Append(testHints(now, "foo"))
+	require.NoError(t, err)
+
+	removed, err := q.RemoveExpiredSegments(start)
+	require.NoError(t, err)
+	assert.Equal(t, 0, removed)
+
+	now = start.Add(time.Minute)
+	removed, err = q.RemoveExpiredSegments(now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, int64(0), q.Bytes())
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(files))
+
+	require.NoError(t, q.Close())
+}
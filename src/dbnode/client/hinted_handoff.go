@@ -0,0 +1,456 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/topology"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+	xlog "github.com/m3db/m3x/log"
+
+	"github.com/uber-go/tally"
+	"github.com/uber/tchannel-go/thrift"
+)
+
+var (
+	errHintedHandoffAlreadyOpen = errors.New("hinted handoff is already open")
+	errHintedHandoffNotOpen     = errors.New("hinted handoff is not open")
+)
+
+type addHintsFn func(
+	host topology.Host,
+	namespace ident.ID,
+	elems []*rpc.WriteTaggedBatchRawRequestElement,
+)
+
+type addUntaggedHintsFn func(
+	host topology.Host,
+	namespace ident.ID,
+	elems []*rpc.WriteBatchRawRequestElement,
+)
+
+// healthyHostQueueFn returns the host queue for a host if the host is part
+// of the current topology and has healthy connections.
+type healthyHostQueueFn func(hostID string) (hostQueue, bool)
+
+type hintedHandoffMetrics struct {
+	hintsWritten      tally.Counter
+	hintsDropped      tally.Counter
+	hintsExpired      tally.Counter
+	hintsReplayed     tally.Counter
+	hintsReplayErrors tally.Counter
+	writeErrors       tally.Counter
+	replayErrors      tally.Counter
+	segmentsExpired   tally.Counter
+	segmentsCorrupt   tally.Counter
+	backlogBytes      tally.Gauge
+	backlogHosts      tally.Gauge
+}
+
+func newHintedHandoffMetrics(scope tally.Scope) hintedHandoffMetrics {
+	return hintedHandoffMetrics{
+		hintsWritten:      scope.Counter("hints-written"),
+		hintsDropped:      scope.Counter("hints-dropped"),
+		hintsExpired:      scope.Counter("hints-expired"),
+		hintsReplayed:     scope.Counter("hints-replayed"),
+		hintsReplayErrors: scope.Counter("hints-replay-errors"),
+		writeErrors:       scope.Counter("write-errors"),
+		replayErrors:      scope.Counter("replay-errors"),
+		segmentsExpired:   scope.Counter("segments-expired"),
+		segmentsCorrupt:   scope.Counter("segments-corrupt"),
+		backlogBytes:      scope.Gauge("backlog-bytes"),
+		backlogHosts:      scope.Gauge("backlog-hosts"),
+	}
+}
+
+// hintedHandoff persists the writes that fail to reach a host as hints
+// in a bounded on-disk queue per host, and replays them to the host once it
+// is healthy again.
+type hintedHandoff struct {
+	sync.RWMutex
+
+	opts               HintedHandoffOptions
+	nowFn              clock.NowFn
+	log                xlog.Logger
+	writeBatchSize     int
+	writeTimeout       time.Duration
+	healthyHostQueueFn healthyHostQueueFn
+
+	queues  map[string]*hintQueue
+	status  status
+	closeCh chan struct{}
+	doneCh  chan struct{}
+	metrics hintedHandoffMetrics
+}
+
+func newHintedHandoff(
+	opts Options,
+	healthyHostQueueFn healthyHostQueueFn,
+) *hintedHandoff {
+	scope := opts.InstrumentOptions().MetricsScope().SubScope("hinted-handoff")
+	return &hintedHandoff{
+		opts:               opts.HintedHandoffOptions(),
+		nowFn:              opts.ClockOptions().NowFn(),
+		log:                opts.InstrumentOptions().Logger(),
+		writeBatchSize:     opts.WriteBatchSize(),
+		writeTimeout:       opts.WriteRequestTimeout(),
+		healthyHostQueueFn: healthyHostQueueFn,
+		queues:             make(map[string]*hintQueue),
+		closeCh:            make(chan struct{}),
+		doneCh:             make(chan struct{}),
+		metrics:            newHintedHandoffMetrics(scope),
+	}
+}
+
+// Open recovers the hints persisted by a previous session and begins
+// replaying hints to hosts in the background.
+func (h *hintedHandoff) Open() error {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.status != statusNotOpen {
+		return errHintedHandoffAlreadyOpen
+	}
+
+	dir := h.opts.Directory()
+	if err := os.MkdirAll(dir, hintsDirMode); err != nil {
+		return err
+	}
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		hostID, err := url.PathUnescape(entry.Name())
+		if err != nil {
+			continue
+		}
+		q, err := newHintQueue(filepath.Join(dir, entry.Name()),
+			h.opts.MaxHintsBytes(), h.nowFn)
+		if err != nil {
+			return err
+		}
+		h.queues[hostID] = q
+	}
+
+	h.status = statusOpen
+	go h.replayEvery(h.opts.ReplayInterval())
+	return nil
+}
+
+// AddHints persists the tagged writes that failed to reach a host so they
+// can be replayed to the host once it is healthy again.
+func (h *hintedHandoff) AddHints(
+	host topology.Host,
+	namespace ident.ID,
+	elems []*rpc.WriteTaggedBatchRawRequestElement,
+) {
+	var (
+		now   = h.nowFn()
+		hints = make([]hint, 0, len(elems))
+	)
+	for _, elem := range elems {
+		hints = append(hints, hint{
+			createdAt: now,
+			namespace: namespace.Bytes(),
+			tagged:    true,
+			element:   *elem,
+		})
+	}
+	h.addHints(host, hints)
+}
+
+// AddUntaggedHints persists the untagged writes that failed to reach a host
+// so they can be replayed to the host once it is healthy again.
+func (h *hintedHandoff) AddUntaggedHints(
+	host topology.Host,
+	namespace ident.ID,
+	elems []*rpc.WriteBatchRawRequestElement,
+) {
+	var (
+		now   = h.nowFn()
+		hints = make([]hint, 0, len(elems))
+	)
+	for _, elem := range elems {
+		hints = append(hints, hint{
+			createdAt: now,
+			namespace: namespace.Bytes(),
+			element: rpc.WriteTaggedBatchRawRequestElement{
+				ID:        elem.ID,
+				Datapoint: elem.Datapoint,
+			},
+		})
+	}
+	h.addHints(host, hints)
+}
+
+func (h *hintedHandoff) addHints(host topology.Host, hints []hint) {
+	q, err := h.queue(host.ID())
+	if err != nil {
+		h.metrics.writeErrors.Inc(1)
+		h.log.Errorf("unable to open hints queue for host %s: %v", host.ID(), err)
+		return
+	}
+
+	dropped, err := q.Append(hints)
+	if err != nil {
+		h.metrics.writeErrors.Inc(1)
+		h.log.Errorf("unable to persist hints for host %s: %v", host.ID(), err)
+		return
+	}
+	h.metrics.hintsWritten.Inc(int64(len(hints) - dropped))
+	h.metrics.hintsDropped.Inc(int64(dropped))
+}
+
+func (h *hintedHandoff) queue(hostID string) (*hintQueue, error) {
+	h.RLock()
+	q, ok := h.queues[hostID]
+	status := h.status
+	h.RUnlock()
+	if status != statusOpen {
+		return nil, errHintedHandoffNotOpen
+	}
+	if ok {
+		return q, nil
+	}
+
+	h.Lock()
+	defer h.Unlock()
+
+	if h.status != statusOpen {
+		return nil, errHintedHandoffNotOpen
+	}
+	if q, ok := h.queues[hostID]; ok {
+		return q, nil
+	}
+	dir := filepath.Join(h.opts.Directory(), url.PathEscape(hostID))
+	q, err := newHintQueue(dir, h.opts.MaxHintsBytes(), h.nowFn)
+	if err != nil {
+		return nil, err
+	}
+	h.queues[hostID] = q
+	return q, nil
+}
+
+func (h *hintedHandoff) replayEvery(interval time.Duration) {
+	defer close(h.doneCh)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.closeCh:
+			return
+		case <-ticker.C:
+			h.replay()
+		}
+	}
+}
+
+func (h *hintedHandoff) replay() {
+	h.RLock()
+	queues := make(map[string]*hintQueue, len(h.queues))
+	for hostID, q := range h.queues {
+		queues[hostID] = q
+	}
+	h.RUnlock()
+
+	var (
+		backlogBytes int64
+		backlogHosts int
+	)
+	for hostID, q := range queues {
+		if err := h.replayHost(hostID, q); err != nil {
+			h.metrics.replayErrors.Inc(1)
+			h.log.Warnf("unable to replay hints to host %s: %v", hostID, err)
+		}
+		if v := q.Bytes(); v > 0 {
+			backlogBytes += v
+			backlogHosts++
+		}
+	}
+	h.metrics.backlogBytes.Update(float64(backlogBytes))
+	h.metrics.backlogHosts.Update(float64(backlogHosts))
+}
+
+func (h *hintedHandoff) replayHost(hostID string, q *hintQueue) error {
+	// NB(r): Segments last appended to before the cutoff only hold expired
+	// hints so are removed without being read, regardless of host health.
+	cutoff := h.nowFn().Add(-h.opts.MaxHintAge())
+	expired, err := q.RemoveExpiredSegments(cutoff)
+	h.metrics.segmentsExpired.Inc(int64(expired))
+	if err != nil {
+		return err
+	}
+
+	hostQueue, ok := h.healthyHostQueueFn(hostID)
+	if !ok {
+		return nil
+	}
+
+	for {
+		select {
+		case <-h.closeCh:
+			return nil
+		default:
+		}
+
+		segment, ok, err := q.OldestSegment()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+
+		hints, err := readHintSegment(segment.path)
+		if err == errHintRecordCorrupt {
+			// Replay the hints that precede the corrupt record.
+			h.metrics.segmentsCorrupt.Inc(1)
+		} else if err != nil {
+			return err
+		}
+
+		if err := h.replayHints(hostQueue, hints, cutoff); err != nil {
+			return err
+		}
+		if err := q.RemoveSegment(segment); err != nil {
+			return err
+		}
+	}
+}
+
+func (h *hintedHandoff) replayHints(
+	hostQueue hostQueue,
+	hints []hint,
+	cutoff time.Time,
+) error {
+	var (
+		namespace []byte
+		tagged    bool
+		elems     = make([]*rpc.WriteTaggedBatchRawRequestElement, 0, h.writeBatchSize)
+	)
+	for i := range hints {
+		hint := &hints[i]
+		if hint.createdAt.Before(cutoff) {
+			h.metrics.hintsExpired.Inc(1)
+			continue
+		}
+		if !bytes.Equal(hint.namespace, namespace) || hint.tagged != tagged ||
+			len(elems) == h.writeBatchSize {
+			if err := h.writeBatch(hostQueue, namespace, tagged, elems); err != nil {
+				return err
+			}
+			namespace, tagged = hint.namespace, hint.tagged
+			elems = elems[:0]
+		}
+		elems = append(elems, &hint.element)
+	}
+	return h.writeBatch(hostQueue, namespace, tagged, elems)
+}
+
+func (h *hintedHandoff) writeBatch(
+	hostQueue hostQueue,
+	namespace []byte,
+	tagged bool,
+	elems []*rpc.WriteTaggedBatchRawRequestElement,
+) error {
+	if len(elems) == 0 {
+		return nil
+	}
+
+	var writeErr error
+	if err := hostQueue.BorrowConnection(func(c rpc.TChanNode) {
+		ctx, _ := thrift.NewContext(h.writeTimeout)
+		if tagged {
+			writeErr = c.WriteTaggedBatchRaw(ctx, &rpc.WriteTaggedBatchRawRequest{
+				NameSpace: namespace,
+				Elements:  elems,
+			})
+			return
+		}
+		untaggedElems := make([]*rpc.WriteBatchRawRequestElement, 0, len(elems))
+		for _, elem := range elems {
+			untaggedElems = append(untaggedElems, &rpc.WriteBatchRawRequestElement{
+				ID:        elem.ID,
+				Datapoint: elem.Datapoint,
+			})
+		}
+		writeErr = c.WriteBatchRaw(ctx, &rpc.WriteBatchRawRequest{
+			NameSpace: namespace,
+			Elements:  untaggedElems,
+		})
+	}); err != nil {
+		return err
+	}
+
+	if writeErr == nil {
+		h.metrics.hintsReplayed.Inc(int64(len(elems)))
+		return nil
+	}
+	if batchErrs, ok := writeErr.(*rpc.WriteBatchRawErrors); ok {
+		// NB(r): The host was reachable so these errors are specific to the
+		// individual writes and would not succeed if retried.
+		h.metrics.hintsReplayed.Inc(int64(len(elems) - len(batchErrs.Errors)))
+		h.metrics.hintsReplayErrors.Inc(int64(len(batchErrs.Errors)))
+		return nil
+	}
+	return writeErr
+}
+
+// Close stops replaying hints, hints not yet replayed remain on disk and are
+// recovered when hinted handoff is next opened.
+func (h *hintedHandoff) Close() error {
+	h.Lock()
+	if h.status != statusOpen {
+		h.Unlock()
+		return errHintedHandoffNotOpen
+	}
+	h.status = statusClosed
+	close(h.closeCh)
+	queues := h.queues
+	h.Unlock()
+
+	<-h.doneCh
+
+	multiErr := xerrors.NewMultiError()
+	for _, q := range queues {
+		if err := q.Close(); err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+	return multiErr.FinalError()
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// defaultHintedHandoffMaxHintAge is the default max age of a hint
+	defaultHintedHandoffMaxHintAge = 3 * time.Hour
+
+	// defaultHintedHandoffMaxHintsBytes is the default max size of the hints
+	// persisted for a single host
+	defaultHintedHandoffMaxHintsBytes = 1 << 30
+
+	// defaultHintedHandoffReplayInterval is the default interval at which
+	// hints are replayed to hosts
+	defaultHintedHandoffReplayInterval = 10 * time.Second
+)
+
+var (
+	errHintedHandoffNoDirectory    = errors.New("hinted handoff enabled without a hints directory")
+	errHintedHandoffMaxHintAge     = errors.New("hinted handoff max hint age must be positive")
+	errHintedHandoffMaxHintsBytes  = errors.New("hinted handoff max hints bytes must be positive")
+	errHintedHandoffReplayInterval = errors.New("hinted handoff replay interval must be positive")
+)
+
+type hintedHandoffOptions struct {
+	enabled        bool
+	directory      string
+	maxHintAge     time.Duration
+	maxHintsBytes  int64
+	replayInterval time.Duration
+}
+
+// NewHintedHandoffOptions creates a new set of hinted handoff options with
+// defaults, hinted handoff is disabled by default.
+func NewHintedHandoffOptions() HintedHandoffOptions {
+	return &hintedHandoffOptions{
+		maxHintAge:     defaultHintedHandoffMaxHintAge,
+		maxHintsBytes:  defaultHintedHandoffMaxHintsBytes,
+		replayInterval: defaultHintedHandoffReplayInterval,
+	}
+}
+
+func (o *hintedHandoffOptions) Validate() error {
+	if !o.enabled {
+		return nil
+	}
+	if o.directory == "" {
+		return errHintedHandoffNoDirectory
+	}
+	if o.maxHintAge <= 0 {
+		return errHintedHandoffMaxHintAge
+	}
+	if o.maxHintsBytes <= 0 {
+		return errHintedHandoffMaxHintsBytes
+	}
+	if o.replayInterval <= 0 {
+		return errHintedHandoffReplayInterval
+	}
+	return nil
+}
+
+func (o *hintedHandoffOptions) SetEnabled(value bool) HintedHandoffOptions {
+	opts := *o
+	opts.enabled = value
+	return &opts
+}
+
+func (o *hintedHandoffOptions) Enabled() bool {
+	return o.enabled
+}
+
+func (o *hintedHandoffOptions) SetDirectory(value string) HintedHandoffOptions {
+	opts := *o
+	opts.directory = value
+	return &opts
+}
+
+func (o *hintedHandoffOptions) Directory() string {
+	return o.directory
+}
+
+func (o *hintedHandoffOptions) SetMaxHintAge(value time.Duration) HintedHandoffOptions {
+	opts := *o
+	opts.maxHintAge = value
+	return &opts
+}
+
+func (o *hintedHandoffOptions) MaxHintAge() time.Duration {
+	return o.maxHintAge
+}
+
+func (o *hintedHandoffOptions) SetMaxHintsBytes(value int64) HintedHandoffOptions {
+	opts := *o
+	opts.maxHintsBytes = value
+	return &opts
+}
+
+func (o *hintedHandoffOptions) MaxHintsBytes() int64 {
+	return o.maxHintsBytes
+}
+
+func (o *hintedHandoffOptions) SetReplayInterval(value time.Duration) HintedHandoffOptions {
+	opts := *o
+	opts.replayInterval = value
+	return &opts
+}
+
+func (o *hintedHandoffOptions) ReplayInterval() time.Duration {
+	return o.replayInterval
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/tchannel-go/thrift"
+)
+
+func newTestHintedHandoff(
+	t *testing.T,
+	dir string,
+	now *time.Time,
+	healthyHostQueueFn healthyHostQueueFn,
+) *hintedHandoff {
+	opts := newSessionTestOptions()
+	opts = opts.
+		SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+			return *now
+		})).
+		SetHintedHandoffOptions(NewHintedHandoffOptions().
+			SetEnabled(true).
+			SetDirectory(dir).
+			SetMaxHintAge(time.Hour).
+			SetReplayInterval(time.Hour))
+	require.NoError(t, opts.HintedHandoffOptions().Validate())
+
+	return newHintedHandoff(opts, healthyHostQueueFn)
+}
+
+func testHintElements(ids ...string) []*rpc.WriteTaggedBatchRawRequestElement {
+	hints := testHints(time.Time{}, ids...)
+	elems := make([]*rpc.WriteTaggedBatchRawRequestElement, 0, len(hints))
+	for i := range hints {
+		elems = append(elems, &hints[i].element)
+	}
+	return elems
+}
+
+func TestHintedHandoffReplaysToHealthyHost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		now           = time.Now()
+		healthy       = false
+		mockHostQueue = NewMockhostQueue(ctrl)
+	)
+	dir, err := ioutil.TempDir("", "hinted-handoff")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	h := newTestHintedHandoff(t, dir, &now, func(hostID string) (hostQueue, bool) {
+		assert.Equal(t, testHost.ID(), hostID)
+		return mockHostQueue, healthy
+	})
+
+	require.NoError(t, h.Open())
+
+	elems := testHintElements("foo", "bar", "baz")
+	h.AddHints(testHost, ident.StringID("testNs"), elems)
+
+	// Hints are retained while the host is unhealthy.
+	h.replay()
+	q, err := h.queue(testHost.ID())
+	require.NoError(t, err)
+	assert.True(t, q.Bytes() > 0)
+
+	// Hints are replayed once the host is healthy.
+	healthy = true
+	client := rpc.NewMockTChanNode(ctrl)
+	client.EXPECT().WriteTaggedBatchRaw(gomock.Any(), gomock.Any()).
+		Do(func(ctx thrift.Context, req *rpc.WriteTaggedBatchRawRequest) {
+			assert.Equal(t, []byte("testNs"), req.NameSpace)
+			assert.Equal(t, elems, req.Elements)
+		}).
+		Return(nil)
+	mockHostQueue.EXPECT().BorrowConnection(gomock.Any()).
+		Do(func(fn withConnectionFn) {
+			fn(client)
+		}).
+		Return(nil)
+
+	h.replay()
+	assert.Equal(t, int64(0), q.Bytes())
+
+	require.NoError(t, h.Close())
+}
+
+func TestHintedHandoffReplaysUntaggedWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		now           = time.Now()
+		mockHostQueue = NewMockhostQueue(ctrl)
+	)
+	dir, err := ioutil.TempDir("", "hinted-handoff")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	h := newTestHintedHandoff(t, dir, &now, func(hostID string) (hostQueue, bool) {
+		return mockHostQueue, true
+	})
+
+	require.NoError(t, h.Open())
+
+	var untaggedElems []*rpc.WriteBatchRawRequestElement
+	for _, elem := range testHintElements("foo", "bar") {
+		untaggedElems = append(untaggedElems, &rpc.WriteBatchRawRequestElement{
+			ID:        elem.ID,
+			Datapoint: elem.Datapoint,
+		})
+	}
+	taggedElems := testHintElements("baz")
+	h.AddUntaggedHints(testHost, ident.StringID("testNs"), untaggedElems)
+	h.AddHints(testHost, ident.StringID("testNs"), taggedElems)
+
+	// Untagged hints are replayed as untagged writes so the series are not
+	// indexed, and tagged hints are replayed in a separate batch.
+	client := rpc.NewMockTChanNode(ctrl)
+	gomock.InOrder(
+		client.EXPECT().WriteBatchRaw(gomock.Any(), gomock.Any()).
+			Do(func(ctx thrift.Context, req *rpc.WriteBatchRawRequest) {
+				assert.Equal(t, []byte("testNs"), req.NameSpace)
+				assert.Equal(t, untaggedElems, req.Elements)
+			}).
+			Return(nil),
+		client.EXPECT().WriteTaggedBatchRaw(gomock.Any(), gomock.Any()).
+			Do(func(ctx thrift.Context, req *rpc.WriteTaggedBatchRawRequest) {
+				assert.Equal(t, []byte("testNs"), req.NameSpace)
+				assert.Equal(t, taggedElems, req.Elements)
+			}).
+			Return(nil),
+	)
+	mockHostQueue.EXPECT().BorrowConnection(gomock.Any()).
+		Do(func(fn withConnectionFn) {
+			fn(client)
+		}).
+		Return(nil).
+		Times(2)
+
+	h.replay()
+	q, err := h.queue(testHost.ID())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), q.Bytes())
+
+	require.NoError(t, h.Close())
+}
+
+func TestHintedHandoffRetainsHintsOnReplayError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		now           = time.Now()
+		mockHostQueue = NewMockhostQueue(ctrl)
+	)
+	dir, err := ioutil.TempDir("", "hinted-handoff")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	h := newTestHintedHandoff(t, dir, &now, func(hostID string) (hostQueue, bool) {
+		return mockHostQueue, true
+	})
+
+	require.NoError(t, h.Open())
+	h.AddHints(testHost, ident.StringID("testNs"), testHintElements("foo"))
+
+	client := rpc.NewMockTChanNode(ctrl)
+	client.EXPECT().WriteTaggedBatchRaw(gomock.Any(), gomock.Any()).
+		Return(errors.New("an error"))
+	mockHostQueue.EXPECT().BorrowConnection(gomock.Any()).
+		Do(func(fn withConnectionFn) {
+			fn(client)
+		}).
+		Return(nil)
+
+	h.replay()
+	q, err := h.queue(testHost.ID())
+	require.NoError(t, err)
+	bytes := q.Bytes()
+	assert.True(t, bytes > 0)
+	require.NoError(t, h.Close())
+
+	// Hints not yet replayed are recovered when reopened.
+	h = newTestHintedHandoff(t, dir, &now, nil)
+	require.NoError(t, h.Open())
+	q, err = h.queue(testHost.ID())
+	require.NoError(t, err)
+	assert.Equal(t, bytes, q.Bytes())
+	require.NoError(t, h.Close())
+}
+
+func TestHintedHandoffDropsExpiredHints(t *testing.T) {
+	now := time.Now()
+	dir, err := ioutil.TempDir("", "hinted-handoff")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	h := newTestHintedHandoff(t, dir, &now, func(hostID string) (hostQueue, bool) {
+		return nil, false
+	})
+
+	require.NoError(t, h.Open())
+	h.AddHints(testHost, ident.StringID("testNs"), testHintElements("foo"))
+
+	now = now.Add(2 * time.Hour)
+	h.replay()
+
+	q, err := h.queue(testHost.ID())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), q.Bytes())
+
+	require.NoError(t, h.Close())
+}
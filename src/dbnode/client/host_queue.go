@@ -50,6 +50,8 @@ type queue struct {
 	writeBatchRawRequestElementArrayPool       writeBatchRawRequestElementArrayPool
 	writeTaggedBatchRawRequestPool             writeTaggedBatchRawRequestPool
 	writeTaggedBatchRawRequestElementArrayPool writeTaggedBatchRawRequestElementArrayPool
+	addHintsFn                                 addHintsFn
+	addUntaggedHintsFn                         addUntaggedHintsFn
 	workerPool                                 xsync.PooledWorkerPool
 	size                                       int
 	ops                                        []op
@@ -111,11 +113,13 @@ func newHostQueue(
 		writeBatchRawRequestElementArrayPool:       hostQueueOpts.writeBatchRawRequestElementArrayPool,
 		writeTaggedBatchRawRequestPool:             hostQueueOpts.writeTaggedBatchRawRequestPool,
 		writeTaggedBatchRawRequestElementArrayPool: hostQueueOpts.writeTaggedBatchRawRequestElementArrayPool,
-		workerPool:   workerPool,
-		size:         size,
-		ops:          opArrayPool.Get(),
-		opsArrayPool: opArrayPool,
-		drainIn:      make(chan []op, opsArraysLen),
+		addHintsFn:         hostQueueOpts.addHintsFn,
+		addUntaggedHintsFn: hostQueueOpts.addUntaggedHintsFn,
+		workerPool:         workerPool,
+		size:               size,
+		ops:                opArrayPool.Get(),
+		opsArrayPool:       opArrayPool,
+		drainIn:            make(chan []op, opsArraysLen),
 	}, nil
 }
 
@@ -257,8 +261,12 @@ func (q *queue) drain() {
 				q.asyncFetch(v)
 			case *fetchTaggedOp:
 				q.asyncFetchTagged(v)
+			case *aggregateOp:
+				q.asyncAggregate(v)
 			case *truncateOp:
 				q.asyncTruncate(v)
+			case *deleteTaggedOp:
+				q.asyncDeleteTagged(v)
 			default:
 				completionFn := ops[i].CompletionFn()
 				completionFn(nil, errQueueUnknownOperation(q.host.ID()))
@@ -325,6 +333,7 @@ func (q *queue) asyncTaggedWrite(
 		client, err := q.connPool.NextClient()
 		if err != nil {
 			// No client available
+			q.addHints(namespace, elems, err)
 			callAllCompletionFns(ops, q.host, err)
 			cleanup()
 			return
@@ -359,11 +368,39 @@ func (q *queue) asyncTaggedWrite(
 		}
 
 		// Entire batch failed
+		q.addHints(namespace, elems, err)
 		callAllCompletionFns(ops, q.host, err)
 		cleanup()
 	})
 }
 
+// addHints records the tagged writes that failed to reach the host so they
+// can be replayed once the host is healthy again, writes rejected by the host
+// as bad requests would fail again on replay so are not recorded.
+func (q *queue) addHints(
+	namespace ident.ID,
+	elems []*rpc.WriteTaggedBatchRawRequestElement,
+	err error,
+) {
+	if q.addHintsFn == nil || IsBadRequestError(err) {
+		return
+	}
+	q.addHintsFn(q.host, namespace, elems)
+}
+
+// addUntaggedHints records the untagged writes that failed to reach the host
+// in the same way as addHints.
+func (q *queue) addUntaggedHints(
+	namespace ident.ID,
+	elems []*rpc.WriteBatchRawRequestElement,
+	err error,
+) {
+	if q.addUntaggedHintsFn == nil || IsBadRequestError(err) {
+		return
+	}
+	q.addUntaggedHintsFn(q.host, namespace, elems)
+}
+
 func (q *queue) asyncWrite(
 	namespace ident.ID,
 	ops []op,
@@ -389,6 +426,7 @@ func (q *queue) asyncWrite(
 		client, err := q.connPool.NextClient()
 		if err != nil {
 			// No client available
+			q.addUntaggedHints(namespace, elems, err)
 			callAllCompletionFns(ops, q.host, err)
 			cleanup()
 			return
@@ -423,6 +461,7 @@ func (q *queue) asyncWrite(
 		}
 
 		// Entire batch failed
+		q.addUntaggedHints(namespace, elems, err)
 		callAllCompletionFns(ops, q.host, err)
 		cleanup()
 	})
@@ -505,6 +544,36 @@ func (q *queue) asyncFetchTagged(op *fetchTaggedOp) {
 	})
 }
 
+func (q *queue) asyncAggregate(op *aggregateOp) {
+	q.Add(1)
+
+	q.workerPool.Go(func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(aggregateResultAccumulatorOpts{host: q.host}, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.FetchRequestTimeout())
+		result, err := client.AggregateQuery(ctx, &op.request)
+		if err != nil {
+			op.completionFn(aggregateResultAccumulatorOpts{host: q.host}, err)
+			cleanup()
+			return
+		}
+
+		op.completionFn(aggregateResultAccumulatorOpts{
+			host:     q.host,
+			response: result,
+		}, nil)
+		cleanup()
+	})
+}
+
 func (q *queue) asyncTruncate(op *truncateOp) {
 	q.Add(1)
 
@@ -530,6 +599,33 @@ func (q *queue) asyncTruncate(op *truncateOp) {
 	})
 }
 
+func (q *queue) asyncDeleteTagged(op *deleteTaggedOp) {
+	q.Add(1)
+
+	q.workerPool.Go(func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		// NB: Deletes share the truncate timeout as both are administrative
+		// operations that touch every matching series on the host.
+		ctx, _ := thrift.NewContext(q.opts.TruncateRequestTimeout())
+		if res, err := client.DeleteTagged(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	})
+}
+
 func (q *queue) Len() int {
 	q.RLock()
 	v := q.opsSumSize
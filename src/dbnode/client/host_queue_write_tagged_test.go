@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3x/ident"
 
 	"github.com/golang/mock/gomock"
@@ -409,6 +410,76 @@ func TestHostQueueWriteTaggedBatchesEntireBatchErr(t *testing.T) {
 	closeWg.Wait()
 }
 
+func TestHostQueueWriteTaggedBatchesEntireBatchErrAddsHints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConnPool := NewMockconnectionPool(ctrl)
+
+	opts := newHostQueueTestOptions()
+	opts = opts.SetHostQueueOpsFlushSize(2)
+	queue := newTestHostQueue(opts)
+	queue.connPool = mockConnPool
+
+	var (
+		hintedNamespace ident.ID
+		hintedIDs       []string
+	)
+	queue.addHintsFn = func(
+		host topology.Host,
+		namespace ident.ID,
+		elems []*rpc.WriteTaggedBatchRawRequestElement,
+	) {
+		assert.Equal(t, queue.host, host)
+		hintedNamespace = namespace
+		for _, elem := range elems {
+			hintedIDs = append(hintedIDs, string(elem.ID))
+		}
+	}
+
+	// Open
+	mockConnPool.EXPECT().Open()
+	queue.Open()
+	assert.Equal(t, statusOpen, queue.status)
+
+	// Prepare writes
+	var wg sync.WaitGroup
+	writeErr := fmt.Errorf("an error")
+	callback := func(r interface{}, err error) {
+		assert.Equal(t, writeErr, err)
+		wg.Done()
+	}
+	writes := []*writeTaggedOperation{
+		testWriteTaggedOp("testNs", "foo", map[string]string{"abc": "def"}, 1.0, 1000, rpc.TimeType_UNIX_SECONDS, callback),
+		testWriteTaggedOp("testNs", "bar", map[string]string{"ghi": "klm"}, 2.0, 2000, rpc.TimeType_UNIX_SECONDS, callback),
+	}
+	wg.Add(len(writes))
+
+	// Prepare mocks for flush
+	mockClient := rpc.NewMockTChanNode(ctrl)
+	mockClient.EXPECT().WriteTaggedBatchRaw(gomock.Any(), gomock.Any()).Return(writeErr)
+	mockConnPool.EXPECT().NextClient().Return(mockClient, nil)
+
+	// Perform writes
+	for _, write := range writes {
+		assert.NoError(t, queue.Enqueue(write))
+	}
+
+	// Wait for flush, hints are added before writes are called back
+	wg.Wait()
+	assert.Equal(t, "testNs", hintedNamespace.String())
+	assert.Equal(t, []string{"foo", "bar"}, hintedIDs)
+
+	// Close
+	var closeWg sync.WaitGroup
+	closeWg.Add(1)
+	mockConnPool.EXPECT().Close().Do(func() {
+		closeWg.Done()
+	})
+	queue.Close()
+	closeWg.Wait()
+}
+
 func TestHostQueueDrainOnCloseTaggedWrite(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -30,6 +30,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/encoding/valuetype"
 	m3dbruntime "github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/x/serialize"
@@ -232,6 +233,7 @@ type options struct {
 	fetchSeriesBlocksMetadataBatchTimeout   time.Duration
 	fetchSeriesBlocksBatchTimeout           time.Duration
 	fetchSeriesBlocksBatchConcurrency       int
+	hintedHandoffOpts                       HintedHandoffOptions
 }
 
 // NewOptions creates a new set of client options with defaults
@@ -310,6 +312,7 @@ func newOptions() *options {
 		fetchSeriesBlocksMetadataBatchTimeout:   defaultFetchSeriesBlocksMetadataBatchTimeout,
 		fetchSeriesBlocksBatchTimeout:           defaultFetchSeriesBlocksBatchTimeout,
 		fetchSeriesBlocksBatchConcurrency:       defaultFetchSeriesBlocksBatchConcurrency,
+		hintedHandoffOpts:                       NewHintedHandoffOptions(),
 	}
 	return opts.SetEncodingM3TSZ().(*options)
 }
@@ -336,6 +339,9 @@ func (o *options) Validate() error {
 	); err != nil {
 		return err
 	}
+	if err := o.hintedHandoffOpts.Validate(); err != nil {
+		return err
+	}
 	return topology.ValidateConnectConsistencyLevel(
 		o.clusterConnectConsistencyLevel,
 	)
@@ -344,7 +350,7 @@ func (o *options) Validate() error {
 func (o *options) SetEncodingM3TSZ() Options {
 	opts := *o
 	opts.readerIteratorAllocate = func(r io.Reader) encoding.ReaderIterator {
-		return m3tsz.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
+		return valuetype.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
 	}
 	return &opts
 }
@@ -779,6 +785,16 @@ func (o *options) ReaderIteratorAllocate() encoding.ReaderIteratorAllocate {
 	return o.readerIteratorAllocate
 }
 
+func (o *options) SetHintedHandoffOptions(value HintedHandoffOptions) Options {
+	opts := *o
+	opts.hintedHandoffOpts = value
+	return &opts
+}
+
+func (o *options) HintedHandoffOptions() HintedHandoffOptions {
+	return o.hintedHandoffOpts
+}
+
 func (o *options) SetOrigin(value topology.Host) AdminOptions {
 	opts := *o
 	opts.origin = value
@@ -35,6 +35,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/valuetype"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
 	"github.com/m3db/m3/src/dbnode/runtime"
@@ -105,6 +106,9 @@ var (
 	// errUnableToEncodeTags is raised when the server is unable to encode provided tags
 	// to be sent over the wire.
 	errUnableToEncodeTags = errors.New("unable to include tags")
+	// errFetchTaggedPageSizeInvalid is raised when a paged fetch tagged is requested
+	// without a positive page size.
+	errFetchTaggedPageSizeInvalid = errors.New("fetch tagged page size must be positive")
 )
 
 // sessionState is volatile state that is protected by a
@@ -149,6 +153,7 @@ type session struct {
 	streamBlocksBatchSize            int
 	streamBlocksMetadataBatchTimeout time.Duration
 	streamBlocksBatchTimeout         time.Duration
+	hints                            *hintedHandoff
 	metrics                          sessionMetrics
 }
 
@@ -206,6 +211,8 @@ type hostQueueOpts struct {
 	writeBatchRawRequestElementArrayPool       writeBatchRawRequestElementArrayPool
 	writeTaggedBatchRawRequestPool             writeTaggedBatchRawRequestPool
 	writeTaggedBatchRawRequestElementArrayPool writeTaggedBatchRawRequestElementArrayPool
+	addHintsFn                                 addHintsFn
+	addUntaggedHintsFn                         addUntaggedHintsFn
 	opts                                       Options
 }
 
@@ -246,6 +253,9 @@ func newSession(opts Options) (clientSession, error) {
 	}
 	s.reattemptStreamBlocksFromPeersFn = s.streamBlocksReattemptFromPeers
 	s.pickBestPeerFn = s.streamBlocksPickBestPeer
+	if opts.HintedHandoffOptions().Enabled() {
+		s.hints = newHintedHandoff(opts, s.healthyHostQueue)
+	}
 	writeAttemptPoolOpts := pool.NewObjectPoolOptions().
 		SetSize(opts.WriteOpPoolSize()).
 		SetInstrumentOptions(opts.InstrumentOptions().SetMetricsScope(
@@ -429,6 +439,13 @@ func (s *session) Open() error {
 		return errSessionStatusNotInitial
 	}
 
+	if s.hints != nil {
+		if err := s.hints.Open(); err != nil {
+			s.state.Unlock()
+			return err
+		}
+	}
+
 	watch, err := s.state.topo.Watch()
 	if err != nil {
 		s.state.Unlock()
@@ -829,7 +846,9 @@ func (s *session) newHostQueue(host topology.Host, topoMap topology.Map) (hostQu
 		writeBatchRawRequestElementArrayPool:       writeBatchRawRequestElementArrayPool,
 		writeTaggedBatchRawRequestPool:             writeTaggedBatchRequestPool,
 		writeTaggedBatchRawRequestElementArrayPool: writeTaggedBatchRawRequestElementArrayPool,
-		opts: s.opts,
+		addHintsFn:         s.addHintsFn(),
+		addUntaggedHintsFn: s.addUntaggedHintsFn(),
+		opts:               s.opts,
 	})
 	if err != nil {
 		return nil, err
@@ -1085,7 +1104,7 @@ func (s *session) fetchTaggedAttempt(
 	}
 
 	const fetchData = true
-	fetchState, err := s.fetchTaggedAttemptWithRLock(ns, q, opts, fetchData)
+	fetchState, err := s.fetchTaggedAttemptWithRLock(ns, q, opts, fetchData, nil)
 	s.state.RUnlock()
 
 	if err != nil {
@@ -1108,6 +1127,72 @@ func (s *session) fetchTaggedAttempt(
 	return iters, exhaustive, err
 }
 
+func (s *session) FetchTaggedPages(
+	ns ident.ID, q index.Query, opts index.QueryOptions, pageSize int,
+) (FetchTaggedPageIterator, error) {
+	if pageSize <= 0 {
+		return nil, xerrors.NewNonRetryableError(errFetchTaggedPageSizeInvalid)
+	}
+	return newFetchTaggedPageIterator(s, ns, q, opts, pageSize), nil
+}
+
+func (s *session) fetchTaggedPage(
+	ns ident.ID, q index.Query, opts index.QueryOptions, pageToken []byte,
+) (encoding.SeriesIterators, bool, []byte, error) {
+	var (
+		iters         encoding.SeriesIterators
+		exhaustive    bool
+		nextPageToken []byte
+	)
+	err := s.fetchRetrier.Attempt(func() error {
+		var err error
+		iters, exhaustive, nextPageToken, err = s.fetchTaggedPageAttempt(ns, q, opts, pageToken)
+		return err
+	})
+	return iters, exhaustive, nextPageToken, err
+}
+
+func (s *session) fetchTaggedPageAttempt(
+	ns ident.ID, q index.Query, opts index.QueryOptions, pageToken []byte,
+) (encoding.SeriesIterators, bool, []byte, error) {
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return nil, false, nil, errSessionStatusNotOpen
+	}
+
+	const fetchData = true
+	fetchState, err := s.fetchTaggedAttemptWithRLock(ns, q, opts, fetchData, pageToken)
+	s.state.RUnlock()
+
+	if err != nil {
+		return nil, false, nil, err
+	}
+
+	// it's safe to Wait() here, as we still hold the lock on fetchState, after it's
+	// returned from fetchTaggedAttemptWithRLock.
+	fetchState.Wait()
+
+	// must Unlock before calling `asEncodingSeriesIterators` as the latter needs to acquire
+	// the fetchState Lock
+	fetchState.Unlock()
+	iters, exhaustive, err := fetchState.asEncodingSeriesIterators(s.pools)
+	var nextPageToken []byte
+	if err == nil {
+		nextPageToken, err = fetchState.nextPageToken()
+		if err != nil {
+			iters.Close()
+			iters = nil
+		}
+	}
+
+	// must Unlock() before decRef'ing, as the latter releases the fetchState back into a
+	// pool if ref count == 0.
+	fetchState.decRef()
+
+	return iters, exhaustive, nextPageToken, err
+}
+
 func (s *session) FetchTaggedIDs(
 	ns ident.ID, q index.Query, opts index.QueryOptions,
 ) (TaggedIDsIterator, bool, error) {
@@ -1131,7 +1216,7 @@ func (s *session) fetchTaggedIDsAttempt(
 	}
 
 	const fetchData = false
-	fetchState, err := s.fetchTaggedAttemptWithRLock(ns, q, opts, fetchData)
+	fetchState, err := s.fetchTaggedAttemptWithRLock(ns, q, opts, fetchData, nil)
 	s.state.RUnlock()
 
 	if err != nil {
@@ -1162,6 +1247,7 @@ func (s *session) fetchTaggedAttemptWithRLock(
 	q index.Query,
 	opts index.QueryOptions,
 	fetchData bool,
+	pageToken []byte,
 ) (*fetchState, error) {
 	// NB(prateek): we have to clone the namespace, as we cannot guarantee the lifecycle
 	// of the hostQueues responding is less than the lifecycle of the current method.
@@ -1176,6 +1262,7 @@ func (s *session) fetchTaggedAttemptWithRLock(
 		nsClone.Finalize()
 		return nil, xerrors.NewNonRetryableError(err)
 	}
+	req.PageToken = pageToken
 
 	var (
 		topoMap    = s.state.topoMap
@@ -1546,9 +1633,48 @@ func (s *session) Close() error {
 		closer.Close()
 	}
 
+	if s.hints != nil {
+		// NB(r): Close after the host queues so that writes failing while
+		// the queues drain are still recorded as hints.
+		return s.hints.Close()
+	}
+
 	return nil
 }
 
+func (s *session) addHintsFn() addHintsFn {
+	if s.hints == nil {
+		return nil
+	}
+	return s.hints.AddHints
+}
+
+func (s *session) addUntaggedHintsFn() addUntaggedHintsFn {
+	if s.hints == nil {
+		return nil
+	}
+	return s.hints.AddUntaggedHints
+}
+
+// healthyHostQueue returns the host queue for a host that is part of the
+// current topology and has at least one healthy connection.
+func (s *session) healthyHostQueue(hostID string) (hostQueue, bool) {
+	s.state.RLock()
+	defer s.state.RUnlock()
+
+	if s.state.status != statusOpen {
+		return nil, false
+	}
+	if _, ok := s.state.topoMap.LookupHostShardSet(hostID); !ok {
+		return nil, false
+	}
+	q, ok := s.state.queuesByHostID[hostID]
+	if !ok || q.ConnectionCount() == 0 {
+		return nil, false
+	}
+	return q, true
+}
+
 func (s *session) Origin() topology.Host {
 	return s.origin
 }
@@ -1578,6 +1704,110 @@ func (s *session) TopologyMap() (topology.Map, error) {
 	return topoMap, nil
 }
 
+func (s *session) Aggregate(
+	ns ident.ID, q index.Query, opts index.AggregateQueryOptions,
+) (index.AggregateQueryResult, error) {
+	var result index.AggregateQueryResult
+	err := s.fetchRetrier.Attempt(func() error {
+		var err error
+		result, err = s.aggregateAttempt(ns, q, opts)
+		return err
+	})
+	return result, err
+}
+
+func (s *session) aggregateAttempt(
+	ns ident.ID, q index.Query, opts index.AggregateQueryOptions,
+) (index.AggregateQueryResult, error) {
+	request, err := convert.ToRPCAggregateQueryRequest(ns, q, opts)
+	if err != nil {
+		return index.AggregateQueryResult{}, xerrors.NewNonRetryableError(err)
+	}
+
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return index.AggregateQueryResult{}, errSessionStatusNotOpen
+	}
+
+	// NB: the namespace is only used to label the results so there is no
+	// need to clone it, the request holds its own copy of the bytes.
+	accum := newAggregateResultAccumulator(ident.BytesID(request.NameSpace),
+		s.state.topoMap, s.state.majority, s.state.readLevel)
+	op := &aggregateOp{request: request, completionFn: accum.completionFn}
+	for _, hq := range s.state.queues {
+		accum.wg.Add(1)
+		if err := hq.Enqueue(op); err != nil {
+			accum.wg.Done()
+			s.state.RUnlock()
+
+			// NB: if this happens we have a bug, once we are in the read
+			// lock the current queues should never be closed
+			wrappedErr := fmt.Errorf("[invariant violated] failed to enqueue aggregate: %v", err)
+			s.log.Errorf(wrappedErr.Error())
+			return index.AggregateQueryResult{}, wrappedErr
+		}
+	}
+	s.state.RUnlock()
+
+	return accum.Wait()
+}
+
+func (s *session) DeleteTagged(
+	namespace ident.ID,
+	q index.Query,
+	startInclusive, endExclusive time.Time,
+) (int64, error) {
+	request, err := convert.ToRPCDeleteTaggedRequest(namespace, q,
+		startInclusive, endExclusive)
+	if err != nil {
+		return 0, xerrors.NewInvalidParamsError(err)
+	}
+
+	var (
+		wg            sync.WaitGroup
+		enqueueErr    xerrors.MultiError
+		resultErrLock sync.Mutex
+		resultErr     xerrors.MultiError
+		deleted       int64
+	)
+
+	d := &deleteTaggedOp{request: request}
+	d.completionFn = func(result interface{}, err error) {
+		if err != nil {
+			resultErrLock.Lock()
+			resultErr = resultErr.Add(err)
+			resultErrLock.Unlock()
+		} else {
+			res := result.(*rpc.DeleteTaggedResult_)
+			atomic.AddInt64(&deleted, res.NumSeries)
+		}
+		wg.Done()
+	}
+
+	// Every host deletes the matching series of the shards it owns so the
+	// request is sent to all hosts, the same as a truncate.
+	s.state.RLock()
+	for idx := range s.state.queues {
+		wg.Add(1)
+		if err := s.state.queues[idx].Enqueue(d); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	}
+	s.state.RUnlock()
+
+	if err := enqueueErr.FinalError(); err != nil {
+		s.log.Errorf("failed to enqueue request: %v", err)
+		return 0, err
+	}
+
+	// Wait for the series to be deleted on all replicas
+	wg.Wait()
+
+	return deleted, resultErr.FinalError()
+}
+
 func (s *session) Truncate(namespace ident.ID) (int64, error) {
 	var (
 		wg            sync.WaitGroup
@@ -1740,7 +1970,7 @@ func (s *session) FetchBootstrapBlocksFromPeers(
 ) (result.ShardResult, error) {
 	var (
 		result = newBulkBlocksResult(s.opts, opts,
-			s.pools.tagDecoder, s.pools.id)
+			nsMetadata.Options().ValueType(), s.pools.tagDecoder, s.pools.id)
 		doneCh   = make(chan struct{})
 		progress = s.newPeerMetadataStreamingProgressMetrics(shard,
 			resultTypeBootstrap)
@@ -1810,7 +2040,8 @@ func (s *session) FetchBlocksFromPeers(
 		complete = int64(0)
 		doneCh   = make(chan error, 1)
 		outputCh = make(chan peerBlocksDatapoint, 4096)
-		result   = newStreamBlocksResult(s.opts, opts, outputCh,
+		result   = newStreamBlocksResult(s.opts, opts,
+			nsMetadata.Options().ValueType(), outputCh,
 			s.pools.tagDecoder.Get(), s.pools.id)
 		onDone = func(err error) {
 			atomic.StoreInt64(&complete, 1)
@@ -1877,6 +2108,57 @@ func (s *session) FetchBlocksFromPeers(
 	return pbi, nil
 }
 
+func (s *session) FetchBlockMerkleTreeNodesFromPeer(
+	peer topology.Host,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+	depth, level int,
+	indexes []int,
+) ([]uint32, error) {
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return nil, errSessionStatusNotOpen
+	}
+	hostQueue, ok := s.state.queuesByHostID[peer.ID()]
+	s.state.RUnlock()
+	if !ok {
+		return nil, errSessionHasNoHostQueueForHost
+	}
+
+	req := rpc.NewFetchBlockMerkleTreeNodesRequest()
+	req.NameSpace = namespace.Bytes()
+	req.Shard = int32(shard)
+	req.BlockStart = blockStart.UnixNano()
+	req.Depth = int32(depth)
+	req.Level = int32(level)
+	req.Indexes = make([]int32, 0, len(indexes))
+	for _, idx := range indexes {
+		req.Indexes = append(req.Indexes, int32(idx))
+	}
+
+	var (
+		result   *rpc.FetchBlockMerkleTreeNodesResult_
+		fetchErr error
+	)
+	if err := hostQueue.BorrowConnection(func(client rpc.TChanNode) {
+		tctx, _ := thrift.NewContext(s.opts.FetchRequestTimeout())
+		result, fetchErr = client.FetchBlockMerkleTreeNodes(tctx, req)
+	}); err != nil {
+		return nil, err
+	}
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+
+	hashes := make([]uint32, 0, len(result.Hashes))
+	for _, hash := range result.Hashes {
+		hashes = append(hashes, uint32(hash))
+	}
+	return hashes, nil
+}
+
 func (s *session) streamBlocksMetadataFromPeers(
 	namespace ident.ID,
 	shardID uint32,
@@ -2933,13 +3215,27 @@ type baseBlocksResult struct {
 func newBaseBlocksResult(
 	opts Options,
 	resultOpts result.Options,
+	valueType encoding.ValueType,
 ) baseBlocksResult {
 	blockOpts := resultOpts.DatabaseBlockOptions()
+	encoderPool := blockOpts.EncoderPool()
+	if valueType != encoding.FloatValueType {
+		// Blocks of series that are not floats are merged with their own
+		// encoders, which are returned to the pool once each block is merged.
+		encoderPool = valuetype.NewEncoderPool(valueType,
+			pool.NewObjectPoolOptions().
+				SetSize(opts.FetchSeriesBlocksBatchConcurrency()).
+				SetInstrumentOptions(opts.InstrumentOptions()),
+			encoding.NewOptions().
+				SetBytesPool(blockOpts.BytesPool()).
+				SetReaderIteratorPool(blockOpts.ReaderIteratorPool()).
+				SetSegmentReaderPool(blockOpts.SegmentReaderPool()))
+	}
 	return baseBlocksResult{
 		blockOpts:               blockOpts,
 		blockAllocSize:          blockOpts.DatabaseBlockAllocSize(),
 		contextPool:             opts.ContextPool(),
-		encoderPool:             blockOpts.EncoderPool(),
+		encoderPool:             encoderPool,
 		multiReaderIteratorPool: blockOpts.MultiReaderIteratorPool(),
 	}
 }
@@ -3057,12 +3353,13 @@ type streamBlocksResult struct {
 func newStreamBlocksResult(
 	opts Options,
 	resultOpts result.Options,
+	valueType encoding.ValueType,
 	outputCh chan<- peerBlocksDatapoint,
 	tagDecoder serialize.TagDecoder,
 	idPool ident.Pool,
 ) *streamBlocksResult {
 	return &streamBlocksResult{
-		baseBlocksResult: newBaseBlocksResult(opts, resultOpts),
+		baseBlocksResult: newBaseBlocksResult(opts, resultOpts, valueType),
 		outputCh:         outputCh,
 		tagDecoder:       tagDecoder,
 		idPool:           idPool,
@@ -3156,11 +3453,12 @@ type bulkBlocksResult struct {
 func newBulkBlocksResult(
 	opts Options,
 	resultOpts result.Options,
+	valueType encoding.ValueType,
 	tagDecoderPool serialize.TagDecoderPool,
 	idPool ident.Pool,
 ) *bulkBlocksResult {
 	return &bulkBlocksResult{
-		baseBlocksResult: newBaseBlocksResult(opts, resultOpts),
+		baseBlocksResult: newBaseBlocksResult(opts, resultOpts, valueType),
 		result:           result.NewShardResult(4096, resultOpts),
 		tagDecoderPool:   tagDecoderPool,
 		idPool:           idPool,
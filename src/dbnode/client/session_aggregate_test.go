@@ -0,0 +1,231 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSessionAggregateQueryOpts(start, end time.Time) index.AggregateQueryOptions {
+	return index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+		},
+		Type: index.AggregateTagNamesAndValues,
+	}
+}
+
+func TestSessionAggregate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	start := time.Now().Truncate(time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	topoInit := opts.TopologyInitializer()
+	topoWatch, err := topoInit.Init()
+	require.NoError(t, err)
+	topoMap := topoWatch.Get()
+	require.True(t, topoMap.HostsLen() > 0)
+
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			aggregate, ok := op.(*aggregateOp)
+			require.True(t, ok)
+			assert.Equal(t, []byte("metrics"), aggregate.request.NameSpace)
+			assert.Equal(t, rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME_VALUE,
+				aggregate.request.AggregateQueryType)
+
+			response := &rpc.AggregateQueryResult_{
+				Results: []*rpc.AggregateQueryResultTagNameElement{
+					{
+						TagName: []byte("city"),
+						Count:   3,
+						TagValues: []*rpc.AggregateQueryResultTagValueElement{
+							{TagValue: []byte("nyc"), Count: 2},
+							{TagValue: []byte("sf"), Count: 1},
+						},
+					},
+				},
+				Exhaustive: idx != 1,
+			}
+			go aggregate.completionFn(aggregateResultAccumulatorOpts{
+				host:     topoMap.Hosts()[idx],
+				response: response,
+			}, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	q := index.Query{Query: idx.NewTermQuery([]byte("foo"), []byte("bar"))}
+	result, err := session.Aggregate(ident.StringID("metrics"), q,
+		testSessionAggregateQueryOpts(start, end))
+	require.NoError(t, err)
+	assert.False(t, result.Exhaustive)
+
+	// Each replica reports the same series, so counts are per replica.
+	expected := []index.AggregateField{
+		{
+			Name:  []byte("city"),
+			Count: 3,
+			Values: []index.AggregateValue{
+				{Value: []byte("nyc"), Count: 2},
+				{Value: []byte("sf"), Count: 1},
+			},
+		},
+	}
+	assert.Equal(t, expected, result.Results.Fields())
+
+	assert.NoError(t, session.Close())
+}
+
+func TestSessionAggregateCountsWithUnavailableReplica(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	start := time.Now().Truncate(time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	topoInit := opts.TopologyInitializer()
+	topoWatch, err := topoInit.Init()
+	require.NoError(t, err)
+	topoMap := topoWatch.Get()
+	require.True(t, topoMap.HostsLen() > 0)
+
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			aggregate, ok := op.(*aggregateOp)
+			require.True(t, ok)
+
+			if idx == 0 {
+				go aggregate.completionFn(aggregateResultAccumulatorOpts{
+					host: topoMap.Hosts()[idx],
+				}, errors.New("host unavailable"))
+				return
+			}
+
+			response := &rpc.AggregateQueryResult_{
+				Results: []*rpc.AggregateQueryResultTagNameElement{
+					{
+						TagName: []byte("city"),
+						Count:   3,
+						TagValues: []*rpc.AggregateQueryResultTagValueElement{
+							{TagValue: []byte("nyc"), Count: 2},
+							{TagValue: []byte("sf"), Count: 1},
+						},
+					},
+				},
+				Exhaustive: true,
+			}
+			go aggregate.completionFn(aggregateResultAccumulatorOpts{
+				host:     topoMap.Hosts()[idx],
+				response: response,
+			}, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	q := index.Query{Query: idx.NewTermQuery([]byte("foo"), []byte("bar"))}
+	result, err := session.Aggregate(ident.StringID("metrics"), q,
+		testSessionAggregateQueryOpts(start, end))
+	require.NoError(t, err)
+	assert.True(t, result.Exhaustive)
+
+	// Only the replicas that responded are accounted for in the counts.
+	expected := []index.AggregateField{
+		{
+			Name:  []byte("city"),
+			Count: 3,
+			Values: []index.AggregateValue{
+				{Value: []byte("nyc"), Count: 2},
+				{Value: []byte("sf"), Count: 1},
+			},
+		},
+	}
+	assert.Equal(t, expected, result.Results.Fields())
+
+	assert.NoError(t, session.Close())
+}
+
+func TestSessionAggregateBadRequestErrorIsNonRetryable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	start := time.Now().Truncate(time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	topoInit := opts.TopologyInitializer()
+	topoWatch, err := topoInit.Init()
+	require.NoError(t, err)
+	topoMap := topoWatch.Get()
+	require.True(t, topoMap.HostsLen() > 0)
+
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			go op.CompletionFn()(aggregateResultAccumulatorOpts{
+				host: topoMap.Hosts()[idx],
+			}, &rpc.Error{
+				Type:    rpc.ErrorType_BAD_REQUEST,
+				Message: "expected bad request error",
+			})
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	q := index.Query{Query: idx.NewTermQuery([]byte("foo"), []byte("bar"))}
+	_, err = session.Aggregate(ident.StringID("metrics"), q,
+		testSessionAggregateQueryOpts(start, end))
+	require.Error(t, err)
+	assert.True(t, xerrors.IsNonRetryableError(err))
+
+	assert.NoError(t, session.Close())
+}
@@ -34,7 +34,9 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/counter"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/encoding/valuetype"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -1357,7 +1359,7 @@ func TestStreamBlocksBatchFromPeerVerifiesBlockErr(t *testing.T) {
 	// Attempt stream blocks
 	bopts := result.NewOptions()
 	m := session.newPeerMetadataStreamingProgressMetrics(0, resultTypeRaw)
-	r := newBulkBlocksResult(opts, bopts, session.pools.tagDecoder, session.pools.id)
+	r := newBulkBlocksResult(opts, bopts, encoding.FloatValueType, session.pools.tagDecoder, session.pools.id)
 	session.streamBlocksBatchFromPeer(testsNsMetadata(t), 0, peer, batch, bopts, r, enqueueCh, retrier, m)
 
 	// Assert result
@@ -1510,7 +1512,7 @@ func TestStreamBlocksBatchFromPeerVerifiesBlockChecksum(t *testing.T) {
 	// Attempt stream blocks
 	bopts := result.NewOptions()
 	m := session.newPeerMetadataStreamingProgressMetrics(0, resultTypeRaw)
-	r := newBulkBlocksResult(opts, bopts, session.pools.tagDecoder, session.pools.id)
+	r := newBulkBlocksResult(opts, bopts, encoding.FloatValueType, session.pools.tagDecoder, session.pools.id)
 	session.streamBlocksBatchFromPeer(testsNsMetadata(t), 0, peer, batch, bopts, r, enqueueCh, retrier, m)
 
 	// Assert enqueueChannel contents (bad bar block)
@@ -1552,7 +1554,7 @@ func TestBlocksResultAddBlockFromPeerReadMerged(t *testing.T) {
 		}},
 	}
 
-	r := newBulkBlocksResult(opts, bopts,
+	r := newBulkBlocksResult(opts, bopts, encoding.FloatValueType,
 		testTagDecodingPool, testIDPool)
 	r.addBlockFromPeer(fooID, fooTags, testHost, bl)
 
@@ -1639,7 +1641,7 @@ func TestBlocksResultAddBlockFromPeerReadUnmerged(t *testing.T) {
 		bl.Segments.Unmerged = append(bl.Segments.Unmerged, seg)
 	}
 
-	r := newBulkBlocksResult(opts, bopts, testTagDecodingPool, testIDPool)
+	r := newBulkBlocksResult(opts, bopts, encoding.FloatValueType, testTagDecodingPool, testIDPool)
 	r.addBlockFromPeer(fooID, fooTags, testHost, bl)
 
 	series := r.result.AllSeries()
@@ -1678,12 +1680,75 @@ func TestBlocksResultAddBlockFromPeerReadUnmerged(t *testing.T) {
 	assert.NoError(t, iter.Err())
 }
 
+func TestBlocksResultAddBlockFromPeerReadUnmergedCounter(t *testing.T) {
+	opts := newSessionTestAdminOptions()
+	bopts := result.NewOptions()
+	start := time.Now().Truncate(time.Hour)
+
+	segments := [][]ts.Datapoint{
+		{
+			{Timestamp: start.Add(1 * time.Second), Value: 1},
+			{Timestamp: start.Add(3 * time.Second), Value: 4},
+		},
+		{
+			{Timestamp: start.Add(2 * time.Second), Value: 2},
+			{Timestamp: start.Add(4 * time.Second), Value: 1},
+		},
+	}
+	bl := &rpc.Block{
+		Start:    start.UnixNano(),
+		Segments: &rpc.Segments{},
+	}
+	for _, dps := range segments {
+		encoder := counter.NewEncoder(start, nil, encoding.NewOptions())
+		for _, dp := range dps {
+			require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+		}
+		result := encoder.Discard()
+		seg := &rpc.Segment{Head: result.Head.Bytes(), Tail: result.Tail.Bytes()}
+		bl.Segments.Unmerged = append(bl.Segments.Unmerged, seg)
+	}
+
+	r := newBulkBlocksResult(opts, bopts, encoding.CounterValueType, testTagDecodingPool, testIDPool)
+	require.NoError(t, r.addBlockFromPeer(fooID, fooTags, testHost, bl))
+
+	sl, ok := r.result.AllSeries().Get(fooID)
+	require.True(t, ok)
+	result, ok := sl.Blocks.BlockAt(start)
+	require.True(t, ok)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	stream, err := result.Stream(ctx)
+	require.NoError(t, err)
+
+	// The merged block must remain a counter stream that keeps the reset.
+	iter := valuetype.NewReaderIterator(stream, true, nil)
+	defer iter.Close()
+	var (
+		expected = []float64{1, 2, 4, 1}
+		resets   = []bool{false, false, false, true}
+		asserted = 0
+	)
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		assert.Equal(t, encoding.CounterValueType, iter.ValueType())
+		assert.True(t, start.Add(time.Duration(asserted+1)*time.Second).Equal(dp.Timestamp))
+		assert.Equal(t, expected[asserted], dp.Value)
+		assert.Equal(t, resets[asserted], iter.CounterReset())
+		asserted++
+	}
+	assert.NoError(t, iter.Err())
+	assert.Equal(t, len(expected), asserted)
+}
+
 // TODO: add test TestBlocksResultAddBlockFromPeerMergeExistingResult
 
 func TestBlocksResultAddBlockFromPeerErrorOnNoSegments(t *testing.T) {
 	opts := newSessionTestAdminOptions()
 	bopts := result.NewOptions()
-	r := newBulkBlocksResult(opts, bopts, testTagDecodingPool, testIDPool)
+	r := newBulkBlocksResult(opts, bopts, encoding.FloatValueType, testTagDecodingPool, testIDPool)
 
 	bl := &rpc.Block{Start: time.Now().UnixNano()}
 	err := r.addBlockFromPeer(fooID, fooTags, testHost, bl)
@@ -1694,7 +1759,7 @@ func TestBlocksResultAddBlockFromPeerErrorOnNoSegments(t *testing.T) {
 func TestBlocksResultAddBlockFromPeerErrorOnNoSegmentsData(t *testing.T) {
 	opts := newSessionTestAdminOptions()
 	bopts := result.NewOptions()
-	r := newBulkBlocksResult(opts, bopts, testTagDecodingPool, testIDPool)
+	r := newBulkBlocksResult(opts, bopts, encoding.FloatValueType, testTagDecodingPool, testIDPool)
 
 	bl := &rpc.Block{Start: time.Now().UnixNano(), Segments: &rpc.Segments{}}
 	err := r.addBlockFromPeer(fooID, fooTags, testHost, bl)
@@ -23,8 +23,11 @@ package client
 import (
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3x/ident"
 
 	"github.com/golang/mock/gomock"
@@ -63,3 +66,42 @@ func TestTruncate(t *testing.T) {
 
 	assert.NoError(t, session.Close())
 }
+
+func TestDeleteTagged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	var (
+		expected int64
+		end      = time.Unix(0, time.Now().UnixNano())
+	)
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			deleteTagged, ok := op.(*deleteTaggedOp)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("metrics"), deleteTagged.request.NameSpace)
+			assert.Nil(t, deleteTagged.request.RangeStart)
+			require.NotNil(t, deleteTagged.request.RangeEnd)
+			assert.Equal(t, end.UnixNano(), *deleteTagged.request.RangeEnd)
+
+			n := rand.Int63n(128)
+			result := &rpc.DeleteTaggedResult_{NumSeries: n}
+			expected += n
+			deleteTagged.completionFn(result, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	q := index.Query{Query: idx.NewTermQuery([]byte("foo"), []byte("bar"))}
+	n, err := s.DeleteTagged(ident.StringID("metrics"), q, time.Time{}, end)
+	require.NoError(t, err)
+	assert.Equal(t, expected, n)
+
+	assert.NoError(t, session.Close())
+}
@@ -37,3 +37,17 @@ func (t *truncateOp) Size() int {
 func (t *truncateOp) CompletionFn() completionFn {
 	return t.completionFn
 }
+
+type deleteTaggedOp struct {
+	request      rpc.DeleteTaggedRequest
+	completionFn completionFn
+}
+
+func (d *deleteTaggedOp) Size() int {
+	// Delete tagged is always a single op
+	return 1
+}
+
+func (d *deleteTaggedOp) CompletionFn() completionFn {
+	return d.completionFn
+}
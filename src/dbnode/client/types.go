@@ -78,6 +78,21 @@ type Session interface {
 	// FetchTaggedIDs resolves the provided query to known IDs.
 	FetchTaggedIDs(namespace ident.ID, q index.Query, opts index.QueryOptions) (iter TaggedIDsIterator, exhaustive bool, err error)
 
+	// FetchTaggedPages resolves the provided query to known IDs, and fetches the data
+	// for them a page of at most pageSize series per host at a time. The query limit,
+	// if set, bounds the total number of series fetched across all pages.
+	FetchTaggedPages(namespace ident.ID, q index.Query, opts index.QueryOptions, pageSize int) (FetchTaggedPageIterator, error)
+
+	// Aggregate resolves the provided query to the distinct tag names, and optionally
+	// tag values, of the matching series along with an approximate count of the series
+	// each was found in.
+	Aggregate(namespace ident.ID, q index.Query, opts index.AggregateQueryOptions) (index.AggregateQueryResult, error)
+
+	// DeleteTagged deletes the datapoints within [startInclusive, endExclusive) of the
+	// series matching the query, a zero start deletes all datapoints written before the
+	// end. It returns the number of series deleted summed across all replicas.
+	DeleteTagged(namespace ident.ID, q index.Query, startInclusive, endExclusive time.Time) (int64, error)
+
 	// ShardID returns the given shard for an ID for callers
 	// to easily discern what shard is failing when operations
 	// for given IDs begin failing
@@ -106,6 +121,22 @@ type TaggedIDsIterator interface {
 	Finalize()
 }
 
+// FetchTaggedPageIterator iterates over the pages of a paged FetchTagged request,
+// each page is fetched from the cluster when Next is called.
+type FetchTaggedPageIterator interface {
+	// Next fetches the next page, returning false once all pages have been
+	// fetched or an error is encountered.
+	Next() bool
+
+	// Current returns the series of the current page and whether the index
+	// query was exhaustive when evaluated for the page. The series iterators
+	// are owned by the caller and must be closed once no longer required.
+	Current() (results encoding.SeriesIterators, exhaustive bool)
+
+	// Err returns any error encountered.
+	Err() error
+}
+
 // AdminClient can create administration sessions
 type AdminClient interface {
 	Client
@@ -200,6 +231,18 @@ type AdminSession interface {
 		metadatas []block.ReplicaMetadata,
 		opts result.Options,
 	) (PeerBlocksIter, error)
+
+	// FetchBlockMerkleTreeNodesFromPeer fetches the hashes of the nodes at the
+	// indexes of a level of a peer's Merkle tree over the series checksums of
+	// a block of a shard.
+	FetchBlockMerkleTreeNodesFromPeer(
+		peer topology.Host,
+		namespace ident.ID,
+		shard uint32,
+		blockStart time.Time,
+		depth, level int,
+		indexes []int,
+	) ([]uint32, error)
 }
 
 // Options is a set of client options
@@ -207,7 +250,8 @@ type Options interface {
 	// Validate validates the options
 	Validate() error
 
-	// SetEncodingM3TSZ sets m3tsz encoding
+	// SetEncodingM3TSZ sets m3tsz encoding, counter and histogram series are read
+	// with their own encodings
 	SetEncodingM3TSZ() Options
 
 	// SetRuntimeOptionsManager sets the runtime options manager, it is optional
@@ -473,6 +517,12 @@ type Options interface {
 
 	// ReaderIteratorAllocate returns the readerIteratorAllocate
 	ReaderIteratorAllocate() encoding.ReaderIteratorAllocate
+
+	// SetHintedHandoffOptions sets the hinted handoff options
+	SetHintedHandoffOptions(value HintedHandoffOptions) Options
+
+	// HintedHandoffOptions returns the hinted handoff options
+	HintedHandoffOptions() HintedHandoffOptions
 }
 
 // AdminOptions is a set of administration client options
@@ -528,6 +578,46 @@ type AdminOptions interface {
 	StreamBlocksRetrier() xretry.Retrier
 }
 
+// HintedHandoffOptions is a set of options for hinted handoff, when enabled
+// writes that fail to reach a host are persisted locally as hints and
+// replayed to the host once it is healthy again.
+type HintedHandoffOptions interface {
+	// Validate validates the options
+	Validate() error
+
+	// SetEnabled sets whether hinted handoff is enabled
+	SetEnabled(value bool) HintedHandoffOptions
+
+	// Enabled returns whether hinted handoff is enabled
+	Enabled() bool
+
+	// SetDirectory sets the directory hints are persisted to
+	SetDirectory(value string) HintedHandoffOptions
+
+	// Directory returns the directory hints are persisted to
+	Directory() string
+
+	// SetMaxHintAge sets the max age of a hint, older hints are discarded
+	// rather than replayed
+	SetMaxHintAge(value time.Duration) HintedHandoffOptions
+
+	// MaxHintAge returns the max age of a hint
+	MaxHintAge() time.Duration
+
+	// SetMaxHintsBytes sets the max size of the hints persisted for a single
+	// host, hints are dropped once a host's backlog reaches this size
+	SetMaxHintsBytes(value int64) HintedHandoffOptions
+
+	// MaxHintsBytes returns the max size of the hints persisted for a single host
+	MaxHintsBytes() int64
+
+	// SetReplayInterval sets the interval at which hints are replayed to hosts
+	SetReplayInterval(value time.Duration) HintedHandoffOptions
+
+	// ReplayInterval returns the interval at which hints are replayed to hosts
+	ReplayInterval() time.Duration
+}
+
 // The rest of these types are internal types that mocks are generated for
 // in file mode and hence need to stay in this file and refer to the other
 // types such as AdminSession.  When mocks are generated in file mode the
@@ -0,0 +1,54 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package counter implements an encoding for monotonic counters. Values are
+// written as delta of deltas of integers so that steadily increasing counters
+// take a bit per value, and decreases are written with an explicit reset
+// marker so that readers know where the counter was reset without having to
+// compare consecutive values.
+package counter
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	opcodeNoReset = 0x0
+	opcodeReset   = 0x1
+)
+
+var (
+	errEncoderClosed       = errors.New("encoder is closed")
+	errNoEncodedDatapoints = errors.New("encoder has no encoded datapoints")
+	errInvalidValue        = errors.New("counter values must be non-negative integers")
+)
+
+// toCounterValue converts a datapoint value to a counter value.
+func toCounterValue(v float64) (int64, error) {
+	if v < 0 || v >= math.MaxInt64 {
+		return 0, errInvalidValue
+	}
+	i, frac := math.Modf(v)
+	if frac != 0 {
+		return 0, errInvalidValue
+	}
+	return int64(i), nil
+}
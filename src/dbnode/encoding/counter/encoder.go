@@ -0,0 +1,181 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package counter
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/checked"
+	xtime "github.com/m3db/m3x/time"
+)
+
+type encoder struct {
+	os        encoding.OStream
+	opts      encoding.Options
+	tsEncoder encoding.TimestampEncoder
+
+	prevValue  int64 // previous counter value
+	prevDelta  int64 // previous counter value delta
+	numEncoded int
+
+	closed bool
+}
+
+// NewEncoder creates a new counter encoder.
+func NewEncoder(
+	start time.Time,
+	bytes checked.Bytes,
+	opts encoding.Options,
+) encoding.Encoder {
+	if opts == nil {
+		opts = encoding.NewOptions()
+	}
+	// NB: Only allocate up front if there is no pool, pooled encoders
+	// allocate when they are reset.
+	initAllocIfEmpty := opts.EncoderPool() == nil
+	return &encoder{
+		os:        encoding.NewOStream(bytes, initAllocIfEmpty, opts.BytesPool()),
+		opts:      opts,
+		tsEncoder: encoding.NewTimestampEncoder(start, opts),
+	}
+}
+
+// Encode encodes the timestamp and the counter value of a datapoint.
+func (enc *encoder) Encode(dp ts.Datapoint, tu xtime.Unit, ant ts.Annotation) error {
+	if enc.closed {
+		return errEncoderClosed
+	}
+	value, err := toCounterValue(dp.Value)
+	if err != nil {
+		return err
+	}
+
+	if enc.numEncoded == 0 {
+		encoding.WriteValueTypeHeader(enc.os, encoding.CounterValueType)
+		if err := enc.tsEncoder.WriteFirstTime(enc.os, dp.Timestamp, ant, tu); err != nil {
+			return err
+		}
+		enc.writeReset(value)
+	} else {
+		if err := enc.tsEncoder.WriteNextTime(enc.os, dp.Timestamp, ant, tu); err != nil {
+			return err
+		}
+		enc.writeNextValue(value)
+	}
+	enc.numEncoded++
+	return nil
+}
+
+func (enc *encoder) writeNextValue(value int64) {
+	if value < enc.prevValue {
+		enc.writeReset(value)
+		return
+	}
+	delta := value - enc.prevValue
+	enc.os.WriteBit(opcodeNoReset)
+	encoding.WriteIntDelta(enc.os, delta-enc.prevDelta)
+	enc.prevValue = value
+	enc.prevDelta = delta
+}
+
+// writeReset writes the full value, as for the first value of the stream or
+// when the counter has been reset, after which deltas start from zero again.
+func (enc *encoder) writeReset(value int64) {
+	enc.os.WriteBit(opcodeReset)
+	enc.os.WriteBits(uint64(value), 64)
+	enc.prevValue = value
+	enc.prevDelta = 0
+}
+
+func (enc *encoder) Stream() xio.SegmentReader {
+	return encoding.SegmentReader(encoding.SegmentFromOStream(enc.os, enc.opts, false), enc.opts)
+}
+
+func (enc *encoder) NumEncoded() int {
+	return enc.numEncoded
+}
+
+func (enc *encoder) LastEncoded() (ts.Datapoint, error) {
+	if enc.numEncoded == 0 {
+		return ts.Datapoint{}, errNoEncodedDatapoints
+	}
+	return ts.Datapoint{
+		Timestamp: enc.tsEncoder.PrevTime,
+		Value:     float64(enc.prevValue),
+	}, nil
+}
+
+func (enc *encoder) Len() int {
+	return enc.os.Len()
+}
+
+func (enc *encoder) Reset(start time.Time, capacity int) {
+	enc.reset(start, enc.newBuffer(capacity))
+}
+
+func (enc *encoder) reset(start time.Time, bytes checked.Bytes) {
+	enc.os.Reset(bytes)
+	enc.tsEncoder.Reset(start)
+	enc.prevValue = 0
+	enc.prevDelta = 0
+	enc.numEncoded = 0
+	enc.closed = false
+}
+
+func (enc *encoder) newBuffer(capacity int) checked.Bytes {
+	if bytesPool := enc.opts.BytesPool(); bytesPool != nil {
+		return bytesPool.Get(capacity)
+	}
+	return checked.NewBytes(make([]byte, 0, capacity), nil)
+}
+
+func (enc *encoder) Close() {
+	if enc.closed {
+		return
+	}
+
+	enc.closed = true
+
+	// Ensure to free ref to ostream bytes
+	enc.os.Reset(nil)
+
+	if pool := enc.opts.EncoderPool(); pool != nil {
+		pool.Put(enc)
+	}
+}
+
+func (enc *encoder) Discard() ts.Segment {
+	segment := encoding.SegmentFromOStream(enc.os, enc.opts, true)
+
+	// Close the encoder no longer needed
+	enc.Close()
+
+	return segment
+}
+
+func (enc *encoder) DiscardReset(start time.Time, capacity int) ts.Segment {
+	segment := encoding.SegmentFromOStream(enc.os, enc.opts, true)
+	enc.Reset(start, capacity)
+	return segment
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package counter
+
+import (
+	"io"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+)
+
+// ReaderIterator is an iterator over counter encoded data.
+type ReaderIterator interface {
+	encoding.ReaderIterator
+
+	// CounterReset returns whether the counter was reset at the current
+	// datapoint, that is whether it decreased since the previous datapoint.
+	CounterReset() bool
+}
+
+type readerIterator struct {
+	is         encoding.IStream
+	opts       encoding.Options
+	tsIterator encoding.TimestampIterator
+
+	value   int64 // current counter value
+	delta   int64 // current counter value delta
+	reset   bool  // whether the counter was reset at the current datapoint
+	started bool  // whether the header has been read
+	err     error
+
+	closed bool
+}
+
+// NewReaderIterator returns a new iterator over counter encoded data.
+func NewReaderIterator(reader io.Reader, opts encoding.Options) ReaderIterator {
+	if opts == nil {
+		opts = encoding.NewOptions()
+	}
+	return &readerIterator{
+		is:         encoding.NewIStream(reader),
+		opts:       opts,
+		tsIterator: encoding.NewTimestampIterator(opts),
+	}
+}
+
+// Next moves to the next item
+func (it *readerIterator) Next() bool {
+	if !it.hasNext() {
+		return false
+	}
+
+	first := !it.started
+	if first {
+		if it.err = encoding.ReadValueTypeHeader(it.is, encoding.CounterValueType); it.err != nil {
+			return false
+		}
+		it.started = true
+	}
+	if it.err = it.tsIterator.ReadTimestamp(it.is); it.err != nil {
+		return false
+	}
+	if it.tsIterator.Done {
+		return false
+	}
+
+	it.err = it.readValue(first)
+	return it.err == nil
+}
+
+func (it *readerIterator) readValue(first bool) error {
+	opcode, err := it.is.ReadBit()
+	if err != nil {
+		return err
+	}
+	if opcode == opcodeReset {
+		value, err := it.is.ReadBits(64)
+		if err != nil {
+			return err
+		}
+		it.value = int64(value)
+		it.delta = 0
+		it.reset = !first
+		return nil
+	}
+
+	dod, err := encoding.ReadIntDelta(it.is)
+	if err != nil {
+		return err
+	}
+	it.delta += dod
+	it.value += it.delta
+	it.reset = false
+	return nil
+}
+
+// Current returns the value as well as the annotation associated with the current datapoint.
+// Users should not hold on to the returned Annotation object as it may get invalidated when
+// the iterator calls Next().
+func (it *readerIterator) Current() (ts.Datapoint, xtime.Unit, ts.Annotation) {
+	return ts.Datapoint{
+		Timestamp: it.tsIterator.PrevTime,
+		Value:     float64(it.value),
+	}, it.tsIterator.TimeUnit, it.tsIterator.PrevAnnotation
+}
+
+func (it *readerIterator) CounterReset() bool {
+	return it.reset
+}
+
+// Err returns the error encountered
+func (it *readerIterator) Err() error {
+	return it.err
+}
+
+func (it *readerIterator) hasNext() bool {
+	return it.err == nil && !it.tsIterator.Done && !it.closed
+}
+
+func (it *readerIterator) Reset(reader io.Reader) {
+	it.is.Reset(reader)
+	it.tsIterator.Reset()
+	it.value = 0
+	it.delta = 0
+	it.reset = false
+	it.started = false
+	it.err = nil
+	it.closed = false
+}
+
+func (it *readerIterator) Close() {
+	if it.closed {
+		return
+	}
+	it.closed = true
+	pool := it.opts.ReaderIteratorPool()
+	if pool != nil {
+		pool.Put(it)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package counter
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+var testStartTime = time.Unix(1427162400, 0)
+
+func TestCounterRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(testStartTime.Unix()))
+	for i := 0; i < 100; i++ {
+		var (
+			input = make([]ts.Datapoint, 0, 1000)
+			value float64
+		)
+		for j := 0; j < 1000; j++ {
+			switch {
+			case r.Intn(100) == 0:
+				// Counter reset.
+				value = float64(r.Intn(10))
+			case r.Intn(10) == 0:
+				value += float64(r.Int63n(math.MaxInt32))
+			default:
+				value += float64(r.Intn(100))
+			}
+			input = append(input, ts.Datapoint{
+				Timestamp: testStartTime.Add(time.Duration(j) * time.Second),
+				Value:     value,
+			})
+		}
+		testRoundTrip(t, input)
+	}
+}
+
+func TestCounterRoundTripLargeValues(t *testing.T) {
+	testRoundTrip(t, []ts.Datapoint{
+		{Timestamp: testStartTime, Value: 0},
+		{Timestamp: testStartTime.Add(time.Second), Value: 1 << 52},
+		{Timestamp: testStartTime.Add(2 * time.Second), Value: 1 << 53},
+		{Timestamp: testStartTime.Add(3 * time.Second), Value: 1},
+		{Timestamp: testStartTime.Add(4 * time.Second), Value: 1 << 53},
+	})
+}
+
+func TestCounterReset(t *testing.T) {
+	input := []ts.Datapoint{
+		{Timestamp: testStartTime, Value: 10},
+		{Timestamp: testStartTime.Add(time.Second), Value: 20},
+		{Timestamp: testStartTime.Add(2 * time.Second), Value: 5},
+		{Timestamp: testStartTime.Add(3 * time.Second), Value: 5},
+		{Timestamp: testStartTime.Add(4 * time.Second), Value: 15},
+	}
+	expectedResets := []bool{false, false, true, false, false}
+
+	encoder := NewEncoder(testStartTime, nil, nil)
+	for _, dp := range input {
+		require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+	}
+
+	it := NewReaderIterator(encoder.Stream(), nil)
+	defer it.Close()
+
+	var resets []bool
+	for it.Next() {
+		resets = append(resets, it.CounterReset())
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, expectedResets, resets)
+}
+
+func TestCounterIteratorRejectsOtherValueTypes(t *testing.T) {
+	b := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, byte(encoding.HistogramValueType)}
+
+	it := NewReaderIterator(bytes.NewReader(b), nil)
+	require.False(t, it.Next())
+	require.Error(t, it.Err())
+}
+
+func testRoundTrip(t *testing.T, input []ts.Datapoint) {
+	encoder := NewEncoder(testStartTime, nil, nil)
+	for j, v := range input {
+		if j == 0 {
+			require.NoError(t, encoder.Encode(v, xtime.Millisecond, proto.EncodeVarint(10)))
+		} else if j == 10 {
+			require.NoError(t, encoder.Encode(v, xtime.Microsecond, proto.EncodeVarint(60)))
+		} else {
+			require.NoError(t, encoder.Encode(v, xtime.Second, nil))
+		}
+	}
+
+	it := NewReaderIterator(encoder.Stream(), nil)
+	defer it.Close()
+	var decompressed []ts.Datapoint
+	j := 0
+	for it.Next() {
+		v, _, a := it.Current()
+		if j == 0 {
+			s, _ := proto.DecodeVarint(a)
+			require.Equal(t, uint64(10), s)
+		} else if j == 10 {
+			s, _ := proto.DecodeVarint(a)
+			require.Equal(t, uint64(60), s)
+		} else {
+			require.Nil(t, a)
+		}
+		decompressed = append(decompressed, v)
+		j++
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, len(input), len(decompressed))
+	for i := 0; i < len(input); i++ {
+		require.Equal(t, input[i].Timestamp, decompressed[i].Timestamp)
+		require.Equal(t, input[i].Value, decompressed[i].Value)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encoding
+
+import (
+	"errors"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+)
+
+var (
+	errDownsampleStepNotPositive = errors.New("downsample step must be positive")
+)
+
+// Downsample reads the datapoints within [start, end) from the iterator and
+// encodes a single aggregated datapoint per step. Steps are aligned to
+// alignStart with step k covering (alignStart+(k-1)*step, alignStart+k*step],
+// so that step boundaries line up with the evaluation times of a query
+// evaluated at alignStart+k*step regardless of how far before the first
+// evaluation time the fetched range starts. Each aggregated datapoint is
+// written at the timestamp of the last datapoint within its step so that no
+// value is ever moved forward in time. Annotations are dropped.
+func Downsample(
+	iter Iterator,
+	encoder Encoder,
+	start, end, alignStart time.Time,
+	step time.Duration,
+	aggType ts.AggregationType,
+) error {
+	if step <= 0 {
+		return errDownsampleStepNotPositive
+	}
+	if err := aggType.Validate(); err != nil {
+		return err
+	}
+
+	agg := stepAggregator{aggType: aggType}
+	for iter.Next() {
+		dp, unit, _ := iter.Current()
+		if dp.Timestamp.Before(start) || !dp.Timestamp.Before(end) {
+			continue
+		}
+		idx := stepIndex(alignStart, step, dp.Timestamp)
+		if agg.count > 0 && idx != agg.idx {
+			if err := agg.encode(encoder); err != nil {
+				return err
+			}
+			agg.reset()
+		}
+		agg.idx = idx
+		agg.add(dp, unit)
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if agg.count > 0 {
+		return agg.encode(encoder)
+	}
+	return nil
+}
+
+// stepIndex returns the index k of the step (alignStart+(k-1)*step,
+// alignStart+k*step] that t falls in, k is negative for steps before
+// alignStart.
+func stepIndex(alignStart time.Time, step time.Duration, t time.Time) int64 {
+	elapsed := t.Sub(alignStart)
+	idx := int64(elapsed / step)
+	if elapsed%step > 0 {
+		idx++
+	}
+	return idx
+}
+
+type stepAggregator struct {
+	aggType ts.AggregationType
+	idx     int64
+	count   int
+	value   float64
+	last    ts.Datapoint
+	unit    xtime.Unit
+}
+
+func (a *stepAggregator) add(dp ts.Datapoint, unit xtime.Unit) {
+	switch {
+	case a.count == 0:
+		a.value = dp.Value
+	case a.aggType == ts.AggregationMin && dp.Value < a.value:
+		a.value = dp.Value
+	case a.aggType == ts.AggregationMax && dp.Value > a.value:
+		a.value = dp.Value
+	case a.aggType == ts.AggregationSum || a.aggType == ts.AggregationAvg:
+		a.value += dp.Value
+	}
+	a.count++
+	a.last = dp
+	a.unit = unit
+}
+
+func (a *stepAggregator) encode(encoder Encoder) error {
+	dp := ts.Datapoint{Timestamp: a.last.Timestamp}
+	switch a.aggType {
+	case ts.AggregationCount:
+		dp.Value = float64(a.count)
+	case ts.AggregationLast:
+		dp.Value = a.last.Value
+	case ts.AggregationAvg:
+		dp.Value = a.value / float64(a.count)
+	default:
+		dp.Value = a.value
+	}
+	return encoder.Encode(dp, a.unit, nil)
+}
+
+func (a *stepAggregator) reset() {
+	a.count = 0
+	a.value = 0
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encoding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownsample(t *testing.T) {
+	start := time.Unix(1500000000, 0)
+	end := start.Add(3 * time.Minute)
+	values := []testValue{
+		{t: start.Add(-time.Second), value: 100.0, unit: xtime.Second},
+		{t: start, value: 4.0, unit: xtime.Second},
+		{t: start.Add(10 * time.Second), value: 1.0, unit: xtime.Second},
+		{t: start.Add(30 * time.Second), value: 3.0, unit: xtime.Second},
+		{t: start.Add(45 * time.Second), value: 8.0, unit: xtime.Second},
+		{t: start.Add(60 * time.Second), value: 2.0, unit: xtime.Second},
+		{t: start.Add(150 * time.Second), value: 5.0, unit: xtime.Second},
+		{t: end, value: 100.0, unit: xtime.Second},
+	}
+
+	tests := []struct {
+		aggType  ts.AggregationType
+		expected []float64
+	}{
+		{aggType: ts.AggregationMin, expected: []float64{4, 1, 5}},
+		{aggType: ts.AggregationMax, expected: []float64{4, 8, 5}},
+		{aggType: ts.AggregationSum, expected: []float64{4, 14, 5}},
+		{aggType: ts.AggregationCount, expected: []float64{1, 4, 1}},
+		{aggType: ts.AggregationLast, expected: []float64{4, 2, 5}},
+		{aggType: ts.AggregationAvg, expected: []float64{4, 3.5, 5}},
+	}
+
+	// Datapoints outside of [start, end) are skipped, the remaining datapoints
+	// fall in the steps (start-1m, start], (start, start+1m] and
+	// (start+2m, start+3m] and each step is written at the timestamp of its
+	// last datapoint.
+	expectedTimes := []time.Time{
+		start,
+		start.Add(60 * time.Second),
+		start.Add(150 * time.Second),
+	}
+
+	for _, test := range tests {
+		t.Run(test.aggType.String(), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			encoder := NewMockEncoder(ctrl)
+			var calls []*gomock.Call
+			for i, v := range test.expected {
+				dp := ts.Datapoint{Timestamp: expectedTimes[i], Value: v}
+				calls = append(calls, encoder.EXPECT().Encode(dp, xtime.Second, nil).Return(nil))
+			}
+			gomock.InOrder(calls...)
+
+			iter := newTestIterator(values)
+			require.NoError(t, Downsample(iter, encoder, start, end, start, time.Minute, test.aggType))
+		})
+	}
+}
+
+func TestDownsampleAlignsStepsToEvaluationStart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The fetched range starts 7m before the first evaluation time, steps
+	// must still end on the evaluation times evalStart+k*5m rather than on
+	// start+k*5m.
+	evalStart := time.Unix(1500000000, 0)
+	start := evalStart.Add(-7 * time.Minute)
+	end := evalStart.Add(10 * time.Minute)
+	values := []testValue{
+		{t: evalStart.Add(-6 * time.Minute), value: 1.0, unit: xtime.Second},
+		{t: evalStart.Add(-4 * time.Minute), value: 2.0, unit: xtime.Second},
+		{t: evalStart.Add(-time.Minute), value: 3.0, unit: xtime.Second},
+		{t: evalStart.Add(time.Minute), value: 4.0, unit: xtime.Second},
+		{t: evalStart.Add(5 * time.Minute), value: 5.0, unit: xtime.Second},
+		{t: evalStart.Add(6 * time.Minute), value: 6.0, unit: xtime.Second},
+	}
+
+	encoder := NewMockEncoder(ctrl)
+	gomock.InOrder(
+		encoder.EXPECT().Encode(ts.Datapoint{Timestamp: evalStart.Add(-6 * time.Minute), Value: 1}, xtime.Second, nil).Return(nil),
+		encoder.EXPECT().Encode(ts.Datapoint{Timestamp: evalStart.Add(-time.Minute), Value: 3}, xtime.Second, nil).Return(nil),
+		encoder.EXPECT().Encode(ts.Datapoint{Timestamp: evalStart.Add(5 * time.Minute), Value: 5}, xtime.Second, nil).Return(nil),
+		encoder.EXPECT().Encode(ts.Datapoint{Timestamp: evalStart.Add(6 * time.Minute), Value: 6}, xtime.Second, nil).Return(nil),
+	)
+
+	iter := newTestIterator(values)
+	require.NoError(t, Downsample(iter, encoder, start, end, evalStart, 5*time.Minute, ts.AggregationLast))
+}
+
+func TestDownsampleInvalidArguments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	encoder := NewMockEncoder(ctrl)
+	start := time.Unix(1500000000, 0)
+	end := start.Add(time.Hour)
+
+	iter := newTestIterator(nil)
+	require.Error(t, Downsample(iter, encoder, start, end, start, 0, ts.AggregationSum))
+	require.Error(t, Downsample(iter, encoder, start, end, start, time.Minute, ts.AggregationNone))
+}
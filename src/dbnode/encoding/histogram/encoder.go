@@ -0,0 +1,208 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/checked"
+	xtime "github.com/m3db/m3x/time"
+)
+
+type encoder struct {
+	os        encoding.OStream
+	opts      encoding.Options
+	tsEncoder encoding.TimestampEncoder
+
+	prev       Value   // previous histogram
+	prevDeltas []int64 // previous bucket count deltas
+	curr       Value   // scratch histogram for decoding annotations
+	numEncoded int
+
+	closed bool
+}
+
+// NewEncoder creates a new histogram encoder.
+func NewEncoder(
+	start time.Time,
+	bytes checked.Bytes,
+	opts encoding.Options,
+) encoding.Encoder {
+	if opts == nil {
+		opts = encoding.NewOptions()
+	}
+	// NB: Only allocate up front if there is no pool, pooled encoders
+	// allocate when they are reset.
+	initAllocIfEmpty := opts.EncoderPool() == nil
+	return &encoder{
+		os:        encoding.NewOStream(bytes, initAllocIfEmpty, opts.BytesPool()),
+		opts:      opts,
+		tsEncoder: encoding.NewTimestampEncoder(start, opts),
+	}
+}
+
+// Encode encodes the timestamp and the histogram of a datapoint, the histogram
+// is read from the annotation which is not stored itself.
+func (enc *encoder) Encode(dp ts.Datapoint, tu xtime.Unit, ant ts.Annotation) error {
+	if enc.closed {
+		return errEncoderClosed
+	}
+	if err := enc.curr.unmarshal(ant); err != nil {
+		return err
+	}
+
+	if enc.numEncoded == 0 {
+		encoding.WriteValueTypeHeader(enc.os, encoding.HistogramValueType)
+		if err := enc.tsEncoder.WriteFirstTime(enc.os, dp.Timestamp, nil, tu); err != nil {
+			return err
+		}
+		enc.writeBounds(enc.curr.Bounds)
+		enc.writeCounts(enc.curr.Counts)
+		enc.os.WriteBits(math.Float64bits(enc.curr.Sum), 64)
+	} else {
+		if err := enc.tsEncoder.WriteNextTime(enc.os, dp.Timestamp, nil, tu); err != nil {
+			return err
+		}
+		if boundsEqual(enc.prev.Bounds, enc.curr.Bounds) {
+			enc.os.WriteBit(opcodeUnchanged)
+		} else {
+			enc.os.WriteBit(opcodeChanged)
+			enc.writeBounds(enc.curr.Bounds)
+		}
+		enc.writeCounts(enc.curr.Counts)
+		enc.writeSum(enc.curr.Sum)
+	}
+	enc.prev.Sum = enc.curr.Sum
+	enc.numEncoded++
+	return nil
+}
+
+// writeBounds writes the bucket bounds, after which bucket count deltas start
+// from zero again.
+func (enc *encoder) writeBounds(bounds []float64) {
+	enc.os.WriteBits(uint64(len(bounds)), numBucketsNumBits)
+	for _, b := range bounds {
+		enc.os.WriteBits(math.Float64bits(b), 64)
+	}
+	enc.prev.Bounds = append(enc.prev.Bounds[:0], bounds...)
+	enc.prev.Counts = enc.prev.Counts[:0]
+	enc.prevDeltas = enc.prevDeltas[:0]
+	for range bounds {
+		enc.prev.Counts = append(enc.prev.Counts, 0)
+		enc.prevDeltas = append(enc.prevDeltas, 0)
+	}
+}
+
+func (enc *encoder) writeCounts(counts []uint64) {
+	for i, c := range counts {
+		delta := int64(c) - int64(enc.prev.Counts[i])
+		encoding.WriteIntDelta(enc.os, delta-enc.prevDeltas[i])
+		enc.prev.Counts[i] = c
+		enc.prevDeltas[i] = delta
+	}
+}
+
+func (enc *encoder) writeSum(sum float64) {
+	if math.Float64bits(sum) == math.Float64bits(enc.prev.Sum) {
+		enc.os.WriteBit(opcodeUnchanged)
+		return
+	}
+	enc.os.WriteBit(opcodeChanged)
+	enc.os.WriteBits(math.Float64bits(sum), 64)
+}
+
+func (enc *encoder) Stream() xio.SegmentReader {
+	return encoding.SegmentReader(encoding.SegmentFromOStream(enc.os, enc.opts, false), enc.opts)
+}
+
+func (enc *encoder) NumEncoded() int {
+	return enc.numEncoded
+}
+
+func (enc *encoder) LastEncoded() (ts.Datapoint, error) {
+	if enc.numEncoded == 0 {
+		return ts.Datapoint{}, errNoEncodedDatapoints
+	}
+	return ts.Datapoint{
+		Timestamp: enc.tsEncoder.PrevTime,
+		Value:     float64(enc.prev.Count()),
+	}, nil
+}
+
+func (enc *encoder) Len() int {
+	return enc.os.Len()
+}
+
+func (enc *encoder) Reset(start time.Time, capacity int) {
+	enc.reset(start, enc.newBuffer(capacity))
+}
+
+func (enc *encoder) reset(start time.Time, bytes checked.Bytes) {
+	enc.os.Reset(bytes)
+	enc.tsEncoder.Reset(start)
+	enc.prev.Bounds = enc.prev.Bounds[:0]
+	enc.prev.Counts = enc.prev.Counts[:0]
+	enc.prev.Sum = 0
+	enc.prevDeltas = enc.prevDeltas[:0]
+	enc.numEncoded = 0
+	enc.closed = false
+}
+
+func (enc *encoder) newBuffer(capacity int) checked.Bytes {
+	if bytesPool := enc.opts.BytesPool(); bytesPool != nil {
+		return bytesPool.Get(capacity)
+	}
+	return checked.NewBytes(make([]byte, 0, capacity), nil)
+}
+
+func (enc *encoder) Close() {
+	if enc.closed {
+		return
+	}
+
+	enc.closed = true
+
+	// Ensure to free ref to ostream bytes
+	enc.os.Reset(nil)
+
+	if pool := enc.opts.EncoderPool(); pool != nil {
+		pool.Put(enc)
+	}
+}
+
+func (enc *encoder) Discard() ts.Segment {
+	segment := encoding.SegmentFromOStream(enc.os, enc.opts, true)
+
+	// Close the encoder no longer needed
+	enc.Close()
+
+	return segment
+}
+
+func (enc *encoder) DiscardReset(start time.Time, capacity int) ts.Segment {
+	segment := encoding.SegmentFromOStream(enc.os, enc.opts, true)
+	enc.Reset(start, capacity)
+	return segment
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package histogram implements an encoding for bucketed histograms, where a
+// single series holds the counts of all buckets for each timestamp. Bucket
+// bounds are only written when they change and bucket counts are written as
+// delta of deltas of integers.
+//
+// Histograms are passed to the encoder and returned by the iterator as
+// marshalled annotations, the datapoint value being the total count.
+package histogram
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+const (
+	maxNumBuckets     = math.MaxUint16
+	numBucketsNumBits = 16
+
+	opcodeUnchanged = 0x0
+	opcodeChanged   = 0x1
+)
+
+var (
+	errEncoderClosed       = errors.New("encoder is closed")
+	errNoEncodedDatapoints = errors.New("encoder has no encoded datapoints")
+	errNoBuckets           = errors.New("histogram has no buckets")
+	errTooManyBuckets      = errors.New("histogram has too many buckets")
+	errMismatchedBuckets   = errors.New("histogram bounds and counts differ in length")
+	errUnsortedBounds      = errors.New("histogram bounds are not strictly increasing")
+	errCountOverflow       = errors.New("histogram bucket count overflows")
+	errInvalidHistogram    = errors.New("invalid marshalled histogram")
+)
+
+// Value is a bucketed histogram.
+type Value struct {
+	// Bounds are the upper bounds of the buckets in increasing order.
+	Bounds []float64
+	// Counts are the number of values in each bucket.
+	Counts []uint64
+	// Sum is the sum of all values.
+	Sum float64
+}
+
+// Count returns the total number of values in the histogram.
+func (v Value) Count() uint64 {
+	var count uint64
+	for _, c := range v.Counts {
+		count += c
+	}
+	return count
+}
+
+// Validate validates the histogram.
+func (v Value) Validate() error {
+	if len(v.Bounds) == 0 {
+		return errNoBuckets
+	}
+	if len(v.Bounds) > maxNumBuckets {
+		return errTooManyBuckets
+	}
+	if len(v.Bounds) != len(v.Counts) {
+		return errMismatchedBuckets
+	}
+	for i := 1; i < len(v.Bounds); i++ {
+		if !(v.Bounds[i] > v.Bounds[i-1]) {
+			return errUnsortedBounds
+		}
+	}
+	for _, c := range v.Counts {
+		if c > math.MaxInt64 {
+			return errCountOverflow
+		}
+	}
+	return nil
+}
+
+// Marshal marshals the histogram so it can be passed as an annotation.
+func (v Value) Marshal() []byte {
+	return v.MarshalTo(nil)
+}
+
+// MarshalTo appends the marshalled histogram to the given buffer.
+func (v Value) MarshalTo(buf []byte) []byte {
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], uint64(len(v.Bounds)))
+	buf = append(buf, scratch[:n]...)
+	for i := range v.Bounds {
+		buf = appendFloat64(buf, v.Bounds[i])
+		n = binary.PutUvarint(scratch[:], v.Counts[i])
+		buf = append(buf, scratch[:n]...)
+	}
+	return appendFloat64(buf, v.Sum)
+}
+
+// Unmarshal unmarshals a histogram marshalled with Marshal.
+func Unmarshal(b []byte) (Value, error) {
+	var v Value
+	if err := v.unmarshal(b); err != nil {
+		return Value{}, err
+	}
+	return v, nil
+}
+
+// unmarshal unmarshals into the histogram reusing its slices.
+func (v *Value) unmarshal(b []byte) error {
+	numBuckets, n := binary.Uvarint(b)
+	if n <= 0 || numBuckets > maxNumBuckets {
+		return errInvalidHistogram
+	}
+	b = b[n:]
+
+	v.Bounds = v.Bounds[:0]
+	v.Counts = v.Counts[:0]
+	for i := 0; i < int(numBuckets); i++ {
+		if len(b) < 8 {
+			return errInvalidHistogram
+		}
+		v.Bounds = append(v.Bounds, math.Float64frombits(binary.BigEndian.Uint64(b)))
+		b = b[8:]
+
+		count, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errInvalidHistogram
+		}
+		v.Counts = append(v.Counts, count)
+		b = b[n:]
+	}
+
+	if len(b) != 8 {
+		return errInvalidHistogram
+	}
+	v.Sum = math.Float64frombits(binary.BigEndian.Uint64(b))
+	return v.Validate()
+}
+
+func appendFloat64(buf []byte, f float64) []byte {
+	var scratch [8]byte
+	binary.BigEndian.PutUint64(scratch[:], math.Float64bits(f))
+	return append(buf, scratch[:]...)
+}
+
+func boundsEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
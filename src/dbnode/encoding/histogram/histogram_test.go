@@ -0,0 +1,65 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueMarshalRoundTrip(t *testing.T) {
+	v := Value{
+		Bounds: []float64{0.1, 1, 10, math.Inf(1)},
+		Counts: []uint64{3, 0, 1 << 40, 7},
+		Sum:    1234.5,
+	}
+	unmarshalled, err := Unmarshal(v.Marshal())
+	require.NoError(t, err)
+	require.Equal(t, v, unmarshalled)
+	require.Equal(t, uint64(1<<40+10), unmarshalled.Count())
+}
+
+func TestValueValidate(t *testing.T) {
+	tests := []struct {
+		value Value
+		err   error
+	}{
+		{value: Value{}, err: errNoBuckets},
+		{value: Value{Bounds: []float64{1, 2}, Counts: []uint64{1}}, err: errMismatchedBuckets},
+		{value: Value{Bounds: []float64{2, 1}, Counts: []uint64{1, 1}}, err: errUnsortedBounds},
+		{value: Value{Bounds: []float64{1, 1}, Counts: []uint64{1, 1}}, err: errUnsortedBounds},
+		{value: Value{Bounds: []float64{1}, Counts: []uint64{math.MaxUint64}}, err: errCountOverflow},
+		{value: Value{Bounds: []float64{1, 2}, Counts: []uint64{1, 1}}},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.err, test.value.Validate())
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	b := Value{Bounds: []float64{1, 2}, Counts: []uint64{1, 1}}.Marshal()
+	for _, invalid := range [][]byte{nil, b[:len(b)-1], append(b, 0)} {
+		_, err := Unmarshal(invalid)
+		require.Error(t, err)
+	}
+}
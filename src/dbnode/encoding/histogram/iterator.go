@@ -0,0 +1,213 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"io"
+	"math"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+)
+
+// ReaderIterator is an iterator over histogram encoded data.
+type ReaderIterator interface {
+	encoding.ReaderIterator
+
+	// Histogram returns the histogram of the current datapoint, users should
+	// not hold on to it as it is invalidated when the iterator calls Next().
+	Histogram() Value
+}
+
+type readerIterator struct {
+	is         encoding.IStream
+	opts       encoding.Options
+	tsIterator encoding.TimestampIterator
+
+	curr    Value   // current histogram
+	deltas  []int64 // current bucket count deltas
+	ant     []byte  // current histogram marshalled as an annotation
+	started bool    // whether the header has been read
+	err     error
+
+	closed bool
+}
+
+// NewReaderIterator returns a new iterator over histogram encoded data.
+func NewReaderIterator(reader io.Reader, opts encoding.Options) ReaderIterator {
+	if opts == nil {
+		opts = encoding.NewOptions()
+	}
+	return &readerIterator{
+		is:         encoding.NewIStream(reader),
+		opts:       opts,
+		tsIterator: encoding.NewTimestampIterator(opts),
+	}
+}
+
+// Next moves to the next item
+func (it *readerIterator) Next() bool {
+	if !it.hasNext() {
+		return false
+	}
+
+	first := !it.started
+	if first {
+		if it.err = encoding.ReadValueTypeHeader(it.is, encoding.HistogramValueType); it.err != nil {
+			return false
+		}
+		it.started = true
+	}
+	if it.err = it.tsIterator.ReadTimestamp(it.is); it.err != nil {
+		return false
+	}
+	if it.tsIterator.Done {
+		return false
+	}
+
+	if it.err = it.readHistogram(first); it.err != nil {
+		return false
+	}
+	it.ant = it.curr.MarshalTo(it.ant[:0])
+	return true
+}
+
+func (it *readerIterator) readHistogram(first bool) error {
+	if first {
+		if err := it.readBounds(); err != nil {
+			return err
+		}
+		if err := it.readCounts(); err != nil {
+			return err
+		}
+		sum, err := it.is.ReadBits(64)
+		if err != nil {
+			return err
+		}
+		it.curr.Sum = math.Float64frombits(sum)
+		return nil
+	}
+
+	opcode, err := it.is.ReadBit()
+	if err != nil {
+		return err
+	}
+	if opcode == opcodeChanged {
+		if err := it.readBounds(); err != nil {
+			return err
+		}
+	}
+	if err := it.readCounts(); err != nil {
+		return err
+	}
+	opcode, err = it.is.ReadBit()
+	if err != nil {
+		return err
+	}
+	if opcode == opcodeChanged {
+		sum, err := it.is.ReadBits(64)
+		if err != nil {
+			return err
+		}
+		it.curr.Sum = math.Float64frombits(sum)
+	}
+	return nil
+}
+
+func (it *readerIterator) readBounds() error {
+	numBuckets, err := it.is.ReadBits(numBucketsNumBits)
+	if err != nil {
+		return err
+	}
+	it.curr.Bounds = it.curr.Bounds[:0]
+	it.curr.Counts = it.curr.Counts[:0]
+	it.deltas = it.deltas[:0]
+	for i := 0; i < int(numBuckets); i++ {
+		bound, err := it.is.ReadBits(64)
+		if err != nil {
+			return err
+		}
+		it.curr.Bounds = append(it.curr.Bounds, math.Float64frombits(bound))
+		it.curr.Counts = append(it.curr.Counts, 0)
+		it.deltas = append(it.deltas, 0)
+	}
+	return nil
+}
+
+func (it *readerIterator) readCounts() error {
+	for i := range it.curr.Counts {
+		dod, err := encoding.ReadIntDelta(it.is)
+		if err != nil {
+			return err
+		}
+		it.deltas[i] += dod
+		it.curr.Counts[i] = uint64(int64(it.curr.Counts[i]) + it.deltas[i])
+	}
+	return nil
+}
+
+// Current returns the value as well as the annotation associated with the current datapoint.
+// Users should not hold on to the returned Annotation object as it may get invalidated when
+// the iterator calls Next().
+func (it *readerIterator) Current() (ts.Datapoint, xtime.Unit, ts.Annotation) {
+	return ts.Datapoint{
+		Timestamp: it.tsIterator.PrevTime,
+		Value:     float64(it.curr.Count()),
+	}, it.tsIterator.TimeUnit, it.ant
+}
+
+func (it *readerIterator) Histogram() Value {
+	return it.curr
+}
+
+// Err returns the error encountered
+func (it *readerIterator) Err() error {
+	return it.err
+}
+
+func (it *readerIterator) hasNext() bool {
+	return it.err == nil && !it.tsIterator.Done && !it.closed
+}
+
+func (it *readerIterator) Reset(reader io.Reader) {
+	it.is.Reset(reader)
+	it.tsIterator.Reset()
+	it.curr.Bounds = it.curr.Bounds[:0]
+	it.curr.Counts = it.curr.Counts[:0]
+	it.curr.Sum = 0
+	it.deltas = it.deltas[:0]
+	it.ant = it.ant[:0]
+	it.started = false
+	it.err = nil
+	it.closed = false
+}
+
+func (it *readerIterator) Close() {
+	if it.closed {
+		return
+	}
+	it.closed = true
+	pool := it.opts.ReaderIteratorPool()
+	if pool != nil {
+		pool.Put(it)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testStartTime = time.Unix(1427162400, 0)
+
+func TestHistogramRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(testStartTime.Unix()))
+	for i := 0; i < 100; i++ {
+		var (
+			bounds = []float64{0.005, 0.01, 0.1, 1, 10, math.Inf(1)}
+			counts = make([]uint64, len(bounds))
+			sum    float64
+			input  = make([]Value, 0, 100)
+		)
+		for j := 0; j < 100; j++ {
+			if r.Intn(20) == 0 {
+				// Change the bucket bounds.
+				bounds = append([]float64{r.Float64() * bounds[1]}, bounds[1:]...)
+			}
+			next := make([]uint64, len(counts))
+			for k := range counts {
+				next[k] = counts[k] + uint64(r.Intn(1000))
+			}
+			counts = next
+			if r.Intn(2) == 0 {
+				sum += r.Float64() * 100
+			}
+			input = append(input, Value{
+				Bounds: append([]float64(nil), bounds...),
+				Counts: counts,
+				Sum:    sum,
+			})
+		}
+		testRoundTrip(t, input)
+	}
+}
+
+func TestHistogramEncoderRejectsInvalidHistograms(t *testing.T) {
+	encoder := NewEncoder(testStartTime, nil, nil)
+	dp := ts.Datapoint{Timestamp: testStartTime}
+	require.Error(t, encoder.Encode(dp, xtime.Second, nil))
+	require.Error(t, encoder.Encode(dp, xtime.Second, ts.Annotation{0x1}))
+	require.Equal(t, 0, encoder.NumEncoded())
+}
+
+func testRoundTrip(t *testing.T, input []Value) {
+	encoder := NewEncoder(testStartTime, nil, nil)
+	for i, v := range input {
+		dp := ts.Datapoint{
+			Timestamp: testStartTime.Add(time.Duration(i) * time.Second),
+			Value:     float64(v.Count()),
+		}
+		require.NoError(t, encoder.Encode(dp, xtime.Second, v.Marshal()))
+	}
+
+	last, err := encoder.LastEncoded()
+	require.NoError(t, err)
+	require.Equal(t, float64(input[len(input)-1].Count()), last.Value)
+
+	it := NewReaderIterator(encoder.Stream(), nil)
+	defer it.Close()
+	i := 0
+	for it.Next() {
+		dp, _, ant := it.Current()
+		require.Equal(t, testStartTime.Add(time.Duration(i)*time.Second), dp.Timestamp)
+		require.Equal(t, float64(input[i].Count()), dp.Value)
+		require.Equal(t, input[i], it.Histogram())
+
+		unmarshalled, err := Unmarshal(ant)
+		require.NoError(t, err)
+		require.Equal(t, input[i], unmarshalled)
+		i++
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, len(input), i)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encoding
+
+// intDeltaBucket is a range of signed integer deltas written with an opcode
+// followed by a fixed number of bits.
+type intDeltaBucket struct {
+	opcode        uint64
+	numOpcodeBits int
+	numValueBits  int
+}
+
+var intDeltaBuckets = []intDeltaBucket{
+	{opcode: 0x2, numOpcodeBits: 2, numValueBits: 8},
+	{opcode: 0x6, numOpcodeBits: 3, numValueBits: 16},
+	{opcode: 0xE, numOpcodeBits: 4, numValueBits: 32},
+	{opcode: 0xF, numOpcodeBits: 4, numValueBits: 64},
+}
+
+// WriteIntDelta writes a signed integer delta, or delta of deltas, using the
+// fewest bits of a small set of sizes that fit it. A zero delta takes a
+// single bit so that steady series compress to a bit per value.
+func WriteIntDelta(os OStream, v int64) {
+	if v == 0 {
+		os.WriteBit(0)
+		return
+	}
+	for _, bucket := range intDeltaBuckets {
+		if bucket.numValueBits < 64 {
+			shift := uint(64 - bucket.numValueBits)
+			if (v<<shift)>>shift != v {
+				continue
+			}
+		}
+		os.WriteBits(bucket.opcode, bucket.numOpcodeBits)
+		os.WriteBits(uint64(v), bucket.numValueBits)
+		return
+	}
+}
+
+// ReadIntDelta reads a signed integer delta written by WriteIntDelta.
+func ReadIntDelta(is IStream) (int64, error) {
+	cb, err := is.ReadBits(1)
+	if err != nil {
+		return 0, err
+	}
+	if cb == 0 {
+		return 0, nil
+	}
+	for i, bucket := range intDeltaBuckets {
+		// NB: The opcode of the last bucket is known once the opcodes of all
+		// the other buckets have been ruled out.
+		if i < len(intDeltaBuckets)-1 {
+			next, err := is.ReadBits(1)
+			if err != nil {
+				return 0, err
+			}
+			cb = (cb << 1) | next
+		}
+		if cb == bucket.opcode || i == len(intDeltaBuckets)-1 {
+			bits, err := is.ReadBits(bucket.numValueBits)
+			if err != nil {
+				return 0, err
+			}
+			return SignExtend(bits, bucket.numValueBits), nil
+		}
+	}
+	return 0, nil
+}
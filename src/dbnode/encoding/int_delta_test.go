@@ -0,0 +1,50 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encoding
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntDeltaRoundTrip(t *testing.T) {
+	inputs := []int64{
+		0, 1, -1, 127, -128, 128, -129, math.MaxInt16, math.MinInt16,
+		math.MaxInt16 + 1, math.MaxInt32, math.MinInt32, math.MaxInt32 + 1,
+		math.MaxInt64, math.MinInt64,
+	}
+
+	os := NewOStream(nil, true, nil)
+	for _, v := range inputs {
+		WriteIntDelta(os, v)
+	}
+	b, _ := os.Rawbytes()
+
+	is := NewIStream(bytes.NewReader(b.Bytes()))
+	for _, expected := range inputs {
+		v, err := ReadIntDelta(is)
+		require.NoError(t, err)
+		require.Equal(t, expected, v)
+	}
+}
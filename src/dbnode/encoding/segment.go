@@ -0,0 +1,81 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encoding
+
+import (
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/checked"
+)
+
+// SegmentFromOStream returns the contents of an output stream as a segment
+// terminated by the end of stream marker. The contents are copied unless by
+// ref is set, in which case the segment takes ownership of them from the
+// stream. It is shared by encoders that use the default marker scheme.
+func SegmentFromOStream(os OStream, opts Options, byRef bool) ts.Segment {
+	length := os.Len()
+	if length == 0 {
+		return ts.Segment{}
+	}
+
+	// NB: A multibyte tail is required to capture an immutable snapshot of
+	// the stream since the last byte may still be partially written.
+	var head checked.Bytes
+	buffer, pos := os.Rawbytes()
+	lastByte := buffer.Bytes()[length-1]
+	if byRef {
+		head = os.Discard()
+
+		head.IncRef()
+		defer head.DecRef()
+
+		head.Resize(length - 1)
+	} else {
+		if bytesPool := opts.BytesPool(); bytesPool != nil {
+			head = bytesPool.Get(length - 1)
+		} else {
+			head = checked.NewBytes(make([]byte, 0, length-1), nil)
+		}
+
+		head.IncRef()
+		defer head.DecRef()
+
+		head.AppendAll(buffer.Bytes()[:length-1])
+	}
+
+	scheme := opts.MarkerEncodingScheme()
+	tail := scheme.Tail(lastByte, pos)
+	return ts.NewSegment(head, tail, ts.FinalizeHead)
+}
+
+// SegmentReader returns a reader for a segment, using the segment reader pool
+// if one is set, or nil if the segment is empty.
+func SegmentReader(segment ts.Segment, opts Options) xio.SegmentReader {
+	if segment.Len() == 0 {
+		return nil
+	}
+	if readerPool := opts.SegmentReaderPool(); readerPool != nil {
+		reader := readerPool.Get()
+		reader.Reset(segment)
+		return reader
+	}
+	return xio.NewSegmentReader(segment)
+}
@@ -0,0 +1,359 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encoding
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+)
+
+// TimestampEncoder encodes the timestamps of a stream along with the time
+// unit and annotation markers interleaved with them in the same format as
+// m3tsz, it is shared by encoders that only differ in how values are encoded.
+type TimestampEncoder struct {
+	PrevTime       time.Time
+	PrevTimeDelta  time.Duration
+	PrevAnnotation ts.Annotation
+	TimeUnit       xtime.Unit
+
+	opts Options
+}
+
+// NewTimestampEncoder creates a new timestamp encoder.
+func NewTimestampEncoder(start time.Time, opts Options) TimestampEncoder {
+	return TimestampEncoder{
+		PrevTime: start,
+		TimeUnit: InitialTimeUnit(start, opts.DefaultTimeUnit()),
+		opts:     opts,
+	}
+}
+
+// WriteFirstTime writes the start time followed by the first timestamp.
+func (enc *TimestampEncoder) WriteFirstTime(
+	os OStream,
+	t time.Time,
+	ant ts.Annotation,
+	tu xtime.Unit,
+) error {
+	// NB: Always write the start time in nanoseconds because it is not known
+	// whether it is a multiple of the time unit provided.
+	nt := xtime.ToNormalizedTime(enc.PrevTime, time.Nanosecond)
+	os.WriteBits(uint64(nt), 64)
+	return enc.WriteNextTime(os, t, ant, tu)
+}
+
+// WriteNextTime writes a timestamp preceded by annotation and time unit
+// markers if either have changed.
+func (enc *TimestampEncoder) WriteNextTime(
+	os OStream,
+	t time.Time,
+	ant ts.Annotation,
+	tu xtime.Unit,
+) error {
+	enc.writeAnnotation(os, ant)
+	tuChanged := enc.writeTimeUnit(os, tu)
+
+	dt := t.Sub(enc.PrevTime)
+	enc.PrevTime = t
+	if tuChanged {
+		// NB: If the time unit has changed the delta of delta is written in
+		// nanoseconds and the time delta is reset to zero since it is not
+		// necessarily a multiple of the new time unit.
+		os.WriteBits(uint64(int64(dt-enc.PrevTimeDelta)), 64)
+		enc.PrevTimeDelta = 0
+		return nil
+	}
+	err := enc.writeDeltaOfDelta(os, enc.PrevTimeDelta, dt, tu)
+	enc.PrevTimeDelta = dt
+	return err
+}
+
+// Reset resets the timestamp encoder to encode a new stream.
+func (enc *TimestampEncoder) Reset(start time.Time) {
+	enc.PrevTime = start
+	enc.PrevTimeDelta = 0
+	enc.PrevAnnotation = nil
+	enc.TimeUnit = InitialTimeUnit(start, enc.opts.DefaultTimeUnit())
+}
+
+func (enc *TimestampEncoder) writeAnnotation(os OStream, ant ts.Annotation) {
+	if len(ant) == 0 || bytes.Equal(ant, enc.PrevAnnotation) {
+		return
+	}
+	scheme := enc.opts.MarkerEncodingScheme()
+	WriteSpecialMarker(os, scheme, scheme.Annotation())
+
+	var buf [binary.MaxVarintLen32]byte
+	// NB: The length is written minus one for possible varint savings.
+	annotationLength := binary.PutVarint(buf[:], int64(len(ant)-1))
+	os.WriteBytes(buf[:annotationLength])
+	os.WriteBytes(ant)
+	enc.PrevAnnotation = ant
+}
+
+func (enc *TimestampEncoder) writeTimeUnit(os OStream, tu xtime.Unit) bool {
+	if !tu.IsValid() || tu == enc.TimeUnit {
+		return false
+	}
+	scheme := enc.opts.MarkerEncodingScheme()
+	WriteSpecialMarker(os, scheme, scheme.TimeUnit())
+	os.WriteByte(byte(tu))
+	enc.TimeUnit = tu
+	return true
+}
+
+func (enc *TimestampEncoder) writeDeltaOfDelta(
+	os OStream,
+	prevDelta, curDelta time.Duration,
+	tu xtime.Unit,
+) error {
+	u, err := tu.Value()
+	if err != nil {
+		return err
+	}
+	deltaOfDelta := xtime.ToNormalizedDuration(curDelta-prevDelta, u)
+	tes, exists := enc.opts.TimeEncodingSchemes()[tu]
+	if !exists {
+		return fmt.Errorf("time encoding scheme for time unit %v doesn't exist", tu)
+	}
+
+	if deltaOfDelta == 0 {
+		zeroBucket := tes.ZeroBucket()
+		os.WriteBits(zeroBucket.Opcode(), zeroBucket.NumOpcodeBits())
+		return nil
+	}
+	buckets := tes.Buckets()
+	for i := 0; i < len(buckets); i++ {
+		if deltaOfDelta >= buckets[i].Min() && deltaOfDelta <= buckets[i].Max() {
+			os.WriteBits(buckets[i].Opcode(), buckets[i].NumOpcodeBits())
+			os.WriteBits(uint64(deltaOfDelta), buckets[i].NumValueBits())
+			return nil
+		}
+	}
+	defaultBucket := tes.DefaultBucket()
+	os.WriteBits(defaultBucket.Opcode(), defaultBucket.NumOpcodeBits())
+	os.WriteBits(uint64(deltaOfDelta), defaultBucket.NumValueBits())
+	return nil
+}
+
+// TimestampIterator reads the timestamps written by a TimestampEncoder.
+type TimestampIterator struct {
+	PrevTime        time.Time
+	PrevTimeDelta   time.Duration
+	PrevAnnotation  ts.Annotation
+	TimeUnit        xtime.Unit
+	TimeUnitChanged bool
+	Done            bool
+
+	opts Options
+}
+
+// NewTimestampIterator creates a new timestamp iterator.
+func NewTimestampIterator(opts Options) TimestampIterator {
+	return TimestampIterator{opts: opts}
+}
+
+// ReadTimestamp reads the next timestamp along with any markers preceding it,
+// Done is set instead if the end of stream marker is read.
+func (it *TimestampIterator) ReadTimestamp(is IStream) error {
+	it.PrevAnnotation = nil
+	it.TimeUnitChanged = false
+
+	if !it.PrevTime.IsZero() {
+		dod, err := it.readMarkerOrDeltaOfDelta(is)
+		if err != nil {
+			return err
+		}
+		it.PrevTimeDelta += dod
+		it.PrevTime = it.PrevTime.Add(it.PrevTimeDelta)
+	} else {
+		// NB: The start time is always written in nanoseconds.
+		nt, err := is.ReadBits(64)
+		if err != nil {
+			return err
+		}
+		start := xtime.FromNormalizedTime(int64(nt), time.Nanosecond)
+		it.TimeUnit = InitialTimeUnit(start, it.opts.DefaultTimeUnit())
+		dod, err := it.readMarkerOrDeltaOfDelta(is)
+		if err != nil {
+			return err
+		}
+		it.PrevTimeDelta += dod
+		it.PrevTime = start.Add(it.PrevTimeDelta)
+	}
+
+	// NB: Reset the time delta when the time unit changes to be consistent
+	// with the encoder.
+	if it.TimeUnitChanged {
+		it.PrevTimeDelta = 0
+	}
+	return nil
+}
+
+// Reset resets the timestamp iterator to read a new stream.
+func (it *TimestampIterator) Reset() {
+	it.PrevTime = time.Time{}
+	it.PrevTimeDelta = 0
+	it.PrevAnnotation = nil
+	it.TimeUnit = xtime.None
+	it.TimeUnitChanged = false
+	it.Done = false
+}
+
+func (it *TimestampIterator) readMarkerOrDeltaOfDelta(is IStream) (time.Duration, error) {
+	mes := it.opts.MarkerEncodingScheme()
+	numBits := mes.NumOpcodeBits() + mes.NumValueBits()
+	if opcodeAndValue, err := is.PeekBits(numBits); err == nil &&
+		opcodeAndValue>>uint(mes.NumValueBits()) == mes.Opcode() {
+		valueMask := (1 << uint(mes.NumValueBits())) - 1
+		marker := Marker(opcodeAndValue & uint64(valueMask))
+		switch marker {
+		case mes.EndOfStream():
+			if _, err := is.ReadBits(numBits); err != nil {
+				return 0, err
+			}
+			it.Done = true
+			return 0, nil
+		case mes.Annotation():
+			if _, err := is.ReadBits(numBits); err != nil {
+				return 0, err
+			}
+			if err := it.readAnnotation(is); err != nil {
+				return 0, err
+			}
+			return it.readMarkerOrDeltaOfDelta(is)
+		case mes.TimeUnit():
+			if _, err := is.ReadBits(numBits); err != nil {
+				return 0, err
+			}
+			if err := it.readTimeUnit(is); err != nil {
+				return 0, err
+			}
+			return it.readMarkerOrDeltaOfDelta(is)
+		}
+	}
+
+	tes, exists := it.opts.TimeEncodingSchemes()[it.TimeUnit]
+	if !exists {
+		return 0, fmt.Errorf("time encoding scheme for time unit %v doesn't exist", it.TimeUnit)
+	}
+	return it.readDeltaOfDelta(is, tes)
+}
+
+func (it *TimestampIterator) readDeltaOfDelta(is IStream, tes TimeEncodingScheme) (time.Duration, error) {
+	if it.TimeUnitChanged {
+		// NB: If the time unit has changed the delta of delta is always
+		// written in nanoseconds.
+		dod, err := is.ReadBits(64)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(SignExtend(dod, 64)), nil
+	}
+
+	u, err := it.TimeUnit.Value()
+	if err != nil {
+		return 0, err
+	}
+
+	cb, err := is.ReadBits(1)
+	if err != nil {
+		return 0, err
+	}
+	if cb == tes.ZeroBucket().Opcode() {
+		return 0, nil
+	}
+	buckets := tes.Buckets()
+	for i := 0; i < len(buckets); i++ {
+		next, err := is.ReadBits(1)
+		if err != nil {
+			return 0, err
+		}
+		cb = (cb << 1) | next
+		if cb == buckets[i].Opcode() {
+			return it.readBucketValue(is, buckets[i].NumValueBits(), u)
+		}
+	}
+	return it.readBucketValue(is, tes.DefaultBucket().NumValueBits(), u)
+}
+
+func (it *TimestampIterator) readBucketValue(is IStream, numValueBits int, u time.Duration) (time.Duration, error) {
+	bits, err := is.ReadBits(numValueBits)
+	if err != nil {
+		return 0, err
+	}
+	return xtime.FromNormalizedDuration(SignExtend(bits, numValueBits), u), nil
+}
+
+func (it *TimestampIterator) readAnnotation(is IStream) error {
+	// NB: The length is written minus one.
+	antLen, err := binary.ReadVarint(is)
+	if err != nil {
+		return err
+	}
+	antLen++
+	if antLen <= 0 {
+		return fmt.Errorf("unexpected annotation length %d", antLen)
+	}
+	buf := make([]byte, antLen)
+	for i := range buf {
+		b, err := is.ReadByte()
+		if err != nil {
+			return err
+		}
+		buf[i] = b
+	}
+	it.PrevAnnotation = buf
+	return nil
+}
+
+func (it *TimestampIterator) readTimeUnit(is IStream) error {
+	b, err := is.ReadByte()
+	if err != nil {
+		return err
+	}
+	tu := xtime.Unit(b)
+	if tu.IsValid() && tu != it.TimeUnit {
+		it.TimeUnitChanged = true
+	}
+	it.TimeUnit = tu
+	return nil
+}
+
+// InitialTimeUnit returns the time unit of a stream starting at the given
+// time, which is the given time unit if the start is a multiple of it.
+func InitialTimeUnit(start time.Time, tu xtime.Unit) xtime.Unit {
+	tv, err := tu.Value()
+	if err != nil {
+		return xtime.None
+	}
+	startInNano := xtime.ToNormalizedTime(start, time.Nanosecond)
+	tvInNano := xtime.ToNormalizedDuration(tv, time.Nanosecond)
+	if startInNano%tvInNano == 0 {
+		return tu
+	}
+	return xtime.None
+}
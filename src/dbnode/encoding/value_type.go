@@ -0,0 +1,142 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encoding
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ValueType is the type of the values held by an encoded stream.
+type ValueType byte
+
+const (
+	// FloatValueType is a stream of float64 values encoded with m3tsz, the
+	// default value type. Float streams have no value type header.
+	FloatValueType ValueType = iota
+	// CounterValueType is a stream of monotonic counter values.
+	CounterValueType
+	// HistogramValueType is a stream of bucketed histograms.
+	HistogramValueType
+)
+
+const (
+	// NB: The value type header takes the place of the start time that an
+	// m3tsz stream begins with, the magic leaves it interpreted as a time a
+	// few hundred nanoseconds before the epoch which is never a block start.
+	valueTypeHeaderMagic   = uint64(0xFFFFFFFFFFFFFF00)
+	valueTypeHeaderMask    = uint64(0xFF)
+	valueTypeHeaderNumBits = 64
+
+	// ValueTypeHeaderLen is the length in bytes of the value type header.
+	ValueTypeHeaderLen = valueTypeHeaderNumBits / 8
+)
+
+var validValueTypes = []ValueType{
+	FloatValueType,
+	CounterValueType,
+	HistogramValueType,
+}
+
+// ValidValueTypes returns the valid value types.
+func ValidValueTypes() []ValueType {
+	return validValueTypes
+}
+
+// Validate validates the value type.
+func (t ValueType) Validate() error {
+	for _, valid := range validValueTypes {
+		if t == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value type: %d", t)
+}
+
+func (t ValueType) String() string {
+	switch t {
+	case FloatValueType:
+		return "float"
+	case CounterValueType:
+		return "counter"
+	case HistogramValueType:
+		return "histogram"
+	}
+	return "unknown"
+}
+
+// UnmarshalYAML unmarshals a value type from its string representation.
+func (t *ValueType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	if str == "" {
+		*t = FloatValueType
+		return nil
+	}
+	for _, valid := range validValueTypes {
+		if str == valid.String() {
+			*t = valid
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value type '%s' valid types are: %v",
+		str, validValueTypes)
+}
+
+// WriteValueTypeHeader writes the header identifying the value type at the
+// start of a stream, it must not be written for float streams.
+func WriteValueTypeHeader(os OStream, t ValueType) {
+	os.WriteBits(valueTypeHeaderMagic|uint64(t), valueTypeHeaderNumBits)
+}
+
+// ReadValueTypeHeader reads the header identifying the value type at the start
+// of a stream and checks it matches the expected value type.
+func ReadValueTypeHeader(is IStream, expected ValueType) error {
+	header, err := is.ReadBits(valueTypeHeaderNumBits)
+	if err != nil {
+		return err
+	}
+	if t, ok := valueTypeFromHeader(header); !ok || t != expected {
+		return fmt.Errorf("stream does not have a %s value type header", expected)
+	}
+	return nil
+}
+
+// ValueTypeOf returns the value type of a stream given at least its first
+// ValueTypeHeaderLen bytes, streams without a header are float streams.
+func ValueTypeOf(b []byte) ValueType {
+	if len(b) < ValueTypeHeaderLen {
+		return FloatValueType
+	}
+	if t, ok := valueTypeFromHeader(binary.BigEndian.Uint64(b)); ok {
+		return t
+	}
+	return FloatValueType
+}
+
+func valueTypeFromHeader(header uint64) (ValueType, bool) {
+	if header&^valueTypeHeaderMask != valueTypeHeaderMagic {
+		return 0, false
+	}
+	return ValueType(header & valueTypeHeaderMask), true
+}
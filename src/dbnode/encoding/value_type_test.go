@@ -0,0 +1,61 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encoding
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestValueTypeHeaderRoundTrip(t *testing.T) {
+	for _, valueType := range []ValueType{CounterValueType, HistogramValueType} {
+		os := NewOStream(nil, true, nil)
+		WriteValueTypeHeader(os, valueType)
+		b, _ := os.Rawbytes()
+		require.Equal(t, valueType, ValueTypeOf(b.Bytes()))
+
+		is := NewIStream(bytes.NewReader(b.Bytes()))
+		require.NoError(t, ReadValueTypeHeader(is, valueType))
+
+		is = NewIStream(bytes.NewReader(b.Bytes()))
+		require.Error(t, ReadValueTypeHeader(is, FloatValueType))
+	}
+}
+
+func TestValueTypeOfFloatStream(t *testing.T) {
+	require.Equal(t, FloatValueType, ValueTypeOf(nil))
+	require.Equal(t, FloatValueType, ValueTypeOf([]byte{0xff, 0xff}))
+	require.Equal(t, FloatValueType, ValueTypeOf([]byte{0x14, 0xff, 0x3e, 0x5f, 0x4c, 0x48, 0x00, 0x00, 0x12}))
+}
+
+func TestValueTypeUnmarshalYAML(t *testing.T) {
+	for _, valueType := range ValidValueTypes() {
+		var v ValueType
+		require.NoError(t, yaml.Unmarshal([]byte(valueType.String()), &v))
+		require.Equal(t, valueType, v)
+	}
+
+	var v ValueType
+	require.Error(t, yaml.Unmarshal([]byte("gauge"), &v))
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package valuetype
+
+import (
+	"io"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/counter"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+)
+
+// ReaderIterator is an iterator over encoded data of any value type.
+type ReaderIterator interface {
+	encoding.ReaderIterator
+
+	// ValueType returns the value type of the stream being read.
+	ValueType() encoding.ValueType
+
+	// CounterReset returns whether the counter was reset at the current
+	// datapoint, it is always false for streams that are not counters.
+	CounterReset() bool
+
+	// Histogram returns the histogram of the current datapoint and whether
+	// the stream being read is a histogram stream.
+	Histogram() (histogram.Value, bool)
+}
+
+type readerIterator struct {
+	opts         encoding.Options
+	iterOpts     encoding.Options
+	intOptimized bool
+	reader       headerReader
+
+	valueType encoding.ValueType
+	current   encoding.ReaderIterator
+	float     encoding.ReaderIterator
+	counter   counter.ReaderIterator
+	histogram histogram.ReaderIterator
+	err       error
+
+	closed bool
+}
+
+// NewReaderIterator returns a new iterator over encoded data of any value
+// type, float streams are read with int optimization as specified.
+func NewReaderIterator(
+	reader io.Reader,
+	intOptimized bool,
+	opts encoding.Options,
+) ReaderIterator {
+	if opts == nil {
+		opts = encoding.NewOptions()
+	}
+	it := &readerIterator{
+		opts: opts,
+		// NB: The underlying iterators must not return themselves to the
+		// pool as only this iterator is pooled.
+		iterOpts:     opts.SetReaderIteratorPool(nil),
+		intOptimized: intOptimized,
+	}
+	it.Reset(reader)
+	return it
+}
+
+// Next moves to the next item
+func (it *readerIterator) Next() bool {
+	if it.err != nil || it.closed {
+		return false
+	}
+	return it.current.Next()
+}
+
+// Current returns the value as well as the annotation associated with the current datapoint.
+// Users should not hold on to the returned Annotation object as it may get invalidated when
+// the iterator calls Next().
+func (it *readerIterator) Current() (ts.Datapoint, xtime.Unit, ts.Annotation) {
+	return it.current.Current()
+}
+
+// Err returns the error encountered
+func (it *readerIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.current.Err()
+}
+
+func (it *readerIterator) ValueType() encoding.ValueType {
+	return it.valueType
+}
+
+func (it *readerIterator) CounterReset() bool {
+	if it.valueType != encoding.CounterValueType {
+		return false
+	}
+	return it.counter.CounterReset()
+}
+
+func (it *readerIterator) Histogram() (histogram.Value, bool) {
+	if it.valueType != encoding.HistogramValueType {
+		return histogram.Value{}, false
+	}
+	return it.histogram.Histogram(), true
+}
+
+func (it *readerIterator) Reset(reader io.Reader) {
+	it.err = nil
+	it.closed = false
+	if reader == nil {
+		it.reader.reset(nil)
+		it.valueType = encoding.FloatValueType
+		it.current = it.floatIterator()
+		it.current.Reset(nil)
+		return
+	}
+
+	it.valueType, it.err = it.reader.resetAndReadHeader(reader)
+	switch it.valueType {
+	case encoding.CounterValueType:
+		it.current = it.counterIterator()
+	case encoding.HistogramValueType:
+		it.current = it.histogramIterator()
+	default:
+		it.current = it.floatIterator()
+	}
+	it.current.Reset(&it.reader)
+}
+
+func (it *readerIterator) floatIterator() encoding.ReaderIterator {
+	if it.float == nil {
+		it.float = m3tsz.NewReaderIterator(nil, it.intOptimized, it.iterOpts)
+	}
+	return it.float
+}
+
+func (it *readerIterator) counterIterator() encoding.ReaderIterator {
+	if it.counter == nil {
+		it.counter = counter.NewReaderIterator(nil, it.iterOpts)
+	}
+	return it.counter
+}
+
+func (it *readerIterator) histogramIterator() encoding.ReaderIterator {
+	if it.histogram == nil {
+		it.histogram = histogram.NewReaderIterator(nil, it.iterOpts)
+	}
+	return it.histogram
+}
+
+func (it *readerIterator) Close() {
+	if it.closed {
+		return
+	}
+	it.closed = true
+	it.current.Reset(nil)
+	it.reader.reset(nil)
+	pool := it.opts.ReaderIteratorPool()
+	if pool != nil {
+		pool.Put(it)
+	}
+}
+
+// headerReader reads the value type header of a stream and then replays it
+// ahead of the rest of the stream.
+type headerReader struct {
+	r      io.Reader
+	header [encoding.ValueTypeHeaderLen]byte
+	peeked []byte
+}
+
+func (r *headerReader) reset(reader io.Reader) {
+	r.r = reader
+	r.peeked = nil
+}
+
+func (r *headerReader) resetAndReadHeader(reader io.Reader) (encoding.ValueType, error) {
+	r.reset(reader)
+	n, err := io.ReadFull(reader, r.header[:])
+	r.peeked = r.header[:n]
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return encoding.FloatValueType, err
+	}
+	return encoding.ValueTypeOf(r.peeked), nil
+}
+
+func (r *headerReader) Read(p []byte) (int, error) {
+	if len(r.peeked) > 0 {
+		n := copy(p, r.peeked)
+		r.peeked = r.peeked[n:]
+		return n, nil
+	}
+	return r.r.Read(p)
+}
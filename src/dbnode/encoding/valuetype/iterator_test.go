@@ -0,0 +1,102 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package valuetype
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testStartTime = time.Unix(1427162400, 0)
+
+func TestReaderIteratorDetectsValueType(t *testing.T) {
+	h := histogram.Value{
+		Bounds: []float64{1, 10, 100},
+		Counts: []uint64{4, 5, 6},
+		Sum:    345.6,
+	}
+	ant := ts.Annotation(h.Marshal())
+
+	var (
+		it    ReaderIterator
+		types = []encoding.ValueType{
+			encoding.FloatValueType,
+			encoding.CounterValueType,
+			encoding.HistogramValueType,
+			encoding.FloatValueType,
+		}
+	)
+	for _, valueType := range types {
+		encoder := NewEncoder(valueType, testStartTime, nil, true, nil)
+		for i := 0; i < 10; i++ {
+			dp := ts.Datapoint{
+				Timestamp: testStartTime.Add(time.Duration(i) * time.Second),
+				Value:     float64(15),
+			}
+			require.NoError(t, encoder.Encode(dp, xtime.Second, ant))
+		}
+
+		if it == nil {
+			it = NewReaderIterator(encoder.Stream(), true, nil)
+		} else {
+			it.Reset(encoder.Stream())
+		}
+		require.Equal(t, valueType, it.ValueType())
+
+		n := 0
+		for it.Next() {
+			dp, _, _ := it.Current()
+			require.Equal(t, testStartTime.Add(time.Duration(n)*time.Second), dp.Timestamp)
+			require.Equal(t, float64(15), dp.Value)
+			require.False(t, it.CounterReset())
+
+			v, ok := it.Histogram()
+			require.Equal(t, valueType == encoding.HistogramValueType, ok)
+			if ok {
+				require.Equal(t, h, v)
+			}
+			n++
+		}
+		require.NoError(t, it.Err())
+		require.Equal(t, 10, n)
+	}
+	it.Close()
+}
+
+func TestReaderIteratorEmptyStream(t *testing.T) {
+	it := NewReaderIterator(nil, true, nil)
+	it.Reset(&emptyReader{})
+	require.Equal(t, encoding.FloatValueType, it.ValueType())
+	require.False(t, it.Next())
+	it.Close()
+}
+
+type emptyReader struct{}
+
+func (r *emptyReader) Read(p []byte) (int, error) { return 0, io.EOF }
@@ -0,0 +1,71 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package valuetype provides encoders and iterators for streams of any value
+// type, iterators detect the value type of each stream they are reset with.
+package valuetype
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/counter"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3x/checked"
+	"github.com/m3db/m3x/pool"
+)
+
+var timeZero time.Time
+
+// NewEncoder creates a new encoder for the given value type.
+func NewEncoder(
+	valueType encoding.ValueType,
+	start time.Time,
+	bytes checked.Bytes,
+	intOptimized bool,
+	opts encoding.Options,
+) encoding.Encoder {
+	switch valueType {
+	case encoding.CounterValueType:
+		return counter.NewEncoder(start, bytes, opts)
+	case encoding.HistogramValueType:
+		return histogram.NewEncoder(start, bytes, opts)
+	default:
+		return m3tsz.NewEncoder(start, bytes, intOptimized, opts)
+	}
+}
+
+// NewEncoderPool returns an initialized pool of encoders for the given value
+// type, encoders are returned to the pool on close and share the other pools
+// of the given encoding options.
+func NewEncoderPool(
+	valueType encoding.ValueType,
+	poolOpts pool.ObjectPoolOptions,
+	opts encoding.Options,
+) encoding.EncoderPool {
+	encoderPool := encoding.NewEncoderPool(poolOpts)
+	encodingOpts := opts.SetEncoderPool(encoderPool)
+	encoderPool.Init(func() encoding.Encoder {
+		return NewEncoder(valueType, timeZero, nil,
+			m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
+	})
+	return encoderPool
+}
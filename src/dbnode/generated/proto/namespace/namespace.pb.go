@@ -52,6 +52,30 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+type ValueType int32
+
+const (
+	ValueType_FLOAT     ValueType = 0
+	ValueType_COUNTER   ValueType = 1
+	ValueType_HISTOGRAM ValueType = 2
+)
+
+var ValueType_name = map[int32]string{
+	0: "FLOAT",
+	1: "COUNTER",
+	2: "HISTOGRAM",
+}
+var ValueType_value = map[string]int32{
+	"FLOAT":     0,
+	"COUNTER":   1,
+	"HISTOGRAM": 2,
+}
+
+func (x ValueType) String() string {
+	return proto.EnumName(ValueType_name, int32(x))
+}
+func (ValueType) EnumDescriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{0} }
+
 type RetentionOptions struct {
 	RetentionPeriodNanos                     int64 `protobuf:"varint,1,opt,name=retentionPeriodNanos,proto3" json:"retentionPeriodNanos,omitempty"`
 	BlockSizeNanos                           int64 `protobuf:"varint,2,opt,name=blockSizeNanos,proto3" json:"blockSizeNanos,omitempty"`
@@ -141,6 +165,8 @@ type NamespaceOptions struct {
 	RetentionOptions  *RetentionOptions `protobuf:"bytes,6,opt,name=retentionOptions" json:"retentionOptions,omitempty"`
 	SnapshotEnabled   bool              `protobuf:"varint,7,opt,name=snapshotEnabled,proto3" json:"snapshotEnabled,omitempty"`
 	IndexOptions      *IndexOptions     `protobuf:"bytes,8,opt,name=indexOptions" json:"indexOptions,omitempty"`
+	ColdWritesEnabled bool              `protobuf:"varint,9,opt,name=coldWritesEnabled,proto3" json:"coldWritesEnabled,omitempty"`
+	ValueType         ValueType         `protobuf:"varint,10,opt,name=valueType,proto3,enum=namespace.ValueType" json:"valueType,omitempty"`
 }
 
 func (m *NamespaceOptions) Reset()                    { *m = NamespaceOptions{} }
@@ -204,6 +230,20 @@ func (m *NamespaceOptions) GetIndexOptions() *IndexOptions {
 	return nil
 }
 
+func (m *NamespaceOptions) GetColdWritesEnabled() bool {
+	if m != nil {
+		return m.ColdWritesEnabled
+	}
+	return false
+}
+
+func (m *NamespaceOptions) GetValueType() ValueType {
+	if m != nil {
+		return m.ValueType
+	}
+	return ValueType_FLOAT
+}
+
 type Registry struct {
 	Namespaces map[string]*NamespaceOptions `protobuf:"bytes,1,rep,name=namespaces" json:"namespaces,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
 }
@@ -225,6 +265,7 @@ func init() {
 	proto.RegisterType((*IndexOptions)(nil), "namespace.IndexOptions")
 	proto.RegisterType((*NamespaceOptions)(nil), "namespace.NamespaceOptions")
 	proto.RegisterType((*Registry)(nil), "namespace.Registry")
+	proto.RegisterEnum("namespace.ValueType", ValueType_name, ValueType_value)
 }
 func (m *RetentionOptions) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -407,6 +448,21 @@ func (m *NamespaceOptions) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n2
 	}
+	if m.ColdWritesEnabled {
+		dAtA[i] = 0x48
+		i++
+		if m.ColdWritesEnabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.ValueType != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.ValueType))
+	}
 	return i, nil
 }
 
@@ -530,6 +586,12 @@ func (m *NamespaceOptions) Size() (n int) {
 		l = m.IndexOptions.Size()
 		n += 1 + l + sovNamespace(uint64(l))
 	}
+	if m.ColdWritesEnabled {
+		n += 2
+	}
+	if m.ValueType != 0 {
+		n += 1 + sovNamespace(uint64(m.ValueType))
+	}
 	return n
 }
 
@@ -1034,6 +1096,45 @@ func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColdWritesEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ColdWritesEnabled = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueType", wireType)
+			}
+			m.ValueType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValueType |= (ValueType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNamespace(dAtA[iNdEx:])
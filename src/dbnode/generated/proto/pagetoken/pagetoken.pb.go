@@ -52,6 +52,7 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type PageToken struct {
 	ActiveSeriesPhase  *PageToken_ActiveSeriesPhase  `protobuf:"bytes,1,opt,name=active_series_phase,json=activeSeriesPhase" json:"active_series_phase,omitempty"`
 	FlushedSeriesPhase *PageToken_FlushedSeriesPhase `protobuf:"bytes,2,opt,name=flushed_series_phase,json=flushedSeriesPhase" json:"flushed_series_phase,omitempty"`
+	FetchTaggedPhase   *PageToken_FetchTaggedPhase   `protobuf:"bytes,3,opt,name=fetch_tagged_phase,json=fetchTaggedPhase" json:"fetch_tagged_phase,omitempty"`
 }
 
 func (m *PageToken) Reset()                    { *m = PageToken{} }
@@ -73,6 +74,13 @@ func (m *PageToken) GetFlushedSeriesPhase() *PageToken_FlushedSeriesPhase {
 	return nil
 }
 
+func (m *PageToken) GetFetchTaggedPhase() *PageToken_FetchTaggedPhase {
+	if m != nil {
+		return m.FetchTaggedPhase
+	}
+	return nil
+}
+
 type PageToken_ActiveSeriesPhase struct {
 	IndexCursor int64 `protobuf:"varint,1,opt,name=indexCursor,proto3" json:"indexCursor,omitempty"`
 }
@@ -117,10 +125,37 @@ func (m *PageToken_FlushedSeriesPhase) GetCurrBlockEntryIdx() int64 {
 	return 0
 }
 
+type PageToken_FetchTaggedPhase struct {
+	Shard uint32 `protobuf:"varint,1,opt,name=shard,proto3" json:"shard,omitempty"`
+	Id    []byte `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *PageToken_FetchTaggedPhase) Reset()         { *m = PageToken_FetchTaggedPhase{} }
+func (m *PageToken_FetchTaggedPhase) String() string { return proto.CompactTextString(m) }
+func (*PageToken_FetchTaggedPhase) ProtoMessage()    {}
+func (*PageToken_FetchTaggedPhase) Descriptor() ([]byte, []int) {
+	return fileDescriptorPagetoken, []int{0, 2}
+}
+
+func (m *PageToken_FetchTaggedPhase) GetShard() uint32 {
+	if m != nil {
+		return m.Shard
+	}
+	return 0
+}
+
+func (m *PageToken_FetchTaggedPhase) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*PageToken)(nil), "pagetoken.PageToken")
 	proto.RegisterType((*PageToken_ActiveSeriesPhase)(nil), "pagetoken.PageToken.ActiveSeriesPhase")
 	proto.RegisterType((*PageToken_FlushedSeriesPhase)(nil), "pagetoken.PageToken.FlushedSeriesPhase")
+	proto.RegisterType((*PageToken_FetchTaggedPhase)(nil), "pagetoken.PageToken.FetchTaggedPhase")
 }
 func (m *PageToken) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -157,6 +192,16 @@ func (m *PageToken) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n2
 	}
+	if m.FetchTaggedPhase != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPagetoken(dAtA, i, uint64(m.FetchTaggedPhase.Size()))
+		n3, err := m.FetchTaggedPhase.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
 	return i, nil
 }
 
@@ -211,6 +256,35 @@ func (m *PageToken_FlushedSeriesPhase) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *PageToken_FetchTaggedPhase) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PageToken_FetchTaggedPhase) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Shard != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPagetoken(dAtA, i, uint64(m.Shard))
+	}
+	if len(m.Id) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPagetoken(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	return i, nil
+}
+
 func encodeVarintPagetoken(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -231,6 +305,10 @@ func (m *PageToken) Size() (n int) {
 		l = m.FlushedSeriesPhase.Size()
 		n += 1 + l + sovPagetoken(uint64(l))
 	}
+	if m.FetchTaggedPhase != nil {
+		l = m.FetchTaggedPhase.Size()
+		n += 1 + l + sovPagetoken(uint64(l))
+	}
 	return n
 }
 
@@ -255,6 +333,19 @@ func (m *PageToken_FlushedSeriesPhase) Size() (n int) {
 	return n
 }
 
+func (m *PageToken_FetchTaggedPhase) Size() (n int) {
+	var l int
+	_ = l
+	if m.Shard != 0 {
+		n += 1 + sovPagetoken(uint64(m.Shard))
+	}
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovPagetoken(uint64(l))
+	}
+	return n
+}
+
 func sovPagetoken(x uint64) (n int) {
 	for {
 		n++
@@ -363,6 +454,39 @@ func (m *PageToken) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FetchTaggedPhase", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPagetoken
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPagetoken
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FetchTaggedPhase == nil {
+				m.FetchTaggedPhase = &PageToken_FetchTaggedPhase{}
+			}
+			if err := m.FetchTaggedPhase.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPagetoken(dAtA[iNdEx:])
@@ -541,6 +665,106 @@ func (m *PageToken_FlushedSeriesPhase) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *PageToken_FetchTaggedPhase) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPagetoken
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FetchTaggedPhase: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FetchTaggedPhase: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+			}
+			m.Shard = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPagetoken
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Shard |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPagetoken
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPagetoken
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
+			if m.Id == nil {
+				m.Id = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPagetoken(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPagetoken
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipPagetoken(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -651,24 +875,27 @@ func init() {
 }
 
 var fileDescriptorPagetoken = []byte{
-	// 291 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x72, 0x4f, 0xcf, 0x2c, 0xc9,
-	0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0xcf, 0x35, 0x4e, 0x49, 0xd2, 0xcf, 0x35, 0xd6, 0x2f,
-	0x2e, 0x4a, 0xd6, 0x4f, 0x49, 0xca, 0xcb, 0x4f, 0x49, 0xd5, 0x4f, 0x4f, 0xcd, 0x4b, 0x2d, 0x4a,
-	0x2c, 0x49, 0x4d, 0xd1, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0xd7, 0x2f, 0x48, 0x4c, 0x4f, 0x2d, 0xc9,
-	0xcf, 0x4e, 0xcd, 0x43, 0xb0, 0xf4, 0xc0, 0x32, 0x42, 0x9c, 0x70, 0x01, 0xa5, 0xcf, 0x4c, 0x5c,
-	0x9c, 0x01, 0x89, 0xe9, 0xa9, 0x21, 0x20, 0x9e, 0x50, 0x18, 0x97, 0x70, 0x62, 0x72, 0x49, 0x66,
-	0x59, 0x6a, 0x7c, 0x71, 0x6a, 0x51, 0x66, 0x6a, 0x71, 0x7c, 0x41, 0x46, 0x62, 0x71, 0xaa, 0x04,
-	0xa3, 0x02, 0xa3, 0x06, 0xb7, 0x91, 0x9a, 0x1e, 0xc2, 0x1c, 0xb8, 0x16, 0x3d, 0x47, 0xb0, 0xfa,
-	0x60, 0xb0, 0xf2, 0x00, 0x90, 0xea, 0x20, 0xc1, 0x44, 0x74, 0x21, 0xa1, 0x48, 0x2e, 0x91, 0xb4,
-	0x9c, 0xd2, 0xe2, 0x8c, 0xd4, 0x14, 0x54, 0x83, 0x99, 0xc0, 0x06, 0xab, 0x63, 0x35, 0xd8, 0x0d,
-	0xa2, 0x01, 0xd9, 0x64, 0xa1, 0x34, 0x0c, 0x31, 0x29, 0x53, 0x2e, 0x41, 0x0c, 0x27, 0x08, 0x29,
-	0x70, 0x71, 0x67, 0xe6, 0xa5, 0xa4, 0x56, 0x38, 0x97, 0x16, 0x15, 0xe7, 0x17, 0x81, 0xdd, 0xcf,
-	0x1c, 0x84, 0x2c, 0x24, 0x55, 0xc3, 0x25, 0x84, 0x69, 0x81, 0x90, 0x05, 0x97, 0x78, 0x72, 0x69,
-	0x51, 0x91, 0x53, 0x4e, 0x7e, 0x72, 0x76, 0x70, 0x49, 0x62, 0x51, 0x49, 0x68, 0x5e, 0x66, 0x85,
-	0x5f, 0x62, 0x5e, 0x7e, 0x31, 0xd4, 0x0c, 0x5c, 0xd2, 0x42, 0x3a, 0x5c, 0x82, 0x70, 0x29, 0xd7,
-	0xbc, 0x92, 0xa2, 0x4a, 0xcf, 0x94, 0x0a, 0xb0, 0xf7, 0x98, 0x83, 0x30, 0x25, 0x9c, 0x04, 0x4e,
-	0x3c, 0x92, 0x63, 0xbc, 0xf0, 0x48, 0x8e, 0xf1, 0xc1, 0x23, 0x39, 0xc6, 0x09, 0x8f, 0xe5, 0x18,
-	0x92, 0xd8, 0xc0, 0x31, 0x63, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0xc9, 0xdd, 0x02, 0xeb, 0xe4,
-	0x01, 0x00, 0x00,
+	// 345 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x75, 0x92, 0xcd, 0x4a, 0x03, 0x31,
+	0x10, 0xc7, 0x6d, 0xab, 0x42, 0xa7, 0x2a, 0x6d, 0x2c, 0x28, 0x3d, 0x94, 0x22, 0xf8, 0x71, 0x90,
+	0x5d, 0xb0, 0x08, 0xbd, 0x5a, 0x51, 0xf1, 0x22, 0x65, 0x5b, 0x05, 0x4f, 0x25, 0xbb, 0x99, 0xfd,
+	0xa0, 0x6d, 0x52, 0x92, 0xac, 0x54, 0xf0, 0xe4, 0x13, 0xf8, 0x58, 0x1e, 0x7d, 0x04, 0xd1, 0x17,
+	0x71, 0x9b, 0x95, 0xb6, 0x76, 0xeb, 0x21, 0x61, 0xe6, 0x3f, 0xff, 0xf9, 0x25, 0x19, 0x02, 0x37,
+	0x41, 0xa4, 0xc3, 0xd8, 0xb5, 0x3c, 0x31, 0xb2, 0x47, 0x4d, 0xe6, 0x26, 0x9b, 0xad, 0xa4, 0x67,
+	0x33, 0x97, 0x0b, 0x86, 0x76, 0x80, 0x1c, 0x25, 0xd5, 0xc8, 0xec, 0xb1, 0x14, 0x5a, 0xd8, 0x63,
+	0x1a, 0xa0, 0x16, 0x03, 0xe4, 0xf3, 0xc8, 0x32, 0x15, 0x52, 0x9c, 0x09, 0x07, 0xaf, 0xeb, 0x50,
+	0xec, 0x24, 0x59, 0x6f, 0x9a, 0x91, 0x07, 0xd8, 0xa5, 0x9e, 0x8e, 0x9e, 0xb0, 0xaf, 0x50, 0x46,
+	0xa8, 0xfa, 0xe3, 0x90, 0x2a, 0xdc, 0xcf, 0x35, 0x72, 0x27, 0xa5, 0xb3, 0x23, 0x6b, 0xce, 0x99,
+	0xb5, 0x58, 0x17, 0xc6, 0xdf, 0x35, 0xf6, 0xce, 0xd4, 0xed, 0x54, 0xe8, 0xb2, 0x44, 0x1e, 0xa1,
+	0xea, 0x0f, 0x63, 0x15, 0x22, 0xfb, 0x0b, 0xce, 0x1b, 0xf0, 0xf1, 0x4a, 0xf0, 0x75, 0xda, 0xb0,
+	0x48, 0x26, 0x7e, 0x46, 0x23, 0x5d, 0x20, 0x3e, 0x6a, 0x2f, 0xec, 0x6b, 0x1a, 0x04, 0x09, 0x3f,
+	0x05, 0x17, 0x0c, 0xf8, 0x70, 0x35, 0x78, 0x6a, 0xef, 0x19, 0x77, 0x8a, 0x2d, 0xfb, 0x4b, 0x4a,
+	0xed, 0x1c, 0x2a, 0x99, 0x77, 0x91, 0x06, 0x94, 0x22, 0xce, 0x70, 0x72, 0x19, 0x4b, 0x25, 0xa4,
+	0x19, 0x4a, 0xc1, 0x59, 0x94, 0x6a, 0x2f, 0x40, 0xb2, 0xb7, 0x26, 0x2d, 0xd8, 0xf3, 0x62, 0x29,
+	0xdb, 0x43, 0xe1, 0x0d, 0xba, 0x9a, 0x4a, 0x7d, 0xcf, 0xa3, 0xc9, 0x1d, 0xe5, 0x42, 0xfd, 0x32,
+	0xfe, 0x2b, 0x93, 0x53, 0xa8, 0xcc, 0x4a, 0x57, 0x5c, 0xcb, 0xe7, 0x5b, 0x36, 0x31, 0x33, 0x2b,
+	0x38, 0xd9, 0x42, 0xad, 0x05, 0xe5, 0xe5, 0xa7, 0x91, 0x2a, 0x6c, 0xa8, 0x90, 0x4a, 0x66, 0x4e,
+	0xda, 0x76, 0xd2, 0x84, 0xec, 0x40, 0x3e, 0x62, 0x06, 0xb4, 0xe5, 0x24, 0x51, 0xbb, 0xfc, 0xfe,
+	0x55, 0xcf, 0x7d, 0x24, 0xeb, 0x33, 0x59, 0x6f, 0xdf, 0xf5, 0x35, 0x77, 0xd3, 0x7c, 0x94, 0xe6,
+	0x0f, 0x81, 0x3d, 0x87, 0xbb, 0x73, 0x02, 0x00, 0x00,
 }
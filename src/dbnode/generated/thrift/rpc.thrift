@@ -32,6 +32,21 @@ enum ErrorType {
 	BAD_REQUEST
 }
 
+enum AggregationType {
+	NONE,
+	MIN,
+	MAX,
+	SUM,
+	COUNT,
+	LAST,
+	AVG
+}
+
+enum AggregateQueryType {
+	AGGREGATE_BY_TAG_NAME_VALUE,
+	AGGREGATE_BY_TAG_NAME
+}
+
 exception Error {
 	1: required ErrorType type = ErrorType.INTERNAL_ERROR
 	2: required string message
@@ -46,6 +61,7 @@ service Node {
 	QueryResult query(1: QueryRequest req) throws (1: Error err)
 	FetchResult fetch(1: FetchRequest req) throws (1: Error err)
 	FetchTaggedResult fetchTagged(1: FetchTaggedRequest req) throws (1: Error err)
+	AggregateQueryResult aggregateQuery(1: AggregateQueryRequest req) throws (1: Error err)
 	void write(1: WriteRequest req) throws (1: Error err)
 	void writeTagged(1: WriteTaggedRequest req) throws (1: Error err)
 
@@ -54,10 +70,12 @@ service Node {
 	FetchBlocksRawResult fetchBlocksRaw(1: FetchBlocksRawRequest req) throws (1: Error err)
 
 	FetchBlocksMetadataRawV2Result fetchBlocksMetadataRawV2(1: FetchBlocksMetadataRawV2Request req) throws (1: Error err)
+	FetchBlockMerkleTreeNodesResult fetchBlockMerkleTreeNodes(1: FetchBlockMerkleTreeNodesRequest req) throws (1: Error err)
 	void writeBatchRaw(1: WriteBatchRawRequest req) throws (1: WriteBatchRawErrors err)
 	void writeTaggedBatchRaw(1: WriteTaggedBatchRawRequest req) throws (1: WriteBatchRawErrors err)
 	void repair() throws (1: Error err)
 	TruncateResult truncate(1: TruncateRequest req) throws (1: Error err)
+	DeleteTaggedResult deleteTagged(1: DeleteTaggedRequest req) throws (1: Error err)
 
 	// Management endpoints
 	NodeHealthResult health() throws (1: Error err)
@@ -142,11 +160,16 @@ struct FetchTaggedRequest {
 	5: required bool fetchData
 	6: optional i64 limit
 	7: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+	8: optional i64 downsampleStep
+	9: optional AggregationType downsampleAggregation = AggregationType.NONE
+	10: optional binary pageToken
+	11: optional i64 downsampleStart
 }
 
 struct FetchTaggedResult {
 	1: required list<FetchTaggedIDResult> elements
 	2: required bool exhaustive
+	3: optional binary nextPageToken
 }
 
 struct FetchTaggedIDResult {
@@ -157,6 +180,33 @@ struct FetchTaggedIDResult {
 	5: optional Error err
 }
 
+struct AggregateQueryRequest {
+	1: required binary nameSpace
+	2: required binary query
+	3: required i64 rangeStart
+	4: required i64 rangeEnd
+	5: optional i64 limit
+	6: optional list<binary> tagNameFilter
+	7: optional AggregateQueryType aggregateQueryType = AggregateQueryType.AGGREGATE_BY_TAG_NAME_VALUE
+	8: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+}
+
+struct AggregateQueryResult {
+	1: required list<AggregateQueryResultTagNameElement> results
+	2: required bool exhaustive
+}
+
+struct AggregateQueryResultTagNameElement {
+	1: required binary tagName
+	2: required i64 count
+	3: optional list<AggregateQueryResultTagValueElement> tagValues
+}
+
+struct AggregateQueryResultTagValueElement {
+	1: required binary tagValue
+	2: required i64 count
+}
+
 struct FetchBlocksRawRequest {
 	1: required binary nameSpace
 	2: required i32 shard
@@ -217,6 +267,19 @@ struct BlockMetadataV2 {
 	8: optional binary encodedTags
 }
 
+struct FetchBlockMerkleTreeNodesRequest {
+	1: required binary nameSpace
+	2: required i32 shard
+	3: required i64 blockStart
+	4: required i32 depth
+	5: required i32 level
+	6: required list<i32> indexes
+}
+
+struct FetchBlockMerkleTreeNodesResult {
+	1: required list<i64> hashes
+}
+
 struct WriteBatchRawRequest {
 	1: required binary nameSpace
 	2: required list<WriteBatchRawRequestElement> elements
@@ -251,6 +314,18 @@ struct TruncateResult {
 	1: required i64 numSeries
 }
 
+struct DeleteTaggedRequest {
+	1: required binary nameSpace
+	2: required binary query
+	3: optional i64 rangeStart
+	4: optional i64 rangeEnd
+	5: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+}
+
+struct DeleteTaggedResult {
+	1: required i64 numSeries
+}
+
 struct NodeHealthResult {
 	1: required bool ok
 	2: required string status
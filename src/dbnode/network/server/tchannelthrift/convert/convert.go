@@ -26,9 +26,11 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/generated/proto/pagetoken"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
 	"github.com/m3db/m3/src/m3ninx/generated/proto/querypb"
@@ -37,6 +39,8 @@ import (
 	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
 	xtime "github.com/m3db/m3x/time"
+
+	"github.com/gogo/protobuf/proto"
 )
 
 var (
@@ -44,6 +48,13 @@ var (
 	errUnknownUnit      = errors.New("unknown unit")
 	errNilTaggedRequest = errors.New("nil write tagged request")
 
+	errUnknownAggregationType  = errors.New("unknown aggregation type")
+	errDownsampleNoAggregation = errors.New("downsample step requires an aggregation type")
+
+	errUnknownAggregateQueryType = errors.New("unknown aggregate query type")
+
+	errInvalidFetchTaggedPageToken = errors.New("invalid fetch tagged page token")
+
 	timeZero time.Time
 )
 
@@ -112,6 +123,48 @@ func ToTimeType(unit xtime.Unit) (rpc.TimeType, error) {
 	return 0, errUnknownUnit
 }
 
+// ToAggregationType converts an rpc aggregation type to an aggregation type.
+func ToAggregationType(aggType rpc.AggregationType) (ts.AggregationType, error) {
+	switch aggType {
+	case rpc.AggregationType_NONE:
+		return ts.AggregationNone, nil
+	case rpc.AggregationType_MIN:
+		return ts.AggregationMin, nil
+	case rpc.AggregationType_MAX:
+		return ts.AggregationMax, nil
+	case rpc.AggregationType_SUM:
+		return ts.AggregationSum, nil
+	case rpc.AggregationType_COUNT:
+		return ts.AggregationCount, nil
+	case rpc.AggregationType_LAST:
+		return ts.AggregationLast, nil
+	case rpc.AggregationType_AVG:
+		return ts.AggregationAvg, nil
+	}
+	return 0, errUnknownAggregationType
+}
+
+// ToRPCAggregationType converts an aggregation type to an rpc aggregation type.
+func ToRPCAggregationType(aggType ts.AggregationType) (rpc.AggregationType, error) {
+	switch aggType {
+	case ts.AggregationNone:
+		return rpc.AggregationType_NONE, nil
+	case ts.AggregationMin:
+		return rpc.AggregationType_MIN, nil
+	case ts.AggregationMax:
+		return rpc.AggregationType_MAX, nil
+	case ts.AggregationSum:
+		return rpc.AggregationType_SUM, nil
+	case ts.AggregationCount:
+		return rpc.AggregationType_COUNT, nil
+	case ts.AggregationLast:
+		return rpc.AggregationType_LAST, nil
+	case ts.AggregationAvg:
+		return rpc.AggregationType_AVG, nil
+	}
+	return 0, errUnknownAggregationType
+}
+
 // ToSegmentsResult is the result of a convert to segments call,
 // if the segments were merged then checksum is ptr to the checksum
 // otherwise it is nil.
@@ -223,6 +276,25 @@ func FromRPCFetchTaggedRequest(
 	if l := req.Limit; l != nil {
 		opts.Limit = int(*l)
 	}
+	if step := req.DownsampleStep; step != nil && *step > 0 {
+		aggType, err := ToAggregationType(req.DownsampleAggregation)
+		if err != nil {
+			return nil, index.Query{}, index.QueryOptions{}, false, err
+		}
+		if aggType == ts.AggregationNone {
+			return nil, index.Query{}, index.QueryOptions{}, false, errDownsampleNoAggregation
+		}
+		opts.DownsampleStep = xtime.FromNormalizedDuration(*step, time.Nanosecond)
+		opts.DownsampleAggregation = aggType
+		opts.DownsampleStart = start
+		if req.DownsampleStart != nil {
+			downsampleStart, err := ToTime(*req.DownsampleStart, fetchTaggedTimeType)
+			if err != nil {
+				return nil, index.Query{}, index.QueryOptions{}, false, err
+			}
+			opts.DownsampleStart = downsampleStart
+		}
+	}
 
 	q, err := idx.Unmarshal(req.Query)
 	if err != nil {
@@ -274,9 +346,214 @@ func ToRPCFetchTaggedRequest(
 		request.Limit = &l
 	}
 
+	if opts.DownsampleStep > 0 {
+		aggType, err := ToRPCAggregationType(opts.DownsampleAggregation)
+		if err != nil {
+			return rpc.FetchTaggedRequest{}, err
+		}
+		step := xtime.ToNormalizedDuration(opts.DownsampleStep, time.Nanosecond)
+		request.DownsampleStep = &step
+		request.DownsampleAggregation = aggType
+		if !opts.DownsampleStart.IsZero() {
+			downsampleStart, err := ToValue(opts.DownsampleStart, fetchTaggedTimeType)
+			if err != nil {
+				return rpc.FetchTaggedRequest{}, err
+			}
+			request.DownsampleStart = &downsampleStart
+		}
+	}
+
+	return request, nil
+}
+
+// FromRPCAggregateQueryRequest converts the rpc request type for AggregateQueryRequest into corresponding Go API types.
+func FromRPCAggregateQueryRequest(
+	req *rpc.AggregateQueryRequest, pools FetchTaggedConversionPools,
+) (ident.ID, index.Query, index.AggregateQueryOptions, error) {
+	start, rangeStartErr := ToTime(req.RangeStart, req.RangeTimeType)
+	if rangeStartErr != nil {
+		return nil, index.Query{}, index.AggregateQueryOptions{}, rangeStartErr
+	}
+
+	end, rangeEndErr := ToTime(req.RangeEnd, req.RangeTimeType)
+	if rangeEndErr != nil {
+		return nil, index.Query{}, index.AggregateQueryOptions{}, rangeEndErr
+	}
+
+	opts := index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+		},
+		TagNameFilter: req.TagNameFilter,
+	}
+	if l := req.Limit; l != nil {
+		opts.Limit = int(*l)
+	}
+	switch req.AggregateQueryType {
+	case rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME_VALUE:
+		opts.Type = index.AggregateTagNamesAndValues
+	case rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME:
+		opts.Type = index.AggregateTagNames
+	default:
+		return nil, index.Query{}, index.AggregateQueryOptions{}, errUnknownAggregateQueryType
+	}
+
+	q, err := idx.Unmarshal(req.Query)
+	if err != nil {
+		return nil, index.Query{}, index.AggregateQueryOptions{}, err
+	}
+
+	var ns ident.ID
+	if pools != nil {
+		nsBytes := pools.CheckedBytesWrapper().Get(req.NameSpace)
+		ns = pools.ID().BinaryID(nsBytes)
+	} else {
+		ns = ident.StringID(string(req.NameSpace))
+	}
+	return ns, index.Query{Query: q}, opts, nil
+}
+
+// ToRPCAggregateQueryRequest converts the Go `client/` types into rpc request type for AggregateQueryRequest.
+func ToRPCAggregateQueryRequest(
+	ns ident.ID,
+	q index.Query,
+	opts index.AggregateQueryOptions,
+) (rpc.AggregateQueryRequest, error) {
+	rangeStart, tsErr := ToValue(opts.StartInclusive, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.AggregateQueryRequest{}, tsErr
+	}
+
+	rangeEnd, tsErr := ToValue(opts.EndExclusive, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.AggregateQueryRequest{}, tsErr
+	}
+
+	query, queryErr := idx.Marshal(q.Query)
+	if queryErr != nil {
+		return rpc.AggregateQueryRequest{}, queryErr
+	}
+
+	request := rpc.AggregateQueryRequest{
+		NameSpace:     ns.Bytes(),
+		RangeStart:    rangeStart,
+		RangeEnd:      rangeEnd,
+		Query:         query,
+		TagNameFilter: opts.TagNameFilter,
+		RangeTimeType: fetchTaggedTimeType,
+	}
+
+	if opts.Limit > 0 {
+		l := int64(opts.Limit)
+		request.Limit = &l
+	}
+
+	switch opts.Type {
+	case index.AggregateTagNamesAndValues:
+		request.AggregateQueryType = rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME_VALUE
+	case index.AggregateTagNames:
+		request.AggregateQueryType = rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME
+	default:
+		return rpc.AggregateQueryRequest{}, errUnknownAggregateQueryType
+	}
+
+	return request, nil
+}
+
+// FromRPCDeleteTaggedRequest converts the rpc request type for DeleteTaggedRequest
+// into the query and time range of the datapoints to delete. A missing range start
+// results in a zero start time and a missing range end defaults to now.
+func FromRPCDeleteTaggedRequest(
+	req *rpc.DeleteTaggedRequest, now time.Time,
+) (index.Query, time.Time, time.Time, error) {
+	var start, end time.Time
+	if req.RangeStart != nil {
+		var err error
+		start, err = ToTime(*req.RangeStart, req.RangeTimeType)
+		if err != nil {
+			return index.Query{}, timeZero, timeZero, err
+		}
+	}
+
+	end = now
+	if req.RangeEnd != nil {
+		var err error
+		end, err = ToTime(*req.RangeEnd, req.RangeTimeType)
+		if err != nil {
+			return index.Query{}, timeZero, timeZero, err
+		}
+	}
+
+	q, err := idx.Unmarshal(req.Query)
+	if err != nil {
+		return index.Query{}, timeZero, timeZero, err
+	}
+	return index.Query{Query: q}, start, end, nil
+}
+
+// ToRPCDeleteTaggedRequest converts the Go `client/` types into rpc request type for DeleteTaggedRequest.
+func ToRPCDeleteTaggedRequest(
+	ns ident.ID,
+	q index.Query,
+	start, end time.Time,
+) (rpc.DeleteTaggedRequest, error) {
+	query, err := idx.Marshal(q.Query)
+	if err != nil {
+		return rpc.DeleteTaggedRequest{}, err
+	}
+
+	request := rpc.DeleteTaggedRequest{
+		NameSpace:     ns.Bytes(),
+		Query:         query,
+		RangeTimeType: fetchTaggedTimeType,
+	}
+	if !start.IsZero() {
+		rangeStart, err := ToValue(start, fetchTaggedTimeType)
+		if err != nil {
+			return rpc.DeleteTaggedRequest{}, err
+		}
+		request.RangeStart = &rangeStart
+	}
+	if !end.IsZero() {
+		rangeEnd, err := ToValue(end, fetchTaggedTimeType)
+		if err != nil {
+			return rpc.DeleteTaggedRequest{}, err
+		}
+		request.RangeEnd = &rangeEnd
+	}
 	return request, nil
 }
 
+// ToFetchTaggedPagePosition decodes a FetchTagged page token into the
+// position of the last series of the previous page, returning nil if the
+// token is empty and hence refers to the first page.
+func ToFetchTaggedPagePosition(token []byte) (*index.QueryPagePosition, error) {
+	if len(token) == 0 {
+		return nil, nil
+	}
+	var pageToken pagetoken.PageToken
+	if err := proto.Unmarshal(token, &pageToken); err != nil {
+		return nil, err
+	}
+	phase := pageToken.GetFetchTaggedPhase()
+	if phase == nil {
+		return nil, errInvalidFetchTaggedPageToken
+	}
+	return &index.QueryPagePosition{Shard: phase.Shard, ID: phase.Id}, nil
+}
+
+// ToFetchTaggedPageToken encodes the position of the last series returned in
+// a page as the token used to request the next page.
+func ToFetchTaggedPageToken(pos index.QueryPagePosition) ([]byte, error) {
+	return proto.Marshal(&pagetoken.PageToken{
+		FetchTaggedPhase: &pagetoken.PageToken_FetchTaggedPhase{
+			Shard: pos.Shard,
+			Id:    pos.ID,
+		},
+	})
+}
+
 // ToTagsIter returns a tag iterator over the given request.
 func ToTagsIter(r *rpc.WriteTaggedRequest) (ident.TagIterator, error) {
 	if r == nil {
@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
 	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3x/ident"
@@ -150,6 +151,68 @@ func TestConvertFetchTaggedRequest(t *testing.T) {
 	}
 }
 
+func TestConvertFetchTaggedRequestDownsample(t *testing.T) {
+	ns := ident.StringID("abc")
+	opts := index.QueryOptions{
+		StartInclusive:        time.Unix(0, time.Now().Add(-900*time.Hour).UnixNano()),
+		EndExclusive:          time.Unix(0, time.Now().UnixNano()),
+		DownsampleStep:        5 * time.Minute,
+		DownsampleStart:       time.Unix(0, time.Now().Add(-899*time.Hour).UnixNano()),
+		DownsampleAggregation: ts.AggregationMax,
+	}
+	q, _ := termQueryTestCase(t)
+
+	request, err := convert.ToRPCFetchTaggedRequest(ns, index.Query{Query: q}, opts, true)
+	require.NoError(t, err)
+	require.NotNil(t, request.DownsampleStep)
+	assert.Equal(t, int64(5*time.Minute), *request.DownsampleStep)
+	require.NotNil(t, request.DownsampleStart)
+	assert.Equal(t, opts.DownsampleStart.UnixNano(), *request.DownsampleStart)
+	assert.Equal(t, rpc.AggregationType_MAX, request.DownsampleAggregation)
+
+	_, _, observedOpts, _, err := convert.FromRPCFetchTaggedRequest(&request, nil)
+	require.NoError(t, err)
+	assert.Equal(t, opts, observedOpts)
+
+	// Requests without a downsample start align steps to the range start
+	request.DownsampleStart = nil
+	_, _, observedOpts, _, err = convert.FromRPCFetchTaggedRequest(&request, nil)
+	require.NoError(t, err)
+	assert.Equal(t, opts.StartInclusive, observedOpts.DownsampleStart)
+
+	request.DownsampleAggregation = rpc.AggregationType_NONE
+	_, _, _, _, err = convert.FromRPCFetchTaggedRequest(&request, nil)
+	require.Error(t, err)
+}
+
+func TestConvertAggregateQueryRequest(t *testing.T) {
+	ns := ident.StringID("abc")
+	opts := index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: time.Unix(0, time.Now().Add(-900*time.Hour).UnixNano()),
+			EndExclusive:   time.Unix(0, time.Now().UnixNano()),
+			Limit:          10,
+		},
+		Type:          index.AggregateTagNames,
+		TagNameFilter: [][]byte{[]byte("foo"), []byte("bar")},
+	}
+	q, _ := conjunctionQueryATestCase(t)
+
+	request, err := convert.ToRPCAggregateQueryRequest(ns, index.Query{Query: q}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME, request.AggregateQueryType)
+
+	observedNs, observedQuery, observedOpts, err := convert.FromRPCAggregateQueryRequest(&request, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ns.String(), observedNs.String())
+	assert.True(t, index.NewQueryMatcher(index.Query{Query: q}).Matches(observedQuery))
+	assert.Equal(t, opts, observedOpts)
+
+	request.AggregateQueryType = rpc.AggregateQueryType(-1)
+	_, _, _, err = convert.FromRPCAggregateQueryRequest(&request, nil)
+	require.Error(t, err)
+}
+
 type testPools struct {
 	id      ident.Pool
 	wrapper xpool.CheckedBytesWrapperPool
@@ -171,3 +234,50 @@ var _ convert.FetchTaggedConversionPools = &testPools{}
 
 func (t *testPools) ID() ident.Pool                                     { return t.id }
 func (t *testPools) CheckedBytesWrapper() xpool.CheckedBytesWrapperPool { return t.wrapper }
+
+func TestConvertDeleteTaggedRequest(t *testing.T) {
+	ns := ident.StringID("abc")
+	q, _ := conjunctionQueryATestCase(t)
+	start := time.Unix(0, time.Now().Add(-time.Hour).UnixNano())
+	end := time.Unix(0, time.Now().UnixNano())
+
+	req, err := convert.ToRPCDeleteTaggedRequest(ns, index.Query{Query: q}, start, end)
+	require.NoError(t, err)
+	require.Equal(t, ns.Bytes(), req.NameSpace)
+
+	observedQuery, observedStart, observedEnd, err := convert.FromRPCDeleteTaggedRequest(&req, time.Now())
+	require.NoError(t, err)
+	require.True(t, index.NewQueryMatcher(index.Query{Query: q}).Matches(observedQuery))
+	require.True(t, start.Equal(observedStart))
+	require.True(t, end.Equal(observedEnd))
+}
+
+func TestConvertDeleteTaggedRequestUnboundedRange(t *testing.T) {
+	q, _ := termQueryTestCase(t)
+	req, err := convert.ToRPCDeleteTaggedRequest(ident.StringID("abc"),
+		index.Query{Query: q}, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Nil(t, req.RangeStart)
+	require.Nil(t, req.RangeEnd)
+
+	now := time.Now()
+	_, start, end, err := convert.FromRPCDeleteTaggedRequest(&req, now)
+	require.NoError(t, err)
+	require.True(t, start.IsZero())
+	require.True(t, now.Equal(end))
+}
+
+func TestConvertFetchTaggedPageToken(t *testing.T) {
+	pos := index.QueryPagePosition{Shard: 3, ID: []byte("foo")}
+	token, err := convert.ToFetchTaggedPageToken(pos)
+	require.NoError(t, err)
+
+	result, err := convert.ToFetchTaggedPagePosition(token)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, pos, *result)
+
+	result, err = convert.ToFetchTaggedPagePosition([]byte{})
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
@@ -77,17 +78,23 @@ var (
 
 	// errNodeIsNotBootstrapped
 	errNodeIsNotBootstrapped = errors.New("node is not bootstrapped")
+
+	// errPagedFetchTaggedRequiresLimit raised when a paged fetch tagged request does not specify a page size
+	errPagedFetchTaggedRequiresLimit = errors.New("paged fetch tagged requires a limit as the page size")
 )
 
 type serviceMetrics struct {
 	fetch               instrument.MethodMetrics
 	fetchTagged         instrument.MethodMetrics
+	aggregateQuery      instrument.MethodMetrics
 	write               instrument.MethodMetrics
 	writeTagged         instrument.MethodMetrics
 	fetchBlocks         instrument.MethodMetrics
 	fetchBlocksMetadata instrument.MethodMetrics
+	fetchMerkleTree     instrument.MethodMetrics
 	repair              instrument.MethodMetrics
 	truncate            instrument.MethodMetrics
+	deleteTagged        instrument.MethodMetrics
 	fetchBatchRaw       instrument.BatchMethodMetrics
 	writeBatchRaw       instrument.BatchMethodMetrics
 	writeTaggedBatchRaw instrument.BatchMethodMetrics
@@ -98,12 +105,15 @@ func newServiceMetrics(scope tally.Scope, samplingRate float64) serviceMetrics {
 	return serviceMetrics{
 		fetch:               instrument.NewMethodMetrics(scope, "fetch", samplingRate),
 		fetchTagged:         instrument.NewMethodMetrics(scope, "fetchTagged", samplingRate),
+		aggregateQuery:      instrument.NewMethodMetrics(scope, "aggregateQuery", samplingRate),
 		write:               instrument.NewMethodMetrics(scope, "write", samplingRate),
 		writeTagged:         instrument.NewMethodMetrics(scope, "writeTagged", samplingRate),
 		fetchBlocks:         instrument.NewMethodMetrics(scope, "fetchBlocks", samplingRate),
 		fetchBlocksMetadata: instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", samplingRate),
+		fetchMerkleTree:     instrument.NewMethodMetrics(scope, "fetchMerkleTree", samplingRate),
 		repair:              instrument.NewMethodMetrics(scope, "repair", samplingRate),
 		truncate:            instrument.NewMethodMetrics(scope, "truncate", samplingRate),
+		deleteTagged:        instrument.NewMethodMetrics(scope, "deleteTagged", samplingRate),
 		fetchBatchRaw:       instrument.NewBatchMethodMetrics(scope, "fetchBatchRaw", samplingRate),
 		writeBatchRaw:       instrument.NewBatchMethodMetrics(scope, "writeBatchRaw", samplingRate),
 		writeTaggedBatchRaw: instrument.NewBatchMethodMetrics(scope, "writeTaggedBatchRaw", samplingRate),
@@ -395,6 +405,27 @@ func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest)
 		return nil, tterrors.NewBadRequestError(err)
 	}
 
+	// NB(r): A non-nil page token, even an empty one, requests paged results
+	// in which case the limit is the page size rather than a limit on the
+	// results of the query itself.
+	if req.PageToken != nil {
+		if opts.Limit <= 0 {
+			s.metrics.fetchTagged.ReportError(s.nowFn().Sub(callStart))
+			return nil, tterrors.NewBadRequestError(errPagedFetchTaggedRequiresLimit)
+		}
+		after, err := convert.ToFetchTaggedPagePosition(req.PageToken)
+		if err != nil {
+			s.metrics.fetchTagged.ReportError(s.nowFn().Sub(callStart))
+			return nil, tterrors.NewBadRequestError(err)
+		}
+		opts.Page = &index.QueryPage{
+			After:   after,
+			Size:    opts.Limit,
+			ShardFn: s.db.ShardSet().Lookup,
+		}
+		opts.Limit = 0
+	}
+
 	queryResult, err := s.db.QueryIDs(ctx, ns, query, opts)
 	if err != nil {
 		s.metrics.fetchTagged.ReportError(s.nowFn().Sub(callStart))
@@ -404,6 +435,13 @@ func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest)
 	response := &rpc.FetchTaggedResult_{
 		Exhaustive: queryResult.Exhaustive,
 	}
+	if queryResult.NextPage != nil {
+		response.NextPageToken, err = convert.ToFetchTaggedPageToken(*queryResult.NextPage)
+		if err != nil {
+			s.metrics.fetchTagged.ReportError(s.nowFn().Sub(callStart))
+			return nil, tterrors.NewInternalError(err)
+		}
+	}
 	results := queryResult.Results
 	nsID := results.Namespace()
 	tagsIter := ident.NewTagsIterator(ident.Tags{})
@@ -428,7 +466,15 @@ func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest)
 		if !fetchData {
 			continue
 		}
-		segments, rpcErr := s.readEncoded(ctx, nsID, tsID, opts.StartInclusive, opts.EndExclusive)
+		var (
+			segments []*rpc.Segments
+			rpcErr   *rpc.Error
+		)
+		if opts.DownsampleStep > 0 {
+			segments, rpcErr = s.readDownsampled(ctx, nsID, tsID, opts)
+		} else {
+			segments, rpcErr = s.readEncoded(ctx, nsID, tsID, opts.StartInclusive, opts.EndExclusive)
+		}
 		if rpcErr != nil {
 			elem.Err = rpcErr
 			continue
@@ -440,6 +486,49 @@ func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest)
 	return response, nil
 }
 
+func (s *service) AggregateQuery(tctx thrift.Context, req *rpc.AggregateQueryRequest) (*rpc.AggregateQueryResult_, error) {
+	if s.isOverloaded() {
+		s.metrics.overloadRejected.Inc(1)
+		return nil, tterrors.NewInternalError(errServerIsOverloaded)
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+	ns, query, opts, err := convert.FromRPCAggregateQueryRequest(req, s.pools)
+	if err != nil {
+		s.metrics.aggregateQuery.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	queryResult, err := s.db.AggregateQuery(ctx, ns, query, opts)
+	if err != nil {
+		s.metrics.aggregateQuery.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewInternalError(err)
+	}
+
+	fields := queryResult.Results.Fields()
+	response := &rpc.AggregateQueryResult_{
+		Results:    make([]*rpc.AggregateQueryResultTagNameElement, 0, len(fields)),
+		Exhaustive: queryResult.Exhaustive,
+	}
+	for _, field := range fields {
+		elem := &rpc.AggregateQueryResultTagNameElement{
+			TagName: field.Name,
+			Count:   field.Count,
+		}
+		for _, value := range field.Values {
+			elem.TagValues = append(elem.TagValues, &rpc.AggregateQueryResultTagValueElement{
+				TagValue: value.Value,
+				Count:    value.Count,
+			})
+		}
+		response.Results = append(response.Results, elem)
+	}
+
+	s.metrics.aggregateQuery.ReportSuccess(s.nowFn().Sub(callStart))
+	return response, nil
+}
+
 func (s *service) encodeTags(
 	enc serialize.TagEncoder,
 	tags ident.TagIterator,
@@ -644,6 +733,46 @@ func (s *service) FetchBlocksMetadataRawV2(tctx thrift.Context, req *rpc.FetchBl
 	return result, nil
 }
 
+func (s *service) FetchBlockMerkleTreeNodes(tctx thrift.Context, req *rpc.FetchBlockMerkleTreeNodesRequest) (*rpc.FetchBlockMerkleTreeNodesResult_, error) {
+	if s.db.IsOverloaded() {
+		s.metrics.overloadRejected.Inc(1)
+		return nil, tterrors.NewInternalError(errServerIsOverloaded)
+	}
+
+	var err error
+	callStart := s.nowFn()
+	defer func() {
+		s.metrics.fetchMerkleTree.ReportSuccessOrError(err, s.nowFn().Sub(callStart))
+	}()
+
+	ctx := tchannelthrift.Context(tctx)
+	var (
+		nsID       = s.newID(ctx, req.NameSpace)
+		blockStart = time.Unix(0, req.BlockStart)
+	)
+	tree, err := s.db.BlockMerkleTree(ctx, nsID, uint32(req.Shard), blockStart,
+		int(req.Depth))
+	if err != nil {
+		return nil, convert.ToRPCError(err)
+	}
+
+	indexes := make([]int, 0, len(req.Indexes))
+	for _, idx := range req.Indexes {
+		indexes = append(indexes, int(idx))
+	}
+	nodes, err := tree.Nodes(int(req.Level), indexes)
+	if err != nil {
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	result := rpc.NewFetchBlockMerkleTreeNodesResult_()
+	result.Hashes = make([]int64, 0, len(nodes))
+	for _, hash := range nodes {
+		result.Hashes = append(result.Hashes, int64(hash))
+	}
+	return result, nil
+}
+
 func (s *service) getFetchBlocksMetadataRawV2Result(
 	ctx context.Context,
 	nextPageToken storage.PageToken,
@@ -980,6 +1109,34 @@ func (s *service) Truncate(tctx thrift.Context, req *rpc.TruncateRequest) (r *rp
 	return res, nil
 }
 
+func (s *service) DeleteTagged(tctx thrift.Context, req *rpc.DeleteTaggedRequest) (*rpc.DeleteTaggedResult_, error) {
+	if s.isOverloaded() {
+		s.metrics.overloadRejected.Inc(1)
+		return nil, tterrors.NewInternalError(errServerIsOverloaded)
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+	query, start, end, err := convert.FromRPCDeleteTaggedRequest(req, callStart)
+	if err != nil {
+		s.metrics.deleteTagged.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	deleted, err := s.db.DeleteTagged(ctx, s.newID(ctx, req.NameSpace), query, start, end)
+	if err != nil {
+		s.metrics.deleteTagged.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := rpc.NewDeleteTaggedResult_()
+	res.NumSeries = deleted
+
+	s.metrics.deleteTagged.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
 func (s *service) GetPersistRateLimit(
 	ctx thrift.Context,
 ) (*rpc.NodePersistRateLimitResult_, error) {
@@ -1157,6 +1314,54 @@ func (s *service) readEncoded(
 	return segments, nil
 }
 
+// readDownsampled decodes the series and returns a single segment holding one
+// aggregated datapoint per downsample step.
+func (s *service) readDownsampled(
+	ctx context.Context,
+	nsID, tsID ident.ID,
+	opts index.QueryOptions,
+) ([]*rpc.Segments, *rpc.Error) {
+	encoded, err := s.db.ReadEncoded(ctx, nsID, tsID, opts.StartInclusive, opts.EndExclusive)
+	if err != nil {
+		return nil, convert.ToRPCError(err)
+	}
+	if len(encoded) == 0 {
+		return nil, nil
+	}
+
+	var (
+		dbOpts  = s.db.Options()
+		bopts   = dbOpts.DatabaseBlockOptions()
+		iter    = dbOpts.MultiReaderIteratorPool().Get()
+		encoder = bopts.EncoderPool().Get()
+	)
+	defer iter.Close()
+
+	iter.ResetSliceOfSlices(xio.NewReaderSliceOfSlicesFromBlockReadersIterator(encoded))
+	encoder.Reset(opts.StartInclusive, bopts.DatabaseBlockAllocSize())
+	if err := encoding.Downsample(iter, encoder, opts.StartInclusive, opts.EndExclusive,
+		opts.DownsampleStart, opts.DownsampleStep, opts.DownsampleAggregation); err != nil {
+		encoder.Close()
+		return nil, convert.ToRPCError(err)
+	}
+
+	reader := xio.NewSegmentReader(encoder.Discard())
+	ctx.RegisterFinalizer(reader)
+
+	converted, err := convert.ToSegments([]xio.BlockReader{{
+		SegmentReader: reader,
+		Start:         opts.StartInclusive,
+		BlockSize:     opts.EndExclusive.Sub(opts.StartInclusive),
+	}})
+	if err != nil {
+		return nil, convert.ToRPCError(err)
+	}
+	if converted.Segments == nil {
+		return nil, nil
+	}
+	return []*rpc.Segments{converted.Segments}, nil
+}
+
 func (s *service) newTagsDecoder(ctx context.Context, encodedTags []byte) (serialize.TagDecoder, error) {
 	checkedBytes := s.pools.checkedBytesWrapper.Get(encodedTags)
 	dec := s.pools.tagDecoder.Get()
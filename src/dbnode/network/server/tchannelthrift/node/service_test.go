@@ -27,21 +27,25 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
 	"github.com/m3db/m3/src/dbnode/runtime"
+	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3/src/x/serialize"
 	"github.com/m3db/m3x/checked"
+	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
 	xtime "github.com/m3db/m3x/time"
 
@@ -993,6 +997,115 @@ func TestServiceFetchTagged(t *testing.T) {
 	}
 }
 
+func TestServiceFetchTaggedDownsample(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := time.Now().Add(-2 * time.Hour).Truncate(time.Hour)
+	end := start.Add(2 * time.Hour)
+	// Steps end on the evaluation times rather than on the range start
+	alignStart := start.Add(30 * time.Second)
+
+	nsID := "metrics"
+
+	enc := testStorageOpts.EncoderPool().Get()
+	enc.Reset(start, 0)
+	for i, v := range []float64{1.0, 5.0, 3.0, 2.0} {
+		dp := ts.Datapoint{
+			Timestamp: start.Add(time.Duration(i+1) * 30 * time.Second),
+			Value:     v,
+		}
+		require.NoError(t, enc.Encode(dp, xtime.Second, nil))
+	}
+	mockDB.EXPECT().
+		ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher("foo"), start, end).
+		Return([][]xio.BlockReader{{
+			xio.BlockReader{
+				SegmentReader: enc.Stream(),
+			},
+		}}, nil)
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	qry := index.Query{Query: req}
+
+	resMap := index.NewResults(index.NewOptions())
+	resMap.Reset(ident.StringID(nsID))
+	resMap.Map().Set(ident.StringID("foo"), ident.NewTags(
+		ident.StringTag("foo", "bar"),
+	))
+
+	mockDB.EXPECT().QueryIDs(
+		ctx,
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		index.QueryOptions{
+			StartInclusive:        start,
+			EndExclusive:          end,
+			DownsampleStep:        time.Minute,
+			DownsampleStart:       alignStart,
+			DownsampleAggregation: ts.AggregationMax,
+		}).Return(index.QueryResults{Results: resMap, Exhaustive: true}, nil)
+
+	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	alignNanos, err := convert.ToValue(alignStart, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	step := int64(time.Minute)
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	r, err := service.FetchTagged(tctx, &rpc.FetchTaggedRequest{
+		NameSpace:             []byte(nsID),
+		Query:                 data,
+		RangeStart:            startNanos,
+		RangeEnd:              endNanos,
+		FetchData:             true,
+		DownsampleStep:        &step,
+		DownsampleStart:       &alignNanos,
+		DownsampleAggregation: rpc.AggregationType_MAX,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, len(r.Elements))
+	elem := r.Elements[0]
+	assert.Nil(t, elem.Err)
+	require.Equal(t, 1, len(elem.Segments))
+	seg := elem.Segments[0].Merged
+	require.NotNil(t, seg)
+
+	iter := testStorageOpts.MultiReaderIteratorPool().Get()
+	defer iter.Close()
+	iter.Reset([]xio.SegmentReader{xio.NewSegmentReader(ts.NewSegment(
+		checked.NewBytes(seg.Head, nil), checked.NewBytes(seg.Tail, nil), ts.FinalizeNone,
+	))}, start, end.Sub(start))
+
+	expected := []ts.Datapoint{
+		{Timestamp: start.Add(30 * time.Second), Value: 1.0},
+		{Timestamp: start.Add(90 * time.Second), Value: 5.0},
+		{Timestamp: start.Add(2 * time.Minute), Value: 2.0},
+	}
+	for _, dp := range expected {
+		require.True(t, iter.Next())
+		actual, _, _ := iter.Current()
+		assert.True(t, dp.Timestamp.Equal(actual.Timestamp))
+		assert.Equal(t, dp.Value, actual.Value)
+	}
+	require.False(t, iter.Next())
+	require.NoError(t, iter.Err())
+}
+
 func TestServiceFetchTaggedIsOverloaded(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1117,6 +1230,105 @@ func TestServiceFetchTaggedNoData(t *testing.T) {
 	}
 }
 
+func TestServiceFetchTaggedPaged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shardSet, err := sharding.NewShardSet(
+		sharding.NewShards([]uint32{0}, shard.Available),
+		sharding.DefaultHashFn(1))
+	require.NoError(t, err)
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false).Times(2)
+	mockDB.EXPECT().ShardSet().Return(shardSet).AnyTimes()
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := time.Now().Add(-2 * time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	start, end = start.Truncate(time.Second), end.Truncate(time.Second)
+	nsID := "metrics"
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	qry := index.Query{Query: req}
+
+	newResults := func(ids ...string) index.Results {
+		results := index.NewResults(index.NewOptions())
+		results.Reset(ident.StringID(nsID))
+		for _, id := range ids {
+			results.Map().Set(ident.StringID(id), ident.Tags{})
+		}
+		return results
+	}
+	lastPos := index.QueryPagePosition{Shard: 0, ID: []byte("b")}
+	gomock.InOrder(
+		mockDB.EXPECT().QueryIDs(ctx, ident.NewIDMatcher(nsID), index.NewQueryMatcher(qry), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ ident.ID, _ index.Query, opts index.QueryOptions) (index.QueryResults, error) {
+				require.NotNil(t, opts.Page)
+				assert.Nil(t, opts.Page.After)
+				assert.Equal(t, 2, opts.Page.Size)
+				assert.Equal(t, 0, opts.Limit)
+				return index.QueryResults{
+					Results:    newResults("a", "b"),
+					Exhaustive: true,
+					NextPage:   &lastPos,
+				}, nil
+			}),
+		mockDB.EXPECT().QueryIDs(ctx, ident.NewIDMatcher(nsID), index.NewQueryMatcher(qry), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ ident.ID, _ index.Query, opts index.QueryOptions) (index.QueryResults, error) {
+				require.NotNil(t, opts.Page)
+				require.NotNil(t, opts.Page.After)
+				assert.Equal(t, lastPos, *opts.Page.After)
+				assert.Equal(t, 2, opts.Page.Size)
+				return index.QueryResults{
+					Results:    newResults("c"),
+					Exhaustive: true,
+				}, nil
+			}),
+	)
+
+	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	var limit int64 = 2
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	fetchPage := func(pageToken []byte) *rpc.FetchTaggedResult_ {
+		r, err := service.FetchTagged(tctx, &rpc.FetchTaggedRequest{
+			NameSpace:  []byte(nsID),
+			Query:      data,
+			RangeStart: startNanos,
+			RangeEnd:   endNanos,
+			FetchData:  false,
+			Limit:      &limit,
+			PageToken:  pageToken,
+		})
+		require.NoError(t, err)
+		return r
+	}
+
+	r := fetchPage([]byte{})
+	require.Equal(t, 2, len(r.Elements))
+	ids := []string{string(r.Elements[0].ID), string(r.Elements[1].ID)}
+	sort.Strings(ids)
+	assert.Equal(t, []string{"a", "b"}, ids)
+	require.NotNil(t, r.NextPageToken)
+
+	r = fetchPage(r.NextPageToken)
+	require.Equal(t, 1, len(r.Elements))
+	assert.Equal(t, []byte("c"), r.Elements[0].ID)
+	assert.Nil(t, r.NextPageToken)
+}
+
 func TestServiceFetchTaggedErrs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1169,6 +1381,106 @@ func TestServiceFetchTaggedErrs(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestServiceAggregateQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := time.Now().Add(-2 * time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	start, end = start.Truncate(time.Second), end.Truncate(time.Second)
+	nsID := "metrics"
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	qry := index.Query{Query: req}
+
+	results := index.NewAggregateResults(ident.StringID(nsID))
+	results.AddFieldValue([]byte("foo"), []byte("baz"), 2)
+	results.AddFieldValue([]byte("foo"), []byte("bar"), 1)
+	results.AddFieldValue([]byte("city"), []byte("nyc"), 3)
+	mockDB.EXPECT().AggregateQuery(
+		ctx,
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		index.AggregateQueryOptions{
+			QueryOptions: index.QueryOptions{
+				StartInclusive: start,
+				EndExclusive:   end,
+				Limit:          10,
+			},
+			Type:          index.AggregateTagNamesAndValues,
+			TagNameFilter: [][]byte{[]byte("foo"), []byte("city")},
+		}).Return(index.AggregateQueryResult{Results: results, Exhaustive: true}, nil)
+
+	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	var limit int64 = 10
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	r, err := service.AggregateQuery(tctx, &rpc.AggregateQueryRequest{
+		NameSpace:          []byte(nsID),
+		Query:              data,
+		RangeStart:         startNanos,
+		RangeEnd:           endNanos,
+		Limit:              &limit,
+		TagNameFilter:      [][]byte{[]byte("foo"), []byte("city")},
+		AggregateQueryType: rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME_VALUE,
+		RangeTimeType:      rpc.TimeType_UNIX_NANOSECONDS,
+	})
+	require.NoError(t, err)
+	require.True(t, r.Exhaustive)
+
+	expected := []*rpc.AggregateQueryResultTagNameElement{
+		{
+			TagName: []byte("city"),
+			Count:   3,
+			TagValues: []*rpc.AggregateQueryResultTagValueElement{
+				{TagValue: []byte("nyc"), Count: 3},
+			},
+		},
+		{
+			TagName: []byte("foo"),
+			Count:   3,
+			TagValues: []*rpc.AggregateQueryResultTagValueElement{
+				{TagValue: []byte("bar"), Count: 1},
+				{TagValue: []byte("baz"), Count: 2},
+			},
+		},
+	}
+	require.Equal(t, expected, r.Results)
+}
+
+func TestServiceAggregateQueryIsOverloaded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(true)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	_, err := service.AggregateQuery(tctx, &rpc.AggregateQueryRequest{})
+	require.Equal(t, tterrors.NewInternalError(errServerIsOverloaded), err)
+}
+
 func TestServiceWrite(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1366,6 +1678,62 @@ func TestServiceWriteTaggedBatchRaw(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+func TestServiceFetchBlockMerkleTreeNodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false).AnyTimes()
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	builder, err := repair.NewMerkleTreeBuilder(2)
+	require.NoError(t, err)
+	builder.Add([]byte("foo"), 1)
+	builder.Add([]byte("bar"), 2)
+	tree := builder.Build()
+
+	var (
+		nsID       = "metrics"
+		blockStart = time.Now().Truncate(2 * time.Hour)
+	)
+	mockDB.EXPECT().
+		BlockMerkleTree(gomock.Any(), ident.NewIDMatcher(nsID), uint32(3),
+			blockStart, 2).
+		Return(tree, nil).
+		Times(2)
+
+	req := &rpc.FetchBlockMerkleTreeNodesRequest{
+		NameSpace:  []byte(nsID),
+		Shard:      3,
+		BlockStart: blockStart.UnixNano(),
+		Depth:      2,
+		Level:      2,
+		Indexes:    []int32{0, 1, 2, 3},
+	}
+	result, err := service.FetchBlockMerkleTreeNodes(tctx, req)
+	require.NoError(t, err)
+
+	leaves, err := tree.Nodes(2, []int{0, 1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, len(leaves), len(result.Hashes))
+	for i, leaf := range leaves {
+		assert.Equal(t, int64(leaf), result.Hashes[i])
+	}
+
+	// Requesting nodes outside the tree is a bad request.
+	req.Level = 3
+	_, err = service.FetchBlockMerkleTreeNodes(tctx, req)
+	rpcErr, ok := err.(*rpc.Error)
+	require.True(t, ok)
+	assert.True(t, tterrors.IsBadRequestError(rpcErr))
+}
+
 func TestServiceRepair(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1409,6 +1777,40 @@ func TestServiceTruncate(t *testing.T) {
 	assert.Equal(t, truncated, r.NumSeries)
 }
 
+func TestServiceDeleteTagged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	nsID := "metrics"
+	start := time.Unix(0, time.Now().Add(-2*time.Hour).UnixNano())
+	end := start.Add(time.Hour)
+	qry := index.Query{Query: idx.NewTermQuery([]byte("foo"), []byte("b"))}
+
+	mockDB.EXPECT().DeleteTagged(
+		ctx,
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		start,
+		end,
+	).Return(int64(2), nil)
+
+	req, err := convert.ToRPCDeleteTaggedRequest(ident.StringID(nsID), qry, start, end)
+	require.NoError(t, err)
+	r, err := service.DeleteTagged(tctx, &req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), r.NumSeries)
+}
+
 func TestServiceSetPersistRateLimit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,352 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/adler32"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	xlog "github.com/m3db/m3x/log"
+)
+
+const (
+	// ManifestPath is the path of the manifest relative to the root of the
+	// destination.
+	ManifestPath = "manifest.json"
+
+	checkpointFileSuffix = "checkpoint"
+)
+
+type backuper struct {
+	filePathPrefix string
+	destination    Destination
+	bufferSize     int
+	coldStorage    fs.ColdStorage
+	nowFn          func() time.Time
+	logger         xlog.Logger
+}
+
+// NewBackuper returns a new backuper.
+func NewBackuper(opts Options) (Backuper, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return &backuper{
+		filePathPrefix: opts.FilePathPrefix(),
+		destination:    opts.Destination(),
+		bufferSize:     opts.BufferSize(),
+		coldStorage:    opts.ColdStorage(),
+		nowFn:          time.Now,
+		logger:         opts.InstrumentOptions().Logger(),
+	}, nil
+}
+
+func (b *backuper) Backup(targets []Target) (Manifest, error) {
+	manifest := Manifest{CreatedAt: b.nowFn()}
+
+	for _, target := range targets {
+		namespace := target.Namespace
+		for _, shard := range target.Shards {
+			filesets, err := fs.DataFiles(b.filePathPrefix, namespace, shard)
+			if err != nil {
+				return Manifest{}, err
+			}
+			for _, fileset := range filesets {
+				if !fileset.HasCheckpointFile() {
+					// Volume is still being written.
+					continue
+				}
+				if err := b.backupDataFileSet(&manifest, DataFileType,
+					persist.FileSetFlushType, fileset); err != nil {
+					return Manifest{}, err
+				}
+			}
+
+			snapshots, err := fs.SnapshotFiles(b.filePathPrefix, namespace, shard)
+			if err != nil {
+				return Manifest{}, err
+			}
+			for _, blockStart := range distinctBlockStarts(snapshots) {
+				latest, ok := snapshots.LatestVolumeForBlock(blockStart)
+				if !ok {
+					continue
+				}
+				if err := b.backupDataFileSet(&manifest, SnapshotFileType,
+					persist.FileSetSnapshotType, latest); err != nil {
+					return Manifest{}, err
+				}
+			}
+		}
+
+		filesets, err := fs.IndexFiles(b.filePathPrefix, namespace)
+		if err != nil {
+			return Manifest{}, err
+		}
+		for _, fileset := range filesets {
+			if !fileset.HasCheckpointFile() {
+				continue
+			}
+			template := manifestFileTemplate(IndexFileType, fileset)
+			if err := b.backupFileSet(&manifest, template, fileset, nil); err != nil {
+				return Manifest{}, err
+			}
+		}
+	}
+
+	// NB: Commit logs are shared by all namespaces and shards, the reader
+	// tolerates a partially written final chunk of the active commit log.
+	commitLogs, err := fs.SortedCommitLogFiles(fs.CommitLogsDirPath(b.filePathPrefix))
+	if err != nil {
+		return Manifest{}, err
+	}
+	for _, commitLog := range commitLogs {
+		file, err := b.backupFile(ManifestFile{Type: CommitLogFileType}, commitLog, nil)
+		if err != nil {
+			return Manifest{}, err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return Manifest{}, err
+	}
+	if err := b.destination.Write(ManifestPath, bytes.NewReader(data)); err != nil {
+		return Manifest{}, err
+	}
+
+	b.logger.Infof("backed up %d files", len(manifest.Files))
+	return manifest, nil
+}
+
+func (b *backuper) backupDataFileSet(
+	manifest *Manifest,
+	fileType FileType,
+	fileSetType persist.FileSetType,
+	fileset fs.FileSetFile,
+) error {
+	digests, err := fs.DataFileSetDigests(b.filePathPrefix, fileSetType,
+		fileset.ID, b.bufferSize)
+	if err != nil {
+		return err
+	}
+	template := manifestFileTemplate(fileType, fileset)
+	return b.backupFileSet(manifest, template, fileset, digests)
+}
+
+// backupFileSet copies the files of a fileset volume, the checkpoint file is
+// copied last so that a restored volume is only complete once all of its
+// other files have been restored. The files of a volume offloaded to cold
+// storage that are not cached on local disk are copied from the cold storage
+// in place of its marker file, so the restored volume is complete locally.
+func (b *backuper) backupFileSet(
+	manifest *Manifest,
+	template ManifestFile,
+	fileset fs.FileSetFile,
+	digests map[string]uint32,
+) error {
+	var (
+		checkpointFilePath string
+		offloaded          []string
+		local              = make(map[string]struct{}, len(fileset.AbsoluteFilepaths))
+	)
+	for _, filePath := range fileset.AbsoluteFilepaths {
+		local[filePath] = struct{}{}
+	}
+	for _, filePath := range fileset.AbsoluteFilepaths {
+		if isCheckpointFile(filePath) {
+			checkpointFilePath = filePath
+			continue
+		}
+		if offloadedFilePaths, ok := fs.OffloadedFilePaths(filePath); ok {
+			for _, offloadedFilePath := range offloadedFilePaths {
+				if _, ok := local[offloadedFilePath]; !ok {
+					offloaded = append(offloaded, offloadedFilePath)
+				}
+			}
+			continue
+		}
+		if err := b.backupFileSetFile(manifest, template, filePath, false, digests); err != nil {
+			return err
+		}
+	}
+	for _, filePath := range offloaded {
+		if err := b.backupFileSetFile(manifest, template, filePath, true, digests); err != nil {
+			return err
+		}
+	}
+	return b.backupFileSetFile(manifest, template, checkpointFilePath, false, digests)
+}
+
+func (b *backuper) backupFileSetFile(
+	manifest *Manifest,
+	template ManifestFile,
+	filePath string,
+	offloaded bool,
+	digests map[string]uint32,
+) error {
+	var expected *uint32
+	if digest, ok := digests[filePath]; ok {
+		expected = &digest
+	}
+	var (
+		file ManifestFile
+		err  error
+	)
+	if offloaded {
+		file, err = b.backupOffloadedFile(template, filePath, expected)
+	} else {
+		file, err = b.backupFile(template, filePath, expected)
+	}
+	if err != nil {
+		return err
+	}
+	manifest.Files = append(manifest.Files, file)
+	return nil
+}
+
+// backupFile copies a file to the destination, validating its checksum
+// against the expected checksum if any.
+func (b *backuper) backupFile(
+	template ManifestFile,
+	filePath string,
+	expected *uint32,
+) (ManifestFile, error) {
+	fd, err := os.Open(filePath)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	defer fd.Close()
+
+	return b.copyFile(template, filePath, fd, expected)
+}
+
+// backupOffloadedFile copies a file offloaded to cold storage to the
+// destination, validating its checksum against the expected checksum if any.
+func (b *backuper) backupOffloadedFile(
+	template ManifestFile,
+	filePath string,
+	expected *uint32,
+) (ManifestFile, error) {
+	if b.coldStorage == nil {
+		return ManifestFile{}, fmt.Errorf(
+			"%s is offloaded to cold storage but no cold storage is set", filePath)
+	}
+	coldPath, err := fs.ColdStoragePath(b.filePathPrefix, filePath)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	r, err := b.coldStorage.Get(coldPath)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	defer r.Close()
+
+	return b.copyFile(template, filePath, r, expected)
+}
+
+func (b *backuper) copyFile(
+	template ManifestFile,
+	filePath string,
+	r io.Reader,
+	expected *uint32,
+) (ManifestFile, error) {
+	relPath, err := filepath.Rel(b.filePathPrefix, filePath)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+
+	reader := newChecksumReader(bufio.NewReaderSize(r, b.bufferSize))
+	file := template
+	file.Path = filepath.ToSlash(relPath)
+	if err := b.destination.Write(file.Path, reader); err != nil {
+		return ManifestFile{}, err
+	}
+
+	file.Size = reader.size
+	file.Checksum = reader.digest.Sum32()
+	if expected != nil && *expected != file.Checksum {
+		return ManifestFile{}, fmt.Errorf(
+			"checksum mismatch for %s: expected=%d, actual=%d",
+			filePath, *expected, file.Checksum)
+	}
+
+	return file, nil
+}
+
+func manifestFileTemplate(fileType FileType, fileset fs.FileSetFile) ManifestFile {
+	return ManifestFile{
+		Type:        fileType,
+		Namespace:   fileset.ID.Namespace.String(),
+		Shard:       fileset.ID.Shard,
+		BlockStart:  fileset.ID.BlockStart.UnixNano(),
+		VolumeIndex: fileset.ID.VolumeIndex,
+	}
+}
+
+func distinctBlockStarts(filesets fs.FileSetFilesSlice) []time.Time {
+	var blockStarts []time.Time
+	for _, fileset := range filesets {
+		n := len(blockStarts)
+		if n > 0 && blockStarts[n-1].Equal(fileset.ID.BlockStart) {
+			continue
+		}
+		blockStarts = append(blockStarts, fileset.ID.BlockStart)
+	}
+	return blockStarts
+}
+
+func isCheckpointFile(filePath string) bool {
+	return strings.Contains(filepath.Base(filePath), checkpointFileSuffix)
+}
+
+// checksumReader computes the size and adler32 checksum of the bytes read
+// through it, matching the digests of fileset files.
+type checksumReader struct {
+	reader io.Reader
+	digest hash.Hash32
+	size   int64
+}
+
+func newChecksumReader(reader io.Reader) *checksumReader {
+	return &checksumReader{
+		reader: reader,
+		digest: adler32.New(),
+	}
+}
+
+func (r *checksumReader) Read(b []byte) (int, error) {
+	n, err := r.reader.Read(b)
+	r.digest.Write(b[:n])
+	r.size += int64(n)
+	return n, err
+}
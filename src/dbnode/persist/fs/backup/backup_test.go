@@ -0,0 +1,273 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3x/checked"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testNamespace  = ident.StringID("testns")
+	testBlockSize  = 2 * time.Hour
+	testBlockStart = time.Unix(0, 0).Add(100 * testBlockSize)
+)
+
+func createTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	return dir
+}
+
+func writeTestFileSet(t *testing.T, filePathPrefix string, shard uint32) {
+	writer, err := fs.NewWriter(fs.NewOptions().SetFilePathPrefix(filePathPrefix))
+	require.NoError(t, err)
+	require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  testNamespace,
+			Shard:      shard,
+			BlockStart: testBlockStart,
+		},
+		BlockSize:   testBlockSize,
+		FileSetType: persist.FileSetFlushType,
+	}))
+
+	for _, id := range []string{"foo", "bar", "baz"} {
+		data := []byte(id + "-data")
+		bytes := checked.NewBytes(data, nil)
+		bytes.IncRef()
+		require.NoError(t, writer.Write(ident.StringID(id), ident.Tags{},
+			bytes, digest.Checksum(data)))
+	}
+	require.NoError(t, writer.Close())
+}
+
+func writeTestCommitLog(t *testing.T, filePathPrefix string) string {
+	dir := fs.CommitLogsDirPath(filePathPrefix)
+	require.NoError(t, os.MkdirAll(dir, os.ModeDir|os.FileMode(0755)))
+	filePath := filepath.Join(dir, "commitlog-0-0.db")
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("commitlog"), 0666))
+	return filePath
+}
+
+func newTestOptions(filePathPrefix, destDir string) Options {
+	opts := NewOptions()
+	return opts.
+		SetFilePathPrefix(filePathPrefix).
+		SetDestination(NewLocalDestination(destDir, opts.FileMode(), opts.DirMode()))
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	var (
+		srcDir     = createTempDir(t)
+		destDir    = createTempDir(t)
+		restoreDir = createTempDir(t)
+	)
+	defer os.RemoveAll(srcDir)
+	defer os.RemoveAll(destDir)
+	defer os.RemoveAll(restoreDir)
+
+	writeTestFileSet(t, srcDir, 0)
+	writeTestCommitLog(t, srcDir)
+
+	backuper, err := NewBackuper(newTestOptions(srcDir, destDir))
+	require.NoError(t, err)
+	manifest, err := backuper.Backup([]Target{
+		{Namespace: testNamespace, Shards: []uint32{0}},
+	})
+	require.NoError(t, err)
+
+	var dataFiles, commitLogFiles []ManifestFile
+	for _, file := range manifest.Files {
+		switch file.Type {
+		case DataFileType:
+			dataFiles = append(dataFiles, file)
+		case CommitLogFileType:
+			commitLogFiles = append(commitLogFiles, file)
+		}
+	}
+	// Info, index, summaries, bloom filter, data, digest and checkpoint files.
+	require.Equal(t, 7, len(dataFiles))
+	require.True(t, isCheckpointFile(dataFiles[len(dataFiles)-1].Path))
+	require.Equal(t, 1, len(commitLogFiles))
+
+	read, err := ReadManifest(NewLocalDestination(destDir, 0, 0))
+	require.NoError(t, err)
+	require.Equal(t, len(manifest.Files), len(read.Files))
+
+	restorer, err := NewRestorer(newTestOptions(restoreDir, destDir))
+	require.NoError(t, err)
+	_, err = restorer.Restore()
+	require.NoError(t, err)
+
+	for _, file := range manifest.Files {
+		expected, err := ioutil.ReadFile(filepath.Join(srcDir, file.Path))
+		require.NoError(t, err)
+		actual, err := ioutil.ReadFile(filepath.Join(restoreDir, file.Path))
+		require.NoError(t, err)
+		require.Equal(t, expected, actual, file.Path)
+	}
+
+	restored, ok, err := fs.FileSetAt(restoreDir, testNamespace, 0, testBlockStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, err = fs.DataFileSetDigests(restoreDir, persist.FileSetFlushType,
+		restored.ID, 4096)
+	require.NoError(t, err)
+
+	// Restores never overwrite existing files.
+	_, err = restorer.Restore()
+	require.Error(t, err)
+}
+
+func TestBackupAfterOffloadToColdStorage(t *testing.T) {
+	var (
+		srcDir     = createTempDir(t)
+		coldDir    = createTempDir(t)
+		destDir    = createTempDir(t)
+		restoreDir = createTempDir(t)
+	)
+	defer os.RemoveAll(srcDir)
+	defer os.RemoveAll(coldDir)
+	defer os.RemoveAll(destDir)
+	defer os.RemoveAll(restoreDir)
+
+	writeTestFileSet(t, srcDir, 0)
+	fileset, ok, err := fs.FileSetAt(srcDir, testNamespace, 0, testBlockStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	contents := make(map[string][]byte, len(fileset.AbsoluteFilepaths))
+	for _, filePath := range fileset.AbsoluteFilepaths {
+		relPath, err := filepath.Rel(srcDir, filePath)
+		require.NoError(t, err)
+		contents[relPath], err = ioutil.ReadFile(filePath)
+		require.NoError(t, err)
+	}
+
+	coldStorage := fs.NewLocalColdStorage(coldDir, 0666, os.ModeDir|os.FileMode(0755))
+	result, err := fs.OffloadDataFileSets(fs.NewOptions().
+		SetFilePathPrefix(srcDir).
+		SetColdStorage(coldStorage), testNamespace, 0, testBlockStart.Add(testBlockSize))
+	require.NoError(t, err)
+	require.Equal(t, 1, result.NumOffloaded)
+
+	targets := []Target{{Namespace: testNamespace, Shards: []uint32{0}}}
+
+	// Offloaded files can not be backed up without the cold storage.
+	backuper, err := NewBackuper(newTestOptions(srcDir, destDir))
+	require.NoError(t, err)
+	_, err = backuper.Backup(targets)
+	require.Error(t, err)
+
+	backuper, err = NewBackuper(newTestOptions(srcDir, destDir).
+		SetColdStorage(coldStorage))
+	require.NoError(t, err)
+	manifest, err := backuper.Backup(targets)
+	require.NoError(t, err)
+
+	// The offloaded index and data files are backed up in place of the marker.
+	require.Equal(t, len(contents), len(manifest.Files))
+	require.True(t, isCheckpointFile(manifest.Files[len(manifest.Files)-1].Path))
+
+	restorer, err := NewRestorer(newTestOptions(restoreDir, destDir))
+	require.NoError(t, err)
+	_, err = restorer.Restore()
+	require.NoError(t, err)
+
+	for relPath, expected := range contents {
+		actual, err := ioutil.ReadFile(filepath.Join(restoreDir, relPath))
+		require.NoError(t, err)
+		require.Equal(t, expected, actual, relPath)
+	}
+
+	restored, ok, err := fs.FileSetAt(restoreDir, testNamespace, 0, testBlockStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, len(contents), len(restored.AbsoluteFilepaths))
+	for _, filePath := range restored.AbsoluteFilepaths {
+		_, offloaded := fs.OffloadedFilePaths(filePath)
+		require.False(t, offloaded, filePath)
+	}
+}
+
+func TestRestoreChecksumMismatch(t *testing.T) {
+	var (
+		srcDir     = createTempDir(t)
+		destDir    = createTempDir(t)
+		restoreDir = createTempDir(t)
+	)
+	defer os.RemoveAll(srcDir)
+	defer os.RemoveAll(destDir)
+	defer os.RemoveAll(restoreDir)
+
+	commitLog := writeTestCommitLog(t, srcDir)
+
+	backuper, err := NewBackuper(newTestOptions(srcDir, destDir))
+	require.NoError(t, err)
+	manifest, err := backuper.Backup(nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(manifest.Files))
+
+	relPath, err := filepath.Rel(srcDir, commitLog)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(destDir, relPath),
+		[]byte("corrupt"), 0666))
+
+	restorer, err := NewRestorer(newTestOptions(restoreDir, destDir))
+	require.NoError(t, err)
+	_, err = restorer.Restore()
+	require.Error(t, err)
+
+	_, err = os.Stat(filepath.Join(restoreDir, relPath))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestLocalDestinationRejectsEscapingPaths(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	dest := NewLocalDestination(dir, 0666, os.ModeDir|os.FileMode(0755))
+	_, err := dest.Read("../outside")
+	require.Error(t, err)
+	_, err = dest.Read("/etc/passwd")
+	require.Error(t, err)
+}
+
+func TestOptionsValidate(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errNoFilePathPrefix, opts.Validate())
+	opts = opts.SetFilePathPrefix("/var/lib/m3db")
+	require.Equal(t, errNoDestination, opts.Validate())
+	opts = opts.SetDestination(NewLocalDestination("/tmp", 0666, 0755))
+	require.NoError(t, opts.Validate())
+	require.Equal(t, errBufferSizeTooLow, opts.SetBufferSize(0).Validate())
+}
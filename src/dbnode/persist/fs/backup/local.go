@@ -0,0 +1,115 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+)
+
+const tempFileSuffix = ".tmp"
+
+var errInvalidPath = errors.New("path must be relative and within the destination")
+
+type localDestination struct {
+	dir      string
+	fileMode os.FileMode
+	dirMode  os.FileMode
+}
+
+// NewLocalDestination returns a destination that stores backups in a
+// directory of the local filesystem.
+func NewLocalDestination(dir string, fileMode, dirMode os.FileMode) Destination {
+	return &localDestination{
+		dir:      dir,
+		fileMode: fileMode,
+		dirMode:  dirMode,
+	}
+}
+
+func (d *localDestination) Write(p string, r io.Reader) error {
+	filePath, err := localPath(d.dir, p)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), d.dirMode); err != nil {
+		return err
+	}
+	return writeFileAtomically(filePath, r, d.fileMode, nil)
+}
+
+func (d *localDestination) Read(p string) (io.ReadCloser, error) {
+	filePath, err := localPath(d.dir, p)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(filePath)
+}
+
+// localPath returns the path on the local filesystem of a slash separated
+// path relative to the directory, rejecting paths that escape it.
+func localPath(dir string, p string) (string, error) {
+	cleaned := path.Clean(p)
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("%v: %s", errInvalidPath, p)
+	}
+	return filepath.Join(dir, filepath.FromSlash(cleaned)), nil
+}
+
+// writeFileAtomically writes the contents of the reader to a temporary file
+// that is synced and, once fully written and validated if a validation
+// function is given, renamed over the file path.
+func writeFileAtomically(
+	filePath string,
+	r io.Reader,
+	fileMode os.FileMode,
+	validateFn func() error,
+) error {
+	tempFilePath := filePath + tempFileSuffix
+	fd, err := fs.OpenWritable(tempFilePath, fileMode)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(fd, r)
+	if err == nil {
+		err = fd.Sync()
+	}
+	if closeErr := fd.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && validateFn != nil {
+		err = validateFn()
+	}
+	if err != nil {
+		os.Remove(tempFilePath)
+		return err
+	}
+
+	return os.Rename(tempFilePath, filePath)
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"errors"
+	"os"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3x/instrument"
+)
+
+const (
+	defaultBufferSize = 65536
+	defaultFileMode   = os.FileMode(0666)
+	defaultDirMode    = os.ModeDir | os.FileMode(0755)
+)
+
+var (
+	errNoFilePathPrefix = errors.New("no file path prefix set")
+	errNoDestination    = errors.New("no destination set")
+	errBufferSizeTooLow = errors.New("buffer size must be positive")
+)
+
+type options struct {
+	instrumentOpts instrument.Options
+	filePathPrefix string
+	destination    Destination
+	bufferSize     int
+	fileMode       os.FileMode
+	dirMode        os.FileMode
+	coldStorage    fs.ColdStorage
+}
+
+// NewOptions returns new backup options.
+func NewOptions() Options {
+	return &options{
+		instrumentOpts: instrument.NewOptions(),
+		bufferSize:     defaultBufferSize,
+		fileMode:       defaultFileMode,
+		dirMode:        defaultDirMode,
+	}
+}
+
+func (o *options) Validate() error {
+	if o.filePathPrefix == "" {
+		return errNoFilePathPrefix
+	}
+	if o.destination == nil {
+		return errNoDestination
+	}
+	if o.bufferSize <= 0 {
+		return errBufferSizeTooLow
+	}
+	return nil
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
+
+func (o *options) SetFilePathPrefix(value string) Options {
+	opts := *o
+	opts.filePathPrefix = value
+	return &opts
+}
+
+func (o *options) FilePathPrefix() string {
+	return o.filePathPrefix
+}
+
+func (o *options) SetDestination(value Destination) Options {
+	opts := *o
+	opts.destination = value
+	return &opts
+}
+
+func (o *options) Destination() Destination {
+	return o.destination
+}
+
+func (o *options) SetBufferSize(value int) Options {
+	opts := *o
+	opts.bufferSize = value
+	return &opts
+}
+
+func (o *options) BufferSize() int {
+	return o.bufferSize
+}
+
+func (o *options) SetFileMode(value os.FileMode) Options {
+	opts := *o
+	opts.fileMode = value
+	return &opts
+}
+
+func (o *options) FileMode() os.FileMode {
+	return o.fileMode
+}
+
+func (o *options) SetDirMode(value os.FileMode) Options {
+	opts := *o
+	opts.dirMode = value
+	return &opts
+}
+
+func (o *options) DirMode() os.FileMode {
+	return o.dirMode
+}
+
+func (o *options) SetColdStorage(value fs.ColdStorage) Options {
+	opts := *o
+	opts.coldStorage = value
+	return &opts
+}
+
+func (o *options) ColdStorage() fs.ColdStorage {
+	return o.coldStorage
+}
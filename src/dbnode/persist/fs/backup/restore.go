@@ -0,0 +1,128 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	xlog "github.com/m3db/m3x/log"
+)
+
+type restorer struct {
+	filePathPrefix string
+	destination    Destination
+	bufferSize     int
+	fileMode       os.FileMode
+	dirMode        os.FileMode
+	logger         xlog.Logger
+}
+
+// NewRestorer returns a new restorer.
+func NewRestorer(opts Options) (Restorer, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return &restorer{
+		filePathPrefix: opts.FilePathPrefix(),
+		destination:    opts.Destination(),
+		bufferSize:     opts.BufferSize(),
+		fileMode:       opts.FileMode(),
+		dirMode:        opts.DirMode(),
+		logger:         opts.InstrumentOptions().Logger(),
+	}, nil
+}
+
+func (r *restorer) Restore() (Manifest, error) {
+	manifest, err := ReadManifest(r.destination)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	// Restore checkpoint files last so that the bootstrappers only consider
+	// volumes whose files have all been restored.
+	files := append([]ManifestFile(nil), manifest.Files...)
+	sort.SliceStable(files, func(i, j int) bool {
+		return !isCheckpointFile(files[i].Path) && isCheckpointFile(files[j].Path)
+	})
+
+	for _, file := range files {
+		if err := r.restoreFile(file); err != nil {
+			return Manifest{}, err
+		}
+	}
+
+	r.logger.Infof("restored %d files", len(files))
+	return manifest, nil
+}
+
+func (r *restorer) restoreFile(file ManifestFile) error {
+	filePath, err := localPath(r.filePathPrefix, file.Path)
+	if err != nil {
+		return err
+	}
+
+	// Never overwrite the files of a node, restores are into an empty node.
+	if _, err := os.Stat(filePath); err == nil {
+		return fmt.Errorf("file already exists: %s", filePath)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), r.dirMode); err != nil {
+		return err
+	}
+
+	rc, err := r.destination.Read(file.Path)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	reader := newChecksumReader(bufio.NewReaderSize(rc, r.bufferSize))
+	return writeFileAtomically(filePath, reader, r.fileMode, func() error {
+		if reader.size == file.Size && reader.digest.Sum32() == file.Checksum {
+			return nil
+		}
+		return fmt.Errorf(
+			"restored file %s does not match manifest: expected size=%d checksum=%d, actual size=%d checksum=%d",
+			file.Path, file.Size, file.Checksum, reader.size, reader.digest.Sum32())
+	})
+}
+
+// ReadManifest reads the manifest of the backup at the destination.
+func ReadManifest(destination Destination) (Manifest, error) {
+	rc, err := destination.Read(ManifestPath)
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer rc.Close()
+
+	var manifest Manifest
+	if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
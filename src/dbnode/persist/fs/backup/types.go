@@ -0,0 +1,173 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package backup copies the filesets of a node to a destination and restores
+// them from it.
+package backup
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/instrument"
+)
+
+// FileType is the type of a file in a backup.
+type FileType string
+
+const (
+	// DataFileType is a file of a data fileset volume.
+	DataFileType FileType = "data"
+
+	// IndexFileType is a file of an index fileset volume.
+	IndexFileType FileType = "index"
+
+	// SnapshotFileType is a file of a data snapshot fileset volume.
+	SnapshotFileType FileType = "snapshot"
+
+	// CommitLogFileType is a commit log file.
+	CommitLogFileType FileType = "commitlog"
+)
+
+// ManifestFile describes a single file of a backup.
+type ManifestFile struct {
+	// Type is the type of the file.
+	Type FileType `json:"type"`
+
+	// Namespace is the namespace of the file, empty for commit logs.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Shard is the shard of data and snapshot files.
+	Shard uint32 `json:"shard"`
+
+	// BlockStart is the block start of fileset files in nanoseconds.
+	BlockStart int64 `json:"blockStart"`
+
+	// VolumeIndex is the volume index of fileset files.
+	VolumeIndex int `json:"volumeIndex"`
+
+	// Path is the slash separated path of the file relative to the file
+	// path prefix of the node and the root of the destination.
+	Path string `json:"path"`
+
+	// Size is the size of the file in bytes.
+	Size int64 `json:"size"`
+
+	// Checksum is the adler32 checksum of the contents of the file.
+	Checksum uint32 `json:"checksum"`
+}
+
+// Manifest describes the files of a backup, a backup is only complete once
+// its manifest has been written.
+type Manifest struct {
+	// CreatedAt is the time the backup was started.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Files are the files of the backup.
+	Files []ManifestFile `json:"files"`
+}
+
+// Target selects the shards of a namespace to back up.
+type Target struct {
+	Namespace ident.ID
+	Shards    []uint32
+}
+
+// Destination stores the files of backups, it is implemented for the local
+// filesystem and can be implemented for object stores.
+type Destination interface {
+	// Write writes the contents of the reader to the file at the slash
+	// separated path relative to the root of the destination.
+	Write(path string, r io.Reader) error
+
+	// Read opens the file at the slash separated path relative to the root
+	// of the destination for reading.
+	Read(path string) (io.ReadCloser, error)
+}
+
+// Backuper backs up the filesets of a node.
+type Backuper interface {
+	// Backup copies the complete data and index fileset volumes, the latest
+	// snapshot volumes and the commit logs of the targets to the destination
+	// and writes the manifest of the backup last. The files of data fileset
+	// volumes offloaded to cold storage are copied from the cold storage, so
+	// that restored volumes are complete on local disk.
+	Backup(targets []Target) (Manifest, error)
+}
+
+// Restorer restores the filesets of a node.
+type Restorer interface {
+	// Restore reads the manifest from the destination and copies the files
+	// it describes to the file path prefix, validating their checksums.
+	Restore() (Manifest, error)
+}
+
+// Options represents the options for backups and restores.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetInstrumentOptions sets the instrumentation options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrumentation options.
+	InstrumentOptions() instrument.Options
+
+	// SetFilePathPrefix sets the file path prefix of the node.
+	SetFilePathPrefix(value string) Options
+
+	// FilePathPrefix returns the file path prefix of the node.
+	FilePathPrefix() string
+
+	// SetDestination sets the destination of backups.
+	SetDestination(value Destination) Options
+
+	// Destination returns the destination of backups.
+	Destination() Destination
+
+	// SetBufferSize sets the buffer size used to read and copy files.
+	SetBufferSize(value int) Options
+
+	// BufferSize returns the buffer size used to read and copy files.
+	BufferSize() int
+
+	// SetFileMode sets the file mode used for file creation.
+	SetFileMode(value os.FileMode) Options
+
+	// FileMode returns the file mode used for file creation.
+	FileMode() os.FileMode
+
+	// SetDirMode sets the file mode used for dir creation.
+	SetDirMode(value os.FileMode) Options
+
+	// DirMode returns the file mode used for dir creation.
+	DirMode() os.FileMode
+
+	// SetColdStorage sets the cold storage that the node offloads data
+	// fileset volumes to, the offloaded files of a volume are copied from it.
+	SetColdStorage(value fs.ColdStorage) Options
+
+	// ColdStorage returns the cold storage that the node offloads data
+	// fileset volumes to.
+	ColdStorage() fs.ColdStorage
+}
@@ -0,0 +1,382 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+)
+
+const coldStorageTempFileSuffix = ".tmp"
+
+var (
+	errColdStoragePathInvalid = errors.New("cold storage path must be relative and within the directory")
+
+	// offloadedFileSuffixes are the suffixes of the files of a data fileset
+	// volume that are moved to cold storage, they hold the bulk of a volume.
+	// The remaining files are small and kept on local disk so that bootstrap
+	// and cleanup still discover the volume and the bloom filter and summaries
+	// can be consulted without fetching anything.
+	offloadedFileSuffixes = []string{indexFileSuffix, dataFileSuffix}
+)
+
+// OffloadResult is the result of offloading the data fileset volumes of a shard.
+type OffloadResult struct {
+	// NumOffloaded is the number of volumes moved to cold storage.
+	NumOffloaded int
+	// NumEvicted is the number of volumes whose locally cached copies were evicted.
+	NumEvicted int
+}
+
+// OffloadDataFileSets moves the index and data files of the complete data
+// fileset volumes of a shard whose block start is before the given time to
+// cold storage, leaving a marker file in their place. Copies of offloaded
+// files that were fetched back from cold storage longer than the cache TTL
+// ago are evicted from local disk. It is a no-op if no cold storage is set.
+func OffloadDataFileSets(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	before time.Time,
+) (OffloadResult, error) {
+	var result OffloadResult
+	coldStorage := opts.ColdStorage()
+	if coldStorage == nil {
+		return result, nil
+	}
+
+	filePathPrefix := opts.FilePathPrefix()
+	filesets, err := DataFiles(filePathPrefix, namespace, shard)
+	if err != nil {
+		return result, err
+	}
+
+	var (
+		shardDir = ShardDataDirPath(filePathPrefix, namespace, shard)
+		now      = opts.ClockOptions().NowFn()()
+		multiErr = xerrors.NewMultiError()
+	)
+	for _, fileset := range filesets {
+		if !fileset.ID.BlockStart.Before(before) || !fileset.HasCheckpointFile() {
+			continue
+		}
+
+		var (
+			blockStart  = fileset.ID.BlockStart
+			volumeIndex = fileset.ID.VolumeIndex
+			markerPath  = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, offloadedFileSuffix)
+			filePaths   = make([]string, 0, len(offloadedFileSuffixes))
+		)
+		for _, suffix := range offloadedFileSuffixes {
+			filePaths = append(filePaths,
+				dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, suffix))
+		}
+
+		offloaded, err := FileExists(markerPath)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		if offloaded {
+			evicted, err := evictColdStorageCache(filePaths, now, opts.ColdStorageCacheTTL())
+			if err != nil {
+				multiErr = multiErr.Add(err)
+				continue
+			}
+			if evicted {
+				result.NumEvicted++
+			}
+			continue
+		}
+
+		if err := offloadFiles(coldStorage, filePathPrefix, filePaths,
+			markerPath, opts.NewFileMode()); err != nil {
+			multiErr = multiErr.Add(fmt.Errorf(
+				"unable to offload fileset volume %d of block %v: %v",
+				volumeIndex, blockStart, err))
+			continue
+		}
+		result.NumOffloaded++
+	}
+
+	return result, multiErr.FinalError()
+}
+
+// offloadFiles uploads the files to cold storage and writes the marker file
+// before deleting the local files, a volume interrupted part way through is
+// uploaded again in full on the next attempt since it has no marker file yet.
+func offloadFiles(
+	coldStorage ColdStorage,
+	filePathPrefix string,
+	filePaths []string,
+	markerPath string,
+	newFileMode os.FileMode,
+) error {
+	for _, filePath := range filePaths {
+		coldPath, err := ColdStoragePath(filePathPrefix, filePath)
+		if err != nil {
+			return err
+		}
+		fd, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		err = coldStorage.Put(coldPath, fd)
+		if closeErr := fd.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	fd, err := OpenWritable(markerPath, newFileMode)
+	if err != nil {
+		return err
+	}
+	err = fd.Sync()
+	if closeErr := fd.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	// NB: Readers that already have the files open or mmap'd can keep reading
+	// them after they are unlinked.
+	return DeleteFiles(filePaths)
+}
+
+// evictColdStorageCache deletes the local copies of offloaded files that were
+// fetched back from cold storage at least the TTL ago, returning whether any
+// were deleted.
+func evictColdStorageCache(filePaths []string, now time.Time, ttl time.Duration) (bool, error) {
+	var toDelete []string
+	for _, filePath := range filePaths {
+		info, err := os.Stat(filePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		if now.Sub(info.ModTime()) >= ttl {
+			toDelete = append(toDelete, filePath)
+		}
+	}
+	if len(toDelete) == 0 {
+		return false, nil
+	}
+	return true, DeleteFiles(toDelete)
+}
+
+// fetchFromColdStorage fetches any of the given files that are missing from
+// local disk back from cold storage so they can be opened as usual, the
+// fetched files are left in place as a cache until they are evicted.
+func fetchFromColdStorage(opts Options, filePathPrefix string, filePaths ...string) error {
+	coldStorage := opts.ColdStorage()
+	if coldStorage == nil {
+		return nil
+	}
+
+	for _, filePath := range filePaths {
+		_, err := os.Stat(filePath)
+		if err == nil {
+			continue
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+
+		coldPath, err := ColdStoragePath(filePathPrefix, filePath)
+		if err != nil {
+			return err
+		}
+		r, err := coldStorage.Get(coldPath)
+		if err != nil {
+			return err
+		}
+		err = writeFileAtomically(filePath, r, opts.NewFileMode())
+		if closeErr := r.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteOffloadedFiles deletes the cold storage copies of the files of any
+// offloaded data fileset volumes among the given local fileset files. It
+// should be called before deleting the local files as the marker files of
+// offloaded volumes are the only record of what is in cold storage.
+func DeleteOffloadedFiles(opts Options, filePaths []string) error {
+	coldStorage := opts.ColdStorage()
+	if coldStorage == nil {
+		return nil
+	}
+
+	var (
+		filePathPrefix = opts.FilePathPrefix()
+		multiErr       = xerrors.NewMultiError()
+	)
+	for _, filePath := range filePaths {
+		offloadedFilePaths, ok := OffloadedFilePaths(filePath)
+		if !ok {
+			continue
+		}
+		for _, offloadedFilePath := range offloadedFilePaths {
+			coldPath, err := ColdStoragePath(filePathPrefix, offloadedFilePath)
+			if err != nil {
+				multiErr = multiErr.Add(err)
+				continue
+			}
+			multiErr = multiErr.Add(coldStorage.Delete(coldPath))
+		}
+	}
+	return multiErr.FinalError()
+}
+
+// OffloadedFilePaths returns the local paths of the files that were moved to
+// cold storage when the data fileset volume with the given marker file was
+// offloaded, returning false if the path is not that of a marker file.
+func OffloadedFilePaths(markerPath string) ([]string, bool) {
+	markerSuffix := offloadedFileSuffix + fileSuffix
+	if !strings.HasSuffix(markerPath, markerSuffix) {
+		return nil, false
+	}
+	trimmed := strings.TrimSuffix(markerPath, markerSuffix)
+	filePaths := make([]string, 0, len(offloadedFileSuffixes))
+	for _, suffix := range offloadedFileSuffixes {
+		filePaths = append(filePaths, trimmed+suffix+fileSuffix)
+	}
+	return filePaths, true
+}
+
+// ColdStoragePath returns the cold storage path of a file beneath the file
+// path prefix.
+func ColdStoragePath(filePathPrefix string, filePath string) (string, error) {
+	rel, err := filepath.Rel(filePathPrefix, filePath)
+	if err != nil {
+		return "", err
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("file %s is not beneath file path prefix %s", filePath, filePathPrefix)
+	}
+	return rel, nil
+}
+
+type localColdStorage struct {
+	dir              string
+	newFileMode      os.FileMode
+	newDirectoryMode os.FileMode
+}
+
+// NewLocalColdStorage returns a cold storage backend that stores files in a
+// directory of the local filesystem, useful for testing or for offloading to
+// a network mount.
+func NewLocalColdStorage(dir string, newFileMode, newDirectoryMode os.FileMode) ColdStorage {
+	return &localColdStorage{
+		dir:              dir,
+		newFileMode:      newFileMode,
+		newDirectoryMode: newDirectoryMode,
+	}
+}
+
+func (s *localColdStorage) Put(p string, r io.Reader) error {
+	filePath, err := s.localPath(p)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), s.newDirectoryMode); err != nil {
+		return err
+	}
+	return writeFileAtomically(filePath, r, s.newFileMode)
+}
+
+func (s *localColdStorage) Get(p string) (io.ReadCloser, error) {
+	filePath, err := s.localPath(p)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(filePath)
+}
+
+func (s *localColdStorage) Delete(p string) error {
+	filePath, err := s.localPath(p)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *localColdStorage) localPath(p string) (string, error) {
+	cleaned := path.Clean(p)
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("%v: %s", errColdStoragePathInvalid, p)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(cleaned)), nil
+}
+
+// writeFileAtomically writes the contents of the reader to a uniquely named
+// temporary file in the same directory that is synced and renamed over the
+// file path once fully written, so concurrent writers of the same file and
+// readers never observe a partially written file.
+func writeFileAtomically(filePath string, r io.Reader, newFileMode os.FileMode) error {
+	fd, err := ioutil.TempFile(filepath.Dir(filePath),
+		filepath.Base(filePath)+coldStorageTempFileSuffix)
+	if err != nil {
+		return err
+	}
+	tempFilePath := fd.Name()
+
+	_, err = io.Copy(fd, r)
+	if err == nil {
+		err = fd.Chmod(newFileMode)
+	}
+	if err == nil {
+		err = fd.Sync()
+	}
+	if closeErr := fd.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tempFilePath)
+		return err
+	}
+
+	return os.Rename(tempFilePath, filePath)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffloadDataFileSetsFetchAndEvict(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	filePathPrefix := filepath.Join(dir, "data")
+	coldDir := filepath.Join(dir, "cold")
+
+	entries := []testEntry{
+		{"foo", nil, []byte{1, 2, 3}},
+		{"bar", nil, []byte{4, 5, 6}},
+	}
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, entries, persist.FileSetFlushType)
+
+	var (
+		before = testWriterStart.Add(testBlockSize)
+		now    = time.Now()
+	)
+	opts := testDefaultOpts.
+		SetFilePathPrefix(filePathPrefix).
+		SetInfoReaderBufferSize(testReaderBufferSize).
+		SetDataReaderBufferSize(testReaderBufferSize).
+		SetColdStorage(NewLocalColdStorage(coldDir, defaultNewFileMode, defaultNewDirectoryMode)).
+		SetColdStorageCacheTTL(time.Hour).
+		SetClockOptions(clock.NewOptions().SetNowFn(func() time.Time {
+			return now
+		}))
+
+	shardDir := ShardDataDirPath(filePathPrefix, testNs1ID, 0)
+	var (
+		markerPath = dataFilesetPathFromTimeAndIndex(shardDir, testWriterStart, 0, offloadedFileSuffix)
+		indexPath  = dataFilesetPathFromTimeAndIndex(shardDir, testWriterStart, 0, indexFileSuffix)
+		dataPath   = dataFilesetPathFromTimeAndIndex(shardDir, testWriterStart, 0, dataFileSuffix)
+	)
+	indexColdPath, err := ColdStoragePath(filePathPrefix, indexPath)
+	require.NoError(t, err)
+	dataColdPath, err := ColdStoragePath(filePathPrefix, dataPath)
+	require.NoError(t, err)
+
+	offloadedPaths, ok := OffloadedFilePaths(markerPath)
+	require.True(t, ok)
+	assert.Equal(t, []string{indexPath, dataPath}, offloadedPaths)
+	_, ok = OffloadedFilePaths(indexPath)
+	assert.False(t, ok)
+
+	// Blocks starting at or after the cutoff are not offloaded.
+	result, err := OffloadDataFileSets(opts, testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	assert.Equal(t, OffloadResult{}, result)
+
+	result, err = OffloadDataFileSets(opts, testNs1ID, 0, before)
+	require.NoError(t, err)
+	assert.Equal(t, OffloadResult{NumOffloaded: 1}, result)
+	assertFileExists(t, markerPath, true)
+	assertFileExists(t, indexPath, false)
+	assertFileExists(t, dataPath, false)
+	assertFileExists(t, filepath.Join(coldDir, filepath.FromSlash(indexColdPath)), true)
+	assertFileExists(t, filepath.Join(coldDir, filepath.FromSlash(dataColdPath)), true)
+
+	// The volume is still discoverable and complete locally.
+	filesets, err := DataFileSetsAt(filePathPrefix, testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(filesets))
+
+	// Opening a seeker fetches the offloaded files back.
+	s := NewSeeker(filePathPrefix, testReaderBufferSize, testReaderBufferSize,
+		testReaderBufferSize, testBytesPool, false, nil, opts)
+	require.NoError(t, s.Open(testNs1ID, 0, testWriterStart, 0))
+	data, err := s.SeekByID(ident.StringID("bar"))
+	require.NoError(t, err)
+	data.IncRef()
+	assert.Equal(t, []byte{4, 5, 6}, data.Bytes())
+	data.DecRef()
+	require.NoError(t, s.Close())
+	assertFileExists(t, indexPath, true)
+	assertFileExists(t, dataPath, true)
+
+	// Fetched files are kept until the cache TTL has passed.
+	result, err = OffloadDataFileSets(opts, testNs1ID, 0, before)
+	require.NoError(t, err)
+	assert.Equal(t, OffloadResult{}, result)
+
+	now = time.Now().Add(time.Hour)
+	result, err = OffloadDataFileSets(opts, testNs1ID, 0, before)
+	require.NoError(t, err)
+	assert.Equal(t, OffloadResult{NumEvicted: 1}, result)
+	assertFileExists(t, indexPath, false)
+	assertFileExists(t, dataPath, false)
+
+	// Opening a reader fetches the offloaded files back too.
+	r, err := NewReader(testBytesPool, opts)
+	require.NoError(t, err)
+	readTestData(t, r, 0, testWriterStart, entries)
+
+	// Deleting the volume removes the cold storage copies.
+	filesets, err = DataFiles(filePathPrefix, testNs1ID, 0)
+	require.NoError(t, err)
+	require.NoError(t, DeleteOffloadedFiles(opts, filesets.Filepaths()))
+	assertFileExists(t, filepath.Join(coldDir, filepath.FromSlash(indexColdPath)), false)
+	assertFileExists(t, filepath.Join(coldDir, filepath.FromSlash(dataColdPath)), false)
+}
+
+func TestOffloadDataFileSetsNoColdStorage(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+	filePathPrefix := filepath.Join(dir, "")
+
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, nil, persist.FileSetFlushType)
+
+	opts := testDefaultOpts.SetFilePathPrefix(filePathPrefix)
+	result, err := OffloadDataFileSets(opts, testNs1ID, 0, testWriterStart.Add(testBlockSize))
+	require.NoError(t, err)
+	assert.Equal(t, OffloadResult{}, result)
+
+	shardDir := ShardDataDirPath(filePathPrefix, testNs1ID, 0)
+	assertFileExists(t, dataFilesetPathFromTimeAndIndex(shardDir, testWriterStart, 0, dataFileSuffix), true)
+}
+
+func TestLocalColdStorage(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	storage := NewLocalColdStorage(dir, defaultNewFileMode, defaultNewDirectoryMode)
+	require.NoError(t, storage.Put("a/b/c.db", bytes.NewReader([]byte("foo"))))
+
+	r, err := storage.Get("a/b/c.db")
+	require.NoError(t, err)
+	contents, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, []byte("foo"), contents)
+
+	require.NoError(t, storage.Delete("a/b/c.db"))
+	require.NoError(t, storage.Delete("a/b/c.db"))
+	_, err = storage.Get("a/b/c.db")
+	assert.True(t, os.IsNotExist(err))
+
+	for _, p := range []string{"/a/b", "../a", "a/../../b"} {
+		assert.Error(t, storage.Put(p, bytes.NewReader(nil)))
+	}
+}
+
+func assertFileExists(t *testing.T, filePath string, expected bool) {
+	_, err := os.Stat(filePath)
+	if expected {
+		assert.NoError(t, err, filePath)
+	} else {
+		assert.True(t, os.IsNotExist(err), filePath)
+	}
+}
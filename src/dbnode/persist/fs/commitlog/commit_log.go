@@ -200,6 +200,10 @@ type commitLogWrite struct {
 	unit       xtime.Unit
 	annotation ts.Annotation
 	callbackFn callbackFn
+
+	// tombstoneEnd is set for tombstone writes which delete the datapoints
+	// of the series from the datapoint timestamp up until tombstoneEnd
+	tombstoneEnd time.Time
 }
 
 // NewCommitLog creates a new commit log
@@ -443,8 +447,14 @@ func (l *commitLog) write() {
 			}
 		}
 
-		err := l.writerState.writer.Write(write.series,
-			write.datapoint, write.unit, write.annotation)
+		var err error
+		if !write.tombstoneEnd.IsZero() {
+			err = l.writerState.writer.WriteTombstone(write.series,
+				write.datapoint.Timestamp, write.tombstoneEnd)
+		} else {
+			err = l.writerState.writer.Write(write.series,
+				write.datapoint, write.unit, write.annotation)
+		}
 
 		if err != nil {
 			l.metrics.errors.Inc(1)
@@ -545,6 +555,27 @@ func (l *commitLog) writeWait(
 	unit xtime.Unit,
 	annotation ts.Annotation,
 ) error {
+	return l.enqueueAndWait(commitLogWrite{
+		series:     series,
+		datapoint:  datapoint,
+		unit:       unit,
+		annotation: annotation,
+	})
+}
+
+func (l *commitLog) WriteTombstone(
+	ctx context.Context,
+	series Series,
+	start, end time.Time,
+) error {
+	return l.enqueueAndWait(commitLogWrite{
+		series:       series,
+		datapoint:    ts.Datapoint{Timestamp: start},
+		tombstoneEnd: end,
+	})
+}
+
+func (l *commitLog) enqueueAndWait(write commitLogWrite) error {
 	l.closedState.RLock()
 	if l.closedState.closed {
 		l.closedState.RUnlock()
@@ -558,19 +589,11 @@ func (l *commitLog) writeWait(
 
 	wg.Add(1)
 
-	completion := func(r callbackResult) {
+	write.callbackFn = func(r callbackResult) {
 		result = r.err
 		wg.Done()
 	}
 
-	write := commitLogWrite{
-		series:     series,
-		datapoint:  datapoint,
-		unit:       unit,
-		annotation: annotation,
-		callbackFn: completion,
-	}
-
 	enqueued := false
 
 	select {
@@ -155,6 +155,7 @@ func snapshotCounterValue(
 type mockCommitLogWriter struct {
 	openFn  func(start time.Time, duration time.Duration) (File, error)
 	writeFn func(Series, ts.Datapoint, xtime.Unit, ts.Annotation) error
+	tombFn  func(Series, time.Time, time.Time) error
 	flushFn func(sync bool) error
 	closeFn func() error
 }
@@ -167,6 +168,9 @@ func newMockCommitLogWriter() *mockCommitLogWriter {
 		writeFn: func(Series, ts.Datapoint, xtime.Unit, ts.Annotation) error {
 			return nil
 		},
+		tombFn: func(Series, time.Time, time.Time) error {
+			return nil
+		},
 		flushFn: func(sync bool) error {
 			return nil
 		},
@@ -189,6 +193,10 @@ func (w *mockCommitLogWriter) Write(
 	return w.writeFn(series, datapoint, unit, annotation)
 }
 
+func (w *mockCommitLogWriter) WriteTombstone(series Series, start, end time.Time) error {
+	return w.tombFn(series, start, end)
+}
+
 func (w *mockCommitLogWriter) Flush(sync bool) error {
 	return w.flushFn(sync)
 }
@@ -369,6 +377,34 @@ func TestCommitLogWrite(t *testing.T) {
 	assertCommitLogWritesByIterating(t, commitLog, writes)
 }
 
+func TestCommitLogWriteTombstone(t *testing.T) {
+	opts, scope := newTestOptions(t, overrides{
+		strategy: StrategyWriteBehind,
+	})
+	defer cleanup(t, opts)
+
+	commitLog := newTestCommitLog(t, opts)
+
+	// Write the tombstone first so that it carries the series metadata
+	// which the datapoints written afterwards rely on
+	series := testSeries(0, "foo.bar", ident.NewTags(ident.StringTag("name1", "val1")), 127)
+	now := time.Now()
+	ctx := context.NewContext()
+	require.NoError(t, commitLog.WriteTombstone(ctx, series, time.Time{}, now))
+	ctx.Close()
+
+	writes := []testWrite{
+		{series, now.Add(time.Second), 123.456, xtime.Second, nil, nil},
+		{testSeries(1, "foo.baz", ident.NewTags(ident.StringTag("name2", "val2")), 150), now, 456.789, xtime.Second, nil, nil},
+	}
+	writeCommitLogs(t, scope, commitLog, writes).Wait()
+
+	require.NoError(t, commitLog.Close())
+
+	// Tombstones are not returned as datapoints when reading the commit log
+	assertCommitLogWritesByIterating(t, commitLog, writes)
+}
+
 func TestReadCommitLogMissingMetadata(t *testing.T) {
 	readConc := 4
 	// Make sure we're not leaking goroutines
@@ -359,6 +359,15 @@ func (r *reader) decoderLoop(inBuf <-chan decoderArg, outBuf chan<- readResponse
 			continue
 		}
 
+		if entry.TombstoneEnd != 0 {
+			// Tombstones are not replayed as datapoints, the shard tombstone files are
+			// written before a delete is acknowledged and are the source of truth for
+			// deleted ranges on restart. Any metadata attached to the entry has already
+			// been recorded above for the entries that follow it.
+			r.handleDecoderLoopIterationEnd(arg, nil, readResponse{}, nil)
+			continue
+		}
+
 		response.series = metadata.Series
 
 		response.datapoint = ts.Datapoint{
@@ -62,6 +62,15 @@ type CommitLog interface {
 		annotation ts.Annotation,
 	) error
 
+	// WriteTombstone will write a tombstone in the commit log marking the
+	// datapoints of a given series within [start, end) as deleted, it always
+	// waits for the tombstone to be flushed regardless of the write strategy
+	WriteTombstone(
+		ctx context.Context,
+		series Series,
+		start, end time.Time,
+	) error
+
 	// Close the commit log
 	Close() error
 
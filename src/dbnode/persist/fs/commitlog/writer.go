@@ -75,6 +75,10 @@ type commitLogWriter interface {
 		annotation ts.Annotation,
 	) error
 
+	// WriteTombstone will write a tombstone in the commit log marking the
+	// datapoints of a given series within [start, end) as deleted
+	WriteTombstone(series Series, start, end time.Time) error
+
 	// Flush will flush any data in the writers buffer to the chunkWriter, essentially forcing
 	// a new chunk to be created. Optionally forces the data to be FSync'd to disk.
 	Flush(sync bool) error
@@ -191,6 +195,23 @@ func (w *writer) Write(
 	annotation ts.Annotation,
 ) error {
 	var logEntry schema.LogEntry
+	logEntry.Timestamp = datapoint.Timestamp.UnixNano()
+	logEntry.Value = datapoint.Value
+	logEntry.Unit = uint32(unit)
+	logEntry.Annotation = annotation
+	return w.writeLogEntry(series, logEntry)
+}
+
+func (w *writer) WriteTombstone(series Series, start, end time.Time) error {
+	var logEntry schema.LogEntry
+	if !start.IsZero() {
+		logEntry.Timestamp = start.UnixNano()
+	}
+	logEntry.TombstoneEnd = end.UnixNano()
+	return w.writeLogEntry(series, logEntry)
+}
+
+func (w *writer) writeLogEntry(series Series, logEntry schema.LogEntry) error {
 	logEntry.Create = w.nowFn().UnixNano()
 	logEntry.Index = series.UniqueIndex
 
@@ -231,10 +252,6 @@ func (w *writer) Write(
 		logEntry.Metadata = w.metadataEncoder.Bytes()
 	}
 
-	logEntry.Timestamp = datapoint.Timestamp.UnixNano()
-	logEntry.Value = datapoint.Value
-	logEntry.Unit = uint32(unit)
-	logEntry.Annotation = annotation
 	w.logEncoder.Reset()
 	if err := w.logEncoder.EncodeLogEntry(logEntry); err != nil {
 		return err
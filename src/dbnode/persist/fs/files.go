@@ -53,6 +53,12 @@ const (
 
 	commitLogComponentPosition    = 2
 	indexFileSetComponentPosition = 2
+	dataFileSetComponentPosition  = 2
+
+	// dataFileSetLegacyComponents is the number of components in the file name
+	// of a data fileset file written without a volume index, which is always
+	// the case for volume zero.
+	dataFileSetLegacyComponents = 3
 )
 
 var (
@@ -120,7 +126,7 @@ func (f FileSetFilesSlice) Filepaths() []string {
 }
 
 // LatestVolumeForBlock returns the latest (highest index) FileSetFile in the
-// slice for a given block start, only applicable for index and snapshot file set files.
+// slice for a given block start.
 func (f FileSetFilesSlice) LatestVolumeForBlock(blockStart time.Time) (FileSetFile, bool) {
 	// Make sure we're already sorted
 	f.sortByTimeAndVolumeIndexAscending()
@@ -153,8 +159,8 @@ func (f FileSetFilesSlice) LatestVolumeForBlock(blockStart time.Time) (FileSetFi
 	return FileSetFile{}, false
 }
 
-// ignores the index in the FileSetFileIdentifier because fileset files should
-// always have index 0.
+// ignores the index in the FileSetFileIdentifier, callers that care about
+// volumes should use sortByTimeAndVolumeIndexAscending.
 func (f FileSetFilesSlice) sortByTimeAscending() {
 	sort.Slice(f, func(i, j int) bool {
 		return f[i].ID.BlockStart.Before(f[j].ID.BlockStart)
@@ -286,6 +292,21 @@ func (a fileSetFilesByTimeAndVolumeIndexAscending) Less(i, j int) bool {
 	return ti.Equal(tj) && ii < ij
 }
 
+// dataFileSetFilesByTimeAndVolumeIndexAscending sorts data file sets files by their block start
+// times and volume index in ascending order. Files written without a volume index are volume zero.
+type dataFileSetFilesByTimeAndVolumeIndexAscending []string
+
+func (a dataFileSetFilesByTimeAndVolumeIndexAscending) Len() int      { return len(a) }
+func (a dataFileSetFilesByTimeAndVolumeIndexAscending) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a dataFileSetFilesByTimeAndVolumeIndexAscending) Less(i, j int) bool {
+	ti, ii, _ := TimeAndVolumeIndexFromDataFileSetFilename(a[i])
+	tj, ij, _ := TimeAndVolumeIndexFromDataFileSetFilename(a[j])
+	if ti.Before(tj) {
+		return true
+	}
+	return ti.Equal(tj) && ii < ij
+}
+
 func componentsAndTimeFromFileName(fname string) ([]string, time.Time, error) {
 	components := strings.Split(filepath.Base(fname), separator)
 	if len(components) < 3 {
@@ -315,6 +336,19 @@ func TimeAndVolumeIndexFromFileSetFilename(fname string) (time.Time, int, error)
 	return timeAndIndexFromFileName(fname, indexFileSetComponentPosition)
 }
 
+// TimeAndVolumeIndexFromDataFileSetFilename extracts the block start and volume index from file
+// name for a data fileset file, file names without a volume index are treated as volume zero.
+func TimeAndVolumeIndexFromDataFileSetFilename(fname string) (time.Time, int, error) {
+	components, t, err := componentsAndTimeFromFileName(fname)
+	if err != nil {
+		return timeZero, 0, err
+	}
+	if len(components) == dataFileSetLegacyComponents {
+		return t, 0, nil
+	}
+	return timeAndIndexFromFileName(fname, dataFileSetComponentPosition)
+}
+
 func timeAndIndexFromFileName(fname string, componentPosition int) (time.Time, int, error) {
 	components, t, err := componentsAndTimeFromFileName(fname)
 	if err != nil {
@@ -456,9 +490,9 @@ func forEachInfoFile(
 		case persist.FileSetFlushType:
 			switch args.contentType {
 			case persist.FileSetDataContentType:
-				checkpointFilePath = filesetPathFromTime(dir, t, checkpointFileSuffix)
-				digestsFilePath = filesetPathFromTime(dir, t, digestFileSuffix)
-				infoFilePath = filesetPathFromTime(dir, t, infoFileSuffix)
+				checkpointFilePath = dataFilesetPathFromTimeAndIndex(dir, t, volume, checkpointFileSuffix)
+				digestsFilePath = dataFilesetPathFromTimeAndIndex(dir, t, volume, digestFileSuffix)
+				infoFilePath = dataFilesetPathFromTimeAndIndex(dir, t, volume, infoFileSuffix)
 			case persist.FileSetIndexContentType:
 				checkpointFilePath = filesetPathFromTimeAndIndex(dir, t, volume, checkpointFileSuffix)
 				digestsFilePath = filesetPathFromTimeAndIndex(dir, t, volume, digestFileSuffix)
@@ -521,6 +555,7 @@ func forEachInfoFile(
 
 // ReadInfoFileResult is the result of reading an info file
 type ReadInfoFileResult struct {
+	ID   FileSetFileIdentifier
 	Info schema.IndexInfo
 	Err  ReadInfoFileResultError
 }
@@ -547,8 +582,9 @@ func (r readInfoFileResultError) Filepath() string {
 	return r.filepath
 }
 
-// ReadInfoFiles reads all the valid info entries. Even if ReadInfoFiles returns an error,
-// there may be some valid entries in the returned slice.
+// ReadInfoFiles reads all the valid info entries, returning only the latest complete
+// volume for each block start. Even if ReadInfoFiles returns an error, there may be
+// some valid entries in the returned slice.
 func ReadInfoFiles(
 	filePathPrefix string,
 	namespace ident.ID,
@@ -570,13 +606,22 @@ func ReadInfoFiles(
 		func(filepath string, id FileSetFileIdentifier, data []byte) {
 			decoder.Reset(msgpack.NewDecoderStream(data))
 			info, err := decoder.DecodeIndexInfo()
-			infoFileResults = append(infoFileResults, ReadInfoFileResult{
+			result := ReadInfoFileResult{
+				ID:   id,
 				Info: info,
 				Err: readInfoFileResultError{
 					err:      err,
 					filepath: filepath,
 				},
-			})
+			}
+			// NB: info files are visited in block start and volume index ascending
+			// order so a later volume for the same block supersedes an earlier one.
+			if n := len(infoFileResults); n > 0 &&
+				infoFileResults[n-1].ID.BlockStart.Equal(id.BlockStart) {
+				infoFileResults[n-1] = result
+				return
+			}
+			infoFileResults = append(infoFileResults, result)
 		})
 	return infoFileResults
 }
@@ -619,6 +664,31 @@ func ReadIndexInfoFiles(
 	return infoFileResults
 }
 
+// DataFiles returns a slice of all the names for all the data fileset files
+// for a given namespace and shard combination.
+func DataFiles(filePathPrefix string, namespace ident.ID, shard uint32) (FileSetFilesSlice, error) {
+	return filesetFiles(filesetFilesSelector{
+		fileSetType:    persist.FileSetFlushType,
+		contentType:    persist.FileSetDataContentType,
+		filePathPrefix: filePathPrefix,
+		namespace:      namespace,
+		shard:          shard,
+		pattern:        filesetFilePattern,
+	})
+}
+
+// IndexFiles returns a slice of all the names for all the index fileset files
+// for a given namespace.
+func IndexFiles(filePathPrefix string, namespace ident.ID) (FileSetFilesSlice, error) {
+	return filesetFiles(filesetFilesSelector{
+		fileSetType:    persist.FileSetFlushType,
+		contentType:    persist.FileSetIndexContentType,
+		filePathPrefix: filePathPrefix,
+		namespace:      namespace,
+		pattern:        filesetFilePattern,
+	})
+}
+
 // SnapshotFiles returns a slice of all the names for all the fileset files
 // for a given namespace and shard combination.
 func SnapshotFiles(filePathPrefix string, namespace ident.ID, shard uint32) (FileSetFilesSlice, error) {
@@ -644,41 +714,51 @@ func IndexSnapshotFiles(filePathPrefix string, namespace ident.ID) (FileSetFiles
 	})
 }
 
-// FileSetAt returns a FileSetFile for the given namespace/shard/blockStart combination if it exists.
+// FileSetAt returns the latest complete volume FileSetFile for the given
+// namespace/shard/blockStart combination if it exists.
 func FileSetAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (FileSetFile, bool, error) {
+	matched, err := dataFileSetsAt(filePathPrefix, namespace, shard, blockStart)
+	if err != nil {
+		return FileSetFile{}, false, err
+	}
+
+	fileset, ok := matched.LatestVolumeForBlock(blockStart)
+	return fileset, ok, nil
+}
+
+// DataFileSetsAt returns all the complete volumes of data FileSetFile(s) for the
+// given namespace/shard/blockStart combination in volume index ascending order.
+func DataFileSetsAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (FileSetFilesSlice, error) {
+	matched, err := dataFileSetsAt(filePathPrefix, namespace, shard, blockStart)
+	if err != nil {
+		return nil, err
+	}
+
+	filesets := make(FileSetFilesSlice, 0, len(matched))
+	for _, fileset := range matched {
+		if !fileset.ID.BlockStart.Equal(blockStart) || !fileset.HasCheckpointFile() {
+			continue
+		}
+		filesets = append(filesets, fileset)
+	}
+	return filesets, nil
+}
+
+func dataFileSetsAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (FileSetFilesSlice, error) {
 	matched, err := filesetFiles(filesetFilesSelector{
 		fileSetType:    persist.FileSetFlushType,
 		contentType:    persist.FileSetDataContentType,
 		filePathPrefix: filePathPrefix,
 		namespace:      namespace,
 		shard:          shard,
-		pattern:        filesetFileForTime(blockStart, anyLowerCaseCharsPattern),
+		pattern:        filesetFileForTime(blockStart, anyLowerCaseCharsNumbersPattern),
 	})
 	if err != nil {
-		return FileSetFile{}, false, err
-	}
-
-	matched.sortByTimeAscending()
-	for i, fileset := range matched {
-		if fileset.ID.BlockStart.Equal(blockStart) {
-			nextIdx := i + 1
-			if nextIdx < len(matched) && matched[nextIdx].ID.BlockStart.Equal(blockStart) {
-				// Should never happen
-				return FileSetFile{}, false, fmt.Errorf(
-					"found multiple fileset files for blockStart: %d",
-					blockStart.Unix(),
-				)
-			}
-
-			if !fileset.HasCheckpointFile() {
-				continue
-			}
-
-			return fileset, true, nil
-		}
+		return nil, err
 	}
 
-	return FileSetFile{}, false, nil
+	matched.sortByTimeAndVolumeIndexAscending()
+	return matched, nil
 }
 
 // IndexFileSetsAt returns all FileSetFile(s) for the given namespace/blockStart combination.
@@ -837,7 +917,7 @@ func filesetFiles(args filesetFilesSelector) (FileSetFilesSlice, error) {
 		case persist.FileSetDataContentType:
 			dir := ShardDataDirPath(args.filePathPrefix, args.namespace, args.shard)
 			byTimeAsc, err = findFiles(dir, args.pattern, func(files []string) sort.Interface {
-				return byTimeAscending(files)
+				return dataFileSetFilesByTimeAndVolumeIndexAscending(files)
 			})
 		case persist.FileSetIndexContentType:
 			dir := NamespaceIndexDataDirPath(args.filePathPrefix, args.namespace)
@@ -887,7 +967,7 @@ func filesetFiles(args filesetFilesSelector) (FileSetFilesSlice, error) {
 		case persist.FileSetFlushType:
 			switch args.contentType {
 			case persist.FileSetDataContentType:
-				currentFileBlockStart, err = TimeFromFileName(file)
+				currentFileBlockStart, volumeIndex, err = TimeAndVolumeIndexFromDataFileSetFilename(file)
 			case persist.FileSetIndexContentType:
 				currentFileBlockStart, volumeIndex, err = TimeAndVolumeIndexFromFileSetFilename(file)
 			default:
@@ -1036,7 +1116,14 @@ func CommitLogsDirPath(prefix string) string {
 func DataFileSetExistsAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (bool, error) {
 	shardDir := ShardDataDirPath(filePathPrefix, namespace, shard)
 	checkpointPath := filesetPathFromTime(shardDir, blockStart, checkpointFileSuffix)
-	return CompleteCheckpointFileExists(checkpointPath)
+	exists, err := CompleteCheckpointFileExists(checkpointPath)
+	if err != nil || exists {
+		return exists, err
+	}
+
+	// Volume zero may have been superseded and cleaned up after a cold flush.
+	_, exists, err = FileSetAt(filePathPrefix, namespace, shard, blockStart)
+	return exists, err
 }
 
 // SnapshotFileSetExistsAt determines whether snapshot fileset files exist for the given namespace, shard, and block start time.
@@ -1086,6 +1173,22 @@ func NextSnapshotFileSetVolumeIndex(filePathPrefix string, namespace ident.ID, s
 	return latestFile.ID.VolumeIndex + 1, nil
 }
 
+// NextDataFileSetVolumeIndex returns the next data file set volume index for a given
+// namespace/shard/blockStart combination.
+func NextDataFileSetVolumeIndex(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (int, error) {
+	files, err := dataFileSetsAt(filePathPrefix, namespace, shard, blockStart)
+	if err != nil {
+		return -1, err
+	}
+
+	latestFile, ok := files.LatestVolumeForBlock(blockStart)
+	if !ok {
+		return 0, nil
+	}
+
+	return latestFile.ID.VolumeIndex + 1, nil
+}
+
 // NextIndexFileSetVolumeIndex returns the next index file set index for a given
 // namespace/blockStart combination.
 func NextIndexFileSetVolumeIndex(filePathPrefix string, namespace ident.ID, blockStart time.Time) (int, error) {
@@ -1191,6 +1294,16 @@ func filesetPathFromTimeAndIndex(prefix string, t time.Time, index int, suffix s
 	return path.Join(prefix, filesetFileForTime(t, fmt.Sprintf("%d%s%s", index, separator, suffix)))
 }
 
+// dataFilesetPathFromTimeAndIndex returns the path of a data fileset file, volume
+// zero omits the volume index from the file name to remain compatible with file
+// sets written before data filesets were volumed.
+func dataFilesetPathFromTimeAndIndex(prefix string, t time.Time, index int, suffix string) string {
+	if index == 0 {
+		return filesetPathFromTime(prefix, t, suffix)
+	}
+	return filesetPathFromTimeAndIndex(prefix, t, index, suffix)
+}
+
 func filesetIndexSegmentFileSuffixFromTime(
 	t time.Time,
 	segmentIndex int,
@@ -282,6 +282,25 @@ func TestTimeAndVolumeIndexFromFileSetFilename(t *testing.T) {
 	require.Equal(t, filesetPathFromTimeAndIndex("foo/bar", exp.t, exp.i, "data"), validName)
 }
 
+func TestTimeAndVolumeIndexFromDataFileSetFilename(t *testing.T) {
+	_, _, err := TimeAndVolumeIndexFromDataFileSetFilename("foo/bar")
+	require.Error(t, err)
+
+	legacyName := "foo/bar/fileset-21234567890-data.db"
+	ts, i, err := TimeAndVolumeIndexFromDataFileSetFilename(legacyName)
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(0, 21234567890), ts)
+	require.Equal(t, 0, i)
+	require.Equal(t, dataFilesetPathFromTimeAndIndex("foo/bar", ts, 0, "data"), legacyName)
+
+	volumeName := "foo/bar/fileset-21234567890-2-data.db"
+	ts, i, err = TimeAndVolumeIndexFromDataFileSetFilename(volumeName)
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(0, 21234567890), ts)
+	require.Equal(t, 2, i)
+	require.Equal(t, dataFilesetPathFromTimeAndIndex("foo/bar", ts, 2, "data"), volumeName)
+}
+
 func TestFileExists(t *testing.T) {
 
 	var (
@@ -491,6 +510,37 @@ func TestSnapshotFiles(t *testing.T) {
 	require.False(t, testWriterStart.IsZero())
 }
 
+func TestDataFilesAndDigests(t *testing.T) {
+	var (
+		shard          = uint32(0)
+		dir            = createTempDir(t)
+		filePathPrefix = filepath.Join(dir, "")
+		entries        = []testEntry{
+			{"foo", nil, []byte{1, 2, 3}},
+			{"bar", nil, []byte{4, 5, 6}},
+		}
+	)
+	defer os.RemoveAll(dir)
+
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, shard, testWriterStart, entries, persist.FileSetFlushType)
+
+	files, err := DataFiles(filePathPrefix, testNs1ID, shard)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(files))
+	require.True(t, files[0].HasCheckpointFile())
+
+	digests, err := DataFileSetDigests(filePathPrefix, persist.FileSetFlushType,
+		files[0].ID, testReaderBufferSize)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(digests))
+	for filePath, expected := range digests {
+		data, err := ioutil.ReadFile(filePath)
+		require.NoError(t, err)
+		require.Equal(t, expected, digest.Checksum(data), filePath)
+	}
+}
+
 func TestSnapshotFilesNoFiles(t *testing.T) {
 	// Make empty directory
 	shard := uint32(0)
@@ -555,6 +605,42 @@ func TestNextIndexFileSetVolumeIndex(t *testing.T) {
 	}
 }
 
+func TestNextDataFileSetVolumeIndex(t *testing.T) {
+	var (
+		shard      = uint32(0)
+		dir        = createTempDir(t)
+		shardDir   = ShardDataDirPath(dir, testNs1ID, shard)
+		blockStart = time.Now().Truncate(time.Hour)
+	)
+	require.NoError(t, os.MkdirAll(shardDir, 0755))
+	defer os.RemoveAll(dir)
+
+	// Check increments properly and that the latest volume is returned
+	curr := -1
+	for i := 0; i <= 10; i++ {
+		index, err := NextDataFileSetVolumeIndex(dir, testNs1ID, shard, blockStart)
+		require.NoError(t, err)
+		require.Equal(t, curr+1, index)
+		curr = index
+
+		p := dataFilesetPathFromTimeAndIndex(shardDir, blockStart, index, checkpointFileSuffix)
+		createFile(t, p, make([]byte, CheckpointFileSizeBytes))
+
+		fileset, ok, err := FileSetAt(dir, testNs1ID, shard, blockStart)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, index, fileset.ID.VolumeIndex)
+
+		exists, err := DataFileSetExistsAt(dir, testNs1ID, shard, blockStart)
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		filesets, err := DataFileSetsAt(dir, testNs1ID, shard, blockStart)
+		require.NoError(t, err)
+		require.Equal(t, index+1, len(filesets))
+	}
+}
+
 func TestMultipleForBlockStart(t *testing.T) {
 	numSnapshots := 20
 	numSnapshotsPerBlock := 4
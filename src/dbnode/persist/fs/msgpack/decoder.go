@@ -40,6 +40,7 @@ var (
 	emptyLogInfo                schema.LogInfo
 	emptyLogEntry               schema.LogEntry
 	emptyLogMetadata            schema.LogMetadata
+	emptyTombstone              schema.Tombstone
 	emptyLogEntryRemainingToken DecodeLogEntryRemainingToken
 )
 
@@ -157,6 +158,7 @@ func (dec *Decoder) DecodeLogEntry() (schema.LogEntry, error) {
 type DecodeLogEntryRemainingToken struct {
 	numFieldsToSkip1 int
 	numFieldsToSkip2 int
+	numFields        int
 }
 
 // DecodeLogEntryUniqueIndex decodes a log entry as much as is required to return
@@ -168,7 +170,7 @@ func (dec *Decoder) DecodeLogEntryUniqueIndex() (DecodeLogEntryRemainingToken, u
 	}
 
 	_, numFieldsToSkip1 := dec.decodeRootObject(logEntryVersion, logEntryType)
-	numFieldsToSkip2, _, ok := dec.checkNumFieldsFor(logEntryType, checkNumFieldsOptions{})
+	numFieldsToSkip2, numFields, ok := dec.checkNumFieldsFor(logEntryType, checkNumFieldsOptions{})
 	if !ok {
 		return emptyLogEntryRemainingToken, 0, errorUnableToDetermineNumFieldsToSkip
 	}
@@ -177,6 +179,7 @@ func (dec *Decoder) DecodeLogEntryUniqueIndex() (DecodeLogEntryRemainingToken, u
 	token := DecodeLogEntryRemainingToken{
 		numFieldsToSkip1: numFieldsToSkip1,
 		numFieldsToSkip2: numFieldsToSkip2,
+		numFields:        numFields,
 	}
 	return token, idx, nil
 }
@@ -196,6 +199,9 @@ func (dec *Decoder) DecodeLogEntryRemaining(token DecodeLogEntryRemainingToken,
 	logEntry.Value = dec.decodeFloat64()
 	logEntry.Unit = uint32(dec.decodeVarUint())
 	logEntry.Annotation, _, _ = dec.decodeBytes()
+	if token.numFields >= 8 {
+		logEntry.TombstoneEnd = dec.decodeVarint()
+	}
 
 	dec.skip(token.numFieldsToSkip1)
 	if dec.err != nil {
@@ -223,6 +229,20 @@ func (dec *Decoder) DecodeLogMetadata() (schema.LogMetadata, error) {
 	return logMetadata, nil
 }
 
+// DecodeTombstone decodes a tombstone
+func (dec *Decoder) DecodeTombstone() (schema.Tombstone, error) {
+	if dec.err != nil {
+		return emptyTombstone, dec.err
+	}
+	_, numFieldsToSkip := dec.decodeRootObject(tombstoneVersion, tombstoneType)
+	tombstone := dec.decodeTombstone()
+	dec.skip(numFieldsToSkip)
+	if dec.err != nil {
+		return emptyTombstone, dec.err
+	}
+	return tombstone, nil
+}
+
 func (dec *Decoder) decodeIndexInfo() schema.IndexInfo {
 	var opts checkNumFieldsOptions
 	if dec.legacy.decodeLegacyV1IndexInfo {
@@ -361,7 +381,7 @@ func (dec *Decoder) decodeLogInfo() schema.LogInfo {
 }
 
 func (dec *Decoder) decodeLogEntry() schema.LogEntry {
-	numFieldsToSkip, _, ok := dec.checkNumFieldsFor(logEntryType, checkNumFieldsOptions{})
+	numFieldsToSkip, actual, ok := dec.checkNumFieldsFor(logEntryType, checkNumFieldsOptions{})
 	if !ok {
 		return emptyLogEntry
 	}
@@ -373,6 +393,9 @@ func (dec *Decoder) decodeLogEntry() schema.LogEntry {
 	logEntry.Value = dec.decodeFloat64()
 	logEntry.Unit = uint32(dec.decodeVarUint())
 	logEntry.Annotation, _, _ = dec.decodeBytes()
+	if actual >= 8 {
+		logEntry.TombstoneEnd = dec.decodeVarint()
+	}
 	dec.skip(numFieldsToSkip)
 	if dec.err != nil {
 		return emptyLogEntry
@@ -397,6 +420,23 @@ func (dec *Decoder) decodeLogMetadata() schema.LogMetadata {
 	return logMetadata
 }
 
+func (dec *Decoder) decodeTombstone() schema.Tombstone {
+	numFieldsToSkip, _, ok := dec.checkNumFieldsFor(tombstoneType, checkNumFieldsOptions{})
+	if !ok {
+		return emptyTombstone
+	}
+	var tombstone schema.Tombstone
+	tombstone.ID, _, _ = dec.decodeBytes()
+	tombstone.Start = dec.decodeVarint()
+	tombstone.End = dec.decodeVarint()
+	tombstone.Applied = dec.decodeVarint() != 0
+	dec.skip(numFieldsToSkip)
+	if dec.err != nil {
+		return emptyTombstone
+	}
+	return tombstone
+}
+
 func (dec *Decoder) decodeRootObject(expectedVersion int, expectedType objectType) (version int, numFieldsToSkip int) {
 	version = dec.checkVersion(expectedVersion)
 	if dec.err != nil {
@@ -170,6 +170,16 @@ func (enc *Encoder) EncodeLogMetadata(entry schema.LogMetadata) error {
 	return enc.err
 }
 
+// EncodeTombstone encodes a tombstone
+func (enc *Encoder) EncodeTombstone(tombstone schema.Tombstone) error {
+	if enc.err != nil {
+		return enc.err
+	}
+	enc.encodeRootObject(tombstoneVersion, tombstoneType)
+	enc.encodeTombstone(tombstone)
+	return enc.err
+}
+
 // We only keep this method around for the sake of testing
 // backwards-compatbility
 func (enc *Encoder) encodeIndexInfoV1(info schema.IndexInfo) {
@@ -253,6 +263,7 @@ func (enc *Encoder) encodeLogEntry(entry schema.LogEntry) {
 	enc.encodeFloat64Fn(entry.Value)
 	enc.encodeVarUintFn(uint64(entry.Unit))
 	enc.encodeBytesFn(entry.Annotation)
+	enc.encodeVarintFn(entry.TombstoneEnd)
 }
 
 func (enc *Encoder) encodeLogMetadata(metadata schema.LogMetadata) {
@@ -263,6 +274,18 @@ func (enc *Encoder) encodeLogMetadata(metadata schema.LogMetadata) {
 	enc.encodeBytesFn(metadata.EncodedTags)
 }
 
+func (enc *Encoder) encodeTombstone(tombstone schema.Tombstone) {
+	enc.encodeNumObjectFieldsForFn(tombstoneType)
+	enc.encodeBytesFn(tombstone.ID)
+	enc.encodeVarintFn(tombstone.Start)
+	enc.encodeVarintFn(tombstone.End)
+	var applied int64
+	if tombstone.Applied {
+		applied = 1
+	}
+	enc.encodeVarintFn(applied)
+}
+
 func (enc *Encoder) encodeRootObject(version int, objType objectType) {
 	enc.encodeVersionFn(version)
 	enc.encodeNumObjectFieldsForFn(rootObjectType)
@@ -129,6 +129,7 @@ func testExpectedResultForLogEntry(t *testing.T, logEntry schema.LogEntry) []int
 		logEntry.Value,
 		uint64(logEntry.Unit),
 		logEntry.Annotation,
+		logEntry.TombstoneEnd,
 	}
 }
 
@@ -147,6 +148,25 @@ func testExpectedResultForLogMetadata(t *testing.T, logMetadata schema.LogMetada
 	}
 }
 
+func testExpectedResultForTombstone(t *testing.T, tombstone schema.Tombstone) []interface{} {
+	_, currRoot := numFieldsForType(rootObjectType)
+	_, currTombstone := numFieldsForType(tombstoneType)
+	var applied int64
+	if tombstone.Applied {
+		applied = 1
+	}
+	return []interface{}{
+		int64(tombstoneVersion),
+		currRoot,
+		int64(tombstoneType),
+		currTombstone,
+		tombstone.ID,
+		tombstone.Start,
+		tombstone.End,
+		applied,
+	}
+}
+
 func TestEncodeIndexInfo(t *testing.T) {
 	enc, actual := testCapturingEncoder(t)
 	require.NoError(t, enc.EncodeIndexInfo(testIndexInfo))
@@ -182,6 +202,13 @@ func TestEncodeLogMetadata(t *testing.T) {
 	require.Equal(t, expected, *actual)
 }
 
+func TestEncodeTombstone(t *testing.T) {
+	enc, actual := testCapturingEncoder(t)
+	require.NoError(t, enc.EncodeTombstone(testTombstone))
+	expected := testExpectedResultForTombstone(t, testTombstone)
+	require.Equal(t, expected, *actual)
+}
+
 func TestEncodeVarintError(t *testing.T) {
 	enc, _ := testCapturingEncoder(t)
 	enc.encodeVarintFn = func(value int64) { enc.err = errTestVarint }
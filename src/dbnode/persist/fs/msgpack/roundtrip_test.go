@@ -84,6 +84,13 @@ var (
 		Shard:       123,
 		EncodedTags: []byte("testLogMetadataTags"),
 	}
+
+	testTombstone = schema.Tombstone{
+		ID:      []byte("testTombstone"),
+		Start:   time.Now().Add(-time.Hour).UnixNano(),
+		End:     time.Now().UnixNano(),
+		Applied: true,
+	}
 )
 
 func TestIndexInfoRoundtrip(t *testing.T) {
@@ -258,6 +265,49 @@ func TestLogEntryRoundtrip(t *testing.T) {
 	require.Equal(t, testLogEntry, res)
 }
 
+func TestLogEntryRoundtripTombstone(t *testing.T) {
+	var (
+		enc = NewEncoder()
+		dec = NewDecoder(nil)
+	)
+	// Copy so we don't mutate global state
+	logEntry := testLogEntry
+	logEntry.TombstoneEnd = time.Now().Add(time.Hour).UnixNano()
+	require.NoError(t, enc.EncodeLogEntry(logEntry))
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	create, idx, err := dec.DecodeLogEntryUniqueIndex()
+	require.NoError(t, err)
+
+	res, err := dec.DecodeLogEntryRemaining(create, idx)
+	require.NoError(t, err)
+	require.Equal(t, logEntry, res)
+}
+
+func TestLogEntryRoundTripBackwardsCompatibilityV1(t *testing.T) {
+	var (
+		enc = NewEncoder()
+		dec = NewDecoder(nil)
+	)
+
+	// V1 log entries had 7 fields and did not encode the tombstone end
+	enc.encodeNumObjectFieldsForFn = testGenEncodeNumObjectFieldsForFn(enc, logEntryType, -1)
+	enc.encodeRootObject(logEntryVersion, logEntryType)
+	enc.encodeNumObjectFieldsForFn(logEntryType)
+	enc.encodeVarUintFn(testLogEntry.Index)
+	enc.encodeVarintFn(testLogEntry.Create)
+	enc.encodeBytesFn(testLogEntry.Metadata)
+	enc.encodeVarintFn(testLogEntry.Timestamp)
+	enc.encodeFloat64Fn(testLogEntry.Value)
+	enc.encodeVarUintFn(uint64(testLogEntry.Unit))
+	enc.encodeBytesFn(testLogEntry.Annotation)
+	require.NoError(t, enc.err)
+
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeLogEntry()
+	require.NoError(t, err)
+	require.Equal(t, testLogEntry, res)
+}
+
 func BenchmarkLogEntryDecoder(b *testing.B) {
 	// Copy so we don't mutate global state
 	logEntry := testLogEntry
@@ -311,6 +361,18 @@ func TestLogMetadataRoundtrip(t *testing.T) {
 	require.Equal(t, testLogMetadata, res)
 }
 
+func TestTombstoneRoundtrip(t *testing.T) {
+	var (
+		enc = NewEncoder()
+		dec = NewDecoder(nil)
+	)
+	require.NoError(t, enc.EncodeTombstone(testTombstone))
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeTombstone()
+	require.NoError(t, err)
+	require.Equal(t, testTombstone, res)
+}
+
 func TestMultiTypeRoundtripStress(t *testing.T) {
 	var (
 		enc    = NewEncoder()
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
@@ -58,6 +59,9 @@ const (
 
 	// defaultMmapHugePagesThreshold is the default threshold for when to enable huge pages if enabled
 	defaultMmapHugePagesThreshold = 2 << 14 // 32kb (or when eclipsing 8 pages of default 4096 page size)
+
+	// defaultColdStorageCacheTTL is the default time fileset files fetched back from cold storage are kept locally
+	defaultColdStorageCacheTTL = 6 * time.Hour
 )
 
 var (
@@ -67,6 +71,9 @@ var (
 
 	errTagEncoderPoolNotSet = errors.New("tag encoder pool is not set")
 	errTagDecoderPoolNotSet = errors.New("tag decoder pool is not set")
+
+	errColdStorageOffloadAgeNegative = errors.New("cold storage offload age is negative")
+	errColdStorageCacheTTLNegative   = errors.New("cold storage cache TTL is negative")
 )
 
 type options struct {
@@ -88,6 +95,9 @@ type options struct {
 	tagEncoderPool                       serialize.TagEncoderPool
 	tagDecoderPool                       serialize.TagDecoderPool
 	fstOptions                           fst.Options
+	coldStorage                          ColdStorage
+	coldStorageOffloadAge                time.Duration
+	coldStorageCacheTTL                  time.Duration
 }
 
 // NewOptions creates a new set of fs options
@@ -119,6 +129,7 @@ func NewOptions() Options {
 		tagEncoderPool:                       tagEncoderPool,
 		tagDecoderPool:                       tagDecoderPool,
 		fstOptions:                           fstOptions,
+		coldStorageCacheTTL:                  defaultColdStorageCacheTTL,
 	}
 }
 
@@ -139,6 +150,12 @@ func (o *options) Validate() error {
 	if o.tagDecoderPool == nil {
 		return errTagDecoderPoolNotSet
 	}
+	if o.coldStorageOffloadAge < 0 {
+		return errColdStorageOffloadAgeNegative
+	}
+	if o.coldStorageCacheTTL < 0 {
+		return errColdStorageCacheTTLNegative
+	}
 	return nil
 }
 
@@ -321,3 +338,33 @@ func (o *options) SetFSTOptions(value fst.Options) Options {
 func (o *options) FSTOptions() fst.Options {
 	return o.fstOptions
 }
+
+func (o *options) SetColdStorage(value ColdStorage) Options {
+	opts := *o
+	opts.coldStorage = value
+	return &opts
+}
+
+func (o *options) ColdStorage() ColdStorage {
+	return o.coldStorage
+}
+
+func (o *options) SetColdStorageOffloadAge(value time.Duration) Options {
+	opts := *o
+	opts.coldStorageOffloadAge = value
+	return &opts
+}
+
+func (o *options) ColdStorageOffloadAge() time.Duration {
+	return o.coldStorageOffloadAge
+}
+
+func (o *options) SetColdStorageCacheTTL(value time.Duration) Options {
+	opts := *o
+	opts.coldStorageCacheTTL = value
+	return &opts
+}
+
+func (o *options) ColdStorageCacheTTL() time.Duration {
+	return o.coldStorageCacheTTL
+}
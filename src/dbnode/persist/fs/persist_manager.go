@@ -386,13 +386,23 @@ func (pm *persistManager) PrepareData(opts persist.DataPrepareOptions) (persist.
 	}
 
 	var volumeIndex int
-	if opts.FileSetType == persist.FileSetSnapshotType {
+	switch {
+	case opts.FileSetType == persist.FileSetSnapshotType:
 		// Need to work out the volume index for the next snapshot
 		volumeIndex, err = NextSnapshotFileSetVolumeIndex(pm.opts.FilePathPrefix(),
 			nsMetadata.ID(), shard, blockStart)
 		if err != nil {
 			return prepared, err
 		}
+	case opts.FileSetType == persist.FileSetFlushType && opts.NewVolume:
+		// Writing a new volume supersedes rather than collides with the
+		// existing volumes for the block.
+		volumeIndex, err = NextDataFileSetVolumeIndex(pm.opts.FilePathPrefix(),
+			nsMetadata.ID(), shard, blockStart)
+		if err != nil {
+			return prepared, err
+		}
+		exists = false
 	}
 
 	if exists && !opts.DeleteIfExists {
@@ -21,7 +21,12 @@
 package fs
 
 import (
+	"fmt"
+	"os"
+	"time"
+
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
 )
 
 // filesetDigests is a container struct for storing a digest for all of the
@@ -61,3 +66,69 @@ func readFileSetDigests(
 
 	return fsDigests, nil
 }
+
+// DataFileSetDigests returns the digests of the info, index, summaries, bloom
+// filter and data files of a complete data fileset volume keyed by the absolute
+// path of each file. The digests are read from the digest file of the volume
+// which is validated against the digest held by its checkpoint file.
+func DataFileSetDigests(
+	filePathPrefix string,
+	fileSetType persist.FileSetType,
+	id FileSetFileIdentifier,
+	readerBufferSize int,
+) (map[string]uint32, error) {
+	var (
+		shardDir string
+		pathFn   func(prefix string, t time.Time, index int, suffix string) string
+	)
+	switch fileSetType {
+	case persist.FileSetFlushType:
+		shardDir = ShardDataDirPath(filePathPrefix, id.Namespace, id.Shard)
+		pathFn = dataFilesetPathFromTimeAndIndex
+	case persist.FileSetSnapshotType:
+		shardDir = ShardSnapshotsDirPath(filePathPrefix, id.Namespace, id.Shard)
+		pathFn = filesetPathFromTimeAndIndex
+	default:
+		return nil, fmt.Errorf("unable to read digests with fileset type: %s", fileSetType)
+	}
+	filePath := func(suffix string) string {
+		return pathFn(shardDir, id.BlockStart, id.VolumeIndex, suffix)
+	}
+
+	checkpointFd, err := os.Open(filePath(checkpointFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	expectedDigestOfDigest, err := digest.NewBuffer().ReadDigestFromFile(checkpointFd)
+	closeErr := checkpointFd.Close()
+	if err != nil {
+		return nil, err
+	}
+	if closeErr != nil {
+		return nil, closeErr
+	}
+
+	digestFd, err := os.Open(filePath(digestFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	defer digestFd.Close()
+
+	digestFdWithDigestContents := digest.NewFdWithDigestContentsReader(readerBufferSize)
+	digestFdWithDigestContents.Reset(digestFd)
+	fsDigests, err := readFileSetDigests(digestFdWithDigestContents)
+	if err != nil {
+		return nil, err
+	}
+	if err := digestFdWithDigestContents.Validate(expectedDigestOfDigest); err != nil {
+		return nil, err
+	}
+
+	return map[string]uint32{
+		filePath(infoFileSuffix):        fsDigests.infoDigest,
+		filePath(indexFileSuffix):       fsDigests.indexDigest,
+		filePath(summariesFileSuffix):   fsDigests.summariesDigest,
+		filePath(bloomFilterFileSuffix): fsDigests.bloomFilterDigest,
+		filePath(dataFileSuffix):        fsDigests.dataDigest,
+	}, nil
+}
@@ -156,7 +156,7 @@ func (s *seeker) ConcurrentIDBloomFilter() *ManagedConcurrentBloomFilter {
 	return s.bloomFilter
 }
 
-func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time) error {
+func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time, volume int) error {
 	if s.isClone {
 		return errClonesShouldNotBeOpened
 	}
@@ -164,14 +164,23 @@ func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time) er
 	shardDir := ShardDataDirPath(s.filePathPrefix, namespace, shard)
 	var infoFd, indexFd, dataFd, digestFd, bloomFilterFd, summariesFd *os.File
 
+	// Fetch files of offloaded volumes back from cold storage, they are
+	// validated against the digests below like any other files
+	if err := fetchFromColdStorage(s.opts.opts, s.filePathPrefix,
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volume, indexFileSuffix),
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volume, dataFileSuffix),
+	); err != nil {
+		return err
+	}
+
 	// Open necessary files
 	if err := openFiles(os.Open, map[string]**os.File{
-		filesetPathFromTime(shardDir, blockStart, infoFileSuffix):        &infoFd,
-		filesetPathFromTime(shardDir, blockStart, indexFileSuffix):       &indexFd,
-		filesetPathFromTime(shardDir, blockStart, dataFileSuffix):        &dataFd,
-		filesetPathFromTime(shardDir, blockStart, digestFileSuffix):      &digestFd,
-		filesetPathFromTime(shardDir, blockStart, bloomFilterFileSuffix): &bloomFilterFd,
-		filesetPathFromTime(shardDir, blockStart, summariesFileSuffix):   &summariesFd,
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volume, infoFileSuffix):        &infoFd,
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volume, indexFileSuffix):       &indexFd,
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volume, dataFileSuffix):        &dataFd,
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volume, digestFileSuffix):      &digestFd,
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volume, bloomFilterFileSuffix): &bloomFilterFd,
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volume, summariesFileSuffix):   &summariesFd,
 	}); err != nil {
 		return err
 	}
@@ -208,12 +217,12 @@ func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time) er
 		},
 	}
 	mmapResult, err := mmap.Files(os.Open, map[string]mmap.FileDesc{
-		filesetPathFromTime(shardDir, blockStart, indexFileSuffix): mmap.FileDesc{
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volume, indexFileSuffix): mmap.FileDesc{
 			File:    &indexFd,
 			Bytes:   &s.indexMmap,
 			Options: mmapOptions,
 		},
-		filesetPathFromTime(shardDir, blockStart, dataFileSuffix): mmap.FileDesc{
+		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volume, dataFileSuffix): mmap.FileDesc{
 			File:    &dataFd,
 			Bytes:   &s.dataMmap,
 			Options: mmapOptions,
@@ -250,7 +259,7 @@ func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time) er
 		s.Close()
 		return fmt.Errorf(
 			"index file digest for file: %s does not match the expected digest",
-			filesetPathFromTime(shardDir, blockStart, indexFileSuffix),
+			dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volume, indexFileSuffix),
 		)
 	}
 
@@ -107,6 +107,9 @@ type seekersByTime struct {
 	shard    uint32
 	accessed bool
 	seekers  map[xtime.UnixNano]seekersAndBloom
+	// superseded holds seekers for volumes that have been replaced by a newer
+	// volume, they are closed by the openCloseLoop once they are all returned.
+	superseded []seekersAndBloom
 }
 
 type seekerManagerPendingClose struct {
@@ -234,6 +237,17 @@ func (m *seekerManager) Return(shard uint32, start time.Time, seeker ConcurrentD
 
 	startNano := xtime.ToUnixNano(start)
 	seekersAndBloom, ok := byTime.seekers[startNano]
+	if ok && returnSeeker(seekersAndBloom, seeker) {
+		return nil
+	}
+
+	// The seeker may have been borrowed before its volume was superseded.
+	for _, superseded := range byTime.superseded {
+		if returnSeeker(superseded, seeker) {
+			return nil
+		}
+	}
+
 	// Should never happen - This either means that the caller (DataBlockRetriever) is trying to return seekers
 	// that it never requested, OR its trying to return seekers after the openCloseLoop has already
 	// determined that they were all no longer in use and safe to close. Either way it indicates there is
@@ -242,22 +256,57 @@ func (m *seekerManager) Return(shard uint32, start time.Time, seeker ConcurrentD
 		return errSeekersDontExist
 	}
 
-	found := false
+	// Should never happen with a well behaved caller. Either they are trying to return a seeker
+	// that we're not managing, or they provided the wrong shard/start.
+	return errReturnedUnmanagedSeeker
+}
+
+func returnSeeker(seekersAndBloom seekersAndBloom, seeker ConcurrentDataFileSetSeeker) bool {
 	for i, compareSeeker := range seekersAndBloom.seekers {
 		if seeker == compareSeeker.seeker {
-			found = true
 			compareSeeker.isBorrowed = false
 			seekersAndBloom.seekers[i] = compareSeeker
-			break
+			return true
 		}
 	}
-	// Should never happen with a well behaved caller. Either they are trying to return a seeker
-	// that we're not managing, or they provided the wrong shard/start.
-	if !found {
-		return errReturnedUnmanagedSeeker
+	return false
+}
+
+func allSeekersReturned(seekersAndBloom seekersAndBloom) bool {
+	for _, seeker := range seekersAndBloom.seekers {
+		if seeker.isBorrowed {
+			return false
+		}
 	}
+	return true
+}
 
-	return nil
+func (m *seekerManager) InvalidateSeekers(shard uint32, start time.Time) error {
+	byTime := m.seekersByTime(shard)
+
+	byTime.Lock()
+	defer byTime.Unlock()
+
+	startNano := xtime.ToUnixNano(start)
+	for {
+		seekers, ok := byTime.seekers[startNano]
+		if !ok {
+			// Nothing open, the next borrow will open the latest volume.
+			return nil
+		}
+		if seekers.wg != nil {
+			// Seekers are being opened and may have found the previous volume,
+			// wait for them to finish opening and then supersede them.
+			byTime.Unlock()
+			seekers.wg.Wait()
+			byTime.Lock()
+			continue
+		}
+
+		delete(byTime.seekers, startNano)
+		byTime.superseded = append(byTime.superseded, seekers)
+		return nil
+	}
 }
 
 // getOrOpenSeekersWithLock checks if the seekers are already open / initialized. If they are, then it
@@ -360,7 +409,7 @@ func (m *seekerManager) newOpenSeeker(
 	shard uint32,
 	blockStart time.Time,
 ) (DataFileSetSeeker, error) {
-	exists, err := DataFileSetExistsAt(m.filePathPrefix, m.namespace, shard, blockStart)
+	fileSet, exists, err := FileSetAt(m.filePathPrefix, m.namespace, shard, blockStart)
 	if err != nil {
 		return nil, err
 	}
@@ -389,7 +438,7 @@ func (m *seekerManager) newOpenSeeker(
 	// Set the unread buffer to reuse it amongst all seekers.
 	seeker.setUnreadBuffer(m.unreadBuf.value)
 
-	if err := seeker.Open(m.namespace, shard, blockStart); err != nil {
+	if err := seeker.Open(m.namespace, shard, blockStart, fileSet.ID.VolumeIndex); err != nil {
 		return nil, err
 	}
 
@@ -451,12 +500,17 @@ func (m *seekerManager) Close() error {
 	for _, byTime := range m.seekersByShardIdx {
 		byTime.Lock()
 		for _, seekersByTime := range byTime.seekers {
-			for _, seeker := range seekersByTime.seekers {
-				if seeker.isBorrowed {
-					byTime.Unlock()
-					m.Unlock()
-					return errCantCloseSeekerManagerWhileSeekersAreBorrowed
-				}
+			if !allSeekersReturned(seekersByTime) {
+				byTime.Unlock()
+				m.Unlock()
+				return errCantCloseSeekerManagerWhileSeekersAreBorrowed
+			}
+		}
+		for _, seekersByTime := range byTime.superseded {
+			if !allSeekersReturned(seekersByTime) {
+				byTime.Unlock()
+				m.Unlock()
+				return errCantCloseSeekerManagerWhileSeekersAreBorrowed
 			}
 		}
 		byTime.Unlock()
@@ -533,6 +587,27 @@ func (m *seekerManager) openCloseLoop() {
 			m.openAnyUnopenSeekersFn(byTime)
 		}
 
+		m.RLock()
+		for _, byTime := range m.seekersByShardIdx {
+			byTime.Lock()
+			remaining := byTime.superseded[:0]
+			for _, seekersAndBloom := range byTime.superseded {
+				// Same as below, clones can't outlive the original so wait
+				// until all of them have been returned.
+				if allSeekersReturned(seekersAndBloom) {
+					closing = append(closing, seekersAndBloom.seekers...)
+					continue
+				}
+				remaining = append(remaining, seekersAndBloom)
+			}
+			for i := len(remaining); i < len(byTime.superseded); i++ {
+				byTime.superseded[i] = seekersAndBloom{}
+			}
+			byTime.superseded = remaining
+			byTime.Unlock()
+		}
+		m.RUnlock()
+
 		m.RLock()
 		for shard, byTime := range m.seekersByShardIdx {
 			byTime.RLock()
@@ -604,7 +679,18 @@ func (m *seekerManager) openCloseLoop() {
 				}
 			}
 		}
+		for _, seekersByTime := range byTime.superseded {
+			for _, seeker := range seekersByTime.seekers {
+				err := seeker.seeker.Close()
+				if err != nil {
+					m.logger.
+						WithFields(log.NewField("err", err.Error())).
+						Error("err closing superseded seeker in SeekerManager at end of openCloseLoop")
+				}
+			}
+		}
 		byTime.seekers = nil
+		byTime.superseded = nil
 		byTime.Unlock()
 	}
 	m.seekersByShardIdx = nil
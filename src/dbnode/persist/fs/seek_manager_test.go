@@ -81,7 +81,7 @@ func TestSeekerManagerBorrowOpenSeekersLazy(t *testing.T) {
 		blockStart time.Time,
 	) (DataFileSetSeeker, error) {
 		mock := NewMockDataFileSetSeeker(ctrl)
-		mock.EXPECT().Open(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mock.EXPECT().Open(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		mock.EXPECT().ConcurrentClone().Return(mock, nil)
 		for i := 0; i < NewBlockRetrieverOptions().FetchConcurrency(); i++ {
 			mock.EXPECT().Close().Return(nil)
@@ -109,6 +109,66 @@ func TestSeekerManagerBorrowOpenSeekersLazy(t *testing.T) {
 	require.NoError(t, m.Close())
 }
 
+// TestSeekerManagerInvalidateSeekers tests that seekers borrowed before their
+// volume is superseded can still be returned and that the next borrow opens
+// new seekers.
+func TestSeekerManagerInvalidateSeekers(t *testing.T) {
+	defer leaktest.CheckTimeout(t, 1*time.Minute)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		shard       = uint32(2)
+		concurrency = NewBlockRetrieverOptions().FetchConcurrency()
+		opened      int
+	)
+	m := NewSeekerManager(nil, testDefaultOpts, concurrency).(*seekerManager)
+	m.newOpenSeekerFn = func(
+		shard uint32,
+		blockStart time.Time,
+	) (DataFileSetSeeker, error) {
+		opened++
+		mock := NewMockDataFileSetSeeker(ctrl)
+		mock.EXPECT().ConcurrentClone().Return(mock, nil).Times(concurrency - 1)
+		mock.EXPECT().ConcurrentIDBloomFilter().Return(nil)
+		mock.EXPECT().Close().Return(nil).Times(concurrency)
+		return mock, nil
+	}
+	// Only open seekers on borrow so the open close loop doesn't race with the test.
+	m.openAnyUnopenSeekersFn = func(byTime *seekersByTime) error {
+		return nil
+	}
+	m.sleepFn = func(_ time.Duration) {
+		time.Sleep(time.Millisecond)
+	}
+
+	metadata := testNs1Metadata(t)
+	require.NoError(t, m.Open(metadata))
+
+	seeker, err := m.Borrow(shard, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, 1, opened)
+
+	require.NoError(t, m.InvalidateSeekers(shard, time.Time{}))
+
+	byTime := m.seekersByTime(shard)
+	byTime.RLock()
+	_, ok := byTime.seekers[xtime.ToUnixNano(time.Time{})]
+	byTime.RUnlock()
+	require.False(t, ok)
+
+	// Seekers borrowed before the invalidation can still be returned.
+	require.NoError(t, m.Return(shard, time.Time{}, seeker))
+
+	seeker, err = m.Borrow(shard, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, 2, opened)
+	require.NoError(t, m.Return(shard, time.Time{}, seeker))
+
+	require.NoError(t, m.Close())
+}
+
 // TestSeekerManagerOpenCloseLoop tests the openCloseLoop of the SeekerManager
 // by making sure that it makes the right decisions with regards to cleaning
 // up resources based on their state.
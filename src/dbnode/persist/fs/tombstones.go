@@ -0,0 +1,175 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bufio"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
+	"github.com/m3db/m3/src/dbnode/persist/schema"
+	"github.com/m3db/m3x/ident"
+)
+
+const (
+	tombstonesFileName       = "tombstones.db"
+	tombstonesTempFileSuffix = ".tmp"
+)
+
+var (
+	errTombstonesFileTooShort       = errors.New("tombstones file is too short to contain a digest")
+	errTombstonesFileDigestMismatch = errors.New("tombstones file digest does not match its contents")
+)
+
+// TombstonesFilePath returns the path of the tombstones file of a shard.
+func TombstonesFilePath(prefix string, namespace ident.ID, shard uint32) string {
+	return path.Join(ShardDataDirPath(prefix, namespace, shard), tombstonesFileName)
+}
+
+// WriteTombstones replaces the tombstones file of a shard with the given
+// tombstones. The file is written to a temporary path and renamed into place
+// so that readers never observe a partially written file.
+func WriteTombstones(
+	filePathPrefix string,
+	namespace ident.ID,
+	shard uint32,
+	tombstones []persist.Tombstone,
+	newFileMode os.FileMode,
+	newDirectoryMode os.FileMode,
+) error {
+	shardDir := ShardDataDirPath(filePathPrefix, namespace, shard)
+	if err := os.MkdirAll(shardDir, newDirectoryMode); err != nil {
+		return err
+	}
+
+	encoder := msgpack.NewEncoder()
+	for _, tombstone := range tombstones {
+		if err := encoder.EncodeTombstone(schema.Tombstone{
+			ID:      tombstone.ID.Bytes(),
+			Start:   tombstoneTimeToNanos(tombstone.Start),
+			End:     tombstoneTimeToNanos(tombstone.End),
+			Applied: tombstone.Applied,
+		}); err != nil {
+			return err
+		}
+	}
+	data := encoder.Bytes()
+
+	filePath := TombstonesFilePath(filePathPrefix, namespace, shard)
+	tempFilePath := filePath + tombstonesTempFileSuffix
+	fd, err := OpenWritable(tempFilePath, newFileMode)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(fd)
+	digestBuffer := digest.NewBuffer()
+	digestBuffer.WriteDigest(digest.Checksum(data))
+	if _, err := writer.Write(digestBuffer); err != nil {
+		fd.Close()
+		return err
+	}
+	if _, err := writer.Write(data); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Sync(); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFilePath, filePath)
+}
+
+// ReadTombstones reads the tombstones file of a shard, no tombstones are
+// returned if the shard does not have a tombstones file.
+func ReadTombstones(
+	filePathPrefix string,
+	namespace ident.ID,
+	shard uint32,
+	decodingOpts msgpack.DecodingOptions,
+) ([]persist.Tombstone, error) {
+	filePath := TombstonesFilePath(filePathPrefix, namespace, shard)
+	buf, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) < digest.DigestLenBytes {
+		return nil, errTombstonesFileTooShort
+	}
+
+	expectedDigest := digest.ToBuffer(buf).ReadDigest()
+	data := buf[digest.DigestLenBytes:]
+	if digest.Checksum(data) != expectedDigest {
+		return nil, errTombstonesFileDigestMismatch
+	}
+
+	var (
+		stream     = msgpack.NewDecoderStream(data)
+		decoder    = msgpack.NewDecoder(decodingOpts)
+		tombstones []persist.Tombstone
+	)
+	decoder.Reset(stream)
+	for stream.Remaining() > 0 {
+		tombstone, err := decoder.DecodeTombstone()
+		if err != nil {
+			return nil, err
+		}
+		tombstones = append(tombstones, persist.Tombstone{
+			ID:      ident.BytesID(append([]byte(nil), tombstone.ID...)),
+			Start:   tombstoneTimeFromNanos(tombstone.Start),
+			End:     tombstoneTimeFromNanos(tombstone.End),
+			Applied: tombstone.Applied,
+		})
+	}
+	return tombstones, nil
+}
+
+// NB: An unbounded tombstone start is the zero time which has no
+// representation in nanoseconds, it is persisted as zero instead.
+func tombstoneTimeToNanos(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func tombstoneTimeFromNanos(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTombstonesNoFile(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	tombstones, err := ReadTombstones(dir, testNs1ID, 0, nil)
+	require.NoError(t, err)
+	require.Empty(t, tombstones)
+}
+
+func TestWriteReadTombstones(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	now := time.Unix(0, time.Now().UnixNano())
+	expected := []persist.Tombstone{
+		{ID: ident.StringID("foo"), End: now},
+		{
+			ID:      ident.StringID("bar"),
+			Start:   now.Add(-2 * time.Hour),
+			End:     now.Add(-time.Hour),
+			Applied: true,
+		},
+	}
+	require.NoError(t, WriteTombstones(dir, testNs1ID, 3, expected,
+		defaultNewFileMode, defaultNewDirectoryMode))
+
+	tombstones, err := ReadTombstones(dir, testNs1ID, 3, nil)
+	require.NoError(t, err)
+	require.Equal(t, len(expected), len(tombstones))
+	for i := range expected {
+		require.True(t, expected[i].ID.Equal(tombstones[i].ID))
+		require.True(t, expected[i].Start.Equal(tombstones[i].Start))
+		require.True(t, expected[i].End.Equal(tombstones[i].End))
+		require.Equal(t, expected[i].Applied, tombstones[i].Applied)
+	}
+	require.True(t, tombstones[0].Start.IsZero())
+
+	// Overwriting drops the previous tombstones
+	require.NoError(t, WriteTombstones(dir, testNs1ID, 3, expected[1:],
+		defaultNewFileMode, defaultNewDirectoryMode))
+	tombstones, err = ReadTombstones(dir, testNs1ID, 3, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(tombstones))
+	require.Equal(t, "bar", tombstones[0].ID.String())
+}
+
+func TestReadTombstonesDigestMismatch(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	tombstones := []persist.Tombstone{{ID: ident.StringID("foo"), End: time.Now()}}
+	require.NoError(t, WriteTombstones(dir, testNs1ID, 0, tombstones,
+		defaultNewFileMode, defaultNewDirectoryMode))
+
+	filePath := TombstonesFilePath(dir, testNs1ID, 0)
+	data, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	data[len(data)-1]++
+	require.NoError(t, ioutil.WriteFile(filePath, data, defaultNewFileMode))
+
+	_, err = ReadTombstones(dir, testNs1ID, 0, nil)
+	require.Equal(t, errTombstonesFileDigestMismatch, err)
+}
@@ -155,8 +155,8 @@ type DataFileSetReader interface {
 type DataFileSetSeeker interface {
 	io.Closer
 
-	// Open opens the files for the given shard and version for reading
-	Open(namespace ident.ID, shard uint32, start time.Time) error
+	// Open opens the files for the given shard, block start and volume for reading
+	Open(namespace ident.ID, shard uint32, start time.Time, volume int) error
 
 	// SeekByID returns the data for specified ID provided the index was loaded upon open. An
 	// error will be returned if the index was not loaded or ID cannot be found.
@@ -231,6 +231,11 @@ type DataFileSetSeekerManager interface {
 	// ConcurrentIDBloomFilter returns a concurrent ID bloom filter for a given
 	// shard and block start time
 	ConcurrentIDBloomFilter(shard uint32, start time.Time) (*ManagedConcurrentBloomFilter, error)
+
+	// InvalidateSeekers supersedes the open seekers for a given shard and block
+	// start time, the seekers are closed once returned and the next borrow opens
+	// the latest volume for the block start time.
+	InvalidateSeekers(shard uint32, start time.Time) error
 }
 
 // DataBlockRetriever provides a block retriever for TSDB file sets
@@ -426,6 +431,48 @@ type Options interface {
 
 	// FSTOptions returns the fst options
 	FSTOptions() fst.Options
+
+	// SetColdStorage sets the cold storage backend that old fileset volumes
+	// are offloaded to, nil disables offloading
+	SetColdStorage(value ColdStorage) Options
+
+	// ColdStorage returns the cold storage backend that old fileset volumes
+	// are offloaded to
+	ColdStorage() ColdStorage
+
+	// SetColdStorageOffloadAge sets the age of a block after which its fileset
+	// volumes are offloaded to cold storage, zero disables offloading
+	SetColdStorageOffloadAge(value time.Duration) Options
+
+	// ColdStorageOffloadAge returns the age of a block after which its fileset
+	// volumes are offloaded to cold storage
+	ColdStorageOffloadAge() time.Duration
+
+	// SetColdStorageCacheTTL sets how long fileset files fetched back from cold
+	// storage are kept on local disk before being evicted
+	SetColdStorageCacheTTL(value time.Duration) Options
+
+	// ColdStorageCacheTTL returns how long fileset files fetched back from cold
+	// storage are kept on local disk before being evicted
+	ColdStorageCacheTTL() time.Duration
+}
+
+// ColdStorage is a backend that fileset files too old to be worth keeping on
+// local disk are offloaded to. Paths are slash separated and relative to the
+// file path prefix so that a backend can be shared by nodes with different
+// prefixes.
+type ColdStorage interface {
+	// Put stores the contents of the reader at the path, replacing any
+	// existing contents.
+	Put(path string, r io.Reader) error
+
+	// Get returns a reader for the contents stored at the path, the error
+	// satisfies os.IsNotExist if nothing is stored at the path.
+	Get(path string) (io.ReadCloser, error)
+
+	// Delete removes the contents stored at the path, deleting a path that
+	// does not exist is not an error.
+	Delete(path string) error
 }
 
 // BlockRetrieverOptions represents the options for block retrieval
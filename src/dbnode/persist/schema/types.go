@@ -78,13 +78,14 @@ type LogInfo struct {
 
 // LogEntry stores per-entry data in a commit log
 type LogEntry struct {
-	Index      uint64
-	Create     int64
-	Metadata   []byte
-	Timestamp  int64
-	Value      float64
-	Unit       uint32
-	Annotation []byte
+	Index        uint64
+	Create       int64
+	Metadata     []byte
+	Timestamp    int64
+	Value        float64
+	Unit         uint32
+	Annotation   []byte
+	TombstoneEnd int64
 }
 
 // LogMetadata stores metadata information about a commit log
@@ -94,3 +95,11 @@ type LogMetadata struct {
 	Shard       uint32
 	EncodedTags []byte
 }
+
+// Tombstone stores a deleted time range of a series in a tombstone file
+type Tombstone struct {
+	ID      []byte
+	Start   int64
+	End     int64
+	Applied bool
+}
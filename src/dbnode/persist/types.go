@@ -98,6 +98,10 @@ type DataPrepareOptions struct {
 	Shard             uint32
 	FileSetType       FileSetType
 	DeleteIfExists    bool
+	// NewVolume is applicable to flushes (data yes) and writes the fileset as
+	// the next volume for the block start rather than the first, such as when
+	// merging cold writes into a block that has already been flushed.
+	NewVolume bool
 	// Snapshot options are applicable to snapshots (index yes, data yes)
 	Snapshot DataPrepareSnapshotOptions
 }
@@ -164,3 +168,24 @@ const (
 	// FileSetIndexContentType indicates that the fileset files contain time series index metadata
 	FileSetIndexContentType
 )
+
+// Tombstone marks the datapoints of a series within [Start, End) as deleted,
+// a zero Start deletes every datapoint of the series written before End.
+type Tombstone struct {
+	ID    ident.ID
+	Start time.Time
+	End   time.Time
+	// Applied is set once the filesets flushed before the tombstone was
+	// recorded have been rewritten without the deleted datapoints.
+	Applied bool
+}
+
+// Covers returns whether the tombstone deletes the datapoint at the given time.
+func (t Tombstone) Covers(at time.Time) bool {
+	return !at.Before(t.Start) && at.Before(t.End)
+}
+
+// Overlaps returns whether the tombstone deletes any datapoints within [start, end).
+func (t Tombstone) Overlaps(start, end time.Time) bool {
+	return t.Start.Before(end) && start.Before(t.End)
+}
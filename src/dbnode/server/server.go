@@ -42,6 +42,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/encoding/valuetype"
 	"github.com/m3db/m3/src/dbnode/environment"
 	"github.com/m3db/m3/src/dbnode/kvconfig"
 	hjcluster "github.com/m3db/m3/src/dbnode/network/server/httpjson/cluster"
@@ -309,6 +310,16 @@ func Run(runOpts RunOptions) {
 		SetRuntimeOptionsManager(runtimeOptsMgr).
 		SetTagEncoderPool(tagEncoderPool).
 		SetTagDecoderPool(tagDecoderPool)
+	if coldStorageCfg := cfg.Filesystem.ColdStorage; coldStorageCfg != nil {
+		coldStorage := fs.NewLocalColdStorage(coldStorageCfg.Directory,
+			newFileMode, newDirectoryMode)
+		fsopts = fsopts.
+			SetColdStorage(coldStorage).
+			SetColdStorageOffloadAge(coldStorageCfg.OffloadAge)
+		if coldStorageCfg.CacheTTL > 0 {
+			fsopts = fsopts.SetColdStorageCacheTTL(coldStorageCfg.CacheTTL)
+		}
+	}
 
 	var commitLogQueueSize int
 	specified := cfg.CommitLog.Queue.Size
@@ -442,16 +453,33 @@ func Run(runOpts RunOptions) {
 			scope.SubScope("host-block-metadata-slice-pool")),
 		policy.HostBlockMetadataSlicePool.Capacity)
 
+	repairOpts := opts.RepairOptions().
+		SetAdminClient(m3dbClient).
+		SetRepairInterval(cfg.Repair.Interval).
+		SetRepairTimeOffset(cfg.Repair.Offset).
+		SetRepairTimeJitter(cfg.Repair.Jitter).
+		SetRepairThrottle(cfg.Repair.Throttle).
+		SetRepairCheckInterval(cfg.Repair.CheckInterval).
+		SetHostBlockMetadataSlicePool(hostBlockMetadataSlicePool)
+	if antiEntropyCfg := cfg.Repair.AntiEntropy; antiEntropyCfg != nil {
+		repairOpts = repairOpts.
+			SetAntiEntropyEnabled(antiEntropyCfg.Enabled).
+			SetAntiEntropyRateLimitOptions(ratelimit.NewOptions().
+				SetLimitEnabled(antiEntropyCfg.ThroughputLimitMbps > 0).
+				SetLimitMbps(antiEntropyCfg.ThroughputLimitMbps).
+				SetLimitCheckEvery(antiEntropyCfg.ThroughputCheckEvery))
+		if antiEntropyCfg.MerkleTreeDepth > 0 {
+			repairOpts = repairOpts.SetMerkleTreeDepth(antiEntropyCfg.MerkleTreeDepth)
+		}
+		if antiEntropyCfg.MerkleTreeRefreshInterval > 0 {
+			repairOpts = repairOpts.SetMerkleTreeRefreshInterval(
+				antiEntropyCfg.MerkleTreeRefreshInterval)
+		}
+	}
+
 	opts = opts.
 		SetRepairEnabled(cfg.Repair.Enabled).
-		SetRepairOptions(opts.RepairOptions().
-			SetAdminClient(m3dbClient).
-			SetRepairInterval(cfg.Repair.Interval).
-			SetRepairTimeOffset(cfg.Repair.Offset).
-			SetRepairTimeJitter(cfg.Repair.Jitter).
-			SetRepairThrottle(cfg.Repair.Throttle).
-			SetRepairCheckInterval(cfg.Repair.CheckInterval).
-			SetHostBlockMetadataSlicePool(hostBlockMetadataSlicePool))
+		SetRepairOptions(repairOpts)
 
 	// Set tchannelthrift options
 	ttopts := tchannelthrift.NewOptions().
@@ -967,7 +995,7 @@ func withEncodingAndPoolingOptions(
 	})
 
 	iteratorPool.Init(func(r io.Reader) encoding.ReaderIterator {
-		return m3tsz.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
+		return valuetype.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
 	})
 
 	multiIteratorPool.Init(func(r io.Reader) encoding.ReaderIterator {
@@ -272,6 +272,10 @@ type DatabaseBlockRetriever interface {
 		blockStart time.Time,
 		onRetrieve OnRetrieveBlock,
 	) (xio.BlockReader, error)
+
+	// InvalidateBlock invalidates any open resources for a given shard and
+	// block start so subsequent streams read the latest volume written for it.
+	InvalidateBlock(shard uint32, blockStart time.Time) error
 }
 
 // DatabaseShardBlockRetriever is a block retriever bound to a shard.
@@ -51,6 +51,9 @@ var (
 	// errShardNotBootstrappedToRead raised when trying to read data for a shard that's not yet bootstrapped.
 	errShardNotBootstrappedToRead = errors.New("shard is not yet bootstrapped to read")
 
+	// errShardNotBootstrappedToDelete raised when trying to delete data for a shard that's not yet bootstrapped.
+	errShardNotBootstrappedToDelete = errors.New("shard is not yet bootstrapped to delete")
+
 	// errBootstrapEnqueued raised when trying to bootstrap and bootstrap becomes enqueued.
 	errBootstrapEnqueued = errors.New("database bootstrapping enqueued bootstrap")
 )
@@ -29,6 +29,7 @@ import (
 
 	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/valuetype"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
@@ -276,7 +277,7 @@ func (s *commitLogSource) ReadData(
 		// remembering to subtract 1 to convert to zero-based indexing
 		numShards        = s.findHighestShard(shardsTimeRanges) + 1
 		numConc          = s.opts.EncodingConcurrency()
-		encoderPool      = blOpts.EncoderPool()
+		encoderPool      = s.newEncoderPool(ns)
 		workerErrs       = make([]int, numConc)
 		shardDataByShard = s.newShardDataByShard(shardsTimeRanges, numShards)
 	)
@@ -355,6 +356,7 @@ func (s *commitLogSource) ReadData(
 		int(numShards),
 		blockSize,
 		shardDataByShard,
+		encoderPool,
 	)
 	if err != nil {
 		return nil, err
@@ -997,6 +999,7 @@ func (s *commitLogSource) mergeAllShardsCommitLogEncodersAndSnapshots(
 	numShards int,
 	blockSize time.Duration,
 	unmerged []shardData,
+	encoderPool encoding.EncoderPool,
 ) (result.DataBootstrapResult, error) {
 	var (
 		shardErrs       = make([]int, numShards)
@@ -1043,7 +1046,7 @@ func (s *commitLogSource) mergeAllShardsCommitLogEncodersAndSnapshots(
 		mergeShardFunc := func() {
 			var shardResult result.ShardResult
 			shardResult, shardEmptyErrs[shard], shardErrs[shard] = s.mergeShardCommitLogEncodersAndSnapshots(
-				shard, snapshotData, unmergedShard, blockSize)
+				shard, snapshotData, unmergedShard, blockSize, encoderPool)
 
 			if shardResult != nil && shardResult.NumSeries() > 0 {
 				// Prevent race conditions while updating bootstrapResult from multiple go-routines
@@ -1073,6 +1076,7 @@ func (s *commitLogSource) mergeShardCommitLogEncodersAndSnapshots(
 	snapshotData result.ShardResult,
 	unmergedShard shardData,
 	blockSize time.Duration,
+	encoderPool encoding.EncoderPool,
 ) (result.ShardResult, int, int) {
 	var (
 		bOpts                   = s.opts.ResultOptions()
@@ -1080,7 +1084,6 @@ func (s *commitLogSource) mergeShardCommitLogEncodersAndSnapshots(
 		blocksPool              = blOpts.DatabaseBlockPool()
 		multiReaderIteratorPool = blOpts.MultiReaderIteratorPool()
 		segmentReaderPool       = blOpts.SegmentReaderPool()
-		encoderPool             = blOpts.EncoderPool()
 	)
 
 	numSeries := 0
@@ -1245,6 +1248,24 @@ func (s *commitLogSource) mergeSeries(
 	return seriesBlocks, numEmptyErrs, numErrs
 }
 
+// newEncoderPool returns the pool of encoders for the value type of the
+// namespace, series that are not floats are encoded with their own encoders.
+func (s *commitLogSource) newEncoderPool(ns namespace.Metadata) encoding.EncoderPool {
+	blOpts := s.opts.ResultOptions().DatabaseBlockOptions()
+	valueType := ns.Options().ValueType()
+	if valueType == encoding.FloatValueType {
+		return blOpts.EncoderPool()
+	}
+	return valuetype.NewEncoderPool(valueType,
+		pool.NewObjectPoolOptions().
+			SetSize(s.opts.EncodingConcurrency()).
+			SetInstrumentOptions(s.opts.ResultOptions().InstrumentOptions()),
+		encoding.NewOptions().
+			SetBytesPool(blOpts.BytesPool()).
+			SetReaderIteratorPool(blOpts.ReaderIteratorPool()).
+			SetSegmentReaderPool(blOpts.SegmentReaderPool()))
+}
+
 func (s *commitLogSource) findHighestShard(shardsTimeRanges result.ShardTimeRanges) uint32 {
 	var max uint32
 	for shard := range shardsTimeRanges {
@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/encoding/valuetype"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
@@ -42,6 +43,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3x/checked"
+	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/pool"
 	xtime "github.com/m3db/m3x/time"
@@ -89,6 +91,26 @@ func testOptions() Options {
 		SetRuntimeOptionsManager(runtime.NewOptionsManager())
 }
 
+func testValueTypeOptions() Options {
+	opts := testOptions()
+	ropts := opts.ResultOptions()
+	rlopts := ropts.DatabaseBlockOptions()
+	eopts := encoding.NewOptions()
+	readerIteratorPool := encoding.NewReaderIteratorPool(nil)
+	readerIteratorPool.Init(func(reader io.Reader) encoding.ReaderIterator {
+		return valuetype.NewReaderIterator(reader, true, eopts)
+	})
+	multiReaderIteratorPool := encoding.NewMultiReaderIteratorPool(nil)
+	multiReaderIteratorPool.Init(func(reader io.Reader) encoding.ReaderIterator {
+		it := readerIteratorPool.Get()
+		it.Reset(reader)
+		return it
+	})
+	return opts.SetResultOptions(ropts.SetDatabaseBlockOptions(rlopts.
+		SetReaderIteratorPool(readerIteratorPool).
+		SetMultiReaderIteratorPool(multiReaderIteratorPool)))
+}
+
 func TestAvailableEmptyRangeError(t *testing.T) {
 	var (
 		opts     = testDefaultOpts
@@ -304,6 +326,74 @@ func TestReadTrimsToRanges(t *testing.T) {
 		values[1:3], blockSize, res.ShardResults(), opts))
 }
 
+func TestReadCounterNamespaceValues(t *testing.T) {
+	opts := testValueTypeOptions()
+	md, err := namespace.NewMetadata(testNamespaceID, namespace.NewOptions().
+		SetValueType(encoding.CounterValueType))
+	require.NoError(t, err)
+	src := newCommitLogSource(opts, fs.Inspection{}).(*commitLogSource)
+
+	blockSize := md.Options().RetentionOptions().BlockSize()
+	now := time.Now()
+	start := now.Truncate(blockSize).Add(-blockSize)
+	end := now.Truncate(blockSize)
+
+	ranges := xtime.Ranges{}
+	ranges = ranges.AddRange(xtime.Range{
+		Start: start,
+		End:   end,
+	})
+
+	foo := commitlog.Series{Namespace: testNamespaceID, Shard: 0, ID: ident.StringID("foo")}
+
+	// Out of order values are written to separate encoders which are merged
+	// when the shard results are built, the last value resets the counter.
+	values := []testValue{
+		{foo, start, 1.0, xtime.Second, nil},
+		{foo, start.Add(2 * time.Minute), 5.0, xtime.Second, nil},
+		{foo, start.Add(1 * time.Minute), 3.0, xtime.Second, nil},
+		{foo, start.Add(3 * time.Minute), 2.0, xtime.Second, nil},
+	}
+	src.newIteratorFn = func(_ commitlog.IteratorOpts) (commitlog.Iterator, []commitlog.ErrorWithPath, error) {
+		return newTestCommitLogIterator(values, nil), nil, nil
+	}
+
+	res, err := src.ReadData(md, result.ShardTimeRanges{0: ranges}, testDefaultRunOpts)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.ShardResults()))
+	require.Equal(t, 0, len(res.Unfulfilled()))
+
+	series, ok := res.ShardResults()[0].AllSeries().Get(foo.ID)
+	require.True(t, ok)
+	bl, ok := series.Blocks.BlockAt(start)
+	require.True(t, ok)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+	stream, err := bl.Stream(ctx)
+	require.NoError(t, err)
+
+	iter := valuetype.NewReaderIterator(stream, true, nil)
+	defer iter.Close()
+
+	var (
+		expected = []float64{1, 3, 5, 2}
+		resets   = []bool{false, false, false, true}
+		i        int
+	)
+	for iter.Next() {
+		require.Equal(t, encoding.CounterValueType, iter.ValueType())
+		dp, _, _ := iter.Current()
+		require.True(t, i < len(expected))
+		require.True(t, start.Add(time.Duration(i)*time.Minute).Equal(dp.Timestamp))
+		require.Equal(t, expected[i], dp.Value)
+		require.Equal(t, resets[i], iter.CounterReset())
+		i++
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, len(expected), i)
+}
+
 func TestItMergesSnapshotsAndCommitLogs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
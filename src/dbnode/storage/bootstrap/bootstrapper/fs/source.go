@@ -341,9 +341,10 @@ func (s *fileSystemSource) newShardReaders(
 
 		openOpts := fs.DataReaderOpenOptions{
 			Identifier: fs.FileSetFileIdentifier{
-				Namespace:  ns.ID(),
-				Shard:      shard,
-				BlockStart: blockStart,
+				Namespace:   ns.ID(),
+				Shard:       shard,
+				BlockStart:  blockStart,
+				VolumeIndex: result.ID.VolumeIndex,
 			},
 		}
 		if err := r.Open(openOpts); err != nil {
@@ -68,14 +68,19 @@ type cleanupManagerMetrics struct {
 	status               tally.Gauge
 	corruptCommitlogFile tally.Counter
 	deletedCommitlogFile tally.Counter
+	offloadedFileSet     tally.Counter
+	evictedFileSet       tally.Counter
 }
 
 func newCleanupManagerMetrics(scope tally.Scope) cleanupManagerMetrics {
 	clScope := scope.SubScope("commitlog")
+	csScope := scope.SubScope("cold-storage")
 	return cleanupManagerMetrics{
 		status:               scope.Gauge("cleanup"),
 		corruptCommitlogFile: clScope.Counter("corrupt"),
 		deletedCommitlogFile: clScope.Counter("deleted"),
+		offloadedFileSet:     csScope.Counter("offloaded"),
+		evictedFileSet:       csScope.Counter("evicted"),
 	}
 }
 
@@ -117,6 +122,11 @@ func (m *cleanupManager) Cleanup(t time.Time) error {
 			"encountered errors when cleaning up data files for %v: %v", t, err))
 	}
 
+	if err := m.offloadDataFiles(t); err != nil {
+		multiErr = multiErr.Add(fmt.Errorf(
+			"encountered errors when offloading data files for %v: %v", t, err))
+	}
+
 	if err := m.cleanupExpiredIndexFiles(t); err != nil {
 		multiErr = multiErr.Add(fmt.Errorf(
 			"encountered errors when cleaning up index files for %v: %v", t, err))
@@ -236,6 +246,38 @@ func (m *cleanupManager) cleanupExpiredDataFiles(t time.Time) error {
 	return multiErr.FinalError()
 }
 
+// offloadDataFiles moves data fileset volumes of blocks that ended more than
+// the offload age ago to cold storage if it is configured.
+func (m *cleanupManager) offloadDataFiles(t time.Time) error {
+	fsOpts := m.opts.CommitLogOptions().FilesystemOptions()
+	offloadAge := fsOpts.ColdStorageOffloadAge()
+	if fsOpts.ColdStorage() == nil || offloadAge <= 0 {
+		return nil
+	}
+
+	multiErr := xerrors.NewMultiError()
+	namespaces, err := m.database.GetOwnedNamespaces()
+	if err != nil {
+		return err
+	}
+	for _, n := range namespaces {
+		if !n.Options().CleanupEnabled() {
+			continue
+		}
+		blockSize := n.Options().RetentionOptions().BlockSize()
+		before := t.Add(-offloadAge).Truncate(blockSize)
+		for _, shard := range n.GetOwnedShards() {
+			result, err := fs.OffloadDataFileSets(fsOpts, n.ID(), shard.ID(), before)
+			m.metrics.offloadedFileSet.Inc(int64(result.NumOffloaded))
+			m.metrics.evictedFileSet.Inc(int64(result.NumEvicted))
+			if err != nil {
+				multiErr = multiErr.Add(err)
+			}
+		}
+	}
+	return multiErr.FinalError()
+}
+
 func (m *cleanupManager) cleanupExpiredIndexFiles(t time.Time) error {
 	namespaces, err := m.database.GetOwnedNamespaces()
 	if err != nil {
@@ -23,10 +23,14 @@ package storage
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
@@ -180,6 +184,87 @@ func TestCleanupDataAndSnapshotFileSetFiles(t *testing.T) {
 	require.NoError(t, mgr.Cleanup(ts))
 }
 
+func TestCleanupManagerOffloadsDataFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "testdb")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filePathPrefix := filepath.Join(dir, "data")
+	coldDir := filepath.Join(dir, "cold")
+
+	ts := timeFor(36000)
+	blockSize := 3600 * time.Second
+	rOpts := retention.NewOptions().
+		SetRetentionPeriod(21600 * time.Second).
+		SetBlockSize(blockSize)
+	nsOpts := namespace.NewOptions().
+		SetRetentionOptions(rOpts).
+		SetCleanupEnabled(true)
+	nsID := ident.StringID("ns")
+
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().ID().Return(uint32(0)).AnyTimes()
+	ns := NewMockdatabaseNamespace(ctrl)
+	ns.EXPECT().ID().Return(nsID).AnyTimes()
+	ns.EXPECT().Options().Return(nsOpts).AnyTimes()
+	ns.EXPECT().GetOwnedShards().Return([]databaseShard{shard}).AnyTimes()
+	namespaces := []databaseNamespace{ns}
+
+	db := newMockdatabase(ctrl, namespaces...)
+	db.EXPECT().GetOwnedNamespaces().Return(namespaces, nil).AnyTimes()
+
+	fsOpts := fs.NewOptions().
+		SetFilePathPrefix(filePathPrefix).
+		SetColdStorage(fs.NewLocalColdStorage(coldDir, 0666, 0755)).
+		SetColdStorageOffloadAge(2 * blockSize)
+
+	var dataPaths []string
+	for _, blockStart := range []time.Time{
+		ts.Add(-3 * blockSize),
+		ts.Add(-blockSize),
+	} {
+		writer, err := fs.NewWriter(fsOpts)
+		require.NoError(t, err)
+		require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+			Identifier: fs.FileSetFileIdentifier{
+				Namespace:  nsID,
+				Shard:      0,
+				BlockStart: blockStart,
+			},
+			BlockSize: blockSize,
+		}))
+		require.NoError(t, writer.Close())
+
+		filesets, err := fs.DataFileSetsAt(filePathPrefix, nsID, 0, blockStart)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(filesets))
+		for _, filePath := range filesets[0].AbsoluteFilepaths {
+			if strings.HasSuffix(filePath, "-data.db") {
+				dataPaths = append(dataPaths, filePath)
+			}
+		}
+	}
+	require.Equal(t, 2, len(dataPaths))
+
+	mgr := newCleanupManager(db, newNoopFakeActiveLogs(), tally.NoopScope).(*cleanupManager)
+	mgr.opts = mgr.opts.SetCommitLogOptions(
+		mgr.opts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+	require.NoError(t, mgr.offloadDataFiles(ts))
+
+	// Only the block that ended before the offload age is offloaded.
+	for i, expectOffloaded := range []bool{true, false} {
+		coldPath, err := fs.ColdStoragePath(filePathPrefix, dataPaths[i])
+		require.NoError(t, err)
+
+		_, err = os.Stat(filepath.Join(coldDir, filepath.FromSlash(coldPath)))
+		require.Equal(t, expectOffloaded, err == nil)
+		_, err = os.Stat(dataPaths[i])
+		require.Equal(t, expectOffloaded, os.IsNotExist(err))
+	}
+}
+
 type deleteInactiveDirectoriesCall struct {
 	parentDirPath  string
 	activeDirNames []string
@@ -34,6 +34,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/x/xcounter"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3x/context"
@@ -95,6 +96,8 @@ type db struct {
 	errors       xcounter.FrequencyCounter
 	errWindow    time.Duration
 	errThreshold int64
+
+	merkleTrees *blockMerkleTrees
 }
 
 type databaseMetrics struct {
@@ -104,6 +107,7 @@ type databaseMetrics struct {
 	unknownNamespaceFetchBlocks         tally.Counter
 	unknownNamespaceFetchBlocksMetadata tally.Counter
 	unknownNamespaceQueryIDs            tally.Counter
+	unknownNamespaceAggregateQuery      tally.Counter
 	errQueryIDsIndexDisabled            tally.Counter
 	errWriteTaggedIndexDisabled         tally.Counter
 }
@@ -118,6 +122,7 @@ func newDatabaseMetrics(scope tally.Scope) databaseMetrics {
 		unknownNamespaceFetchBlocks:         unknownNamespaceScope.Counter("fetch-blocks"),
 		unknownNamespaceFetchBlocksMetadata: unknownNamespaceScope.Counter("fetch-blocks-metadata"),
 		unknownNamespaceQueryIDs:            unknownNamespaceScope.Counter("query-ids"),
+		unknownNamespaceAggregateQuery:      unknownNamespaceScope.Counter("aggregate-query"),
 		errQueryIDsIndexDisabled:            indexDisabledScope.Counter("err-query-ids"),
 		errWriteTaggedIndexDisabled:         indexDisabledScope.Counter("err-write-tagged"),
 	}
@@ -158,6 +163,13 @@ func NewDatabase(
 		errThreshold: opts.ErrorThresholdForLoad(),
 	}
 
+	merkleTreeRefresh := repair.NewOptions().MerkleTreeRefreshInterval()
+	if ropts := opts.RepairOptions(); ropts != nil {
+		merkleTreeRefresh = ropts.MerkleTreeRefreshInterval()
+	}
+	d.merkleTrees = newBlockMerkleTrees(d.FetchBlocksMetadataV2, d.nowFn,
+		merkleTreeRefresh)
+
 	databaseIOpts := iopts.SetMetricsScope(scope)
 
 	// initialize namespaces
@@ -545,6 +557,31 @@ func (d *db) QueryIDs(
 	return queryResults, err
 }
 
+func (d *db) AggregateQuery(
+	ctx context.Context,
+	namespace ident.ID,
+	query index.Query,
+	opts index.AggregateQueryOptions,
+) (index.AggregateQueryResult, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		d.metrics.unknownNamespaceAggregateQuery.Inc(1)
+		return index.AggregateQueryResult{}, err
+	}
+
+	var (
+		wg     = sync.WaitGroup{}
+		result index.AggregateQueryResult
+	)
+	wg.Add(1)
+	d.opts.QueryIDsWorkerPool().Go(func() {
+		result, err = n.AggregateQuery(ctx, query, opts)
+		wg.Done()
+	})
+	wg.Wait()
+	return result, err
+}
+
 func (d *db) ReadEncoded(
 	ctx context.Context,
 	namespace ident.ID,
@@ -595,6 +632,24 @@ func (d *db) FetchBlocksMetadataV2(
 		pageToken, opts)
 }
 
+func (d *db) BlockMerkleTree(
+	ctx context.Context,
+	namespace ident.ID,
+	shardID uint32,
+	blockStart time.Time,
+	depth int,
+) (*repair.MerkleTree, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		d.metrics.unknownNamespaceFetchBlocksMetadata.Inc(1)
+		return nil, xerrors.NewInvalidParamsError(err)
+	}
+
+	blockSize := n.Options().RetentionOptions().BlockSize()
+	return d.merkleTrees.Tree(ctx, namespace, shardID,
+		blockStart.Truncate(blockSize), blockSize, depth)
+}
+
 func (d *db) Bootstrap() error {
 	d.Lock()
 	d.bootstraps++
@@ -618,6 +673,19 @@ func (d *db) Truncate(namespace ident.ID) (int64, error) {
 	return n.Truncate()
 }
 
+func (d *db) DeleteTagged(
+	ctx context.Context,
+	namespace ident.ID,
+	query index.Query,
+	start, end time.Time,
+) (int64, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		return 0, err
+	}
+	return n.DeleteTagged(ctx, query, start, end)
+}
+
 func (d *db) IsOverloaded() bool {
 	return d.errors.Count(d.errWindow) > d.errThreshold
 }
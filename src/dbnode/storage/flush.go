@@ -115,6 +115,14 @@ func (m *flushManager) Flush(
 			continue
 		}
 		multiErr = multiErr.Add(m.flushNamespaceWithTimes(ns, shardBootstrapTimes, flushTimes, flush))
+
+		// Cold flush after the regular flush so that any blocks just flushed
+		// can have their cold writes merged and deleted data dropped straight away.
+		if err := ns.ColdFlush(flush); err != nil {
+			detailedErr := fmt.Errorf("namespace %s failed to cold flush data: %v",
+				ns.ID().String(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
 	}
 
 	// Perform two separate loops through all the namespaces so that we can emit better
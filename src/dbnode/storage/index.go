@@ -80,6 +80,10 @@ type nsIndex struct {
 	bufferPast      time.Duration
 	bufferFuture    time.Duration
 
+	// coldWritesEnabled allows writes older than buffer past to be indexed,
+	// they are accepted as far back as the retention period.
+	coldWritesEnabled bool
+
 	indexFilesetsBeforeFn indexFilesetsBeforeFn
 	deleteFilesFn         deleteFilesFn
 
@@ -112,6 +116,11 @@ type nsIndexState struct {
 	// chronological order. This is used at query time to enforce determinism about results
 	// returned.
 	blockStartsDescOrder []xtime.UnixNano
+
+	// removedSeries contains the IDs of deleted series, keyed by the string
+	// of the ID bytes. The segments are immutable once sealed so deleted
+	// series are filtered from query results instead.
+	removedSeries map[string]ident.ID
 }
 
 // NB: nsIndexRuntimeOptions does not contain its own mutex as some of the variables
@@ -221,6 +230,8 @@ func newNamespaceIndexWithOptions(
 		bufferPast:      nsMD.Options().RetentionOptions().BufferPast(),
 		bufferFuture:    nsMD.Options().RetentionOptions().BufferFuture(),
 
+		coldWritesEnabled: nsMD.Options().ColdWritesEnabled(),
+
 		indexFilesetsBeforeFn: fs.IndexFileSetsBefore,
 		deleteFilesFn:         fs.DeleteFiles,
 
@@ -344,6 +355,9 @@ func (i *nsIndex) writeBatches(
 	now := i.nowFn()
 	futureLimit := now.Add(1 * i.bufferFuture)
 	pastLimit := now.Add(-1 * i.bufferPast)
+	if i.coldWritesEnabled {
+		pastLimit = now.Add(-1 * i.retentionPeriod)
+	}
 	writeBatchFn := i.writeBatchForBlockStartWithRLock
 	for _, batch := range batches {
 		// Ensure timestamp is not too old/new based on retention policies and that
@@ -721,8 +735,21 @@ func (i *nsIndex) Query(
 		return index.QueryResults{}, errDbIndexUnableToQueryClosed
 	}
 
+	// NB(r): a paged query must visit every series matched to find those that
+	// fall in the page, the results are instead bounded by the page size.
+	var page *index.QueryPage
+	if opts.Page != nil {
+		pageCopy := *opts.Page
+		page, opts.Limit = &pageCopy, 0
+		if maxLimit := i.state.runtimeOpts.maxQueryLimit; maxLimit > 0 && int64(page.Size) > maxLimit {
+			i.logger.Debugf("overriding query page size, requested: %d, max-allowed: %d",
+				page.Size, maxLimit)
+			page.Size = int(maxLimit)
+		}
+	}
+
 	// override query response limit if needed.
-	if i.state.runtimeOpts.maxQueryLimit > 0 && (opts.Limit == 0 ||
+	if page == nil && i.state.runtimeOpts.maxQueryLimit > 0 && (opts.Limit == 0 ||
 		int64(opts.Limit) > i.state.runtimeOpts.maxQueryLimit) {
 		i.logger.Debugf("overriding query response limit, requested: %d, max-allowed: %d",
 			opts.Limit, i.state.runtimeOpts.maxQueryLimit) // FOLLOWUP(prateek): log query too once it's serializable.
@@ -730,12 +757,17 @@ func (i *nsIndex) Query(
 	}
 
 	var (
-		exhaustive = true
-		results    = i.opts.IndexOptions().ResultsPool().Get()
-		err        error
+		exhaustive   = true
+		results      = i.opts.IndexOptions().ResultsPool().Get()
+		pagedResults index.PagedResults
+		err          error
 	)
 	results.Reset(i.nsMetadata.ID())
 	ctx.RegisterFinalizer(results)
+	if page != nil {
+		pagedResults = index.NewPagedResults(results, *page)
+		results = pagedResults
+	}
 
 	// Chunk the query request into bounds based on applicable blocks and
 	// execute the requests to each of them; and merge results.
@@ -783,12 +815,105 @@ func (i *nsIndex) Query(
 	// FOLLOWUP(prateek): do the above operation with controllable parallelism to optimize
 	// for latency at the cost of higher mem-usage.
 
-	return index.QueryResults{
+	for _, id := range i.state.removedSeries {
+		results.Remove(id)
+	}
+
+	queryResults := index.QueryResults{
+		Exhaustive: exhaustive,
+		Results:    results,
+	}
+	if pagedResults != nil {
+		queryResults.NextPage = pagedResults.NextPage()
+	}
+	return queryResults, nil
+}
+
+func (i *nsIndex) AggregateQuery(
+	ctx context.Context,
+	query index.Query,
+	opts index.AggregateQueryOptions,
+) (index.AggregateQueryResult, error) {
+	i.state.RLock()
+	defer i.state.RUnlock()
+	if !i.isOpenWithRLock() {
+		return index.AggregateQueryResult{}, errDbIndexUnableToQueryClosed
+	}
+
+	// override query response limit if needed.
+	if i.state.runtimeOpts.maxQueryLimit > 0 && (opts.Limit == 0 ||
+		int64(opts.Limit) > i.state.runtimeOpts.maxQueryLimit) {
+		i.logger.Debugf("overriding aggregate query response limit, requested: %d, max-allowed: %d",
+			opts.Limit, i.state.runtimeOpts.maxQueryLimit)
+		opts.Limit = int(i.state.runtimeOpts.maxQueryLimit)
+	}
+
+	var (
+		exhaustive = true
+		results    = index.NewAggregateResults(i.nsMetadata.ID())
+		err        error
+	)
+
+	// NB: unlike Query the results are not restricted by series removed via
+	// RemoveSeries.
+	queryRange := xtime.NewRanges(xtime.Range{
+		Start: opts.StartInclusive, End: opts.EndExclusive})
+
+	for _, start := range i.state.blockStartsDescOrder {
+		block, ok := i.state.blocksByTime[start]
+		if !ok { // should never happen
+			return index.AggregateQueryResult{}, i.missingBlockInvariantError(start)
+		}
+
+		// ensure the block has data requested by the query
+		blockRange := xtime.Range{Start: block.StartTime(), End: block.EndTime()}
+		if !queryRange.Overlaps(blockRange) {
+			continue
+		}
+
+		// terminate early if we know we don't need any more results
+		if opts.Limit > 0 && results.Size() >= opts.Limit {
+			exhaustive = false
+			break
+		}
+
+		exhaustive, err = block.Aggregate(query, opts, results)
+		if err != nil {
+			return index.AggregateQueryResult{}, err
+		}
+
+		if !exhaustive {
+			break
+		}
+
+		// terminate if queryRange doesn't need any more data
+		queryRange = queryRange.RemoveRange(blockRange)
+		if queryRange.IsEmpty() {
+			break
+		}
+	}
+
+	return index.AggregateQueryResult{
 		Exhaustive: exhaustive,
 		Results:    results,
 	}, nil
 }
 
+func (i *nsIndex) RemoveSeries(id ident.ID) {
+	i.state.Lock()
+	if i.state.removedSeries == nil {
+		i.state.removedSeries = make(map[string]ident.ID)
+	}
+	i.state.removedSeries[id.String()] = ident.BytesID(append([]byte(nil), id.Bytes()...))
+	i.state.Unlock()
+}
+
+func (i *nsIndex) RestoreSeries(id ident.ID) {
+	i.state.Lock()
+	delete(i.state.removedSeries, id.String())
+	i.state.Unlock()
+}
+
 // ensureBlockPresentWithRLock guarantees an index.Block exists for the specified
 // blockStart, allocating one if it does not. It returns the desired block, or
 // error if it's unable to do so.
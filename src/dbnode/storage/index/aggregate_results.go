@@ -0,0 +1,199 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/m3db/m3x/ident"
+)
+
+// AggregateResults is a collection of the distinct tag names, and optionally
+// tag values, matched by an aggregate query along with the number of series
+// each was found in. The same series is indexed by every block and segment it
+// was written to, so series added by ID are only counted once, while counts
+// added without an ID, such as those reported by other hosts, are summed.
+type AggregateResults struct {
+	nsID   ident.ID
+	fields map[string]*aggregateField
+	size   int
+}
+
+type aggregateField struct {
+	aggregateCount
+	values map[string]*aggregateCount
+}
+
+type aggregateCount struct {
+	series map[string]struct{}
+	count  int64
+}
+
+func (c *aggregateCount) addSeries(id []byte) {
+	if c.series == nil {
+		c.series = make(map[string]struct{})
+	}
+	c.series[string(id)] = struct{}{}
+}
+
+func (c *aggregateCount) value() int64 {
+	return c.count + int64(len(c.series))
+}
+
+// AggregateField is a distinct tag name and its distinct tag values along
+// with the number of series each was found in. The counts are exact for the
+// results of a single host and approximate once merged across the replicas of
+// a cluster.
+type AggregateField struct {
+	Name   []byte
+	Count  int64
+	Values []AggregateValue
+}
+
+// AggregateValue is a distinct tag value and the number of series it was
+// found in.
+type AggregateValue struct {
+	Value []byte
+	Count int64
+}
+
+// NewAggregateResults returns new aggregate results for the given namespace.
+func NewAggregateResults(nsID ident.ID) *AggregateResults {
+	return &AggregateResults{
+		nsID:   nsID,
+		fields: make(map[string]*aggregateField),
+	}
+}
+
+// Namespace returns the namespace associated with the results.
+func (r *AggregateResults) Namespace() ident.ID {
+	return r.nsID
+}
+
+// Size returns the number of distinct tag names plus the number of distinct
+// tag values held by the results.
+func (r *AggregateResults) Size() int {
+	return r.size
+}
+
+// HasField returns whether the results hold the given tag name.
+func (r *AggregateResults) HasField(name []byte) bool {
+	_, ok := r.fields[string(name)]
+	return ok
+}
+
+// HasFieldValue returns whether the results hold the given tag name and value.
+func (r *AggregateResults) HasFieldValue(name, value []byte) bool {
+	field, ok := r.fields[string(name)]
+	if !ok {
+		return false
+	}
+	_, ok = field.values[string(value)]
+	return ok
+}
+
+// AddField adds count series to the given tag name.
+func (r *AggregateResults) AddField(name []byte, count int64) {
+	r.field(name).count += count
+}
+
+// AddFieldValue adds count series to the given tag name and value.
+func (r *AggregateResults) AddFieldValue(name, value []byte, count int64) {
+	field := r.field(name)
+	field.count += count
+	r.fieldValue(field, value).count += count
+}
+
+// AddFieldSeries adds the series with the given ID to the given tag name,
+// the series is counted once however many times it is added.
+func (r *AggregateResults) AddFieldSeries(name, id []byte) {
+	r.field(name).addSeries(id)
+}
+
+// AddFieldValueSeries adds the series with the given ID to the given tag name
+// and value, the series is counted once however many times it is added.
+func (r *AggregateResults) AddFieldValueSeries(name, value, id []byte) {
+	field := r.field(name)
+	field.addSeries(id)
+	r.fieldValue(field, value).addSeries(id)
+}
+
+// Merge adds the tag names and values of other to the results, the counts
+// are summed.
+func (r *AggregateResults) Merge(other *AggregateResults) {
+	for _, field := range other.Fields() {
+		// NB: AddFieldValue adds to the tag name count as well, so only
+		// add the remainder of the tag name count explicitly.
+		remaining := field.Count
+		for _, value := range field.Values {
+			r.AddFieldValue(field.Name, value.Value, value.Count)
+			remaining -= value.Count
+		}
+		r.AddField(field.Name, remaining)
+	}
+}
+
+// Fields returns the tag names, and their tag values, held by the results
+// sorted lexicographically.
+func (r *AggregateResults) Fields() []AggregateField {
+	fields := make([]AggregateField, 0, len(r.fields))
+	for name, field := range r.fields {
+		result := AggregateField{Name: []byte(name), Count: field.value()}
+		for value, count := range field.values {
+			result.Values = append(result.Values, AggregateValue{
+				Value: []byte(value),
+				Count: count.value(),
+			})
+		}
+		sort.Slice(result.Values, func(i, j int) bool {
+			return bytes.Compare(result.Values[i].Value, result.Values[j].Value) < 0
+		})
+		fields = append(fields, result)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return bytes.Compare(fields[i].Name, fields[j].Name) < 0
+	})
+	return fields
+}
+
+func (r *AggregateResults) field(name []byte) *aggregateField {
+	field, ok := r.fields[string(name)]
+	if !ok {
+		field = &aggregateField{}
+		r.fields[string(name)] = field
+		r.size++
+	}
+	return field
+}
+
+func (r *AggregateResults) fieldValue(field *aggregateField, value []byte) *aggregateCount {
+	if field.values == nil {
+		field.values = make(map[string]*aggregateCount)
+	}
+	count, ok := field.values[string(value)]
+	if !ok {
+		count = &aggregateCount{}
+		field.values[string(value)] = count
+		r.size++
+	}
+	return count
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateResultsMerge(t *testing.T) {
+	a := NewAggregateResults(ident.StringID("ns"))
+	a.AddFieldValue([]byte("foo"), []byte("bar"), 2)
+	a.AddFieldValue([]byte("foo"), []byte("baz"), 1)
+	a.AddField([]byte("qux"), 3)
+	require.Equal(t, 4, a.Size())
+
+	b := NewAggregateResults(ident.StringID("ns"))
+	b.AddFieldValue([]byte("foo"), []byte("bar"), 1)
+	b.AddFieldValue([]byte("foo"), []byte("qux"), 4)
+	b.Merge(a)
+	require.Equal(t, 5, b.Size())
+
+	require.Equal(t, []AggregateField{
+		{
+			Name:  []byte("foo"),
+			Count: 8,
+			Values: []AggregateValue{
+				{Value: []byte("bar"), Count: 3},
+				{Value: []byte("baz"), Count: 1},
+				{Value: []byte("qux"), Count: 4},
+			},
+		},
+		{Name: []byte("qux"), Count: 3},
+	}, b.Fields())
+}
+
+func TestAggregateResultsAddSeriesCountsDistinctSeries(t *testing.T) {
+	r := NewAggregateResults(ident.StringID("ns"))
+	r.AddFieldValueSeries([]byte("foo"), []byte("bar"), []byte("a"))
+	r.AddFieldValueSeries([]byte("foo"), []byte("bar"), []byte("a"))
+	r.AddFieldValueSeries([]byte("foo"), []byte("bar"), []byte("b"))
+	r.AddFieldValueSeries([]byte("foo"), []byte("baz"), []byte("b"))
+	r.AddFieldSeries([]byte("qux"), []byte("a"))
+	r.AddFieldSeries([]byte("qux"), []byte("a"))
+	require.Equal(t, 4, r.Size())
+	require.True(t, r.HasField([]byte("qux")))
+	require.True(t, r.HasFieldValue([]byte("foo"), []byte("baz")))
+	require.False(t, r.HasFieldValue([]byte("qux"), []byte("baz")))
+
+	require.Equal(t, []AggregateField{
+		{
+			Name:  []byte("foo"),
+			Count: 2,
+			Values: []AggregateValue{
+				{Value: []byte("bar"), Count: 2},
+				{Value: []byte("baz"), Count: 1},
+			},
+		},
+		{Name: []byte("qux"), Count: 1},
+	}, r.Fields())
+}
@@ -21,6 +21,7 @@
 package index
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"sync"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/m3ninx/doc"
 	m3ninxindex "github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
@@ -69,6 +71,11 @@ type block struct {
 	activeSegment       segment.MutableSegment
 	shardRangesSegments []blockShardRangesSegments
 
+	// coldSegment holds documents written to the block after it has been
+	// sealed when cold writes are enabled for the namespace. It is created
+	// lazily, is not flushed and lives until the block is closed.
+	coldSegment segment.MutableSegment
+
 	newExecutorFn newExecutorFn
 	startTime     time.Time
 	endTime       time.Time
@@ -130,6 +137,10 @@ func (b *block) WriteBatch(inserts *WriteBatch) (WriteBatchResult, error) {
 	b.Lock()
 	defer b.Unlock()
 
+	if b.state == blockStateSealed && b.nsMD.Options().ColdWritesEnabled() {
+		return b.writeColdBatchWithLock(inserts)
+	}
+
 	if b.state != blockStateOpen {
 		err := b.writeBatchErrorInvalidState(b.state)
 		inserts.MarkUnmarkedEntriesError(err)
@@ -148,7 +159,29 @@ func (b *block) WriteBatch(inserts *WriteBatch) (WriteBatchResult, error) {
 		}, err
 	}
 
-	err := b.activeSegment.InsertBatch(m3ninxindex.Batch{
+	return b.writeBatchToSegmentWithLock(b.activeSegment, inserts)
+}
+
+func (b *block) writeColdBatchWithLock(inserts *WriteBatch) (WriteBatchResult, error) {
+	if b.coldSegment == nil {
+		seg, err := mem.NewSegment(postings.ID(0), b.opts.MemSegmentOptions())
+		if err != nil {
+			inserts.MarkUnmarkedEntriesError(err)
+			return WriteBatchResult{
+				NumError: int64(inserts.Len()),
+			}, err
+		}
+		b.coldSegment = seg
+	}
+
+	return b.writeBatchToSegmentWithLock(b.coldSegment, inserts)
+}
+
+func (b *block) writeBatchToSegmentWithLock(
+	seg segment.MutableSegment,
+	inserts *WriteBatch,
+) (WriteBatchResult, error) {
+	err := seg.InsertBatch(m3ninxindex.Batch{
 		Docs:                inserts.PendingDocs(),
 		AllowPartialUpdates: true,
 	})
@@ -186,6 +219,9 @@ func (b *block) executorWithRLock() (search.Executor, error) {
 	if b.activeSegment != nil {
 		expectedReaders++
 	}
+	if b.coldSegment != nil {
+		expectedReaders++
+	}
 	for _, group := range b.shardRangesSegments {
 		expectedReaders += len(group.segments)
 	}
@@ -213,6 +249,15 @@ func (b *block) executorWithRLock() (search.Executor, error) {
 		readers = append(readers, reader)
 	}
 
+	// followed by any writes received after the block was sealed
+	if b.coldSegment != nil {
+		reader, err := b.coldSegment.Reader()
+		if err != nil {
+			return nil, err
+		}
+		readers = append(readers, reader)
+	}
+
 	// loop over the segments associated to shard time ranges
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
@@ -293,6 +338,152 @@ func (b *block) Query(
 	return exhaustive, nil
 }
 
+func (b *block) Aggregate(
+	query Query,
+	opts AggregateQueryOptions,
+	results *AggregateResults,
+) (bool, error) {
+	b.RLock()
+	defer b.RUnlock()
+	if b.state == blockStateClosed {
+		return false, errUnableToQueryBlockClosed
+	}
+
+	searcher, err := query.Query.SearchQuery().Searcher()
+	if err != nil {
+		return false, err
+	}
+
+	for _, seg := range b.segmentsWithRLock() {
+		exhaustive, err := aggregateSegment(seg, searcher, opts, results)
+		if err != nil {
+			return false, err
+		}
+		if !exhaustive {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (b *block) segmentsWithRLock() []segment.Segment {
+	var segments []segment.Segment
+	if b.activeSegment != nil {
+		segments = append(segments, b.activeSegment)
+	}
+	if b.coldSegment != nil {
+		segments = append(segments, b.coldSegment)
+	}
+	for _, group := range b.shardRangesSegments {
+		segments = append(segments, group.segments...)
+	}
+	return segments
+}
+
+// aggregateSegment adds the tag names, and optionally tag values, of every
+// document matched by the searcher in the segment along with the ID of the
+// document so that each series is counted once across segments and blocks.
+func aggregateSegment(
+	seg segment.Segment,
+	searcher search.Searcher,
+	opts AggregateQueryOptions,
+	results *AggregateResults,
+) (bool, error) {
+	reader, err := seg.Reader()
+	if err != nil {
+		return false, err
+	}
+
+	exhaustive, err := aggregateSegmentReader(reader, searcher, opts, results)
+	if closeErr := reader.Close(); err == nil {
+		err = closeErr
+	}
+	return exhaustive, err
+}
+
+func aggregateSegmentReader(
+	reader m3ninxindex.Reader,
+	searcher search.Searcher,
+	opts AggregateQueryOptions,
+	results *AggregateResults,
+) (bool, error) {
+	matched, err := searcher.Search(reader)
+	if err != nil {
+		return false, err
+	}
+	if matched.IsEmpty() {
+		return true, nil
+	}
+
+	docs, err := reader.Docs(matched)
+	if err != nil {
+		return false, err
+	}
+
+	exhaustive := true
+	for docs.Next() {
+		d := docs.Current()
+		for _, field := range d.Fields {
+			if !aggregateIncludesField(opts, field.Name) {
+				continue
+			}
+			if !aggregateDocField(d.ID, field, opts, results) {
+				exhaustive = false
+			}
+		}
+	}
+	if err = docs.Err(); err == nil {
+		err = docs.Close()
+	} else {
+		docs.Close()
+	}
+	if err != nil {
+		return false, err
+	}
+	return exhaustive, nil
+}
+
+// aggregateDocField adds the given field of a document to the results and
+// returns false if the field was not added because the limit was reached,
+// the series of tag names and values already held are still counted.
+func aggregateDocField(
+	id []byte,
+	field doc.Field,
+	opts AggregateQueryOptions,
+	results *AggregateResults,
+) bool {
+	limitReached := opts.Limit > 0 && results.Size() >= opts.Limit
+	if opts.Type == AggregateTagNames {
+		if limitReached && !results.HasField(field.Name) {
+			return false
+		}
+		results.AddFieldSeries(field.Name, id)
+		return true
+	}
+
+	if limitReached && !results.HasFieldValue(field.Name, field.Value) {
+		return false
+	}
+	results.AddFieldValueSeries(field.Name, field.Value, id)
+	return true
+}
+
+func aggregateIncludesField(opts AggregateQueryOptions, field []byte) bool {
+	if bytes.Equal(field, ReservedFieldNameID) {
+		return false
+	}
+	if len(opts.TagNameFilter) == 0 {
+		return true
+	}
+	for _, name := range opts.TagNameFilter {
+		if bytes.Equal(field, name) {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *block) AddResults(
 	results result.IndexBlock,
 ) error {
@@ -384,6 +575,12 @@ func (b *block) Tick(c context.Cancellable, tickStart time.Time) (BlockTickResul
 		result.NumDocs += b.activeSegment.Size()
 	}
 
+	// cold segment, only present if written to after the block was sealed.
+	if b.coldSegment != nil {
+		result.NumSegments++
+		result.NumDocs += b.coldSegment.Size()
+	}
+
 	// any other segments
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
@@ -509,6 +706,12 @@ func (b *block) Close() error {
 		b.activeSegment = nil
 	}
 
+	// close cold segment.
+	if b.coldSegment != nil {
+		multiErr = multiErr.Add(b.coldSegment.Close())
+		b.coldSegment = nil
+	}
+
 	// close any other added segments too.
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
@@ -163,6 +163,54 @@ func TestBlockWriteAfterSeal(t *testing.T) {
 	require.Equal(t, 1, verified)
 }
 
+func TestBlockWriteAfterSealWithColdWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blockSize := time.Hour
+	md := newTestNSMetadata(t)
+	md, err := namespace.NewMetadata(md.ID(), md.Options().SetColdWritesEnabled(true))
+	require.NoError(t, err)
+
+	now := time.Now()
+	blockStart := now.Truncate(blockSize)
+
+	nowNotBlockStartAligned := now.
+		Truncate(blockSize).
+		Add(time.Minute)
+
+	b, err := NewBlock(blockStart, md, testOpts)
+	require.NoError(t, err)
+	require.NoError(t, b.Seal())
+
+	lifecycle := NewMockOnIndexSeries(ctrl)
+	lifecycle.EXPECT().OnIndexFinalize(xtime.ToUnixNano(blockStart))
+	lifecycle.EXPECT().OnIndexSuccess(xtime.ToUnixNano(blockStart))
+
+	batch := NewWriteBatch(WriteBatchOptions{
+		IndexBlockSize: blockSize,
+	})
+	batch.Append(WriteBatchEntry{
+		Timestamp:     nowNotBlockStartAligned,
+		OnIndexSeries: lifecycle,
+	}, testDoc1())
+
+	res, err := b.WriteBatch(batch)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), res.NumSuccess)
+	require.Equal(t, int64(0), res.NumError)
+
+	// Cold writes are kept when the mutable segments are evicted.
+	_, err = b.EvictMutableSegments()
+	require.NoError(t, err)
+
+	tickResult, err := b.Tick(nil, now)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), tickResult.NumDocs)
+
+	require.NoError(t, b.Close())
+}
+
 func TestBlockWriteMockSegment(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1249,6 +1297,140 @@ func TestBlockE2EInsertQuery(t *testing.T) {
 		ident.NewTagsIterator(t2)))
 }
 
+func TestBlockE2EInsertAggregate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blockSize := time.Hour
+
+	testMD := newTestNSMetadata(t)
+	now := time.Now()
+	blockStart := now.Truncate(blockSize)
+
+	nowNotBlockStartAligned := now.
+		Truncate(blockSize).
+		Add(time.Minute)
+
+	blk, err := NewBlock(blockStart, testMD, testOpts)
+	require.NoError(t, err)
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	h1 := NewMockOnIndexSeries(ctrl)
+	h1.EXPECT().OnIndexFinalize(xtime.ToUnixNano(blockStart))
+	h1.EXPECT().OnIndexSuccess(xtime.ToUnixNano(blockStart))
+
+	h2 := NewMockOnIndexSeries(ctrl)
+	h2.EXPECT().OnIndexFinalize(xtime.ToUnixNano(blockStart))
+	h2.EXPECT().OnIndexSuccess(xtime.ToUnixNano(blockStart))
+
+	batch := NewWriteBatch(WriteBatchOptions{
+		IndexBlockSize: blockSize,
+	})
+	batch.Append(WriteBatchEntry{
+		Timestamp:     nowNotBlockStartAligned,
+		OnIndexSeries: h1,
+	}, testDoc1())
+	batch.Append(WriteBatchEntry{
+		Timestamp:     nowNotBlockStartAligned,
+		OnIndexSeries: h2,
+	}, testDoc2())
+
+	res, err := b.WriteBatch(batch)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), res.NumSuccess)
+	require.Equal(t, int64(0), res.NumError)
+
+	q, err := idx.NewRegexpQuery([]byte("bar"), []byte("b.*"))
+	require.NoError(t, err)
+
+	results := NewAggregateResults(ident.StringID("ns"))
+	exhaustive, err := b.Aggregate(Query{q}, AggregateQueryOptions{}, results)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+	require.Equal(t, []AggregateField{
+		{
+			Name:   []byte("bar"),
+			Count:  2,
+			Values: []AggregateValue{{Value: []byte("baz"), Count: 2}},
+		},
+		{
+			Name:   []byte("some"),
+			Count:  1,
+			Values: []AggregateValue{{Value: []byte("more"), Count: 1}},
+		},
+	}, results.Fields())
+
+	results = NewAggregateResults(ident.StringID("ns"))
+	exhaustive, err = b.Aggregate(Query{q}, AggregateQueryOptions{
+		Type:          AggregateTagNames,
+		TagNameFilter: [][]byte{[]byte("some")},
+	}, results)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+	require.Equal(t, []AggregateField{
+		{Name: []byte("some"), Count: 1},
+	}, results.Fields())
+
+	results = NewAggregateResults(ident.StringID("ns"))
+	exhaustive, err = b.Aggregate(Query{q}, AggregateQueryOptions{
+		QueryOptions: QueryOptions{Limit: 1},
+		Type:         AggregateTagNames,
+	}, results)
+	require.NoError(t, err)
+	require.False(t, exhaustive)
+	require.Equal(t, 1, results.Size())
+}
+
+func TestBlockAggregateAcrossSegmentsAndBlocks(t *testing.T) {
+	testMD := newTestNSMetadata(t)
+	blockSize := time.Hour
+	blockStart := time.Now().Truncate(blockSize)
+
+	// The same series are indexed by two segments of the first block and
+	// again by the second block, each value must still be returned once and
+	// each series counted once.
+	blk1, err := NewBlock(blockStart, testMD, testOpts)
+	require.NoError(t, err)
+	require.NoError(t, blk1.AddResults(
+		result.NewIndexBlock(blockStart, []segment.Segment{
+			testSegment(t, testDoc1(), testDoc2()),
+			testSegment(t, testDoc1(), testDoc2()),
+		}, result.NewShardTimeRanges(blockStart, blockStart.Add(blockSize), 1, 2, 3))))
+
+	nextBlockStart := blockStart.Add(blockSize)
+	blk2, err := NewBlock(nextBlockStart, testMD, testOpts)
+	require.NoError(t, err)
+	require.NoError(t, blk2.AddResults(
+		result.NewIndexBlock(nextBlockStart, []segment.Segment{
+			testSegment(t, testDoc2()),
+		}, result.NewShardTimeRanges(nextBlockStart, nextBlockStart.Add(blockSize), 1, 2, 3))))
+
+	q, err := idx.NewRegexpQuery([]byte("bar"), []byte("b.*"))
+	require.NoError(t, err)
+
+	results := NewAggregateResults(ident.StringID("ns"))
+	for _, blk := range []Block{blk1, blk2} {
+		exhaustive, err := blk.Aggregate(Query{q}, AggregateQueryOptions{}, results)
+		require.NoError(t, err)
+		require.True(t, exhaustive)
+	}
+
+	require.Equal(t, 4, results.Size())
+	require.Equal(t, []AggregateField{
+		{
+			Name:   []byte("bar"),
+			Count:  2,
+			Values: []AggregateValue{{Value: []byte("baz"), Count: 2}},
+		},
+		{
+			Name:   []byte("some"),
+			Count:  1,
+			Values: []AggregateValue{{Value: []byte("more"), Count: 1}},
+		},
+	}, results.Fields())
+}
+
 func TestBlockE2EInsertQueryLimit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
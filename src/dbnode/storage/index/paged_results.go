@@ -0,0 +1,133 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"container/heap"
+
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3x/ident"
+)
+
+// pagedResults restricts the results of a query to a single page, i.e. the
+// first page.Size series ordered after page.After. The position of every
+// series held is tracked in a max heap so that once the page is full the
+// series ordered last can be evicted when a series ordered before it is
+// added, this bounds the results to the size of the page however many series
+// the query matches.
+type pagedResults struct {
+	Results
+
+	page      QueryPage
+	positions positionsMaxHeap
+	truncated bool
+}
+
+// NewPagedResults returns results that hold at most a single page of the
+// series added to the provided results.
+func NewPagedResults(results Results, page QueryPage) PagedResults {
+	return &pagedResults{
+		Results:   results,
+		page:      page,
+		positions: make(positionsMaxHeap, 0, page.Size+1),
+	}
+}
+
+func (r *pagedResults) Add(d doc.Document) (bool, int, error) {
+	if len(d.ID) == 0 {
+		return r.Results.Add(d)
+	}
+
+	pos := QueryPagePosition{
+		Shard: r.page.ShardFn(ident.BytesID(d.ID)),
+		ID:    d.ID,
+	}
+	if r.page.After != nil && !r.page.After.Before(pos) {
+		return false, r.Size(), nil
+	}
+	if len(r.positions) >= r.page.Size && !pos.Before(r.positions[0]) {
+		if !r.Map().Contains(ident.BytesID(d.ID)) {
+			r.truncated = true
+		}
+		return false, r.Size(), nil
+	}
+
+	added, size, err := r.Results.Add(d)
+	if err != nil || !added {
+		return added, size, err
+	}
+
+	// NB: copy the ID since the bytes backing the document may be reused
+	// once this function returns.
+	pos.ID = append([]byte(nil), d.ID...)
+	heap.Push(&r.positions, pos)
+	if len(r.positions) > r.page.Size {
+		last := heap.Pop(&r.positions).(QueryPagePosition)
+		r.Results.Remove(ident.BytesID(last.ID))
+		r.truncated = true
+	}
+	return true, r.Size(), nil
+}
+
+func (r *pagedResults) Remove(id ident.ID) bool {
+	if !r.Results.Remove(id) {
+		return false
+	}
+	for i, pos := range r.positions {
+		if string(pos.ID) == string(id.Bytes()) {
+			heap.Remove(&r.positions, i)
+			break
+		}
+	}
+	return true
+}
+
+func (r *pagedResults) Reset(nsID ident.ID) {
+	r.Results.Reset(nsID)
+	r.positions = r.positions[:0]
+	r.truncated = false
+}
+
+func (r *pagedResults) NextPage() *QueryPagePosition {
+	if !r.truncated || len(r.positions) == 0 {
+		return nil
+	}
+	last := r.positions[0]
+	return &last
+}
+
+type positionsMaxHeap []QueryPagePosition
+
+func (h positionsMaxHeap) Len() int           { return len(h) }
+func (h positionsMaxHeap) Less(i, j int) bool { return h[j].Before(h[i]) }
+func (h positionsMaxHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *positionsMaxHeap) Push(x interface{}) {
+	*h = append(*h, x.(QueryPagePosition))
+}
+
+func (h *positionsMaxHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPageShardFn places IDs beginning with "x" in shard 0 and all other IDs
+// in shard 1.
+func testPageShardFn(id ident.ID) uint32 {
+	if id.Bytes()[0] == 'x' {
+		return 0
+	}
+	return 1
+}
+
+func addPagedDocs(t *testing.T, res Results, ids ...string) {
+	for _, id := range ids {
+		_, _, err := res.Add(doc.Document{ID: []byte(id)})
+		require.NoError(t, err)
+	}
+}
+
+func pagedResultIDs(res Results) []string {
+	var ids []string
+	for _, entry := range res.Map().Iter() {
+		ids = append(ids, entry.Key().String())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestQueryPagePositionBefore(t *testing.T) {
+	a := QueryPagePosition{Shard: 1, ID: []byte("b")}
+	b := QueryPagePosition{Shard: 2, ID: []byte("a")}
+	c := QueryPagePosition{Shard: 2, ID: []byte("b")}
+
+	assert.True(t, a.Before(b))
+	assert.True(t, b.Before(c))
+	assert.False(t, c.Before(b))
+	assert.False(t, c.Before(c))
+}
+
+func TestPagedResultsKeepsFirstPage(t *testing.T) {
+	res := NewPagedResults(NewResults(testOpts), QueryPage{
+		Size:    2,
+		ShardFn: testPageShardFn,
+	})
+	addPagedDocs(t, res, "c", "a", "xb", "b", "a", "xa")
+
+	require.Equal(t, 2, res.Size())
+	assert.Equal(t, []string{"xa", "xb"}, pagedResultIDs(res))
+
+	next := res.NextPage()
+	require.NotNil(t, next)
+	assert.Equal(t, QueryPagePosition{Shard: 0, ID: []byte("xb")}, *next)
+}
+
+func TestPagedResultsResumesAfterPosition(t *testing.T) {
+	res := NewPagedResults(NewResults(testOpts), QueryPage{
+		After:   &QueryPagePosition{Shard: 0, ID: []byte("xb")},
+		Size:    2,
+		ShardFn: testPageShardFn,
+	})
+	addPagedDocs(t, res, "c", "a", "xb", "b", "xa")
+
+	assert.Equal(t, []string{"a", "b"}, pagedResultIDs(res))
+	next := res.NextPage()
+	require.NotNil(t, next)
+	assert.Equal(t, QueryPagePosition{Shard: 1, ID: []byte("b")}, *next)
+
+	res = NewPagedResults(NewResults(testOpts), QueryPage{
+		After:   next,
+		Size:    2,
+		ShardFn: testPageShardFn,
+	})
+	addPagedDocs(t, res, "c", "a", "xb", "b", "xa")
+
+	assert.Equal(t, []string{"c"}, pagedResultIDs(res))
+	assert.Nil(t, res.NextPage())
+}
+
+func TestPagedResultsLastPageExactlyFull(t *testing.T) {
+	res := NewPagedResults(NewResults(testOpts), QueryPage{
+		Size:    2,
+		ShardFn: testPageShardFn,
+	})
+	addPagedDocs(t, res, "b", "a", "b")
+
+	assert.Equal(t, []string{"a", "b"}, pagedResultIDs(res))
+	assert.Nil(t, res.NextPage())
+}
+
+func TestPagedResultsRemove(t *testing.T) {
+	res := NewPagedResults(NewResults(testOpts), QueryPage{
+		Size:    2,
+		ShardFn: testPageShardFn,
+	})
+	addPagedDocs(t, res, "a", "b", "c")
+	require.True(t, res.Remove(ident.StringID("b")))
+	require.False(t, res.Remove(ident.StringID("c")))
+
+	next := res.NextPage()
+	require.NotNil(t, next)
+	assert.Equal(t, QueryPagePosition{Shard: 1, ID: []byte("a")}, *next)
+}
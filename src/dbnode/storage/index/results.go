@@ -81,6 +81,17 @@ func (r *results) Add(d doc.Document) (added bool, size int, err error) {
 	return added, r.size, nil
 }
 
+func (r *results) Remove(id ident.ID) bool {
+	tags, ok := r.resultsMap.Get(id)
+	if !ok {
+		return false
+	}
+	r.resultsMap.Delete(id)
+	tags.Finalize()
+	r.size--
+	return true
+}
+
 func (r *results) tags(fields doc.Fields) ident.Tags {
 	tags := r.idPool.Tags()
 	for _, f := range fields {
@@ -102,6 +102,20 @@ func TestResultsInsertContains(t *testing.T) {
 	require.Equal(t, 0, len(tags.Values()))
 }
 
+func TestResultsRemove(t *testing.T) {
+	res := NewResults(testOpts)
+	_, _, err := res.Add(doc.Document{ID: []byte("abc")})
+	require.NoError(t, err)
+	_, _, err = res.Add(doc.Document{ID: []byte("def")})
+	require.NoError(t, err)
+
+	require.True(t, res.Remove(ident.StringID("abc")))
+	require.False(t, res.Remove(ident.StringID("abc")))
+	require.Equal(t, 1, res.Size())
+	require.False(t, res.Map().Contains(ident.StringID("abc")))
+	require.True(t, res.Map().Contains(ident.StringID("def")))
+}
+
 func TestResultsInsertCopies(t *testing.T) {
 	res := NewResults(testOpts)
 	dValid := doc.Document{ID: []byte("abc"), Fields: []doc.Field{
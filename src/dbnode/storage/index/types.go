@@ -21,12 +21,14 @@
 package index
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
@@ -62,12 +64,90 @@ type QueryOptions struct {
 	StartInclusive time.Time
 	EndExclusive   time.Time
 	Limit          int
+
+	// DownsampleStep, when non-zero, requests that the datapoints of each
+	// series are aggregated into steps of this duration using the
+	// DownsampleAggregation function before being returned. Steps are
+	// aligned to DownsampleStart, step k covering the datapoints within
+	// (DownsampleStart+(k-1)*DownsampleStep, DownsampleStart+k*DownsampleStep].
+	DownsampleStep        time.Duration
+	DownsampleStart       time.Time
+	DownsampleAggregation ts.AggregationType
+
+	// Page, when set, restricts the results to a single page of the series
+	// matched by the query, the limit is then ignored in favor of the size
+	// of the page.
+	Page *QueryPage
+}
+
+// QueryPagePosition is the position of a series within the results of a
+// paged query, results are ordered by shard and then by ID.
+type QueryPagePosition struct {
+	Shard uint32
+	ID    []byte
+}
+
+// Before returns whether the position is ordered before the other position.
+func (p QueryPagePosition) Before(other QueryPagePosition) bool {
+	if p.Shard != other.Shard {
+		return p.Shard < other.Shard
+	}
+	return bytes.Compare(p.ID, other.ID) < 0
+}
+
+// QueryPage describes a single page of the results of a query.
+type QueryPage struct {
+	// After is the position of the last series of the previous page, only
+	// series ordered after it are returned. A nil position requests the
+	// first page.
+	After *QueryPagePosition
+
+	// Size is the maximum number of series returned in the page.
+	Size int
+
+	// ShardFn returns the shard that owns a series.
+	ShardFn func(id ident.ID) uint32
+}
+
+// AggregateQueryType specifies what an aggregate query returns.
+type AggregateQueryType byte
+
+const (
+	// AggregateTagNamesAndValues returns the distinct tag names and their
+	// distinct tag values.
+	AggregateTagNamesAndValues AggregateQueryType = iota
+	// AggregateTagNames returns only the distinct tag names.
+	AggregateTagNames
+)
+
+// AggregateQueryOptions enables users to specify constraints on aggregate
+// query execution, the limit restricts the number of distinct tag names and
+// values returned.
+type AggregateQueryOptions struct {
+	QueryOptions
+
+	// Type specifies whether tag values are returned along with tag names.
+	Type AggregateQueryType
+	// TagNameFilter restricts the results to the given tag names, when empty
+	// every tag name is returned.
+	TagNameFilter [][]byte
+}
+
+// AggregateQueryResult is the collection of results for an aggregate query.
+type AggregateQueryResult struct {
+	Results    *AggregateResults
+	Exhaustive bool
 }
 
 // QueryResults is the collection of results for a query.
 type QueryResults struct {
 	Results    Results
 	Exhaustive bool
+
+	// NextPage is the position of the last series of a paged query when
+	// further series remain after it, it is nil for the last page and for
+	// queries that are not paged.
+	NextPage *QueryPagePosition
 }
 
 // Results is a collection of results for a query.
@@ -94,6 +174,20 @@ type Results interface {
 	// NB: it returns a bool to indicate if the doc was added (it won't be added
 	// if it already existed in the ResultsMap).
 	Add(d doc.Document) (added bool, size int, err error)
+
+	// Remove removes the ID and its tags from the results, it returns
+	// whether the ID was present.
+	Remove(id ident.ID) bool
+}
+
+// PagedResults is a collection of results restricted to a single page of the
+// series matched by a query.
+type PagedResults interface {
+	Results
+
+	// NextPage returns the position of the last series in the page if
+	// further series were matched after it, or nil for the last page.
+	NextPage() *QueryPagePosition
 }
 
 // ResultsAllocator allocates Results types.
@@ -145,6 +239,14 @@ type Block interface {
 		results Results,
 	) (exhaustive bool, err error)
 
+	// Aggregate resolves the given query into the distinct tag names and
+	// values of the matching documents.
+	Aggregate(
+		query Query,
+		opts AggregateQueryOptions,
+		results *AggregateResults,
+	) (exhaustive bool, err error)
+
 	// AddResults adds bootstrap results to the block, if c.
 	AddResults(results result.IndexBlock) error
 
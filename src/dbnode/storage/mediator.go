@@ -98,7 +98,11 @@ func newMediator(database database, commitlog commitlog.CommitLog, opts Options)
 	d.databaseRepairer = newNoopDatabaseRepairer()
 	if opts.RepairEnabled() {
 		var err error
-		d.databaseRepairer, err = newDatabaseRepairer(database, opts)
+		if ropts := opts.RepairOptions(); ropts != nil && ropts.AntiEntropyEnabled() {
+			d.databaseRepairer, err = newAntiEntropyRepairer(database, opts)
+		} else {
+			d.databaseRepairer, err = newDatabaseRepairer(database, opts)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,183 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3x/context"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+)
+
+const (
+	// blockMerkleTreeFetchLimit is the number of series fetched per page of
+	// metadata when building the Merkle tree of a block.
+	blockMerkleTreeFetchLimit = 4096
+)
+
+type fetchBlocksMetadataFn func(
+	ctx context.Context,
+	namespace ident.ID,
+	shard uint32,
+	start, end time.Time,
+	limit int64,
+	pageToken PageToken,
+	opts block.FetchBlocksMetadataOptions,
+) (block.FetchBlocksMetadataResults, PageToken, error)
+
+type blockMerkleTreeKey struct {
+	namespace  string
+	shard      uint32
+	blockStart xtime.UnixNano
+	depth      int
+}
+
+type blockMerkleTree struct {
+	tree    *repair.MerkleTree
+	builtAt time.Time
+}
+
+// blockMerkleTrees maintains the Merkle trees over the series checksums of
+// the blocks of the shards owned by the database. A tree is built on first
+// use and rebuilt once it is older than the refresh interval, so that it
+// reflects blocks that have since been flushed, cold written or repaired.
+type blockMerkleTrees struct {
+	sync.Mutex
+
+	fetchBlocksMetadataFn fetchBlocksMetadataFn
+	nowFn                 clock.NowFn
+	refreshInterval       time.Duration
+
+	trees     map[blockMerkleTreeKey]blockMerkleTree
+	lastSweep time.Time
+}
+
+func newBlockMerkleTrees(
+	fetchBlocksMetadataFn fetchBlocksMetadataFn,
+	nowFn clock.NowFn,
+	refreshInterval time.Duration,
+) *blockMerkleTrees {
+	return &blockMerkleTrees{
+		fetchBlocksMetadataFn: fetchBlocksMetadataFn,
+		nowFn:                 nowFn,
+		refreshInterval:       refreshInterval,
+		trees:                 make(map[blockMerkleTreeKey]blockMerkleTree),
+	}
+}
+
+func (t *blockMerkleTrees) Tree(
+	ctx context.Context,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+	blockSize time.Duration,
+	depth int,
+) (*repair.MerkleTree, error) {
+	var (
+		now = t.nowFn()
+		key = blockMerkleTreeKey{
+			namespace:  namespace.String(),
+			shard:      shard,
+			blockStart: xtime.ToUnixNano(blockStart),
+			depth:      depth,
+		}
+	)
+
+	t.Lock()
+	entry, ok := t.trees[key]
+	t.Unlock()
+	if ok && now.Sub(entry.builtAt) < t.refreshInterval {
+		return entry.tree, nil
+	}
+
+	// NB(r): Build without holding the lock as building a tree reads the
+	// metadata of every series in the block, concurrent builds of the
+	// same tree are rare and result in the same tree.
+	tree, err := t.build(ctx, namespace, shard, blockStart, blockSize, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Lock()
+	t.trees[key] = blockMerkleTree{tree: tree, builtAt: now}
+	if now.Sub(t.lastSweep) >= t.refreshInterval {
+		t.sweepWithLock(now)
+	}
+	t.Unlock()
+
+	return tree, nil
+}
+
+func (t *blockMerkleTrees) build(
+	ctx context.Context,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+	blockSize time.Duration,
+	depth int,
+) (*repair.MerkleTree, error) {
+	builder, err := repair.NewMerkleTreeBuilder(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		end       = blockStart.Add(blockSize)
+		opts      = block.FetchBlocksMetadataOptions{IncludeChecksums: true}
+		pageToken PageToken
+	)
+	for {
+		results, nextPageToken, err := t.fetchBlocksMetadataFn(ctx, namespace,
+			shard, blockStart, end, blockMerkleTreeFetchLimit, pageToken, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results.Results() {
+			for _, b := range result.Blocks.Results() {
+				if b.Err != nil || b.Checksum == nil || !b.Start.Equal(blockStart) {
+					continue
+				}
+				builder.Add(result.ID.Bytes(), *b.Checksum)
+			}
+		}
+		results.Close()
+
+		if nextPageToken == nil {
+			return builder.Build(), nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+func (t *blockMerkleTrees) sweepWithLock(now time.Time) {
+	for key, entry := range t.trees {
+		if now.Sub(entry.builtAt) >= t.refreshInterval {
+			delete(t.trees, key)
+		}
+	}
+	t.lastSweep = now
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3x/context"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockMerkleTreesBuildsAcrossPages(t *testing.T) {
+	var (
+		blockSize  = time.Hour
+		blockStart = time.Now().Truncate(blockSize)
+		checksums  = []uint32{1, 2, 3}
+		ids        = []string{"foo", "bar", "baz"}
+		calls      int
+	)
+	fetchFn := func(
+		_ context.Context,
+		_ ident.ID,
+		_ uint32,
+		start, end time.Time,
+		_ int64,
+		pageToken PageToken,
+		_ block.FetchBlocksMetadataOptions,
+	) (block.FetchBlocksMetadataResults, PageToken, error) {
+		require.Equal(t, blockStart, start)
+		require.Equal(t, blockStart.Add(blockSize), end)
+
+		// Return one series per page.
+		idx := len(pageToken)
+		calls++
+
+		results := block.NewFetchBlockMetadataResults()
+		results.Add(block.NewFetchBlockMetadataResult(blockStart, 0,
+			&checksums[idx], time.Time{}, nil))
+		res := block.NewFetchBlocksMetadataResults()
+		res.Add(block.NewFetchBlocksMetadataResult(ident.StringID(ids[idx]),
+			nil, results))
+
+		if idx == len(ids)-1 {
+			return res, nil, nil
+		}
+		return res, append(pageToken, 0), nil
+	}
+
+	now := time.Now()
+	trees := newBlockMerkleTrees(fetchFn, func() time.Time { return now }, time.Minute)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	tree, err := trees.Tree(ctx, ident.StringID("ns"), 0, blockStart, blockSize, 4)
+	require.NoError(t, err)
+	require.Equal(t, len(ids), calls)
+
+	builder, err := repair.NewMerkleTreeBuilder(4)
+	require.NoError(t, err)
+	for i, id := range ids {
+		builder.Add([]byte(id), checksums[i])
+	}
+	require.Equal(t, builder.Build().Root(), tree.Root())
+}
+
+func TestBlockMerkleTreesRefresh(t *testing.T) {
+	var (
+		blockSize  = time.Hour
+		blockStart = time.Now().Truncate(blockSize)
+		checksum   = uint32(1)
+		calls      int
+	)
+	fetchFn := func(
+		_ context.Context,
+		_ ident.ID,
+		_ uint32,
+		_, _ time.Time,
+		_ int64,
+		_ PageToken,
+		_ block.FetchBlocksMetadataOptions,
+	) (block.FetchBlocksMetadataResults, PageToken, error) {
+		calls++
+		results := block.NewFetchBlockMetadataResults()
+		results.Add(block.NewFetchBlockMetadataResult(blockStart, 0,
+			&checksum, time.Time{}, nil))
+		res := block.NewFetchBlocksMetadataResults()
+		res.Add(block.NewFetchBlocksMetadataResult(ident.StringID("foo"),
+			nil, results))
+		return res, nil, nil
+	}
+
+	now := time.Now()
+	nowFn := func() time.Time { return now }
+	trees := newBlockMerkleTrees(fetchFn, nowFn, time.Minute)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	first, err := trees.Tree(ctx, ident.StringID("ns"), 0, blockStart, blockSize, 4)
+	require.NoError(t, err)
+
+	// Cached until the refresh interval elapses.
+	now = now.Add(30 * time.Second)
+	checksum = 2
+	cached, err := trees.Tree(ctx, ident.StringID("ns"), 0, blockStart, blockSize, 4)
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+	require.Equal(t, first.Root(), cached.Root())
+
+	now = now.Add(time.Minute)
+	refreshed, err := trees.Tree(ctx, ident.StringID("ns"), 0, blockStart, blockSize, 4)
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+	require.NotEqual(t, first.Root(), refreshed.Root())
+}
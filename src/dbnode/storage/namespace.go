@@ -29,9 +29,11 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
@@ -67,6 +69,16 @@ type commitLogWriter interface {
 	) error
 }
 
+// commitLogTombstoneWriter is implemented by commit log writers that can
+// record series deletions.
+type commitLogTombstoneWriter interface {
+	WriteTombstone(
+		ctx context.Context,
+		series commitlog.Series,
+		start, end time.Time,
+	) error
+}
+
 type commitLogWriterFn func(
 	ctx context.Context,
 	series commitlog.Series,
@@ -145,6 +157,7 @@ type databaseNamespaceMetrics struct {
 	bootstrap           instrument.MethodMetrics
 	flush               instrument.MethodMetrics
 	flushIndex          instrument.MethodMetrics
+	coldFlush           instrument.MethodMetrics
 	snapshot            instrument.MethodMetrics
 	write               instrument.MethodMetrics
 	writeTagged         instrument.MethodMetrics
@@ -152,6 +165,8 @@ type databaseNamespaceMetrics struct {
 	fetchBlocks         instrument.MethodMetrics
 	fetchBlocksMetadata instrument.MethodMetrics
 	queryIDs            instrument.MethodMetrics
+	aggregateQuery      instrument.MethodMetrics
+	deleteTagged        instrument.MethodMetrics
 	unfulfilled         tally.Counter
 	bootstrapStart      tally.Counter
 	bootstrapEnd        tally.Counter
@@ -222,6 +237,7 @@ func newDatabaseNamespaceMetrics(scope tally.Scope, samplingRate float64) databa
 		bootstrap:           instrument.NewMethodMetrics(scope, "bootstrap", samplingRate),
 		flush:               instrument.NewMethodMetrics(scope, "flush", samplingRate),
 		flushIndex:          instrument.NewMethodMetrics(scope, "flushIndex", samplingRate),
+		coldFlush:           instrument.NewMethodMetrics(scope, "coldFlush", samplingRate),
 		snapshot:            instrument.NewMethodMetrics(scope, "snapshot", samplingRate),
 		write:               instrument.NewMethodMetrics(scope, "write", overrideWriteSamplingRate),
 		writeTagged:         instrument.NewMethodMetrics(scope, "write-tagged", overrideWriteSamplingRate),
@@ -229,6 +245,8 @@ func newDatabaseNamespaceMetrics(scope tally.Scope, samplingRate float64) databa
 		fetchBlocks:         instrument.NewMethodMetrics(scope, "fetchBlocks", samplingRate),
 		fetchBlocksMetadata: instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", samplingRate),
 		queryIDs:            instrument.NewMethodMetrics(scope, "queryIDs", samplingRate),
+		aggregateQuery:      instrument.NewMethodMetrics(scope, "aggregateQuery", samplingRate),
+		deleteTagged:        instrument.NewMethodMetrics(scope, "deleteTagged", samplingRate),
 		unfulfilled:         scope.Counter("bootstrap.unfulfilled"),
 		bootstrapStart:      scope.Counter("bootstrap.start"),
 		bootstrapEnd:        scope.Counter("bootstrap.end"),
@@ -300,7 +318,16 @@ func newDatabaseNamespace(
 	tickWorkers.Init()
 
 	seriesOpts := NewSeriesOptionsFromOptions(opts, nopts.RetentionOptions()).
-		SetStats(series.NewStats(scope))
+		SetStats(series.NewStats(scope)).
+		SetColdWritesEnabled(nopts.ColdWritesEnabled())
+	if valueType := nopts.ValueType(); valueType != encoding.FloatValueType {
+		// Series of other value types are written with their own encoders.
+		encoderPool := newValueTypeEncoderPool(opts, valueType)
+		seriesOpts = seriesOpts.
+			SetEncoderPool(encoderPool).
+			SetDatabaseBlockOptions(seriesOpts.DatabaseBlockOptions().
+				SetEncoderPool(encoderPool))
+	}
 	if err := seriesOpts.Validate(); err != nil {
 		return nil, fmt.Errorf(
 			"unable to create namespace %v, invalid series options: %v",
@@ -608,6 +635,21 @@ func (n *dbNamespace) QueryIDs(
 	return res, err
 }
 
+func (n *dbNamespace) AggregateQuery(
+	ctx context.Context,
+	query index.Query,
+	opts index.AggregateQueryOptions,
+) (index.AggregateQueryResult, error) {
+	callStart := n.nowFn()
+	if n.reverseIndex == nil { // only happens if indexing is enabled.
+		n.metrics.aggregateQuery.ReportError(n.nowFn().Sub(callStart))
+		return index.AggregateQueryResult{}, errNamespaceIndexingDisabled
+	}
+	res, err := n.reverseIndex.AggregateQuery(ctx, query, opts)
+	n.metrics.aggregateQuery.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+	return res, err
+}
+
 func (n *dbNamespace) ReadEncoded(
 	ctx context.Context,
 	id ident.ID,
@@ -873,6 +915,45 @@ func (n *dbNamespace) FlushIndex(
 	return err
 }
 
+func (n *dbNamespace) ColdFlush(
+	flush persist.DataFlush,
+) error {
+	callStart := n.nowFn()
+	n.RLock()
+	if n.bootstrapState != Bootstrapped {
+		n.RUnlock()
+		n.metrics.coldFlush.ReportError(n.nowFn().Sub(callStart))
+		return errNamespaceNotBootstrapped
+	}
+	n.RUnlock()
+
+	if !n.nopts.FlushEnabled() {
+		n.metrics.coldFlush.ReportSuccess(n.nowFn().Sub(callStart))
+		return nil
+	}
+
+	multiErr := xerrors.NewMultiError()
+	shards := n.GetOwnedShards()
+	for _, shard := range shards {
+		// Shards without cold writes only need to rewrite blocks to drop the
+		// data of series deleted since the blocks were flushed.
+		if !n.nopts.ColdWritesEnabled() && !shard.HasPendingTombstones() {
+			continue
+		}
+		// Shards only cold flush blocks that have already been flushed, so
+		// there is no need to check the bootstrap state before the last tick.
+		if err := shard.ColdFlush(flush); err != nil {
+			detailedErr := fmt.Errorf("shard %d failed to cold flush data: %v",
+				shard.ID(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+	}
+
+	res := multiErr.FinalError()
+	n.metrics.coldFlush.ReportSuccessOrError(res, n.nowFn().Sub(callStart))
+	return res
+}
+
 func (n *dbNamespace) Snapshot(blockStart, snapshotTime time.Time, flush persist.DataFlush) error {
 	// NB(rartoul): This value can be used for emitting metrics, but should not be used
 	// for business logic.
@@ -1025,6 +1106,62 @@ func (n *dbNamespace) Truncate() (int64, error) {
 	return totalNumSeries, nil
 }
 
+func (n *dbNamespace) DeleteTagged(
+	ctx context.Context,
+	query index.Query,
+	start, end time.Time,
+) (int64, error) {
+	callStart := n.nowFn()
+	if n.reverseIndex == nil {
+		n.metrics.deleteTagged.ReportError(n.nowFn().Sub(callStart))
+		return 0, errNamespaceIndexingDisabled
+	}
+
+	// Series can only match the query if they were written to within retention.
+	queryStart := retention.FlushTimeStart(n.nopts.RetentionOptions(), callStart)
+	if start.After(queryStart) {
+		queryStart = start
+	}
+	res, err := n.reverseIndex.Query(ctx, query, index.QueryOptions{
+		StartInclusive: queryStart,
+		EndExclusive:   end,
+	})
+	if err != nil {
+		n.metrics.deleteTagged.ReportError(n.nowFn().Sub(callStart))
+		return 0, err
+	}
+
+	var (
+		numSeries int64
+		multiErr  = xerrors.NewMultiError()
+		byShard   = make(map[databaseShard][]ident.ID)
+	)
+	for _, entry := range res.Results.Map().Iter() {
+		id := entry.Key()
+		shard, err := n.shardFor(id)
+		if err != nil {
+			multiErr = multiErr.Add(fmt.Errorf(
+				"unable to delete series %s: %v", id.String(), err))
+			continue
+		}
+		byShard[shard] = append(byShard[shard], id)
+	}
+
+	// Delete the series of each shard in a single batch so that the shard
+	// tombstones file is only written once per shard.
+	for shard, ids := range byShard {
+		deleted, err := shard.DeleteSeries(ctx, ids, start, end)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+		}
+		numSeries += int64(deleted)
+	}
+
+	err = multiErr.FinalError()
+	n.metrics.deleteTagged.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+	return numSeries, err
+}
+
 func (n *dbNamespace) Repair(
 	repairer databaseShardRepairer,
 	tr xtime.Range,
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3x/ident"
 )
@@ -54,6 +55,8 @@ type MetadataConfiguration struct {
 	WritesToCommitLog *bool                   `yaml:"writesToCommitLog"`
 	CleanupEnabled    *bool                   `yaml:"cleanupEnabled"`
 	RepairEnabled     *bool                   `yaml:"repairEnabled"`
+	ColdWritesEnabled *bool                   `yaml:"coldWritesEnabled"`
+	ValueType         *encoding.ValueType     `yaml:"valueType"`
 	Retention         retention.Configuration `yaml:"retention" validate:"nonzero"`
 	Index             IndexConfiguration      `yaml:"index"`
 }
@@ -80,6 +83,12 @@ func (mc *MetadataConfiguration) Metadata() (Metadata, error) {
 	if v := mc.RepairEnabled; v != nil {
 		opts = opts.SetRepairEnabled(*v)
 	}
+	if v := mc.ColdWritesEnabled; v != nil {
+		opts = opts.SetColdWritesEnabled(*v)
+	}
+	if v := mc.ValueType; v != nil {
+		opts = opts.SetValueType(*v)
+	}
 	return NewMetadata(ident.StringID(mc.ID), opts)
 }
 
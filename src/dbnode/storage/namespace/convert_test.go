@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
@@ -217,6 +218,58 @@ func TestFromProtoSnapshotEnabled(t *testing.T) {
 	assert.Equal(t, !namespace.NewOptions().SnapshotEnabled(), md.Options().SnapshotEnabled())
 }
 
+func TestColdWritesEnabledRoundTrip(t *testing.T) {
+	md, err := namespace.NewMetadata(
+		ident.StringID("ns1"),
+		namespace.NewOptions().
+			// Don't use default value
+			SetColdWritesEnabled(!namespace.NewOptions().ColdWritesEnabled()),
+	)
+	require.NoError(t, err)
+	nsMap, err := namespace.NewMap([]namespace.Metadata{md})
+	require.NoError(t, err)
+
+	reg := namespace.ToProto(nsMap)
+	require.Len(t, reg.Namespaces, 1)
+	assert.Equal(t,
+		!namespace.NewOptions().ColdWritesEnabled(),
+		reg.Namespaces["ns1"].ColdWritesEnabled,
+	)
+
+	nsMap, err = namespace.FromProto(*reg)
+	require.NoError(t, err)
+	md, err = nsMap.Get(ident.StringID("ns1"))
+	require.NoError(t, err)
+	assert.Equal(t, !namespace.NewOptions().ColdWritesEnabled(), md.Options().ColdWritesEnabled())
+}
+
+func TestValueTypeRoundTrip(t *testing.T) {
+	md, err := namespace.NewMetadata(
+		ident.StringID("ns1"),
+		namespace.NewOptions().SetValueType(encoding.HistogramValueType),
+	)
+	require.NoError(t, err)
+	nsMap, err := namespace.NewMap([]namespace.Metadata{md})
+	require.NoError(t, err)
+
+	reg := namespace.ToProto(nsMap)
+	require.Len(t, reg.Namespaces, 1)
+	assert.Equal(t, nsproto.ValueType_HISTOGRAM, reg.Namespaces["ns1"].ValueType)
+
+	nsMap, err = namespace.FromProto(*reg)
+	require.NoError(t, err)
+	md, err = nsMap.Get(ident.StringID("ns1"))
+	require.NoError(t, err)
+	assert.Equal(t, encoding.HistogramValueType, md.Options().ValueType())
+}
+
+func TestValueTypeInvalid(t *testing.T) {
+	opts := validNamespaceOpts[0]
+	opts.ValueType = nsproto.ValueType(100)
+	_, err := namespace.ToMetadata("ns1", &opts)
+	require.Error(t, err)
+}
+
 func assertEqualMetadata(t *testing.T, name string, expected nsproto.NamespaceOptions, observed namespace.Metadata) {
 	require.Equal(t, name, observed.ID().String())
 	opts := observed.Options()
@@ -226,6 +279,8 @@ func assertEqualMetadata(t *testing.T, name string, expected nsproto.NamespaceOp
 	require.Equal(t, expected.WritesToCommitLog, opts.WritesToCommitLog())
 	require.Equal(t, expected.CleanupEnabled, opts.CleanupEnabled())
 	require.Equal(t, expected.RepairEnabled, opts.RepairEnabled())
+	require.Equal(t, expected.ColdWritesEnabled, opts.ColdWritesEnabled())
+	require.Equal(t, encoding.ValueType(expected.ValueType), opts.ValueType())
 
 	assertEqualRetentions(t, *expected.RetentionOptions, opts.RetentionOptions())
 }
@@ -23,6 +23,7 @@ package namespace
 import (
 	"errors"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/retention"
 )
 
@@ -44,6 +45,12 @@ const (
 
 	// Namespace requires repair disabled by default
 	defaultRepairEnabled = false
+
+	// Namespace rejects writes outside of the buffer past and future by default
+	defaultColdWritesEnabled = false
+
+	// Namespace series are floats by default
+	defaultValueType = encoding.FloatValueType
 )
 
 var (
@@ -59,6 +66,8 @@ type options struct {
 	writesToCommitLog bool
 	cleanupEnabled    bool
 	repairEnabled     bool
+	coldWritesEnabled bool
+	valueType         encoding.ValueType
 	retentionOpts     retention.Options
 	indexOpts         IndexOptions
 }
@@ -72,6 +81,8 @@ func NewOptions() Options {
 		writesToCommitLog: defaultWritesToCommitLog,
 		cleanupEnabled:    defaultCleanupEnabled,
 		repairEnabled:     defaultRepairEnabled,
+		coldWritesEnabled: defaultColdWritesEnabled,
+		valueType:         defaultValueType,
 		retentionOpts:     retention.NewOptions(),
 		indexOpts:         NewIndexOptions(),
 	}
@@ -81,6 +92,9 @@ func (o *options) Validate() error {
 	if err := o.retentionOpts.Validate(); err != nil {
 		return err
 	}
+	if err := o.valueType.Validate(); err != nil {
+		return err
+	}
 	if !o.indexOpts.Enabled() {
 		return nil
 	}
@@ -108,6 +122,8 @@ func (o *options) Equal(value Options) bool {
 		o.snapshotEnabled == value.SnapshotEnabled() &&
 		o.cleanupEnabled == value.CleanupEnabled() &&
 		o.repairEnabled == value.RepairEnabled() &&
+		o.coldWritesEnabled == value.ColdWritesEnabled() &&
+		o.valueType == value.ValueType() &&
 		o.retentionOpts.Equal(value.RetentionOptions()) &&
 		o.indexOpts.Equal(value.IndexOptions())
 }
@@ -172,6 +188,26 @@ func (o *options) RepairEnabled() bool {
 	return o.repairEnabled
 }
 
+func (o *options) SetColdWritesEnabled(value bool) Options {
+	opts := *o
+	opts.coldWritesEnabled = value
+	return &opts
+}
+
+func (o *options) ColdWritesEnabled() bool {
+	return o.coldWritesEnabled
+}
+
+func (o *options) SetValueType(value encoding.ValueType) Options {
+	opts := *o
+	opts.valueType = value
+	return &opts
+}
+
+func (o *options) ValueType() encoding.ValueType {
+	return o.valueType
+}
+
 func (o *options) SetRetentionOptions(value retention.Options) Options {
 	opts := *o
 	opts.retentionOpts = value
@@ -25,6 +25,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/retention"
 
 	"github.com/golang/mock/gomock"
@@ -164,3 +165,12 @@ func TestOptionsValidateNoIndexing(t *testing.T) {
 	rOpts.EXPECT().Validate().Return(nil)
 	require.NoError(t, o1.Validate())
 }
+
+func TestOptionsValidateValueType(t *testing.T) {
+	o1 := NewOptions().SetValueType(encoding.CounterValueType)
+	require.NoError(t, o1.Validate())
+	require.False(t, o1.Equal(NewOptions()))
+
+	o2 := NewOptions().SetValueType(encoding.ValueType(100))
+	require.Error(t, o2.Validate())
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/instrument"
@@ -73,6 +74,22 @@ type Options interface {
 	// RepairEnabled returns whether the data for this namespace needs to be repaired
 	RepairEnabled() bool
 
+	// SetColdWritesEnabled sets whether writes older than buffer past are accepted into
+	// cold buffers and later merged into the flushed fileset for their block
+	SetColdWritesEnabled(value bool) Options
+
+	// ColdWritesEnabled returns whether writes older than buffer past are accepted into
+	// cold buffers and later merged into the flushed fileset for their block
+	ColdWritesEnabled() bool
+
+	// SetValueType sets the value type of the series in this namespace, which selects
+	// the encoding used for their data
+	SetValueType(value encoding.ValueType) Options
+
+	// ValueType returns the value type of the series in this namespace, which selects
+	// the encoding used for their data
+	ValueType() encoding.ValueType
+
 	// SetRetentionOptions sets the retention options for this namespace
 	SetRetentionOptions(value retention.Options) Options
 
@@ -83,6 +83,13 @@ type fsFileSetExistsAtFn func(
 	blockStart time.Time,
 ) (bool, error)
 
+type fsFileSetAtFn func(
+	prefix string,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+) (fs.FileSetFile, bool, error)
+
 type fsNewReaderFn func(
 	bytesPool pool.CheckedBytesPool,
 	opts fs.Options,
@@ -92,6 +99,7 @@ type namespaceReaderManager struct {
 	sync.Mutex
 
 	filesetExistsAtFn fsFileSetExistsAtFn
+	filesetAtFn       fsFileSetAtFn
 	newReaderFn       fsNewReaderFn
 
 	namespace namespace.Metadata
@@ -150,6 +158,7 @@ func newNamespaceReaderManager(
 ) databaseNamespaceReaderManager {
 	return &namespaceReaderManager{
 		filesetExistsAtFn: fs.DataFileSetExistsAt,
+		filesetAtFn:       fs.FileSetAt,
 		newReaderFn:       fs.NewReader,
 		namespace:         namespace,
 		fsOpts:            opts.CommitLogOptions().FilesystemOptions(),
@@ -251,11 +260,22 @@ func (m *namespaceReaderManager) get(
 	// We have a closed reader from the cache (either a cached closed
 	// reader or newly allocated, either way need to prepare it)
 	reader := lookup.closedReader
+	fileset, ok, err := m.filesetAtFn(m.fsOpts.FilePathPrefix(),
+		m.namespace.ID(), shard, blockStart)
+	if err != nil {
+		return nil, err
+	}
+	var volumeIndex int
+	if ok {
+		// Read the latest volume in case cold writes have been merged into the block.
+		volumeIndex = fileset.ID.VolumeIndex
+	}
 	openOpts := fs.DataReaderOpenOptions{
 		Identifier: fs.FileSetFileIdentifier{
-			Namespace:  m.namespace.ID(),
-			Shard:      shard,
-			BlockStart: blockStart,
+			Namespace:   m.namespace.ID(),
+			Shard:       shard,
+			BlockStart:  blockStart,
+			VolumeIndex: volumeIndex,
 		},
 	}
 	if err := reader.Open(openOpts); err != nil {
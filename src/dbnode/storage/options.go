@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/encoding/valuetype"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/retention"
@@ -411,14 +412,14 @@ func (o *options) SetEncodingM3TSZPooled() Options {
 
 	// initialize single reader iterator pool
 	readerIteratorPool.Init(func(r io.Reader) encoding.ReaderIterator {
-		return m3tsz.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
+		return valuetype.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
 	})
 	opts.readerIteratorPool = readerIteratorPool
 
 	// initialize multi reader iterator pool
 	multiReaderIteratorPool := encoding.NewMultiReaderIteratorPool(opts.poolOpts)
 	multiReaderIteratorPool.Init(func(r io.Reader) encoding.ReaderIterator {
-		return m3tsz.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
+		return valuetype.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
 	})
 	opts.multiReaderIteratorPool = multiReaderIteratorPool
 
@@ -432,6 +433,17 @@ func (o *options) SetEncodingM3TSZPooled() Options {
 	return &opts
 }
 
+// newValueTypeEncoderPool returns a pool of encoders for series of the given
+// value type, the encoders share the other pools of the database.
+func newValueTypeEncoderPool(opts Options, valueType encoding.ValueType) encoding.EncoderPool {
+	return valuetype.NewEncoderPool(valueType,
+		pool.NewObjectPoolOptions().SetInstrumentOptions(opts.InstrumentOptions()),
+		encoding.NewOptions().
+			SetBytesPool(opts.BytesPool()).
+			SetReaderIteratorPool(opts.ReaderIteratorPool()).
+			SetSegmentReaderPool(opts.SegmentReaderPool()))
+}
+
 func (o *options) SetNewEncoderFn(value encoding.NewEncoderFn) Options {
 	opts := *o
 	opts.newEncoderFn = value
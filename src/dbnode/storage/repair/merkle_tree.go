@@ -0,0 +1,191 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package repair
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+
+	"github.com/spaolacci/murmur3"
+)
+
+const (
+	// MaxMerkleTreeDepth is the max depth of a Merkle tree, a tree of the
+	// max depth has 2^MaxMerkleTreeDepth leaves.
+	MaxMerkleTreeDepth = 20
+)
+
+var (
+	errMerkleTreeNodesMismatch = errors.New("merkle tree nodes returned do not match the nodes requested")
+)
+
+// MerkleTreeLeafIndex returns the index of the leaf a series is assigned to
+// in a Merkle tree of the given depth.
+func MerkleTreeLeafIndex(id []byte, depth int) int {
+	if depth == 0 {
+		return 0
+	}
+	return int(murmur3.Sum32(id) >> uint(32-depth))
+}
+
+// MerkleTreeBuilder builds a Merkle tree from the checksums of the series
+// in a block.
+type MerkleTreeBuilder struct {
+	depth  int
+	leaves []uint32
+}
+
+// NewMerkleTreeBuilder returns a new Merkle tree builder for a tree of the
+// given depth.
+func NewMerkleTreeBuilder(depth int) (*MerkleTreeBuilder, error) {
+	if err := validateMerkleTreeDepth(depth); err != nil {
+		return nil, err
+	}
+	return &MerkleTreeBuilder{
+		depth:  depth,
+		leaves: make([]uint32, 1<<uint(depth)),
+	}, nil
+}
+
+// Add adds the checksum of a series' block to the tree.
+func (b *MerkleTreeBuilder) Add(id []byte, checksum uint32) {
+	// NB(r): Sum the hashes of the series in a leaf so that the leaf hash
+	// does not depend on the order the series are added in.
+	idx := MerkleTreeLeafIndex(id, b.depth)
+	b.leaves[idx] += murmur3.Sum32WithSeed(id, checksum)
+}
+
+// Build builds the tree from the checksums added.
+func (b *MerkleTreeBuilder) Build() *MerkleTree {
+	var (
+		numLeaves = len(b.leaves)
+		first     = numLeaves - 1
+		nodes     = make([]uint32, first+numLeaves)
+		buf       [8]byte
+	)
+	copy(nodes[first:], b.leaves)
+	for i := first - 1; i >= 0; i-- {
+		left, right := nodes[2*i+1], nodes[2*i+2]
+		if left == 0 && right == 0 {
+			continue
+		}
+		binary.BigEndian.PutUint32(buf[:4], left)
+		binary.BigEndian.PutUint32(buf[4:], right)
+		nodes[i] = digest.Checksum(buf[:])
+	}
+	return &MerkleTree{depth: b.depth, nodes: nodes}
+}
+
+// MerkleTree is a fixed depth binary hash tree over the checksums of the
+// series in a block of a shard. Series are assigned to leaves by the hash of
+// their ID, so replicas holding the same series with the same data build
+// identical trees and the subtrees that differ narrow down which series
+// have diverged.
+type MerkleTree struct {
+	depth int
+
+	// nodes holds the hashes of the tree in level order, the children of
+	// the node at i are at 2i+1 and 2i+2.
+	nodes []uint32
+}
+
+// Depth returns the depth of the tree.
+func (t *MerkleTree) Depth() int {
+	return t.depth
+}
+
+// Root returns the hash of the root of the tree.
+func (t *MerkleTree) Root() uint32 {
+	return t.nodes[0]
+}
+
+// Nodes returns the hashes of the nodes at the indexes of a level of the
+// tree, the root is at level zero and the leaves are at level depth.
+func (t *MerkleTree) Nodes(level int, indexes []int) ([]uint32, error) {
+	if level < 0 || level > t.depth {
+		return nil, fmt.Errorf("merkle tree level %d out of range for depth %d",
+			level, t.depth)
+	}
+
+	var (
+		width  = 1 << uint(level)
+		offset = width - 1
+		hashes = make([]uint32, 0, len(indexes))
+	)
+	for _, idx := range indexes {
+		if idx < 0 || idx >= width {
+			return nil, fmt.Errorf("merkle tree index %d out of range for level %d",
+				idx, level)
+		}
+		hashes = append(hashes, t.nodes[offset+idx])
+	}
+	return hashes, nil
+}
+
+// MerkleTreeNodesFn returns the hashes of the nodes at the indexes of a
+// level of another replica's tree.
+type MerkleTreeNodesFn func(level int, indexes []int) ([]uint32, error)
+
+// DivergentLeaves compares the tree with another replica's tree of the same
+// depth, descending only into the subtrees whose hashes differ, and returns
+// the indexes of the leaves that differ.
+func (t *MerkleTree) DivergentLeaves(fn MerkleTreeNodesFn) ([]int, error) {
+	indexes := []int{0}
+	for level := 0; ; level++ {
+		remote, err := fn(level, indexes)
+		if err != nil {
+			return nil, err
+		}
+		if len(remote) != len(indexes) {
+			return nil, errMerkleTreeNodesMismatch
+		}
+		local, err := t.Nodes(level, indexes)
+		if err != nil {
+			return nil, err
+		}
+
+		var diverged []int
+		for i := range indexes {
+			if local[i] != remote[i] {
+				diverged = append(diverged, indexes[i])
+			}
+		}
+		if len(diverged) == 0 || level == t.depth {
+			return diverged, nil
+		}
+
+		indexes = make([]int, 0, 2*len(diverged))
+		for _, idx := range diverged {
+			indexes = append(indexes, 2*idx, 2*idx+1)
+		}
+	}
+}
+
+func validateMerkleTreeDepth(depth int) error {
+	if depth < 0 || depth > MaxMerkleTreeDepth {
+		return fmt.Errorf("merkle tree depth %d must be between 0 and %d",
+			depth, MaxMerkleTreeDepth)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package repair
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSeriesChecksum struct {
+	id       string
+	checksum uint32
+}
+
+func testMerkleTree(
+	t *testing.T,
+	depth int,
+	series []testSeriesChecksum,
+) *MerkleTree {
+	b, err := NewMerkleTreeBuilder(depth)
+	require.NoError(t, err)
+	for _, s := range series {
+		b.Add([]byte(s.id), s.checksum)
+	}
+	return b.Build()
+}
+
+func testSeriesChecksums(n int) []testSeriesChecksum {
+	series := make([]testSeriesChecksum, 0, n)
+	for i := 0; i < n; i++ {
+		series = append(series, testSeriesChecksum{
+			id:       fmt.Sprintf("series.%d", i),
+			checksum: uint32(i),
+		})
+	}
+	return series
+}
+
+func TestMerkleTreeBuilderInvalidDepth(t *testing.T) {
+	_, err := NewMerkleTreeBuilder(-1)
+	assert.Error(t, err)
+
+	_, err = NewMerkleTreeBuilder(MaxMerkleTreeDepth + 1)
+	assert.Error(t, err)
+}
+
+func TestMerkleTreeOrderIndependent(t *testing.T) {
+	series := testSeriesChecksums(100)
+	reversed := make([]testSeriesChecksum, 0, len(series))
+	for i := len(series) - 1; i >= 0; i-- {
+		reversed = append(reversed, series[i])
+	}
+
+	tree := testMerkleTree(t, 4, series)
+	other := testMerkleTree(t, 4, reversed)
+	assert.Equal(t, tree.nodes, other.nodes)
+	assert.NotEqual(t, uint32(0), tree.Root())
+}
+
+func TestMerkleTreeNodes(t *testing.T) {
+	tree := testMerkleTree(t, 2, testSeriesChecksums(10))
+	assert.Equal(t, 2, tree.Depth())
+
+	root, err := tree.Nodes(0, []int{0})
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{tree.Root()}, root)
+
+	leaves, err := tree.Nodes(2, []int{0, 1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, tree.nodes[3:], leaves)
+
+	_, err = tree.Nodes(3, []int{0})
+	assert.Error(t, err)
+
+	_, err = tree.Nodes(1, []int{2})
+	assert.Error(t, err)
+}
+
+func TestMerkleTreeDivergentLeaves(t *testing.T) {
+	var (
+		depth    = 6
+		series   = testSeriesChecksums(200)
+		diverged = append([]testSeriesChecksum(nil), series...)
+	)
+	// Change the checksum of one series and drop another.
+	diverged[10].checksum++
+	diverged = append(diverged[:50], diverged[51:]...)
+
+	var (
+		tree   = testMerkleTree(t, depth, series)
+		other  = testMerkleTree(t, depth, diverged)
+		levels []int
+	)
+	leaves, err := tree.DivergentLeaves(func(level int, indexes []int) ([]uint32, error) {
+		levels = append(levels, level)
+		return other.Nodes(level, indexes)
+	})
+	require.NoError(t, err)
+
+	expected := map[int]struct{}{
+		MerkleTreeLeafIndex([]byte(series[10].id), depth): struct{}{},
+		MerkleTreeLeafIndex([]byte(series[50].id), depth): struct{}{},
+	}
+	assert.Equal(t, len(expected), len(leaves))
+	for _, leaf := range leaves {
+		_, ok := expected[leaf]
+		assert.True(t, ok)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6}, levels)
+}
+
+func TestMerkleTreeDivergentLeavesInSync(t *testing.T) {
+	series := testSeriesChecksums(50)
+	tree := testMerkleTree(t, 8, series)
+	other := testMerkleTree(t, 8, series)
+
+	calls := 0
+	leaves, err := tree.DivergentLeaves(func(level int, indexes []int) ([]uint32, error) {
+		calls++
+		return other.Nodes(level, indexes)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(leaves))
+	assert.Equal(t, 1, calls)
+}
+
+func TestMerkleTreeDivergentLeavesMismatchedNodes(t *testing.T) {
+	tree := testMerkleTree(t, 2, testSeriesChecksums(10))
+	_, err := tree.DivergentLeaves(func(level int, indexes []int) ([]uint32, error) {
+		return nil, nil
+	})
+	assert.Equal(t, errMerkleTreeNodesMismatch, err)
+}
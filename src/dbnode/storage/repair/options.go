@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/ratelimit"
 	"github.com/m3db/m3/src/dbnode/topology"
 )
 
@@ -37,6 +38,8 @@ const (
 	defaultRepairThrottle         = 90 * time.Second
 	defaultRepairMaxRetries       = 3
 	defaultRepairShardConcurrency = 1
+	defaultMerkleTreeDepth        = 10
+	defaultMerkleTreeRefresh      = 10 * time.Minute
 )
 
 var (
@@ -50,6 +53,8 @@ var (
 	errInvalidRepairThrottle        = errors.New("invalid repair throttle in repair options")
 	errInvalidRepairMaxRetries      = errors.New("invalid repair max retries in repair options")
 	errNoHostBlockMetadataSlicePool = errors.New("no host block metadata pool in repair options")
+	errNoAntiEntropyRateLimitOpts   = errors.New("no anti-entropy rate limit options in repair options")
+	errInvalidMerkleTreeRefresh     = errors.New("invalid merkle tree refresh interval in repair options")
 )
 
 type options struct {
@@ -63,6 +68,10 @@ type options struct {
 	repairThrottle             time.Duration
 	repairMaxRetries           int
 	hostBlockMetadataSlicePool HostBlockMetadataSlicePool
+	antiEntropyEnabled         bool
+	antiEntropyRateLimitOpts   ratelimit.Options
+	merkleTreeDepth            int
+	merkleTreeRefreshInterval  time.Duration
 }
 
 // NewOptions creates new bootstrap options
@@ -77,6 +86,9 @@ func NewOptions() Options {
 		repairThrottle:             defaultRepairThrottle,
 		repairMaxRetries:           defaultRepairMaxRetries,
 		hostBlockMetadataSlicePool: NewHostBlockMetadataSlicePool(nil, 0),
+		antiEntropyRateLimitOpts:   ratelimit.NewOptions(),
+		merkleTreeDepth:            defaultMerkleTreeDepth,
+		merkleTreeRefreshInterval:  defaultMerkleTreeRefresh,
 	}
 }
 
@@ -180,6 +192,46 @@ func (o *options) HostBlockMetadataSlicePool() HostBlockMetadataSlicePool {
 	return o.hostBlockMetadataSlicePool
 }
 
+func (o *options) SetAntiEntropyEnabled(value bool) Options {
+	opts := *o
+	opts.antiEntropyEnabled = value
+	return &opts
+}
+
+func (o *options) AntiEntropyEnabled() bool {
+	return o.antiEntropyEnabled
+}
+
+func (o *options) SetAntiEntropyRateLimitOptions(value ratelimit.Options) Options {
+	opts := *o
+	opts.antiEntropyRateLimitOpts = value
+	return &opts
+}
+
+func (o *options) AntiEntropyRateLimitOptions() ratelimit.Options {
+	return o.antiEntropyRateLimitOpts
+}
+
+func (o *options) SetMerkleTreeDepth(value int) Options {
+	opts := *o
+	opts.merkleTreeDepth = value
+	return &opts
+}
+
+func (o *options) MerkleTreeDepth() int {
+	return o.merkleTreeDepth
+}
+
+func (o *options) SetMerkleTreeRefreshInterval(value time.Duration) Options {
+	opts := *o
+	opts.merkleTreeRefreshInterval = value
+	return &opts
+}
+
+func (o *options) MerkleTreeRefreshInterval() time.Duration {
+	return o.merkleTreeRefreshInterval
+}
+
 func (o *options) Validate() error {
 	if o.adminClient == nil {
 		return errNoAdminClient
@@ -211,5 +263,14 @@ func (o *options) Validate() error {
 	if o.hostBlockMetadataSlicePool == nil {
 		return errNoHostBlockMetadataSlicePool
 	}
+	if o.antiEntropyRateLimitOpts == nil {
+		return errNoAntiEntropyRateLimitOpts
+	}
+	if err := validateMerkleTreeDepth(o.merkleTreeDepth); err != nil {
+		return err
+	}
+	if o.merkleTreeRefreshInterval <= 0 {
+		return errInvalidMerkleTreeRefresh
+	}
 	return nil
 }
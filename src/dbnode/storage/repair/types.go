@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/ratelimit"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3x/ident"
@@ -206,6 +207,37 @@ type Options interface {
 	// MaxRepairRetries returns the max number of retries for a block start
 	RepairMaxRetries() int
 
+	// SetAntiEntropyEnabled sets whether continuous anti-entropy repair is
+	// enabled, when enabled it replaces the scheduled repair
+	SetAntiEntropyEnabled(value bool) Options
+
+	// AntiEntropyEnabled returns whether continuous anti-entropy repair is enabled
+	AntiEntropyEnabled() bool
+
+	// SetAntiEntropyRateLimitOptions sets the rate limit options for the
+	// blocks streamed from peers by anti-entropy repair
+	SetAntiEntropyRateLimitOptions(value ratelimit.Options) Options
+
+	// AntiEntropyRateLimitOptions returns the rate limit options for the
+	// blocks streamed from peers by anti-entropy repair
+	AntiEntropyRateLimitOptions() ratelimit.Options
+
+	// SetMerkleTreeDepth sets the depth of the Merkle trees built over the
+	// series checksums of each block of a shard
+	SetMerkleTreeDepth(value int) Options
+
+	// MerkleTreeDepth returns the depth of the Merkle trees built over the
+	// series checksums of each block of a shard
+	MerkleTreeDepth() int
+
+	// SetMerkleTreeRefreshInterval sets the interval after which the Merkle
+	// tree of a block is rebuilt
+	SetMerkleTreeRefreshInterval(value time.Duration) Options
+
+	// MerkleTreeRefreshInterval returns the interval after which the Merkle
+	// tree of a block is rebuilt
+	MerkleTreeRefreshInterval() time.Duration
+
 	// SetHostBlockMetadataSlicePool sets the hostBlockMetadataSlice pool
 	SetHostBlockMetadataSlicePool(value HostBlockMetadataSlicePool) Options
 
@@ -0,0 +1,617 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/ratelimit"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/context"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+	xlog "github.com/m3db/m3x/log"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/uber-go/tally"
+)
+
+const (
+	// antiEntropyFetchLimit is the number of series fetched per page of local
+	// metadata when narrowing divergent leaves down to series.
+	antiEntropyFetchLimit = 4096
+
+	antiEntropyBytesPerMegabit = 1024 * 1024 / 8
+)
+
+type antiEntropyNamespaceMetrics struct {
+	blocksCompared   tally.Counter
+	blocksDiverged   tally.Counter
+	leavesDiverged   tally.Counter
+	seriesRepaired   tally.Counter
+	blocksStreamed   tally.Counter
+	bytesStreamed    tally.Counter
+	datapointsMerged tally.Counter
+	mergesSkipped    tally.Counter
+	errors           tally.Counter
+}
+
+func newAntiEntropyNamespaceMetrics(
+	scope tally.Scope,
+	namespace ident.ID,
+) antiEntropyNamespaceMetrics {
+	scope = scope.Tagged(map[string]string{"namespace": namespace.String()})
+	return antiEntropyNamespaceMetrics{
+		blocksCompared:   scope.Counter("blocks-compared"),
+		blocksDiverged:   scope.Counter("blocks-diverged"),
+		leavesDiverged:   scope.Counter("leaves-diverged"),
+		seriesRepaired:   scope.Counter("series-repaired"),
+		blocksStreamed:   scope.Counter("blocks-streamed"),
+		bytesStreamed:    scope.Counter("bytes-streamed"),
+		datapointsMerged: scope.Counter("datapoints-merged"),
+		mergesSkipped:    scope.Counter("merges-skipped"),
+		errors:           scope.Counter("errors"),
+	}
+}
+
+// antiEntropyRepairer continuously repairs the blocks of the shards owned by
+// the database. For every block it compares the Merkle tree over the series
+// checksums of the block with those of its peers, descending only into the
+// subtrees whose hashes differ, and then streams and merges the blocks of
+// just the series that fall under the divergent leaves.
+//
+// NB: Like the dbRepairer, Repair(...) guarantees atomicity of execution so
+// only the closed flag needs to be guarded.
+type antiEntropyRepairer struct {
+	database database
+	opts     Options
+	ropts    repair.Options
+	client   client.AdminClient
+
+	repairFn      repairFn
+	sleepFn       sleepFn
+	nowFn         clock.NowFn
+	logger        xlog.Logger
+	scope         tally.Scope
+	checkInterval time.Duration
+	depth         int
+	level         topology.ReadConsistencyLevel
+	rateLimitOpts ratelimit.Options
+	status        tally.Gauge
+	metrics       map[string]antiEntropyNamespaceMetrics
+
+	start         time.Time
+	count         int
+	bytesStreamed int64
+
+	closedLock sync.Mutex
+	running    int32
+	closed     bool
+}
+
+func newAntiEntropyRepairer(database database, opts Options) (databaseRepairer, error) {
+	ropts := opts.RepairOptions()
+	if ropts == nil {
+		return nil, errNoRepairOptions
+	}
+	if err := ropts.Validate(); err != nil {
+		return nil, err
+	}
+
+	iopts := opts.InstrumentOptions()
+	scope := iopts.MetricsScope()
+	r := &antiEntropyRepairer{
+		database:      database,
+		opts:          opts,
+		ropts:         ropts,
+		client:        ropts.AdminClient(),
+		sleepFn:       time.Sleep,
+		nowFn:         opts.ClockOptions().NowFn(),
+		logger:        iopts.Logger(),
+		scope:         scope.SubScope("repair").SubScope("anti-entropy"),
+		checkInterval: ropts.RepairCheckInterval(),
+		depth:         ropts.MerkleTreeDepth(),
+		level:         ropts.RepairConsistencyLevel(),
+		rateLimitOpts: ropts.AntiEntropyRateLimitOptions(),
+		status:        scope.Gauge("repair"),
+		metrics:       make(map[string]antiEntropyNamespaceMetrics),
+	}
+	r.repairFn = r.Repair
+
+	return r, nil
+}
+
+func (r *antiEntropyRepairer) run() {
+	for {
+		r.closedLock.Lock()
+		closed := r.closed
+		r.closedLock.Unlock()
+
+		if closed {
+			break
+		}
+
+		r.sleepFn(r.checkInterval)
+
+		if err := r.repairFn(); err != nil {
+			r.logger.Errorf("error running anti-entropy repair: %v", err)
+		}
+	}
+}
+
+func (r *antiEntropyRepairer) isClosed() bool {
+	r.closedLock.Lock()
+	closed := r.closed
+	r.closedLock.Unlock()
+	return closed
+}
+
+func (r *antiEntropyRepairer) Start() {
+	go r.run()
+}
+
+func (r *antiEntropyRepairer) Stop() {
+	r.closedLock.Lock()
+	r.closed = true
+	r.closedLock.Unlock()
+}
+
+func (r *antiEntropyRepairer) Repair() error {
+	// Don't attempt a repair if the database is not bootstrapped yet
+	if !r.database.IsBootstrapped() {
+		return nil
+	}
+
+	if !atomic.CompareAndSwapInt32(&r.running, 0, 1) {
+		return errRepairInProgress
+	}
+
+	defer func() {
+		atomic.StoreInt32(&r.running, 0)
+	}()
+
+	session, err := r.client.DefaultAdminSession()
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := r.database.GetOwnedNamespaces()
+	if err != nil {
+		return err
+	}
+
+	// Reset the rate limiter at the start of every pass so that the time
+	// spent sleeping between passes does not count towards the budget.
+	r.start, r.count, r.bytesStreamed = time.Time{}, 0, 0
+
+	multiErr := xerrors.NewMultiError()
+	for _, n := range namespaces {
+		if !n.Options().RepairEnabled() {
+			continue
+		}
+
+		var (
+			now       = r.nowFn()
+			rtopts    = n.Options().RetentionOptions()
+			blockSize = rtopts.BlockSize()
+			start     = now.Add(-rtopts.RetentionPeriod()).Truncate(blockSize)
+			end       = now.Add(-rtopts.BufferPast()).Truncate(blockSize)
+			metrics   = r.namespaceMetrics(n.ID())
+		)
+		for _, s := range n.GetOwnedShards() {
+			for blockStart := start; blockStart.Before(end); blockStart = blockStart.Add(blockSize) {
+				if r.isClosed() {
+					return multiErr.FinalError()
+				}
+
+				err := r.repairBlock(session, n, s.ID(), blockStart, metrics)
+				if err != nil {
+					metrics.errors.Inc(1)
+					multiErr = multiErr.Add(fmt.Errorf(
+						"namespace %s shard %d failed to repair block %v: %v",
+						n.ID().String(), s.ID(), blockStart, err))
+				}
+			}
+		}
+	}
+
+	return multiErr.FinalError()
+}
+
+func (r *antiEntropyRepairer) Report() {
+	if atomic.LoadInt32(&r.running) == 1 {
+		r.status.Update(1)
+	} else {
+		r.status.Update(0)
+	}
+}
+
+func (r *antiEntropyRepairer) namespaceMetrics(
+	namespace ident.ID,
+) antiEntropyNamespaceMetrics {
+	metrics, ok := r.metrics[namespace.String()]
+	if !ok {
+		metrics = newAntiEntropyNamespaceMetrics(r.scope, namespace)
+		r.metrics[namespace.String()] = metrics
+	}
+	return metrics
+}
+
+func (r *antiEntropyRepairer) repairBlock(
+	session client.AdminSession,
+	n databaseNamespace,
+	shardID uint32,
+	blockStart time.Time,
+	metrics antiEntropyNamespaceMetrics,
+) error {
+	ctx := r.opts.ContextPool().Get()
+	defer ctx.Close()
+
+	local, err := r.database.BlockMerkleTree(ctx, n.ID(), shardID, blockStart, r.depth)
+	if err != nil {
+		return err
+	}
+
+	peers, err := r.peers(session, shardID)
+	if err != nil {
+		return err
+	}
+
+	var (
+		multiErr  = xerrors.NewMultiError()
+		divergent = make(map[string]map[int]struct{}, len(peers))
+		anyLeaves = make(map[int]struct{})
+		numLeaves int
+		depth     = r.depth
+	)
+	for _, peer := range peers {
+		peer := peer
+		leaves, err := local.DivergentLeaves(func(level int, indexes []int) ([]uint32, error) {
+			return session.FetchBlockMerkleTreeNodesFromPeer(peer, n.ID(), shardID,
+				blockStart, depth, level, indexes)
+		})
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		if len(leaves) == 0 {
+			continue
+		}
+
+		peerLeaves := make(map[int]struct{}, len(leaves))
+		for _, leaf := range leaves {
+			peerLeaves[leaf] = struct{}{}
+			anyLeaves[leaf] = struct{}{}
+		}
+		divergent[peer.ID()] = peerLeaves
+		numLeaves += len(leaves)
+	}
+
+	metrics.blocksCompared.Inc(1)
+	if len(divergent) == 0 {
+		return multiErr.FinalError()
+	}
+	metrics.blocksDiverged.Inc(1)
+	metrics.leavesDiverged.Inc(int64(numLeaves))
+
+	metadatas, err := r.divergentMetadata(ctx, session, n, shardID, blockStart,
+		divergent, anyLeaves)
+	if err != nil {
+		return multiErr.Add(err).FinalError()
+	}
+	if len(metadatas) == 0 {
+		return multiErr.FinalError()
+	}
+
+	seriesRepaired := make(map[string]struct{}, len(metadatas))
+	for _, metadata := range metadatas {
+		seriesRepaired[metadata.ID.String()] = struct{}{}
+	}
+	metrics.seriesRepaired.Inc(int64(len(seriesRepaired)))
+
+	// Datapoints older than buffer past can only be merged into the
+	// namespace when it accepts cold writes.
+	if !n.Options().ColdWritesEnabled() {
+		metrics.mergesSkipped.Inc(int64(len(metadatas)))
+		return multiErr.FinalError()
+	}
+
+	if err := r.streamAndMerge(ctx, session, n, shardID, metadatas, metrics); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+
+	return multiErr.FinalError()
+}
+
+// peers returns the peers other than this node that own the shard and have
+// it available.
+func (r *antiEntropyRepairer) peers(
+	session client.AdminSession,
+	shardID uint32,
+) ([]topology.Host, error) {
+	topoMap, err := session.TopologyMap()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		origin = session.Origin()
+		peers  []topology.Host
+	)
+	err = topoMap.RouteShardForEach(shardID, func(_ int, host topology.Host) {
+		if host.ID() == origin.ID() {
+			return
+		}
+		hostShardSet, ok := topoMap.LookupHostShardSet(host.ID())
+		if !ok {
+			return
+		}
+		state, err := hostShardSet.ShardSet().LookupStateByID(shardID)
+		if err != nil || state != shard.Available {
+			return
+		}
+		peers = append(peers, host)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return peers, nil
+}
+
+// divergentMetadata returns the metadata of the peer replicas of the series
+// under the divergent leaves that are either missing locally or differ from
+// the local replica.
+func (r *antiEntropyRepairer) divergentMetadata(
+	ctx context.Context,
+	session client.AdminSession,
+	n databaseNamespace,
+	shardID uint32,
+	blockStart time.Time,
+	divergent map[string]map[int]struct{},
+	anyLeaves map[int]struct{},
+) ([]block.ReplicaMetadata, error) {
+	var (
+		blockEnd  = blockStart.Add(n.Options().RetentionOptions().BlockSize())
+		opts      = block.FetchBlocksMetadataOptions{IncludeChecksums: true}
+		local     = make(map[string]uint32)
+		pageToken PageToken
+	)
+	for {
+		results, nextPageToken, err := r.database.FetchBlocksMetadataV2(ctx, n.ID(),
+			shardID, blockStart, blockEnd, antiEntropyFetchLimit, pageToken, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results.Results() {
+			leaf := repair.MerkleTreeLeafIndex(result.ID.Bytes(), r.depth)
+			if _, ok := anyLeaves[leaf]; !ok {
+				continue
+			}
+			for _, b := range result.Blocks.Results() {
+				if b.Err != nil || b.Checksum == nil || !b.Start.Equal(blockStart) {
+					continue
+				}
+				local[result.ID.String()] = *b.Checksum
+			}
+		}
+		results.Close()
+
+		if nextPageToken == nil {
+			break
+		}
+		pageToken = nextPageToken
+	}
+
+	iter, err := session.FetchBlocksMetadataFromPeers(n.ID(), shardID, blockStart,
+		blockEnd, r.level, result.NewOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	var metadatas []block.ReplicaMetadata
+	for iter.Next() {
+		host, metadata := iter.Current()
+		leaves, ok := divergent[host.ID()]
+		if !ok {
+			continue
+		}
+		if metadata.Checksum == nil || !metadata.Start.Equal(blockStart) {
+			continue
+		}
+		leaf := repair.MerkleTreeLeafIndex(metadata.ID.Bytes(), r.depth)
+		if _, ok := leaves[leaf]; !ok {
+			continue
+		}
+		checksum, ok := local[metadata.ID.String()]
+		if ok && checksum == *metadata.Checksum {
+			continue
+		}
+		metadatas = append(metadatas, block.ReplicaMetadata{
+			Metadata: metadata,
+			Host:     host,
+		})
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	return metadatas, nil
+}
+
+func (r *antiEntropyRepairer) streamAndMerge(
+	ctx context.Context,
+	session client.AdminSession,
+	n databaseNamespace,
+	shardID uint32,
+	metadatas []block.ReplicaMetadata,
+	metrics antiEntropyNamespaceMetrics,
+) error {
+	nsMetadata, err := namespace.NewMetadata(n.ID(), n.Options())
+	if err != nil {
+		return err
+	}
+
+	tagsByID := make(map[string]ident.Tags, len(metadatas))
+	for _, metadata := range metadatas {
+		tagsByID[metadata.ID.String()] = metadata.Tags
+	}
+
+	iter, err := session.FetchBlocksFromPeers(nsMetadata, shardID, r.level,
+		metadatas, result.NewOptions())
+	if err != nil {
+		return err
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for iter.Next() {
+		_, id, b := iter.Current()
+
+		size := int64(b.Len())
+		r.rateLimit(size)
+		metrics.blocksStreamed.Inc(1)
+		metrics.bytesStreamed.Inc(size)
+
+		merged, err := r.merge(ctx, n, id, tagsByID[id.String()], b)
+		metrics.datapointsMerged.Inc(merged)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+
+	return multiErr.FinalError()
+}
+
+// merge writes the datapoints of a peer block into the namespace that are
+// either missing locally or win over the local datapoint at the same time.
+// NB(r): The winner of two datapoints at the same time is the value with the
+// greater bit representation so that every pair of values, including NaNs,
+// has a winner regardless of which replica is repaired, replicas then converge
+// rather than repeatedly overwriting each other's values.
+func (r *antiEntropyRepairer) merge(
+	ctx context.Context,
+	n databaseNamespace,
+	id ident.ID,
+	tags ident.Tags,
+	b block.DatabaseBlock,
+) (int64, error) {
+	reader, err := b.Stream(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if reader.IsEmpty() {
+		return 0, nil
+	}
+
+	local, err := r.localValues(ctx, n, id, reader.Start,
+		reader.Start.Add(reader.BlockSize))
+	if err != nil {
+		return 0, err
+	}
+
+	iter := r.opts.MultiReaderIteratorPool().Get()
+	defer iter.Close()
+
+	var merged int64
+	iter.Reset([]xio.SegmentReader{reader}, reader.Start, reader.BlockSize)
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		bits := math.Float64bits(dp.Value)
+		if localBits, ok := local[xtime.ToUnixNano(dp.Timestamp)]; ok && localBits >= bits {
+			continue
+		}
+		if len(tags.Values()) == 0 {
+			err = r.database.Write(ctx, n.ID(), id, dp.Timestamp, dp.Value,
+				unit, annotation)
+		} else {
+			err = r.database.WriteTagged(ctx, n.ID(), id,
+				ident.NewTagsIterator(tags), dp.Timestamp, dp.Value, unit, annotation)
+		}
+		if err != nil {
+			return merged, err
+		}
+		merged++
+	}
+
+	return merged, iter.Err()
+}
+
+// localValues returns the bit representation of the values of the local
+// datapoints of a series within [start, end) keyed by their timestamp.
+func (r *antiEntropyRepairer) localValues(
+	ctx context.Context,
+	n databaseNamespace,
+	id ident.ID,
+	start, end time.Time,
+) (map[xtime.UnixNano]uint64, error) {
+	encoded, err := n.ReadEncoded(ctx, id, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	iter := r.opts.MultiReaderIteratorPool().Get()
+	defer iter.Close()
+
+	values := make(map[xtime.UnixNano]uint64)
+	iter.ResetSliceOfSlices(xio.NewReaderSliceOfSlicesFromBlockReadersIterator(encoded))
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		values[xtime.ToUnixNano(dp.Timestamp)] = math.Float64bits(dp.Value)
+	}
+	return values, iter.Err()
+}
+
+// rateLimit sleeps for as long as the bytes streamed in the current pass are
+// ahead of the configured rate limit.
+func (r *antiEntropyRepairer) rateLimit(size int64) {
+	opts := r.rateLimitOpts
+	rateLimitMbps := opts.LimitMbps()
+	if opts.LimitEnabled() && rateLimitMbps > 0.0 {
+		now := r.nowFn()
+		if r.start.IsZero() {
+			r.start = now
+		} else if r.count >= opts.LimitCheckEvery() {
+			target := time.Duration(float64(time.Second) * float64(r.bytesStreamed) / (rateLimitMbps * antiEntropyBytesPerMegabit))
+			if elapsed := now.Sub(r.start); elapsed < target {
+				r.sleepFn(target - elapsed)
+			}
+			r.count = 0
+		}
+	}
+	r.count++
+	r.bytesStreamed += size
+}
@@ -0,0 +1,426 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/ratelimit"
+	"github.com/m3db/m3/src/dbnode/sharding"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/context"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+const testAntiEntropyMerkleTreeDepth = 2
+
+var (
+	testAntiEntropyOrigin = topology.NewHost("0", "addr0")
+	testAntiEntropyPeer   = topology.NewHost("1", "addr1")
+)
+
+type testAntiEntropyRepairer struct {
+	repairer  *antiEntropyRepairer
+	db        *Mockdatabase
+	session   *client.MockAdminSession
+	namespace *MockdatabaseNamespace
+	scope     tally.TestScope
+}
+
+func newTestAntiEntropyRepairer(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	nsOpts namespace.Options,
+) testAntiEntropyRepairer {
+	session := client.NewMockAdminSession(ctrl)
+	mockClient := client.NewMockAdminClient(ctrl)
+	mockClient.EXPECT().DefaultAdminSession().Return(session, nil).AnyTimes()
+
+	scope := tally.NewTestScope("", nil)
+	opts := testDatabaseOptions()
+	opts = opts.
+		SetInstrumentOptions(opts.InstrumentOptions().SetMetricsScope(scope)).
+		SetRepairOptions(testRepairOptions(ctrl).
+			SetAdminClient(mockClient).
+			SetAntiEntropyEnabled(true).
+			SetMerkleTreeDepth(testAntiEntropyMerkleTreeDepth))
+
+	db := NewMockdatabase(ctrl)
+	databaseRepairer, err := newAntiEntropyRepairer(db, opts)
+	require.NoError(t, err)
+
+	ns := NewMockdatabaseNamespace(ctrl)
+	ns.EXPECT().ID().Return(defaultTestNs1ID).AnyTimes()
+	ns.EXPECT().Options().Return(nsOpts).AnyTimes()
+
+	return testAntiEntropyRepairer{
+		repairer:  databaseRepairer.(*antiEntropyRepairer),
+		db:        db,
+		session:   session,
+		namespace: ns,
+		scope:     scope,
+	}
+}
+
+func newTestAntiEntropyTopologyMap(t *testing.T, shardID uint32) topology.Map {
+	shardSet, err := sharding.NewShardSet(
+		sharding.NewShards([]uint32{shardID}, shard.Available),
+		sharding.DefaultHashFn(1))
+	require.NoError(t, err)
+
+	return topology.NewStaticMap(topology.NewStaticOptions().
+		SetShardSet(shardSet).
+		SetReplicas(2).
+		SetHostShardSets([]topology.HostShardSet{
+			topology.NewHostShardSet(testAntiEntropyOrigin, shardSet),
+			topology.NewHostShardSet(testAntiEntropyPeer, shardSet),
+		}))
+}
+
+func newTestAntiEntropyMerkleTree(
+	t *testing.T,
+	checksums map[string]uint32,
+) *repair.MerkleTree {
+	builder, err := repair.NewMerkleTreeBuilder(testAntiEntropyMerkleTreeDepth)
+	require.NoError(t, err)
+	for id, checksum := range checksums {
+		builder.Add([]byte(id), checksum)
+	}
+	return builder.Build()
+}
+
+func (r testAntiEntropyRepairer) expectCompare(
+	t *testing.T,
+	shardID uint32,
+	blockStart time.Time,
+	local, peer *repair.MerkleTree,
+) {
+	r.db.EXPECT().
+		BlockMerkleTree(gomock.Any(), defaultTestNs1ID, shardID, blockStart,
+			testAntiEntropyMerkleTreeDepth).
+		Return(local, nil)
+	r.session.EXPECT().TopologyMap().Return(newTestAntiEntropyTopologyMap(t, shardID), nil)
+	r.session.EXPECT().Origin().Return(testAntiEntropyOrigin)
+	r.session.EXPECT().
+		FetchBlockMerkleTreeNodesFromPeer(testAntiEntropyPeer, defaultTestNs1ID,
+			shardID, blockStart, testAntiEntropyMerkleTreeDepth, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ topology.Host,
+			_ ident.ID,
+			_ uint32,
+			_ time.Time,
+			_, level int,
+			indexes []int,
+		) ([]uint32, error) {
+			return peer.Nodes(level, indexes)
+		}).
+		AnyTimes()
+}
+
+func (r testAntiEntropyRepairer) expectDivergentMetadata(
+	ctrl *gomock.Controller,
+	shardID uint32,
+	blockStart time.Time,
+	local, peer map[string]uint32,
+) {
+	blockEnd := blockStart.Add(defaultTestRetentionOpts.BlockSize())
+
+	localResults := block.NewFetchBlocksMetadataResults()
+	for id, checksum := range local {
+		checksum := checksum
+		results := block.NewFetchBlockMetadataResults()
+		results.Add(block.NewFetchBlockMetadataResult(blockStart, 0, &checksum,
+			time.Time{}, nil))
+		localResults.Add(block.NewFetchBlocksMetadataResult(ident.StringID(id),
+			nil, results))
+	}
+	r.db.EXPECT().
+		FetchBlocksMetadataV2(gomock.Any(), defaultTestNs1ID, shardID, blockStart,
+			blockEnd, int64(antiEntropyFetchLimit), gomock.Any(), gomock.Any()).
+		Return(localResults, nil, nil)
+
+	peerIter := client.NewMockPeerBlockMetadataIter(ctrl)
+	var calls []*gomock.Call
+	for id, checksum := range peer {
+		checksum := checksum
+		calls = append(calls,
+			peerIter.EXPECT().Next().Return(true),
+			peerIter.EXPECT().Current().Return(testAntiEntropyPeer,
+				block.NewMetadata(ident.StringID(id), ident.Tags{}, blockStart,
+					0, &checksum, time.Time{})))
+	}
+	calls = append(calls,
+		peerIter.EXPECT().Next().Return(false),
+		peerIter.EXPECT().Err().Return(nil))
+	gomock.InOrder(calls...)
+
+	r.session.EXPECT().
+		FetchBlocksMetadataFromPeers(defaultTestNs1ID, shardID, blockStart, blockEnd,
+			r.repairer.level, gomock.Any()).
+		Return(peerIter, nil)
+}
+
+func (r testAntiEntropyRepairer) counter(name string) int64 {
+	key := "repair.anti-entropy." + name + "+namespace=" + defaultTestNs1ID.String()
+	counter, ok := r.scope.Snapshot().Counters()[key]
+	if !ok {
+		return 0
+	}
+	return counter.Value()
+}
+
+func TestAntiEntropyRepairerRepairNotBootstrapped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := newTestAntiEntropyRepairer(t, ctrl, defaultTestNs1Opts)
+	r.db.EXPECT().IsBootstrapped().Return(false)
+	require.NoError(t, r.repairer.Repair())
+}
+
+func TestAntiEntropyRepairerRepairBlockNoDivergence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		r          = newTestAntiEntropyRepairer(t, ctrl, defaultTestNs1Opts)
+		shardID    = uint32(0)
+		blockStart = time.Now().Truncate(defaultTestRetentionOpts.BlockSize())
+		checksums  = map[string]uint32{"foo": 1, "bar": 2}
+	)
+	r.expectCompare(t, shardID, blockStart,
+		newTestAntiEntropyMerkleTree(t, checksums),
+		newTestAntiEntropyMerkleTree(t, checksums))
+
+	err := r.repairer.repairBlock(r.session, r.namespace, shardID, blockStart,
+		r.repairer.namespaceMetrics(defaultTestNs1ID))
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1), r.counter("blocks-compared"))
+	require.Equal(t, int64(0), r.counter("blocks-diverged"))
+}
+
+func TestAntiEntropyRepairerRepairBlockStreamsDivergentSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		nsOpts     = defaultTestNs1Opts.SetColdWritesEnabled(true)
+		r          = newTestAntiEntropyRepairer(t, ctrl, nsOpts)
+		shardID    = uint32(0)
+		blockStart = time.Now().Truncate(defaultTestRetentionOpts.BlockSize())
+		local      = map[string]uint32{"foo": 1}
+		peer       = map[string]uint32{"foo": 1, "bar": 2}
+	)
+	r.expectCompare(t, shardID, blockStart,
+		newTestAntiEntropyMerkleTree(t, local),
+		newTestAntiEntropyMerkleTree(t, peer))
+	r.expectDivergentMetadata(ctrl, shardID, blockStart, local, peer)
+
+	checksum := peer["bar"]
+	expected := []block.ReplicaMetadata{{
+		Metadata: block.NewMetadata(ident.StringID("bar"), ident.Tags{}, blockStart,
+			0, &checksum, time.Time{}),
+		Host: testAntiEntropyPeer,
+	}}
+	blocksIter := client.NewMockPeerBlocksIter(ctrl)
+	blocksIter.EXPECT().Next().Return(false)
+	blocksIter.EXPECT().Err().Return(nil)
+	r.session.EXPECT().
+		FetchBlocksFromPeers(gomock.Any(), shardID, r.repairer.level, expected, gomock.Any()).
+		Return(blocksIter, nil)
+
+	err := r.repairer.repairBlock(r.session, r.namespace, shardID, blockStart,
+		r.repairer.namespaceMetrics(defaultTestNs1ID))
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1), r.counter("blocks-compared"))
+	require.Equal(t, int64(1), r.counter("blocks-diverged"))
+	require.Equal(t, int64(1), r.counter("series-repaired"))
+	require.Equal(t, int64(0), r.counter("merges-skipped"))
+}
+
+func TestAntiEntropyRepairerRepairBlockSkipsMergeWithoutColdWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		r          = newTestAntiEntropyRepairer(t, ctrl, defaultTestNs1Opts)
+		shardID    = uint32(0)
+		blockStart = time.Now().Truncate(defaultTestRetentionOpts.BlockSize())
+		local      = map[string]uint32{"foo": 1}
+		peer       = map[string]uint32{"foo": 3}
+	)
+	r.expectCompare(t, shardID, blockStart,
+		newTestAntiEntropyMerkleTree(t, local),
+		newTestAntiEntropyMerkleTree(t, peer))
+	r.expectDivergentMetadata(ctrl, shardID, blockStart, local, peer)
+
+	err := r.repairer.repairBlock(r.session, r.namespace, shardID, blockStart,
+		r.repairer.namespaceMetrics(defaultTestNs1ID))
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1), r.counter("blocks-diverged"))
+	require.Equal(t, int64(1), r.counter("leaves-diverged"))
+	require.Equal(t, int64(1), r.counter("series-repaired"))
+	require.Equal(t, int64(1), r.counter("merges-skipped"))
+}
+
+func newTestAntiEntropySegment(
+	t *testing.T,
+	start time.Time,
+	values map[time.Duration]float64,
+) ts.Segment {
+	var offsets []time.Duration
+	for offset := range values {
+		offsets = append(offsets, offset)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+
+	encoder := m3tsz.NewEncoder(start, nil, true, encoding.NewOptions())
+	for _, offset := range offsets {
+		dp := ts.Datapoint{Timestamp: start.Add(offset), Value: values[offset]}
+		require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+	}
+	return encoder.Discard()
+}
+
+// testAntiEntropyMerge merges the peer values of a series into the local
+// values, returning the values written.
+func testAntiEntropyMerge(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	local, peer map[time.Duration]float64,
+) map[time.Duration]float64 {
+	var (
+		r          = newTestAntiEntropyRepairer(t, ctrl, defaultTestNs1Opts)
+		blockSize  = defaultTestRetentionOpts.BlockSize()
+		blockStart = time.Now().Truncate(blockSize)
+		id         = ident.StringID("foo")
+		written    = make(map[time.Duration]float64)
+	)
+	localReader := xio.BlockReader{
+		SegmentReader: xio.NewSegmentReader(newTestAntiEntropySegment(t, blockStart, local)),
+		Start:         blockStart,
+		BlockSize:     blockSize,
+	}
+	r.namespace.EXPECT().
+		ReadEncoded(gomock.Any(), id, blockStart, blockStart.Add(blockSize)).
+		Return([][]xio.BlockReader{{localReader}}, nil)
+	r.db.EXPECT().
+		Write(gomock.Any(), defaultTestNs1ID, id, gomock.Any(), gomock.Any(),
+			xtime.Second, gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_, _ ident.ID,
+			timestamp time.Time,
+			value float64,
+			_ xtime.Unit,
+			_ []byte,
+		) error {
+			written[timestamp.Sub(blockStart)] = value
+			return nil
+		}).
+		AnyTimes()
+
+	peerBlock := block.NewDatabaseBlock(blockStart, blockSize,
+		newTestAntiEntropySegment(t, blockStart, peer), block.NewOptions())
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	merged, err := r.repairer.merge(ctx, r.namespace, id, ident.Tags{}, peerBlock)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(written)), merged)
+	return written
+}
+
+func TestAntiEntropyRepairerMergeConverges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		local = map[time.Duration]float64{
+			0:               1,
+			time.Minute:     5,
+			2 * time.Minute: 2,
+		}
+		peer = map[time.Duration]float64{
+			0:               1,
+			time.Minute:     3,
+			2 * time.Minute: 4,
+			3 * time.Minute: 7,
+		}
+	)
+
+	// Only the datapoints missing locally and those that win over the local
+	// datapoint are written.
+	require.Equal(t, map[time.Duration]float64{
+		2 * time.Minute: 4,
+		3 * time.Minute: 7,
+	}, testAntiEntropyMerge(t, ctrl, local, peer))
+
+	// Repairing the peer from the local replica picks the same winners, so
+	// the replicas converge on the same values.
+	require.Equal(t, map[time.Duration]float64{
+		time.Minute: 5,
+	}, testAntiEntropyMerge(t, ctrl, peer, local))
+}
+
+func TestAntiEntropyRepairerRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := newTestAntiEntropyRepairer(t, ctrl, defaultTestNs1Opts)
+
+	now := time.Now()
+	r.repairer.nowFn = func() time.Time { return now }
+
+	var slept time.Duration
+	r.repairer.sleepFn = func(d time.Duration) { slept += d }
+	r.repairer.rateLimitOpts = ratelimit.NewOptions().
+		SetLimitEnabled(true).
+		SetLimitMbps(1).
+		SetLimitCheckEvery(1)
+
+	r.repairer.rateLimit(antiEntropyBytesPerMegabit)
+	require.Equal(t, time.Duration(0), slept)
+
+	// One megabit streamed in no time at one megabit per second.
+	r.repairer.rateLimit(antiEntropyBytesPerMegabit)
+	require.Equal(t, time.Second, slept)
+}
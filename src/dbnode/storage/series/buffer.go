@@ -28,11 +28,13 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3x/context"
+	xerrors "github.com/m3db/m3x/errors"
 	xtime "github.com/m3db/m3x/time"
 )
 
@@ -102,6 +104,28 @@ type databaseBuffer interface {
 
 	Bootstrap(bl block.DatabaseBlock) error
 
+	// ColdStreams returns the streams for any cold writes held for the
+	// given block start, including those sealed by an in progress cold flush.
+	ColdStreams(ctx context.Context, blockStart time.Time) []xio.BlockReader
+
+	// ColdBlockStarts returns the block starts that have cold writes which
+	// are yet to be flushed.
+	ColdBlockStarts() []time.Time
+
+	// SealColdWrites seals the cold writes held for the given block start so
+	// that they can be flushed, returning their streams. Writes received
+	// while the seal is in place are held separately for a later cold flush.
+	SealColdWrites(ctx context.Context, blockStart time.Time) []xio.BlockReader
+
+	// ColdWritesFlushed releases the sealed cold writes for the given block
+	// start once they are durable on disk, returning them merged as a block
+	// so that any copy of the block held in memory can be brought up to date.
+	ColdWritesFlushed(blockStart time.Time) (block.DatabaseBlock, error)
+
+	// UnsealColdWrites reverts a seal after a failed cold flush so that the
+	// cold writes are retried with the next cold flush.
+	UnsealColdWrites(blockStart time.Time)
+
 	Reset(opts Options)
 }
 
@@ -127,6 +151,20 @@ type dbBuffer struct {
 	blockSize         time.Duration
 	bufferPast        time.Duration
 	bufferFuture      time.Duration
+
+	// coldBuckets holds writes older than buffer past by block start, these
+	// are merged with the existing fileset volume for the block by cold flush
+	// rather than being drained into a block. Lazily allocated since the
+	// majority of series never receive a cold write.
+	coldBuckets map[xtime.UnixNano]*coldBuckets
+}
+
+// coldBuckets are the buckets holding cold writes for a single block start,
+// the last bucket takes writes unless it has been sealed by an in progress
+// cold flush in which case a new bucket is appended to take writes.
+type coldBuckets struct {
+	buckets []*dbBufferBucket
+	sealed  int
 }
 
 type databaseBufferDrainFn func(b block.DatabaseBlock)
@@ -149,6 +187,10 @@ func (b *dbBuffer) Reset(opts Options) {
 	b.bufferFuture = ropts.BufferFuture()
 	// Avoid capturing any variables with callback
 	b.computedForEachBucketAsc(computeAndResetBucketIdx, bucketResetStart)
+	for key, cold := range b.coldBuckets {
+		cold.finalize()
+		delete(b.coldBuckets, key)
+	}
 }
 
 func bucketResetStart(now time.Time, b *dbBuffer, idx int, start time.Time) int {
@@ -189,7 +231,10 @@ func (b *dbBuffer) Write(
 		return m3dberrors.ErrTooFuture
 	}
 	if !pastLimit.Before(timestamp) {
-		return m3dberrors.ErrTooPast
+		if !b.opts.ColdWritesEnabled() {
+			return m3dberrors.ErrTooPast
+		}
+		return b.writeCold(now, timestamp, value, unit, annotation)
 	}
 
 	bucketStart := timestamp.Truncate(b.blockSize)
@@ -202,6 +247,50 @@ func (b *dbBuffer) Write(
 	return b.buckets[idx].write(timestamp, value, unit, annotation)
 }
 
+func (b *dbBuffer) writeCold(
+	now time.Time,
+	timestamp time.Time,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
+) error {
+	if timestamp.Before(retention.FlushTimeStart(b.opts.RetentionOptions(), now)) {
+		return m3dberrors.ErrTooPast
+	}
+
+	// If the bucket for the block has not been drained yet the write can
+	// simply be merged with the rest of the block when it is drained.
+	bucketStart := timestamp.Truncate(b.blockSize)
+	idx := b.writableBucketIdx(timestamp)
+	if bucket := &b.buckets[idx]; bucket.start.Equal(bucketStart) && !bucket.drained {
+		return bucket.write(timestamp, value, unit, annotation)
+	}
+
+	if err := b.writableColdBucket(bucketStart).write(timestamp, value, unit, annotation); err != nil {
+		return err
+	}
+	b.opts.Stats().IncColdWrites()
+	return nil
+}
+
+func (b *dbBuffer) writableColdBucket(blockStart time.Time) *dbBufferBucket {
+	if b.coldBuckets == nil {
+		b.coldBuckets = make(map[xtime.UnixNano]*coldBuckets)
+	}
+	key := xtime.ToUnixNano(blockStart)
+	cold, ok := b.coldBuckets[key]
+	if !ok {
+		cold = &coldBuckets{}
+		b.coldBuckets[key] = cold
+	}
+	if cold.sealed == len(cold.buckets) {
+		bucket := &dbBufferBucket{opts: b.opts}
+		bucket.resetTo(blockStart)
+		cold.buckets = append(cold.buckets, bucket)
+	}
+	return cold.buckets[len(cold.buckets)-1]
+}
+
 func (b *dbBuffer) writableBucketIdx(t time.Time) int {
 	return int(t.Truncate(b.blockSize).UnixNano() / int64(b.blockSize) % bucketsLen)
 }
@@ -211,7 +300,7 @@ func (b *dbBuffer) IsEmpty() bool {
 	for i := range b.buckets {
 		canReadAny = canReadAny || b.buckets[i].canRead()
 	}
-	return !canReadAny
+	return !canReadAny && len(b.coldBuckets) == 0
 }
 
 func (b *dbBuffer) Stats() bufferStats {
@@ -226,6 +315,7 @@ func (b *dbBuffer) Stats() bufferStats {
 		}
 		stats.wiredBlocks++
 	}
+	stats.wiredBlocks += len(b.coldBuckets)
 	return stats
 }
 
@@ -244,6 +334,7 @@ func bucketNeedsDrain(now time.Time, b *dbBuffer, idx int, start time.Time) int
 func (b *dbBuffer) Tick() bufferTickResult {
 	// Avoid capturing any variables with callback
 	mergedOutOfOrder := b.computedForEachBucketAsc(computeAndResetBucketIdx, bucketTick)
+	mergedOutOfOrder += b.tickColdBuckets()
 	return bufferTickResult{
 		mergedOutOfOrderBlocks: mergedOutOfOrder,
 	}
@@ -266,6 +357,41 @@ func bucketTick(now time.Time, b *dbBuffer, idx int, start time.Time) int {
 	return mergedOutOfOrderBlocks
 }
 
+func (b *dbBuffer) tickColdBuckets() int {
+	if len(b.coldBuckets) == 0 {
+		return 0
+	}
+
+	var (
+		mergedOutOfOrderBlocks int
+		earliest               = retention.FlushTimeStart(b.opts.RetentionOptions(), b.nowFn())
+	)
+	for key, cold := range b.coldBuckets {
+		if key.ToTime().Before(earliest) {
+			// Cold writes for blocks that have fallen out of retention will
+			// never be flushed, release them.
+			cold.finalize()
+			delete(b.coldBuckets, key)
+			continue
+		}
+
+		// Only merge the bucket taking writes, the sealed buckets are being
+		// read by an in progress cold flush.
+		if cold.sealed == len(cold.buckets) {
+			continue
+		}
+		r, err := cold.buckets[len(cold.buckets)-1].merge()
+		if err != nil {
+			log := b.opts.InstrumentOptions().Logger()
+			log.Errorf("buffer cold merge encode error: %v", err)
+		}
+		if r.merges > 0 {
+			mergedOutOfOrderBlocks++
+		}
+	}
+	return mergedOutOfOrderBlocks
+}
+
 func (b *dbBuffer) DrainAndReset() drainAndResetResult {
 	// Avoid capturing any variables with callback
 	mergedOutOfOrder := b.computedForEachBucketAsc(computeAndResetBucketIdx, bucketDrainAndReset)
@@ -363,6 +489,90 @@ func (b *dbBuffer) computedForEachBucketAsc(
 	return result
 }
 
+func (b *dbBuffer) ColdStreams(ctx context.Context, blockStart time.Time) []xio.BlockReader {
+	cold, ok := b.coldBuckets[xtime.ToUnixNano(blockStart)]
+	if !ok {
+		return nil
+	}
+	var res []xio.BlockReader
+	for _, bucket := range cold.buckets {
+		if !bucket.canRead() {
+			continue
+		}
+		res = append(res, bucket.streams(ctx)...)
+	}
+	return res
+}
+
+func (b *dbBuffer) ColdBlockStarts() []time.Time {
+	if len(b.coldBuckets) == 0 {
+		return nil
+	}
+	starts := make([]time.Time, 0, len(b.coldBuckets))
+	for key := range b.coldBuckets {
+		starts = append(starts, key.ToTime())
+	}
+	return starts
+}
+
+func (b *dbBuffer) SealColdWrites(ctx context.Context, blockStart time.Time) []xio.BlockReader {
+	cold, ok := b.coldBuckets[xtime.ToUnixNano(blockStart)]
+	if !ok {
+		return nil
+	}
+	cold.sealed = len(cold.buckets)
+	var res []xio.BlockReader
+	for _, bucket := range cold.buckets {
+		if !bucket.canRead() {
+			continue
+		}
+		res = append(res, bucket.streams(ctx)...)
+	}
+	return res
+}
+
+func (b *dbBuffer) ColdWritesFlushed(blockStart time.Time) (block.DatabaseBlock, error) {
+	key := xtime.ToUnixNano(blockStart)
+	cold, ok := b.coldBuckets[key]
+	if !ok {
+		return nil, nil
+	}
+
+	var (
+		flushed  block.DatabaseBlock
+		multiErr xerrors.MultiError
+	)
+	for i := 0; i < cold.sealed; i++ {
+		bucket := cold.buckets[i]
+		if bucket.canRead() {
+			result, err := bucket.discardMerged()
+			switch {
+			case err != nil:
+				multiErr = multiErr.Add(err)
+			case flushed == nil:
+				flushed = result.block
+			default:
+				multiErr = multiErr.Add(flushed.Merge(result.block))
+			}
+		}
+		bucket.finalize()
+		cold.buckets[i] = nil
+	}
+
+	cold.buckets = cold.buckets[cold.sealed:]
+	cold.sealed = 0
+	if len(cold.buckets) == 0 {
+		delete(b.coldBuckets, key)
+	}
+	return flushed, multiErr.FinalError()
+}
+
+func (b *dbBuffer) UnsealColdWrites(blockStart time.Time) {
+	if cold, ok := b.coldBuckets[xtime.ToUnixNano(blockStart)]; ok {
+		cold.sealed = 0
+	}
+}
+
 func (b *dbBuffer) Snapshot(ctx context.Context, blockStart time.Time) (xio.SegmentReader, error) {
 	var (
 		res xio.SegmentReader
@@ -505,6 +715,14 @@ func (b *dbBuffer) FetchBlocksMetadata(
 	return res
 }
 
+func (c *coldBuckets) finalize() {
+	for _, bucket := range c.buckets {
+		bucket.finalize()
+	}
+	c.buckets = nil
+	c.sealed = 0
+}
+
 type dbBufferBucket struct {
 	opts              Options
 	start             time.Time
@@ -103,6 +103,88 @@ func TestBufferWriteTooPast(t *testing.T) {
 	assert.True(t, xerrors.IsInvalidParams(err))
 }
 
+func TestBufferWriteColdWithinRetention(t *testing.T) {
+	opts := newBufferTestOptions().SetColdWritesEnabled(true)
+	rops := opts.RetentionOptions()
+	curr := time.Now().Truncate(rops.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	buffer := newDatabaseBuffer(nil).(*dbBuffer)
+	buffer.Reset(opts)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	blockStart := curr.Add(-10 * rops.BlockSize())
+	require.NoError(t, buffer.Write(ctx, blockStart.Add(secs(1)), 1, xtime.Second, nil))
+	assert.False(t, buffer.IsEmpty())
+	assert.Equal(t, []time.Time{blockStart}, buffer.ColdBlockStarts())
+
+	// Writes beyond retention are still rejected.
+	err := buffer.Write(ctx, curr.Add(-2*rops.RetentionPeriod()), 1, xtime.Second, nil)
+	assert.Error(t, err)
+	assert.True(t, xerrors.IsInvalidParams(err))
+}
+
+func TestBufferColdWritesSealAndFlush(t *testing.T) {
+	opts := newBufferTestOptions().SetColdWritesEnabled(true)
+	rops := opts.RetentionOptions()
+	curr := time.Now().Truncate(rops.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	buffer := newDatabaseBuffer(nil).(*dbBuffer)
+	buffer.Reset(opts)
+
+	blockStart := curr.Add(-10 * rops.BlockSize())
+	sealedData := []value{
+		{blockStart.Add(secs(1)), 1, xtime.Second, nil},
+		{blockStart.Add(secs(2)), 2, xtime.Second, nil},
+	}
+	for _, v := range sealedData {
+		ctx := context.NewContext()
+		require.NoError(t, buffer.Write(ctx, v.timestamp, v.value, v.unit, v.annotation))
+		ctx.Close()
+	}
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	sealed := buffer.SealColdWrites(ctx, blockStart)
+	assertValuesEqual(t, sealedData, [][]xio.BlockReader{sealed}, opts)
+
+	// Writes while sealed are held apart from the sealed writes.
+	pending := value{blockStart.Add(secs(3)), 3, xtime.Second, nil}
+	require.NoError(t, buffer.Write(ctx, pending.timestamp, pending.value, pending.unit, pending.annotation))
+	assertValuesEqual(t, append(sealedData, pending),
+		[][]xio.BlockReader{buffer.ColdStreams(ctx, blockStart)}, opts)
+
+	flushed, err := buffer.ColdWritesFlushed(blockStart)
+	require.NoError(t, err)
+	require.NotNil(t, flushed)
+	stream, err := flushed.Stream(ctx)
+	require.NoError(t, err)
+	assertValuesEqual(t, sealedData, [][]xio.BlockReader{{stream}}, opts)
+
+	// Only the writes received while sealed remain.
+	assertValuesEqual(t, []value{pending},
+		[][]xio.BlockReader{buffer.ColdStreams(ctx, blockStart)}, opts)
+	assert.Equal(t, []time.Time{blockStart}, buffer.ColdBlockStarts())
+
+	// A failed cold flush leaves the writes in place to be retried.
+	buffer.SealColdWrites(ctx, blockStart)
+	buffer.UnsealColdWrites(blockStart)
+	assertValuesEqual(t, []value{pending},
+		[][]xio.BlockReader{buffer.ColdStreams(ctx, blockStart)}, opts)
+
+	buffer.SealColdWrites(ctx, blockStart)
+	_, err = buffer.ColdWritesFlushed(blockStart)
+	require.NoError(t, err)
+	assert.Empty(t, buffer.ColdBlockStarts())
+	assert.True(t, buffer.IsEmpty())
+}
+
 func TestBufferWriteRead(t *testing.T) {
 	opts := newBufferTestOptions()
 	rops := opts.RetentionOptions()
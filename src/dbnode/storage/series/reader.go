@@ -117,40 +117,47 @@ func (r Reader) readersWithBlocksMapAndBuffer(
 
 	first, last := alignedStart, alignedEnd
 	for blockAt := first; !blockAt.After(last); blockAt = blockAt.Add(size) {
-		if seriesBlocks != nil {
-			if block, ok := seriesBlocks.BlockAt(blockAt); ok {
-				// Block served from in-memory or in-memory metadata
-				// will defer to disk read
-				streamedBlock, err := block.Stream(ctx)
-				if err != nil {
-					return nil, err
+		var blockResults []xio.BlockReader
+		if block, ok := r.blockAt(seriesBlocks, blockAt); ok {
+			// Block served from in-memory or in-memory metadata
+			// will defer to disk read
+			streamedBlock, err := block.Stream(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if streamedBlock.IsNotEmpty() {
+				blockResults = append(blockResults, streamedBlock)
+				// NB(r): Mark this block as read now
+				block.SetLastReadTime(now)
+				if r.onRead != nil {
+					r.onRead.OnReadBlock(block)
 				}
-				if streamedBlock.IsNotEmpty() {
-					results = append(results, []xio.BlockReader{streamedBlock})
-					// NB(r): Mark this block as read now
-					block.SetLastReadTime(now)
-					if r.onRead != nil {
-						r.onRead.OnReadBlock(block)
+			}
+		} else {
+			switch {
+			case cachePolicy == CacheAll:
+				// No-op, block metadata should have been in-memory
+			case r.retriever != nil:
+				// Try to stream from disk
+				if r.retriever.IsBlockRetrievable(blockAt) {
+					streamedBlock, err := r.retriever.Stream(ctx, r.id, blockAt, r.onRetrieve)
+					if err != nil {
+						return nil, err
+					}
+					if streamedBlock.IsNotEmpty() {
+						blockResults = append(blockResults, streamedBlock)
 					}
 				}
-				continue
 			}
 		}
 
-		switch {
-		case cachePolicy == CacheAll:
-			// No-op, block metadata should have been in-memory
-		case r.retriever != nil:
-			// Try to stream from disk
-			if r.retriever.IsBlockRetrievable(blockAt) {
-				streamedBlock, err := r.retriever.Stream(ctx, r.id, blockAt, r.onRetrieve)
-				if err != nil {
-					return nil, err
-				}
-				if streamedBlock.IsNotEmpty() {
-					results = append(results, []xio.BlockReader{streamedBlock})
-				}
-			}
+		// NB: Cold writes for the block are read alongside the block itself
+		// so that the block's datapoints are iterated in order.
+		if seriesBuffer != nil {
+			blockResults = append(blockResults, seriesBuffer.ColdStreams(ctx, blockAt)...)
+		}
+		if len(blockResults) > 0 {
+			results = append(results, blockResults)
 		}
 	}
 
@@ -191,43 +198,45 @@ func (r Reader) fetchBlocksWithBlocksMapAndBuffer(
 		onRetrieve block.OnRetrieveBlock
 	)
 	for _, start := range starts {
-		if seriesBlocks != nil {
-			if b, exists := seriesBlocks.BlockAt(start); exists {
-				streamedBlock, err := b.Stream(ctx)
-				if err != nil {
-					r := block.NewFetchBlockResult(start, nil,
-						fmt.Errorf("unable to retrieve block stream for series %s time %v: %v",
-							r.id.String(), start, err))
-					res = append(res, r)
-				}
-				if streamedBlock.IsNotEmpty() {
-					b := []xio.BlockReader{streamedBlock}
-					r := block.NewFetchBlockResult(start, b, nil)
-					res = append(res, r)
-				}
-				continue
+		var (
+			blockResults []xio.BlockReader
+			err          error
+		)
+		if b, exists := r.blockAt(seriesBlocks, start); exists {
+			var streamedBlock xio.BlockReader
+			streamedBlock, err = b.Stream(ctx)
+			if streamedBlock.IsNotEmpty() {
+				blockResults = append(blockResults, streamedBlock)
 			}
-		}
-		switch {
-		case cachePolicy == CacheAll:
-			// No-op, block metadata should have been in-memory
-		case r.retriever != nil:
-			// Try to stream from disk
-			if r.retriever.IsBlockRetrievable(start) {
-				streamedBlock, err := r.retriever.Stream(ctx, r.id, start, onRetrieve)
-				if err != nil {
-					r := block.NewFetchBlockResult(start, nil,
-						fmt.Errorf("unable to retrieve block stream for series %s time %v: %v",
-							r.id.String(), start, err))
-					res = append(res, r)
-				}
-				if streamedBlock.IsNotEmpty() {
-					b := []xio.BlockReader{streamedBlock}
-					r := block.NewFetchBlockResult(start, b, nil)
-					res = append(res, r)
+		} else {
+			switch {
+			case cachePolicy == CacheAll:
+				// No-op, block metadata should have been in-memory
+			case r.retriever != nil:
+				// Try to stream from disk
+				if r.retriever.IsBlockRetrievable(start) {
+					var streamedBlock xio.BlockReader
+					streamedBlock, err = r.retriever.Stream(ctx, r.id, start, onRetrieve)
+					if streamedBlock.IsNotEmpty() {
+						blockResults = append(blockResults, streamedBlock)
+					}
 				}
 			}
 		}
+		if err != nil {
+			r := block.NewFetchBlockResult(start, nil,
+				fmt.Errorf("unable to retrieve block stream for series %s time %v: %v",
+					r.id.String(), start, err))
+			res = append(res, r)
+		}
+
+		if seriesBuffer != nil {
+			blockResults = append(blockResults, seriesBuffer.ColdStreams(ctx, start)...)
+		}
+		if len(blockResults) > 0 {
+			r := block.NewFetchBlockResult(start, blockResults, nil)
+			res = append(res, r)
+		}
 	}
 
 	if seriesBuffer != nil && !seriesBuffer.IsEmpty() {
@@ -239,3 +248,13 @@ func (r Reader) fetchBlocksWithBlocksMapAndBuffer(
 
 	return res, nil
 }
+
+func (r Reader) blockAt(
+	seriesBlocks block.DatabaseSeriesBlocks,
+	blockStart time.Time,
+) (block.DatabaseBlock, bool) {
+	if seriesBlocks == nil {
+		return nil, false
+	}
+	return seriesBlocks.BlockAt(blockStart)
+}
@@ -676,6 +676,151 @@ func (s *dbSeries) Snapshot(
 	return persistFn(s.id, s.tags, segment, digest.SegmentChecksum(segment))
 }
 
+func (s *dbSeries) ColdFlushBlockStarts() []time.Time {
+	s.RLock()
+	starts := s.buffer.ColdBlockStarts()
+	s.RUnlock()
+	return starts
+}
+
+func (s *dbSeries) ColdFlush(
+	ctx context.Context,
+	blockStart time.Time,
+	existing ts.Segment,
+	persistFn persist.DataFn,
+) (FlushOutcome, error) {
+	// Need a write lock because sealing the cold writes mutates the buffer.
+	s.Lock()
+	id, tags := s.id, s.tags
+	if s.bs != bootstrapped {
+		s.Unlock()
+		// Carry the existing data over so the new volume remains complete.
+		if err := persistExisting(id, tags, existing, persistFn); err != nil {
+			return FlushOutcomeErr, err
+		}
+		return FlushOutcomeErr, errSeriesNotBootstrapped
+	}
+	coldStreams := s.buffer.SealColdWrites(ctx, blockStart)
+	s.Unlock()
+
+	if len(coldStreams) == 0 {
+		if existing.Len() == 0 {
+			return FlushOutcomeBlockDoesNotExist, nil
+		}
+		// Nothing to merge, carry the existing data over to the new volume.
+		if err := persistExisting(id, tags, existing, persistFn); err != nil {
+			return FlushOutcomeErr, err
+		}
+		return FlushOutcomeFlushedToDisk, nil
+	}
+
+	segment, err := s.mergeColdStreams(blockStart, existing, coldStreams)
+	if err != nil {
+		// Carry the existing data over so the new volume remains complete, the
+		// cold writes are retried with the next cold flush.
+		if err := persistExisting(id, tags, existing, persistFn); err != nil {
+			return FlushOutcomeErr, err
+		}
+		return FlushOutcomeErr, err
+	}
+	defer segment.Finalize()
+
+	if err := persistFn(id, tags, segment, digest.SegmentChecksum(segment)); err != nil {
+		return FlushOutcomeErr, err
+	}
+	return FlushOutcomeFlushedToDisk, nil
+}
+
+// persistExisting persists the data of the series from the volume being
+// superseded, if any.
+func persistExisting(
+	id ident.ID,
+	tags ident.Tags,
+	existing ts.Segment,
+	persistFn persist.DataFn,
+) error {
+	if existing.Len() == 0 {
+		return nil
+	}
+	return persistFn(id, tags, existing, digest.SegmentChecksum(existing))
+}
+
+func (s *dbSeries) mergeColdStreams(
+	blockStart time.Time,
+	existing ts.Segment,
+	coldStreams []xio.BlockReader,
+) (ts.Segment, error) {
+	var (
+		bopts   = s.opts.DatabaseBlockOptions()
+		readers = make([]xio.SegmentReader, 0, len(coldStreams)+1)
+		iter    = s.opts.MultiReaderIteratorPool().Get()
+		encoder = bopts.EncoderPool().Get()
+	)
+	defer iter.Close()
+	encoder.Reset(blockStart, bopts.DatabaseBlockAllocSize())
+
+	// Rank the existing data before the cold writes so that the cold writes
+	// take precedence for any datapoints written at the same timestamp.
+	if existing.Len() > 0 {
+		readers = append(readers, xio.NewSegmentReader(existing))
+	}
+	for _, stream := range coldStreams {
+		readers = append(readers, stream.SegmentReader)
+	}
+
+	iter.Reset(readers, blockStart, s.opts.RetentionOptions().BlockSize())
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return ts.Segment{}, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		encoder.Close()
+		return ts.Segment{}, err
+	}
+
+	return encoder.Discard(), nil
+}
+
+func (s *dbSeries) ColdFlushCompleted(blockStart time.Time, success bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	if !success {
+		s.buffer.UnsealColdWrites(blockStart)
+		return
+	}
+
+	flushed, err := s.buffer.ColdWritesFlushed(blockStart)
+	if err != nil {
+		s.opts.InstrumentOptions().Logger().WithFields(
+			xlog.NewField("id", s.id.String()),
+			xlog.NewField("blockStart", blockStart),
+			xlog.NewField("err", err.Error()),
+		).Errorf("error trying to release flushed cold writes")
+	}
+	if flushed == nil {
+		return
+	}
+
+	// A block held in memory predates the new volume on disk so needs the
+	// cold writes merged into it, otherwise the block is retrieved from the
+	// new volume when next read unless all blocks are held in memory.
+	if _, ok := s.blocks.BlockAt(blockStart); !ok && s.opts.CachePolicy() != CacheAll {
+		flushed.Close()
+		return
+	}
+	if err := s.mergeBlockWithLock(flushed); err != nil {
+		s.opts.InstrumentOptions().Logger().WithFields(
+			xlog.NewField("id", s.id.String()),
+			xlog.NewField("blockStart", blockStart),
+			xlog.NewField("err", err.Error()),
+		).Errorf("error trying to merge flushed cold writes")
+	}
+}
+
 func (s *dbSeries) Close() {
 	s.Lock()
 	defer s.Unlock()
@@ -364,6 +364,91 @@ func TestSeriesTickRecentlyRead(t *testing.T) {
 	require.Equal(t, 1, tickResult.PendingMergeBlocks)
 }
 
+func TestSeriesColdFlushMergesExisting(t *testing.T) {
+	opts := newSeriesTestOptions().SetColdWritesEnabled(true)
+	ropts := opts.RetentionOptions()
+	curr := time.Now().Truncate(ropts.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	series := NewDatabaseSeries(ident.StringID("foo"), ident.Tags{}, opts).(*dbSeries)
+	_, err := series.Bootstrap(nil)
+	require.NoError(t, err)
+
+	blockStart := curr.Add(-10 * ropts.BlockSize())
+	encoder := opts.EncoderPool().Get()
+	encoder.Reset(blockStart, 0)
+	for _, v := range []value{
+		{blockStart.Add(secs(1)), 1, xtime.Second, nil},
+		{blockStart.Add(secs(3)), 3, xtime.Second, nil},
+	} {
+		require.NoError(t, encoder.Encode(ts.Datapoint{
+			Timestamp: v.timestamp,
+			Value:     v.value,
+		}, v.unit, v.annotation))
+	}
+	existing := encoder.Discard()
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	// Cold writes take precedence over the existing data.
+	require.NoError(t, series.Write(ctx, blockStart.Add(secs(2)), 2, xtime.Second, nil))
+	require.NoError(t, series.Write(ctx, blockStart.Add(secs(3)), 30, xtime.Second, nil))
+	require.Equal(t, []time.Time{blockStart}, series.ColdFlushBlockStarts())
+
+	var persisted []byte
+	persistFn := func(_ ident.ID, _ ident.Tags, segment ts.Segment, _ uint32) error {
+		if segment.Head != nil {
+			persisted = append(persisted, segment.Head.Bytes()...)
+		}
+		if segment.Tail != nil {
+			persisted = append(persisted, segment.Tail.Bytes()...)
+		}
+		return nil
+	}
+	outcome, err := series.ColdFlush(ctx, blockStart, existing, persistFn)
+	require.NoError(t, err)
+	require.Equal(t, FlushOutcomeFlushedToDisk, outcome)
+
+	merged := xio.BlockReader{
+		SegmentReader: xio.NewSegmentReader(ts.NewSegment(
+			checked.NewBytes(persisted, nil), nil, ts.FinalizeNone)),
+		Start:     blockStart,
+		BlockSize: ropts.BlockSize(),
+	}
+	assertValuesEqual(t, []value{
+		{blockStart.Add(secs(1)), 1, xtime.Second, nil},
+		{blockStart.Add(secs(2)), 2, xtime.Second, nil},
+		{blockStart.Add(secs(3)), 30, xtime.Second, nil},
+	}, [][]xio.BlockReader{{merged}}, opts)
+
+	series.ColdFlushCompleted(blockStart, true)
+	require.Empty(t, series.ColdFlushBlockStarts())
+}
+
+func TestSeriesColdFlushNotBootstrappedCarriesOverExisting(t *testing.T) {
+	opts := newSeriesTestOptions().SetColdWritesEnabled(true)
+	blockStart := time.Now().Truncate(opts.RetentionOptions().BlockSize())
+	series := NewDatabaseSeries(ident.StringID("foo"), ident.Tags{}, opts).(*dbSeries)
+
+	data := []byte{1, 2, 3}
+	existing := ts.NewSegment(checked.NewBytes(data, nil), nil, ts.FinalizeNone)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	var persisted []byte
+	persistFn := func(_ ident.ID, _ ident.Tags, segment ts.Segment, _ uint32) error {
+		persisted = append(persisted, segment.Head.Bytes()...)
+		return nil
+	}
+	outcome, err := series.ColdFlush(ctx, blockStart, existing, persistFn)
+	require.Equal(t, errSeriesNotBootstrapped, err)
+	require.Equal(t, FlushOutcomeErr, outcome)
+	require.Equal(t, data, persisted)
+}
+
 func TestSeriesTickCacheLRU(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
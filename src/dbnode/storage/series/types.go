@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
@@ -98,6 +99,24 @@ type DatabaseSeries interface {
 	// not been rotated into a block yet
 	Snapshot(ctx context.Context, blockStart time.Time, persistFn persist.DataFn) error
 
+	// ColdFlushBlockStarts returns the block starts with cold writes that are
+	// yet to be flushed
+	ColdFlushBlockStarts() []time.Time
+
+	// ColdFlush merges the cold writes of this series for a given start time
+	// with the existing data for the block on disk, if any, and persists the result,
+	// the existing data is persisted as is if the cold writes can't be merged
+	ColdFlush(
+		ctx context.Context,
+		blockStart time.Time,
+		existing ts.Segment,
+		persistFn persist.DataFn,
+	) (FlushOutcome, error)
+
+	// ColdFlushCompleted releases the cold writes flushed for a given start time
+	// if the cold flush succeeded, otherwise they are retried with the next cold flush
+	ColdFlushCompleted(blockStart time.Time, success bool)
+
 	// Close will close the series and if pooled returned to the pool
 	Close()
 
@@ -261,11 +280,20 @@ type Options interface {
 
 	// Stats returns the configured Stats.
 	Stats() Stats
+
+	// SetColdWritesEnabled sets whether writes older than buffer past are
+	// accepted into cold buffers rather than rejected.
+	SetColdWritesEnabled(value bool) Options
+
+	// ColdWritesEnabled returns whether writes older than buffer past are
+	// accepted into cold buffers rather than rejected.
+	ColdWritesEnabled() bool
 }
 
 // Stats is passed down from namespace/shard to avoid allocations per series.
 type Stats struct {
 	encoderCreated tally.Counter
+	coldWrites     tally.Counter
 }
 
 // NewStats returns a new Stats for the provided scope.
@@ -273,6 +301,7 @@ func NewStats(scope tally.Scope) Stats {
 	subScope := scope.SubScope("series")
 	return Stats{
 		encoderCreated: subScope.Counter("encoder-created"),
+		coldWrites:     subScope.Counter("cold-writes"),
 	}
 }
 
@@ -280,3 +309,8 @@ func NewStats(scope tally.Scope) Stats {
 func (s Stats) IncCreatedEncoders() {
 	s.encoderCreated.Inc(1)
 }
+
+// IncColdWrites incs the ColdWrites stat.
+func (s Stats) IncColdWrites() {
+	s.coldWrites.Inc(1)
+}
@@ -160,6 +160,7 @@ type dbShard struct {
 	contextPool              context.Pool
 	flushState               shardFlushState
 	snapshotState            shardSnapshotState
+	tombstones               shardTombstones
 	tickWg                   *sync.WaitGroup
 	runtimeOptsListenClosers []xclose.SimpleCloser
 	currRuntimeOptions       dbShardRuntimeOptions
@@ -191,10 +192,15 @@ type dbShardMetrics struct {
 	insertAsyncWriteErrors        tally.Counter
 	seriesBootstrapBlocksToBuffer tally.Counter
 	seriesBootstrapBlocksMerged   tally.Counter
+	coldFlushSeries               tally.Counter
+	coldFlushBytes                tally.Counter
+	coldFlushErrors               tally.Counter
+	coldFlushLatency              tally.Timer
 }
 
 func newDatabaseShardMetrics(scope tally.Scope) dbShardMetrics {
 	seriesBootstrapScope := scope.SubScope("series-bootstrap")
+	coldFlushScope := scope.SubScope("cold-flush")
 	return dbShardMetrics{
 		create:       scope.Counter("create"),
 		close:        scope.Counter("close"),
@@ -211,6 +217,10 @@ func newDatabaseShardMetrics(scope tally.Scope) dbShardMetrics {
 		}).Counter("insert-async.errors"),
 		seriesBootstrapBlocksToBuffer: seriesBootstrapScope.Counter("blocks-to-buffer"),
 		seriesBootstrapBlocksMerged:   seriesBootstrapScope.Counter("blocks-merged"),
+		coldFlushSeries:               coldFlushScope.Counter("series"),
+		coldFlushBytes:                coldFlushScope.Counter("bytes"),
+		coldFlushErrors:               coldFlushScope.Counter("errors"),
+		coldFlushLatency:              coldFlushScope.Timer("latency"),
 	}
 }
 
@@ -576,6 +586,12 @@ func (s *dbShard) isClosingWithLock() bool {
 
 func (s *dbShard) Tick(c context.Cancellable, tickStart time.Time) (tickResult, error) {
 	s.removeAnyFlushStatesTooEarly(tickStart)
+	if err := s.pruneTombstones(tickStart); err != nil {
+		s.logger.WithFields(
+			xlog.NewField("shard", s.ID()),
+			xlog.NewField("error", err.Error()),
+		).Error("unable to prune expired tombstones")
+	}
 	return s.tickAndExpire(c, tickPolicyRegular)
 }
 
@@ -873,6 +889,10 @@ func (s *dbShard) writeAndIndex(
 		commitLogSeriesUniqueIndex = result.entry.Index
 	}
 
+	// A series that was deleted entirely is visible to queries again once
+	// written to after the deletion.
+	s.restoreRemovedSeries(id, timestamp)
+
 	// Write commit log
 	series := commitlog.Series{
 		UniqueIndex: commitLogSeriesUniqueIndex,
@@ -916,15 +936,20 @@ func (s *dbShard) ReadEncoded(
 		return nil, err
 	}
 
+	var blocks [][]xio.BlockReader
 	if entry != nil {
-		return entry.Series.ReadEncoded(ctx, start, end)
+		blocks, err = entry.Series.ReadEncoded(ctx, start, end)
+	} else {
+		retriever := s.seriesBlockRetriever
+		onRetrieve := s.seriesOnRetrieveBlock
+		opts := s.seriesOpts
+		reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, nil, opts)
+		blocks, err = reader.ReadEncoded(ctx, start, end)
 	}
-
-	retriever := s.seriesBlockRetriever
-	onRetrieve := s.seriesOnRetrieveBlock
-	opts := s.seriesOpts
-	reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, nil, opts)
-	return reader.ReadEncoded(ctx, start, end)
+	if err != nil || len(s.tombstonesFor(id, start, end)) == 0 {
+		return blocks, err
+	}
+	return s.filterTombstonedBlocks(ctx, id, blocks)
 }
 
 // lookupEntryWithLock returns the entry for a given id while holding a read lock or a write lock.
@@ -1369,18 +1394,23 @@ func (s *dbShard) FetchBlocks(
 		return nil, err
 	}
 
+	var results []block.FetchBlockResult
 	if entry != nil {
-		return entry.Series.FetchBlocks(ctx, starts)
+		results, err = entry.Series.FetchBlocks(ctx, starts)
+	} else {
+		retriever := s.seriesBlockRetriever
+		onRetrieve := s.seriesOnRetrieveBlock
+		opts := s.seriesOpts
+		// Nil for onRead callback because we don't want peer bootstrapping to impact
+		// the behavior of the LRU
+		var onReadCb block.OnReadBlock
+		reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, onReadCb, opts)
+		results, err = reader.FetchBlocks(ctx, starts)
 	}
-
-	retriever := s.seriesBlockRetriever
-	onRetrieve := s.seriesOnRetrieveBlock
-	opts := s.seriesOpts
-	// Nil for onRead callback because we don't want peer bootstrapping to impact
-	// the behavior of the LRU
-	var onReadCb block.OnReadBlock
-	reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, onReadCb, opts)
-	return reader.FetchBlocks(ctx, starts)
+	if err != nil {
+		return nil, err
+	}
+	return s.filterTombstonedFetchResults(ctx, id, results)
 }
 
 func (s *dbShard) fetchActiveBlocksMetadata(
@@ -1687,6 +1717,15 @@ func (s *dbShard) Bootstrap(
 		shardBootstrapResult = dbShardBootstrapResult{}
 		multiErr             = xerrors.NewMultiError()
 	)
+	if err := s.loadTombstones(); err != nil {
+		s.logger.WithFields(
+			xlog.NewField("shard", s.ID()),
+			xlog.NewField("namespace", s.namespace.ID()),
+			xlog.NewField("error", err.Error()),
+		).Error("unable to read tombstones file in shard bootstrap")
+		multiErr = multiErr.Add(err)
+	}
+
 	for _, elem := range bootstrappedSeries.Iter() {
 		dbBlocks := elem.Value()
 
@@ -1811,13 +1850,16 @@ func (s *dbShard) Flush(
 	var multiErr xerrors.MultiError
 	tmpCtx := context.NewContext()
 
+	// Drop the datapoints of deleted series so that they never reach disk.
+	persistFn := s.newTombstonesPersistFn(blockStart, prepared.Persist)
+
 	flushResult := dbShardFlushResult{}
 	s.forEachShardEntry(func(entry *lookup.Entry) bool {
 		curr := entry.Series
 		// Use a temporary context here so the stream readers can be returned to
 		// the pool after we finish fetching flushing the series.
 		tmpCtx.Reset()
-		flushOutcome, err := curr.Flush(tmpCtx, blockStart, prepared.Persist)
+		flushOutcome, err := curr.Flush(tmpCtx, blockStart, persistFn)
 		tmpCtx.BlockingClose()
 
 		if err != nil {
@@ -1908,6 +1950,348 @@ func (s *dbShard) Snapshot(
 	return multiErr.FinalError()
 }
 
+func (s *dbShard) ColdFlush(flush persist.DataFlush) error {
+	// We don't flush data when the shard is still bootstrapping
+	s.RLock()
+	if s.bootstrapState != Bootstrapped {
+		s.RUnlock()
+		return errShardNotBootstrappedToFlush
+	}
+	s.RUnlock()
+
+	var blockStarts []time.Time
+	seen := make(map[xtime.UnixNano]struct{})
+	s.forEachShardEntry(func(entry *lookup.Entry) bool {
+		for _, blockStart := range entry.Series.ColdFlushBlockStarts() {
+			key := xtime.ToUnixNano(blockStart)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			blockStarts = append(blockStarts, blockStart)
+		}
+		return true
+	})
+
+	// Blocks flushed before series were deleted are rewritten to drop the
+	// deleted datapoints.
+	tombstones, tombstoneBlockStarts := s.pendingTombstoneBlockStarts(s.nowFn())
+	for key := range tombstoneBlockStarts {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		blockStarts = append(blockStarts, key.ToTime())
+	}
+	sort.Slice(blockStarts, func(i, j int) bool {
+		return blockStarts[i].Before(blockStarts[j])
+	})
+
+	var (
+		multiErr = xerrors.NewMultiError()
+		failed   = make(map[int]struct{})
+	)
+	for _, blockStart := range blockStarts {
+		// Blocks that have not been flushed yet still have cold writes merged
+		// by a cold flush once the regular flush for the block succeeds, which
+		// also drops the deleted datapoints.
+		if s.FlushState(blockStart).Status != fileOpSuccess {
+			continue
+		}
+		if err := s.coldFlushBlock(blockStart, flush); err != nil {
+			s.metrics.coldFlushErrors.Inc(1)
+			multiErr = multiErr.Add(fmt.Errorf(
+				"cold flush for block %s failed: %v", blockStart.String(), err))
+			for _, idx := range tombstoneBlockStarts[xtime.ToUnixNano(blockStart)] {
+				failed[idx] = struct{}{}
+			}
+		}
+	}
+
+	applied := make([]persist.Tombstone, 0, len(tombstones))
+	for idx, tombstone := range tombstones {
+		if _, ok := failed[idx]; !ok {
+			applied = append(applied, tombstone)
+		}
+	}
+	if err := s.markTombstonesApplied(applied); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+	return multiErr.FinalError()
+}
+
+func (s *dbShard) coldFlushBlock(
+	blockStart time.Time,
+	flush persist.DataFlush,
+) error {
+	var (
+		callStart      = s.nowFn()
+		fsOpts         = s.opts.CommitLogOptions().FilesystemOptions()
+		filePathPrefix = fsOpts.FilePathPrefix()
+		nsID           = s.namespace.ID()
+		result         dbShardColdFlushResult
+		multiErr       xerrors.MultiError
+		// coldFlushed holds the entries that have had their cold writes sealed
+		// and which need to be told of the outcome of the cold flush.
+		coldFlushed = make(map[*lookup.Entry]bool)
+	)
+	defer func() {
+		success := multiErr.Empty()
+		for entry, merged := range coldFlushed {
+			entry.Series.ColdFlushCompleted(blockStart, success && merged)
+			entry.DecrementReaderWriterCount()
+		}
+		s.metrics.coldFlushLatency.Record(s.nowFn().Sub(callStart))
+	}()
+
+	existing, ok, err := fs.FileSetAt(filePathPrefix, nsID, s.ID(), blockStart)
+	if err != nil {
+		multiErr = multiErr.Add(err)
+		return multiErr.FinalError()
+	}
+
+	var reader fs.DataFileSetReader
+	if ok {
+		reader, err = fs.NewReader(s.opts.BytesPool(), fsOpts)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			return multiErr.FinalError()
+		}
+		if err := reader.Open(fs.DataReaderOpenOptions{
+			Identifier: fs.FileSetFileIdentifier{
+				Namespace:   nsID,
+				Shard:       s.ID(),
+				BlockStart:  blockStart,
+				VolumeIndex: existing.ID.VolumeIndex,
+			},
+			FileSetType: persist.FileSetFlushType,
+		}); err != nil {
+			multiErr = multiErr.Add(err)
+			return multiErr.FinalError()
+		}
+		defer reader.Close()
+	}
+
+	// NB: Resolve the volume the persist manager writes to so that it can be
+	// removed if the cold flush fails part way through.
+	volumeIndex, err := fs.NextDataFileSetVolumeIndex(filePathPrefix, nsID, s.ID(), blockStart)
+	if err != nil {
+		multiErr = multiErr.Add(err)
+		return multiErr.FinalError()
+	}
+
+	prepared, err := flush.PrepareData(persist.DataPrepareOptions{
+		NamespaceMetadata: s.namespace,
+		Shard:             s.ID(),
+		BlockStart:        blockStart,
+		NewVolume:         true,
+	})
+	if err != nil {
+		multiErr = multiErr.Add(err)
+		return multiErr.FinalError()
+	}
+
+	persistFn := s.newTombstonesPersistFn(blockStart, func(
+		id ident.ID,
+		tags ident.Tags,
+		segment ts.Segment,
+		checksum uint32,
+	) error {
+		result.numSeries++
+		result.numBytes += int64(segment.Len())
+		return prepared.Persist(id, tags, segment, checksum)
+	})
+
+	tmpCtx := context.NewContext()
+	coldFlush := func(entry *lookup.Entry, segment ts.Segment) {
+		persisted := false
+		seriesPersistFn := func(
+			id ident.ID,
+			tags ident.Tags,
+			segment ts.Segment,
+			checksum uint32,
+		) error {
+			if err := persistFn(id, tags, segment, checksum); err != nil {
+				return err
+			}
+			persisted = true
+			return nil
+		}
+
+		tmpCtx.Reset()
+		outcome, err := entry.Series.ColdFlush(tmpCtx, blockStart, segment, seriesPersistFn)
+		tmpCtx.BlockingClose()
+
+		if err == nil && outcome == series.FlushOutcomeBlockDoesNotExist {
+			// Nothing was sealed for the series, no need to track it.
+			entry.DecrementReaderWriterCount()
+			return
+		}
+		coldFlushed[entry] = err == nil
+		if err != nil && segment.Len() > 0 && !persisted {
+			// The existing data of the series did not make it into the new
+			// volume, fail the cold flush so the superseded volume is kept.
+			multiErr = multiErr.Add(fmt.Errorf(
+				"unable to carry over existing data for series %s: %v",
+				entry.Series.ID().String(), err))
+			return
+		}
+		if err != nil {
+			// The existing data of the series was carried over to the new
+			// volume, only the cold writes of the series are retried.
+			s.logger.WithFields(
+				xlog.NewField("shard", s.ID()),
+				xlog.NewField("id", entry.Series.ID().String()),
+				xlog.NewField("blockStart", blockStart.String()),
+				xlog.NewField("error", err.Error()),
+			).Error("unable to merge cold writes for series")
+		}
+	}
+
+	// Carry over the existing volume merging in the cold writes of any series
+	// that are in memory.
+	for reader != nil {
+		id, tagsIter, data, checksum, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			break
+		}
+
+		s.RLock()
+		entry, _, lookupErr := s.lookupEntryWithLock(id)
+		if entry != nil {
+			entry.IncrementReaderWriterCount()
+		}
+		s.RUnlock()
+
+		segment := ts.NewSegment(data, nil, ts.FinalizeHead)
+		if entry != nil {
+			coldFlush(entry, segment)
+			id.Finalize()
+			tagsIter.Close()
+		} else if lookupErr != nil && lookupErr != errShardEntryNotFound {
+			tagsIter.Close()
+			multiErr = multiErr.Add(lookupErr)
+		} else {
+			// NB: The ID and tags are retained by the writer until it is closed
+			// so they are not returned to their pools.
+			var tags ident.Tags
+			tags, err = convert.TagsFromTagsIter(id, tagsIter, s.identifierPool)
+			tagsIter.Close()
+			if err == nil {
+				err = persistFn(id, tags, segment, checksum)
+			}
+			if err != nil {
+				multiErr = multiErr.Add(err)
+			}
+		}
+		segment.Finalize()
+
+		if !multiErr.Empty() {
+			break
+		}
+	}
+
+	// Then flush the series that only have cold writes for the block.
+	if multiErr.Empty() {
+		s.forEachShardEntry(func(entry *lookup.Entry) bool {
+			if _, ok := coldFlushed[entry]; ok {
+				return true
+			}
+			entry.IncrementReaderWriterCount()
+			coldFlush(entry, ts.Segment{})
+			return true
+		})
+	}
+
+	// NB: The writer only writes out the checkpoint file if no errors were
+	// encountered persisting, however reading the existing volume can fail
+	// independently of the writer so the new volume is removed explicitly.
+	// An incomplete volume is ignored by readers and reused by the next attempt.
+	failed := !multiErr.Empty()
+	if err := prepared.Close(); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+	if failed {
+		if err := s.deleteDataFileSetVolume(blockStart, volumeIndex); err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+	if !multiErr.Empty() {
+		return multiErr.FinalError()
+	}
+
+	// Switch reads over to the new volume before releasing the cold writes
+	// so that no data goes missing in between.
+	if s.DatabaseBlockRetriever != nil {
+		if err := s.DatabaseBlockRetriever.InvalidateBlock(s.ID(), blockStart); err != nil {
+			multiErr = multiErr.Add(err)
+			return multiErr.FinalError()
+		}
+	}
+
+	s.metrics.coldFlushSeries.Inc(result.numSeries)
+	s.metrics.coldFlushBytes.Inc(result.numBytes)
+
+	// Superseded volumes can be removed now that the new volume is complete,
+	// the retriever keeps any files it still has open readable until closed.
+	if err := s.deleteSupersededDataFileSets(blockStart); err != nil {
+		s.logger.WithFields(
+			xlog.NewField("shard", s.ID()),
+			xlog.NewField("blockStart", blockStart.String()),
+			xlog.NewField("error", err.Error()),
+		).Error("unable to delete superseded fileset volumes")
+	}
+	return nil
+}
+
+func (s *dbShard) deleteDataFileSetVolume(blockStart time.Time, volumeIndex int) error {
+	filePathPrefix := s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+	filesets, err := fs.DataFileSetsAt(filePathPrefix, s.namespace.ID(), s.ID(), blockStart)
+	if err != nil {
+		return err
+	}
+
+	for _, fileset := range filesets {
+		if fileset.ID.VolumeIndex == volumeIndex {
+			return s.deleteDataFileSetFiles(fileset.AbsoluteFilepaths)
+		}
+	}
+	return nil
+}
+
+func (s *dbShard) deleteSupersededDataFileSets(blockStart time.Time) error {
+	filePathPrefix := s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+	filesets, err := fs.DataFileSetsAt(filePathPrefix, s.namespace.ID(), s.ID(), blockStart)
+	if err != nil {
+		return err
+	}
+	if len(filesets) < 2 {
+		return nil
+	}
+
+	var filesToDelete []string
+	for _, fileset := range filesets[:len(filesets)-1] {
+		filesToDelete = append(filesToDelete, fileset.AbsoluteFilepaths...)
+	}
+	return s.deleteDataFileSetFiles(filesToDelete)
+}
+
+// deleteDataFileSetFiles deletes data fileset files along with the cold storage
+// copies of any volumes among them that were offloaded. The local files are
+// kept if the cold storage copies can't be deleted so that the marker files of
+// offloaded volumes remain and a later attempt can retry.
+func (s *dbShard) deleteDataFileSetFiles(filePaths []string) error {
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	if err := fs.DeleteOffloadedFiles(fsOpts, filePaths); err != nil {
+		return err
+	}
+	return s.deleteFilesFn(filePaths)
+}
+
 func (s *dbShard) FlushState(blockStart time.Time) fileOpState {
 	s.flushState.RLock()
 	state, ok := s.flushState.statesByTime[xtime.ToUnixNano(blockStart)]
@@ -2045,7 +2429,7 @@ func (s *dbShard) CleanupExpiredFileSets(earliestToRetain time.Time) error {
 				filePathPrefix, s.namespace.ID(), s.ID(), err)
 		multiErr = multiErr.Add(detailedErr)
 	}
-	if err := s.deleteFilesFn(expired); err != nil {
+	if err := s.deleteDataFileSetFiles(expired); err != nil {
 		multiErr = multiErr.Add(err)
 	}
 	return multiErr.FinalError()
@@ -2090,6 +2474,13 @@ func (r *dbShardBootstrapResult) update(u series.BootstrapResult) {
 	r.numBlocksMerged += u.NumBlocksMerged
 }
 
+// dbShardColdFlushResult is a helper struct for keeping track of the result of cold
+// flushing a block of the shard.
+type dbShardColdFlushResult struct {
+	numSeries int64
+	numBytes  int64
+}
+
 // dbShardFlushResult is a helper struct for keeping track of the result of flushing all the
 // series in the shard.
 type dbShardFlushResult struct {
@@ -23,6 +23,9 @@ package storage
 import (
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -30,6 +33,7 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/retention"
@@ -186,6 +190,40 @@ func TestShardFlushDuringBootstrap(t *testing.T) {
 	require.Equal(t, err, errShardNotBootstrappedToFlush)
 }
 
+func TestShardDeleteSeriesBatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := testDatabaseOptions()
+	fsOpts := opts.CommitLogOptions().FilesystemOptions().SetFilePathPrefix(dir)
+	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+
+	s := testDatabaseShard(t, opts)
+	defer s.Close()
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	ids := []ident.ID{ident.StringID("foo"), ident.StringID("bar"), ident.StringID("baz")}
+	end := time.Now().Truncate(time.Second)
+	_, err = s.DeleteSeries(ctx, ids, time.Time{}, end)
+	require.Equal(t, errShardNotBootstrappedToDelete, err)
+
+	s.bootstrapState = Bootstrapped
+	deleted, err := s.DeleteSeries(ctx, ids, time.Time{}, end)
+	require.NoError(t, err)
+	require.Equal(t, len(ids), deleted)
+
+	// The tombstones of the whole batch are persisted together
+	tombstones, err := fs.ReadTombstones(dir, s.namespace.ID(), s.shard, fsOpts.DecodingOptions())
+	require.NoError(t, err)
+	require.Len(t, tombstones, len(ids))
+	for _, id := range ids {
+		require.Len(t, s.tombstonesFor(id, end.Add(-time.Hour), end), 1)
+	}
+}
+
 func TestShardFlushSeriesFlushError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -312,6 +350,118 @@ func TestShardFlushSeriesFlushSuccess(t *testing.T) {
 	}, flushState)
 }
 
+func TestShardColdFlushSeriesErrorKeepsExistingData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := testDatabaseOptions()
+	fsOpts := opts.CommitLogOptions().FilesystemOptions().SetFilePathPrefix(dir)
+	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+
+	s := testDatabaseShard(t, opts)
+	defer s.Close()
+	s.bootstrapState = Bootstrapped
+
+	blockSize := s.namespace.Options().RetentionOptions().BlockSize()
+	blockStart := time.Now().Truncate(blockSize).Add(-2 * blockSize)
+	s.flushState.statesByTime[xtime.ToUnixNano(blockStart)] = fileOpState{
+		Status: fileOpSuccess,
+	}
+
+	// Write out the volume that the cold flush supersedes.
+	existing := map[string][]byte{
+		"foo": {1, 2, 3},
+		"bar": {4, 5, 6},
+	}
+	writer, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  s.namespace.ID(),
+			Shard:      s.shard,
+			BlockStart: blockStart,
+		},
+		BlockSize: blockSize,
+	}))
+	for id, data := range existing {
+		bytes := checked.NewBytes(data, nil)
+		bytes.IncRef()
+		require.NoError(t, writer.Write(ident.StringID(id), ident.Tags{}, bytes, digest.Checksum(data)))
+	}
+	require.NoError(t, writer.Close())
+
+	persisted := make(map[string]struct{})
+	flush := persist.NewMockDataFlush(ctrl)
+	flush.EXPECT().PrepareData(gomock.Any()).Return(persist.PreparedDataPersist{
+		Persist: func(id ident.ID, _ ident.Tags, _ ts.Segment, _ uint32) error {
+			persisted[id.String()] = struct{}{}
+			return nil
+		},
+		Close: func() error { return nil },
+	}, nil)
+
+	// The series "foo" fails without carrying over its existing data while
+	// the series "bar" carries over its existing data.
+	foo := addMockTestSeries(ctrl, s, ident.StringID("foo"))
+	foo.EXPECT().
+		ColdFlush(gomock.Any(), blockStart, gomock.Any(), gomock.Any()).
+		Return(series.FlushOutcomeErr, errors.New("error foo"))
+	foo.EXPECT().ColdFlushCompleted(blockStart, false).AnyTimes()
+	bar := addMockTestSeries(ctrl, s, ident.StringID("bar"))
+	bar.EXPECT().
+		ColdFlush(gomock.Any(), blockStart, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ time.Time,
+			existing ts.Segment,
+			persistFn persist.DataFn,
+		) (series.FlushOutcome, error) {
+			return series.FlushOutcomeFlushedToDisk, persistFn(ident.StringID("bar"), ident.Tags{}, existing, 0)
+		}).
+		AnyTimes()
+	bar.EXPECT().ColdFlushCompleted(blockStart, false).AnyTimes()
+
+	err = s.coldFlushBlock(blockStart, flush)
+	require.Error(t, err)
+	require.NotContains(t, persisted, "foo")
+
+	// The superseded volume is kept along with the existing data of "foo".
+	filesets, err := fs.DataFileSetsAt(dir, s.namespace.ID(), s.shard, blockStart)
+	require.NoError(t, err)
+	require.Len(t, filesets, 1)
+	require.Equal(t, 0, filesets[0].ID.VolumeIndex)
+
+	reader, err := fs.NewReader(opts.BytesPool(), fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  s.namespace.ID(),
+			Shard:      s.shard,
+			BlockStart: blockStart,
+		},
+		FileSetType: persist.FileSetFlushType,
+	}))
+	defer reader.Close()
+
+	read := make(map[string][]byte)
+	for {
+		id, tagsIter, data, _, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data.IncRef()
+		read[id.String()] = append([]byte(nil), data.Bytes()...)
+		data.DecRef()
+		tagsIter.Close()
+	}
+	require.Equal(t, existing, read)
+}
+
 func TestShardSnapshotShardNotBootstrapped(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,521 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/context"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+)
+
+// shardTombstones holds the deleted time ranges of the series of a shard,
+// they are persisted to the shard tombstones file whenever they change.
+type shardTombstones struct {
+	sync.RWMutex
+
+	// persistLock serializes writes of the tombstones file so that the last
+	// write always reflects the latest tombstones.
+	persistLock sync.Mutex
+
+	// byID contains the tombstones of each series keyed by the string of
+	// the series ID bytes.
+	byID map[string][]persist.Tombstone
+
+	// removed contains the deletion time of series that have been entirely
+	// deleted and removed from the index, and not written to since.
+	removed map[string]time.Time
+}
+
+func (t *shardTombstones) addWithLock(tombstone persist.Tombstone) {
+	if t.byID == nil {
+		t.byID = make(map[string][]persist.Tombstone)
+	}
+	key := tombstone.ID.String()
+	t.byID[key] = append(t.byID[key], tombstone)
+
+	if !tombstone.Start.IsZero() {
+		return
+	}
+	if t.removed == nil {
+		t.removed = make(map[string]time.Time)
+	}
+	if removedAt, ok := t.removed[key]; !ok || removedAt.Before(tombstone.End) {
+		t.removed[key] = tombstone.End
+	}
+}
+
+func (t *shardTombstones) allWithRLock() []persist.Tombstone {
+	var all []persist.Tombstone
+	for _, tombstones := range t.byID {
+		all = append(all, tombstones...)
+	}
+	return all
+}
+
+// DeleteSeries records tombstones for the datapoints of the series within
+// [start, end), the tombstones are written to the commit log and then to the
+// shard tombstones file once for the whole batch before returning, so that
+// deleting many series does not rewrite the file for each of them. It
+// returns the number of series whose tombstones were recorded.
+func (s *dbShard) DeleteSeries(
+	ctx context.Context,
+	ids []ident.ID,
+	start, end time.Time,
+) (int, error) {
+	s.RLock()
+	bootstrapped := s.bootstrapState == Bootstrapped
+	s.RUnlock()
+	if !bootstrapped {
+		return 0, errShardNotBootstrappedToDelete
+	}
+
+	var (
+		tombstones = make([]persist.Tombstone, 0, len(ids))
+		multiErr   = xerrors.NewMultiError()
+	)
+	for _, id := range ids {
+		tombstone, err := s.writeTombstone(ctx, id, start, end)
+		if err != nil {
+			multiErr = multiErr.Add(fmt.Errorf(
+				"unable to delete series %s: %v", id.String(), err))
+			continue
+		}
+		tombstones = append(tombstones, tombstone)
+	}
+	if len(tombstones) == 0 {
+		return 0, multiErr.FinalError()
+	}
+
+	s.tombstones.Lock()
+	for _, tombstone := range tombstones {
+		s.tombstones.addWithLock(tombstone)
+	}
+	s.tombstones.Unlock()
+
+	if err := s.persistTombstones(); err != nil {
+		return 0, err
+	}
+
+	if start.IsZero() && s.reverseIndex != nil {
+		for _, tombstone := range tombstones {
+			s.reverseIndex.RemoveSeries(tombstone.ID)
+		}
+	}
+	return len(tombstones), multiErr.FinalError()
+}
+
+// writeTombstone writes the tombstone of a series to the commit log.
+func (s *dbShard) writeTombstone(
+	ctx context.Context,
+	id ident.ID,
+	start, end time.Time,
+) (persist.Tombstone, error) {
+	s.RLock()
+	entry, _, err := s.lookupEntryWithLock(id)
+	if entry != nil {
+		entry.IncrementReaderWriterCount()
+	}
+	s.RUnlock()
+	if err != nil && err != errShardEntryNotFound {
+		return persist.Tombstone{}, err
+	}
+
+	tombstone := persist.Tombstone{
+		ID:    ident.BytesID(append([]byte(nil), id.Bytes()...)),
+		Start: start,
+		End:   end,
+	}
+
+	series := commitlog.Series{
+		Namespace: s.namespace.ID(),
+		ID:        tombstone.ID,
+		Shard:     s.shard,
+	}
+	if entry != nil {
+		series.UniqueIndex = entry.Index
+		series.Tags = entry.Series.Tags()
+		entry.DecrementReaderWriterCount()
+	} else {
+		series.UniqueIndex = s.increasingIndex.nextIndex()
+	}
+	if writer, ok := s.commitLogWriter.(commitLogTombstoneWriter); ok {
+		if err := writer.WriteTombstone(ctx, series, start, end); err != nil {
+			return persist.Tombstone{}, err
+		}
+	}
+	return tombstone, nil
+}
+
+func (s *dbShard) HasPendingTombstones() bool {
+	s.tombstones.RLock()
+	defer s.tombstones.RUnlock()
+	for _, tombstones := range s.tombstones.byID {
+		for _, tombstone := range tombstones {
+			if !tombstone.Applied {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// loadTombstones replaces the tombstones of the shard with those in the
+// shard tombstones file.
+func (s *dbShard) loadTombstones() error {
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	tombstones, err := fs.ReadTombstones(fsOpts.FilePathPrefix(), s.namespace.ID(),
+		s.shard, fsOpts.DecodingOptions())
+	if err != nil {
+		return err
+	}
+
+	s.tombstones.Lock()
+	s.tombstones.byID = nil
+	s.tombstones.removed = nil
+	for _, tombstone := range tombstones {
+		s.tombstones.addWithLock(tombstone)
+	}
+	removed := make([]ident.ID, 0, len(s.tombstones.removed))
+	for key := range s.tombstones.removed {
+		removed = append(removed, ident.StringID(key))
+	}
+	s.tombstones.Unlock()
+
+	// NB: A series written to after it was deleted remains removed from the
+	// index until it is next written to after a restart.
+	if s.reverseIndex != nil {
+		for _, id := range removed {
+			s.reverseIndex.RemoveSeries(id)
+		}
+	}
+	return nil
+}
+
+func (s *dbShard) persistTombstones() error {
+	s.tombstones.persistLock.Lock()
+	defer s.tombstones.persistLock.Unlock()
+
+	s.tombstones.RLock()
+	tombstones := s.tombstones.allWithRLock()
+	s.tombstones.RUnlock()
+
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	return fs.WriteTombstones(fsOpts.FilePathPrefix(), s.namespace.ID(), s.shard,
+		tombstones, fsOpts.NewFileMode(), fsOpts.NewDirectoryMode())
+}
+
+// tombstonesFor returns the tombstones of a series that overlap [start, end).
+func (s *dbShard) tombstonesFor(id ident.ID, start, end time.Time) []persist.Tombstone {
+	s.tombstones.RLock()
+	defer s.tombstones.RUnlock()
+	if len(s.tombstones.byID) == 0 {
+		return nil
+	}
+
+	var result []persist.Tombstone
+	for _, tombstone := range s.tombstones.byID[string(id.Bytes())] {
+		if tombstone.Overlaps(start, end) {
+			result = append(result, tombstone)
+		}
+	}
+	return result
+}
+
+// restoreRemovedSeries makes a series that was entirely deleted visible to
+// index queries again once it is written to after the deletion.
+func (s *dbShard) restoreRemovedSeries(id ident.ID, timestamp time.Time) {
+	s.tombstones.RLock()
+	removedAt, ok := s.tombstones.removed[string(id.Bytes())]
+	s.tombstones.RUnlock()
+	if !ok || timestamp.Before(removedAt) {
+		return
+	}
+
+	s.tombstones.Lock()
+	delete(s.tombstones.removed, id.String())
+	s.tombstones.Unlock()
+
+	if s.reverseIndex != nil {
+		s.reverseIndex.RestoreSeries(id)
+	}
+}
+
+// pruneTombstones drops the tombstones that only cover expired data.
+func (s *dbShard) pruneTombstones(tickStart time.Time) error {
+	retentionStart := retention.FlushTimeStart(s.namespace.Options().RetentionOptions(), tickStart)
+
+	var restored []ident.ID
+	s.tombstones.Lock()
+	pruned := false
+	for key, tombstones := range s.tombstones.byID {
+		retained := tombstones[:0]
+		for _, tombstone := range tombstones {
+			if tombstone.End.After(retentionStart) {
+				retained = append(retained, tombstone)
+			}
+		}
+		if len(retained) == len(tombstones) {
+			continue
+		}
+		pruned = true
+		if len(retained) > 0 {
+			s.tombstones.byID[key] = retained
+			continue
+		}
+		delete(s.tombstones.byID, key)
+		if _, ok := s.tombstones.removed[key]; ok {
+			delete(s.tombstones.removed, key)
+			restored = append(restored, ident.StringID(key))
+		}
+	}
+	s.tombstones.Unlock()
+
+	if !pruned {
+		return nil
+	}
+	if s.reverseIndex != nil {
+		for _, id := range restored {
+			s.reverseIndex.RestoreSeries(id)
+		}
+	}
+	return s.persistTombstones()
+}
+
+// pendingTombstoneBlockStarts returns the tombstones whose data has not yet
+// been dropped from the flushed filesets along with the block starts that
+// need to be rewritten to drop it.
+func (s *dbShard) pendingTombstoneBlockStarts(
+	now time.Time,
+) ([]persist.Tombstone, map[xtime.UnixNano][]int) {
+	var (
+		ropts          = s.namespace.Options().RetentionOptions()
+		blockSize      = ropts.BlockSize()
+		retentionStart = retention.FlushTimeStart(ropts, now)
+		pending        []persist.Tombstone
+		blockStarts    = make(map[xtime.UnixNano][]int)
+	)
+	s.tombstones.RLock()
+	for _, tombstones := range s.tombstones.byID {
+		for _, tombstone := range tombstones {
+			if tombstone.Applied {
+				continue
+			}
+			start, end := tombstone.Start, tombstone.End
+			if start.Before(retentionStart) {
+				start = retentionStart
+			}
+			if end.After(now) {
+				end = now
+			}
+			for blockStart := start.Truncate(blockSize); blockStart.Before(end); blockStart = blockStart.Add(blockSize) {
+				key := xtime.ToUnixNano(blockStart)
+				blockStarts[key] = append(blockStarts[key], len(pending))
+			}
+			pending = append(pending, tombstone)
+		}
+	}
+	s.tombstones.RUnlock()
+	return pending, blockStarts
+}
+
+// markTombstonesApplied marks the given tombstones as applied and persists
+// the change to the shard tombstones file.
+func (s *dbShard) markTombstonesApplied(applied []persist.Tombstone) error {
+	if len(applied) == 0 {
+		return nil
+	}
+
+	s.tombstones.Lock()
+	for _, a := range applied {
+		tombstones := s.tombstones.byID[a.ID.String()]
+		for i := range tombstones {
+			if tombstones[i].Start.Equal(a.Start) && tombstones[i].End.Equal(a.End) {
+				tombstones[i].Applied = true
+			}
+		}
+	}
+	s.tombstones.Unlock()
+
+	return s.persistTombstones()
+}
+
+// newTombstonesPersistFn returns a persist function that drops the deleted
+// datapoints of each series before persisting it.
+func (s *dbShard) newTombstonesPersistFn(
+	blockStart time.Time,
+	persistFn persist.DataFn,
+) persist.DataFn {
+	blockEnd := blockStart.Add(s.namespace.Options().RetentionOptions().BlockSize())
+	return func(id ident.ID, tags ident.Tags, segment ts.Segment, checksum uint32) error {
+		tombstones := s.tombstonesFor(id, blockStart, blockEnd)
+		if len(tombstones) == 0 {
+			return persistFn(id, tags, segment, checksum)
+		}
+
+		readers := []xio.SegmentReader{xio.NewSegmentReader(segment)}
+		filtered, err := s.dropTombstoned(blockStart, readers, tombstones)
+		if err != nil {
+			return err
+		}
+		defer filtered.Finalize()
+
+		if filtered.Len() == 0 {
+			// Every datapoint of the series in this block was deleted.
+			return nil
+		}
+		return persistFn(id, tags, filtered, digest.SegmentChecksum(filtered))
+	}
+}
+
+// filterTombstonedBlocks drops the deleted datapoints of a series from the
+// blocks read for it.
+func (s *dbShard) filterTombstonedBlocks(
+	ctx context.Context,
+	id ident.ID,
+	blocks [][]xio.BlockReader,
+) ([][]xio.BlockReader, error) {
+	filtered := blocks[:0]
+	for _, readers := range blocks {
+		if len(readers) == 0 {
+			continue
+		}
+		readers, err := s.filterTombstonedBlock(ctx, id, readers)
+		if err != nil {
+			return nil, err
+		}
+		if len(readers) > 0 {
+			filtered = append(filtered, readers)
+		}
+	}
+	return filtered, nil
+}
+
+// filterTombstonedFetchResults drops the deleted datapoints of a series from
+// the block results fetched for it.
+func (s *dbShard) filterTombstonedFetchResults(
+	ctx context.Context,
+	id ident.ID,
+	results []block.FetchBlockResult,
+) ([]block.FetchBlockResult, error) {
+	for i := range results {
+		if results[i].Err != nil || len(results[i].Blocks) == 0 {
+			continue
+		}
+		readers, err := s.filterTombstonedBlock(ctx, id, results[i].Blocks)
+		if err != nil {
+			return nil, err
+		}
+		results[i].Blocks = readers
+	}
+	return results, nil
+}
+
+func (s *dbShard) filterTombstonedBlock(
+	ctx context.Context,
+	id ident.ID,
+	readers []xio.BlockReader,
+) ([]xio.BlockReader, error) {
+	var (
+		blockStart = readers[0].Start
+		blockSize  = readers[0].BlockSize
+		tombstones = s.tombstonesFor(id, blockStart, blockStart.Add(blockSize))
+	)
+	if len(tombstones) == 0 {
+		return readers, nil
+	}
+
+	segmentReaders := make([]xio.SegmentReader, 0, len(readers))
+	for _, reader := range readers {
+		segmentReaders = append(segmentReaders, reader.SegmentReader)
+	}
+	segment, err := s.dropTombstoned(blockStart, segmentReaders, tombstones)
+	if err != nil {
+		return nil, err
+	}
+	if segment.Len() == 0 {
+		segment.Finalize()
+		return nil, nil
+	}
+
+	reader := xio.NewSegmentReader(segment)
+	ctx.RegisterFinalizer(reader)
+	return []xio.BlockReader{{
+		SegmentReader: reader,
+		Start:         blockStart,
+		BlockSize:     blockSize,
+	}}, nil
+}
+
+// dropTombstoned merges the readers of a block into a single segment without
+// the datapoints covered by any of the tombstones.
+func (s *dbShard) dropTombstoned(
+	blockStart time.Time,
+	readers []xio.SegmentReader,
+	tombstones []persist.Tombstone,
+) (ts.Segment, error) {
+	var (
+		bopts   = s.seriesOpts.DatabaseBlockOptions()
+		iter    = s.opts.MultiReaderIteratorPool().Get()
+		encoder = bopts.EncoderPool().Get()
+	)
+	defer iter.Close()
+	encoder.Reset(blockStart, bopts.DatabaseBlockAllocSize())
+
+	iter.Reset(readers, blockStart, s.namespace.Options().RetentionOptions().BlockSize())
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if tombstonesCover(tombstones, dp.Timestamp) {
+			continue
+		}
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return ts.Segment{}, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		encoder.Close()
+		return ts.Segment{}, err
+	}
+
+	return encoder.Discard(), nil
+}
+
+func tombstonesCover(tombstones []persist.Tombstone, at time.Time) bool {
+	for _, tombstone := range tombstones {
+		if tombstone.Covers(at) {
+			return true
+		}
+	}
+	return false
+}
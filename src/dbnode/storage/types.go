@@ -111,6 +111,15 @@ type Database interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// AggregateQuery resolves the given query into the distinct tag names
+	// and values of the matching series.
+	AggregateQuery(
+		ctx context.Context,
+		namespace ident.ID,
+		query index.Query,
+		opts index.AggregateQueryOptions,
+	) (index.AggregateQueryResult, error)
+
 	// ReadEncoded retrieves encoded segments for an ID
 	ReadEncoded(
 		ctx context.Context,
@@ -141,6 +150,17 @@ type Database interface {
 		opts block.FetchBlocksMetadataOptions,
 	) (block.FetchBlocksMetadataResults, PageToken, error)
 
+	// BlockMerkleTree returns the Merkle tree of the given depth over the
+	// series checksums of a block of a shard, trees are cached and rebuilt
+	// once older than the repair Merkle tree refresh interval.
+	BlockMerkleTree(
+		ctx context.Context,
+		namespace ident.ID,
+		shard uint32,
+		blockStart time.Time,
+		depth int,
+	) (*repair.MerkleTree, error)
+
 	// Bootstrap bootstraps the database.
 	Bootstrap() error
 
@@ -156,6 +176,17 @@ type Database interface {
 	// Truncate truncates data for the given namespace
 	Truncate(namespace ident.ID) (int64, error)
 
+	// DeleteTagged deletes the datapoints within [start, end) of the series
+	// matching the query from the given namespace, a zero start deletes all
+	// datapoints of the series written before end. It returns the number of
+	// series the datapoints were deleted from.
+	DeleteTagged(
+		ctx context.Context,
+		namespace ident.ID,
+		query index.Query,
+		start, end time.Time,
+	) (int64, error)
+
 	// BootstrapState captures and returns a snapshot of the databases' bootstrap state.
 	BootstrapState() DatabaseBootstrapState
 }
@@ -241,6 +272,14 @@ type databaseNamespace interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// AggregateQuery resolves the given query into the distinct tag names
+	// and values of the matching series.
+	AggregateQuery(
+		ctx context.Context,
+		query index.Query,
+		opts index.AggregateQueryOptions,
+	) (index.AggregateQueryResult, error)
+
 	// ReadEncoded reads data for given id within [start, end)
 	ReadEncoded(
 		ctx context.Context,
@@ -281,6 +320,10 @@ type databaseNamespace interface {
 		flush persist.IndexFlush,
 	) error
 
+	// ColdFlush merges cold writes with the data already flushed for their
+	// blocks and drops any deleted data, writing the result to new fileset volumes
+	ColdFlush(flush persist.DataFlush) error
+
 	// Snapshot snapshots unflushed in-memory data
 	Snapshot(blockStart, snapshotTime time.Time, flush persist.DataFlush) error
 
@@ -302,6 +345,15 @@ type databaseNamespace interface {
 	// Truncate truncates the in-memory data for this namespace
 	Truncate() (int64, error)
 
+	// DeleteTagged deletes the datapoints within [start, end) of the series
+	// matching the query, a zero start deletes all datapoints of the series
+	// written before end.
+	DeleteTagged(
+		ctx context.Context,
+		query index.Query,
+		start, end time.Time,
+	) (int64, error)
+
 	// Repair repairs the namespace data for a given time range
 	Repair(repairer databaseShardRepairer, tr xtime.Range) error
 
@@ -380,6 +432,19 @@ type databaseShard interface {
 		opts block.FetchBlocksMetadataOptions,
 	) (block.FetchBlocksMetadataResults, PageToken, error)
 
+	// DeleteSeries records tombstones deleting the datapoints of the series
+	// within [start, end), a zero start deletes all datapoints before end. It
+	// returns the number of series deleted.
+	DeleteSeries(
+		ctx context.Context,
+		ids []ident.ID,
+		start, end time.Time,
+	) (int, error)
+
+	// HasPendingTombstones returns whether there are deleted datapoints that
+	// have not yet been dropped from the flushed filesets of this shard.
+	HasPendingTombstones() bool
+
 	// Bootstrap bootstraps the shard with provided data.
 	Bootstrap(
 		bootstrappedSeries *result.Map,
@@ -394,6 +459,11 @@ type databaseShard interface {
 	// Snapshot snapshot's the unflushed series' in this shard.
 	Snapshot(blockStart, snapshotStart time.Time, flush persist.DataFlush) error
 
+	// ColdFlush merges the cold writes of the series' in this shard with the
+	// data already flushed for their blocks and drops any deleted data,
+	// writing new fileset volumes.
+	ColdFlush(flush persist.DataFlush) error
+
 	// FlushState returns the flush state for this shard at block start.
 	FlushState(blockStart time.Time) fileOpState
 
@@ -434,6 +504,22 @@ type namespaceIndex interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// AggregateQuery resolves the given query into the distinct tag names
+	// and values of the matching documents.
+	AggregateQuery(
+		ctx context.Context,
+		query index.Query,
+		opts index.AggregateQueryOptions,
+	) (index.AggregateQueryResult, error)
+
+	// RemoveSeries removes a series from the results of queries until it is
+	// restored, the series is only dropped from the index segments once the
+	// blocks containing it expire.
+	RemoveSeries(id ident.ID)
+
+	// RestoreSeries makes a series removed from query results visible again.
+	RestoreSeries(id ident.ID)
+
 	// Bootstrap bootstraps the index the provided segments.
 	Bootstrap(
 		bootstrapResults result.IndexResults,
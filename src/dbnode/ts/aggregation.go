@@ -0,0 +1,86 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ts
+
+import (
+	"fmt"
+)
+
+// AggregationType is the function used to combine the datapoints that fall
+// within a single step when downsampling a series.
+type AggregationType uint8
+
+const (
+	// AggregationNone performs no aggregation.
+	AggregationNone AggregationType = iota
+	// AggregationMin takes the minimum value within a step.
+	AggregationMin
+	// AggregationMax takes the maximum value within a step.
+	AggregationMax
+	// AggregationSum takes the sum of the values within a step.
+	AggregationSum
+	// AggregationCount takes the number of values within a step.
+	AggregationCount
+	// AggregationLast takes the last value within a step.
+	AggregationLast
+	// AggregationAvg takes the mean of the values within a step.
+	AggregationAvg
+)
+
+var validAggregationTypes = []AggregationType{
+	AggregationMin,
+	AggregationMax,
+	AggregationSum,
+	AggregationCount,
+	AggregationLast,
+	AggregationAvg,
+}
+
+// Validate returns an error if the aggregation type is not a known
+// aggregation function.
+func (t AggregationType) Validate() error {
+	for _, valid := range validAggregationTypes {
+		if t == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid aggregation type: %d", t)
+}
+
+func (t AggregationType) String() string {
+	switch t {
+	case AggregationNone:
+		return "none"
+	case AggregationMin:
+		return "min"
+	case AggregationMax:
+		return "max"
+	case AggregationSum:
+		return "sum"
+	case AggregationCount:
+		return "count"
+	case AggregationLast:
+		return "last"
+	case AggregationAvg:
+		return "avg"
+	}
+	return "unknown"
+}
@@ -39,6 +39,8 @@ defaultTimerAggregationTypes:
 counterTransformFnType: empty
 timerTransformFnType: suffix
 gaugeTransformFnType: empty
+defaultSetAggregationTypes: [Count, Distinct]
+setTransformFnType: suffix
 `
 
 	var cfg TypesConfiguration
@@ -55,6 +57,8 @@ gaugeTransformFnType: empty
 	require.Equal(t, []byte(".p50"), opts.TypeStringForTimer(P50))
 	require.Equal(t, []byte(".p999"), opts.TypeStringForTimer(P999))
 	require.Equal(t, []byte(nil), opts.TypeStringForGauge(Last))
+	require.Equal(t, Types{Count, Distinct}, opts.DefaultSetAggregationTypes())
+	require.Equal(t, []byte(".distinct"), opts.TypeStringForSet(Distinct))
 }
 
 func TestTypesConfigurationNoTransformFnType(t *testing.T) {
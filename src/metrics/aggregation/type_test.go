@@ -31,16 +31,32 @@ import (
 )
 
 func TestTypeIsValid(t *testing.T) {
-	require.True(t, P9999.IsValid())
-	require.False(t, Type(int(P9999)+1).IsValid())
+	require.True(t, Distinct.IsValid())
+	require.False(t, Type(int(Distinct)+1).IsValid())
 }
 
 func TestTypeMaxID(t *testing.T) {
-	require.Equal(t, maxTypeID, P9999.ID())
-	require.Equal(t, P9999, Type(maxTypeID))
+	require.Equal(t, maxTypeID, Distinct.ID())
+	require.Equal(t, Distinct, Type(maxTypeID))
 	require.Equal(t, maxTypeID, len(ValidTypes))
 }
 
+func TestTypeIsValidForSet(t *testing.T) {
+	for aggType := range ValidTypes {
+		switch aggType {
+		case Count, Distinct:
+			require.True(t, aggType.IsValidForSet())
+		default:
+			require.False(t, aggType.IsValidForSet())
+		}
+	}
+	require.False(t, Distinct.IsValidForCounter())
+	require.False(t, Distinct.IsValidForTimer())
+	require.False(t, Distinct.IsValidForGauge())
+	require.True(t, Types{Count, Distinct}.IsValidForSet())
+	require.False(t, Types{Distinct, Sum}.IsValidForSet())
+}
+
 func TestTypeUnmarshalYAML(t *testing.T) {
 	inputs := []struct {
 		str         string
@@ -51,6 +67,10 @@ func TestTypeUnmarshalYAML(t *testing.T) {
 			str:      "Min",
 			expected: Min,
 		},
+		{
+			str:      "Distinct",
+			expected: Distinct,
+		},
 		{
 			str:         "Mean,",
 			expectedErr: true,
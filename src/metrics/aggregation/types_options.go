@@ -57,6 +57,12 @@ type TypesOptions interface {
 	// DefaultGaugeAggregationTypes returns the default aggregation types for gauges.
 	DefaultGaugeAggregationTypes() Types
 
+	// SetDefaultSetAggregationTypes sets the default aggregation types for sets.
+	SetDefaultSetAggregationTypes(value Types) TypesOptions
+
+	// DefaultSetAggregationTypes returns the default aggregation types for sets.
+	DefaultSetAggregationTypes() Types
+
 	// SetQuantileTypeStringFn sets the quantile type string function for timers.
 	SetQuantileTypeStringFn(value QuantileTypeStringFn) TypesOptions
 
@@ -81,6 +87,12 @@ type TypesOptions interface {
 	// GaugeTypeStringTransformFn returns the transformation function for gauge type strings.
 	GaugeTypeStringTransformFn() TypeStringTransformFn
 
+	// SetSetTypeStringTransformFn sets the transformation function for set type strings.
+	SetSetTypeStringTransformFn(value TypeStringTransformFn) TypesOptions
+
+	// SetTypeStringTransformFn returns the transformation function for set type strings.
+	SetTypeStringTransformFn() TypeStringTransformFn
+
 	// SetTypesPool sets the aggregation types pool.
 	SetTypesPool(pool TypesPool) TypesOptions
 
@@ -104,6 +116,9 @@ type TypesOptions interface {
 	// TypeStringForGauge returns the type string for the aggregation type for gauges.
 	TypeStringForGauge(value Type) []byte
 
+	// TypeStringForSet returns the type string for the aggregation type for sets.
+	TypeStringForSet(value Type) []byte
+
 	// TypeForCounter returns the aggregation type for given counter type string.
 	TypeForCounter(value []byte) Type
 
@@ -113,6 +128,9 @@ type TypesOptions interface {
 	// TypeForGauge returns the aggregation type for given gauge type string.
 	TypeForGauge(value []byte) Type
 
+	// TypeForSet returns the aggregation type for given set type string.
+	TypeForSet(value []byte) Type
+
 	// Quantiles returns the quantiles for timers.
 	Quantiles() []float64
 
@@ -141,16 +159,20 @@ var (
 	defaultDefaultGaugeAggregationTypes = Types{
 		Last,
 	}
+	defaultDefaultSetAggregationTypes = Types{
+		Distinct,
+	}
 	defaultTypeStringsMap = map[Type][]byte{
-		Last:   []byte("last"),
-		Sum:    []byte("sum"),
-		SumSq:  []byte("sum_sq"),
-		Mean:   []byte("mean"),
-		Min:    []byte("lower"),
-		Max:    []byte("upper"),
-		Count:  []byte("count"),
-		Stdev:  []byte("stdev"),
-		Median: []byte("median"),
+		Last:     []byte("last"),
+		Sum:      []byte("sum"),
+		SumSq:    []byte("sum_sq"),
+		Mean:     []byte("mean"),
+		Min:      []byte("lower"),
+		Max:      []byte("upper"),
+		Count:    []byte("count"),
+		Stdev:    []byte("stdev"),
+		Median:   []byte("median"),
+		Distinct: []byte("distinct"),
 	}
 )
 
@@ -158,16 +180,19 @@ type options struct {
 	defaultCounterAggregationTypes Types
 	defaultTimerAggregationTypes   Types
 	defaultGaugeAggregationTypes   Types
+	defaultSetAggregationTypes     Types
 	quantileTypeStringFn           QuantileTypeStringFn
 	counterTypeStringTransformFn   TypeStringTransformFn
 	timerTypeStringTransformFn     TypeStringTransformFn
 	gaugeTypeStringTransformFn     TypeStringTransformFn
+	setTypeStringTransformFn       TypeStringTransformFn
 	aggTypesPool                   TypesPool
 	quantilesPool                  pool.FloatsPool
 
 	counterTypeStrings [][]byte
 	timerTypeStrings   [][]byte
 	gaugeTypeStrings   [][]byte
+	setTypeStrings     [][]byte
 	quantiles          []float64
 }
 
@@ -177,10 +202,12 @@ func NewTypesOptions() TypesOptions {
 		defaultCounterAggregationTypes: defaultDefaultCounterAggregationTypes,
 		defaultGaugeAggregationTypes:   defaultDefaultGaugeAggregationTypes,
 		defaultTimerAggregationTypes:   defaultDefaultTimerAggregationTypes,
+		defaultSetAggregationTypes:     defaultDefaultSetAggregationTypes,
 		quantileTypeStringFn:           defaultQuantileTypeStringFn,
 		counterTypeStringTransformFn:   NoOpTransform,
 		timerTypeStringTransformFn:     NoOpTransform,
 		gaugeTypeStringTransformFn:     NoOpTransform,
+		setTypeStringTransformFn:       NoOpTransform,
 	}
 	o.initPools()
 	o.computeAllDerived()
@@ -230,6 +257,17 @@ func (o *options) DefaultGaugeAggregationTypes() Types {
 	return o.defaultGaugeAggregationTypes
 }
 
+func (o *options) SetDefaultSetAggregationTypes(aggTypes Types) TypesOptions {
+	opts := *o
+	opts.defaultSetAggregationTypes = aggTypes
+	opts.computeAllDerived()
+	return &opts
+}
+
+func (o *options) DefaultSetAggregationTypes() Types {
+	return o.defaultSetAggregationTypes
+}
+
 func (o *options) SetQuantileTypeStringFn(value QuantileTypeStringFn) TypesOptions {
 	opts := *o
 	opts.quantileTypeStringFn = value
@@ -274,6 +312,17 @@ func (o *options) GaugeTypeStringTransformFn() TypeStringTransformFn {
 	return o.gaugeTypeStringTransformFn
 }
 
+func (o *options) SetSetTypeStringTransformFn(value TypeStringTransformFn) TypesOptions {
+	opts := *o
+	opts.setTypeStringTransformFn = value
+	opts.computeAllDerived()
+	return &opts
+}
+
+func (o *options) SetTypeStringTransformFn() TypeStringTransformFn {
+	return o.setTypeStringTransformFn
+}
+
 func (o *options) SetTypesPool(pool TypesPool) TypesOptions {
 	opts := *o
 	opts.aggTypesPool = pool
@@ -306,6 +355,10 @@ func (o *options) TypeStringForGauge(aggType Type) []byte {
 	return o.gaugeTypeStrings[aggType.ID()]
 }
 
+func (o *options) TypeStringForSet(aggType Type) []byte {
+	return o.setTypeStrings[aggType.ID()]
+}
+
 func (o *options) TypeForCounter(value []byte) Type {
 	return typeFor(value, o.counterTypeStrings)
 }
@@ -318,6 +371,10 @@ func (o *options) TypeForGauge(value []byte) Type {
 	return typeFor(value, o.gaugeTypeStrings)
 }
 
+func (o *options) TypeForSet(value []byte) Type {
+	return typeFor(value, o.setTypeStrings)
+}
+
 func (o *options) Quantiles() []float64 {
 	return o.quantiles
 }
@@ -331,6 +388,8 @@ func (o *options) IsContainedInDefaultAggregationTypes(at Type, mt metric.Type)
 		aggTypes = o.DefaultGaugeAggregationTypes()
 	case metric.TimerType:
 		aggTypes = o.DefaultTimerAggregationTypes()
+	case metric.SetType:
+		aggTypes = o.DefaultSetAggregationTypes()
 	}
 	return aggTypes.Contains(at)
 }
@@ -340,6 +399,7 @@ func (o *options) computeAllDerived() {
 	o.computeCounterTypeStrings()
 	o.computeTimerTypeStrings()
 	o.computeGaugeTypeStrings()
+	o.computeSetTypeStrings()
 }
 
 func (o *options) computeQuantiles() {
@@ -358,6 +418,10 @@ func (o *options) computeGaugeTypeStrings() {
 	o.gaugeTypeStrings = o.computeTypeStrings(o.gaugeTypeStringTransformFn)
 }
 
+func (o *options) computeSetTypeStrings() {
+	o.setTypeStrings = o.computeTypeStrings(o.setTypeStringTransformFn)
+}
+
 func (o *options) computeTypeStrings(transformFn TypeStringTransformFn) [][]byte {
 	res := make([][]byte, maxTypeID+1)
 	for aggType := range ValidTypes {
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3x/pool"
 
 	"github.com/stretchr/testify/require"
@@ -40,6 +41,8 @@ func TestTypesOptionsValidateDefault(t *testing.T) {
 	require.NotNil(t, o.CounterTypeStringTransformFn())
 	require.NotNil(t, o.TimerTypeStringTransformFn())
 	require.NotNil(t, o.GaugeTypeStringTransformFn())
+	require.Equal(t, defaultDefaultSetAggregationTypes, o.DefaultSetAggregationTypes())
+	require.NotNil(t, o.SetTypeStringTransformFn())
 
 	// Validate derived options
 	opts := o.(*options)
@@ -47,6 +50,7 @@ func TestTypesOptionsValidateDefault(t *testing.T) {
 	require.Equal(t, typeStrings(nil), opts.counterTypeStrings)
 	require.Equal(t, typeStrings(nil), opts.timerTypeStrings)
 	require.Equal(t, typeStrings(nil), opts.gaugeTypeStrings)
+	require.Equal(t, typeStrings(nil), opts.setTypeStrings)
 }
 
 func TestOptionsSetDefaultCounterAggregationTypes(t *testing.T) {
@@ -71,6 +75,15 @@ func TestOptionsSetDefaultGaugeAggregationTypes(t *testing.T) {
 	require.Equal(t, typeStrings(nil), o.(*options).gaugeTypeStrings)
 }
 
+func TestOptionsSetDefaultSetAggregationTypes(t *testing.T) {
+	aggTypes := Types{Count, Distinct}
+	o := NewTypesOptions().SetDefaultSetAggregationTypes(aggTypes)
+	require.Equal(t, aggTypes, o.DefaultSetAggregationTypes())
+	require.Equal(t, typeStrings(nil), o.(*options).setTypeStrings)
+	require.True(t, o.IsContainedInDefaultAggregationTypes(Distinct, metric.SetType))
+	require.False(t, o.IsContainedInDefaultAggregationTypes(Sum, metric.SetType))
+}
+
 func TestOptionsSetTimerQuantileTypeStringFn(t *testing.T) {
 	fn := func(q float64) []byte { return []byte(fmt.Sprintf("%1.2f", q)) }
 	o := NewTypesOptions().SetQuantileTypeStringFn(fn)
@@ -286,6 +299,16 @@ func TestOptionsTypeStringForGauge(t *testing.T) {
 	}
 }
 
+func TestOptionsTypeStringForSet(t *testing.T) {
+	o := NewTypesOptions()
+	require.Equal(t, []byte("distinct"), o.TypeStringForSet(Distinct))
+	require.Equal(t, []byte("count"), o.TypeStringForSet(Count))
+	require.Equal(t, Distinct, o.TypeForSet([]byte("distinct")))
+
+	o = o.SetSetTypeStringTransformFn(SuffixTransform)
+	require.Equal(t, []byte(".distinct"), o.TypeStringForSet(Distinct))
+}
+
 func TestOptionsTypeForCounter(t *testing.T) {
 	inputs := []struct {
 		typeStr  []byte
@@ -450,28 +473,29 @@ func validateQuantiles(t *testing.T, o TypesOptions) {
 
 func typeStrings(overrides map[Type][]byte) [][]byte {
 	defaultTypeStrings := map[Type][]byte{
-		Last:   []byte("last"),
-		Min:    []byte("lower"),
-		Max:    []byte("upper"),
-		Mean:   []byte("mean"),
-		Median: []byte("median"),
-		Count:  []byte("count"),
-		Sum:    []byte("sum"),
-		SumSq:  []byte("sum_sq"),
-		Stdev:  []byte("stdev"),
-		P10:    []byte("p10"),
-		P20:    []byte("p20"),
-		P30:    []byte("p30"),
-		P40:    []byte("p40"),
-		P50:    []byte("p50"),
-		P60:    []byte("p60"),
-		P70:    []byte("p70"),
-		P80:    []byte("p80"),
-		P90:    []byte("p90"),
-		P95:    []byte("p95"),
-		P99:    []byte("p99"),
-		P999:   []byte("p999"),
-		P9999:  []byte("p9999"),
+		Last:     []byte("last"),
+		Min:      []byte("lower"),
+		Max:      []byte("upper"),
+		Mean:     []byte("mean"),
+		Median:   []byte("median"),
+		Count:    []byte("count"),
+		Sum:      []byte("sum"),
+		SumSq:    []byte("sum_sq"),
+		Stdev:    []byte("stdev"),
+		P10:      []byte("p10"),
+		P20:      []byte("p20"),
+		P30:      []byte("p30"),
+		P40:      []byte("p40"),
+		P50:      []byte("p50"),
+		P60:      []byte("p60"),
+		P70:      []byte("p70"),
+		P80:      []byte("p80"),
+		P90:      []byte("p90"),
+		P95:      []byte("p95"),
+		P99:      []byte("p99"),
+		P999:     []byte("p999"),
+		P9999:    []byte("p9999"),
+		Distinct: []byte("distinct"),
 	}
 	res := make([][]byte, maxTypeID+1)
 	for t, bstr := range defaultTypeStrings {
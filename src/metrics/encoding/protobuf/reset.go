@@ -38,6 +38,7 @@ func resetMetricWithMetadatasProto(pb *metricpb.MetricWithMetadatas) {
 	resetGaugeWithMetadatasProto(pb.GaugeWithMetadatas)
 	resetForwardedMetricWithMetadataProto(pb.ForwardedMetricWithMetadata)
 	resetTimedMetricWithMetadataProto(pb.TimedMetricWithMetadata)
+	resetSetWithMetadatasProto(pb.SetWithMetadatas)
 }
 
 func resetCounterWithMetadatasProto(pb *metricpb.CounterWithMetadatas) {
@@ -80,6 +81,14 @@ func resetTimedMetricWithMetadataProto(pb *metricpb.TimedMetricWithMetadata) {
 	resetTimedMetadata(&pb.Metadata)
 }
 
+func resetSetWithMetadatasProto(pb *metricpb.SetWithMetadatas) {
+	if pb == nil {
+		return
+	}
+	resetSet(&pb.Set)
+	resetMetadatas(&pb.Metadatas)
+}
+
 func resetCounter(pb *metricpb.Counter) {
 	if pb == nil {
 		return
@@ -124,6 +133,14 @@ func resetTimedMetric(pb *metricpb.TimedMetric) {
 	pb.Value = 0
 }
 
+func resetSet(pb *metricpb.Set) {
+	if pb == nil {
+		return
+	}
+	pb.Id = pb.Id[:0]
+	pb.Value = pb.Value[:0]
+}
+
 func resetMetadatas(pb *metricpb.StagedMetadatas) {
 	if pb == nil {
 		return
@@ -55,6 +55,14 @@ var (
 		Id:    []byte{},
 		Value: 0.0,
 	}
+	testSetBeforeResetProto = metricpb.Set{
+		Id:    []byte("testSet"),
+		Value: []byte("testValue"),
+	}
+	testSetAfterResetProto = metricpb.Set{
+		Id:    []byte{},
+		Value: []byte{},
+	}
 	testForwardedMetricBeforeResetProto = metricpb.ForwardedMetric{
 		Type:      metricpb.MetricType_COUNTER,
 		Id:        []byte("testForwardedMetric"),
@@ -173,6 +181,28 @@ func TestResetMetricWithMetadatasProtoOnlyGauge(t *testing.T) {
 	require.True(t, cap(input.GaugeWithMetadatas.Metadatas.Metadatas) > 0)
 }
 
+func TestResetMetricWithMetadatasProtoOnlySet(t *testing.T) {
+	input := &metricpb.MetricWithMetadatas{
+		Type: metricpb.MetricWithMetadatas_SET_WITH_METADATAS,
+		SetWithMetadatas: &metricpb.SetWithMetadatas{
+			Set:       testSetBeforeResetProto,
+			Metadatas: testMetadatasBeforeResetProto,
+		},
+	}
+	expected := &metricpb.MetricWithMetadatas{
+		Type: metricpb.MetricWithMetadatas_UNKNOWN,
+		SetWithMetadatas: &metricpb.SetWithMetadatas{
+			Set:       testSetAfterResetProto,
+			Metadatas: testMetadatasAfterResetProto,
+		},
+	}
+	resetMetricWithMetadatasProto(input)
+	require.Equal(t, expected, input)
+	require.True(t, cap(input.SetWithMetadatas.Set.Id) > 0)
+	require.True(t, cap(input.SetWithMetadatas.Set.Value) > 0)
+	require.True(t, cap(input.SetWithMetadatas.Metadatas.Metadatas) > 0)
+}
+
 func TestResetMetricWithMetadatasProtoOnlyForwardedMetric(t *testing.T) {
 	input := &metricpb.MetricWithMetadatas{
 		Type: metricpb.MetricWithMetadatas_FORWARDED_METRIC_WITH_METADATA,
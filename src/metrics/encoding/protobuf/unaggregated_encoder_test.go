@@ -72,6 +72,14 @@ var (
 		ID:    []byte("testGauge2"),
 		Value: 234231.345,
 	}
+	testSet1 = unaggregated.Set{
+		ID:    []byte("testSet1"),
+		Value: []byte("foo"),
+	}
+	testSet2 = unaggregated.Set{
+		ID:    []byte("testSet2"),
+		Value: []byte("bar"),
+	}
 	testForwardedMetric1 = aggregated.ForwardedMetric{
 		Type:      metric.CounterType,
 		ID:        []byte("testForwardedMetric1"),
@@ -263,6 +271,14 @@ var (
 		Id:    []byte("testGauge2"),
 		Value: 234231.345,
 	}
+	testSet1Proto = metricpb.Set{
+		Id:    []byte("testSet1"),
+		Value: []byte("foo"),
+	}
+	testSet2Proto = metricpb.Set{
+		Id:    []byte("testSet2"),
+		Value: []byte("bar"),
+	}
 	testForwardedMetric1Proto = metricpb.ForwardedMetric{
 		Type:      metricpb.MetricType_COUNTER,
 		Id:        []byte("testForwardedMetric1"),
@@ -702,6 +718,66 @@ func TestUnaggregatedEncoderEncodeGaugeWithMetadatas(t *testing.T) {
 	}
 }
 
+func TestUnaggregatedEncoderEncodeSetWithMetadatas(t *testing.T) {
+	inputs := []unaggregated.SetWithMetadatas{
+		{
+			Set:             testSet1,
+			StagedMetadatas: testStagedMetadatas1,
+		},
+		{
+			Set:             testSet2,
+			StagedMetadatas: testStagedMetadatas1,
+		},
+		{
+			Set:             testSet1,
+			StagedMetadatas: testStagedMetadatas2,
+		},
+		{
+			Set:             testSet2,
+			StagedMetadatas: testStagedMetadatas2,
+		},
+	}
+	expected := []metricpb.SetWithMetadatas{
+		{
+			Set:       testSet1Proto,
+			Metadatas: testStagedMetadatas1Proto,
+		},
+		{
+			Set:       testSet2Proto,
+			Metadatas: testStagedMetadatas1Proto,
+		},
+		{
+			Set:       testSet1Proto,
+			Metadatas: testStagedMetadatas2Proto,
+		},
+		{
+			Set:       testSet2Proto,
+			Metadatas: testStagedMetadatas2Proto,
+		},
+	}
+
+	var (
+		sizeRes int
+		pbRes   metricpb.MetricWithMetadatas
+	)
+	enc := NewUnaggregatedEncoder(NewUnaggregatedOptions())
+	enc.(*unaggregatedEncoder).encodeMessageSizeFn = func(size int) { sizeRes = size }
+	enc.(*unaggregatedEncoder).encodeMessageFn = func(pb metricpb.MetricWithMetadatas) error { pbRes = pb; return nil }
+	for i, input := range inputs {
+		require.NoError(t, enc.EncodeMessage(encoding.UnaggregatedMessageUnion{
+			Type:             encoding.SetWithMetadatasType,
+			SetWithMetadatas: input,
+		}))
+		expectedProto := metricpb.MetricWithMetadatas{
+			Type:             metricpb.MetricWithMetadatas_SET_WITH_METADATAS,
+			SetWithMetadatas: &expected[i],
+		}
+		expectedMsgSize := expectedProto.Size()
+		require.Equal(t, expectedMsgSize, sizeRes)
+		require.Equal(t, expectedProto, pbRes)
+	}
+}
+
 func TestUnaggregatedEncoderEncodeForwardedMetricWithMetadata(t *testing.T) {
 	inputs := []aggregated.ForwardedMetricWithMetadata{
 		{
@@ -172,6 +172,52 @@ func TestUnaggregatedIteratorDecodeGaugeWithMetadatas(t *testing.T) {
 	require.Equal(t, len(inputs), i)
 }
 
+func TestUnaggregatedIteratorDecodeSetWithMetadatas(t *testing.T) {
+	inputs := []unaggregated.SetWithMetadatas{
+		{
+			Set:             testSet1,
+			StagedMetadatas: testStagedMetadatas1,
+		},
+		{
+			Set:             testSet2,
+			StagedMetadatas: testStagedMetadatas1,
+		},
+		{
+			Set:             testSet1,
+			StagedMetadatas: testStagedMetadatas2,
+		},
+		{
+			Set:             testSet2,
+			StagedMetadatas: testStagedMetadatas2,
+		},
+	}
+
+	enc := NewUnaggregatedEncoder(NewUnaggregatedOptions())
+	for _, input := range inputs {
+		require.NoError(t, enc.EncodeMessage(encoding.UnaggregatedMessageUnion{
+			Type:             encoding.SetWithMetadatasType,
+			SetWithMetadatas: input,
+		}))
+	}
+	dataBuf := enc.Relinquish()
+	defer dataBuf.Close()
+
+	var (
+		i      int
+		stream = bytes.NewReader(dataBuf.Bytes())
+	)
+	it := NewUnaggregatedIterator(stream, NewUnaggregatedOptions())
+	defer it.Close()
+	for it.Next() {
+		res := it.Current()
+		require.Equal(t, encoding.SetWithMetadatasType, res.Type)
+		require.Equal(t, inputs[i], res.SetWithMetadatas)
+		i++
+	}
+	require.Equal(t, io.EOF, it.Err())
+	require.Equal(t, len(inputs), i)
+}
+
 func TestUnaggregatedIteratorDecodeForwardedMetricWithMetadata(t *testing.T) {
 	inputs := []aggregated.ForwardedMetricWithMetadata{
 		{
@@ -38,6 +38,7 @@ const (
 	GaugeWithMetadatasType
 	ForwardedMetricWithMetadataType
 	TimedMetricWithMetadataType
+	SetWithMetadatasType
 )
 
 // UnaggregatedMessageUnion is a union of different types of unaggregated messages.
@@ -51,6 +52,7 @@ type UnaggregatedMessageUnion struct {
 	GaugeWithMetadatas          unaggregated.GaugeWithMetadatas
 	ForwardedMetricWithMetadata aggregated.ForwardedMetricWithMetadata
 	TimedMetricWithMetadata     aggregated.TimedMetricWithMetadata
+	SetWithMetadatas            unaggregated.SetWithMetadatas
 }
 
 // ByteReadScanner is capable of reading and scanning bytes.
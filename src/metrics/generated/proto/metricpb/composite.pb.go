@@ -36,6 +36,7 @@
 		ForwardedMetricWithMetadata
 		TimedMetricWithMetadata
 		MetricWithMetadatas
+		SetWithMetadatas
 		PipelineMetadata
 		Metadata
 		StagedMetadata
@@ -47,6 +48,7 @@
 		Gauge
 		TimedMetric
 		ForwardedMetric
+		Set
 */
 package metricpb
 
@@ -77,6 +79,7 @@ const (
 	MetricWithMetadatas_GAUGE_WITH_METADATAS           MetricWithMetadatas_Type = 3
 	MetricWithMetadatas_FORWARDED_METRIC_WITH_METADATA MetricWithMetadatas_Type = 4
 	MetricWithMetadatas_TIMED_METRIC_WITH_METADATA     MetricWithMetadatas_Type = 5
+	MetricWithMetadatas_SET_WITH_METADATAS             MetricWithMetadatas_Type = 6
 )
 
 var MetricWithMetadatas_Type_name = map[int32]string{
@@ -86,6 +89,7 @@ var MetricWithMetadatas_Type_name = map[int32]string{
 	3: "GAUGE_WITH_METADATAS",
 	4: "FORWARDED_METRIC_WITH_METADATA",
 	5: "TIMED_METRIC_WITH_METADATA",
+	6: "SET_WITH_METADATAS",
 }
 var MetricWithMetadatas_Type_value = map[string]int32{
 	"UNKNOWN":                        0,
@@ -94,6 +98,7 @@ var MetricWithMetadatas_Type_value = map[string]int32{
 	"GAUGE_WITH_METADATAS":           3,
 	"FORWARDED_METRIC_WITH_METADATA": 4,
 	"TIMED_METRIC_WITH_METADATA":     5,
+	"SET_WITH_METADATAS":             6,
 }
 
 func (x MetricWithMetadatas_Type) String() string {
@@ -238,6 +243,7 @@ type MetricWithMetadatas struct {
 	GaugeWithMetadatas          *GaugeWithMetadatas          `protobuf:"bytes,4,opt,name=gauge_with_metadatas,json=gaugeWithMetadatas" json:"gauge_with_metadatas,omitempty"`
 	ForwardedMetricWithMetadata *ForwardedMetricWithMetadata `protobuf:"bytes,5,opt,name=forwarded_metric_with_metadata,json=forwardedMetricWithMetadata" json:"forwarded_metric_with_metadata,omitempty"`
 	TimedMetricWithMetadata     *TimedMetricWithMetadata     `protobuf:"bytes,6,opt,name=timed_metric_with_metadata,json=timedMetricWithMetadata" json:"timed_metric_with_metadata,omitempty"`
+	SetWithMetadatas            *SetWithMetadatas            `protobuf:"bytes,7,opt,name=set_with_metadatas,json=setWithMetadatas" json:"set_with_metadatas,omitempty"`
 }
 
 func (m *MetricWithMetadatas) Reset()                    { *m = MetricWithMetadatas{} }
@@ -287,6 +293,37 @@ func (m *MetricWithMetadatas) GetTimedMetricWithMetadata() *TimedMetricWithMetad
 	return nil
 }
 
+func (m *MetricWithMetadatas) GetSetWithMetadatas() *SetWithMetadatas {
+	if m != nil {
+		return m.SetWithMetadatas
+	}
+	return nil
+}
+
+type SetWithMetadatas struct {
+	Set       Set             `protobuf:"bytes,1,opt,name=set" json:"set"`
+	Metadatas StagedMetadatas `protobuf:"bytes,2,opt,name=metadatas" json:"metadatas"`
+}
+
+func (m *SetWithMetadatas) Reset()                    { *m = SetWithMetadatas{} }
+func (m *SetWithMetadatas) String() string            { return proto.CompactTextString(m) }
+func (*SetWithMetadatas) ProtoMessage()               {}
+func (*SetWithMetadatas) Descriptor() ([]byte, []int) { return fileDescriptorComposite, []int{6} }
+
+func (m *SetWithMetadatas) GetSet() Set {
+	if m != nil {
+		return m.Set
+	}
+	return Set{}
+}
+
+func (m *SetWithMetadatas) GetMetadatas() StagedMetadatas {
+	if m != nil {
+		return m.Metadatas
+	}
+	return StagedMetadatas{}
+}
+
 func init() {
 	proto.RegisterType((*CounterWithMetadatas)(nil), "metricpb.CounterWithMetadatas")
 	proto.RegisterType((*BatchTimerWithMetadatas)(nil), "metricpb.BatchTimerWithMetadatas")
@@ -294,6 +331,7 @@ func init() {
 	proto.RegisterType((*ForwardedMetricWithMetadata)(nil), "metricpb.ForwardedMetricWithMetadata")
 	proto.RegisterType((*TimedMetricWithMetadata)(nil), "metricpb.TimedMetricWithMetadata")
 	proto.RegisterType((*MetricWithMetadatas)(nil), "metricpb.MetricWithMetadatas")
+	proto.RegisterType((*SetWithMetadatas)(nil), "metricpb.SetWithMetadatas")
 	proto.RegisterEnum("metricpb.MetricWithMetadatas_Type", MetricWithMetadatas_Type_name, MetricWithMetadatas_Type_value)
 }
 func (m *CounterWithMetadatas) Marshal() (dAtA []byte, err error) {
@@ -536,6 +574,50 @@ func (m *MetricWithMetadatas) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n15
 	}
+	if m.SetWithMetadatas != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintComposite(dAtA, i, uint64(m.SetWithMetadatas.Size()))
+		n16, err := m.SetWithMetadatas.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	return i, nil
+}
+
+func (m *SetWithMetadatas) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetWithMetadatas) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintComposite(dAtA, i, uint64(m.Set.Size()))
+	n17, err := m.Set.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n17
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintComposite(dAtA, i, uint64(m.Metadatas.Size()))
+	n18, err := m.Metadatas.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n18
 	return i, nil
 }
 
@@ -624,6 +706,20 @@ func (m *MetricWithMetadatas) Size() (n int) {
 		l = m.TimedMetricWithMetadata.Size()
 		n += 1 + l + sovComposite(uint64(l))
 	}
+	if m.SetWithMetadatas != nil {
+		l = m.SetWithMetadatas.Size()
+		n += 1 + l + sovComposite(uint64(l))
+	}
+	return n
+}
+
+func (m *SetWithMetadatas) Size() (n int) {
+	var l int
+	_ = l
+	l = m.Set.Size()
+	n += 1 + l + sovComposite(uint64(l))
+	l = m.Metadatas.Size()
+	n += 1 + l + sovComposite(uint64(l))
 	return n
 }
 
@@ -1403,6 +1499,149 @@ func (m *MetricWithMetadatas) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SetWithMetadatas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComposite
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthComposite
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SetWithMetadatas == nil {
+				m.SetWithMetadatas = &SetWithMetadatas{}
+			}
+			if err := m.SetWithMetadatas.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipComposite(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthComposite
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetWithMetadatas) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowComposite
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetWithMetadatas: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetWithMetadatas: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Set", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComposite
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthComposite
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Set.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadatas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowComposite
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthComposite
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Metadatas.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipComposite(dAtA[iNdEx:])
@@ -1534,44 +1773,47 @@ func init() {
 }
 
 var fileDescriptorComposite = []byte{
-	// 618 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0x5d, 0x6b, 0xd3, 0x50,
-	0x1c, 0xc6, 0x77, 0xb6, 0x76, 0x9b, 0xff, 0x81, 0xd6, 0x63, 0x5d, 0x6b, 0x26, 0x51, 0x03, 0x82,
-	0x20, 0x36, 0xb8, 0x82, 0x43, 0x86, 0x17, 0xe9, 0xeb, 0x8a, 0xb4, 0x85, 0x2c, 0xa5, 0xe0, 0xc5,
-	0x42, 0x92, 0xa6, 0x69, 0x84, 0x2c, 0x25, 0x39, 0x65, 0xec, 0xce, 0x4b, 0xbd, 0x13, 0xc4, 0x8f,
-	0xe1, 0xf7, 0xd8, 0x9d, 0x7e, 0x02, 0x91, 0xfa, 0x45, 0x24, 0xc9, 0x49, 0xf3, 0x5a, 0x2f, 0xd6,
-	0xbb, 0xe6, 0x3c, 0xff, 0xe7, 0x77, 0x1e, 0xce, 0x79, 0x4e, 0xa1, 0x6b, 0x98, 0x64, 0xb6, 0x50,
-	0x6b, 0x9a, 0x6d, 0xf1, 0x56, 0x7d, 0xa2, 0xf2, 0x56, 0x9d, 0x77, 0x1d, 0x8d, 0xb7, 0x74, 0xe2,
-	0x98, 0x9a, 0xcb, 0x1b, 0xfa, 0xa5, 0xee, 0x28, 0x44, 0x9f, 0xf0, 0x73, 0xc7, 0x26, 0x36, 0x5d,
-	0x9f, 0xab, 0xbc, 0x66, 0x5b, 0x73, 0xdb, 0x35, 0x89, 0x5e, 0xf3, 0x05, 0xbc, 0x1f, 0x2a, 0xcc,
-	0xab, 0x18, 0xd2, 0xb0, 0x0d, 0x3b, 0x70, 0xaa, 0x8b, 0xa9, 0xff, 0x15, 0x60, 0xbc, 0x5f, 0x81,
-	0x91, 0x69, 0xdd, 0x36, 0x41, 0xf0, 0x83, 0x52, 0x3a, 0x1b, 0x50, 0x94, 0x89, 0x42, 0x94, 0x80,
-	0xc3, 0x7d, 0x46, 0x50, 0x6e, 0xda, 0x8b, 0x4b, 0xa2, 0x3b, 0x63, 0x93, 0xcc, 0xfa, 0x54, 0x75,
-	0xf1, 0x6b, 0xd8, 0xd3, 0x82, 0xf5, 0x2a, 0x7a, 0x8a, 0x5e, 0x1c, 0x1c, 0xdf, 0xaf, 0x85, 0x8c,
-	0x1a, 0x35, 0x34, 0x0a, 0x37, 0xbf, 0x9f, 0x6c, 0x89, 0xe1, 0x1c, 0x7e, 0x07, 0x77, 0x42, 0xba,
-	0x5b, 0xdd, 0xf6, 0x4d, 0x8f, 0x22, 0xd3, 0x39, 0x51, 0x0c, 0x7d, 0xb2, 0xda, 0x80, 0x9a, 0x23,
-	0x07, 0xf7, 0x1d, 0x41, 0xa5, 0xa1, 0x10, 0x6d, 0x26, 0x99, 0x56, 0x3a, 0xcd, 0x29, 0x1c, 0xa8,
-	0x9e, 0x24, 0x13, 0x4f, 0xa3, 0x89, 0xca, 0x11, 0x3c, 0xf2, 0x51, 0x2e, 0xa8, 0xab, 0x95, 0x4d,
-	0x73, 0x7d, 0x42, 0x80, 0xbb, 0xca, 0xc2, 0xd0, 0x93, 0x91, 0x5e, 0x42, 0xd1, 0xf0, 0x56, 0x69,
-	0x98, 0x7b, 0x11, 0xd1, 0x1f, 0xa6, 0x9c, 0x60, 0x66, 0xd3, 0x08, 0xdf, 0x10, 0x1c, 0x75, 0x6c,
-	0xe7, 0x4a, 0x71, 0x26, 0xfe, 0x9c, 0x63, 0x6a, 0xf1, 0x30, 0xf8, 0x04, 0x76, 0x03, 0x18, 0x0d,
-	0x13, 0x63, 0xa7, 0x6c, 0x94, 0x4d, 0xc7, 0xf1, 0x29, 0xec, 0x87, 0xbb, 0x64, 0x63, 0x51, 0x6b,
-	0xb8, 0x0b, 0xb5, 0xae, 0x0c, 0xdc, 0x17, 0x04, 0x15, 0xef, 0x84, 0xf3, 0x12, 0xd5, 0x53, 0x89,
-	0x1e, 0x46, 0xd8, 0x98, 0x25, 0x95, 0xe6, 0x6d, 0x26, 0x4d, 0x25, 0x6b, 0xcb, 0xcf, 0xf2, 0xb3,
-	0x08, 0x0f, 0xb2, 0x31, 0x5c, 0xfc, 0x06, 0x0a, 0xe4, 0x7a, 0x1e, 0x5c, 0xd2, 0xdd, 0x63, 0x2e,
-	0xc2, 0xe5, 0x0c, 0xd7, 0xa4, 0xeb, 0xb9, 0x2e, 0xfa, 0xf3, 0x58, 0x82, 0x43, 0x5a, 0x6b, 0xf9,
-	0xca, 0x24, 0x33, 0x39, 0x7d, 0x7b, 0x6c, 0xe6, 0x35, 0x24, 0x50, 0x62, 0x59, 0xcb, 0x7b, 0x54,
-	0x17, 0xc0, 0xc4, 0x6a, 0x9c, 0x26, 0xef, 0xf8, 0xe4, 0x67, 0x79, 0xad, 0x4e, 0xc2, 0x2b, 0xea,
-	0x9a, 0x67, 0x32, 0x80, 0xb2, 0xdf, 0xb7, 0x34, 0xb9, 0xe0, 0x93, 0x1f, 0xa7, 0x2a, 0x9a, 0x84,
-	0x62, 0x23, 0xdb, 0xf1, 0x8f, 0xc0, 0x4e, 0xc3, 0xfe, 0xc8, 0x81, 0x39, 0x89, 0xae, 0x16, 0x7d,
-	0xf2, 0xf3, 0xb5, 0x7d, 0x8b, 0xf3, 0xc4, 0xa3, 0xe9, 0x7f, 0x3a, 0x7c, 0x01, 0x8c, 0x77, 0x2a,
-	0x6b, 0xf6, 0xd9, 0x4d, 0x9f, 0xcd, 0x9a, 0xe2, 0x89, 0x15, 0x92, 0x2f, 0x70, 0x3f, 0x10, 0x14,
-	0xbc, 0x0b, 0xc6, 0x07, 0xb0, 0x37, 0x1a, 0xbc, 0x1f, 0x0c, 0xc7, 0x83, 0xd2, 0x16, 0x66, 0xe0,
-	0xb0, 0x39, 0x1c, 0x0d, 0xa4, 0xb6, 0x28, 0x8f, 0x7b, 0xd2, 0x99, 0xdc, 0x6f, 0x4b, 0x42, 0x4b,
-	0x90, 0x84, 0xf3, 0x12, 0xc2, 0x2c, 0x30, 0x0d, 0x41, 0x6a, 0x9e, 0xc9, 0x52, 0xaf, 0x9f, 0xd5,
-	0xb7, 0x71, 0x15, 0xca, 0x5d, 0x61, 0xd4, 0x6d, 0xa7, 0x95, 0x1d, 0xcc, 0x01, 0xdb, 0x19, 0x8a,
-	0x63, 0x41, 0x6c, 0xb5, 0x5b, 0x9e, 0x20, 0xf6, 0x9a, 0xc9, 0xa1, 0x52, 0xc1, 0xa3, 0x7b, 0xdc,
-	0x35, 0x7a, 0xb1, 0xd1, 0xbb, 0x59, 0xb2, 0xe8, 0xd7, 0x92, 0x45, 0x7f, 0x96, 0x2c, 0xfa, 0xfa,
-	0x97, 0xdd, 0xfa, 0x70, 0x72, 0xcb, 0xff, 0x7c, 0x75, 0xd7, 0xff, 0xae, 0xff, 0x0b, 0x00, 0x00,
-	0xff, 0xff, 0xfc, 0x66, 0x45, 0xc7, 0xfd, 0x06, 0x00, 0x00,
+	// 663 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa5, 0x95, 0xcd, 0x8a, 0xd3, 0x50,
+	0x14, 0xc7, 0x9b, 0xe9, 0xd7, 0x78, 0x8a, 0x5a, 0xaf, 0xb5, 0xad, 0x19, 0xa9, 0x4e, 0x60, 0x40,
+	0x10, 0x1b, 0x9c, 0x82, 0x83, 0x88, 0x8b, 0x7e, 0xb7, 0x48, 0x5b, 0x48, 0x53, 0x0a, 0x2e, 0x0c,
+	0x49, 0x9b, 0x66, 0x2a, 0x74, 0x52, 0x92, 0x5b, 0x46, 0x77, 0x2e, 0x75, 0x27, 0x88, 0x0f, 0xe2,
+	0x43, 0x08, 0xb3, 0xf4, 0x09, 0x44, 0xf4, 0x45, 0xcc, 0xc7, 0x4d, 0x93, 0xdc, 0x34, 0x2e, 0xa6,
+	0x8b, 0x94, 0xe4, 0x9e, 0xf3, 0xff, 0xdd, 0x7f, 0xce, 0x3d, 0x27, 0x85, 0xae, 0xb6, 0xc4, 0xe7,
+	0x1b, 0xa5, 0x3a, 0xd3, 0x57, 0xfc, 0xaa, 0x36, 0x57, 0xac, 0x1f, 0xde, 0x34, 0x66, 0xfc, 0x4a,
+	0xc5, 0xc6, 0x72, 0x66, 0xf2, 0x9a, 0x7a, 0xa1, 0x1a, 0x32, 0x56, 0xe7, 0xfc, 0xda, 0xd0, 0xb1,
+	0x4e, 0xd6, 0xd7, 0x0a, 0x6f, 0x09, 0xd6, 0xba, 0xb9, 0xc4, 0x6a, 0xd5, 0x09, 0xa0, 0x43, 0x2f,
+	0xc2, 0x3e, 0x0d, 0x20, 0x35, 0x5d, 0xd3, 0x5d, 0xa5, 0xb2, 0x59, 0x38, 0x4f, 0x2e, 0xc6, 0xbe,
+	0x73, 0x85, 0x6c, 0xeb, 0xba, 0x0e, 0xdc, 0x1b, 0x42, 0xe9, 0xec, 0x41, 0x91, 0xe7, 0x32, 0x96,
+	0x5d, 0x0e, 0xf7, 0x89, 0x81, 0x42, 0x53, 0xdf, 0x5c, 0x60, 0xd5, 0x98, 0x5a, 0xc4, 0x01, 0x89,
+	0x9a, 0xe8, 0x19, 0x64, 0x67, 0xee, 0x7a, 0x99, 0x79, 0xc4, 0x3c, 0xce, 0x9d, 0xde, 0xa9, 0x7a,
+	0x8c, 0x2a, 0x11, 0x34, 0x52, 0x57, 0xbf, 0x1e, 0x26, 0x04, 0x2f, 0x0f, 0xbd, 0x82, 0x1b, 0x1e,
+	0xdd, 0x2c, 0x1f, 0x38, 0xa2, 0xfb, 0xbe, 0x68, 0x8c, 0x65, 0x4d, 0x9d, 0x6f, 0x37, 0x20, 0x62,
+	0x5f, 0xc1, 0x7d, 0x63, 0xa0, 0xd4, 0x90, 0xf1, 0xec, 0x5c, 0x5c, 0xae, 0x68, 0x37, 0x2f, 0x21,
+	0xa7, 0xd8, 0x21, 0x09, 0xdb, 0x31, 0xe2, 0xa8, 0xe0, 0xc3, 0x7d, 0x1d, 0xe1, 0x82, 0xb2, 0x5d,
+	0xd9, 0xd7, 0xd7, 0x47, 0x06, 0x50, 0x57, 0xde, 0x68, 0x6a, 0xd8, 0xd2, 0x13, 0x48, 0x6b, 0xf6,
+	0x2a, 0x31, 0x73, 0xdb, 0x27, 0x3a, 0xc9, 0x84, 0xe3, 0xe6, 0xec, 0x6b, 0xe1, 0x2b, 0x03, 0x47,
+	0x1d, 0xdd, 0xb8, 0x94, 0x8d, 0xb9, 0x93, 0x67, 0xc9, 0x82, 0x66, 0xd0, 0x19, 0x64, 0x5c, 0x18,
+	0x31, 0x13, 0x60, 0x53, 0x32, 0xc2, 0x26, 0xe9, 0x56, 0x5d, 0x0f, 0xbd, 0x5d, 0xa2, 0xb6, 0x88,
+	0xd4, 0xdb, 0x85, 0x48, 0xb7, 0x02, 0xee, 0xb3, 0x75, 0x60, 0x76, 0x85, 0x77, 0x39, 0xaa, 0x51,
+	0x8e, 0xee, 0xf9, 0xd8, 0x80, 0x84, 0x72, 0xf3, 0x22, 0xe2, 0xa6, 0x14, 0x95, 0xed, 0xf6, 0xf2,
+	0x3d, 0x03, 0x77, 0xa3, 0x36, 0x4c, 0xf4, 0x1c, 0x52, 0xf8, 0xc3, 0xda, 0x3d, 0xa4, 0x5b, 0xa7,
+	0x9c, 0x8f, 0xdb, 0x91, 0x5c, 0x15, 0xad, 0x4c, 0xc1, 0xc9, 0x47, 0x22, 0x14, 0x49, 0x5b, 0x4b,
+	0x97, 0x56, 0x8e, 0x44, 0x9f, 0x5e, 0x25, 0x32, 0x0d, 0x21, 0x94, 0x50, 0x98, 0xed, 0x1a, 0xaa,
+	0xb7, 0xc0, 0x06, 0xda, 0x98, 0x26, 0x27, 0x1d, 0xf2, 0xf1, 0xae, 0xae, 0x0e, 0xc3, 0x4b, 0x4a,
+	0xcc, 0x98, 0x0c, 0xa1, 0xe0, 0xf4, 0x1b, 0x4d, 0x4e, 0x39, 0xe4, 0x07, 0x54, 0x8b, 0x86, 0xa1,
+	0x48, 0x8b, 0xf6, 0xf8, 0x3b, 0xa8, 0x2c, 0xbc, 0xfe, 0x91, 0x5c, 0x71, 0x18, 0x5d, 0x4e, 0x3b,
+	0xe4, 0x93, 0xd8, 0x7e, 0x0b, 0xf2, 0x84, 0xa3, 0xc5, 0x7f, 0x7a, 0xd8, 0xaa, 0x8d, 0x5d, 0x95,
+	0x98, 0x7d, 0x32, 0x74, 0x6d, 0x62, 0x1a, 0x4f, 0x28, 0xe1, 0x98, 0x8e, 0xec, 0x01, 0x32, 0x55,
+	0x4c, 0x57, 0x26, 0xeb, 0x70, 0xd9, 0xc0, 0x2c, 0xaa, 0x38, 0x5c, 0x97, 0xbc, 0x49, 0xad, 0x70,
+	0x3f, 0x18, 0x48, 0xd9, 0xad, 0x82, 0x72, 0x90, 0x9d, 0x0c, 0x5f, 0x0f, 0x47, 0xd3, 0x61, 0x3e,
+	0x81, 0x58, 0x28, 0x36, 0x47, 0x93, 0xa1, 0xd8, 0x16, 0xa4, 0x69, 0x5f, 0xec, 0x49, 0x83, 0xb6,
+	0x58, 0x6f, 0xd5, 0xc5, 0xfa, 0x38, 0xcf, 0xa0, 0x0a, 0xb0, 0x8d, 0xba, 0xd8, 0xec, 0x49, 0x62,
+	0x7f, 0x10, 0x8d, 0x1f, 0xa0, 0x32, 0x14, 0xba, 0xf5, 0x49, 0xb7, 0x4d, 0x47, 0x92, 0x88, 0x83,
+	0x4a, 0x67, 0x24, 0x4c, 0xeb, 0x42, 0xab, 0xdd, 0xb2, 0x03, 0x42, 0xbf, 0x19, 0x4e, 0xca, 0xa7,
+	0x6c, 0xba, 0xcd, 0x8d, 0x89, 0xa7, 0x51, 0x11, 0xd0, 0xb8, 0x2d, 0xd2, 0xec, 0x0c, 0xf7, 0x1e,
+	0xf2, 0xf4, 0xdb, 0xa2, 0x13, 0x48, 0x5a, 0xef, 0x4b, 0x86, 0xf6, 0x66, 0xa8, 0x2c, 0x64, 0xe6,
+	0xec, 0xf8, 0x9e, 0xdf, 0xb3, 0x46, 0xff, 0xea, 0x4f, 0x85, 0xf9, 0x69, 0x5d, 0xbf, 0xad, 0xeb,
+	0xcb, 0xdf, 0x4a, 0xe2, 0xcd, 0xd9, 0x35, 0xff, 0xcf, 0x94, 0x8c, 0xf3, 0x5c, 0xfb, 0x07, 0x99,
+	0x89, 0x9c, 0xbf, 0xd9, 0x07, 0x00, 0x00,
 }
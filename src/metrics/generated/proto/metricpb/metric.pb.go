@@ -43,6 +43,7 @@ const (
 	MetricType_COUNTER MetricType = 1
 	MetricType_TIMER   MetricType = 2
 	MetricType_GAUGE   MetricType = 3
+	MetricType_SET     MetricType = 4
 )
 
 var MetricType_name = map[int32]string{
@@ -50,12 +51,14 @@ var MetricType_name = map[int32]string{
 	1: "COUNTER",
 	2: "TIMER",
 	3: "GAUGE",
+	4: "SET",
 }
 var MetricType_value = map[string]int32{
 	"UNKNOWN": 0,
 	"COUNTER": 1,
 	"TIMER":   2,
 	"GAUGE":   3,
+	"SET":     4,
 }
 
 func (x MetricType) String() string {
@@ -215,12 +218,37 @@ func (m *ForwardedMetric) GetValues() []float64 {
 	return nil
 }
 
+type Set struct {
+	Id    []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *Set) Reset()                    { *m = Set{} }
+func (m *Set) String() string            { return proto.CompactTextString(m) }
+func (*Set) ProtoMessage()               {}
+func (*Set) Descriptor() ([]byte, []int) { return fileDescriptorMetric, []int{5} }
+
+func (m *Set) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *Set) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Counter)(nil), "metricpb.Counter")
 	proto.RegisterType((*BatchTimer)(nil), "metricpb.BatchTimer")
 	proto.RegisterType((*Gauge)(nil), "metricpb.Gauge")
 	proto.RegisterType((*TimedMetric)(nil), "metricpb.TimedMetric")
 	proto.RegisterType((*ForwardedMetric)(nil), "metricpb.ForwardedMetric")
+	proto.RegisterType((*Set)(nil), "metricpb.Set")
 	proto.RegisterEnum("metricpb.MetricType", MetricType_name, MetricType_value)
 }
 func (m *Counter) Marshal() (dAtA []byte, err error) {
@@ -400,6 +428,36 @@ func (m *ForwardedMetric) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *Set) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Set) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMetric(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMetric(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	return i, nil
+}
+
 func encodeVarintMetric(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -486,6 +544,20 @@ func (m *ForwardedMetric) Size() (n int) {
 	return n
 }
 
+func (m *Set) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovMetric(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovMetric(uint64(l))
+	}
+	return n
+}
+
 func sovMetric(x uint64) (n int) {
 	for {
 		n++
@@ -1113,6 +1185,118 @@ func (m *ForwardedMetric) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Set) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetric
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Set: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Set: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetric
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMetric
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
+			if m.Id == nil {
+				m.Id = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetric
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMetric
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetric(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetric
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipMetric(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1223,27 +1407,27 @@ func init() {
 }
 
 var fileDescriptorMetric = []byte{
-	// 340 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x72, 0x49, 0xcf, 0x2c, 0xc9,
-	0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0xcf, 0x35, 0x4e, 0x49, 0xd2, 0xcf, 0x35, 0xd6, 0x2f,
-	0x2e, 0x4a, 0xd6, 0xcf, 0x4d, 0x2d, 0x29, 0xca, 0x4c, 0x2e, 0xd6, 0x4f, 0x4f, 0xcd, 0x4b, 0x2d,
-	0x4a, 0x2c, 0x49, 0x4d, 0xd1, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0x87, 0x8a, 0x17, 0x24, 0x41, 0x19,
-	0x7a, 0x60, 0x51, 0x21, 0x0e, 0x98, 0xb0, 0x92, 0x3e, 0x17, 0xbb, 0x73, 0x7e, 0x69, 0x5e, 0x49,
-	0x6a, 0x91, 0x10, 0x1f, 0x17, 0x53, 0x66, 0x8a, 0x04, 0xa3, 0x02, 0xa3, 0x06, 0x4f, 0x10, 0x53,
-	0x66, 0x8a, 0x90, 0x08, 0x17, 0x6b, 0x59, 0x62, 0x4e, 0x69, 0xaa, 0x04, 0x93, 0x02, 0xa3, 0x06,
-	0x73, 0x10, 0x84, 0xa3, 0x64, 0xc2, 0xc5, 0xe5, 0x94, 0x58, 0x92, 0x9c, 0x11, 0x92, 0x99, 0x8b,
-	0x45, 0x8f, 0x18, 0x17, 0x1b, 0x58, 0x59, 0xb1, 0x04, 0x93, 0x02, 0xb3, 0x06, 0x63, 0x10, 0x94,
-	0xa7, 0xa4, 0xcb, 0xc5, 0xea, 0x9e, 0x58, 0x9a, 0x9e, 0x8a, 0xdf, 0x12, 0x46, 0x98, 0x25, 0x35,
-	0x5c, 0xdc, 0x20, 0xf3, 0x53, 0x7c, 0xc1, 0xce, 0x14, 0xd2, 0xe0, 0x62, 0x29, 0xa9, 0x2c, 0x48,
-	0x05, 0x6b, 0xe3, 0x33, 0x12, 0xd1, 0x83, 0xb9, 0x5e, 0x0f, 0x22, 0x1f, 0x52, 0x59, 0x90, 0x1a,
-	0x04, 0x56, 0x01, 0x35, 0x9e, 0x09, 0x6e, 0xbc, 0x2c, 0x17, 0x57, 0x49, 0x66, 0x6e, 0x6a, 0x7c,
-	0x5e, 0x62, 0x5e, 0x7e, 0xb1, 0x04, 0x33, 0xd8, 0x23, 0x9c, 0x20, 0x11, 0x3f, 0x90, 0x00, 0xc2,
-	0x76, 0x16, 0x64, 0xdb, 0x9b, 0x18, 0xb9, 0xf8, 0xdd, 0xf2, 0x8b, 0xca, 0x13, 0x8b, 0x52, 0x68,
-	0xef, 0x04, 0x44, 0x88, 0xb1, 0x20, 0x87, 0x98, 0x96, 0x0d, 0x17, 0x17, 0xc2, 0x68, 0x21, 0x6e,
-	0x2e, 0xf6, 0x50, 0x3f, 0x6f, 0x3f, 0xff, 0x70, 0x3f, 0x01, 0x06, 0x10, 0xc7, 0xd9, 0x3f, 0xd4,
-	0x2f, 0xc4, 0x35, 0x48, 0x80, 0x51, 0x88, 0x93, 0x8b, 0x35, 0xc4, 0xd3, 0xd7, 0x35, 0x48, 0x80,
-	0x09, 0xc4, 0x74, 0x77, 0x0c, 0x75, 0x77, 0x15, 0x60, 0x76, 0xf2, 0x3c, 0xf1, 0x48, 0x8e, 0xf1,
-	0xc2, 0x23, 0x39, 0xc6, 0x07, 0x8f, 0xe4, 0x18, 0x27, 0x3c, 0x96, 0x63, 0x88, 0x32, 0x27, 0x33,
-	0xe1, 0x24, 0xb1, 0x81, 0xf9, 0xc6, 0x80, 0x00, 0x00, 0x00, 0xff, 0xff, 0x24, 0x3b, 0xb0, 0x62,
-	0x7a, 0x02, 0x00, 0x00,
+	// 344 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe3, 0x72, 0x49, 0xcf, 0x2c, 0xc9,
+	0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0xcf, 0x35, 0x4e, 0x49, 0x02, 0x12, 0xfa, 0xc5, 0x45,
+	0xc9, 0xfa, 0xb9, 0xa9, 0x25, 0x45, 0x99, 0xc9, 0xc5, 0xfa, 0xe9, 0xa9, 0x79, 0xa9, 0x45, 0x89,
+	0x25, 0xa9, 0x29, 0xfa, 0x05, 0x45, 0xf9, 0x25, 0xf9, 0x50, 0xf1, 0x82, 0x24, 0x28, 0x43, 0x0f,
+	0x2c, 0x2a, 0xc4, 0x01, 0x13, 0x56, 0xd2, 0xe7, 0x62, 0x77, 0xce, 0x2f, 0xcd, 0x2b, 0x49, 0x2d,
+	0x12, 0xe2, 0xe3, 0x62, 0xca, 0x4c, 0x91, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x09, 0x02, 0xb2, 0x84,
+	0x44, 0xb8, 0x58, 0xcb, 0x12, 0x73, 0x4a, 0x53, 0x25, 0x98, 0x80, 0x42, 0xcc, 0x41, 0x10, 0x8e,
+	0x92, 0x09, 0x17, 0x97, 0x53, 0x62, 0x49, 0x72, 0x46, 0x48, 0x66, 0x2e, 0x16, 0x3d, 0x62, 0x5c,
+	0x6c, 0x60, 0x65, 0xc5, 0x40, 0x4d, 0xcc, 0x1a, 0x8c, 0x41, 0x50, 0x9e, 0x92, 0x2e, 0x17, 0xab,
+	0x7b, 0x62, 0x69, 0x7a, 0x2a, 0x7e, 0x4b, 0x18, 0x61, 0x96, 0xd4, 0x70, 0x71, 0x83, 0xcc, 0x4f,
+	0xf1, 0x05, 0x3b, 0x53, 0x48, 0x83, 0x8b, 0xa5, 0xa4, 0xb2, 0x20, 0x15, 0xac, 0x8d, 0xcf, 0x48,
+	0x44, 0x0f, 0xe6, 0x7a, 0x3d, 0x88, 0x7c, 0x08, 0x50, 0x2e, 0x08, 0xac, 0x02, 0x6a, 0x3c, 0x13,
+	0xdc, 0x78, 0x59, 0x2e, 0xae, 0x12, 0xa0, 0x41, 0xf1, 0x79, 0x89, 0x79, 0xf9, 0xc5, 0x12, 0xcc,
+	0x60, 0x8f, 0x70, 0x82, 0x44, 0xfc, 0x40, 0x02, 0x08, 0xdb, 0x59, 0x90, 0x6d, 0x6f, 0x62, 0xe4,
+	0xe2, 0x77, 0xcb, 0x2f, 0x2a, 0x4f, 0x2c, 0x4a, 0xa1, 0xbd, 0x13, 0x10, 0x21, 0xc6, 0x82, 0x12,
+	0x62, 0xda, 0x5c, 0xcc, 0xc1, 0xa9, 0x25, 0xf8, 0xc3, 0x8b, 0x07, 0xea, 0x62, 0x2d, 0x57, 0x2e,
+	0x2e, 0x84, 0x3b, 0x84, 0xb8, 0xb9, 0xd8, 0x43, 0xfd, 0xbc, 0xfd, 0xfc, 0xc3, 0xfd, 0x04, 0x18,
+	0x40, 0x1c, 0x67, 0xff, 0x50, 0xbf, 0x10, 0xd7, 0x20, 0x01, 0x46, 0x21, 0x4e, 0x2e, 0xd6, 0x10,
+	0x4f, 0x5f, 0x20, 0x93, 0x09, 0xc4, 0x74, 0x77, 0x0c, 0x75, 0x77, 0x15, 0x60, 0x16, 0x62, 0x07,
+	0x5a, 0xe5, 0x1a, 0x22, 0xc0, 0xe2, 0xe4, 0x79, 0xe2, 0x91, 0x1c, 0xe3, 0x05, 0x20, 0x7e, 0x00,
+	0xc4, 0x13, 0x1e, 0xcb, 0x31, 0x44, 0x99, 0x93, 0x99, 0xdc, 0x92, 0xd8, 0xc0, 0x7c, 0x63, 0x00,
+	0xa5, 0x47, 0xb8, 0x67, 0xb0, 0x02, 0x00, 0x00,
 }
@@ -37,6 +37,7 @@ func TestTypeUnmarshalYAML(t *testing.T) {
 		{str: "counter", expected: CounterType},
 		{str: "timer", expected: TimerType},
 		{str: "gauge", expected: GaugeType},
+		{str: "set", expected: SetType},
 	}
 	for _, input := range inputs {
 		var typ Type
@@ -54,7 +55,7 @@ func TestTypeUnmarshalYAMLErrors(t *testing.T) {
 		var typ Type
 		err := yaml.Unmarshal([]byte(input), &typ)
 		require.Error(t, err)
-		require.Equal(t, "invalid metric type '"+input+"', valid types are: counter, timer, gauge", err.Error())
+		require.Equal(t, "invalid metric type '"+input+"', valid types are: counter, timer, gauge, set", err.Error())
 	}
 }
 
@@ -80,6 +81,10 @@ func TestTypeToProto(t *testing.T) {
 			metricType: GaugeType,
 			expected:   metricpb.MetricType_GAUGE,
 		},
+		{
+			metricType: SetType,
+			expected:   metricpb.MetricType_SET,
+		},
 	}
 
 	for _, input := range inputs {
@@ -118,6 +123,10 @@ func TestTypeFromProto(t *testing.T) {
 			metricType: metricpb.MetricType_GAUGE,
 			expected:   GaugeType,
 		},
+		{
+			metricType: metricpb.MetricType_SET,
+			expected:   SetType,
+		},
 	}
 
 	var mt Type
@@ -69,6 +69,15 @@ var (
 		ID:       []byte("testGauge"),
 		GaugeVal: 45.28,
 	}
+	testSet = Set{
+		ID:    []byte("testSet"),
+		Value: []byte("user-1234"),
+	}
+	testSetUnion = MetricUnion{
+		Type:   metric.SetType,
+		ID:     []byte("testSet"),
+		SetVal: []byte("user-1234"),
+	}
 	testMetadatas = metadata.StagedMetadatas{
 		{
 			CutoverNanos: 1234,
@@ -163,6 +172,10 @@ var (
 		Gauge:           testGauge,
 		StagedMetadatas: testMetadatas,
 	}
+	testSetWithMetadatas = SetWithMetadatas{
+		Set:             testSet,
+		StagedMetadatas: testMetadatas,
+	}
 	testCounterProto = metricpb.Counter{
 		Id:    []byte("testCounter"),
 		Value: 1234,
@@ -175,6 +188,10 @@ var (
 		Id:    []byte("testGauge"),
 		Value: 45.28,
 	}
+	testSetProto = metricpb.Set{
+		Id:    []byte("testSet"),
+		Value: []byte("user-1234"),
+	}
 	testMetadatasProto = metricpb.StagedMetadatas{
 		Metadatas: []metricpb.StagedMetadata{
 			{
@@ -300,6 +317,10 @@ var (
 		Gauge:     testGaugeProto,
 		Metadatas: testMetadatasProto,
 	}
+	testSetWithMetadatasProto = metricpb.SetWithMetadatas{
+		Set:       testSetProto,
+		Metadatas: testMetadatasProto,
+	}
 )
 
 func TestCounterToUnion(t *testing.T) {
@@ -380,6 +401,32 @@ func TestGaugeRoundTrip(t *testing.T) {
 	require.Equal(t, testGauge, c)
 }
 
+func TestSetToUnion(t *testing.T) {
+	require.Equal(t, testSetUnion, testSet.ToUnion())
+}
+
+func TestSetToProto(t *testing.T) {
+	var pb metricpb.Set
+	testSet.ToProto(&pb)
+	require.Equal(t, testSetProto, pb)
+}
+
+func TestSetFromProto(t *testing.T) {
+	var c Set
+	c.FromProto(testSetProto)
+	require.Equal(t, testSet, c)
+}
+
+func TestSetRoundTrip(t *testing.T) {
+	var (
+		pb metricpb.Set
+		c  Set
+	)
+	testSet.ToProto(&pb)
+	c.FromProto(pb)
+	require.Equal(t, testSet, c)
+}
+
 func TestCounterWithMetadatasToProto(t *testing.T) {
 	var pb metricpb.CounterWithMetadatas
 	require.NoError(t, testCounterWithMetadatas.ToProto(&pb))
@@ -514,3 +561,48 @@ func TestGaugeWithMetadatasRoundTrip(t *testing.T) {
 	require.NoError(t, g.FromProto(&pb))
 	require.Equal(t, testGaugeWithMetadatas, g)
 }
+
+func TestSetWithMetadatasToProto(t *testing.T) {
+	var pb metricpb.SetWithMetadatas
+	require.NoError(t, testSetWithMetadatas.ToProto(&pb))
+	require.Equal(t, testSetWithMetadatasProto, pb)
+}
+
+func TestSetWithMetadatasToProtoBadMetadatas(t *testing.T) {
+	var pb metricpb.SetWithMetadatas
+	badSetWithMetadatas := SetWithMetadatas{
+		Set:             testSet,
+		StagedMetadatas: testBadMetadatas,
+	}
+	require.Error(t, badSetWithMetadatas.ToProto(&pb))
+}
+
+func TestSetWithMetadatasFromProto(t *testing.T) {
+	var s SetWithMetadatas
+	require.NoError(t, s.FromProto(&testSetWithMetadatasProto))
+	require.Equal(t, testSetWithMetadatas, s)
+}
+
+func TestSetWithMetadatasFromProtoNilProto(t *testing.T) {
+	var s SetWithMetadatas
+	require.Equal(t, errNilSetWithMetadatasProto, s.FromProto(nil))
+}
+
+func TestSetWithMetadatasFromProtoBadProto(t *testing.T) {
+	var s SetWithMetadatas
+	badSetWithMetadatasProto := metricpb.SetWithMetadatas{
+		Set:       testSetProto,
+		Metadatas: testBadMetadatasProto,
+	}
+	require.Error(t, s.FromProto(&badSetWithMetadatasProto))
+}
+
+func TestSetWithMetadatasRoundTrip(t *testing.T) {
+	var (
+		pb metricpb.SetWithMetadatas
+		s  SetWithMetadatas
+	)
+	require.NoError(t, testSetWithMetadatas.ToProto(&pb))
+	require.NoError(t, s.FromProto(&pb))
+	require.Equal(t, testSetWithMetadatas, s)
+}
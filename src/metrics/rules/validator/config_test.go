@@ -100,6 +100,7 @@ metricTypes:
     - counter
     - timer
     - gauge
+    - set
 policies:
   defaultAllowed:
     storagePolicies:
@@ -124,6 +125,13 @@ policies:
       allowed:
         firstLevelAggregationTypes:
           - Last
+    - type: set
+      allowed:
+        firstLevelAggregationTypes:
+          - Distinct
+        nonFirstLevelAggregationTypes:
+          - Sum
+          - Distinct
 `
 
 	var cfg Configuration
@@ -214,6 +222,31 @@ policies:
 				aggregation.P99,
 			},
 		},
+		{
+			metricType: metric.SetType,
+			allowedStoragePolicies: policy.StoragePolicies{
+				policy.MustParseStoragePolicy("10s:2d"),
+				policy.MustParseStoragePolicy("1m:40d"),
+			},
+			disallowedStoragePolicies: policy.StoragePolicies{
+				policy.MustParseStoragePolicy("1m:2d"),
+				policy.MustParseStoragePolicy("10s:40d"),
+			},
+			allowedFirstLevelAggTypes: aggregation.Types{
+				aggregation.Distinct,
+			},
+			disallowedFirstLevelAggTypes: aggregation.Types{
+				aggregation.Count,
+			},
+			allowedNonFirstLevelAggTypes: aggregation.Types{
+				aggregation.Sum,
+				aggregation.Distinct,
+			},
+			disallowedNonFirstLevelAggTypes: aggregation.Types{
+				aggregation.Last,
+				aggregation.P99,
+			},
+		},
 	}
 
 	for _, input := range inputs {
@@ -230,6 +230,15 @@ func (v *validator) validateAggregationID(
 			}
 		}
 	}
+	if aggregationType == firstLevelAggregationType {
+		// Set values are raw bytes, so only a subset of aggregation types can
+		// be applied to them before they are turned into numeric values.
+		for _, t := range types {
+			if t == metric.SetType && !aggTypes.IsValidForSet() {
+				return fmt.Errorf("aggregation types %v are not valid for metric type %v", aggTypes, t)
+			}
+		}
+	}
 	isAllowedAggregationTypeForFn := v.opts.IsAllowedFirstLevelAggregationTypeFor
 	if aggregationType == nonFirstLevelAggregationType {
 		isAllowedAggregationTypeForFn = v.opts.IsAllowedNonFirstLevelAggregationTypeFor
@@ -48,6 +48,7 @@ const (
 	testCounterType   = "counter"
 	testTimerType     = "timer"
 	testGaugeType     = "gauge"
+	testSetType       = "set"
 	testNamespacesKey = "testNamespaces"
 )
 
@@ -1226,6 +1227,75 @@ func TestValidatorValidateRollupRuleRollupOpFirstLevelAggregationTypes(t *testin
 	}
 }
 
+func TestValidatorValidateRollupRuleRollupOpDistinctAggregationType(t *testing.T) {
+	newView := func(metricType string) view.RuleSet {
+		return view.RuleSet{
+			RollupRules: []view.RollupRule{
+				{
+					Name:   "snapshot1",
+					Filter: testTypeTag + ":" + metricType,
+					Targets: []view.RollupTarget{
+						{
+							Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+								{
+									Type: pipeline.RollupOpType,
+									Rollup: pipeline.RollupOp{
+										NewName:       []byte("rName1"),
+										Tags:          [][]byte{[]byte("rtagName1"), []byte("rtagName2")},
+										AggregationID: aggregation.MustCompressTypes(aggregation.Distinct),
+									},
+								},
+							}),
+							StoragePolicies: policy.StoragePolicies{
+								policy.MustParseStoragePolicy("10s:6h"),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	opts := testValidatorOptions().
+		SetAllowedFirstLevelAggregationTypesFor(metric.SetType, aggregation.Types{aggregation.Distinct})
+	validator := NewValidator(opts)
+	require.NoError(t, validator.ValidateSnapshot(newView(testSetType)))
+	require.Error(t, validator.ValidateSnapshot(newView(testCounterType)))
+}
+
+func TestValidatorValidateRollupRuleRollupOpInvalidAggregationTypeForSet(t *testing.T) {
+	view := view.RuleSet{
+		RollupRules: []view.RollupRule{
+			{
+				Name:   "snapshot1",
+				Filter: testTypeTag + ":" + testSetType,
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type: pipeline.RollupOpType,
+								Rollup: pipeline.RollupOp{
+									NewName:       []byte("rName1"),
+									Tags:          [][]byte{[]byte("rtagName1"), []byte("rtagName2")},
+									AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+								},
+							},
+						}),
+						StoragePolicies: policy.StoragePolicies{
+							policy.MustParseStoragePolicy("10s:6h"),
+						},
+					},
+				},
+			},
+		},
+	}
+	opts := testValidatorOptions().
+		SetAllowedFirstLevelAggregationTypesFor(metric.SetType, aggregation.Types{aggregation.Sum})
+	validator := NewValidator(opts)
+	err := validator.ValidateSnapshot(view)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not valid for metric type")
+}
+
 func TestValidatorValidateRollupRuleRollupOpNonFirstLevelAggregationTypes(t *testing.T) {
 	testAggregationTypes := []aggregation.Type{aggregation.Count, aggregation.Max}
 	view := view.RuleSet{
@@ -1651,6 +1721,8 @@ func testMetricTypesFn() MetricTypesFn {
 			return []metric.Type{metric.TimerType}, nil
 		case testGaugeType:
 			return []metric.Type{metric.GaugeType}, nil
+		case testSetType:
+			return []metric.Type{metric.SetType}, nil
 		default:
 			return nil, fmt.Errorf("unknown metric type %v", fv.Pattern)
 		}
@@ -71,6 +71,7 @@ type metrics struct {
 	messageReceived    tally.Counter
 	messageDecodeError tally.Counter
 	ackSent            tally.Counter
+	nackSent           tally.Counter
 	ackEncodeError     tally.Counter
 	ackWriteError      tally.Counter
 }
@@ -80,6 +81,7 @@ func newConsumerMetrics(scope tally.Scope) metrics {
 		messageReceived:    scope.Counter("message-received"),
 		messageDecodeError: scope.Counter("message-decode-error"),
 		ackSent:            scope.Counter("ack-sent"),
+		nackSent:           scope.Counter("nack-sent"),
 		ackEncodeError:     scope.Counter("ack-encode-error"),
 		ackWriteError:      scope.Counter("ack-write-error"),
 	}
@@ -153,15 +155,34 @@ func (c *consumer) tryAck(m msgpb.Metadata) {
 		return
 	}
 	c.ackPb.Metadata = append(c.ackPb.Metadata, m)
-	ackLen := len(c.ackPb.Metadata)
-	if ackLen < c.opts.AckBufferSize() {
+	c.tryEncodeAckWithLock()
+	c.Unlock()
+}
+
+// This function could be called concurrently if messages are being
+// processed concurrently.
+func (c *consumer) tryNack(m msgpb.Metadata, reason string) {
+	c.Lock()
+	if c.closed {
 		c.Unlock()
 		return
 	}
-	if err := c.encodeAckWithLock(ackLen); err != nil {
+	c.ackPb.Nacks = append(c.ackPb.Nacks, msgpb.Nack{Metadata: m, Reason: reason})
+	c.tryEncodeAckWithLock()
+	c.Unlock()
+}
+
+func (c *consumer) tryEncodeAckWithLock() {
+	if c.ackLenWithLock() < c.opts.AckBufferSize() {
+		return
+	}
+	if err := c.encodeAckWithLock(); err != nil {
 		c.conn.Close()
 	}
-	c.Unlock()
+}
+
+func (c *consumer) ackLenWithLock() int {
+	return len(c.ackPb.Metadata) + len(c.ackPb.Nacks)
 }
 
 func (c *consumer) ackUntilClose() {
@@ -181,16 +202,18 @@ func (c *consumer) ackUntilClose() {
 
 func (c *consumer) tryAckAndFlush() {
 	c.Lock()
-	if ackLen := len(c.ackPb.Metadata); ackLen > 0 {
-		c.encodeAckWithLock(ackLen)
+	if c.ackLenWithLock() > 0 {
+		c.encodeAckWithLock()
 	}
 	c.w.Flush()
 	c.Unlock()
 }
 
-func (c *consumer) encodeAckWithLock(ackLen int) error {
+func (c *consumer) encodeAckWithLock() error {
+	ackLen, nackLen := len(c.ackPb.Metadata), len(c.ackPb.Nacks)
 	err := c.encoder.Encode(&c.ackPb)
 	c.ackPb.Metadata = c.ackPb.Metadata[:0]
+	c.ackPb.Nacks = c.ackPb.Nacks[:0]
 	if err != nil {
 		c.m.ackEncodeError.Inc(1)
 		return err
@@ -201,6 +224,7 @@ func (c *consumer) encodeAckWithLock(ackLen int) error {
 		return err
 	}
 	c.m.ackSent.Inc(int64(ackLen))
+	c.m.nackSent.Inc(int64(nackLen))
 	return nil
 }
 
@@ -240,6 +264,13 @@ func (m *message) Ack() {
 	}
 }
 
+func (m *message) Nack(reason string) {
+	m.c.tryNack(m.Metadata, reason)
+	if m.mPool != nil {
+		m.mPool.Put(m)
+	}
+}
+
 func (m *message) reset(c *consumer) {
 	m.c = c
 	resetProto(&m.Message)
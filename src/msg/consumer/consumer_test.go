@@ -252,6 +252,57 @@ func TestConsumerAckBuffer(t *testing.T) {
 	m2.Ack()
 }
 
+func TestConsumerNack(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	opts := testOptions().SetAckBufferSize(2)
+	l, err := NewListener("127.0.0.1:0", opts)
+	require.NoError(t, err)
+	defer l.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+
+	c, err := l.Accept()
+	require.NoError(t, err)
+
+	mockEncoder := proto.NewMockEncoder(ctrl)
+	cc := c.(*consumer)
+	cc.encoder = mockEncoder
+
+	err = produce(conn, &testMsg1)
+	require.NoError(t, err)
+
+	err = produce(conn, &testMsg2)
+	require.NoError(t, err)
+
+	m1, err := cc.Message()
+	require.NoError(t, err)
+	require.Equal(t, testMsg1.Value, m1.Bytes())
+
+	m2, err := cc.Message()
+	require.NoError(t, err)
+	require.Equal(t, testMsg2.Value, m2.Bytes())
+
+	m1.Ack()
+
+	// Nacks count towards the ack buffer.
+	mockEncoder.EXPECT().Encode(gomock.Any()).Do(func(m proto.Marshaler) {
+		ack := m.(*msgpb.Ack)
+		require.Equal(t, []msgpb.Metadata{testMsg1.Metadata}, ack.Metadata)
+		require.Equal(t, []msgpb.Nack{
+			{Metadata: testMsg2.Metadata, Reason: "bad"},
+		}, ack.Nacks)
+	})
+	mockEncoder.EXPECT().Bytes()
+	m2.Nack("bad")
+	require.Empty(t, cc.ackPb.Metadata)
+	require.Empty(t, cc.ackPb.Nacks)
+}
+
 func TestConsumerAckAfterClosed(t *testing.T) {
 	defer leaktest.Check(t)()
 
@@ -37,6 +37,11 @@ type Message interface {
 
 	// Ack acks the message.
 	Ack()
+
+	// Nack rejects the message with a reason, the message will not be
+	// retried and will be routed to the dead-letter consumer service
+	// of the topic if there is one.
+	Nack(reason string)
 }
 
 // Consumer receives messages from a connection.
@@ -0,0 +1,132 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package deadletter
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/m3db/m3/src/msg/consumer"
+	"github.com/m3db/m3/src/msg/generated/proto/msgpb"
+	"github.com/m3db/m3x/log"
+)
+
+const (
+	invalidDeadLetterReason = "invalid dead letter"
+)
+
+// Recorder records dead letters as JSON lines.
+type Recorder struct {
+	sync.Mutex
+
+	w      *bufio.Writer
+	logger log.Logger
+}
+
+// NewRecorder creates a new recorder.
+func NewRecorder(w io.Writer, logger log.Logger) *Recorder {
+	return &Recorder{
+		w:      bufio.NewWriter(w),
+		logger: logger,
+	}
+}
+
+// Consume records the dead letters received from the consumer, it can be used
+// as the consumer.ConsumeFn of the dead-letter consumer service.
+func (r *Recorder) Consume(c consumer.Consumer) {
+	for {
+		msg, err := c.Message()
+		if err != nil {
+			return
+		}
+		var pb msgpb.DeadLetter
+		if err := pb.Unmarshal(msg.Bytes()); err != nil {
+			r.logger.Errorf("could not unmarshal dead letter: %v", err)
+			msg.Nack(invalidDeadLetterReason)
+			continue
+		}
+		if err := r.Record(pb); err != nil {
+			// NB: Not acking the message so it will be retried.
+			r.logger.Errorf("could not record dead letter: %v", err)
+			continue
+		}
+		msg.Ack()
+	}
+}
+
+// Record records a dead letter.
+func (r *Recorder) Record(pb msgpb.DeadLetter) error {
+	b, err := json.Marshal(pb)
+	if err != nil {
+		return err
+	}
+	r.Lock()
+	defer r.Unlock()
+
+	if _, err := r.w.Write(b); err != nil {
+		return err
+	}
+	if err := r.w.WriteByte('\n'); err != nil {
+		return err
+	}
+	// NB: Flush before acking so the dead letter is not lost.
+	return r.w.Flush()
+}
+
+// Reader reads the dead letters recorded by a Recorder.
+type Reader struct {
+	dec  *json.Decoder
+	curr msgpb.DeadLetter
+	err  error
+}
+
+// NewReader creates a new reader.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{dec: json.NewDecoder(r)}
+}
+
+// Next moves to the next dead letter, returns false when there are no more
+// dead letters or an error occurred.
+func (r *Reader) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	r.curr = msgpb.DeadLetter{}
+	if err := r.dec.Decode(&r.curr); err != nil {
+		if err != io.EOF {
+			r.err = err
+		}
+		return false
+	}
+	return true
+}
+
+// Current returns the current dead letter.
+func (r *Reader) Current() msgpb.DeadLetter {
+	return r.curr
+}
+
+// Err returns the error that stopped the reader, if any.
+func (r *Reader) Err() error {
+	return r.err
+}
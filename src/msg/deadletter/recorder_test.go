@@ -0,0 +1,126 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package deadletter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/m3db/m3/src/msg/consumer"
+	"github.com/m3db/m3/src/msg/generated/proto/msgpb"
+	"github.com/m3db/m3/src/msg/generated/proto/topicpb"
+	"github.com/m3db/m3x/log"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testDeadLetter1 = msgpb.DeadLetter{
+		Value: []byte("foo"),
+		Shard: 1,
+		ConsumerService: &topicpb.ServiceID{
+			Name:        "m3coordinator",
+			Environment: "env",
+			Zone:        "zone",
+		},
+		Reason:          "bad tags",
+		Attempts:        1,
+		InitNanos:       100,
+		DeadLetterNanos: 200,
+	}
+	testDeadLetter2 = msgpb.DeadLetter{
+		Value: []byte("bar"),
+		Shard: 2,
+		ConsumerService: &topicpb.ServiceID{
+			Name:        "m3aggregator",
+			Environment: "env",
+			Zone:        "zone",
+		},
+		Reason:          "retry budget exhausted",
+		Attempts:        10,
+		InitNanos:       300,
+		DeadLetterNanos: 400,
+	}
+)
+
+func TestRecorderReaderRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRecorder(&buf, log.NullLogger)
+	require.NoError(t, r.Record(testDeadLetter1))
+	require.NoError(t, r.Record(testDeadLetter2))
+	require.Equal(t, 2, strings.Count(buf.String(), "\n"))
+
+	reader := NewReader(&buf)
+	require.True(t, reader.Next())
+	require.Equal(t, testDeadLetter1, reader.Current())
+	require.True(t, reader.Next())
+	require.Equal(t, testDeadLetter2, reader.Current())
+	require.False(t, reader.Next())
+	require.NoError(t, reader.Err())
+}
+
+func TestReaderInvalidData(t *testing.T) {
+	reader := NewReader(strings.NewReader("{invalid"))
+	require.False(t, reader.Next())
+	require.Error(t, reader.Err())
+	require.False(t, reader.Next())
+}
+
+func TestRecorderConsume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b, err := testDeadLetter1.Marshal()
+	require.NoError(t, err)
+
+	m1 := consumer.NewMockMessage(ctrl)
+	m1.EXPECT().Bytes().Return(b)
+	m1.EXPECT().Ack()
+	m2 := consumer.NewMockMessage(ctrl)
+	m2.EXPECT().Bytes().Return([]byte("invalid"))
+	m2.EXPECT().Nack(invalidDeadLetterReason)
+
+	var buf bytes.Buffer
+	r := NewRecorder(&buf, log.NullLogger)
+	r.Consume(&mockConsumer{msgs: []consumer.Message{m1, m2}})
+
+	reader := NewReader(&buf)
+	require.True(t, reader.Next())
+	require.Equal(t, testDeadLetter1, reader.Current())
+	require.False(t, reader.Next())
+	require.NoError(t, reader.Err())
+}
+
+type mockConsumer struct {
+	msgs []consumer.Message
+}
+
+func (c *mockConsumer) Message() (consumer.Message, error) {
+	if len(c.msgs) == 0 {
+		return nil, errors.New("closed")
+	}
+	m := c.msgs[0]
+	c.msgs = c.msgs[1:]
+	return m, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package deadletter
+
+import (
+	"errors"
+
+	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/msg/generated/proto/msgpb"
+	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3/src/msg/topic"
+)
+
+var (
+	errNoConsumerService = errors.New("no consumer service for dead letter")
+	errDeadLetterSource  = errors.New("could not replay dead letter to the dead-letter consumer service")
+)
+
+// Replayer produces dead letters back to the consumer services that could
+// not consume them.
+type Replayer struct {
+	p        producer.Producer
+	t        topic.Topic
+	services []services.ServiceID
+}
+
+// NewReplayer creates a new replayer for the topic of the producer, it
+// registers filters on all the consumer services of the topic so each dead
+// letter is only received by its original consumer service. The filters
+// rely on the type of the produced messages, so the producer must not be
+// backed by a disk buffer, which wraps the produced messages.
+func NewReplayer(p producer.Producer, t topic.Topic) *Replayer {
+	r := &Replayer{p: p, t: t}
+	for _, cs := range t.ConsumerServices() {
+		sid := cs.ServiceID()
+		p.RegisterFilter(sid, newReplayFilter(sid))
+		r.services = append(r.services, sid)
+	}
+	return r
+}
+
+// Replay produces the dead letter to its original consumer service.
+func (r *Replayer) Replay(pb msgpb.DeadLetter) error {
+	if pb.ConsumerService == nil {
+		return errNoConsumerService
+	}
+	source := topic.NewServiceIDFromProto(pb.ConsumerService)
+	if dl := r.t.DeadLetterConsumerService(); dl != nil && dl.ServiceID().Equal(source) {
+		return errDeadLetterSource
+	}
+	return r.p.Produce(newReplayMessage(pb, source))
+}
+
+// Close unregisters the filters from the producer.
+func (r *Replayer) Close() {
+	for _, sid := range r.services {
+		r.p.UnregisterFilter(sid)
+	}
+}
+
+func newReplayFilter(sid services.ServiceID) producer.FilterFunc {
+	return func(m producer.Message) bool {
+		rm, ok := m.(*replayMessage)
+		return ok && rm.source.Equal(sid)
+	}
+}
+
+type replayMessage struct {
+	shard  uint32
+	value  []byte
+	source services.ServiceID
+}
+
+func newReplayMessage(pb msgpb.DeadLetter, source services.ServiceID) *replayMessage {
+	return &replayMessage{
+		shard:  pb.Shard,
+		value:  pb.Value,
+		source: source,
+	}
+}
+
+func (m *replayMessage) Shard() uint32 {
+	return m.shard
+}
+
+func (m *replayMessage) Bytes() []byte {
+	return m.value
+}
+
+func (m *replayMessage) Size() int {
+	return len(m.value)
+}
+
+func (m *replayMessage) Finalize(producer.FinalizeReason) {}
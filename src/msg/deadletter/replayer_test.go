@@ -0,0 +1,85 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package deadletter
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/msg/generated/proto/msgpb"
+	"github.com/m3db/m3/src/msg/generated/proto/topicpb"
+	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3/src/msg/topic"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sid1 := services.NewServiceID().SetName("m3coordinator").SetEnvironment("env").SetZone("zone")
+	sid2 := services.NewServiceID().SetName("m3aggregator").SetEnvironment("env").SetZone("zone")
+	sid3 := services.NewServiceID().SetName("dead-letter").SetEnvironment("env").SetZone("zone")
+	tp := topic.NewTopic().
+		SetName("topic").
+		SetNumberOfShards(4).
+		SetConsumerServices([]topic.ConsumerService{
+			topic.NewConsumerService().SetServiceID(sid1),
+			topic.NewConsumerService().SetServiceID(sid2),
+			topic.NewConsumerService().SetServiceID(sid3).SetDeadLetter(true),
+		})
+
+	filters := make(map[string]producer.FilterFunc)
+	p := producer.NewMockProducer(ctrl)
+	p.EXPECT().RegisterFilter(gomock.Any(), gomock.Any()).Do(
+		func(sid services.ServiceID, fn producer.FilterFunc) {
+			filters[sid.String()] = fn
+		},
+	).Times(3)
+	r := NewReplayer(p, tp)
+	require.Len(t, filters, 3)
+
+	var produced producer.Message
+	p.EXPECT().Produce(gomock.Any()).Do(func(m producer.Message) {
+		produced = m
+	})
+	require.NoError(t, r.Replay(testDeadLetter1))
+	require.Equal(t, uint32(1), produced.Shard())
+	require.Equal(t, []byte("foo"), produced.Bytes())
+	require.Equal(t, 3, produced.Size())
+	require.True(t, filters[sid1.String()](produced))
+	require.False(t, filters[sid2.String()](produced))
+	require.False(t, filters[sid3.String()](produced))
+
+	// Only replayed messages are accepted.
+	require.False(t, filters[sid1.String()](producer.NewMockMessage(ctrl)))
+
+	require.Error(t, r.Replay(msgpb.DeadLetter{Value: []byte("foo")}))
+	require.Error(t, r.Replay(msgpb.DeadLetter{
+		Value:           []byte("foo"),
+		ConsumerService: &topicpb.ServiceID{Name: "dead-letter", Environment: "env", Zone: "zone"},
+	}))
+
+	p.EXPECT().UnregisterFilter(gomock.Any()).Times(3)
+	r.Close()
+}
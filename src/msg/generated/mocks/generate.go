@@ -23,6 +23,7 @@
 //go:generate sh -c "mockgen -package=consumer github.com/m3db/m3/src/msg/consumer Message | genclean -pkg github.com/m3db/m3/src/msg/consumer -out $GOPATH/src/github.com/m3db/m3/src/msg/consumer/consumer_mock.go"
 //go:generate sh -c "mockgen -package=proto github.com/m3db/m3/src/msg/protocol/proto Encoder,Decoder | genclean -pkg github.com/m3db/m3/src/msg/protocol/proto -out $GOPATH/src/github.com/m3db/m3/src/msg/protocol/proto/proto_mock.go"
 //go:generate sh -c "mockgen -package=topic github.com/m3db/m3/src/msg/topic Service | genclean -pkg github.com/m3db/m3/src/msg/topic -out $GOPATH/src/github.com/m3db/m3/src/msg/topic/topic_mock.go"
+//go:generate sh -c "mockgen -package=kafka github.com/m3db/m3/src/msg/kafka Producer | genclean -pkg github.com/m3db/m3/src/msg/kafka -out $GOPATH/src/github.com/m3db/m3/src/msg/kafka/kafka_mock.go"
 
 // mockgen rules for generating mocks for unexported interfaces (file mode).
 //go:generate sh -c "mockgen -package=writer -destination=$GOPATH/src/github.com/m3db/m3/src/msg/producer/writer/consumer_service_writer_mock.go -source=$GOPATH/src/github.com/m3db/m3/src/msg/producer/writer/consumer_service_writer.go"
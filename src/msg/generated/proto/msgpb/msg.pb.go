@@ -31,6 +31,8 @@
 		Metadata
 		Message
 		Ack
+		Nack
+		DeadLetter
 */
 package msgpb
 
@@ -38,6 +40,7 @@ import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
+import topicpb "github.com/m3db/m3/src/msg/generated/proto/topicpb"
 
 import io "io"
 
@@ -102,6 +105,7 @@ func (m *Message) GetValue() []byte {
 
 type Ack struct {
 	Metadata []Metadata `protobuf:"bytes,1,rep,name=metadata" json:"metadata"`
+	Nacks    []Nack     `protobuf:"bytes,2,rep,name=nacks" json:"nacks"`
 }
 
 func (m *Ack) Reset()                    { *m = Ack{} }
@@ -116,10 +120,107 @@ func (m *Ack) GetMetadata() []Metadata {
 	return nil
 }
 
+func (m *Ack) GetNacks() []Nack {
+	if m != nil {
+		return m.Nacks
+	}
+	return nil
+}
+
+type Nack struct {
+	Metadata Metadata `protobuf:"bytes,1,opt,name=metadata" json:"metadata"`
+	Reason   string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *Nack) Reset()                    { *m = Nack{} }
+func (m *Nack) String() string            { return proto.CompactTextString(m) }
+func (*Nack) ProtoMessage()               {}
+func (*Nack) Descriptor() ([]byte, []int) { return fileDescriptorMsg, []int{3} }
+
+func (m *Nack) GetMetadata() Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return Metadata{}
+}
+
+func (m *Nack) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type DeadLetter struct {
+	Value           []byte             `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Shard           uint32             `protobuf:"varint,2,opt,name=shard,proto3" json:"shard,omitempty"`
+	ConsumerService *topicpb.ServiceID `protobuf:"bytes,3,opt,name=consumer_service,json=consumerService" json:"consumer_service,omitempty"`
+	Reason          string             `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Attempts        uint32             `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	InitNanos       int64              `protobuf:"varint,6,opt,name=init_nanos,json=initNanos,proto3" json:"init_nanos,omitempty"`
+	DeadLetterNanos int64              `protobuf:"varint,7,opt,name=dead_letter_nanos,json=deadLetterNanos,proto3" json:"dead_letter_nanos,omitempty"`
+}
+
+func (m *DeadLetter) Reset()                    { *m = DeadLetter{} }
+func (m *DeadLetter) String() string            { return proto.CompactTextString(m) }
+func (*DeadLetter) ProtoMessage()               {}
+func (*DeadLetter) Descriptor() ([]byte, []int) { return fileDescriptorMsg, []int{4} }
+
+func (m *DeadLetter) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *DeadLetter) GetShard() uint32 {
+	if m != nil {
+		return m.Shard
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetConsumerService() *topicpb.ServiceID {
+	if m != nil {
+		return m.ConsumerService
+	}
+	return nil
+}
+
+func (m *DeadLetter) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetAttempts() uint32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetInitNanos() int64 {
+	if m != nil {
+		return m.InitNanos
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetDeadLetterNanos() int64 {
+	if m != nil {
+		return m.DeadLetterNanos
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Metadata)(nil), "msgpb.Metadata")
 	proto.RegisterType((*Message)(nil), "msgpb.Message")
 	proto.RegisterType((*Ack)(nil), "msgpb.Ack")
+	proto.RegisterType((*Nack)(nil), "msgpb.Nack")
+	proto.RegisterType((*DeadLetter)(nil), "msgpb.DeadLetter")
 }
 func (m *Metadata) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -208,6 +309,110 @@ func (m *Ack) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.Nacks) > 0 {
+		for _, msg := range m.Nacks {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintMsg(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *Nack) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Nack) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintMsg(dAtA, i, uint64(m.Metadata.Size()))
+	n2, err := m.Metadata.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n2
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMsg(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	return i, nil
+}
+
+func (m *DeadLetter) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeadLetter) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMsg(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	if m.Shard != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintMsg(dAtA, i, uint64(m.Shard))
+	}
+	if m.ConsumerService != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMsg(dAtA, i, uint64(m.ConsumerService.Size()))
+		n3, err := m.ConsumerService.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintMsg(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	if m.Attempts != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintMsg(dAtA, i, uint64(m.Attempts))
+	}
+	if m.InitNanos != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintMsg(dAtA, i, uint64(m.InitNanos))
+	}
+	if m.DeadLetterNanos != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintMsg(dAtA, i, uint64(m.DeadLetterNanos))
+	}
 	return i, nil
 }
 
@@ -253,6 +458,54 @@ func (m *Ack) Size() (n int) {
 			n += 1 + l + sovMsg(uint64(l))
 		}
 	}
+	if len(m.Nacks) > 0 {
+		for _, e := range m.Nacks {
+			l = e.Size()
+			n += 1 + l + sovMsg(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Nack) Size() (n int) {
+	var l int
+	_ = l
+	l = m.Metadata.Size()
+	n += 1 + l + sovMsg(uint64(l))
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovMsg(uint64(l))
+	}
+	return n
+}
+
+func (m *DeadLetter) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovMsg(uint64(l))
+	}
+	if m.Shard != 0 {
+		n += 1 + sovMsg(uint64(m.Shard))
+	}
+	if m.ConsumerService != nil {
+		l = m.ConsumerService.Size()
+		n += 1 + l + sovMsg(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovMsg(uint64(l))
+	}
+	if m.Attempts != 0 {
+		n += 1 + sovMsg(uint64(m.Attempts))
+	}
+	if m.InitNanos != 0 {
+		n += 1 + sovMsg(uint64(m.InitNanos))
+	}
+	if m.DeadLetterNanos != 0 {
+		n += 1 + sovMsg(uint64(m.DeadLetterNanos))
+	}
 	return n
 }
 
@@ -528,6 +781,365 @@ func (m *Ack) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nacks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMsg
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nacks = append(m.Nacks, Nack{})
+			if err := m.Nacks[len(m.Nacks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMsg(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMsg
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Nack) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMsg
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Nack: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Nack: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMsg
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMsg
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMsg(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMsg
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeadLetter) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMsg
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeadLetter: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeadLetter: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMsg
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+			}
+			m.Shard = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Shard |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsumerService", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMsg
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConsumerService == nil {
+				m.ConsumerService = &topicpb.ServiceID{}
+			}
+			if err := m.ConsumerService.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMsg
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attempts", wireType)
+			}
+			m.Attempts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Attempts |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitNanos", wireType)
+			}
+			m.InitNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InitNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeadLetterNanos", wireType)
+			}
+			m.DeadLetterNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DeadLetterNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMsg(dAtA[iNdEx:])
@@ -659,20 +1271,31 @@ func init() {
 }
 
 var fileDescriptorMsg = []byte{
-	// 232 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xb2, 0x4a, 0xcf, 0x2c, 0xc9,
-	0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0xcf, 0x35, 0x4e, 0x49, 0xd2, 0xcf, 0x35, 0xd6, 0x2f,
-	0x2e, 0x4a, 0xd6, 0xcf, 0x2d, 0x4e, 0xd7, 0x4f, 0x4f, 0xcd, 0x4b, 0x2d, 0x4a, 0x2c, 0x49, 0x4d,
-	0xd1, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0x07, 0x89, 0x15, 0x24, 0x81, 0x48, 0x3d, 0x30, 0x5f, 0x88,
-	0x15, 0x2c, 0x20, 0xa5, 0x8b, 0x64, 0x44, 0x7a, 0x7e, 0x7a, 0x3e, 0x44, 0x75, 0x52, 0x69, 0x1a,
-	0x98, 0x07, 0xd1, 0x0a, 0x62, 0x41, 0x74, 0x29, 0x19, 0x70, 0x71, 0xf8, 0xa6, 0x96, 0x24, 0xa6,
-	0x24, 0x96, 0x24, 0x0a, 0x89, 0x70, 0xb1, 0x16, 0x67, 0x24, 0x16, 0xa5, 0x48, 0x30, 0x2a, 0x30,
-	0x6a, 0xb0, 0x04, 0x41, 0x38, 0x42, 0x7c, 0x5c, 0x4c, 0x99, 0x29, 0x12, 0x4c, 0x60, 0x21, 0xa6,
-	0xcc, 0x14, 0xa5, 0x20, 0x2e, 0x76, 0xdf, 0xd4, 0xe2, 0xe2, 0xc4, 0xf4, 0x54, 0x21, 0x43, 0x2e,
-	0x8e, 0x5c, 0xa8, 0x66, 0xb0, 0x1e, 0x6e, 0x23, 0x7e, 0x3d, 0xb0, 0x2b, 0xf4, 0x60, 0x66, 0x3a,
-	0xb1, 0x9c, 0xb8, 0x27, 0xcf, 0x10, 0x04, 0x57, 0x06, 0xb2, 0xa3, 0x2c, 0x31, 0xa7, 0x34, 0x15,
-	0x6c, 0x20, 0x4f, 0x10, 0x84, 0xa3, 0x64, 0xc1, 0xc5, 0xec, 0x98, 0x9c, 0x8d, 0x66, 0x1e, 0x33,
-	0x11, 0xe6, 0x39, 0x09, 0x9c, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72,
-	0x8c, 0x13, 0x1e, 0xcb, 0x31, 0x24, 0xb1, 0x81, 0x3d, 0x66, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff,
-	0x31, 0xd7, 0x86, 0x7e, 0x4c, 0x01, 0x00, 0x00,
+	// 403 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9d, 0x52, 0xcb, 0x4e, 0x83, 0x40,
+	0x14, 0x2d, 0x85, 0xbe, 0x6e, 0xd5, 0x56, 0x62, 0x0c, 0x69, 0x62, 0x35, 0x6c, 0x34, 0x26, 0x82,
+	0xda, 0x9d, 0x89, 0x26, 0x36, 0xdd, 0x98, 0xd8, 0x26, 0xe2, 0x07, 0x34, 0x03, 0x8c, 0x94, 0xb4,
+	0x30, 0x84, 0x19, 0xfa, 0x1d, 0x7e, 0x56, 0x97, 0x7e, 0x81, 0x31, 0xfa, 0x0f, 0xae, 0x9d, 0x19,
+	0x68, 0x25, 0xae, 0xd4, 0x05, 0xc3, 0x9c, 0x33, 0xe7, 0xdc, 0x7b, 0xee, 0x00, 0x5c, 0x05, 0x21,
+	0x9b, 0x65, 0xae, 0xe5, 0x91, 0xc8, 0x8e, 0x06, 0xbe, 0xcb, 0x17, 0x9b, 0xa6, 0x9e, 0x1d, 0xd1,
+	0xc0, 0x0e, 0x70, 0x8c, 0x53, 0xc4, 0xb0, 0x6f, 0x27, 0x29, 0x61, 0x44, 0x70, 0x89, 0x2b, 0x56,
+	0x4b, 0x62, 0xbd, 0x26, 0x89, 0xde, 0x59, 0xa9, 0x44, 0x40, 0x02, 0x92, 0xab, 0xdd, 0xec, 0x49,
+	0xa2, 0xdc, 0x2a, 0x76, 0xb9, 0xab, 0x77, 0xf3, 0x87, 0x8e, 0x8c, 0x24, 0xa1, 0xc7, 0x7b, 0xca,
+	0x77, 0xee, 0x37, 0xcf, 0xa1, 0x39, 0xc6, 0x0c, 0xf9, 0x88, 0x21, 0x7d, 0x0f, 0x6a, 0x74, 0x86,
+	0x52, 0xdf, 0x50, 0x8e, 0x94, 0x13, 0xcd, 0xc9, 0x81, 0xbe, 0x03, 0xd5, 0xd0, 0x37, 0xaa, 0x92,
+	0xe2, 0x3b, 0xd3, 0x81, 0xc6, 0x18, 0x53, 0x8a, 0x02, 0xac, 0x5f, 0x40, 0x33, 0x2a, 0xcc, 0xd2,
+	0xd3, 0xbe, 0xec, 0x58, 0x72, 0x0a, 0x6b, 0x5d, 0x73, 0xa8, 0xad, 0x5e, 0x0f, 0x2b, 0xce, 0x46,
+	0x26, 0x7a, 0x2c, 0xd1, 0x22, 0xc3, 0xb2, 0xe0, 0x96, 0x93, 0x03, 0x13, 0x81, 0x7a, 0xeb, 0xcd,
+	0x7f, 0xd4, 0x53, 0x7f, 0x53, 0xef, 0x18, 0x6a, 0x31, 0xf2, 0xe6, 0x94, 0xd7, 0x13, 0xfa, 0x76,
+	0xa1, 0x9f, 0x70, 0xae, 0xd0, 0xe6, 0xe7, 0xe6, 0x03, 0x68, 0x82, 0xfc, 0x4f, 0xe6, 0x7d, 0xa8,
+	0xa7, 0x18, 0x51, 0x12, 0xcb, 0xd0, 0x2d, 0xa7, 0x40, 0xe6, 0xa7, 0x02, 0x30, 0xc2, 0xc8, 0xbf,
+	0xc7, 0x8c, 0xe1, 0xf4, 0x7b, 0x34, 0xa5, 0x34, 0xda, 0xf7, 0xa5, 0x0a, 0xef, 0xf6, 0xfa, 0x52,
+	0xaf, 0xa1, 0xeb, 0x91, 0x98, 0x66, 0x11, 0x4e, 0xa7, 0x14, 0xa7, 0xcb, 0xd0, 0xc3, 0x86, 0x2a,
+	0xd3, 0xe8, 0x56, 0xf1, 0x99, 0xac, 0xc7, 0x9c, 0xbf, 0x1b, 0x39, 0x9d, 0xb5, 0xb6, 0xa0, 0x4a,
+	0x89, 0xb4, 0x72, 0x22, 0xbd, 0x07, 0x4d, 0xc4, 0xb3, 0x44, 0x09, 0xa3, 0x46, 0x4d, 0xf6, 0xdb,
+	0x60, 0xfd, 0x00, 0x20, 0x8c, 0x43, 0x36, 0x8d, 0x51, 0x4c, 0xa8, 0x51, 0xe7, 0xa7, 0xaa, 0xd3,
+	0x12, 0xcc, 0x44, 0x10, 0xfa, 0x29, 0xec, 0xfa, 0x7c, 0x96, 0xe9, 0x42, 0x0e, 0x53, 0xa8, 0x1a,
+	0x52, 0xd5, 0xf1, 0x37, 0x43, 0x4a, 0xed, 0xb0, 0xbb, 0x7a, 0xef, 0x2b, 0x2f, 0xfc, 0x79, 0xe3,
+	0xcf, 0xf3, 0x47, 0xbf, 0xe2, 0xd6, 0xe5, 0xdf, 0x34, 0xf8, 0x02, 0x50, 0xdd, 0x37, 0x82, 0x01,
+	0x03, 0x00, 0x00,
 }
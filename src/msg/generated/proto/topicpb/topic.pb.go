@@ -111,6 +111,7 @@ type ConsumerService struct {
 	ServiceId       *ServiceID      `protobuf:"bytes,1,opt,name=service_id,json=serviceId" json:"service_id,omitempty"`
 	ConsumptionType ConsumptionType `protobuf:"varint,2,opt,name=consumption_type,json=consumptionType,proto3,enum=topicpb.ConsumptionType" json:"consumption_type,omitempty"`
 	MessageTtlNanos int64           `protobuf:"varint,3,opt,name=message_ttl_nanos,json=messageTtlNanos,proto3" json:"message_ttl_nanos,omitempty"`
+	DeadLetter      bool            `protobuf:"varint,4,opt,name=dead_letter,json=deadLetter,proto3" json:"dead_letter,omitempty"`
 }
 
 func (m *ConsumerService) Reset()                    { *m = ConsumerService{} }
@@ -139,6 +140,13 @@ func (m *ConsumerService) GetMessageTtlNanos() int64 {
 	return 0
 }
 
+func (m *ConsumerService) GetDeadLetter() bool {
+	if m != nil {
+		return m.DeadLetter
+	}
+	return false
+}
+
 type ServiceID struct {
 	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Environment string `protobuf:"bytes,2,opt,name=environment,proto3" json:"environment,omitempty"`
@@ -253,6 +261,16 @@ func (m *ConsumerService) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintTopic(dAtA, i, uint64(m.MessageTtlNanos))
 	}
+	if m.DeadLetter {
+		dAtA[i] = 0x20
+		i++
+		if m.DeadLetter {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -333,6 +351,9 @@ func (m *ConsumerService) Size() (n int) {
 	if m.MessageTtlNanos != 0 {
 		n += 1 + sovTopic(uint64(m.MessageTtlNanos))
 	}
+	if m.DeadLetter {
+		n += 2
+	}
 	return n
 }
 
@@ -596,6 +617,26 @@ func (m *ConsumerService) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeadLetter", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTopic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DeadLetter = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTopic(dAtA[iNdEx:])
@@ -864,30 +905,30 @@ func init() {
 }
 
 var fileDescriptorTopic = []byte{
-	// 385 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x4f, 0x8e, 0xd3, 0x30,
-	0x14, 0x87, 0xc7, 0x53, 0x98, 0x51, 0x5e, 0x44, 0x9b, 0xf1, 0x2a, 0xab, 0x28, 0xea, 0x2a, 0x9a,
-	0x45, 0x22, 0xa6, 0x3b, 0x16, 0x48, 0x43, 0x1b, 0x89, 0x0a, 0x94, 0x41, 0x6e, 0x46, 0x2c, 0xa3,
-	0xfc, 0xf1, 0x64, 0x22, 0xd5, 0x76, 0x64, 0xbb, 0x95, 0xca, 0x19, 0x58, 0x70, 0x19, 0xee, 0xc0,
-	0x92, 0x23, 0xa0, 0x72, 0x11, 0x14, 0xd7, 0x14, 0x0a, 0xb3, 0xca, 0xd3, 0x97, 0x9f, 0xdf, 0xfb,
-	0xfc, 0x0c, 0xaf, 0xdb, 0x4e, 0x3f, 0x6e, 0xaa, 0xb8, 0x16, 0x2c, 0x61, 0xb3, 0xa6, 0x4a, 0xd8,
-	0x2c, 0x51, 0xb2, 0x4e, 0x98, 0x6a, 0x93, 0x96, 0x72, 0x2a, 0x4b, 0x4d, 0x9b, 0xa4, 0x97, 0x42,
-	0x8b, 0x44, 0x8b, 0xbe, 0xab, 0xfb, 0xea, 0xf0, 0x8d, 0x0d, 0xc3, 0x97, 0x16, 0x4e, 0x3f, 0x23,
-	0x78, 0x9e, 0x0f, 0x35, 0xc6, 0xf0, 0x8c, 0x97, 0x8c, 0xfa, 0x28, 0x44, 0x91, 0x43, 0x4c, 0x8d,
-	0x23, 0xf0, 0xf8, 0x86, 0x55, 0x54, 0x16, 0xe2, 0xa1, 0x50, 0x8f, 0xa5, 0x6c, 0x94, 0x7f, 0x1e,
-	0xa2, 0xe8, 0x05, 0x19, 0x1f, 0xf8, 0xdd, 0xc3, 0xca, 0x50, 0x9c, 0xc2, 0x55, 0x2d, 0xb8, 0xda,
-	0x30, 0x2a, 0x0b, 0x45, 0xe5, 0xb6, 0xab, 0xa9, 0xf2, 0x47, 0xe1, 0x28, 0x72, 0x6f, 0xfc, 0xd8,
-	0x0e, 0x8b, 0xe7, 0x36, 0xb1, 0x3a, 0x04, 0x88, 0x57, 0x9f, 0x02, 0x35, 0xfd, 0x8a, 0x60, 0xf2,
-	0x4f, 0x0a, 0xbf, 0x04, 0xb0, 0x1d, 0x8b, 0xae, 0x31, 0x7a, 0xee, 0x0d, 0x3e, 0xf6, 0xb4, 0xa9,
-	0xe5, 0x82, 0x38, 0x36, 0xb5, 0x6c, 0xf0, 0x1c, 0x6c, 0xeb, 0x5e, 0x77, 0x82, 0x17, 0x7a, 0xd7,
-	0x53, 0xe3, 0x3d, 0xfe, 0x4f, 0xc6, 0x04, 0xf2, 0x5d, 0x4f, 0xc9, 0xa4, 0x3e, 0x05, 0xf8, 0x1a,
-	0xae, 0x18, 0x55, 0xaa, 0x6c, 0x69, 0xa1, 0xf5, 0xba, 0xe0, 0x25, 0x17, 0xc3, 0x95, 0x50, 0x34,
-	0x22, 0x13, 0xfb, 0x23, 0xd7, 0xeb, 0x6c, 0xc0, 0xd3, 0x7b, 0x70, 0x8e, 0x22, 0x4f, 0x6e, 0x32,
-	0x04, 0x97, 0xf2, 0x6d, 0x27, 0x05, 0x67, 0x94, 0x6b, 0x23, 0xe3, 0x90, 0xbf, 0xd1, 0x70, 0xea,
-	0x93, 0xe0, 0xd4, 0x4c, 0x70, 0x88, 0xa9, 0xaf, 0x5f, 0xfd, 0xde, 0xc6, 0x1f, 0x2b, 0x17, 0x2e,
-	0xef, 0xb3, 0x77, 0xd9, 0xdd, 0xc7, 0xcc, 0x3b, 0xc3, 0x00, 0x17, 0xab, 0xb7, 0xb7, 0x24, 0x5d,
-	0x78, 0x08, 0x8f, 0x01, 0x48, 0xfa, 0xe1, 0xfd, 0x72, 0x7e, 0x9b, 0xa7, 0x0b, 0xef, 0xfc, 0x8d,
-	0xf7, 0x6d, 0x1f, 0xa0, 0xef, 0xfb, 0x00, 0xfd, 0xd8, 0x07, 0xe8, 0xcb, 0xcf, 0xe0, 0xac, 0xba,
-	0x30, 0x6f, 0x3f, 0xfb, 0x15, 0x00, 0x00, 0xff, 0xff, 0xd4, 0xd7, 0xf0, 0x71, 0x3d, 0x02, 0x00,
-	0x00,
+	// 392 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6d, 0x92, 0xcf, 0x4f, 0xc2, 0x30,
+	0x14, 0xc7, 0x1d, 0x20, 0xc8, 0x5b, 0x84, 0xd1, 0xd3, 0x4e, 0x48, 0x38, 0x11, 0x0e, 0x2c, 0xc2,
+	0xcd, 0x83, 0x09, 0x02, 0x89, 0x44, 0x02, 0xa6, 0x40, 0x3c, 0x2e, 0xfb, 0x51, 0x60, 0x09, 0x6b,
+	0x97, 0xb5, 0x90, 0xe8, 0xdf, 0xe0, 0xc1, 0x3f, 0xcb, 0xa3, 0x27, 0xcf, 0x46, 0xff, 0x11, 0xbb,
+	0x32, 0x51, 0xd4, 0x43, 0xdb, 0xd7, 0xcf, 0xfb, 0xbe, 0xd7, 0x6f, 0x9b, 0xc2, 0xe5, 0x32, 0x10,
+	0xab, 0x8d, 0xdb, 0xf2, 0x58, 0x68, 0x85, 0x1d, 0xdf, 0x95, 0x93, 0xc5, 0x63, 0xcf, 0x0a, 0xf9,
+	0xd2, 0x5a, 0x12, 0x4a, 0x62, 0x47, 0x10, 0xdf, 0x8a, 0x62, 0x26, 0x98, 0x25, 0x58, 0x14, 0x78,
+	0x91, 0xbb, 0x5b, 0x5b, 0x8a, 0xa1, 0x42, 0x0a, 0xeb, 0x8f, 0x1a, 0x1c, 0xcf, 0x92, 0x18, 0x21,
+	0xc8, 0x51, 0x27, 0x24, 0xa6, 0x56, 0xd3, 0x1a, 0x45, 0xac, 0x62, 0xd4, 0x00, 0x83, 0x6e, 0x42,
+	0x97, 0xc4, 0x36, 0x5b, 0xd8, 0x7c, 0xe5, 0xc4, 0x3e, 0x37, 0x33, 0x32, 0x7f, 0x8a, 0x4b, 0x3b,
+	0x3e, 0x59, 0x4c, 0x15, 0x45, 0x03, 0xa8, 0x78, 0x8c, 0xf2, 0x4d, 0x28, 0xb5, 0x9c, 0xc4, 0xdb,
+	0xc0, 0x23, 0xdc, 0xcc, 0xd6, 0xb2, 0x0d, 0xbd, 0x6d, 0xb6, 0xd2, 0xc3, 0x5a, 0xbd, 0x54, 0x31,
+	0xdd, 0x09, 0xb0, 0xe1, 0x1d, 0x02, 0x5e, 0x7f, 0xd5, 0xa0, 0xfc, 0x4b, 0x85, 0xce, 0x01, 0xd2,
+	0x8e, 0x76, 0xe0, 0x2b, 0x7b, 0x7a, 0x1b, 0xed, 0x7b, 0xa6, 0xaa, 0x61, 0x1f, 0x17, 0x53, 0xd5,
+	0xd0, 0x47, 0x3d, 0x48, 0x5b, 0x47, 0x22, 0x60, 0xd4, 0x16, 0xf7, 0x11, 0x51, 0xbe, 0x4b, 0x7f,
+	0xcc, 0x28, 0xc1, 0x4c, 0xe6, 0x71, 0xd9, 0x3b, 0x04, 0xa8, 0x09, 0x95, 0x90, 0x70, 0xee, 0x2c,
+	0x89, 0x2d, 0xc4, 0xda, 0xa6, 0x0e, 0x65, 0xc9, 0x95, 0xb4, 0x46, 0x16, 0x97, 0xd3, 0xc4, 0x4c,
+	0xac, 0xc7, 0x09, 0x46, 0x67, 0xa0, 0xfb, 0xc4, 0xf1, 0xed, 0x35, 0x11, 0x82, 0xc4, 0x66, 0x4e,
+	0xaa, 0x4e, 0x30, 0x24, 0x68, 0xa4, 0x48, 0x7d, 0x0e, 0xc5, 0xbd, 0xd3, 0x7f, 0x9f, 0xba, 0x06,
+	0x3a, 0xa1, 0xdb, 0x20, 0x66, 0x34, 0x24, 0x54, 0x28, 0xb7, 0x45, 0xfc, 0x13, 0x25, 0x55, 0x0f,
+	0x8c, 0x12, 0x65, 0x41, 0x56, 0x25, 0x71, 0xf3, 0xe2, 0xeb, 0xb9, 0xbe, 0x6d, 0xeb, 0x50, 0x98,
+	0x8f, 0x6f, 0xc6, 0x93, 0xbb, 0xb1, 0x71, 0x84, 0x00, 0xf2, 0xd3, 0xeb, 0x2e, 0x1e, 0xf4, 0x0d,
+	0x0d, 0x95, 0x00, 0xf0, 0xe0, 0x76, 0x34, 0xec, 0x75, 0x67, 0x72, 0x9f, 0xb9, 0x32, 0x9e, 0xdf,
+	0xab, 0xda, 0x8b, 0x1c, 0x6f, 0x72, 0x3c, 0x7d, 0x54, 0x8f, 0xdc, 0xbc, 0xfa, 0x1c, 0x9d, 0x4f,
+	0x64, 0x47, 0xbc, 0xd9, 0x5e, 0x02, 0x00, 0x00,
 }
@@ -0,0 +1,180 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/msg/consumer"
+	"github.com/m3db/m3x/instrument"
+
+	"github.com/Shopify/sarama"
+)
+
+const (
+	oldestOffset = "oldest"
+	newestOffset = "newest"
+)
+
+// ClientConfiguration configs the Kafka client.
+type ClientConfiguration struct {
+	// Brokers are the addresses of the seed brokers.
+	Brokers []string `yaml:"brokers" validate:"nonzero"`
+
+	// ClientID is the client id reported to the brokers.
+	ClientID string `yaml:"clientID"`
+
+	// Version is the Kafka version of the brokers, e.g. 1.0.0.
+	Version string `yaml:"version"`
+
+	// DialTimeout is the timeout to connect to a broker.
+	DialTimeout time.Duration `yaml:"dialTimeout"`
+}
+
+// NewConfig creates a new Kafka client config.
+func (c ClientConfiguration) NewConfig() (*sarama.Config, error) {
+	cfg := sarama.NewConfig()
+	if c.ClientID != "" {
+		cfg.ClientID = c.ClientID
+	}
+	if c.Version != "" {
+		version, err := sarama.ParseKafkaVersion(c.Version)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Version = version
+	}
+	if c.DialTimeout > 0 {
+		cfg.Net.DialTimeout = c.DialTimeout
+	}
+	return cfg, nil
+}
+
+// ProducerConfiguration configs the Kafka producer.
+type ProducerConfiguration struct {
+	// Client configs the Kafka client.
+	Client ClientConfiguration `yaml:"client"`
+
+	// Topic is the Kafka topic to produce to.
+	Topic string `yaml:"topic" validate:"nonzero"`
+
+	// RequiredAcks is the number of acks required from the brokers, 0 for
+	// none, 1 for the leader only and -1 for all the in-sync replicas.
+	RequiredAcks *int16 `yaml:"requiredAcks"`
+
+	// FlushFrequency is the frequency to flush the batched messages.
+	FlushFrequency time.Duration `yaml:"flushFrequency"`
+
+	// FlushMessages is the number of messages to batch before a flush.
+	FlushMessages int `yaml:"flushMessages"`
+
+	// MaxMessageBytes is the max size of a message.
+	MaxMessageBytes int `yaml:"maxMessageBytes"`
+
+	// RetryMax is the max number of retries to produce a message.
+	RetryMax *int `yaml:"retryMax"`
+}
+
+// NewProducer creates a new Kafka producer.
+func (c ProducerConfiguration) NewProducer(
+	iOpts instrument.Options,
+) (Producer, error) {
+	cfg, err := c.Client.NewConfig()
+	if err != nil {
+		return nil, err
+	}
+	if c.RequiredAcks != nil {
+		cfg.Producer.RequiredAcks = sarama.RequiredAcks(*c.RequiredAcks)
+	}
+	if c.FlushFrequency > 0 {
+		cfg.Producer.Flush.Frequency = c.FlushFrequency
+	}
+	if c.FlushMessages > 0 {
+		cfg.Producer.Flush.Messages = c.FlushMessages
+	}
+	if c.MaxMessageBytes > 0 {
+		cfg.Producer.MaxMessageBytes = c.MaxMessageBytes
+	}
+	if c.RetryMax != nil {
+		cfg.Producer.Retry.Max = *c.RetryMax
+	}
+	return NewProducer(c.Client.Brokers, cfg, ProducerOptions{
+		Topic:             c.Topic,
+		InstrumentOptions: iOpts,
+	})
+}
+
+// ConsumerConfiguration configs the Kafka consumer.
+type ConsumerConfiguration struct {
+	// Client configs the Kafka client.
+	Client ClientConfiguration `yaml:"client"`
+
+	// Topic is the Kafka topic to consume from.
+	Topic string `yaml:"topic" validate:"nonzero"`
+
+	// Partitions are the partitions to consume, all the partitions of the
+	// topic are consumed if empty.
+	Partitions []int32 `yaml:"partitions"`
+
+	// Group is the consumer group to commit offsets for.
+	Group string `yaml:"group"`
+
+	// InitialOffset is where to start consuming a partition without a
+	// committed offset, either oldest or newest.
+	InitialOffset string `yaml:"initialOffset"`
+
+	// CommitInterval is the interval to commit the offsets.
+	CommitInterval time.Duration `yaml:"commitInterval"`
+}
+
+// NewSubscriber creates a new Kafka subscriber.
+func (c ConsumerConfiguration) NewSubscriber(
+	consumeFn consumer.ConsumeFn,
+	iOpts instrument.Options,
+) (Subscriber, error) {
+	cfg, err := c.Client.NewConfig()
+	if err != nil {
+		return nil, err
+	}
+	switch c.InitialOffset {
+	case "":
+	case oldestOffset:
+		cfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+	case newestOffset:
+		cfg.Consumer.Offsets.Initial = sarama.OffsetNewest
+	default:
+		return nil, fmt.Errorf(
+			"invalid initial offset %s, valid offsets are: %s, %s",
+			c.InitialOffset, oldestOffset, newestOffset,
+		)
+	}
+	if c.CommitInterval > 0 {
+		cfg.Consumer.Offsets.CommitInterval = c.CommitInterval
+	}
+	return NewSubscriber(c.Client.Brokers, cfg, SubscriberOptions{
+		Topic:             c.Topic,
+		Partitions:        c.Partitions,
+		Group:             c.Group,
+		ConsumeFn:         consumeFn,
+		InstrumentOptions: iOpts,
+	})
+}
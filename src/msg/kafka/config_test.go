@@ -0,0 +1,66 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/msg/consumer"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestClientConfiguration(t *testing.T) {
+	str := `
+brokers:
+  - 127.0.0.1:9092
+clientID: m3
+version: 1.0.0
+dialTimeout: 5s
+`
+
+	var cfg ClientConfiguration
+	require.NoError(t, yaml.Unmarshal([]byte(str), &cfg))
+	require.Equal(t, []string{"127.0.0.1:9092"}, cfg.Brokers)
+
+	c, err := cfg.NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, "m3", c.ClientID)
+	require.Equal(t, sarama.V1_0_0_0, c.Version)
+	require.Equal(t, 5*time.Second, c.Net.DialTimeout)
+
+	cfg.Version = "foo"
+	_, err = cfg.NewConfig()
+	require.Error(t, err)
+}
+
+func TestConsumerConfigurationInvalidInitialOffset(t *testing.T) {
+	cfg := ConsumerConfiguration{
+		Topic:         testTopic,
+		InitialOffset: "latest",
+	}
+	_, err := cfg.NewSubscriber(func(consumer.Consumer) {}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid initial offset latest")
+}
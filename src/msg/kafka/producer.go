@@ -0,0 +1,202 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3x/instrument"
+	"github.com/m3db/m3x/log"
+
+	"github.com/Shopify/sarama"
+	"github.com/uber-go/tally"
+)
+
+var (
+	errProducerClosed = errors.New("producer is closed")
+)
+
+// Producer produces m3msg messages to a Kafka topic.
+type Producer interface {
+	// Produce produces the message to the partition its shard maps to, the
+	// message is finalized once it has been acknowledged by the brokers or
+	// could not be produced.
+	Produce(m producer.Message) error
+
+	// Close closes the producer once all the in-flight messages are finalized.
+	Close()
+}
+
+// ProducerOptions configs a producer.
+type ProducerOptions struct {
+	// Topic is the Kafka topic to produce to.
+	Topic string
+
+	// InstrumentOptions is the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+type producerMetrics struct {
+	produceSuccess tally.Counter
+	produceErrors  tally.Counter
+	producerClosed tally.Counter
+}
+
+func newProducerMetrics(scope tally.Scope) producerMetrics {
+	return producerMetrics{
+		produceSuccess: scope.Counter("produce-success"),
+		produceErrors:  scope.Counter("produce-errors"),
+		producerClosed: scope.Counter("producer-closed"),
+	}
+}
+
+type kafkaProducer struct {
+	sync.RWMutex
+
+	topic         string
+	numPartitions uint32
+	client        sarama.Client
+	p             sarama.AsyncProducer
+	logger        log.Logger
+	m             producerMetrics
+
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewProducer creates a new producer for the topic on the brokers. Message
+// shards are mapped onto the partitions of the topic by modulo, so the total
+// number of shards should be a multiple of the number of partitions for the
+// messages to spread evenly.
+func NewProducer(
+	brokers []string,
+	cfg *sarama.Config,
+	opts ProducerOptions,
+) (Producer, error) {
+	// The producer relies on the successes to finalize the messages and picks
+	// the partitions of the messages itself.
+	conf := *cfg
+	conf.Producer.Return.Successes = true
+	conf.Producer.Return.Errors = true
+	conf.Producer.Partitioner = sarama.NewManualPartitioner
+	client, err := sarama.NewClient(brokers, &conf)
+	if err != nil {
+		return nil, err
+	}
+	partitions, err := client.Partitions(opts.Topic)
+	if err == nil && len(partitions) == 0 {
+		err = fmt.Errorf("no partitions for topic %s", opts.Topic)
+	}
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	p, err := sarama.NewAsyncProducerFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return newProducer(p, client, uint32(len(partitions)), opts), nil
+}
+
+func newProducer(
+	p sarama.AsyncProducer,
+	client sarama.Client,
+	numPartitions uint32,
+	opts ProducerOptions,
+) *kafkaProducer {
+	iOpts := opts.InstrumentOptions
+	if iOpts == nil {
+		iOpts = instrument.NewOptions()
+	}
+	kp := &kafkaProducer{
+		topic:         opts.Topic,
+		numPartitions: numPartitions,
+		client:        client,
+		p:             p,
+		logger:        iOpts.Logger(),
+		m:             newProducerMetrics(iOpts.MetricsScope()),
+	}
+	kp.wg.Add(2)
+	go kp.handleSuccesses()
+	go kp.handleErrors()
+	return kp
+}
+
+func (p *kafkaProducer) Produce(m producer.Message) error {
+	p.RLock()
+	if p.closed {
+		p.RUnlock()
+		p.m.producerClosed.Inc(1)
+		return errProducerClosed
+	}
+	p.p.Input() <- &sarama.ProducerMessage{
+		Topic:     p.topic,
+		Partition: int32(m.Shard() % p.numPartitions),
+		Value:     sarama.ByteEncoder(m.Bytes()),
+		Metadata:  m,
+	}
+	p.RUnlock()
+	return nil
+}
+
+func (p *kafkaProducer) Close() {
+	p.Lock()
+	if p.closed {
+		p.Unlock()
+		return
+	}
+	p.closed = true
+	p.Unlock()
+
+	// The producer is shut down once both the successes and the errors
+	// are drained.
+	p.p.AsyncClose()
+	p.wg.Wait()
+	if p.client != nil {
+		p.client.Close()
+	}
+}
+
+func (p *kafkaProducer) handleSuccesses() {
+	defer p.wg.Done()
+
+	for msg := range p.p.Successes() {
+		p.m.produceSuccess.Inc(1)
+		msg.Metadata.(producer.Message).Finalize(producer.Consumed)
+	}
+}
+
+func (p *kafkaProducer) handleErrors() {
+	defer p.wg.Done()
+
+	for err := range p.p.Errors() {
+		p.m.produceErrors.Inc(1)
+		p.logger.Errorf(
+			"could not produce message to partition %d of topic %s: %v",
+			err.Msg.Partition, p.topic, err.Err,
+		)
+		err.Msg.Metadata.(producer.Message).Finalize(producer.Dropped)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/msg/producer"
+
+	"github.com/Shopify/sarama"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+const testTopic = "test-topic"
+
+func TestProducerMapsShardsToPartitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ap := newTestAsyncProducer()
+	p := newProducer(ap, nil, 4, ProducerOptions{Topic: testTopic})
+
+	finalized := make(chan producer.FinalizeReason, 2)
+	mm1 := producer.NewMockMessage(ctrl)
+	mm1.EXPECT().Shard().Return(uint32(6))
+	mm1.EXPECT().Bytes().Return([]byte("foo"))
+	mm1.EXPECT().Finalize(producer.Consumed).Do(func(r producer.FinalizeReason) {
+		finalized <- r
+	})
+	require.NoError(t, p.Produce(mm1))
+
+	msg := <-ap.input
+	require.Equal(t, testTopic, msg.Topic)
+	require.Equal(t, int32(2), msg.Partition)
+	b, err := msg.Value.Encode()
+	require.NoError(t, err)
+	require.Equal(t, []byte("foo"), b)
+	ap.successes <- msg
+	require.Equal(t, producer.Consumed, <-finalized)
+
+	mm2 := producer.NewMockMessage(ctrl)
+	mm2.EXPECT().Shard().Return(uint32(3))
+	mm2.EXPECT().Bytes().Return([]byte("bar"))
+	mm2.EXPECT().Finalize(producer.Dropped).Do(func(r producer.FinalizeReason) {
+		finalized <- r
+	})
+	require.NoError(t, p.Produce(mm2))
+
+	msg = <-ap.input
+	require.Equal(t, int32(3), msg.Partition)
+	ap.errors <- &sarama.ProducerError{Msg: msg, Err: sarama.ErrMessageSizeTooLarge}
+	require.Equal(t, producer.Dropped, <-finalized)
+
+	p.Close()
+	require.Equal(t, errProducerClosed, p.Produce(producer.NewMockMessage(ctrl)))
+}
+
+func TestProducerWithBroker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(testTopic, 0, broker.BrokerID()).
+			SetLeader(testTopic, 1, broker.BrokerID()),
+		"ProduceRequest": sarama.NewMockProduceResponse(t).
+			SetError(testTopic, 1, sarama.ErrMessageSizeTooLarge),
+	})
+
+	cfg := newTestConfig()
+	cfg.Producer.Flush.Frequency = time.Millisecond
+	p, err := NewProducer([]string{broker.Addr()}, cfg, ProducerOptions{Topic: testTopic})
+	require.NoError(t, err)
+
+	finalized := make(chan producer.FinalizeReason, 4)
+	for shard := uint32(0); shard < 4; shard++ {
+		reason := producer.Consumed
+		if shard%2 == 1 {
+			reason = producer.Dropped
+		}
+		mm := producer.NewMockMessage(ctrl)
+		mm.EXPECT().Shard().Return(shard)
+		mm.EXPECT().Bytes().Return([]byte("foo"))
+		mm.EXPECT().Finalize(reason).Do(func(r producer.FinalizeReason) {
+			finalized <- r
+		})
+		require.NoError(t, p.Produce(mm))
+	}
+	p.Close()
+	close(finalized)
+
+	var consumed, dropped int
+	for r := range finalized {
+		if r == producer.Consumed {
+			consumed++
+		} else {
+			dropped++
+		}
+	}
+	require.Equal(t, 2, consumed)
+	require.Equal(t, 2, dropped)
+}
+
+// newTestConfig creates a config speaking the protocol versions the mock
+// broker responds with by default.
+func newTestConfig() *sarama.Config {
+	cfg := sarama.NewConfig()
+	cfg.Version = sarama.V0_8_2_0
+	return cfg
+}
+
+type testAsyncProducer struct {
+	input     chan *sarama.ProducerMessage
+	successes chan *sarama.ProducerMessage
+	errors    chan *sarama.ProducerError
+}
+
+func newTestAsyncProducer() *testAsyncProducer {
+	return &testAsyncProducer{
+		input:     make(chan *sarama.ProducerMessage, 1),
+		successes: make(chan *sarama.ProducerMessage),
+		errors:    make(chan *sarama.ProducerError),
+	}
+}
+
+func (p *testAsyncProducer) AsyncClose() {
+	close(p.successes)
+	close(p.errors)
+}
+
+func (p *testAsyncProducer) Close() error {
+	p.AsyncClose()
+	return nil
+}
+
+func (p *testAsyncProducer) Input() chan<- *sarama.ProducerMessage {
+	return p.input
+}
+
+func (p *testAsyncProducer) Successes() <-chan *sarama.ProducerMessage {
+	return p.successes
+}
+
+func (p *testAsyncProducer) Errors() <-chan *sarama.ProducerError {
+	return p.errors
+}
@@ -0,0 +1,357 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/m3db/m3/src/msg/consumer"
+	"github.com/m3db/m3x/instrument"
+	"github.com/m3db/m3x/log"
+
+	"github.com/Shopify/sarama"
+	"github.com/uber-go/tally"
+)
+
+var (
+	errNoConsumeFn          = errors.New("no consume function")
+	errSubscriberSubscribed = errors.New("subscriber is already subscribed")
+	errSubscriberClosed     = errors.New("subscriber is closed")
+	errNoPartitionsForTopic = errors.New("no partitions for topic")
+)
+
+// Subscriber consumes the partitions of a Kafka topic as m3msg consumers.
+type Subscriber interface {
+	// Subscribe starts consuming the partitions of the topic, each partition
+	// is handed to the consume function as a consumer in its own goroutine.
+	Subscribe() error
+
+	// Close stops consuming and waits for the consume functions to return.
+	Close()
+}
+
+// SubscriberOptions configs a subscriber.
+type SubscriberOptions struct {
+	// Topic is the Kafka topic to consume from.
+	Topic string
+
+	// Partitions are the partitions of the topic to consume, all the
+	// partitions are consumed if empty.
+	Partitions []int32
+
+	// Group is the consumer group to commit the offsets of the acked messages
+	// for, the offsets are not committed if empty and consumption starts from
+	// the initial offset of the client config on every subscription.
+	Group string
+
+	// ConsumeFn consumes the messages of a partition.
+	ConsumeFn consumer.ConsumeFn
+
+	// InstrumentOptions is the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+type subscriberMetrics struct {
+	messageConsumed tally.Counter
+	messageAcked    tally.Counter
+	messageNacked   tally.Counter
+}
+
+func newSubscriberMetrics(scope tally.Scope) subscriberMetrics {
+	return subscriberMetrics{
+		messageConsumed: scope.Counter("message-consumed"),
+		messageAcked:    scope.Counter("message-acked"),
+		messageNacked:   scope.Counter("message-nacked"),
+	}
+}
+
+type subscriber struct {
+	sync.Mutex
+
+	opts   SubscriberOptions
+	client sarama.Client
+	logger log.Logger
+	m      subscriberMetrics
+
+	c          sarama.Consumer
+	om         sarama.OffsetManager
+	consumers  []*partitionConsumer
+	subscribed bool
+	closed     bool
+	wg         sync.WaitGroup
+}
+
+// NewSubscriber creates a new subscriber for the topic on the brokers.
+func NewSubscriber(
+	brokers []string,
+	cfg *sarama.Config,
+	opts SubscriberOptions,
+) (Subscriber, error) {
+	if opts.ConsumeFn == nil {
+		return nil, errNoConsumeFn
+	}
+	if opts.InstrumentOptions == nil {
+		opts.InstrumentOptions = instrument.NewOptions()
+	}
+	client, err := sarama.NewClient(brokers, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &subscriber{
+		opts:   opts,
+		client: client,
+		logger: opts.InstrumentOptions.Logger(),
+		m:      newSubscriberMetrics(opts.InstrumentOptions.MetricsScope()),
+	}, nil
+}
+
+func (s *subscriber) Subscribe() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.closed {
+		return errSubscriberClosed
+	}
+	if s.subscribed {
+		return errSubscriberSubscribed
+	}
+	partitions := s.opts.Partitions
+	if len(partitions) == 0 {
+		var err error
+		if partitions, err = s.client.Partitions(s.opts.Topic); err != nil {
+			return err
+		}
+		if len(partitions) == 0 {
+			return errNoPartitionsForTopic
+		}
+	}
+	if err := s.subscribeWithLock(partitions); err != nil {
+		s.closeWithLock()
+		return err
+	}
+	s.subscribed = true
+	for _, pc := range s.consumers {
+		pc := pc
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.opts.ConsumeFn(pc)
+		}()
+	}
+	return nil
+}
+
+func (s *subscriber) subscribeWithLock(partitions []int32) error {
+	c, err := sarama.NewConsumerFromClient(s.client)
+	if err != nil {
+		return err
+	}
+	s.c = c
+	if s.opts.Group != "" {
+		om, err := sarama.NewOffsetManagerFromClient(s.opts.Group, s.client)
+		if err != nil {
+			return err
+		}
+		s.om = om
+	}
+	for _, partition := range partitions {
+		offset := s.client.Config().Consumer.Offsets.Initial
+		var pom sarama.PartitionOffsetManager
+		if s.om != nil {
+			if pom, err = s.om.ManagePartition(s.opts.Topic, partition); err != nil {
+				return err
+			}
+			offset, _ = pom.NextOffset()
+		}
+		pc, err := s.c.ConsumePartition(s.opts.Topic, partition, offset)
+		if err != nil {
+			if pom != nil {
+				pom.Close()
+			}
+			return err
+		}
+		s.consumers = append(s.consumers, newPartitionConsumer(partition, pc, pom, s.logger, s.m))
+	}
+	return nil
+}
+
+func (s *subscriber) Close() {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closeWithLock()
+}
+
+func (s *subscriber) closeWithLock() {
+	s.closed = true
+
+	// Closing the partitions unblocks the consume functions with io.EOF.
+	for _, pc := range s.consumers {
+		pc.pc.AsyncClose()
+	}
+	s.wg.Wait()
+	for _, pc := range s.consumers {
+		pc.Close()
+	}
+	if s.om != nil {
+		s.om.Close()
+	}
+	if s.c != nil {
+		s.c.Close()
+	}
+	s.client.Close()
+}
+
+// partitionConsumer consumes a partition of the topic, the acked offsets are
+// committed in order since the messages can be acked out of order.
+type partitionConsumer struct {
+	partition int32
+	pc        sarama.PartitionConsumer
+	pom       sarama.PartitionOffsetManager
+	tracker   *offsetTracker
+	logger    log.Logger
+	m         subscriberMetrics
+
+	closeOnce sync.Once
+}
+
+func newPartitionConsumer(
+	partition int32,
+	pc sarama.PartitionConsumer,
+	pom sarama.PartitionOffsetManager,
+	logger log.Logger,
+	m subscriberMetrics,
+) *partitionConsumer {
+	return &partitionConsumer{
+		partition: partition,
+		pc:        pc,
+		pom:       pom,
+		tracker:   newOffsetTracker(),
+		logger:    logger,
+		m:         m,
+	}
+}
+
+func (c *partitionConsumer) Init() {}
+
+func (c *partitionConsumer) Message() (consumer.Message, error) {
+	msg, ok := <-c.pc.Messages()
+	if !ok {
+		return nil, io.EOF
+	}
+	c.tracker.add(msg.Offset)
+	c.m.messageConsumed.Inc(1)
+	return &message{c: c, msg: msg}, nil
+}
+
+func (c *partitionConsumer) Close() {
+	c.closeOnce.Do(func() {
+		if err := c.pc.Close(); err != nil {
+			c.logger.Errorf("could not close consumer of partition %d: %v", c.partition, err)
+		}
+		if c.pom == nil {
+			return
+		}
+		if err := c.pom.Close(); err != nil {
+			c.logger.Errorf("could not commit offset of partition %d: %v", c.partition, err)
+		}
+	})
+}
+
+func (c *partitionConsumer) ack(offset int64) {
+	next, ok := c.tracker.ack(offset)
+	if ok && c.pom != nil {
+		c.pom.MarkOffset(next, "")
+	}
+}
+
+type message struct {
+	c   *partitionConsumer
+	msg *sarama.ConsumerMessage
+}
+
+func (m *message) Bytes() []byte {
+	return m.msg.Value
+}
+
+func (m *message) Ack() {
+	m.c.m.messageAcked.Inc(1)
+	m.c.ack(m.msg.Offset)
+}
+
+// Nack skips the message, Kafka has no way to reject a single message so it
+// will not be redelivered.
+func (m *message) Nack(reason string) {
+	m.c.m.messageNacked.Inc(1)
+	m.c.logger.Warnf(
+		"skipping message at offset %d of partition %d: %s",
+		m.msg.Offset, m.c.partition, reason,
+	)
+	m.c.ack(m.msg.Offset)
+}
+
+// offsetTracker tracks the offsets of the in-flight messages of a partition,
+// the committable offset only moves past a message once it and all the
+// messages before it have been acked.
+type offsetTracker struct {
+	sync.Mutex
+
+	inflight []int64
+	acked    map[int64]struct{}
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{acked: make(map[int64]struct{})}
+}
+
+func (t *offsetTracker) add(offset int64) {
+	t.Lock()
+	t.inflight = append(t.inflight, offset)
+	t.Unlock()
+}
+
+// ack acks the offset and returns the next offset to consume if the
+// committable offset moved.
+func (t *offsetTracker) ack(offset int64) (int64, bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.acked[offset] = struct{}{}
+	var (
+		next  int64
+		moved bool
+	)
+	for len(t.inflight) > 0 {
+		first := t.inflight[0]
+		if _, ok := t.acked[first]; !ok {
+			break
+		}
+		delete(t.acked, first)
+		t.inflight = t.inflight[1:]
+		next, moved = first+1, true
+	}
+	return next, moved
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/msg/consumer"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+)
+
+const testGroup = "test-group"
+
+func TestOffsetTracker(t *testing.T) {
+	tracker := newOffsetTracker()
+	tracker.add(3)
+	tracker.add(4)
+	tracker.add(7)
+
+	_, ok := tracker.ack(4)
+	require.False(t, ok)
+
+	next, ok := tracker.ack(3)
+	require.True(t, ok)
+	require.Equal(t, int64(5), next)
+
+	tracker.add(8)
+	_, ok = tracker.ack(8)
+	require.False(t, ok)
+
+	next, ok = tracker.ack(7)
+	require.True(t, ok)
+	require.Equal(t, int64(9), next)
+	require.Empty(t, tracker.inflight)
+	require.Empty(t, tracker.acked)
+}
+
+func TestSubscriberConsumesAllPartitions(t *testing.T) {
+	broker := newTestBroker(t, nil)
+	defer broker.Close()
+
+	var (
+		l        sync.Mutex
+		received []string
+		done     = make(chan struct{}, 4)
+	)
+	cfg := newTestConfig()
+	cfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+	s, err := NewSubscriber([]string{broker.Addr()}, cfg, SubscriberOptions{
+		Topic: testTopic,
+		ConsumeFn: func(c consumer.Consumer) {
+			for {
+				m, err := c.Message()
+				if err != nil {
+					break
+				}
+				l.Lock()
+				received = append(received, string(m.Bytes()))
+				l.Unlock()
+				m.Ack()
+				done <- struct{}{}
+			}
+			c.Close()
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Subscribe())
+	require.Equal(t, errSubscriberSubscribed, s.Subscribe())
+
+	for i := 0; i < 4; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for messages")
+		}
+	}
+	s.Close()
+
+	sort.Strings(received)
+	require.Equal(t, []string{"a", "b", "c", "d"}, received)
+	require.Equal(t, errSubscriberClosed, s.Subscribe())
+}
+
+func TestSubscriberResumesFromCommittedOffset(t *testing.T) {
+	broker := newTestBroker(t, func(broker *sarama.MockBroker) map[string]sarama.MockResponse {
+		return map[string]sarama.MockResponse{
+			"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+				SetCoordinator(sarama.CoordinatorGroup, testGroup, broker),
+			"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+				SetOffset(testGroup, testTopic, 0, 1, "", sarama.ErrNoError),
+			"OffsetCommitRequest": sarama.NewMockOffsetCommitResponse(t),
+		}
+	})
+	defer broker.Close()
+
+	msgCh := make(chan consumer.Message, 2)
+	cfg := newTestConfig()
+	cfg.Consumer.Offsets.CommitInterval = 10 * time.Millisecond
+	s, err := NewSubscriber([]string{broker.Addr()}, cfg, SubscriberOptions{
+		Topic:      testTopic,
+		Partitions: []int32{0},
+		Group:      testGroup,
+		ConsumeFn: func(c consumer.Consumer) {
+			for {
+				m, err := c.Message()
+				if err != nil {
+					break
+				}
+				msgCh <- m
+			}
+			c.Close()
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Subscribe())
+
+	// Consumption starts from the committed offset.
+	m1 := <-msgCh
+	require.Equal(t, "b", string(m1.Bytes()))
+	m2 := <-msgCh
+	require.Equal(t, "c", string(m2.Bytes()))
+
+	m2.Ack()
+	m1.Nack("bad message")
+	s.Close()
+
+	var committed bool
+	for _, rr := range broker.History() {
+		if _, ok := rr.Request.(*sarama.OffsetCommitRequest); ok {
+			committed = true
+		}
+	}
+	require.True(t, committed)
+}
+
+func TestNewSubscriberWithoutConsumeFn(t *testing.T) {
+	_, err := NewSubscriber(nil, sarama.NewConfig(), SubscriberOptions{Topic: testTopic})
+	require.Equal(t, errNoConsumeFn, err)
+}
+
+// newTestBroker creates a broker leading two partitions of the test topic,
+// with messages a, b and c in partition 0 and d in partition 1.
+func newTestBroker(
+	t *testing.T,
+	extraHandlers func(broker *sarama.MockBroker) map[string]sarama.MockResponse,
+) *sarama.MockBroker {
+	broker := sarama.NewMockBroker(t, 1)
+	handlers := map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(testTopic, 0, broker.BrokerID()).
+			SetLeader(testTopic, 1, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset(testTopic, 0, sarama.OffsetOldest, 0).
+			SetOffset(testTopic, 0, sarama.OffsetNewest, 3).
+			SetOffset(testTopic, 1, sarama.OffsetOldest, 0).
+			SetOffset(testTopic, 1, sarama.OffsetNewest, 1),
+		"FetchRequest": sarama.NewMockFetchResponse(t, 1).
+			SetMessage(testTopic, 0, 0, sarama.StringEncoder("a")).
+			SetMessage(testTopic, 0, 1, sarama.StringEncoder("b")).
+			SetMessage(testTopic, 0, 2, sarama.StringEncoder("c")).
+			SetMessage(testTopic, 1, 0, sarama.StringEncoder("d")),
+	}
+	if extraHandlers != nil {
+		for k, v := range extraHandlers(broker) {
+			handlers[k] = v
+		}
+	}
+	broker.SetHandlerByMap(handlers)
+	return broker
+}
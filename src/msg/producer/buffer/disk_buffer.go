@@ -0,0 +1,666 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package buffer
+
+import (
+	"container/list"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3x/log"
+
+	"github.com/uber-go/tally"
+)
+
+type diskBufferMetrics struct {
+	messageDropped          tally.Counter
+	messageReadDropped      tally.Counter
+	byteDropped             tally.Counter
+	messageExpired          tally.Counter
+	messageTooLarge         tally.Counter
+	bufferFull              tally.Counter
+	writeError              tally.Counter
+	readError               tally.Counter
+	messageReleased         tally.Counter
+	ackError                tally.Counter
+	syncError               tally.Counter
+	segmentRemoved          tally.Counter
+	segmentRemoveError      tally.Counter
+	replayMessage           tally.Counter
+	replayByte              tally.Counter
+	replayWriteError        tally.Counter
+	replaySegmentError      tally.Counter
+	replaySegmentCorrupt    tally.Counter
+	replaySegmentsRemaining tally.Gauge
+	messageBuffered         tally.Gauge
+	memoryBytes             tally.Gauge
+	diskBytes               tally.Gauge
+	diskSegments            tally.Gauge
+}
+
+func newDiskBufferMetrics(scope tally.Scope) diskBufferMetrics {
+	replayScope := scope.SubScope("replay")
+	return diskBufferMetrics{
+		messageDropped:          scope.Counter("buffer-message-dropped"),
+		messageReadDropped:      scope.Counter("buffer-message-read-dropped"),
+		byteDropped:             scope.Counter("buffer-byte-dropped"),
+		messageExpired:          scope.Counter("buffer-message-expired"),
+		messageTooLarge:         scope.Counter("message-too-large"),
+		bufferFull:              scope.Counter("buffer-full"),
+		writeError:              scope.Counter("disk-write-error"),
+		readError:               scope.Counter("disk-read-error"),
+		messageReleased:         scope.Counter("buffer-message-released"),
+		ackError:                scope.Counter("disk-ack-error"),
+		syncError:               scope.Counter("disk-sync-error"),
+		segmentRemoved:          scope.Counter("disk-segment-removed"),
+		segmentRemoveError:      scope.Counter("disk-segment-remove-error"),
+		replayMessage:           replayScope.Counter("message"),
+		replayByte:              replayScope.Counter("byte"),
+		replayWriteError:        replayScope.Counter("write-error"),
+		replaySegmentError:      replayScope.Counter("segment-error"),
+		replaySegmentCorrupt:    replayScope.Counter("segment-corrupt"),
+		replaySegmentsRemaining: replayScope.Gauge("segments-remaining"),
+		messageBuffered:         scope.Gauge("message-buffered"),
+		memoryBytes:             scope.Gauge("memory-bytes"),
+		diskBytes:               scope.Gauge("disk-bytes"),
+		diskSegments:            scope.Gauge("disk-segments"),
+	}
+}
+
+// diskMessage is a message added to a segment of the disk buffer. The added
+// message is kept in memory until it is released to bound the memory used by
+// the buffer, after which its bytes are read back from the segment.
+type diskMessage struct {
+	b       *diskBuffer
+	rm      *producer.RefCountedMessage
+	m       producer.Message
+	shard   uint32
+	size    int
+	segment *diskSegment
+	index   uint32
+	offset  int64
+	length  int
+
+	// memoryElem is the element of the message in the list of messages kept
+	// in memory, nil once the message is released.
+	memoryElem *list.Element
+}
+
+func (m *diskMessage) Shard() uint32 { return m.shard }
+func (m *diskMessage) Size() int     { return m.size }
+
+func (m *diskMessage) Bytes() []byte {
+	if m.m != nil {
+		return m.m.Bytes()
+	}
+	b, err := m.segment.read(m.offset, m.length)
+	if err != nil {
+		m.b.m.readError.Inc(1)
+		m.b.logger.Errorf("could not read message from buffer segment %s: %v", m.segment.dataPath, err)
+		// NB: The message can not be dropped inline as the caller may hold
+		// the read lock of the message while reading its bytes.
+		go m.b.drop([]*producer.RefCountedMessage{m.rm}, m.b.m.messageReadDropped)
+		return nil
+	}
+	return b
+}
+
+func (m *diskMessage) Finalize(r producer.FinalizeReason) {
+	if m.m != nil {
+		m.m.Finalize(r)
+	}
+}
+
+// nolint: maligned
+type diskBuffer struct {
+	sync.Mutex
+
+	w      producer.Writer
+	opts   DiskOptions
+	logger log.Logger
+	nowFn  func() time.Time
+	m      diskBufferMetrics
+
+	segments      []*diskSegment
+	active        *diskSegment
+	nextSegmentID uint64
+	diskSize      int64
+	inMemory      *list.List
+	memorySize    int64
+	isClosed      bool
+	retainOnDrop  bool
+	doneCh        chan struct{}
+	wg            sync.WaitGroup
+}
+
+// NewDiskBuffer returns a new buffer that writes messages to a segmented
+// write-ahead log on disk until they are consumed. Messages that were not
+// consumed before a restart are replayed through the writer when the buffer
+// is initialized, so the writer must be initialized first.
+func NewDiskBuffer(w producer.Writer, opts DiskOptions) (producer.Buffer, error) {
+	if opts == nil {
+		opts = NewDiskOptions()
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(opts.Directory(), opts.NewDirectoryMode()); err != nil {
+		return nil, err
+	}
+	ids, err := listDiskSegments(opts.Directory())
+	if err != nil {
+		return nil, err
+	}
+	var nextSegmentID uint64
+	if len(ids) > 0 {
+		nextSegmentID = ids[len(ids)-1] + 1
+	}
+	return &diskBuffer{
+		w:             w,
+		opts:          opts,
+		logger:        opts.InstrumentOptions().Logger(),
+		nowFn:         time.Now,
+		m:             newDiskBufferMetrics(opts.InstrumentOptions().MetricsScope()),
+		nextSegmentID: nextSegmentID,
+		inMemory:      list.New(),
+		doneCh:        make(chan struct{}),
+	}, nil
+}
+
+func (b *diskBuffer) Add(m producer.Message) (*producer.RefCountedMessage, error) {
+	s := m.Size()
+	if s > b.opts.MaxMessageSize() {
+		b.m.messageTooLarge.Inc(1)
+		return nil, errMessageTooLarge
+	}
+	recordSize := int64(recordHeaderLen + s)
+
+	b.Lock()
+	if b.isClosed {
+		b.Unlock()
+		return nil, errBufferClosed
+	}
+	var dropped []*producer.RefCountedMessage
+	if b.diskSize+recordSize > b.opts.MaxDiskSize() {
+		if b.opts.OnFullStrategy() == ReturnError {
+			b.Unlock()
+			b.m.bufferFull.Inc(1)
+			return nil, errBufferFull
+		}
+		dropped = b.dropOldestWithLock(recordSize)
+	}
+	rm, err := b.appendWithLock(m, recordSize)
+	var released []*producer.RefCountedMessage
+	if err == nil {
+		released = b.releaseOldestWithLock()
+	}
+	b.Unlock()
+
+	b.drop(dropped, b.m.messageDropped)
+	b.release(released)
+	if err != nil {
+		b.m.writeError.Inc(1)
+		return nil, err
+	}
+	return rm, nil
+}
+
+func (b *diskBuffer) appendWithLock(
+	m producer.Message,
+	recordSize int64,
+) (*producer.RefCountedMessage, error) {
+	if b.active == nil || b.active.dataSize+recordSize > b.opts.MaxSegmentSize() {
+		if err := b.rotateWithLock(); err != nil {
+			return nil, err
+		}
+	}
+	bytes := m.Bytes()
+	index, offset, err := b.active.append(m.Shard(), b.nowFn(), bytes)
+	if err != nil {
+		return nil, err
+	}
+	dm := &diskMessage{
+		b:       b,
+		m:       m,
+		shard:   m.Shard(),
+		size:    m.Size(),
+		segment: b.active,
+		index:   index,
+		offset:  offset,
+		length:  len(bytes),
+	}
+	rm := producer.NewRefCountedMessage(dm, b.onFinalize)
+	dm.rm = rm
+	dm.memoryElem = b.inMemory.PushBack(rm)
+	b.memorySize += int64(dm.size)
+	b.active.outstanding[index] = rm
+	b.diskSize += recordSize
+	return rm, nil
+}
+
+// releaseOldestWithLock removes the oldest messages from memory until the
+// messages kept in memory fit within the max memory size and returns them,
+// their bytes must be released without the lock.
+func (b *diskBuffer) releaseOldestWithLock() []*producer.RefCountedMessage {
+	var (
+		maxMemorySize = b.opts.MaxMemorySize()
+		released      []*producer.RefCountedMessage
+	)
+	for b.memorySize > maxMemorySize {
+		e := b.inMemory.Front()
+		if e == nil {
+			break
+		}
+		rm := e.Value.(*producer.RefCountedMessage)
+		dm := rm.Message.(*diskMessage)
+		// NB: The bytes of released messages are read back from disk, so
+		// they must be flushed first.
+		if err := dm.segment.flushTo(dm.offset + int64(dm.length)); err != nil {
+			b.m.writeError.Inc(1)
+			b.logger.Errorf("could not flush buffer segment %s: %v", dm.segment.dataPath, err)
+			break
+		}
+		b.removeFromMemoryWithLock(dm)
+		released = append(released, rm)
+	}
+	return released
+}
+
+func (b *diskBuffer) removeFromMemoryWithLock(dm *diskMessage) {
+	if dm.memoryElem == nil {
+		return
+	}
+	b.inMemory.Remove(dm.memoryElem)
+	b.memorySize -= int64(dm.size)
+	dm.memoryElem = nil
+}
+
+// release finalizes the added messages so that their bytes are no longer
+// held in memory, the bytes are read back from disk from then on.
+func (b *diskBuffer) release(rms []*producer.RefCountedMessage) {
+	for _, rm := range rms {
+		// NB: The write lock waits for the bytes to no longer be read.
+		rm.Lock()
+		if rm.IsDroppedOrConsumed() {
+			// The message was finalized along with the added message.
+			rm.Unlock()
+			continue
+		}
+		dm := rm.Message.(*diskMessage)
+		m := dm.m
+		dm.m = nil
+		rm.Unlock()
+
+		// The buffer owns the bytes of the message on disk from now on.
+		m.Finalize(producer.Spilled)
+		b.m.messageReleased.Inc(1)
+	}
+}
+
+func (b *diskBuffer) rotateWithLock() error {
+	if b.active != nil {
+		if err := b.active.seal(); err != nil {
+			b.m.syncError.Inc(1)
+			b.logger.Errorf("could not seal buffer segment %s: %v", b.active.dataPath, err)
+		}
+		b.active = nil
+	}
+	s, err := openDiskSegment(b.nextSegmentID, b.opts)
+	if err != nil {
+		return err
+	}
+	b.nextSegmentID++
+	b.active = s
+	b.segments = append(b.segments, s)
+	return nil
+}
+
+// dropOldestWithLock removes the oldest segments until there is room for a
+// new record and returns their messages which must be dropped without the lock.
+func (b *diskBuffer) dropOldestWithLock(recordSize int64) []*producer.RefCountedMessage {
+	var dropped []*producer.RefCountedMessage
+	for len(b.segments) > 0 && b.diskSize+recordSize > b.opts.MaxDiskSize() {
+		s := b.segments[0]
+		b.segments = b.segments[1:]
+		if s == b.active {
+			b.active = nil
+		}
+		dropped = appendOutstanding(dropped, s)
+		b.removeSegmentWithLock(s)
+	}
+	return dropped
+}
+
+func (b *diskBuffer) removeSegmentWithLock(s *diskSegment) {
+	b.diskSize -= s.size()
+	if err := s.remove(); err != nil {
+		b.m.segmentRemoveError.Inc(1)
+		b.logger.Errorf("could not remove buffer segment %s: %v", s.dataPath, err)
+		return
+	}
+	b.m.segmentRemoved.Inc(1)
+}
+
+func (b *diskBuffer) onFinalize(rm *producer.RefCountedMessage) {
+	dm := rm.Message.(*diskMessage)
+	b.Lock()
+	b.removeFromMemoryWithLock(dm)
+	delete(dm.segment.outstanding, dm.index)
+	if dm.segment.removed || b.retainOnDrop {
+		b.Unlock()
+		return
+	}
+	if err := dm.segment.ack(dm.index); err != nil {
+		b.Unlock()
+		b.m.ackError.Inc(1)
+		return
+	}
+	b.diskSize += ackRecordLen
+	b.Unlock()
+}
+
+func (b *diskBuffer) drop(rms []*producer.RefCountedMessage, c tally.Counter) {
+	for _, rm := range rms {
+		// There is a chance that the message is consumed right before
+		// the drop call which will lead drop to return false.
+		if rm.Drop() {
+			c.Inc(1)
+			b.m.byteDropped.Inc(int64(rm.Size()))
+		}
+	}
+}
+
+func (b *diskBuffer) Init() {
+	b.replay()
+
+	b.wg.Add(2)
+	go func() {
+		b.syncUntilClose()
+		b.wg.Done()
+	}()
+	go func() {
+		b.cleanupUntilClose()
+		b.wg.Done()
+	}()
+}
+
+// replay writes out the messages of the segments left on disk that were not
+// consumed before the buffer was last closed.
+func (b *diskBuffer) replay() {
+	ids, err := listDiskSegments(b.opts.Directory())
+	if err != nil {
+		b.m.replaySegmentError.Inc(1)
+		b.logger.Errorf("could not list buffer segments for replay: %v", err)
+		return
+	}
+	b.m.replaySegmentsRemaining.Update(float64(len(ids)))
+	for i, id := range ids {
+		rms, err := b.recoverSegment(id)
+		if err != nil {
+			b.m.replaySegmentError.Inc(1)
+			b.logger.Errorf("could not replay buffer segment %d: %v", id, err)
+		}
+		for _, rm := range rms {
+			b.m.replayMessage.Inc(1)
+			b.m.replayByte.Inc(int64(rm.Size()))
+			if err := b.w.Write(rm); err != nil {
+				b.m.replayWriteError.Inc(1)
+			}
+		}
+		b.m.replaySegmentsRemaining.Update(float64(len(ids) - i - 1))
+	}
+	b.updateGauges()
+}
+
+func (b *diskBuffer) recoverSegment(id uint64) ([]*producer.RefCountedMessage, error) {
+	s := newDiskSegment(id, b.opts)
+	records, corrupt, err := s.recover()
+	if err != nil {
+		return nil, err
+	}
+	if corrupt {
+		b.m.replaySegmentCorrupt.Inc(1)
+		b.logger.Warnf("buffer segment %s has a corrupt record, later records are lost", s.dataPath)
+	}
+
+	var (
+		now           = b.nowFn()
+		maxMessageAge = b.opts.MaxMessageAge()
+		rms           = make([]*producer.RefCountedMessage, 0, len(records))
+	)
+	b.Lock()
+	defer b.Unlock()
+
+	b.diskSize += s.size()
+	for _, r := range records {
+		if maxMessageAge > 0 && now.Sub(r.timestamp) >= maxMessageAge {
+			b.m.messageExpired.Inc(1)
+			continue
+		}
+		// NB: Replayed messages are not kept in memory, their bytes are read
+		// from disk when they are written.
+		dm := &diskMessage{
+			b:       b,
+			shard:   r.shard,
+			size:    r.length,
+			segment: s,
+			index:   r.index,
+			offset:  r.offset,
+			length:  r.length,
+		}
+		rm := producer.NewRefCountedMessage(dm, b.onFinalize)
+		dm.rm = rm
+		s.outstanding[r.index] = rm
+		rms = append(rms, rm)
+	}
+	if len(s.outstanding) == 0 {
+		b.removeSegmentWithLock(s)
+		return nil, nil
+	}
+
+	// NB: Keep the segments in order of age, ahead of any that were added
+	// before the replay.
+	i := 0
+	for i < len(b.segments) && b.segments[i].id < id {
+		i++
+	}
+	b.segments = append(b.segments, nil)
+	copy(b.segments[i+1:], b.segments[i:])
+	b.segments[i] = s
+	return rms, nil
+}
+
+func (b *diskBuffer) syncUntilClose() {
+	ticker := time.NewTicker(b.opts.SyncInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.sync()
+		case <-b.doneCh:
+			return
+		}
+	}
+}
+
+func (b *diskBuffer) sync() {
+	b.Lock()
+	defer b.Unlock()
+
+	for _, s := range b.segments {
+		if err := s.sync(); err != nil {
+			b.m.syncError.Inc(1)
+			b.logger.Errorf("could not sync buffer segment %s: %v", s.dataPath, err)
+		}
+	}
+}
+
+func (b *diskBuffer) cleanupUntilClose() {
+	ticker := time.NewTicker(b.opts.CleanupInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.cleanup()
+		case <-b.doneCh:
+			return
+		}
+	}
+}
+
+// cleanup removes the segments whose messages have all been consumed and
+// drops the messages of segments older than the max message age.
+func (b *diskBuffer) cleanup() {
+	var (
+		now           = b.nowFn()
+		maxMessageAge = b.opts.MaxMessageAge()
+		expired       []*producer.RefCountedMessage
+	)
+	b.Lock()
+	if maxMessageAge > 0 && b.active != nil && b.active.numMessages > 0 &&
+		now.Sub(b.active.firstWrite) >= maxMessageAge {
+		// Seal the active segment so that it can expire.
+		if err := b.active.seal(); err != nil {
+			b.m.syncError.Inc(1)
+		}
+		b.active = nil
+	}
+	segments := b.segments[:0]
+	for _, s := range b.segments {
+		switch {
+		case s == b.active:
+			segments = append(segments, s)
+		case len(s.outstanding) == 0:
+			b.removeSegmentWithLock(s)
+		case maxMessageAge > 0 && now.Sub(s.lastWrite) >= maxMessageAge:
+			expired = appendOutstanding(expired, s)
+			b.removeSegmentWithLock(s)
+		default:
+			segments = append(segments, s)
+		}
+	}
+	for i := len(segments); i < len(b.segments); i++ {
+		b.segments[i] = nil
+	}
+	b.segments = segments
+	b.Unlock()
+
+	b.drop(expired, b.m.messageExpired)
+	b.updateGauges()
+}
+
+func (b *diskBuffer) updateGauges() {
+	b.Lock()
+	var (
+		numMessages = b.numOutstandingWithLock()
+		diskSize    = b.diskSize
+		memorySize  = b.memorySize
+		numSegments = len(b.segments)
+	)
+	b.Unlock()
+	b.m.messageBuffered.Update(float64(numMessages))
+	b.m.memoryBytes.Update(float64(memorySize))
+	b.m.diskBytes.Update(float64(diskSize))
+	b.m.diskSegments.Update(float64(numSegments))
+}
+
+func (b *diskBuffer) numOutstandingWithLock() int {
+	n := 0
+	for _, s := range b.segments {
+		n += len(s.outstanding)
+	}
+	return n
+}
+
+func (b *diskBuffer) Close(ct producer.CloseType) {
+	// Stop taking writes right away.
+	b.Lock()
+	if b.isClosed {
+		b.Unlock()
+		return
+	}
+	b.isClosed = true
+	var dropped []*producer.RefCountedMessage
+	if ct == producer.DropEverything {
+		// NB: The messages are only dropped from memory, they are kept on
+		// disk to be replayed when the buffer is next initialized.
+		b.retainOnDrop = true
+		for _, s := range b.segments {
+			dropped = appendOutstanding(dropped, s)
+		}
+	}
+	b.Unlock()
+
+	b.drop(dropped, b.m.messageDropped)
+	b.waitUntilAllDataConsumed()
+	close(b.doneCh)
+	b.wg.Wait()
+
+	b.Lock()
+	for _, s := range b.segments {
+		if len(s.outstanding) == 0 && !b.retainOnDrop {
+			b.removeSegmentWithLock(s)
+			continue
+		}
+		if err := s.close(); err != nil {
+			b.m.syncError.Inc(1)
+			b.logger.Errorf("could not close buffer segment %s: %v", s.dataPath, err)
+		}
+	}
+	b.segments = nil
+	b.active = nil
+	b.Unlock()
+}
+
+func (b *diskBuffer) waitUntilAllDataConsumed() {
+	if b.numOutstanding() == 0 {
+		return
+	}
+	ticker := time.NewTicker(b.opts.CloseCheckInterval())
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if b.numOutstanding() == 0 {
+			return
+		}
+	}
+}
+
+func (b *diskBuffer) numOutstanding() int {
+	b.Lock()
+	n := b.numOutstandingWithLock()
+	b.Unlock()
+	return n
+}
+
+func appendOutstanding(
+	rms []*producer.RefCountedMessage,
+	s *diskSegment,
+) []*producer.RefCountedMessage {
+	for _, rm := range s.outstanding {
+		rms = append(rms, rm)
+	}
+	return rms
+}
@@ -0,0 +1,408 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package buffer
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/msg/producer"
+
+	"github.com/m3db/m3x/instrument"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func TestDiskOptionsValidation(t *testing.T) {
+	opts := NewDiskOptions()
+	require.Equal(t, errNoDirectory, opts.Validate())
+
+	opts = opts.SetDirectory("/tmp")
+	require.NoError(t, opts.Validate())
+
+	opts = opts.SetMaxSegmentSize(opts.MaxDiskSize() + 1)
+	require.Equal(t, errInvalidMaxSegmentSize, opts.Validate())
+
+	opts = opts.SetMaxSegmentSize(10).SetMaxMessageSize(11)
+	require.Equal(t, errInvalidMaxMessageSize, opts.Validate())
+
+	opts = opts.SetMaxMessageSize(10).SetMaxMessageAge(-time.Second)
+	require.Equal(t, errNegativeMaxMessageAge, opts.Validate())
+
+	opts = opts.SetMaxMessageAge(0).SetMaxMemorySize(-1)
+	require.Equal(t, errNegativeMaxMemorySize, opts.Validate())
+}
+
+func TestDiskBufferConsumedMessagesAreRemoved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	b := mustNewDiskBuffer(t, &testWriter{}, testDiskOptions(dir))
+	b.Init()
+
+	mm := newTestMessage(ctrl, 1, "foo")
+	rm, err := b.Add(mm)
+	require.NoError(t, err)
+	require.Len(t, b.segments, 1)
+	require.Equal(t, int64(recordHeaderLen+3), b.diskSize)
+
+	mm.EXPECT().Finalize(producer.Consumed)
+	rm.IncRef()
+	rm.DecRef()
+	require.Equal(t, 0, b.numOutstanding())
+	require.Equal(t, int64(recordHeaderLen+3+ackRecordLen), b.diskSize)
+
+	b.Close(producer.WaitForConsumption)
+	ids, err := listDiskSegments(dir)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
+
+func TestDiskBufferReleasesMessagesOverMemoryLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	b := mustNewDiskBuffer(t, &testWriter{}, testDiskOptions(dir).SetMaxMemorySize(4))
+	b.Init()
+
+	mm1 := newTestMessage(ctrl, 1, "foo")
+	rm1, err := b.Add(mm1)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), b.memorySize)
+
+	// Adding another message goes over the memory limit, so the oldest
+	// message is spilled and its bytes are read back from disk.
+	mm1.EXPECT().Finalize(producer.Spilled)
+	mm2 := newTestMessage(ctrl, 2, "ba")
+	rm2, err := b.Add(mm2)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), b.memorySize)
+	require.Equal(t, 1, b.inMemory.Len())
+
+	require.Nil(t, rm1.Message.(*diskMessage).m)
+	require.Equal(t, uint32(1), rm1.Shard())
+	require.Equal(t, 3, rm1.Message.Size())
+	require.Equal(t, []byte("foo"), rm1.Bytes())
+	require.Equal(t, mm2, rm2.Message.(*diskMessage).m)
+	require.Equal(t, []byte("ba"), rm2.Bytes())
+
+	// The added messages are only finalized once.
+	mm2.EXPECT().Finalize(producer.Consumed)
+	for _, rm := range []*producer.RefCountedMessage{rm1, rm2} {
+		rm.IncRef()
+		rm.DecRef()
+	}
+	require.Equal(t, 0, b.numOutstanding())
+
+	b.Close(producer.WaitForConsumption)
+	ids, err := listDiskSegments(dir)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
+
+func TestDiskBufferDropsMessagesFailingToRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	scope := tally.NewTestScope("", nil)
+	opts := testDiskOptions(dir).
+		SetMaxMemorySize(4).
+		SetInstrumentOptions(instrument.NewOptions().SetMetricsScope(scope))
+	b := mustNewDiskBuffer(t, &testWriter{}, opts)
+	b.Init()
+
+	mm1 := newTestMessage(ctrl, 1, "foo")
+	rm1, err := b.Add(mm1)
+	require.NoError(t, err)
+
+	mm1.EXPECT().Finalize(producer.Spilled)
+	mm2 := newTestMessage(ctrl, 2, "ba")
+	rm2, err := b.Add(mm2)
+	require.NoError(t, err)
+	require.Nil(t, rm1.Message.(*diskMessage).m)
+
+	// The released message can no longer be read back from disk, so it is
+	// dropped rather than written with no bytes.
+	require.NoError(t, os.Truncate(rm1.Message.(*diskMessage).segment.dataPath, 0))
+	require.Nil(t, rm1.Bytes())
+	for {
+		counter, ok := scope.Snapshot().Counters()["buffer-message-read-dropped+"]
+		if ok && counter.Value() == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.True(t, rm1.IsDroppedOrConsumed())
+	require.Equal(t, 1, b.numOutstanding())
+
+	mm2.EXPECT().Finalize(producer.Consumed)
+	rm2.IncRef()
+	rm2.DecRef()
+	b.Close(producer.WaitForConsumption)
+}
+
+func TestDiskBufferReplaysUnconsumedMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	b := mustNewDiskBuffer(t, &testWriter{}, testDiskOptions(dir))
+	b.Init()
+
+	var rms []*producer.RefCountedMessage
+	for i, str := range []string{"a", "bb", "ccc"} {
+		mm := newTestMessage(ctrl, uint32(i), str)
+		rm, err := b.Add(mm)
+		require.NoError(t, err)
+		rms = append(rms, rm)
+	}
+
+	// Consume the first message, then close dropping the others which
+	// should still be replayed.
+	rms[0].Message.(*diskMessage).m.(*producer.MockMessage).EXPECT().Finalize(producer.Consumed)
+	rms[0].IncRef()
+	rms[0].DecRef()
+	for _, rm := range rms[1:] {
+		rm.Message.(*diskMessage).m.(*producer.MockMessage).EXPECT().Finalize(producer.Dropped)
+	}
+	b.Close(producer.DropEverything)
+
+	w := &testWriter{}
+	b = mustNewDiskBuffer(t, w, testDiskOptions(dir))
+	b.Init()
+
+	written := w.written()
+	require.Len(t, written, 2)
+	require.Equal(t, uint32(1), written[0].Shard())
+	require.Equal(t, []byte("bb"), written[0].Bytes())
+	require.Equal(t, uint32(2), written[1].Shard())
+	require.Equal(t, []byte("ccc"), written[1].Bytes())
+
+	// New messages are written to a new segment.
+	mm := newTestMessage(ctrl, 3, "dddd")
+	rm, err := b.Add(mm)
+	require.NoError(t, err)
+	require.Len(t, b.segments, 2)
+
+	mm.EXPECT().Finalize(producer.Consumed)
+	for _, rm := range append(written, rm) {
+		rm.IncRef()
+		rm.DecRef()
+	}
+	b.Close(producer.WaitForConsumption)
+
+	ids, err := listDiskSegments(dir)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
+
+func TestDiskBufferReplayStopsAtCorruptRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	b := mustNewDiskBuffer(t, &testWriter{}, testDiskOptions(dir))
+	b.Init()
+	for i, str := range []string{"a", "bb"} {
+		mm := newTestMessage(ctrl, uint32(i), str)
+		mm.EXPECT().Finalize(producer.Dropped)
+		_, err := b.Add(mm)
+		require.NoError(t, err)
+	}
+	b.Close(producer.DropEverything)
+
+	// Flip a byte of the last message.
+	dataPath, _ := segmentFilePaths(dir, 0)
+	data, err := ioutil.ReadFile(dataPath)
+	require.NoError(t, err)
+	data[len(data)-1] ^= 0xff
+	require.NoError(t, ioutil.WriteFile(dataPath, data, 0666))
+
+	w := &testWriter{}
+	b = mustNewDiskBuffer(t, w, testDiskOptions(dir))
+	b.Init()
+
+	written := w.written()
+	require.Len(t, written, 1)
+	require.Equal(t, []byte("a"), written[0].Bytes())
+	b.Close(producer.DropEverything)
+}
+
+func TestDiskBufferReturnErrorOnFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	opts := testDiskOptions(dir).
+		SetOnFullStrategy(ReturnError).
+		SetMaxDiskSize(2 * (recordHeaderLen + 3)).
+		SetMaxSegmentSize(recordHeaderLen + 3).
+		SetMaxMessageSize(3)
+	b := mustNewDiskBuffer(t, &testWriter{}, opts)
+
+	for i := 0; i < 2; i++ {
+		mm := newTestMessage(ctrl, 0, "foo")
+		mm.EXPECT().Finalize(producer.Dropped)
+		_, err := b.Add(mm)
+		require.NoError(t, err)
+	}
+	_, err := b.Add(newTestMessage(ctrl, 0, "foo"))
+	require.Equal(t, errBufferFull, err)
+	b.Close(producer.DropEverything)
+}
+
+func TestDiskBufferDropOldestOnFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	opts := testDiskOptions(dir).
+		SetOnFullStrategy(DropOldest).
+		SetMaxDiskSize(2 * (recordHeaderLen + 3)).
+		SetMaxSegmentSize(recordHeaderLen + 3).
+		SetMaxMessageSize(3)
+	b := mustNewDiskBuffer(t, &testWriter{}, opts)
+
+	var rms []*producer.RefCountedMessage
+	for i := 0; i < 3; i++ {
+		mm := newTestMessage(ctrl, 0, "foo")
+		if i == 0 {
+			mm.EXPECT().Finalize(producer.Dropped)
+		}
+		rm, err := b.Add(mm)
+		require.NoError(t, err)
+		rms = append(rms, rm)
+	}
+	require.True(t, rms[0].IsDroppedOrConsumed())
+	require.Len(t, b.segments, 2)
+	require.Equal(t, uint64(1), b.segments[0].id)
+
+	for _, rm := range rms[1:] {
+		rm.Message.(*diskMessage).m.(*producer.MockMessage).EXPECT().Finalize(producer.Dropped)
+	}
+	b.Close(producer.DropEverything)
+}
+
+func TestDiskBufferCleanupExpiredMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	b := mustNewDiskBuffer(t, &testWriter{}, testDiskOptions(dir).SetMaxMessageAge(time.Minute))
+	now := time.Now()
+	b.nowFn = func() time.Time { return now }
+
+	mm := newTestMessage(ctrl, 0, "foo")
+	rm, err := b.Add(mm)
+	require.NoError(t, err)
+
+	b.cleanup()
+	require.False(t, rm.IsDroppedOrConsumed())
+	require.Len(t, b.segments, 1)
+
+	now = now.Add(time.Minute)
+	mm.EXPECT().Finalize(producer.Dropped)
+	b.cleanup()
+	require.True(t, rm.IsDroppedOrConsumed())
+	require.Empty(t, b.segments)
+
+	ids, err := listDiskSegments(dir)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+	b.Close(producer.WaitForConsumption)
+}
+
+type testWriter struct {
+	sync.Mutex
+
+	rms []*producer.RefCountedMessage
+}
+
+func (w *testWriter) Write(rm *producer.RefCountedMessage) error {
+	w.Lock()
+	w.rms = append(w.rms, rm)
+	w.Unlock()
+	return nil
+}
+
+func (w *testWriter) written() []*producer.RefCountedMessage {
+	w.Lock()
+	defer w.Unlock()
+	return append([]*producer.RefCountedMessage(nil), w.rms...)
+}
+
+func (w *testWriter) RegisterFilter(services.ServiceID, producer.FilterFunc) {}
+func (w *testWriter) UnregisterFilter(services.ServiceID)                    {}
+func (w *testWriter) Init() error                                            { return nil }
+func (w *testWriter) Close()                                                 {}
+
+func newTestMessage(ctrl *gomock.Controller, shard uint32, str string) *producer.MockMessage {
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Shard().Return(shard).AnyTimes()
+	mm.EXPECT().Bytes().Return([]byte(str)).AnyTimes()
+	mm.EXPECT().Size().Return(len(str)).AnyTimes()
+	return mm
+}
+
+func createTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "producer-buffer")
+	require.NoError(t, err)
+	return dir
+}
+
+func mustNewDiskBuffer(t *testing.T, w producer.Writer, opts DiskOptions) *diskBuffer {
+	b, err := NewDiskBuffer(w, opts)
+	require.NoError(t, err)
+	return b.(*diskBuffer)
+}
+
+func testDiskOptions(dir string) DiskOptions {
+	return NewDiskOptions().
+		SetDirectory(dir).
+		SetCloseCheckInterval(100 * time.Millisecond).
+		SetSyncInterval(100 * time.Millisecond).
+		SetCleanupInterval(time.Hour)
+}
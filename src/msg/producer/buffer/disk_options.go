@@ -0,0 +1,253 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package buffer
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/m3db/m3x/instrument"
+)
+
+const (
+	defaultMaxDiskSize            = 10 * 1024 * 1024 * 1024 // 10GB.
+	defaultMaxSegmentSize         = 64 * 1024 * 1024        // 64MB.
+	defaultMaxMemorySize          = 256 * 1024 * 1024       // 256MB.
+	defaultSyncInterval           = time.Second
+	defaultDiskCleanupInterval    = 10 * time.Second
+	defaultNewDiskFileMode        = os.FileMode(0666)
+	defaultNewDiskDirectoryMode   = os.ModeDir | os.FileMode(0755)
+	defaultDiskMaxMessageAge      = 0
+	defaultDiskOnFullStrategy     = DropOldest
+	defaultDiskCloseCheckInterval = time.Second
+)
+
+var (
+	errNoDirectory              = errors.New("no buffer directory")
+	errNonPositiveMaxDiskSize   = errors.New("non-positive max disk size")
+	errNonPositiveSegmentSize   = errors.New("non-positive max segment size")
+	errInvalidMaxSegmentSize    = errors.New("invalid max segment size")
+	errNegativeMaxMessageAge    = errors.New("negative max message age")
+	errNegativeMaxMemorySize    = errors.New("negative max memory size")
+	errNonPositiveSyncInterval  = errors.New("non-positive sync interval")
+	errNonPositiveCleanInterval = errors.New("non-positive cleanup interval")
+)
+
+type diskOptions struct {
+	directory          string
+	strategy           OnFullStrategy
+	maxMessageSize     int
+	maxDiskSize        int64
+	maxSegmentSize     int64
+	maxMemorySize      int64
+	maxMessageAge      time.Duration
+	syncInterval       time.Duration
+	cleanupInterval    time.Duration
+	closeCheckInterval time.Duration
+	newFileMode        os.FileMode
+	newDirectoryMode   os.FileMode
+	iOpts              instrument.Options
+}
+
+// NewDiskOptions creates DiskOptions.
+func NewDiskOptions() DiskOptions {
+	return &diskOptions{
+		strategy:           defaultDiskOnFullStrategy,
+		maxMessageSize:     defaultMaxMessageSize,
+		maxDiskSize:        defaultMaxDiskSize,
+		maxSegmentSize:     defaultMaxSegmentSize,
+		maxMemorySize:      defaultMaxMemorySize,
+		maxMessageAge:      defaultDiskMaxMessageAge,
+		syncInterval:       defaultSyncInterval,
+		cleanupInterval:    defaultDiskCleanupInterval,
+		closeCheckInterval: defaultDiskCloseCheckInterval,
+		newFileMode:        defaultNewDiskFileMode,
+		newDirectoryMode:   defaultNewDiskDirectoryMode,
+		iOpts:              instrument.NewOptions(),
+	}
+}
+
+func (opts *diskOptions) Directory() string {
+	return opts.directory
+}
+
+func (opts *diskOptions) SetDirectory(value string) DiskOptions {
+	o := *opts
+	o.directory = value
+	return &o
+}
+
+func (opts *diskOptions) OnFullStrategy() OnFullStrategy {
+	return opts.strategy
+}
+
+func (opts *diskOptions) SetOnFullStrategy(value OnFullStrategy) DiskOptions {
+	o := *opts
+	o.strategy = value
+	return &o
+}
+
+func (opts *diskOptions) MaxMessageSize() int {
+	return opts.maxMessageSize
+}
+
+func (opts *diskOptions) SetMaxMessageSize(value int) DiskOptions {
+	o := *opts
+	o.maxMessageSize = value
+	return &o
+}
+
+func (opts *diskOptions) MaxDiskSize() int64 {
+	return opts.maxDiskSize
+}
+
+func (opts *diskOptions) SetMaxDiskSize(value int64) DiskOptions {
+	o := *opts
+	o.maxDiskSize = value
+	return &o
+}
+
+func (opts *diskOptions) MaxSegmentSize() int64 {
+	return opts.maxSegmentSize
+}
+
+func (opts *diskOptions) SetMaxSegmentSize(value int64) DiskOptions {
+	o := *opts
+	o.maxSegmentSize = value
+	return &o
+}
+
+func (opts *diskOptions) MaxMemorySize() int64 {
+	return opts.maxMemorySize
+}
+
+func (opts *diskOptions) SetMaxMemorySize(value int64) DiskOptions {
+	o := *opts
+	o.maxMemorySize = value
+	return &o
+}
+
+func (opts *diskOptions) MaxMessageAge() time.Duration {
+	return opts.maxMessageAge
+}
+
+func (opts *diskOptions) SetMaxMessageAge(value time.Duration) DiskOptions {
+	o := *opts
+	o.maxMessageAge = value
+	return &o
+}
+
+func (opts *diskOptions) SyncInterval() time.Duration {
+	return opts.syncInterval
+}
+
+func (opts *diskOptions) SetSyncInterval(value time.Duration) DiskOptions {
+	o := *opts
+	o.syncInterval = value
+	return &o
+}
+
+func (opts *diskOptions) CleanupInterval() time.Duration {
+	return opts.cleanupInterval
+}
+
+func (opts *diskOptions) SetCleanupInterval(value time.Duration) DiskOptions {
+	o := *opts
+	o.cleanupInterval = value
+	return &o
+}
+
+func (opts *diskOptions) CloseCheckInterval() time.Duration {
+	return opts.closeCheckInterval
+}
+
+func (opts *diskOptions) SetCloseCheckInterval(value time.Duration) DiskOptions {
+	o := *opts
+	o.closeCheckInterval = value
+	return &o
+}
+
+func (opts *diskOptions) NewFileMode() os.FileMode {
+	return opts.newFileMode
+}
+
+func (opts *diskOptions) SetNewFileMode(value os.FileMode) DiskOptions {
+	o := *opts
+	o.newFileMode = value
+	return &o
+}
+
+func (opts *diskOptions) NewDirectoryMode() os.FileMode {
+	return opts.newDirectoryMode
+}
+
+func (opts *diskOptions) SetNewDirectoryMode(value os.FileMode) DiskOptions {
+	o := *opts
+	o.newDirectoryMode = value
+	return &o
+}
+
+func (opts *diskOptions) InstrumentOptions() instrument.Options {
+	return opts.iOpts
+}
+
+func (opts *diskOptions) SetInstrumentOptions(value instrument.Options) DiskOptions {
+	o := *opts
+	o.iOpts = value
+	return &o
+}
+
+func (opts *diskOptions) Validate() error {
+	if opts.Directory() == "" {
+		return errNoDirectory
+	}
+	if opts.MaxMessageSize() <= 0 {
+		return errNegativeMaxMessageSize
+	}
+	if opts.MaxDiskSize() <= 0 {
+		return errNonPositiveMaxDiskSize
+	}
+	if opts.MaxSegmentSize() <= 0 {
+		return errNonPositiveSegmentSize
+	}
+	if opts.MaxSegmentSize() > opts.MaxDiskSize() {
+		// A segment needs to fit on disk for it to be rotated.
+		return errInvalidMaxSegmentSize
+	}
+	if int64(opts.MaxMessageSize()) > opts.MaxSegmentSize() {
+		// Max message size can only be as large as a segment.
+		return errInvalidMaxMessageSize
+	}
+	if opts.MaxMemorySize() < 0 {
+		return errNegativeMaxMemorySize
+	}
+	if opts.MaxMessageAge() < 0 {
+		return errNegativeMaxMessageAge
+	}
+	if opts.SyncInterval() <= 0 {
+		return errNonPositiveSyncInterval
+	}
+	if opts.CleanupInterval() <= 0 {
+		return errNonPositiveCleanInterval
+	}
+	return nil
+}
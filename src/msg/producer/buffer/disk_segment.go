@@ -0,0 +1,400 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package buffer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/msg/producer"
+)
+
+const (
+	segmentFilePrefix    = "segment-"
+	segmentDataFileExt   = ".log"
+	segmentAckFileExt    = ".acks"
+	segmentFileBufSize   = 64 * 1024
+	recordHeaderLen      = 20
+	ackRecordLen         = 4
+	recordLenOffset      = 0
+	recordChecksumOffset = 4
+	recordShardOffset    = 8
+	recordTimeOffset     = 12
+)
+
+var errSegmentSealed = errors.New("segment is sealed")
+
+// diskRecord is a message read back from a segment, its bytes are read from
+// the data file at the offset when needed.
+type diskRecord struct {
+	index     uint32
+	shard     uint32
+	timestamp time.Time
+	offset    int64
+	length    int
+}
+
+// diskSegment is a segment of the disk buffer. The data file holds the
+// messages added while the segment was active, each message written as a
+// record with a checksummed header, and the ack file holds the indexes of
+// the messages that have since been consumed or dropped. The bytes of the
+// messages are read back from the data file, which is safe to do concurrently
+// once the data has been flushed.
+type diskSegment struct {
+	id       uint64
+	dataPath string
+	ackPath  string
+	opts     DiskOptions
+
+	dataFd     *os.File
+	dataW      *bufio.Writer
+	readFd     *os.File
+	readClosed bool
+	ackFd      *os.File
+	ackW       *bufio.Writer
+
+	numMessages uint32
+	dataSize    int64
+	ackSize     int64
+	firstWrite  time.Time
+	lastWrite   time.Time
+	outstanding map[uint32]*producer.RefCountedMessage
+	removed     bool
+}
+
+func newDiskSegment(id uint64, opts DiskOptions) *diskSegment {
+	dataPath, ackPath := segmentFilePaths(opts.Directory(), id)
+	return &diskSegment{
+		id:          id,
+		dataPath:    dataPath,
+		ackPath:     ackPath,
+		opts:        opts,
+		outstanding: make(map[uint32]*producer.RefCountedMessage),
+	}
+}
+
+// openDiskSegment creates a new segment to add messages to.
+func openDiskSegment(id uint64, opts DiskOptions) (*diskSegment, error) {
+	s := newDiskSegment(id, opts)
+	fd, err := os.OpenFile(s.dataPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, opts.NewFileMode())
+	if err != nil {
+		return nil, err
+	}
+	readFd, err := os.Open(s.dataPath)
+	if err != nil {
+		fd.Close()
+		return nil, err
+	}
+	s.dataFd = fd
+	s.dataW = bufio.NewWriterSize(fd, segmentFileBufSize)
+	s.readFd = readFd
+	return s, nil
+}
+
+func (s *diskSegment) size() int64 {
+	return s.dataSize + s.ackSize
+}
+
+func (s *diskSegment) sealed() bool {
+	return s.dataFd == nil
+}
+
+// append writes a message to the segment and returns its index and the
+// offset of its bytes in the data file.
+func (s *diskSegment) append(shard uint32, t time.Time, b []byte) (uint32, int64, error) {
+	if s.sealed() {
+		return 0, 0, errSegmentSealed
+	}
+	var header [recordHeaderLen]byte
+	binary.BigEndian.PutUint32(header[recordLenOffset:], uint32(len(b)))
+	binary.BigEndian.PutUint32(header[recordShardOffset:], shard)
+	binary.BigEndian.PutUint64(header[recordTimeOffset:], uint64(t.UnixNano()))
+	checksum := crc32.ChecksumIEEE(header[recordShardOffset:])
+	checksum = crc32.Update(checksum, crc32.IEEETable, b)
+	binary.BigEndian.PutUint32(header[recordChecksumOffset:], checksum)
+
+	if _, err := s.dataW.Write(header[:]); err != nil {
+		return 0, 0, err
+	}
+	if _, err := s.dataW.Write(b); err != nil {
+		return 0, 0, err
+	}
+
+	index := s.numMessages
+	offset := s.dataSize + recordHeaderLen
+	s.numMessages++
+	s.dataSize += int64(recordHeaderLen + len(b))
+	if index == 0 {
+		s.firstWrite = t
+	}
+	s.lastWrite = t
+	return index, offset, nil
+}
+
+// read reads the bytes of a message from the data file, the message must
+// have been flushed.
+func (s *diskSegment) read(offset int64, length int) ([]byte, error) {
+	b := make([]byte, length)
+	if _, err := s.readFd.ReadAt(b, offset); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ack records that the message at the given index no longer needs replaying.
+func (s *diskSegment) ack(index uint32) error {
+	if s.ackW == nil {
+		fd, err := os.OpenFile(s.ackPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, s.opts.NewFileMode())
+		if err != nil {
+			return err
+		}
+		s.ackFd = fd
+		s.ackW = bufio.NewWriterSize(fd, segmentFileBufSize)
+	}
+	var b [ackRecordLen]byte
+	binary.BigEndian.PutUint32(b[:], index)
+	if _, err := s.ackW.Write(b[:]); err != nil {
+		return err
+	}
+	s.ackSize += ackRecordLen
+	return nil
+}
+
+// flush flushes the buffered messages to the data file so they can be read.
+func (s *diskSegment) flush() error {
+	if s.dataW == nil {
+		return nil
+	}
+	return s.dataW.Flush()
+}
+
+// flushTo flushes the buffered messages if the data file is shorter than the
+// given size.
+func (s *diskSegment) flushTo(size int64) error {
+	if s.dataW == nil || s.dataSize-int64(s.dataW.Buffered()) >= size {
+		return nil
+	}
+	return s.flush()
+}
+
+// sync flushes buffered writes and syncs the segment files to disk.
+func (s *diskSegment) sync() error {
+	if s.dataW != nil {
+		if err := s.flush(); err != nil {
+			return err
+		}
+		if err := s.dataFd.Sync(); err != nil {
+			return err
+		}
+	}
+	if s.ackW != nil {
+		if err := s.ackW.Flush(); err != nil {
+			return err
+		}
+		if err := s.ackFd.Sync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seal syncs and closes the data file so no more messages can be added.
+func (s *diskSegment) seal() error {
+	if s.sealed() {
+		return nil
+	}
+	err := s.sync()
+	if closeErr := s.dataFd.Close(); err == nil {
+		err = closeErr
+	}
+	s.dataFd = nil
+	s.dataW = nil
+	return err
+}
+
+// close syncs and closes all the segment files.
+func (s *diskSegment) close() error {
+	err := s.seal()
+	if s.ackW != nil {
+		if flushErr := s.ackW.Flush(); err == nil {
+			err = flushErr
+		}
+		if closeErr := s.ackFd.Close(); err == nil {
+			err = closeErr
+		}
+		s.ackFd = nil
+		s.ackW = nil
+	}
+	if s.readFd != nil && !s.readClosed {
+		// NB: The read file is not unset as messages may still be reading
+		// from it, reads after it is closed fail.
+		s.readClosed = true
+		if closeErr := s.readFd.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// remove closes and deletes the segment files.
+func (s *diskSegment) remove() error {
+	s.removed = true
+	err := s.close()
+	for _, p := range []string{s.dataPath, s.ackPath} {
+		if rmErr := os.Remove(p); rmErr != nil && !os.IsNotExist(rmErr) && err == nil {
+			err = rmErr
+		}
+	}
+	return err
+}
+
+// recover reads back the segment files of a sealed segment, returning the
+// messages that have not been acked and whether a corrupt or partially
+// written record was found, in which case the records after it are lost.
+func (s *diskSegment) recover() ([]diskRecord, bool, error) {
+	acks, err := ioutil.ReadFile(s.ackPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, false, err
+	}
+	fd, err := os.Open(s.dataPath)
+	if err != nil {
+		return nil, false, err
+	}
+	info, err := fd.Stat()
+	if err != nil {
+		fd.Close()
+		return nil, false, err
+	}
+
+	acked := make(map[uint32]struct{}, len(acks)/ackRecordLen)
+	for i := 0; i+ackRecordLen <= len(acks); i += ackRecordLen {
+		acked[binary.BigEndian.Uint32(acks[i:])] = emptyStruct
+	}
+	s.ackSize = int64(len(acks))
+
+	var (
+		r       = bufio.NewReaderSize(fd, segmentFileBufSize)
+		header  [recordHeaderLen]byte
+		buf     []byte
+		records []diskRecord
+		corrupt bool
+	)
+	for {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if err == io.EOF {
+				break
+			}
+			if err != io.ErrUnexpectedEOF {
+				fd.Close()
+				return nil, false, err
+			}
+			corrupt = true
+			break
+		}
+		l := int(binary.BigEndian.Uint32(header[recordLenOffset:]))
+		if s.dataSize+int64(recordHeaderLen+l) > info.Size() {
+			// The record is partially written or its length is corrupt.
+			corrupt = true
+			break
+		}
+		if cap(buf) < l {
+			buf = make([]byte, l)
+		}
+		buf = buf[:l]
+		if _, err := io.ReadFull(r, buf); err != nil {
+			if err != io.EOF && err != io.ErrUnexpectedEOF {
+				fd.Close()
+				return nil, false, err
+			}
+			corrupt = true
+			break
+		}
+		checksum := crc32.ChecksumIEEE(header[recordShardOffset:])
+		checksum = crc32.Update(checksum, crc32.IEEETable, buf)
+		if checksum != binary.BigEndian.Uint32(header[recordChecksumOffset:]) {
+			corrupt = true
+			break
+		}
+
+		index := s.numMessages
+		t := time.Unix(0, int64(binary.BigEndian.Uint64(header[recordTimeOffset:])))
+		if index == 0 {
+			s.firstWrite = t
+		}
+		s.lastWrite = t
+		s.numMessages++
+		offset := s.dataSize + recordHeaderLen
+		s.dataSize += int64(recordHeaderLen + l)
+
+		if _, ok := acked[index]; !ok {
+			records = append(records, diskRecord{
+				index:     index,
+				shard:     binary.BigEndian.Uint32(header[recordShardOffset:]),
+				timestamp: t,
+				offset:    offset,
+				length:    l,
+			})
+		}
+	}
+	s.readFd = fd
+	return records, corrupt, nil
+}
+
+func segmentFilePaths(dir string, id uint64) (string, string) {
+	prefix := path.Join(dir, fmt.Sprintf("%s%020d", segmentFilePrefix, id))
+	return prefix + segmentDataFileExt, prefix + segmentAckFileExt
+}
+
+// listDiskSegments returns the ids of the segments in the directory in order.
+func listDiskSegments(dir string) ([]uint64, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uint64
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() ||
+			!strings.HasPrefix(name, segmentFilePrefix) ||
+			!strings.HasSuffix(name, segmentDataFileExt) {
+			continue
+		}
+		id, err := strconv.ParseUint(
+			strings.TrimSuffix(strings.TrimPrefix(name, segmentFilePrefix), segmentDataFileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
@@ -21,6 +21,7 @@
 package buffer
 
 import (
+	"os"
 	"time"
 
 	"github.com/m3db/m3x/instrument"
@@ -105,3 +106,95 @@ type Options interface {
 	// Validate validates the options.
 	Validate() error
 }
+
+// DiskOptions configs the disk backed buffer.
+type DiskOptions interface {
+	// Directory returns the directory the buffer segments are written to.
+	Directory() string
+
+	// SetDirectory sets the directory the buffer segments are written to.
+	SetDirectory(value string) DiskOptions
+
+	// OnFullStrategy returns the strategy when the disk buffer is full.
+	OnFullStrategy() OnFullStrategy
+
+	// SetOnFullStrategy sets the strategy when the disk buffer is full.
+	SetOnFullStrategy(value OnFullStrategy) DiskOptions
+
+	// MaxMessageSize returns the max message size.
+	MaxMessageSize() int
+
+	// SetMaxMessageSize sets the max message size.
+	SetMaxMessageSize(value int) DiskOptions
+
+	// MaxDiskSize returns the max size of all the buffer segments on disk.
+	MaxDiskSize() int64
+
+	// SetMaxDiskSize sets the max size of all the buffer segments on disk.
+	SetMaxDiskSize(value int64) DiskOptions
+
+	// MaxSegmentSize returns the size after which a new segment is started.
+	MaxSegmentSize() int64
+
+	// SetMaxSegmentSize sets the size after which a new segment is started.
+	SetMaxSegmentSize(value int64) DiskOptions
+
+	// MaxMemorySize returns the max size of the unconsumed messages kept in
+	// memory, the bytes of older messages are read back from disk when needed.
+	MaxMemorySize() int64
+
+	// SetMaxMemorySize sets the max size of the unconsumed messages kept in
+	// memory, the bytes of older messages are read back from disk when needed.
+	SetMaxMemorySize(value int64) DiskOptions
+
+	// MaxMessageAge returns the age after which unconsumed messages are
+	// dropped, zero means messages are kept until consumed.
+	MaxMessageAge() time.Duration
+
+	// SetMaxMessageAge sets the age after which unconsumed messages are
+	// dropped, zero means messages are kept until consumed.
+	SetMaxMessageAge(value time.Duration) DiskOptions
+
+	// SyncInterval returns the interval at which segments are synced to disk,
+	// messages added within the interval before a crash may be lost.
+	SyncInterval() time.Duration
+
+	// SetSyncInterval sets the interval at which segments are synced to disk,
+	// messages added within the interval before a crash may be lost.
+	SetSyncInterval(value time.Duration) DiskOptions
+
+	// CleanupInterval returns the interval at which consumed and expired
+	// segments are removed.
+	CleanupInterval() time.Duration
+
+	// SetCleanupInterval sets the interval at which consumed and expired
+	// segments are removed.
+	SetCleanupInterval(value time.Duration) DiskOptions
+
+	// CloseCheckInterval returns the close check interval.
+	CloseCheckInterval() time.Duration
+
+	// SetCloseCheckInterval sets the close check interval.
+	SetCloseCheckInterval(value time.Duration) DiskOptions
+
+	// NewFileMode returns the file mode of new segment files.
+	NewFileMode() os.FileMode
+
+	// SetNewFileMode sets the file mode of new segment files.
+	SetNewFileMode(value os.FileMode) DiskOptions
+
+	// NewDirectoryMode returns the mode of the buffer directory when created.
+	NewDirectoryMode() os.FileMode
+
+	// SetNewDirectoryMode sets the mode of the buffer directory when created.
+	SetNewDirectoryMode(value os.FileMode) DiskOptions
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) DiskOptions
+
+	// Validate validates the options.
+	Validate() error
+}
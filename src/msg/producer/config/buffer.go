@@ -30,14 +30,15 @@ import (
 
 // BufferConfiguration configs the buffer.
 type BufferConfiguration struct {
-	OnFullStrategy        *buffer.OnFullStrategy `yaml:"onFullStrategy"`
-	MaxBufferSize         *int                   `yaml:"maxBufferSize"`
-	MaxMessageSize        *int                   `yaml:"maxMessageSize"`
-	CloseCheckInterval    *time.Duration         `yaml:"closeCheckInterval"`
-	DropOldestInterval    *time.Duration         `yaml:"dropOldestInterval"`
-	ScanBatchSize         *int                   `yaml:"scanBatchSize"`
-	AllowedSpilloverRatio *float64               `yaml:"allowedSpilloverRatio"`
-	CleanupRetry          *retry.Configuration   `yaml:"cleanupRetry"`
+	OnFullStrategy        *buffer.OnFullStrategy   `yaml:"onFullStrategy"`
+	MaxBufferSize         *int                     `yaml:"maxBufferSize"`
+	MaxMessageSize        *int                     `yaml:"maxMessageSize"`
+	CloseCheckInterval    *time.Duration           `yaml:"closeCheckInterval"`
+	DropOldestInterval    *time.Duration           `yaml:"dropOldestInterval"`
+	ScanBatchSize         *int                     `yaml:"scanBatchSize"`
+	AllowedSpilloverRatio *float64                 `yaml:"allowedSpilloverRatio"`
+	CleanupRetry          *retry.Configuration     `yaml:"cleanupRetry"`
+	Disk                  *DiskBufferConfiguration `yaml:"disk"`
 }
 
 // NewOptions creates new buffer options.
@@ -69,3 +70,51 @@ func (c *BufferConfiguration) NewOptions(iOpts instrument.Options) buffer.Option
 	}
 	return opts.SetInstrumentOptions(iOpts)
 }
+
+// DiskBufferConfiguration configs the disk backed buffer, when set it is used
+// instead of the in memory buffer.
+type DiskBufferConfiguration struct {
+	Directory          string                 `yaml:"directory" validate:"nonzero"`
+	OnFullStrategy     *buffer.OnFullStrategy `yaml:"onFullStrategy"`
+	MaxMessageSize     *int                   `yaml:"maxMessageSize"`
+	MaxDiskSize        *int64                 `yaml:"maxDiskSize"`
+	MaxSegmentSize     *int64                 `yaml:"maxSegmentSize"`
+	MaxMemorySize      *int64                 `yaml:"maxMemorySize"`
+	MaxMessageAge      *time.Duration         `yaml:"maxMessageAge"`
+	SyncInterval       *time.Duration         `yaml:"syncInterval"`
+	CleanupInterval    *time.Duration         `yaml:"cleanupInterval"`
+	CloseCheckInterval *time.Duration         `yaml:"closeCheckInterval"`
+}
+
+// NewOptions creates new disk buffer options.
+func (c *DiskBufferConfiguration) NewOptions(iOpts instrument.Options) buffer.DiskOptions {
+	opts := buffer.NewDiskOptions().SetDirectory(c.Directory)
+	if c.OnFullStrategy != nil {
+		opts = opts.SetOnFullStrategy(*c.OnFullStrategy)
+	}
+	if c.MaxMessageSize != nil {
+		opts = opts.SetMaxMessageSize(*c.MaxMessageSize)
+	}
+	if c.MaxDiskSize != nil {
+		opts = opts.SetMaxDiskSize(*c.MaxDiskSize)
+	}
+	if c.MaxSegmentSize != nil {
+		opts = opts.SetMaxSegmentSize(*c.MaxSegmentSize)
+	}
+	if c.MaxMemorySize != nil {
+		opts = opts.SetMaxMemorySize(*c.MaxMemorySize)
+	}
+	if c.MaxMessageAge != nil {
+		opts = opts.SetMaxMessageAge(*c.MaxMessageAge)
+	}
+	if c.SyncInterval != nil {
+		opts = opts.SetSyncInterval(*c.SyncInterval)
+	}
+	if c.CleanupInterval != nil {
+		opts = opts.SetCleanupInterval(*c.CleanupInterval)
+	}
+	if c.CloseCheckInterval != nil {
+		opts = opts.SetCloseCheckInterval(*c.CloseCheckInterval)
+	}
+	return opts.SetInstrumentOptions(iOpts)
+}
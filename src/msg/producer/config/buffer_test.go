@@ -69,3 +69,36 @@ func TestEmptyBufferConfiguration(t *testing.T) {
 		cfg.NewOptions(instrument.NewOptions()).SetCleanupRetryOptions(rOpts),
 	)
 }
+
+func TestDiskBufferConfiguration(t *testing.T) {
+	str := `
+disk:
+  directory: /var/lib/m3/producer
+  onFullStrategy: returnError
+  maxMessageSize: 16
+  maxDiskSize: 1000
+  maxSegmentSize: 100
+  maxMemorySize: 50
+  maxMessageAge: 1h
+  syncInterval: 2s
+  cleanupInterval: 5s
+  closeCheckInterval: 3s
+`
+
+	var cfg BufferConfiguration
+	require.NoError(t, yaml.Unmarshal([]byte(str), &cfg))
+	require.NotNil(t, cfg.Disk)
+
+	dOpts := cfg.Disk.NewOptions(instrument.NewOptions())
+	require.Equal(t, "/var/lib/m3/producer", dOpts.Directory())
+	require.Equal(t, buffer.ReturnError, dOpts.OnFullStrategy())
+	require.Equal(t, 16, dOpts.MaxMessageSize())
+	require.Equal(t, int64(1000), dOpts.MaxDiskSize())
+	require.Equal(t, int64(100), dOpts.MaxSegmentSize())
+	require.Equal(t, int64(50), dOpts.MaxMemorySize())
+	require.Equal(t, time.Hour, dOpts.MaxMessageAge())
+	require.Equal(t, 2*time.Second, dOpts.SyncInterval())
+	require.Equal(t, 5*time.Second, dOpts.CleanupInterval())
+	require.Equal(t, 3*time.Second, dOpts.CloseCheckInterval())
+	require.NoError(t, dOpts.Validate())
+}
@@ -42,13 +42,19 @@ func (c *ProducerConfiguration) newOptions(
 	if err != nil {
 		return nil, err
 	}
-	b, err := buffer.NewBuffer(c.Buffer.NewOptions(iOpts))
+	w := writer.NewWriter(wOpts)
+	var b producer.Buffer
+	if c.Buffer.Disk != nil {
+		b, err = buffer.NewDiskBuffer(w, c.Buffer.Disk.NewOptions(iOpts))
+	} else {
+		b, err = buffer.NewBuffer(c.Buffer.NewOptions(iOpts))
+	}
 	if err != nil {
 		return nil, err
 	}
 	return producer.NewOptions().
 		SetBuffer(b).
-		SetWriter(writer.NewWriter(wOpts)), nil
+		SetWriter(w), nil
 }
 
 // NewProducer creates new producer.
@@ -85,6 +85,7 @@ type WriterConfiguration struct {
 	PlacementWatchInitTimeout         *time.Duration                 `yaml:"placementWatchInitTimeout"`
 	MessagePool                       *pool.ObjectPoolConfiguration  `yaml:"messagePool"`
 	MessageRetry                      *retry.Configuration           `yaml:"messageRetry"`
+	MaxMessageRetries                 *int                           `yaml:"maxMessageRetries"`
 	MessageQueueNewWritesScanInterval *time.Duration                 `yaml:"messageQueueNewWritesScanInterval"`
 	MessageQueueFullScanInterval      *time.Duration                 `yaml:"messageQueueFullScanInterval"`
 	MessageQueueScanBatchSize         *int                           `yaml:"messageQueueScanBatchSize"`
@@ -132,6 +133,9 @@ func (c *WriterConfiguration) NewOptions(
 	if c.MessageRetry != nil {
 		opts = opts.SetMessageRetryOptions(c.MessageRetry.NewOptions(iOpts.MetricsScope()))
 	}
+	if c.MaxMessageRetries != nil {
+		opts = opts.SetMaxMessageRetries(*c.MaxMessageRetries)
+	}
 	if c.MessageQueueNewWritesScanInterval != nil {
 		opts = opts.SetMessageQueueNewWritesScanInterval(*c.MessageQueueNewWritesScanInterval)
 	}
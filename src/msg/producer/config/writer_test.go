@@ -78,6 +78,7 @@ messagePool:
   size: 5
 messageRetry:
   initialBackoff: 1ms
+maxMessageRetries: 10
 messageQueueNewWritesScanInterval: 200ms
 messageQueueFullScanInterval: 10s
 messageQueueScanBatchSize: 1024
@@ -113,6 +114,7 @@ decoder:
 	require.Equal(t, 2*time.Second, wOpts.PlacementWatchInitTimeout())
 	require.Equal(t, 5, wOpts.MessagePoolOptions().Size())
 	require.Equal(t, time.Millisecond, wOpts.MessageRetryOptions().InitialBackoff())
+	require.Equal(t, 10, wOpts.MaxMessageRetries())
 	require.Equal(t, 200*time.Millisecond, wOpts.MessageQueueNewWritesScanInterval())
 	require.Equal(t, 10*time.Second, wOpts.MessageQueueFullScanInterval())
 	require.Equal(t, 1024, wOpts.MessageQueueScanBatchSize())
@@ -34,8 +34,13 @@ func NewProducer(opts Options) Producer {
 }
 
 func (p *producer) Init() error {
+	// NB: Must init writer first, a buffer that persists messages replays
+	// the messages that were not consumed through the writer on init.
+	if err := p.Writer.Init(); err != nil {
+		return err
+	}
 	p.Buffer.Init()
-	return p.Writer.Init()
+	return nil
 }
 
 func (p *producer) Produce(m Message) error {
@@ -33,6 +33,10 @@ const (
 
 	// Dropped means the message has been dropped.
 	Dropped
+
+	// Spilled means the message has been written to a buffer which owns its
+	// bytes from then on, the buffer consumes or drops the message later on.
+	Spilled
 )
 
 // Message contains the data that will be produced by the producer.
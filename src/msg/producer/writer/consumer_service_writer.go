@@ -59,9 +59,13 @@ const (
 	allowInitValueError
 )
 
+// drainFn takes over a message drained from a consumer service writer.
+type drainFn func(rm *producer.RefCountedMessage)
+
 type consumerServiceWriter interface {
-	// Write writes a message.
-	Write(rm *producer.RefCountedMessage)
+	// Write writes a message to the given shard, which could be different
+	// from the shard of the message if the message is resharded.
+	Write(shard uint32, rm *producer.RefCountedMessage)
 
 	// Init will initialize the consumer service writer.
 	Init(initType) error
@@ -69,6 +73,11 @@ type consumerServiceWriter interface {
 	// Close closes the writer and the background watch thread.
 	Close()
 
+	// Drain hands the unacknowledged messages over to the drain function,
+	// each message is handed over once even if it's queued for multiple
+	// instances. The writer should not receive new writes while draining.
+	Drain(fn drainFn)
+
 	// SetMessageTTLNanos sets the message ttl nanoseconds.
 	SetMessageTTLNanos(value int64)
 
@@ -122,6 +131,7 @@ type consumerServiceWriterImpl struct {
 func newConsumerServiceWriter(
 	cs topic.ConsumerService,
 	numShards uint32,
+	deadLetterFn deadLetterFn,
 	opts Options,
 ) (consumerServiceWriter, error) {
 	ps, err := opts.ServiceDiscovery().PlacementService(cs.ServiceID(), nil)
@@ -136,7 +146,7 @@ func newConsumerServiceWriter(
 	w := &consumerServiceWriterImpl{
 		cs:              cs,
 		ps:              ps,
-		shardWriters:    initShardWriters(router, ct, numShards, opts),
+		shardWriters:    initShardWriters(router, ct, numShards, deadLetterFn, opts),
 		opts:            opts,
 		logger:          opts.InstrumentOptions().Logger(),
 		dataFilter:      acceptAllFilter,
@@ -155,6 +165,7 @@ func initShardWriters(
 	router ackRouter,
 	ct topic.ConsumptionType,
 	numberOfShards uint32,
+	deadLetterFn deadLetterFn,
 	opts Options,
 ) []shardWriter {
 	var (
@@ -172,17 +183,17 @@ func initShardWriters(
 	for i := range sws {
 		switch ct {
 		case topic.Shared:
-			sws[i] = newSharedShardWriter(uint32(i), router, mPool, opts, m)
+			sws[i] = newSharedShardWriter(uint32(i), router, mPool, opts, m, deadLetterFn)
 		case topic.Replicated:
-			sws[i] = newReplicatedShardWriter(uint32(i), numberOfShards, router, mPool, opts, m)
+			sws[i] = newReplicatedShardWriter(uint32(i), numberOfShards, router, mPool, opts, m, deadLetterFn)
 		}
 	}
 	return sws
 }
 
-func (w *consumerServiceWriterImpl) Write(rm *producer.RefCountedMessage) {
+func (w *consumerServiceWriterImpl) Write(shard uint32, rm *producer.RefCountedMessage) {
 	if rm.Accept(w.dataFilter) {
-		w.shardWriters[rm.Shard()].Write(rm)
+		w.shardWriters[shard].Write(rm)
 		w.m.filterAccepted.Inc(1)
 		return
 	}
@@ -320,6 +331,20 @@ func (w *consumerServiceWriterImpl) Close() {
 	w.logger.Infof("closed consumer service writer %s", w.cs.String())
 }
 
+func (w *consumerServiceWriterImpl) Drain(fn drainFn) {
+	drained := make(map[*producer.RefCountedMessage]struct{})
+	dedupFn := func(rm *producer.RefCountedMessage) {
+		if _, ok := drained[rm]; ok {
+			return
+		}
+		drained[rm] = struct{}{}
+		fn(rm)
+	}
+	for _, sw := range w.shardWriters {
+		sw.Drain(dedupFn)
+	}
+}
+
 func (w *consumerServiceWriterImpl) SetMessageTTLNanos(value int64) {
 	for _, sw := range w.shardWriters {
 		sw.SetMessageTTLNanos(value)
@@ -57,7 +57,7 @@ func TestConsumerServiceWriterWithSharedConsumerWithNonShardedPlacement(t *testi
 	sd.EXPECT().PlacementService(sid, gomock.Any()).Return(ps, nil)
 
 	opts := testOptions().SetServiceDiscovery(sd)
-	w, err := newConsumerServiceWriter(cs, 2, opts)
+	w, err := newConsumerServiceWriter(cs, 2, nil, opts)
 	require.NoError(t, err)
 
 	csw := w.(*consumerServiceWriterImpl)
@@ -124,7 +124,7 @@ func TestConsumerServiceWriterWithSharedConsumerWithNonShardedPlacement(t *testi
 	mm.EXPECT().Finalize(producer.Consumed)
 
 	rm := producer.NewRefCountedMessage(mm, nil)
-	csw.Write(rm)
+	csw.Write(rm.Shard(), rm)
 	for {
 		if rm.IsDroppedOrConsumed() {
 			break
@@ -179,7 +179,7 @@ func TestConsumerServiceWriterWithSharedConsumerWithShardedPlacement(t *testing.
 	sd.EXPECT().PlacementService(sid, gomock.Any()).Return(ps, nil)
 
 	opts := testOptions().SetServiceDiscovery(sd)
-	w, err := newConsumerServiceWriter(cs, 3, opts)
+	w, err := newConsumerServiceWriter(cs, 3, nil, opts)
 	require.NoError(t, err)
 
 	csw := w.(*consumerServiceWriterImpl)
@@ -260,7 +260,7 @@ func TestConsumerServiceWriterWithSharedConsumerWithShardedPlacement(t *testing.
 	mm.EXPECT().Finalize(producer.Consumed)
 
 	rm := producer.NewRefCountedMessage(mm, nil)
-	csw.Write(rm)
+	csw.Write(rm.Shard(), rm)
 	for {
 		if rm.IsDroppedOrConsumed() {
 			break
@@ -350,7 +350,7 @@ func TestConsumerServiceWriterWithReplicatedConsumerWithShardedPlacement(t *test
 	require.NoError(t, ps.Set(p1))
 
 	opts := testOptions().SetServiceDiscovery(sd)
-	w, err := newConsumerServiceWriter(cs, 2, opts)
+	w, err := newConsumerServiceWriter(cs, 2, nil, opts)
 	csw := w.(*consumerServiceWriterImpl)
 	require.NoError(t, err)
 	require.NotNil(t, csw)
@@ -385,7 +385,7 @@ func TestConsumerServiceWriterWithReplicatedConsumerWithShardedPlacement(t *test
 	mm.EXPECT().Finalize(producer.Consumed)
 
 	rm := producer.NewRefCountedMessage(mm, nil)
-	csw.Write(rm)
+	csw.Write(rm.Shard(), rm)
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
@@ -466,7 +466,7 @@ func TestConsumerServiceWriterWithReplicatedConsumerWithShardedPlacement(t *test
 	mm.EXPECT().Finalize(producer.Consumed)
 	mm.EXPECT().Size().Return(3)
 	rm = producer.NewRefCountedMessage(mm, nil)
-	csw.Write(rm)
+	csw.Write(rm.Shard(), rm)
 	for {
 		if rm.IsDroppedOrConsumed() {
 			break
@@ -491,7 +491,7 @@ func TestConsumerServiceWriterFilter(t *testing.T) {
 	sd.EXPECT().PlacementService(sid, gomock.Any()).Return(ps, nil)
 
 	opts := testOptions().SetServiceDiscovery(sd)
-	csw, err := newConsumerServiceWriter(cs, 3, opts)
+	csw, err := newConsumerServiceWriter(cs, 3, nil, opts)
 	require.NoError(t, err)
 
 	sw0 := NewMockshardWriter(ctrl)
@@ -507,19 +507,67 @@ func TestConsumerServiceWriterFilter(t *testing.T) {
 	mm1.EXPECT().Size().Return(3).AnyTimes()
 
 	sw0.EXPECT().Write(gomock.Any())
-	csw.Write(producer.NewRefCountedMessage(mm0, nil))
+	csw.Write(0, producer.NewRefCountedMessage(mm0, nil))
 	sw1.EXPECT().Write(gomock.Any())
-	csw.Write(producer.NewRefCountedMessage(mm1, nil))
+	csw.Write(1, producer.NewRefCountedMessage(mm1, nil))
 
 	csw.RegisterFilter(func(m producer.Message) bool { return m.Shard() == uint32(0) })
-	csw.Write(producer.NewRefCountedMessage(mm1, nil))
+	csw.Write(1, producer.NewRefCountedMessage(mm1, nil))
 
 	sw0.EXPECT().Write(gomock.Any())
-	csw.Write(producer.NewRefCountedMessage(mm0, nil))
+	csw.Write(0, producer.NewRefCountedMessage(mm0, nil))
 
 	csw.UnregisterFilter()
 	sw1.EXPECT().Write(gomock.Any())
-	csw.Write(producer.NewRefCountedMessage(mm1, nil))
+	csw.Write(1, producer.NewRefCountedMessage(mm1, nil))
+
+	// Resharded message is written to the given shard.
+	sw0.EXPECT().Write(gomock.Any())
+	csw.Write(0, producer.NewRefCountedMessage(mm1, nil))
+}
+
+func TestConsumerServiceWriterDrain(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sid := services.NewServiceID().SetName("foo")
+	cs := topic.NewConsumerService().SetServiceID(sid).SetConsumptionType(topic.Replicated)
+	sd := services.NewMockServices(ctrl)
+	ps := testPlacementService(mem.NewStore(), sid)
+	sd.EXPECT().PlacementService(sid, gomock.Any()).Return(ps, nil)
+
+	opts := testOptions().SetServiceDiscovery(sd)
+	csw, err := newConsumerServiceWriter(cs, 2, nil, opts)
+	require.NoError(t, err)
+
+	sw0 := NewMockshardWriter(ctrl)
+	sw1 := NewMockshardWriter(ctrl)
+	csw.(*consumerServiceWriterImpl).shardWriters[0] = sw0
+	csw.(*consumerServiceWriterImpl).shardWriters[1] = sw1
+
+	mm0 := producer.NewMockMessage(ctrl)
+	mm0.EXPECT().Size().Return(3)
+	rm0 := producer.NewRefCountedMessage(mm0, nil)
+	mm1 := producer.NewMockMessage(ctrl)
+	mm1.EXPECT().Size().Return(3)
+	rm1 := producer.NewRefCountedMessage(mm1, nil)
+
+	// The messages queued for multiple instances are only drained once.
+	sw0.EXPECT().Drain(gomock.Any()).Do(func(fn drainFn) {
+		fn(rm0)
+		fn(rm0)
+	})
+	sw1.EXPECT().Drain(gomock.Any()).Do(func(fn drainFn) {
+		fn(rm1)
+		fn(rm0)
+	})
+	var drained []*producer.RefCountedMessage
+	csw.Drain(func(rm *producer.RefCountedMessage) {
+		drained = append(drained, rm)
+	})
+	require.Equal(t, []*producer.RefCountedMessage{rm0, rm1}, drained)
 }
 
 func TestConsumerServiceWriterAllowInitValueErrorWithCreateWatchError(t *testing.T) {
@@ -538,7 +586,7 @@ func TestConsumerServiceWriterAllowInitValueErrorWithCreateWatchError(t *testing
 	sd.EXPECT().PlacementService(sid, gomock.Any()).Return(ps, nil)
 
 	opts := testOptions().SetServiceDiscovery(sd)
-	w, err := newConsumerServiceWriter(cs, 3, opts)
+	w, err := newConsumerServiceWriter(cs, 3, nil, opts)
 	require.NoError(t, err)
 	defer w.Close()
 
@@ -559,7 +607,7 @@ func TestConsumerServiceWriterAllowInitValueErrorWithInitValueError(t *testing.T
 	sd.EXPECT().PlacementService(sid, gomock.Any()).Return(ps, nil)
 
 	opts := testOptions().SetServiceDiscovery(sd)
-	w, err := newConsumerServiceWriter(cs, 3, opts)
+	w, err := newConsumerServiceWriter(cs, 3, nil, opts)
 	require.NoError(t, err)
 	defer w.Close()
 
@@ -582,7 +630,7 @@ func TestConsumerServiceWriterInitError(t *testing.T) {
 	sd.EXPECT().PlacementService(sid, gomock.Any()).Return(ps, nil)
 
 	opts := testOptions().SetServiceDiscovery(sd)
-	w, err := newConsumerServiceWriter(cs, 3, opts)
+	w, err := newConsumerServiceWriter(cs, 3, nil, opts)
 	require.NoError(t, err)
 	defer w.Close()
 
@@ -608,7 +656,7 @@ func TestConsumerServiceWriterUpdateNonShardedPlacementWithReplicatedConsumption
 	}, 0, 1)
 	require.NoError(t, err)
 	opts := testOptions().SetServiceDiscovery(sd)
-	w, err := newConsumerServiceWriter(cs, 2, opts)
+	w, err := newConsumerServiceWriter(cs, 2, nil, opts)
 	require.NoError(t, err)
 	err = w.Init(failOnError)
 	require.Error(t, err)
@@ -630,7 +678,7 @@ func TestConsumerServiceCloseShardWritersConcurrently(t *testing.T) {
 	opts := testOptions().SetServiceDiscovery(sd).SetCloseCheckInterval(time.Second)
 
 	numShards := uint32(1024)
-	w, err := newConsumerServiceWriter(cs, numShards, opts)
+	w, err := newConsumerServiceWriter(cs, numShards, nil, opts)
 	require.NoError(t, err)
 	require.NoError(t, w.Init(allowInitValueError))
 
@@ -639,11 +687,10 @@ func TestConsumerServiceCloseShardWritersConcurrently(t *testing.T) {
 	b := []byte{}
 	for i := uint32(0); i < numShards; i++ {
 		mm := producer.NewMockMessage(ctrl)
-		mm.EXPECT().Shard().Return(i)
 		mm.EXPECT().Bytes().Return(b).AnyTimes()
 		mm.EXPECT().Size().Return(0).AnyTimes()
 		mm.EXPECT().Finalize(gomock.Any())
-		w.Write(producer.NewRefCountedMessage(mm, nil))
+		w.Write(i, producer.NewRefCountedMessage(mm, nil))
 	}
 
 	ch := make(chan struct{})
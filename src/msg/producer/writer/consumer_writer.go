@@ -286,6 +286,7 @@ func (w *consumerWriterImpl) readAcks() error {
 	// NB(cw) The proto needs to be cleaned up because the gogo protobuf
 	// unmarshalling will append to the underlying slice.
 	w.ack.Metadata = w.ack.Metadata[:0]
+	w.ack.Nacks = w.ack.Nacks[:0]
 	w.decodeLock.Lock()
 	err := w.decoder.Decode(&w.ack)
 	w.decodeLock.Unlock()
@@ -301,6 +302,12 @@ func (w *consumerWriterImpl) readAcks() error {
 			w.logger.Errorf("could not ack metadata, %v", err)
 		}
 	}
+	for _, n := range w.ack.Nacks {
+		if err := w.router.Nack(newMetadataFromProto(n.Metadata), n.Reason); err != nil {
+			w.m.ackError.Inc(1)
+			w.logger.Errorf("could not nack metadata, %v", err)
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,155 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package writer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/msg/generated/proto/msgpb"
+	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3/src/msg/topic"
+	"github.com/m3db/m3x/clock"
+	"github.com/m3db/m3x/log"
+)
+
+const (
+	retryBudgetExhaustedReason = "retry budget exhausted"
+)
+
+// deadLetterFn routes a message that could not be consumed by a consumer
+// service to the dead-letter consumer service of the topic, it returns false
+// if the message could not be routed.
+type deadLetterFn func(m *message, reason string) bool
+
+// deadLetterWriter writes dead letters to the dead-letter consumer service
+// writer of the topic, which could change on topic updates.
+type deadLetterWriter struct {
+	sync.RWMutex
+
+	csw       consumerServiceWriter
+	numShards uint32
+	logger    log.Logger
+	nowFn     clock.NowFn
+}
+
+func newDeadLetterWriter(opts Options) *deadLetterWriter {
+	return &deadLetterWriter{
+		logger: opts.InstrumentOptions().Logger(),
+		nowFn:  time.Now,
+	}
+}
+
+// SetConsumerServiceWriter sets the writer for the dead-letter consumer
+// service and the number of shards of the topic, nil means the topic has no
+// dead-letter consumer service.
+func (w *deadLetterWriter) SetConsumerServiceWriter(csw consumerServiceWriter, numShards uint32) {
+	w.Lock()
+	w.csw = csw
+	w.numShards = numShards
+	w.Unlock()
+}
+
+// DeadLetterFn returns the function to route the messages that could not be
+// consumed by the given consumer service.
+func (w *deadLetterWriter) DeadLetterFn(source services.ServiceID) deadLetterFn {
+	return func(m *message, reason string) bool {
+		return w.write(source, m, reason)
+	}
+}
+
+func (w *deadLetterWriter) write(source services.ServiceID, m *message, reason string) bool {
+	// NB: Holding the read lock while writing makes sure no more dead letters
+	// are written to a dead-letter consumer service writer once it's replaced.
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.csw == nil {
+		return false
+	}
+	m.IncReads()
+	if m.IsDroppedOrConsumed() {
+		m.DecReads()
+		return false
+	}
+	pb := msgpb.DeadLetter{
+		Value:           m.Bytes(),
+		Shard:           m.Shard(),
+		ConsumerService: topic.ServiceIDToProto(source),
+		Reason:          reason,
+		Attempts:        uint32(m.WriteTimes()),
+		InitNanos:       m.InitNanos(),
+		DeadLetterNanos: w.nowFn().UnixNano(),
+	}
+	b, err := pb.Marshal()
+	if err != nil {
+		m.DecReads()
+		w.logger.Errorf("could not marshal dead letter from %s: %v", source.String(), err)
+		return false
+	}
+	rm := producer.NewRefCountedMessage(newDeadLetterMessage(m.RefCountedMessage, b), nil)
+	m.DecReads()
+
+	// NB: Need to inc ref here in case the dead-letter consumer service
+	// filters out the message, in which case it's finalized right away.
+	// NB: The message could be from a consumer service writer being drained
+	// after the number of shards of the topic changed, in which case its
+	// shard could be out of range for the dead-letter consumer service.
+	rm.IncRef()
+	w.csw.Write(rm.Shard()%w.numShards, rm)
+	rm.DecRef()
+	return true
+}
+
+// deadLetterMessage is a dead letter produced to the dead-letter consumer
+// service, it holds a reference on the original message until it's consumed
+// so the original message is not released from the buffer before that.
+type deadLetterMessage struct {
+	rm    *producer.RefCountedMessage
+	shard uint32
+	b     []byte
+}
+
+func newDeadLetterMessage(rm *producer.RefCountedMessage, b []byte) producer.Message {
+	rm.IncRef()
+	return &deadLetterMessage{
+		rm:    rm,
+		shard: rm.Shard(),
+		b:     b,
+	}
+}
+
+func (m *deadLetterMessage) Shard() uint32 {
+	return m.shard
+}
+
+func (m *deadLetterMessage) Bytes() []byte {
+	return m.b
+}
+
+func (m *deadLetterMessage) Size() int {
+	return len(m.b)
+}
+
+func (m *deadLetterMessage) Finalize(producer.FinalizeReason) {
+	m.rm.DecRef()
+}
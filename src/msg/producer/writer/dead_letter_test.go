@@ -0,0 +1,92 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package writer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/msg/generated/proto/msgpb"
+	"github.com/m3db/m3/src/msg/producer"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterWriter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Unix(0, 1000)
+	w := newDeadLetterWriter(testOptions())
+	w.nowFn = func() time.Time { return now }
+
+	sid := services.NewServiceID().SetName("s").SetEnvironment("env").SetZone("zone")
+	fn := w.DeadLetterFn(sid)
+
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Size().Return(3)
+	mm.EXPECT().Bytes().Return([]byte("foo")).AnyTimes()
+	mm.EXPECT().Shard().Return(uint32(3)).AnyTimes()
+	rm := producer.NewRefCountedMessage(mm, nil)
+	rm.IncRef()
+
+	m := newMessage()
+	m.Set(metadata{shard: 3, id: 1}, rm, 500)
+	m.IncWriteTimes()
+	m.IncWriteTimes()
+
+	// No dead-letter consumer service.
+	require.False(t, fn(m, "bad tags"))
+
+	var dl *producer.RefCountedMessage
+	csw := NewMockconsumerServiceWriter(ctrl)
+	csw.EXPECT().Write(uint32(3), gomock.Any()).Do(func(_ uint32, rm *producer.RefCountedMessage) {
+		rm.IncRef()
+		dl = rm
+	})
+	w.SetConsumerServiceWriter(csw, 4)
+	require.True(t, fn(m, "bad tags"))
+
+	var pb msgpb.DeadLetter
+	require.NoError(t, pb.Unmarshal(dl.Bytes()))
+	require.Equal(t, []byte("foo"), pb.Value)
+	require.Equal(t, uint32(3), pb.Shard)
+	require.Equal(t, uint32(3), dl.Shard())
+	require.Equal(t, "s", pb.ConsumerService.Name)
+	require.Equal(t, "env", pb.ConsumerService.Environment)
+	require.Equal(t, "zone", pb.ConsumerService.Zone)
+	require.Equal(t, "bad tags", pb.Reason)
+	require.Equal(t, uint32(2), pb.Attempts)
+	require.Equal(t, int64(500), pb.InitNanos)
+	require.Equal(t, now.UnixNano(), pb.DeadLetterNanos)
+
+	// The original message is held until the dead letter is consumed.
+	m.Ack()
+	require.False(t, rm.IsDroppedOrConsumed())
+	mm.EXPECT().Finalize(producer.Consumed)
+	dl.DecRef()
+	require.True(t, rm.IsDroppedOrConsumed())
+
+	w.SetConsumerServiceWriter(nil, 0)
+	require.False(t, fn(m, "bad tags"))
+}
@@ -49,6 +49,10 @@ type messageWriter interface {
 	// Ack acknowledges the metadata.
 	Ack(meta metadata) bool
 
+	// Nack negatively acknowledges the metadata, the message will not be
+	// retried and will be routed to the dead-letter consumer service.
+	Nack(meta metadata, reason string) bool
+
 	// Init initialize the message writer.
 	Init()
 
@@ -56,6 +60,10 @@ type messageWriter interface {
 	// It should block until all buffered messages have been acknowledged.
 	Close()
 
+	// Drain hands the unacknowledged messages over to the drain function and
+	// marks them as consumed for this message writer.
+	Drain(fn drainFn)
+
 	// AddConsumerWriter adds a consumer writer.
 	AddConsumerWriter(cw consumerWriter)
 
@@ -98,6 +106,10 @@ type messageWriterMetrics struct {
 	messageClosed            tally.Counter
 	messageDroppedBufferFull tally.Counter
 	messageDroppedTTLExpire  tally.Counter
+	messageDroppedDeadLetter tally.Counter
+	messageDeadLettered      tally.Counter
+	messageNacked            tally.Counter
+	messageDrained           tally.Counter
 	messageRetry             tally.Counter
 	messageConsumeLatency    tally.Timer
 	messageWriteDelay        tally.Timer
@@ -132,6 +144,12 @@ func newMessageWriterMetrics(
 		messageDroppedTTLExpire: scope.Tagged(
 			map[string]string{"reason": "ttl-expire"},
 		).Counter("message-dropped"),
+		messageDroppedDeadLetter: scope.Tagged(
+			map[string]string{"reason": "no-dead-letter"},
+		).Counter("message-dropped"),
+		messageDeadLettered:   scope.Counter("message-dead-lettered"),
+		messageNacked:         scope.Counter("message-nacked"),
+		messageDrained:        scope.Counter("message-drained"),
 		messageRetry:          scope.Counter("message-retry"),
 		messageConsumeLatency: instrument.MustCreateSampledTimer(scope.Timer("message-consume-latency"), samplingRate),
 		messageWriteDelay:     instrument.MustCreateSampledTimer(scope.Timer("message-write-delay"), samplingRate),
@@ -149,6 +167,8 @@ type messageWriterImpl struct {
 	retryOpts         retry.Options
 	r                 *rand.Rand
 	encoder           proto.Encoder
+	deadLetterFn      deadLetterFn
+	maxRetries        int
 
 	msgID            uint64
 	queue            *list.List
@@ -174,6 +194,7 @@ func newMessageWriter(
 	mPool messagePool,
 	opts Options,
 	m messageWriterMetrics,
+	deadLetterFn deadLetterFn,
 ) messageWriter {
 	if opts == nil {
 		opts = NewOptions()
@@ -186,6 +207,8 @@ func newMessageWriter(
 		retryOpts:         opts.MessageRetryOptions(),
 		r:                 rand.New(rand.NewSource(nowFn().UnixNano())),
 		encoder:           proto.NewEncoder(opts.EncoderOptions()),
+		deadLetterFn:      deadLetterFn,
+		maxRetries:        opts.MaxMessageRetries(),
 		msgID:             0,
 		queue:             list.New(),
 		acks:              newAckHelper(opts.InitialAckMapSize()),
@@ -307,6 +330,56 @@ func (w *messageWriterImpl) Ack(meta metadata) bool {
 	return false
 }
 
+func (w *messageWriterImpl) Nack(meta metadata, reason string) bool {
+	// NB: Holding the lock prevents the message from being removed from the
+	// queue and returned to the pool while it's being routed.
+	w.Lock()
+	m, ok := w.acks.take(meta)
+	if !ok {
+		w.Unlock()
+		// Nacking a message that is already acked or nacked, which is ok.
+		return false
+	}
+	w.m.messageNacked.Inc(1)
+	w.deadLetterWithLock(m, reason)
+	w.Unlock()
+	return true
+}
+
+// deadLetterWithLock routes the message to the dead-letter consumer service
+// and marks it as consumed for this message writer, the message must have been
+// taken out of the ack map so it could only be routed once.
+func (w *messageWriterImpl) deadLetterWithLock(m *message, reason string) {
+	if w.deadLetterFn != nil && w.deadLetterFn(m, reason) {
+		w.m.messageDeadLettered.Inc(1)
+	} else {
+		w.m.messageDroppedDeadLetter.Inc(1)
+	}
+	m.Ack()
+}
+
+func (w *messageWriterImpl) Drain(fn drainFn) {
+	// NB: Holding the lock prevents the messages from being removed from the
+	// queue and returned to the pool while they are being drained, the acked
+	// messages will be removed from the queue in the next scan.
+	w.Lock()
+	for e := w.queue.Front(); e != nil; e = e.Next() {
+		m := e.Value.(*message)
+		if _, ok := w.acks.take(m.Metadata()); !ok {
+			// The message was already acked or nacked.
+			continue
+		}
+		m.IncReads()
+		if !m.IsDroppedOrConsumed() {
+			fn(m.RefCountedMessage)
+			w.m.messageDrained.Inc(1)
+		}
+		m.DecReads()
+		m.Ack()
+	}
+	w.Unlock()
+}
+
 func (w *messageWriterImpl) Init() {
 	w.wg.Add(1)
 	go func() {
@@ -471,6 +544,18 @@ func (w *messageWriterImpl) scanBatchWithLock(
 			w.m.messageDroppedBufferFull.Inc(1)
 			continue
 		}
+		// The dead-letter consumer service does not have a dead-letter
+		// function, the messages for it are retried until consumed.
+		if w.deadLetterFn != nil && w.maxRetries > 0 && m.WriteTimes() > w.maxRetries {
+			// The message has used up its retry budget, stop retrying it.
+			// There is a chance the message was acked or nacked right before
+			// it's taken, in which case just remove it from the queue.
+			if _, ok := w.acks.take(m.Metadata()); ok {
+				w.deadLetterWithLock(m, retryBudgetExhaustedReason)
+			}
+			w.removeFromQueueWithLock(e, m)
+			continue
+		}
 		m.IncWriteTimes()
 		writeTimes := m.WriteTimes()
 		m.SetRetryAtNanos(w.nextRetryNanos(writeTimes, nowNanos))
@@ -642,6 +727,17 @@ func (a *acks) remove(meta metadata) {
 	a.Unlock()
 }
 
+// take removes the message from the ack map without acking it.
+func (a *acks) take(meta metadata) (*message, bool) {
+	a.Lock()
+	m, ok := a.ackMap[meta]
+	if ok {
+		delete(a.ackMap, meta)
+	}
+	a.Unlock()
+	return m, ok
+}
+
 func (a *acks) ack(meta metadata) (bool, int64) {
 	a.Lock()
 	m, ok := a.ackMap[meta]
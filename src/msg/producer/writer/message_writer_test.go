@@ -109,7 +109,7 @@ func TestMessageWriterWithPooling(t *testing.T) {
 		wg.Done()
 	}()
 
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil).(*messageWriterImpl)
 	require.Equal(t, 200, int(w.ReplicatedShardID()))
 	w.Init()
 
@@ -192,7 +192,7 @@ func TestMessageWriterWithoutPooling(t *testing.T) {
 		wg.Done()
 	}()
 
-	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics(), nil).(*messageWriterImpl)
 	require.Equal(t, 200, int(w.ReplicatedShardID()))
 	w.Init()
 
@@ -265,7 +265,7 @@ func TestMessageWriterRetryWithoutPooling(t *testing.T) {
 
 	addr := lis.Addr().String()
 	opts := testOptions()
-	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics(), nil).(*messageWriterImpl)
 	w.Init()
 	defer w.Close()
 
@@ -325,7 +325,7 @@ func TestMessageWriterRetryWithPooling(t *testing.T) {
 
 	addr := lis.Addr().String()
 	opts := testOptions()
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil).(*messageWriterImpl)
 	w.Init()
 	defer w.Close()
 
@@ -385,7 +385,7 @@ func TestMessageWriterCleanupDroppedMessage(t *testing.T) {
 	defer leaktest.Check(t)()
 
 	opts := testOptions()
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics())
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil)
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -427,7 +427,7 @@ func TestMessageWriterCleanupAckedMessage(t *testing.T) {
 	defer leaktest.Check(t)()
 
 	opts := testOptions()
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil).(*messageWriterImpl)
 	w.Init()
 	defer w.Close()
 
@@ -482,7 +482,7 @@ func TestMessageWriterCutoverCutoff(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	w := newMessageWriter(200, testMessagePool(testOptions()), nil, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(testOptions()), nil, testMessageWriterMetrics(), nil).(*messageWriterImpl)
 	now := time.Now()
 	w.nowFn = func() time.Time { return now }
 	require.True(t, w.isValidWriteWithLock(now.UnixNano()))
@@ -510,7 +510,7 @@ func TestMessageWriterKeepNewWritesInOrderInFrontOfTheQueue(t *testing.T) {
 	opts := testOptions().SetMessageRetryOptions(
 		retry.NewOptions().SetInitialBackoff(2 * time.Nanosecond).SetMaxBackoff(5 * time.Nanosecond),
 	)
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil).(*messageWriterImpl)
 
 	now := time.Now()
 	w.nowFn = func() time.Time { return now }
@@ -554,7 +554,7 @@ func TestMessageWriterRetryIterateBatchFullScan(t *testing.T) {
 	opts := testOptions().SetMessageQueueScanBatchSize(retryBatchSize).SetMessageRetryOptions(
 		retry.NewOptions().SetInitialBackoff(2 * time.Nanosecond).SetMaxBackoff(5 * time.Nanosecond),
 	)
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil).(*messageWriterImpl)
 
 	now := time.Now()
 	w.nowFn = func() time.Time { return now }
@@ -618,7 +618,7 @@ func TestMessageWriterRetryIterateBatchFullScanWithMessageTTL(t *testing.T) {
 	opts := testOptions().SetMessageQueueScanBatchSize(retryBatchSize).SetMessageRetryOptions(
 		retry.NewOptions().SetInitialBackoff(2 * time.Nanosecond).SetMaxBackoff(5 * time.Nanosecond),
 	)
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil).(*messageWriterImpl)
 
 	now := time.Now()
 	w.nowFn = func() time.Time { return now }
@@ -679,7 +679,7 @@ func TestMessageWriterRetryIterateBatchNotFullScan(t *testing.T) {
 	opts := testOptions().SetMessageQueueScanBatchSize(retryBatchSize).SetMessageRetryOptions(
 		retry.NewOptions().SetInitialBackoff(2 * time.Nanosecond).SetMaxBackoff(5 * time.Nanosecond),
 	)
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil).(*messageWriterImpl)
 
 	now := time.Now()
 	w.nowFn = func() time.Time { return now }
@@ -749,7 +749,7 @@ func TestNextRetryNanos(t *testing.T) {
 	opts := testOptions().SetMessageRetryOptions(
 		retry.NewOptions().SetInitialBackoff(backoffDuration).SetMaxBackoff(2 * backoffDuration).SetJitter(true),
 	)
-	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics(), nil).(*messageWriterImpl)
 
 	nowNanos := time.Now().UnixNano()
 	m := newMessage()
@@ -772,7 +772,7 @@ func TestMessageWriterCloseCleanupAllMessages(t *testing.T) {
 	defer leaktest.Check(t)()
 
 	opts := testOptions()
-	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics(), nil).(*messageWriterImpl)
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -797,7 +797,7 @@ func TestMessageWriterQueueFullScanOnWriteErrors(t *testing.T) {
 	defer ctrl.Finish()
 
 	opts := testOptions().SetMessageQueueScanBatchSize(1)
-	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics(), nil).(*messageWriterImpl)
 	w.AddConsumerWriter(newConsumerWriter("bad", nil, opts, testConsumerWriterMetrics()))
 
 	mm1 := producer.NewMockMessage(ctrl)
@@ -820,6 +820,96 @@ func TestMessageWriterQueueFullScanOnWriteErrors(t *testing.T) {
 	require.Equal(t, 1, w.queue.Len())
 }
 
+func TestMessageWriterRetryBudgetDeadLetter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := testOptions().SetMaxMessageRetries(1).SetMessageRetryOptions(
+		retry.NewOptions().SetInitialBackoff(2 * time.Nanosecond).SetMaxBackoff(5 * time.Nanosecond),
+	)
+	var reasons []string
+	dlFn := func(m *message, reason string) bool {
+		require.Equal(t, 2, m.WriteTimes())
+		reasons = append(reasons, reason)
+		return true
+	}
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), dlFn).(*messageWriterImpl)
+
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Size().Return(3)
+	mm.EXPECT().Bytes().Return([]byte("foo")).AnyTimes()
+	w.Write(producer.NewRefCountedMessage(mm, nil))
+
+	nowNanos := time.Now().UnixNano() + int64(time.Hour)
+	_, toBeRetried := w.scanBatchWithLock(w.queue.Front(), nowNanos, 10, true)
+	require.Equal(t, 1, len(toBeRetried))
+	_, toBeRetried = w.scanBatchWithLock(w.queue.Front(), nowNanos+int64(time.Hour), 10, true)
+	require.Equal(t, 1, len(toBeRetried))
+	require.Empty(t, reasons)
+
+	mm.EXPECT().Finalize(producer.Consumed)
+	_, toBeRetried = w.scanBatchWithLock(w.queue.Front(), nowNanos+2*int64(time.Hour), 10, true)
+	require.Equal(t, 0, len(toBeRetried))
+	require.Equal(t, 0, w.queue.Len())
+	require.True(t, isEmptyWithLock(w.acks))
+	require.Equal(t, []string{retryBudgetExhaustedReason}, reasons)
+}
+
+func TestMessageWriterRetryBudgetWithoutDeadLetterFn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := testOptions().SetMaxMessageRetries(1).SetMessageRetryOptions(
+		retry.NewOptions().SetInitialBackoff(2 * time.Nanosecond).SetMaxBackoff(5 * time.Nanosecond),
+	)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil).(*messageWriterImpl)
+
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Size().Return(3)
+	mm.EXPECT().Bytes().Return([]byte("foo")).AnyTimes()
+	w.Write(producer.NewRefCountedMessage(mm, nil))
+
+	nowNanos := time.Now().UnixNano() + int64(time.Hour)
+	for i := 0; i < 5; i++ {
+		_, toBeRetried := w.scanBatchWithLock(w.queue.Front(), nowNanos+int64(i)*int64(time.Hour), 10, true)
+		require.Equal(t, 1, len(toBeRetried))
+	}
+	require.Equal(t, 1, w.queue.Len())
+
+	mm.EXPECT().Finalize(producer.Consumed)
+	require.True(t, w.Ack(metadata{shard: 200, id: 1}))
+}
+
+func TestMessageWriterNack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := testOptions()
+	var reasons []string
+	dlFn := func(m *message, reason string) bool {
+		reasons = append(reasons, reason)
+		return false
+	}
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), dlFn).(*messageWriterImpl)
+
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Size().Return(3)
+	mm.EXPECT().Bytes().Return([]byte("foo")).AnyTimes()
+	w.Write(producer.NewRefCountedMessage(mm, nil))
+	require.Equal(t, 1, w.queue.Len())
+
+	mm.EXPECT().Finalize(producer.Consumed)
+	require.True(t, w.Nack(metadata{shard: 200, id: 1}, "bad tags"))
+	require.False(t, w.Nack(metadata{shard: 200, id: 1}, "bad tags"))
+	require.False(t, w.Ack(metadata{shard: 200, id: 1}))
+	require.Equal(t, []string{"bad tags"}, reasons)
+	require.True(t, isEmptyWithLock(w.acks))
+
+	_, toBeRetried := w.scanBatchWithLock(w.queue.Front(), time.Now().UnixNano(), 10, true)
+	require.Equal(t, 0, len(toBeRetried))
+	require.Equal(t, 0, w.queue.Len())
+}
+
 func isEmptyWithLock(h *acks) bool {
 	h.Lock()
 	defer h.Unlock()
@@ -846,3 +936,44 @@ func validateMessages(t *testing.T, msgs []*producer.RefCountedMessage, w *messa
 	w.RUnlock()
 	require.Equal(t, idx, len(msgs))
 }
+
+func TestMessageWriterDrain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := testOptions()
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil).(*messageWriterImpl)
+
+	mm1 := producer.NewMockMessage(ctrl)
+	mm1.EXPECT().Size().Return(3)
+	mm1.EXPECT().Bytes().Return([]byte("foo")).AnyTimes()
+	w.Write(producer.NewRefCountedMessage(mm1, nil))
+
+	mm2 := producer.NewMockMessage(ctrl)
+	mm2.EXPECT().Size().Return(3)
+	mm2.EXPECT().Bytes().Return([]byte("bar")).AnyTimes()
+	rm2 := producer.NewRefCountedMessage(mm2, nil)
+	w.Write(rm2)
+	require.Equal(t, 2, w.queue.Len())
+
+	mm1.EXPECT().Finalize(producer.Consumed)
+	require.True(t, w.Ack(metadata{shard: 200, id: 1}))
+
+	// Only the unacked message is drained, it's held by the drain function.
+	var drained []*producer.RefCountedMessage
+	w.Drain(func(rm *producer.RefCountedMessage) {
+		rm.IncRef()
+		drained = append(drained, rm)
+	})
+	require.Equal(t, []*producer.RefCountedMessage{rm2}, drained)
+	require.True(t, isEmptyWithLock(w.acks))
+	require.False(t, rm2.IsDroppedOrConsumed())
+
+	// The drained messages are removed from the queue in the next scan.
+	_, toBeRetried := w.scanBatchWithLock(w.queue.Front(), time.Now().UnixNano(), 10, true)
+	require.Equal(t, 0, len(toBeRetried))
+	require.Equal(t, 0, w.queue.Len())
+
+	mm2.EXPECT().Finalize(producer.Consumed)
+	rm2.DecRef()
+}
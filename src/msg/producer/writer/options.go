@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/msg/producer"
 	"github.com/m3db/m3/src/msg/protocol/proto"
 	"github.com/m3db/m3/src/msg/topic"
 	"github.com/m3db/m3x/instrument"
@@ -224,6 +225,10 @@ func (opts *connectionOptions) SetInstrumentOptions(value instrument.Options) Co
 	return &o
 }
 
+// ShardFn computes the shard of a message for a topic with the given
+// number of shards.
+type ShardFn func(m producer.Message, numShards uint32) uint32
+
 // Options configs the writer.
 type Options interface {
 	// TopicName returns the topic name.
@@ -244,6 +249,16 @@ type Options interface {
 	// SetTopicWatchInitTimeout sets the timeout for topic watch initialization.
 	SetTopicWatchInitTimeout(value time.Duration) Options
 
+	// ShardFn returns the function to compute the shard of the messages, nil
+	// means the shards of the messages are used as is and the number of
+	// shards of the topic can not be changed.
+	ShardFn() ShardFn
+
+	// SetShardFn sets the function to compute the shard of the messages, nil
+	// means the shards of the messages are used as is and the number of
+	// shards of the topic can not be changed.
+	SetShardFn(value ShardFn) Options
+
 	// ServiceDiscovery returns the client to service discovery service.
 	ServiceDiscovery() services.Services
 
@@ -268,6 +283,16 @@ type Options interface {
 	// MessageRetryOptions returns the retry options for message retry.
 	SetMessageRetryOptions(value retry.Options) Options
 
+	// MaxMessageRetries returns the max number of retries for a message
+	// before it is routed to the dead-letter consumer service of the topic,
+	// zero means the message will be retried until it is consumed.
+	MaxMessageRetries() int
+
+	// SetMaxMessageRetries sets the max number of retries for a message
+	// before it is routed to the dead-letter consumer service of the topic,
+	// zero means the message will be retried until it is consumed.
+	SetMaxMessageRetries(value int) Options
+
 	// MessageQueueNewWritesScanInterval returns the interval between scanning
 	// message queue for new writes.
 	MessageQueueNewWritesScanInterval() time.Duration
@@ -337,10 +362,12 @@ type writerOptions struct {
 	topicName                         string
 	topicService                      topic.Service
 	topicWatchInitTimeout             time.Duration
+	shardFn                           ShardFn
 	services                          services.Services
 	placementWatchInitTimeout         time.Duration
 	messagePoolOptions                pool.ObjectPoolOptions
 	messageRetryOpts                  retry.Options
+	maxMessageRetries                 int
 	messageQueueNewWritesScanInterval time.Duration
 	messageQueueFullScanInterval      time.Duration
 	messageQueueScanBatchSize         int
@@ -402,6 +429,16 @@ func (opts *writerOptions) SetTopicWatchInitTimeout(value time.Duration) Options
 	return &o
 }
 
+func (opts *writerOptions) ShardFn() ShardFn {
+	return opts.shardFn
+}
+
+func (opts *writerOptions) SetShardFn(value ShardFn) Options {
+	o := *opts
+	o.shardFn = value
+	return &o
+}
+
 func (opts *writerOptions) ServiceDiscovery() services.Services {
 	return opts.services
 }
@@ -442,6 +479,16 @@ func (opts *writerOptions) SetMessageRetryOptions(value retry.Options) Options {
 	return &o
 }
 
+func (opts *writerOptions) MaxMessageRetries() int {
+	return opts.maxMessageRetries
+}
+
+func (opts *writerOptions) SetMaxMessageRetries(value int) Options {
+	o := *opts
+	o.maxMessageRetries = value
+	return &o
+}
+
 func (opts *writerOptions) MessageQueueNewWritesScanInterval() time.Duration {
 	return opts.messageQueueNewWritesScanInterval
 }
@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/msg/producer"
 	"github.com/m3db/m3x/instrument"
 
 	"github.com/stretchr/testify/require"
@@ -38,6 +39,10 @@ func TestOptions(t *testing.T) {
 	require.Equal(t, defaultTopicWatchInitTimeout, opts.TopicWatchInitTimeout())
 	require.Equal(t, time.Second, opts.SetTopicWatchInitTimeout(time.Second).TopicWatchInitTimeout())
 
+	require.Nil(t, opts.ShardFn())
+	shardFn := func(m producer.Message, numShards uint32) uint32 { return m.Shard() % numShards }
+	require.NotNil(t, opts.SetShardFn(shardFn).ShardFn())
+
 	require.Equal(t, defaultPlacementWatchInitTimeout, opts.PlacementWatchInitTimeout())
 	require.Equal(t, time.Second, opts.SetPlacementWatchInitTimeout(time.Second).PlacementWatchInitTimeout())
 
@@ -49,6 +54,9 @@ func TestOptions(t *testing.T) {
 
 	require.Nil(t, opts.MessagePoolOptions())
 
+	require.Equal(t, 0, opts.MaxMessageRetries())
+	require.Equal(t, 10, opts.SetMaxMessageRetries(10).MaxMessageRetries())
+
 	require.Equal(t, defaultInitialAckMapSize, opts.InitialAckMapSize())
 	require.Equal(t, 123, opts.SetInitialAckMapSize(123).InitialAckMapSize())
 
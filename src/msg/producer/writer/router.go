@@ -29,6 +29,9 @@ type ackRouter interface {
 	// Ack acks the metadata.
 	Ack(ack metadata) error
 
+	// Nack nacks the metadata with the reason.
+	Nack(nack metadata, reason string) error
+
 	// Register registers a message writer.
 	Register(replicatedShardID uint64, mw messageWriter)
 
@@ -60,6 +63,18 @@ func (r *router) Ack(meta metadata) error {
 	return nil
 }
 
+func (r *router) Nack(meta metadata, reason string) error {
+	r.RLock()
+	mw, ok := r.messageWriters[meta.shard]
+	r.RUnlock()
+	if !ok {
+		// Unexpected.
+		return fmt.Errorf("can't find shard %v", meta.shard)
+	}
+	mw.Nack(meta, reason)
+	return nil
+}
+
 func (r *router) Register(replicatedShardID uint64, mw messageWriter) {
 	r.Lock()
 	r.messageWriters[replicatedShardID] = mw
@@ -46,6 +46,9 @@ type shardWriter interface {
 	// Close closes the shard writer.
 	Close()
 
+	// Drain hands the unacknowledged messages over to the drain function.
+	Drain(fn drainFn)
+
 	// QueueSize returns the number of messages queued for the shard.
 	QueueSize() int
 }
@@ -62,9 +65,10 @@ func newSharedShardWriter(
 	mPool messagePool,
 	opts Options,
 	m messageWriterMetrics,
+	deadLetterFn deadLetterFn,
 ) shardWriter {
 	replicatedShardID := uint64(shard)
-	mw := newMessageWriter(replicatedShardID, mPool, opts, m)
+	mw := newMessageWriter(replicatedShardID, mPool, opts, m, deadLetterFn)
 	mw.Init()
 	router.Register(replicatedShardID, mw)
 	return &sharedShardWriter{
@@ -111,6 +115,10 @@ func (w *sharedShardWriter) Close() {
 	w.mw.Close()
 }
 
+func (w *sharedShardWriter) Drain(fn drainFn) {
+	w.mw.Drain(fn)
+}
+
 func (w *sharedShardWriter) QueueSize() int {
 	return w.mw.QueueSize()
 }
@@ -130,6 +138,7 @@ type replicatedShardWriter struct {
 	opts           Options
 	logger         log.Logger
 	m              messageWriterMetrics
+	deadLetterFn   deadLetterFn
 
 	messageWriters  map[string]messageWriter
 	messageTTLNanos int64
@@ -143,6 +152,7 @@ func newReplicatedShardWriter(
 	mPool messagePool,
 	opts Options,
 	m messageWriterMetrics,
+	deadLetterFn deadLetterFn,
 ) shardWriter {
 	return &replicatedShardWriter{
 		shard:          shard,
@@ -155,6 +165,7 @@ func newReplicatedShardWriter(
 		messageWriters: make(map[string]messageWriter),
 		isClosed:       false,
 		m:              m,
+		deadLetterFn:   deadLetterFn,
 	}
 }
 
@@ -216,7 +227,7 @@ func (w *replicatedShardWriter) UpdateInstances(
 	for instance, cw := range toBeAdded {
 		replicatedShardID := uint64(w.replicaID*w.numberOfShards + w.shard)
 		w.replicaID++
-		mw := newMessageWriter(replicatedShardID, w.mPool, w.opts, w.m)
+		mw := newMessageWriter(replicatedShardID, w.mPool, w.opts, w.m, w.deadLetterFn)
 		mw.AddConsumerWriter(cw)
 		w.updateCutoverCutoffNanos(mw, instance)
 		mw.Init()
@@ -269,6 +280,14 @@ func (w *replicatedShardWriter) Close() {
 	}
 }
 
+func (w *replicatedShardWriter) Drain(fn drainFn) {
+	w.RLock()
+	for _, mw := range w.messageWriters {
+		mw.Drain(fn)
+	}
+	w.RUnlock()
+}
+
 func (w *replicatedShardWriter) QueueSize() int {
 	w.RLock()
 	mws := w.messageWriters
@@ -44,6 +44,8 @@ type writerMetrics struct {
 	topicUpdateError    tally.Counter
 	invalidTopicUpdate  tally.Counter
 	invalidShard        tally.Counter
+	numShardsUpdate     tally.Counter
+	messageResharded    tally.Counter
 	numConsumerServices tally.Gauge
 }
 
@@ -54,6 +56,8 @@ func newWriterMetrics(scope tally.Scope) writerMetrics {
 		invalidTopicUpdate: scope.Counter("invalid-topic"),
 		invalidShard: scope.Tagged(map[string]string{"reason": "invalid-shard"}).
 			Counter("invalid-write"),
+		numShardsUpdate:     scope.Counter("num-shards-update"),
+		messageResharded:    scope.Counter("message-resharded"),
 		numConsumerServices: scope.Gauge("num-consumer-services"),
 	}
 }
@@ -69,8 +73,12 @@ type writer struct {
 
 	value                  watch.Value
 	initType               initType
+	shardFn                ShardFn
 	numShards              uint32
+	consumerServices       map[string]topic.ConsumerService
 	consumerServiceWriters map[string]consumerServiceWriter
+	deadLetterKey          string
+	deadLetterWriter       *deadLetterWriter
 	filterRegistry         map[string]producer.FilterFunc
 	isClosed               bool
 	m                      writerMetrics
@@ -86,7 +94,10 @@ func NewWriter(opts Options) producer.Writer {
 		opts:                   opts,
 		logger:                 opts.InstrumentOptions().Logger(),
 		initType:               failOnError,
+		shardFn:                opts.ShardFn(),
+		consumerServices:       make(map[string]topic.ConsumerService),
 		consumerServiceWriters: make(map[string]consumerServiceWriter),
+		deadLetterWriter:       newDeadLetterWriter(opts),
 		filterRegistry:         make(map[string]producer.FilterFunc),
 		isClosed:               false,
 		m:                      newWriterMetrics(opts.InstrumentOptions().MetricsScope()),
@@ -103,6 +114,12 @@ func (w *writer) Write(rm *producer.RefCountedMessage) error {
 		return errWriterClosed
 	}
 	shard := rm.Shard()
+	if w.shardFn != nil && w.numShards > 0 {
+		if newShard := w.shardFn(rm.Message, w.numShards); newShard != shard {
+			w.m.messageResharded.Inc(1)
+			shard = newShard
+		}
+	}
 	if shard >= w.numShards {
 		w.m.invalidShard.Inc(1)
 		rm.Drop()
@@ -112,14 +129,46 @@ func (w *writer) Write(rm *producer.RefCountedMessage) error {
 	// NB(cw): Need to inc ref here in case a consumer service
 	// writes the message too fast and close the message.
 	rm.IncRef()
-	for _, csw := range w.consumerServiceWriters {
-		csw.Write(rm)
+	for key, csw := range w.consumerServiceWriters {
+		if key == w.deadLetterKey {
+			// The dead-letter consumer service only receives the messages
+			// that could not be consumed by the other consumer services.
+			continue
+		}
+		csw.Write(shard, rm)
 	}
 	rm.DecRef()
 	w.RUnlock()
 	return nil
 }
 
+// drainFn returns the function to take over the messages drained from a
+// replaced consumer service writer, the messages are written to the current
+// consumer service writer of the same consumer service under the current
+// number of shards.
+func (w *writer) drainFn(key string) drainFn {
+	return func(rm *producer.RefCountedMessage) {
+		w.RLock()
+		defer w.RUnlock()
+
+		csw, ok := w.consumerServiceWriters[key]
+		if !ok || w.isClosed {
+			// The consumer service was removed or the writer was closed.
+			return
+		}
+		// NB: The shards of the dead letters do not matter.
+		shard := rm.Shard()
+		newShard := shard % w.numShards
+		if w.shardFn != nil && key != w.deadLetterKey {
+			newShard = w.shardFn(rm.Message, w.numShards)
+		}
+		if newShard != shard {
+			w.m.messageResharded.Inc(1)
+		}
+		csw.Write(newShard, rm)
+	}
+}
+
 func (w *writer) Init() error {
 	newUpdatableFn := func() (watch.Updatable, error) {
 		return w.ts.Watch(w.topic)
@@ -150,33 +199,52 @@ func (w *writer) process(update interface{}) error {
 	if err := t.Validate(); err != nil {
 		return err
 	}
-	// We don't allow changing number of shards for topics, it will be
-	// prevented on topic service side, but also being defensive here as well.
-	if w.numShards != 0 && w.numShards != t.NumberOfShards() {
+	// The number of shards of the topic can only be changed when the shards
+	// of the messages can be computed by the writer.
+	numShardsChanged := w.numShards != 0 && w.numShards != t.NumberOfShards()
+	if numShardsChanged && w.shardFn == nil {
 		w.m.topicUpdateError.Inc(1)
 		return fmt.Errorf("invalid topic update with %d shards, expecting %d", t.NumberOfShards(), w.numShards)
 	}
 	var (
 		iOpts                     = w.opts.InstrumentOptions()
+		newConsumerServices       = make(map[string]topic.ConsumerService, len(t.ConsumerServices()))
 		newConsumerServiceWriters = make(map[string]consumerServiceWriter, len(t.ConsumerServices()))
+		newDeadLetterKey          string
+		toBeDrained               = make(map[string]consumerServiceWriter)
 		toBeClosed                []consumerServiceWriter
 		multiErr                  xerrors.MultiError
 	)
 	for _, cs := range t.ConsumerServices() {
 		key := cs.ServiceID().String()
+		newConsumerServices[key] = cs
+		if cs.DeadLetter() {
+			newDeadLetterKey = key
+		}
 		csw, ok := w.consumerServiceWriters[key]
-		if ok {
+		if ok && !numShardsChanged && w.canReuse(key, cs) {
 			csw.SetMessageTTLNanos(cs.MessageTTLNanos())
 			newConsumerServiceWriters[key] = csw
 			continue
 		}
+		// NB: If the consumer service was re-added with a different role, the
+		// existing consumer service writer can not be reused and will be closed.
+		// If the number of shards or the consumption type changed, the existing
+		// consumer service writer will be drained into the new one.
+
+		// Messages that could not be consumed by the dead-letter consumer
+		// service are retried until consumed.
+		var dlFn deadLetterFn
+		if !cs.DeadLetter() {
+			dlFn = w.deadLetterWriter.DeadLetterFn(cs.ServiceID())
+		}
 		scope := iOpts.MetricsScope().Tagged(map[string]string{
 			"consumer-service-name": cs.ServiceID().Name(),
 			"consumer-service-zone": cs.ServiceID().Zone(),
 			"consumer-service-env":  cs.ServiceID().Environment(),
 			"consumption-type":      cs.ConsumptionType().String(),
 		})
-		csw, err := newConsumerServiceWriter(cs, t.NumberOfShards(), w.opts.SetInstrumentOptions(iOpts.SetMetricsScope(scope)))
+		csw, err := newConsumerServiceWriter(cs, t.NumberOfShards(), dlFn, w.opts.SetInstrumentOptions(iOpts.SetMetricsScope(scope)))
 		if err != nil {
 			w.logger.Errorf("could not create consumer service writer for %s: %v", cs.String(), err)
 			multiErr = multiErr.Add(err)
@@ -194,9 +262,15 @@ func (w *writer) process(update interface{}) error {
 		w.logger.Infof("initialized consumer service writer for %s", cs.String())
 	}
 	for key, csw := range w.consumerServiceWriters {
-		if _, ok := newConsumerServiceWriters[key]; !ok {
-			toBeClosed = append(toBeClosed, csw)
+		newCSW, ok := newConsumerServiceWriters[key]
+		if ok && newCSW == csw {
+			continue
+		}
+		if ok && (key == w.deadLetterKey) == (key == newDeadLetterKey) {
+			toBeDrained[key] = csw
+			continue
 		}
+		toBeClosed = append(toBeClosed, csw)
 	}
 	// Allow InitValueError for any future topic updates after starting up.
 	// This is to handle the case when a new consumer service got added to
@@ -206,6 +280,10 @@ func (w *writer) process(update interface{}) error {
 	// the placement came in.
 	w.initType = allowInitValueError
 	w.m.numConsumerServices.Update(float64(len(newConsumerServiceWriters)))
+	if numShardsChanged {
+		w.logger.Infof("updating number of shards for topic %s from %d to %d", w.topic, w.numShards, t.NumberOfShards())
+		w.m.numShardsUpdate.Inc(1)
+	}
 
 	// Apply the new consumer service writers.
 	w.Lock()
@@ -214,12 +292,22 @@ func (w *writer) process(update interface{}) error {
 			csw.RegisterFilter(filter)
 		}
 	}
+	w.consumerServices = newConsumerServices
 	w.consumerServiceWriters = newConsumerServiceWriters
+	w.deadLetterKey = newDeadLetterKey
+	w.deadLetterWriter.SetConsumerServiceWriter(newConsumerServiceWriters[newDeadLetterKey], t.NumberOfShards())
 	w.numShards = t.NumberOfShards()
 	w.Unlock()
 
-	// Close removed consumer service.
+	// NB: The replaced consumer service writers no longer receive new writes,
+	// drain their messages into the new consumer service writers so they are
+	// re-routed under the new shards and consumption types.
 	go func() {
+		for key, csw := range toBeDrained {
+			csw.Drain(w.drainFn(key))
+			csw.Close()
+		}
+		// Close removed consumer service.
 		for _, csw := range toBeClosed {
 			csw.Close()
 		}
@@ -233,6 +321,17 @@ func (w *writer) process(update interface{}) error {
 	return nil
 }
 
+// canReuse returns true if the existing consumer service writer can be reused
+// for the consumer service.
+func (w *writer) canReuse(key string, cs topic.ConsumerService) bool {
+	cur, ok := w.consumerServices[key]
+	if !ok {
+		return false
+	}
+	return cur.ConsumptionType() == cs.ConsumptionType() &&
+		(key == w.deadLetterKey) == cs.DeadLetter()
+}
+
 func (w *writer) Close() {
 	w.Lock()
 	if w.isClosed {
@@ -243,7 +342,15 @@ func (w *writer) Close() {
 	w.Unlock()
 
 	w.value.Unwatch()
-	for _, csw := range w.consumerServiceWriters {
+	// Close the dead-letter consumer service writer last so it could take the
+	// dead letters from the other consumer service writers while they close.
+	for key, csw := range w.consumerServiceWriters {
+		if key != w.deadLetterKey {
+			csw.Close()
+		}
+	}
+	if csw, ok := w.consumerServiceWriters[w.deadLetterKey]; ok {
+		w.deadLetterWriter.SetConsumerServiceWriter(nil, 0)
 		csw.Close()
 	}
 }
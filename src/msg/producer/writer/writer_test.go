@@ -212,6 +212,7 @@ func TestWriterRegisterFilter(t *testing.T) {
 	filter := func(producer.Message) bool { return false }
 
 	w := NewWriter(opts).(*writer)
+	w.consumerServices[cs1.ServiceID().String()] = cs1
 	w.consumerServiceWriters[cs1.ServiceID().String()] = csw1
 
 	csw1.EXPECT().UnregisterFilter()
@@ -238,6 +239,171 @@ func TestWriterRegisterFilter(t *testing.T) {
 	w.process(testTopic)
 }
 
+func TestWriterWriteSkipsDeadLetterConsumerService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sid1 := services.NewServiceID().SetName("s1")
+	sid2 := services.NewServiceID().SetName("dead-letter")
+	csw1 := NewMockconsumerServiceWriter(ctrl)
+	csw2 := NewMockconsumerServiceWriter(ctrl)
+
+	w := NewWriter(testOptions()).(*writer)
+	w.numShards = 2
+	w.consumerServiceWriters[sid1.String()] = csw1
+	w.consumerServiceWriters[sid2.String()] = csw2
+	w.deadLetterKey = sid2.String()
+
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Size().Return(3)
+	mm.EXPECT().Shard().Return(uint32(1))
+	rm := producer.NewRefCountedMessage(mm, nil)
+	csw1.EXPECT().Write(uint32(1), rm).Do(func(_ uint32, rm *producer.RefCountedMessage) {
+		rm.IncRef()
+	})
+	require.NoError(t, w.Write(rm))
+}
+
+func TestWriterWriteWithShardFn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shardFn := func(m producer.Message, numShards uint32) uint32 {
+		return uint32(len(m.Bytes())) % numShards
+	}
+	sid1 := services.NewServiceID().SetName("s1")
+	csw1 := NewMockconsumerServiceWriter(ctrl)
+
+	w := NewWriter(testOptions().SetShardFn(shardFn)).(*writer)
+	w.numShards = 4
+	w.consumerServiceWriters[sid1.String()] = csw1
+
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Size().Return(3)
+	mm.EXPECT().Shard().Return(uint32(1))
+	mm.EXPECT().Bytes().Return([]byte("foo"))
+	rm := producer.NewRefCountedMessage(mm, nil)
+	csw1.EXPECT().Write(uint32(3), rm).Do(func(_ uint32, rm *producer.RefCountedMessage) {
+		rm.IncRef()
+	})
+	require.NoError(t, w.Write(rm))
+}
+
+func TestWriterDrainFn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shardFn := func(m producer.Message, numShards uint32) uint32 {
+		return uint32(len(m.Bytes())) % numShards
+	}
+	sid1 := services.NewServiceID().SetName("s1")
+	sid2 := services.NewServiceID().SetName("dead-letter")
+	csw1 := NewMockconsumerServiceWriter(ctrl)
+	csw2 := NewMockconsumerServiceWriter(ctrl)
+
+	w := NewWriter(testOptions().SetShardFn(shardFn)).(*writer)
+	w.numShards = 2
+	w.consumerServiceWriters[sid1.String()] = csw1
+	w.consumerServiceWriters[sid2.String()] = csw2
+	w.deadLetterKey = sid2.String()
+
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Size().Return(3)
+	mm.EXPECT().Shard().Return(uint32(2)).AnyTimes()
+	mm.EXPECT().Bytes().Return([]byte("foo")).AnyTimes()
+	rm := producer.NewRefCountedMessage(mm, nil)
+
+	// The messages are resharded with the shard function.
+	csw1.EXPECT().Write(uint32(1), rm)
+	w.drainFn(sid1.String())(rm)
+
+	// The dead letters are resharded into the range of the shards.
+	csw2.EXPECT().Write(uint32(0), rm)
+	w.drainFn(sid2.String())(rm)
+
+	// The consumer service was removed.
+	w.drainFn("unknown")(rm)
+}
+
+func TestWriterNumberOfShardsUpdate(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mem.NewStore()
+	sid1 := services.NewServiceID().SetName("s1")
+	cs1 := topic.NewConsumerService().SetConsumptionType(topic.Shared).SetServiceID(sid1)
+	sd := services.NewMockServices(ctrl)
+	ps1 := testPlacementService(store, sid1)
+	sd.EXPECT().PlacementService(sid1, gomock.Any()).Return(ps1, nil)
+	p1 := placement.NewPlacement().
+		SetInstances([]placement.Instance{
+			placement.NewInstance().
+				SetID("i1").
+				SetEndpoint("addr1").
+				SetShards(shard.NewShards([]shard.Shard{
+					shard.NewShard(0).SetState(shard.Available),
+					shard.NewShard(1).SetState(shard.Available),
+				})),
+		}).
+		SetShards([]uint32{0, 1}).
+		SetReplicaFactor(1).
+		SetIsSharded(true)
+	require.NoError(t, ps1.Set(p1))
+
+	shardFn := func(m producer.Message, numShards uint32) uint32 {
+		return 1 % numShards
+	}
+	opts := testOptions().SetServiceDiscovery(sd).SetShardFn(shardFn)
+	w := NewWriter(opts).(*writer)
+	csw1 := NewMockconsumerServiceWriter(ctrl)
+	w.numShards = 1
+	w.consumerServices[sid1.String()] = cs1
+	w.consumerServiceWriters[sid1.String()] = csw1
+
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Size().Return(3)
+	mm.EXPECT().Shard().Return(uint32(0)).AnyTimes()
+	mm.EXPECT().Bytes().Return([]byte("foo")).AnyTimes()
+	mm.EXPECT().Finalize(producer.Dropped)
+	rm := producer.NewRefCountedMessage(mm, nil)
+
+	// The replaced consumer service writer is drained into the new one.
+	doneCh := make(chan struct{})
+	csw1.EXPECT().Drain(gomock.Any()).Do(func(fn drainFn) { fn(rm) })
+	csw1.EXPECT().Close().Do(func() { close(doneCh) })
+	testTopic := topic.NewTopic().
+		SetName(opts.TopicName()).
+		SetNumberOfShards(2).
+		SetConsumerServices([]topic.ConsumerService{cs1})
+	require.NoError(t, w.process(testTopic))
+	<-doneCh
+
+	require.Equal(t, uint32(2), w.numShards)
+	csw, ok := w.consumerServiceWriters[sid1.String()].(*consumerServiceWriterImpl)
+	require.True(t, ok)
+	require.Equal(t, 0, csw.shardWriters[0].QueueSize())
+	require.Equal(t, 1, csw.shardWriters[1].QueueSize())
+
+	rm.Drop()
+	csw.Close()
+}
+
+func TestWriterNumberOfShardsUpdateWithoutShardFn(t *testing.T) {
+	sid1 := services.NewServiceID().SetName("s1")
+	cs1 := topic.NewConsumerService().SetConsumptionType(topic.Shared).SetServiceID(sid1)
+	w := NewWriter(testOptions()).(*writer)
+	w.numShards = 1
+
+	testTopic := topic.NewTopic().
+		SetName("topic").
+		SetNumberOfShards(2).
+		SetConsumerServices([]topic.ConsumerService{cs1})
+	require.Error(t, w.process(testTopic))
+	require.Equal(t, uint32(1), w.numShards)
+}
+
 func TestWriterTopicUpdate(t *testing.T) {
 	defer leaktest.Check(t)()
 
@@ -548,7 +714,7 @@ func TestWriterWrite(t *testing.T) {
 
 	var wg sync.WaitGroup
 	mm := producer.NewMockMessage(ctrl)
-	mm.EXPECT().Shard().Return(uint32(0)).Times(3)
+	mm.EXPECT().Shard().Return(uint32(0))
 	mm.EXPECT().Size().Return(3)
 	mm.EXPECT().Bytes().Return([]byte("foo")).Times(3)
 	mm.EXPECT().Finalize(producer.Consumed).Do(func(interface{}) { wg.Done() })
@@ -627,7 +793,7 @@ func TestWriterCloseBlocking(t *testing.T) {
 
 	mm := producer.NewMockMessage(ctrl)
 	mm.EXPECT().Size().Return(3)
-	mm.EXPECT().Shard().Return(uint32(0)).Times(2)
+	mm.EXPECT().Shard().Return(uint32(0))
 	mm.EXPECT().Bytes().Return([]byte("foo")).Times(1)
 	mm.EXPECT().Finalize(producer.Dropped)
 	rm := producer.NewRefCountedMessage(mm, nil)
@@ -32,8 +32,9 @@ import (
 )
 
 var (
-	errEmptyName  = errors.New("invalid topic: empty name")
-	errZeroShards = errors.New("invalid topic: zero shards")
+	errEmptyName             = errors.New("invalid topic: empty name")
+	errZeroShards            = errors.New("invalid topic: zero shards")
+	errMultipleDeadLetterCSs = errors.New("invalid topic: more than one dead-letter consumer service")
 )
 
 type topic struct {
@@ -130,8 +131,10 @@ func (t *topic) RemoveConsumerService(value services.ServiceID) (Topic, error) {
 	cur := t.ConsumerServices()
 	for i, cs := range cur {
 		if cs.ServiceID().Equal(value) {
-			cur = append(cur[:i], cur[i+1:]...)
-			return t.SetConsumerServices(cur), nil
+			css := make([]ConsumerService, 0, len(cur)-1)
+			css = append(css, cur[:i]...)
+			css = append(css, cur[i+1:]...)
+			return t.SetConsumerServices(css), nil
 		}
 	}
 
@@ -139,20 +142,31 @@ func (t *topic) RemoveConsumerService(value services.ServiceID) (Topic, error) {
 }
 
 func (t *topic) UpdateConsumerService(value ConsumerService) (Topic, error) {
-	css := t.ConsumerServices()
-	for i, cs := range css {
+	cur := t.ConsumerServices()
+	for i, cs := range cur {
 		if !cs.ServiceID().Equal(value.ServiceID()) {
 			continue
 		}
-		if value.ConsumptionType() != cs.ConsumptionType() {
-			return nil, fmt.Errorf("could not change consumption type for consumer service %s", value.ServiceID().String())
+		if value.DeadLetter() != cs.DeadLetter() {
+			return nil, fmt.Errorf("could not change dead letter for consumer service %s", value.ServiceID().String())
 		}
+		css := make([]ConsumerService, len(cur))
+		copy(css, cur)
 		css[i] = value
 		return t.SetConsumerServices(css), nil
 	}
 	return nil, fmt.Errorf("could not find consumer service %s in the topic", value.String())
 }
 
+func (t *topic) DeadLetterConsumerService() ConsumerService {
+	for _, cs := range t.ConsumerServices() {
+		if cs.DeadLetter() {
+			return cs
+		}
+	}
+	return nil
+}
+
 func (t *topic) String() string {
 	var buf bytes.Buffer
 	buf.WriteString("\n{\n")
@@ -179,13 +193,23 @@ func (t *topic) Validate() error {
 	if t.NumberOfShards() == 0 {
 		return errZeroShards
 	}
-	uniqConsumers := make(map[string]struct{}, len(t.ConsumerServices()))
+	var (
+		uniqConsumers = make(map[string]struct{}, len(t.ConsumerServices()))
+		hasDeadLetter bool
+	)
 	for _, cs := range t.ConsumerServices() {
 		_, ok := uniqConsumers[cs.ServiceID().String()]
 		if ok {
 			return fmt.Errorf("invalid topic: duplicated consumer %s", cs.ServiceID().String())
 		}
 		uniqConsumers[cs.ServiceID().String()] = struct{}{}
+		if !cs.DeadLetter() {
+			continue
+		}
+		if hasDeadLetter {
+			return errMultipleDeadLetterCSs
+		}
+		hasDeadLetter = true
 	}
 	return nil
 }
@@ -209,9 +233,10 @@ func ToProto(t Topic) (*topicpb.Topic, error) {
 }
 
 type consumerService struct {
-	sid      services.ServiceID
-	ct       ConsumptionType
-	ttlNanos int64
+	sid        services.ServiceID
+	ct         ConsumptionType
+	ttlNanos   int64
+	deadLetter bool
 }
 
 // NewConsumerService creates a ConsumerService.
@@ -228,7 +253,8 @@ func NewConsumerServiceFromProto(cs *topicpb.ConsumerService) (ConsumerService,
 	return NewConsumerService().
 		SetServiceID(NewServiceIDFromProto(cs.ServiceId)).
 		SetConsumptionType(ct).
-		SetMessageTTLNanos(cs.MessageTtlNanos), nil
+		SetMessageTTLNanos(cs.MessageTtlNanos).
+		SetDeadLetter(cs.DeadLetter), nil
 }
 
 // ConsumerServiceToProto creates proto from a ConsumerService.
@@ -241,6 +267,7 @@ func ConsumerServiceToProto(cs ConsumerService) (*topicpb.ConsumerService, error
 		ConsumptionType: ct,
 		ServiceId:       ServiceIDToProto(cs.ServiceID()),
 		MessageTtlNanos: cs.MessageTTLNanos(),
+		DeadLetter:      cs.DeadLetter(),
 	}, nil
 }
 
@@ -274,6 +301,16 @@ func (cs *consumerService) SetMessageTTLNanos(value int64) ConsumerService {
 	return &newcs
 }
 
+func (cs *consumerService) DeadLetter() bool {
+	return cs.deadLetter
+}
+
+func (cs *consumerService) SetDeadLetter(value bool) ConsumerService {
+	newcs := *cs
+	newcs.deadLetter = value
+	return &newcs
+}
+
 func (cs *consumerService) String() string {
 	var buf bytes.Buffer
 	buf.WriteString("{")
@@ -281,6 +318,9 @@ func (cs *consumerService) String() string {
 	if cs.ttlNanos != 0 {
 		buf.WriteString(fmt.Sprintf(", ttl: %v", time.Duration(cs.ttlNanos)))
 	}
+	if cs.deadLetter {
+		buf.WriteString(", dead letter")
+	}
 	buf.WriteString("}")
 	return buf.String()
 }
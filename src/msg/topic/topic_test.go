@@ -121,9 +121,9 @@ func TestTopicUpdateConsumer(t *testing.T) {
 			[]ConsumerService{cs1},
 		)
 
-	_, err := tpc.UpdateConsumerService(cs1.SetConsumptionType(Replicated))
+	_, err := tpc.UpdateConsumerService(cs1.SetDeadLetter(true))
 	require.Error(t, err)
-	require.Contains(t, err.Error(), "could not change consumption type")
+	require.Contains(t, err.Error(), "could not change dead letter")
 
 	_, err = tpc.UpdateConsumerService(cs1.SetServiceID(services.NewServiceID().SetName("foo")))
 	require.Error(t, err)
@@ -142,6 +142,12 @@ func TestTopicUpdateConsumer(t *testing.T) {
 	require.Equal(t, []ConsumerService{cs2}, tpc.ConsumerServices())
 	require.Equal(t, int64(0), cs1.MessageTTLNanos())
 	require.Equal(t, int64(500), cs2.MessageTTLNanos())
+
+	cs3 := cs2.SetConsumptionType(Replicated)
+	tpc2, err := tpc.UpdateConsumerService(cs3)
+	require.NoError(t, err)
+	require.Equal(t, []ConsumerService{cs3}, tpc2.ConsumerServices())
+	require.Equal(t, []ConsumerService{cs2}, tpc.ConsumerServices())
 }
 
 func TestTopicString(t *testing.T) {
@@ -222,6 +228,49 @@ func TestTopicValidation(t *testing.T) {
 	})
 	err = topic.Validate()
 	require.NoError(t, err)
+
+	dl1 := cs1.SetServiceID(services.NewServiceID().SetName("dl1")).SetDeadLetter(true)
+	dl2 := cs1.SetServiceID(services.NewServiceID().SetName("dl2")).SetDeadLetter(true)
+	topic = topic.SetConsumerServices([]ConsumerService{
+		cs1, dl1, dl2,
+	})
+	err = topic.Validate()
+	require.Error(t, err)
+	require.Equal(t, errMultipleDeadLetterCSs, err)
+
+	topic = topic.SetConsumerServices([]ConsumerService{
+		cs1, dl1,
+	})
+	err = topic.Validate()
+	require.NoError(t, err)
+}
+
+func TestTopicDeadLetterConsumerService(t *testing.T) {
+	cs1 := NewConsumerService().
+		SetConsumptionType(Shared).
+		SetServiceID(services.NewServiceID().SetName("s1"))
+	dl := NewConsumerService().
+		SetConsumptionType(Shared).
+		SetServiceID(services.NewServiceID().SetName("dl")).
+		SetDeadLetter(true)
+
+	tpc := NewTopic().SetConsumerServices([]ConsumerService{cs1})
+	require.Nil(t, tpc.DeadLetterConsumerService())
+
+	tpc, err := tpc.AddConsumerService(dl)
+	require.NoError(t, err)
+	require.Equal(t, dl, tpc.DeadLetterConsumerService())
+
+	pb, err := ToProto(tpc)
+	require.NoError(t, err)
+	require.False(t, pb.ConsumerServices[0].DeadLetter)
+	require.True(t, pb.ConsumerServices[1].DeadLetter)
+
+	tpc, err = NewTopicFromProto(pb)
+	require.NoError(t, err)
+	require.False(t, tpc.ConsumerServices()[0].DeadLetter())
+	require.True(t, tpc.DeadLetterConsumerService().DeadLetter())
+	require.Equal(t, "dl", tpc.DeadLetterConsumerService().ServiceID().Name())
 }
 
 func TestConsumerService(t *testing.T) {
@@ -231,4 +280,9 @@ func TestConsumerService(t *testing.T) {
 	require.Equal(t, Shared, cs.ConsumptionType())
 	require.Equal(t, int64(time.Second), cs.MessageTTLNanos())
 	require.Equal(t, "{service: [name: s, env: env, zone: zone], consumption type: shared, ttl: 1s}", cs.String())
+	require.False(t, cs.DeadLetter())
+
+	cs = cs.SetDeadLetter(true)
+	require.True(t, cs.DeadLetter())
+	require.Equal(t, "{service: [name: s, env: env, zone: zone], consumption type: shared, ttl: 1s, dead letter}", cs.String())
 }
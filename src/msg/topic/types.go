@@ -61,6 +61,10 @@ type Topic interface {
 	// UpdateConsumerService updates a consumer in the topic.
 	UpdateConsumerService(value ConsumerService) (Topic, error)
 
+	// DeadLetterConsumerService returns the dead-letter consumer service of
+	// the topic, or nil if the topic does not have one.
+	DeadLetterConsumerService() ConsumerService
+
 	// String returns the string representation of the topic.
 	String() string
 
@@ -88,6 +92,15 @@ type ConsumerService interface {
 	// SetMessageTTLNanos sets ttl for each message in nanoseconds.
 	SetMessageTTLNanos(value int64) ConsumerService
 
+	// DeadLetter returns true if the consumer service only receives the
+	// messages that could not be consumed by the other consumer services
+	// of the topic.
+	DeadLetter() bool
+
+	// SetDeadLetter sets whether the consumer service is a dead-letter
+	// consumer service.
+	SetDeadLetter(value bool) ConsumerService
+
 	// String returns the string representation of the consumer service.
 	String() string
 }
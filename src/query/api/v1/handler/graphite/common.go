@@ -0,0 +1,157 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/graphite/native"
+	"github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// routePrefix is the prefix for all graphite routes, graphite clients such
+	// as Grafana should be pointed at this prefix.
+	routePrefix = handler.RoutePrefixV1 + "/graphite"
+
+	targetParam        = "target"
+	fromParam          = "from"
+	untilParam         = "until"
+	formatParam        = "format"
+	maxDataPointsParam = "maxDataPoints"
+	stepParam          = "step"
+	queryParam         = "query"
+
+	jsonFormat = "json"
+
+	defaultFrom = "-24h"
+	defaultStep = 10 * time.Second
+
+	formatErrStr = "error parsing param: %s, error: %v"
+)
+
+var (
+	// HTTPMethods are the HTTP methods supported by the graphite endpoints,
+	// Grafana uses POST form requests while other clients use GET.
+	HTTPMethods = []string{http.MethodGet, http.MethodPost}
+)
+
+type renderParams struct {
+	targets []string
+	start   time.Time
+	end     time.Time
+	step    time.Duration
+	timeout time.Duration
+}
+
+func parseTimeRange(r *http.Request, now time.Time) (time.Time, time.Time, *xhttp.ParseError) {
+	from := r.FormValue(fromParam)
+	if from == "" {
+		from = defaultFrom
+	}
+
+	start, err := native.ParseTime(from, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, xhttp.NewParseError(
+			fmt.Errorf(formatErrStr, fromParam, err), http.StatusBadRequest)
+	}
+
+	end, err := native.ParseTime(r.FormValue(untilParam), now)
+	if err != nil {
+		return time.Time{}, time.Time{}, xhttp.NewParseError(
+			fmt.Errorf(formatErrStr, untilParam, err), http.StatusBadRequest)
+	}
+
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, xhttp.NewParseError(
+			fmt.Errorf("%s must be before %s", fromParam, untilParam), http.StatusBadRequest)
+	}
+
+	return start, end, nil
+}
+
+// parseRenderParams parses the render params from either the query string
+// or a form body.
+func parseRenderParams(r *http.Request, now time.Time) (renderParams, *xhttp.ParseError) {
+	if err := r.ParseForm(); err != nil {
+		return renderParams{}, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	var params renderParams
+	timeout, err := prometheus.ParseRequestTimeout(r)
+	if err != nil {
+		return params, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+	params.timeout = timeout
+
+	for _, target := range r.Form[targetParam] {
+		if target != "" {
+			params.targets = append(params.targets, target)
+		}
+	}
+
+	if len(params.targets) == 0 {
+		return params, xhttp.NewParseError(
+			fmt.Errorf(formatErrStr, targetParam, "no targets"), http.StatusBadRequest)
+	}
+
+	if format := r.FormValue(formatParam); format != "" && format != jsonFormat {
+		return params, xhttp.NewParseError(
+			fmt.Errorf("unsupported format %s, only %s is supported", format, jsonFormat),
+			http.StatusBadRequest)
+	}
+
+	start, end, rErr := parseTimeRange(r, now)
+	if rErr != nil {
+		return params, rErr
+	}
+	params.start, params.end = start, end
+
+	params.step = defaultStep
+	if s := r.FormValue(stepParam); s != "" {
+		step, err := native.ParseInterval(s)
+		if err != nil || step <= 0 {
+			return params, xhttp.NewParseError(
+				fmt.Errorf(formatErrStr, stepParam, s), http.StatusBadRequest)
+		}
+		params.step = step
+	}
+
+	if s := r.FormValue(maxDataPointsParam); s != "" {
+		maxDataPoints, err := strconv.Atoi(s)
+		if err != nil || maxDataPoints <= 0 {
+			return params, xhttp.NewParseError(
+				fmt.Errorf(formatErrStr, maxDataPointsParam, s), http.StatusBadRequest)
+		}
+
+		// Widen the step to honor the max number of datapoints.
+		if minStep := end.Sub(start) / time.Duration(maxDataPoints); minStep > params.step {
+			params.step = ((minStep + time.Second - 1) / time.Second) * time.Second
+		}
+	}
+
+	return params, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/graphite/native"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// FindURL is the url for the graphite metrics find handler.
+	FindURL = routePrefix + "/metrics/find"
+)
+
+// FindHandler is a handler for the graphite metrics find endpoint.
+type FindHandler struct {
+	engine *native.Engine
+	nowFn  func() time.Time
+}
+
+// NewFindHandler returns a new graphite metrics find handler.
+func NewFindHandler(querier storage.Querier) http.Handler {
+	return &FindHandler{
+		engine: native.NewEngine(querier, nil),
+		nowFn:  time.Now,
+	}
+}
+
+// findResult is a node in the graphite metrics tree in the format
+// expected by graphite clients.
+type findResult struct {
+	ID            string `json:"id"`
+	Text          string `json:"text"`
+	Leaf          int    `json:"leaf"`
+	Expandable    int    `json:"expandable"`
+	AllowChildren int    `json:"allowChildren"`
+}
+
+func (h *FindHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx)
+
+	if err := r.ParseForm(); err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	query := r.FormValue(queryParam)
+	if query == "" {
+		xhttp.Error(w, fmt.Errorf(formatErrStr, queryParam, "empty query"),
+			http.StatusBadRequest)
+		return
+	}
+
+	start, end, rErr := parseTimeRange(r, h.nowFn())
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	results, err := h.engine.Find(ctx, query, start, end)
+	if err != nil {
+		logger.Error("unable to find graphite metrics",
+			zap.String("query", query), zap.Error(err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	response := make([]findResult, 0, len(results))
+	for _, result := range results {
+		r := findResult{ID: result.ID, Text: result.Text}
+		if result.Leaf {
+			r.Leaf = 1
+		} else {
+			r.Expandable = 1
+			r.AllowChildren = 1
+		}
+
+		response = append(response, r)
+	}
+
+	xhttp.WriteJSONResponse(w, response, logger)
+}
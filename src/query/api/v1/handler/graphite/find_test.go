@@ -0,0 +1,72 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/graphite/native"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	store.SetFetchTagsResult(&storage.SearchResults{
+		Metrics: models.Metrics{
+			{ID: "1", Tags: graphiteTags("foo", "bar", "baz")},
+			{ID: "2", Tags: graphiteTags("foo", "qux")},
+		},
+	}, nil)
+
+	h := &FindHandler{
+		engine: native.NewEngine(store),
+		nowFn:  func() time.Time { return testNow },
+	}
+
+	req := httptest.NewRequest(http.MethodGet, FindURL+"?query=foo.*", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var results []findResult
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&results))
+	assert.Equal(t, []findResult{
+		{ID: "foo.bar", Text: "bar", Expandable: 1, AllowChildren: 1},
+		{ID: "foo.qux", Text: "qux", Leaf: 1},
+	}, results)
+
+	req = httptest.NewRequest(http.MethodGet, FindURL, nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/cost"
+	"github.com/m3db/m3/src/query/graphite/native"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// RenderURL is the url for the graphite render handler.
+	RenderURL = routePrefix + "/render"
+)
+
+// RenderHandler is a handler for the graphite render endpoint.
+type RenderHandler struct {
+	engine *native.Engine
+	nowFn  func() time.Time
+}
+
+// NewRenderHandler returns a new graphite render handler which enforces the
+// cost limits of the enforcers on each request.
+func NewRenderHandler(
+	querier storage.Querier,
+	enforcers *cost.EnforcerFactory,
+) http.Handler {
+	return &RenderHandler{
+		engine: native.NewEngine(querier, enforcers),
+		nowFn:  time.Now,
+	}
+}
+
+func (h *RenderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx)
+
+	params, rErr := parseRenderParams(r, h.nowFn())
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, params.timeout)
+	defer cancel()
+
+	var results []native.Series
+	for _, target := range params.targets {
+		series, err := h.engine.Render(ctx, target, native.RenderOptions{
+			Start: params.start,
+			End:   params.end,
+			Step:  params.step,
+		})
+		if err != nil {
+			logger.Error("unable to render graphite target",
+				zap.String("target", target), zap.Error(err))
+			xhttp.Error(w, err, handler.QueryErrorStatusCode(err, http.StatusBadRequest))
+			return
+		}
+
+		results = append(results, series...)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	renderResultsJSON(w, results)
+}
+
+// renderResultsJSON writes the series in the graphite render JSON format,
+// NaN values are written as null.
+func renderResultsJSON(w io.Writer, series []native.Series) {
+	jw := json.NewWriter(w)
+	jw.BeginArray()
+	for _, s := range series {
+		jw.BeginObject()
+		jw.BeginObjectField("target")
+		jw.WriteString(s.Name)
+
+		jw.BeginObjectField("datapoints")
+		jw.BeginArray()
+		for i, v := range s.Values {
+			jw.BeginArray()
+			jw.WriteFloat64(v)
+			jw.WriteInt(int(s.TimeAt(i).Unix()))
+			jw.EndArray()
+		}
+		jw.EndArray()
+
+		jw.BeginObjectField("step_size_ms")
+		jw.WriteInt(int(s.Step / time.Millisecond))
+		jw.EndObject()
+	}
+	jw.EndArray()
+	jw.Close()
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/graphite/native"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Unix(1500000000, 0)
+
+func graphiteTags(path ...string) models.Tags {
+	tags := models.NewTags(len(path), nil)
+	for i, node := range path {
+		tags = tags.AddTag(models.Tag{
+			Name:  graphite.TagName(i),
+			Value: []byte(node),
+		})
+	}
+
+	return tags
+}
+
+func newTestRenderHandler(t *testing.T) *RenderHandler {
+	logging.InitWithCores(nil)
+
+	var (
+		start = testNow.Add(-time.Minute)
+		store = mock.NewMockStorage()
+	)
+	result, err := storage.FetchResultToBlockResult(&storage.FetchResult{
+		SeriesList: ts.SeriesList{
+			ts.NewSeries("", ts.Datapoints{
+				{Timestamp: start, Value: 1},
+				{Timestamp: start.Add(30 * time.Second), Value: 2},
+			}, graphiteTags("foo", "bar")),
+		},
+	}, &storage.FetchQuery{
+		Start:    start,
+		End:      testNow,
+		Interval: 30 * time.Second,
+	})
+	require.NoError(t, err)
+	store.SetFetchBlocksResult(result, nil)
+
+	return &RenderHandler{
+		engine: native.NewEngine(store, nil),
+		nowFn:  func() time.Time { return testNow },
+	}
+}
+
+type renderResult struct {
+	Target     string          `json:"target"`
+	Datapoints [][]interface{} `json:"datapoints"`
+}
+
+func TestRender(t *testing.T) {
+	h := newTestRenderHandler(t)
+
+	for _, method := range HTTPMethods {
+		params := url.Values{
+			"target": []string{"foo.bar", "scale(foo.bar, 2)"},
+			"from":   []string{"-1min"},
+			"step":   []string{"30s"},
+		}
+
+		var req *http.Request
+		if method == http.MethodGet {
+			req = httptest.NewRequest(method, RenderURL+"?"+params.Encode(), nil)
+		} else {
+			req = httptest.NewRequest(method, RenderURL, strings.NewReader(params.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, method)
+
+		body, err := ioutil.ReadAll(w.Body)
+		require.NoError(t, err)
+
+		var results []renderResult
+		require.NoError(t, json.Unmarshal(body, &results), string(body))
+		require.Equal(t, 2, len(results))
+
+		assert.Equal(t, "foo.bar", results[0].Target)
+		assert.Equal(t, [][]interface{}{
+			{float64(1), float64(testNow.Unix() - 60)},
+			{float64(2), float64(testNow.Unix() - 30)},
+		}, results[0].Datapoints)
+
+		assert.Equal(t, "scale(foo.bar,2)", results[1].Target)
+		assert.Equal(t, [][]interface{}{
+			{float64(2), float64(testNow.Unix() - 60)},
+			{float64(4), float64(testNow.Unix() - 30)},
+		}, results[1].Datapoints)
+	}
+}
+
+func TestRenderBadRequests(t *testing.T) {
+	h := newTestRenderHandler(t)
+
+	for _, query := range []string{
+		"",
+		"target=foo.bar&format=pickle",
+		"target=foo.bar&from=garbage",
+		"target=foo.bar&from=-1h&until=-2h",
+		"target=foo.bar&step=0s",
+		"target=foo.bar&maxDataPoints=-1",
+		"target=scale(foo.bar",
+	} {
+		req := httptest.NewRequest(http.MethodGet, RenderURL+"?"+query, nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestRenderQueryLimit(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	store.SetFetchBlocksResult(block.Result{},
+		errors.QueryLimitError{Resource: "series", Limit: 10})
+	h := &RenderHandler{
+		engine: native.NewEngine(store, nil),
+		nowFn:  func() time.Time { return testNow },
+	}
+
+	req := httptest.NewRequest(http.MethodGet, RenderURL+"?target=foo.bar&from=-1min", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestParseRenderParamsMaxDataPoints(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet,
+		RenderURL+"?target=foo&from=-1h&maxDataPoints=10", nil)
+	params, err := parseRenderParams(req, testNow)
+	require.Nil(t, err)
+	assert.Equal(t, 6*time.Minute, params.step)
+	assert.Equal(t, []string{"foo"}, params.targets)
+	assert.True(t, testNow.Add(-time.Hour).Equal(params.start))
+	assert.True(t, testNow.Equal(params.end))
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	xtime "github.com/m3db/m3x/time"
+)
+
+var (
+	errMissingMeasurement = errors.New("missing measurement")
+	errMissingFields      = errors.New("missing fields")
+	errMissingTagValue    = errors.New("missing tag value")
+	errMissingFieldValue  = errors.New("missing field value")
+	errUnterminatedString = errors.New("unterminated string field value")
+	errNoNumericFields    = errors.New("no numeric fields")
+)
+
+// point is a single parsed line of the influx line protocol.
+type point struct {
+	measurement  []byte
+	tags         []models.Tag
+	fields       []field
+	timestamp    int64
+	hasTimestamp bool
+}
+
+// field is a numeric field of a point, string fields are not representable
+// as a series and are dropped during parsing.
+type field struct {
+	key   []byte
+	value float64
+}
+
+// precision describes the unit of the timestamps in a write request.
+type precision struct {
+	duration time.Duration
+	unit     xtime.Unit
+}
+
+func parsePrecision(s string) (precision, error) {
+	switch s {
+	case "", "n", "ns":
+		return precision{duration: time.Nanosecond, unit: xtime.Nanosecond}, nil
+	case "u", "us", "µ", "µs":
+		return precision{duration: time.Microsecond, unit: xtime.Microsecond}, nil
+	case "ms":
+		return precision{duration: time.Millisecond, unit: xtime.Millisecond}, nil
+	case "s":
+		return precision{duration: time.Second, unit: xtime.Second}, nil
+	}
+
+	return precision{}, fmt.Errorf("invalid precision: %s", s)
+}
+
+// parseLine parses a single line of the form
+// `measurement[,tag=value...] field=value[,field=value...] [timestamp]`.
+func parseLine(line []byte) (point, error) {
+	var p point
+
+	// Measurement and tags run until the first unescaped space.
+	end := scan(line, 0, ' ', false)
+	series := line[:end]
+	if len(series) == 0 {
+		return p, errMissingMeasurement
+	}
+
+	i := scan(series, 0, ',', false)
+	p.measurement = unescape(series[:i])
+	if len(p.measurement) == 0 {
+		return p, errMissingMeasurement
+	}
+
+	for i < len(series) {
+		start := i + 1
+		i = scan(series, start, ',', false)
+		tag, err := parseTag(series[start:i])
+		if err != nil {
+			return p, err
+		}
+		p.tags = append(p.tags, tag)
+	}
+
+	rest := skipSpaces(line, end)
+	if rest >= len(line) {
+		return p, errMissingFields
+	}
+
+	// Fields run until the next unescaped space outside of a quoted string.
+	end = scan(line, rest, ' ', true)
+	if end > len(line) {
+		return p, errUnterminatedString
+	}
+
+	if err := p.parseFields(line[rest:end]); err != nil {
+		return p, err
+	}
+
+	rest = skipSpaces(line, end)
+	if rest < len(line) {
+		ts, err := strconv.ParseInt(string(bytes.TrimSpace(line[rest:])), 10, 64)
+		if err != nil {
+			return p, fmt.Errorf("invalid timestamp: %s", line[rest:])
+		}
+		p.timestamp = ts
+		p.hasTimestamp = true
+	}
+
+	return p, nil
+}
+
+func parseTag(b []byte) (models.Tag, error) {
+	eq := scan(b, 0, '=', false)
+	if eq == 0 {
+		return models.Tag{}, fmt.Errorf("missing tag key: %s", b)
+	}
+	if eq >= len(b)-1 {
+		return models.Tag{}, errMissingTagValue
+	}
+
+	return models.Tag{
+		Name:  unescape(b[:eq]),
+		Value: unescape(b[eq+1:]),
+	}, nil
+}
+
+func (p *point) parseFields(b []byte) error {
+	var (
+		i         int
+		numFields int
+	)
+	for i < len(b) {
+		end := scan(b, i, ',', true)
+		eq := scan(b[:end], i, '=', false)
+		if eq == i {
+			return fmt.Errorf("missing field key: %s", b[i:end])
+		}
+		if eq >= end-1 {
+			return errMissingFieldValue
+		}
+
+		key, raw := unescape(b[i:eq]), b[eq+1:end]
+		numFields++
+		i = end + 1
+
+		if raw[0] == '"' {
+			// String values cannot be stored as a datapoint.
+			continue
+		}
+
+		value, err := parseFieldValue(raw)
+		if err != nil {
+			return err
+		}
+
+		p.fields = append(p.fields, field{key: key, value: value})
+	}
+
+	if numFields == 0 {
+		return errMissingFields
+	}
+	if len(p.fields) == 0 {
+		return errNoNumericFields
+	}
+
+	return nil
+}
+
+func parseFieldValue(b []byte) (float64, error) {
+	switch string(b) {
+	case "t", "T", "true", "True", "TRUE":
+		return 1, nil
+	case "f", "F", "false", "False", "FALSE":
+		return 0, nil
+	}
+
+	var (
+		v   float64
+		err error
+	)
+	switch b[len(b)-1] {
+	case 'i':
+		var n int64
+		n, err = strconv.ParseInt(string(b[:len(b)-1]), 10, 64)
+		v = float64(n)
+	case 'u':
+		var n uint64
+		n, err = strconv.ParseUint(string(b[:len(b)-1]), 10, 64)
+		v = float64(n)
+	default:
+		v, err = strconv.ParseFloat(string(b), 64)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid field value: %s", b)
+	}
+
+	return v, nil
+}
+
+// scan returns the index of the first unescaped occurrence of sep in b at
+// or after start, or len(b) if there is none. When quoted is set, separators
+// inside double quoted strings are skipped; an unterminated string returns
+// an index past the end of b.
+func scan(b []byte, start int, sep byte, quoted bool) int {
+	inString := false
+	for i := start; i < len(b); i++ {
+		switch c := b[i]; {
+		case c == '\\':
+			i++
+		case quoted && c == '"':
+			inString = !inString
+		case !inString && c == sep:
+			return i
+		}
+	}
+
+	if inString {
+		return len(b) + 1
+	}
+
+	return len(b)
+}
+
+func skipSpaces(b []byte, i int) int {
+	for i < len(b) && b[i] == ' ' {
+		i++
+	}
+
+	return i
+}
+
+func unescape(b []byte) []byte {
+	if bytes.IndexByte(b, '\\') == -1 {
+		return append([]byte(nil), b...)
+	}
+
+	out := make([]byte, 0, len(b))
+	for i := 0; i < len(b); i++ {
+		if b[i] == '\\' && i+1 < len(b) {
+			switch b[i+1] {
+			case ',', ' ', '=', '\\':
+				i++
+			}
+		}
+		out = append(out, b[i])
+	}
+
+	return out
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected point
+	}{
+		{
+			line: "cpu value=1",
+			expected: point{
+				measurement: []byte("cpu"),
+				fields:      []field{{key: []byte("value"), value: 1}},
+			},
+		},
+		{
+			line: "cpu,host=a,region=us-west idle=10.5,user=2i,up=t,free=3u 1465839830100400200",
+			expected: point{
+				measurement: []byte("cpu"),
+				tags: []models.Tag{
+					{Name: []byte("host"), Value: []byte("a")},
+					{Name: []byte("region"), Value: []byte("us-west")},
+				},
+				fields: []field{
+					{key: []byte("idle"), value: 10.5},
+					{key: []byte("user"), value: 2},
+					{key: []byte("up"), value: 1},
+					{key: []byte("free"), value: 3},
+				},
+				timestamp:    1465839830100400200,
+				hasTimestamp: true,
+			},
+		},
+		{
+			line: `disk\ io,path=/var\,log,dev\=x=sda used=1e3,label="a, b=c \"d\"",ok=FALSE  42`,
+			expected: point{
+				measurement: []byte("disk io"),
+				tags: []models.Tag{
+					{Name: []byte("path"), Value: []byte("/var,log")},
+					{Name: []byte("dev=x"), Value: []byte("sda")},
+				},
+				fields: []field{
+					{key: []byte("used"), value: 1000},
+					{key: []byte("ok"), value: 0},
+				},
+				timestamp:    42,
+				hasTimestamp: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			p, err := parseLine([]byte(tt.line))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, p)
+		})
+	}
+}
+
+func TestParseLineErrors(t *testing.T) {
+	tests := []struct {
+		line string
+		err  string
+	}{
+		{line: ",host=a value=1", err: errMissingMeasurement.Error()},
+		{line: "cpu", err: errMissingFields.Error()},
+		{line: "cpu,host value=1", err: errMissingTagValue.Error()},
+		{line: "cpu,=a value=1", err: "missing tag key: =a"},
+		{line: "cpu value=", err: errMissingFieldValue.Error()},
+		{line: "cpu =1", err: "missing field key: =1"},
+		{line: "cpu value=abc", err: "invalid field value: abc"},
+		{line: "cpu value=1.5i", err: "invalid field value: 1.5i"},
+		{line: `cpu value="abc`, err: errUnterminatedString.Error()},
+		{line: `cpu value="abc"`, err: errNoNumericFields.Error()},
+		{line: "cpu value=1 abc", err: "invalid timestamp: abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			_, err := parseLine([]byte(tt.line))
+			require.Error(t, err)
+			assert.Equal(t, tt.err, err.Error())
+		})
+	}
+}
+
+func TestParsePrecision(t *testing.T) {
+	for _, s := range []string{"", "n", "ns", "u", "us", "ms", "s"} {
+		_, err := parsePrecision(s)
+		assert.NoError(t, err, s)
+	}
+
+	_, err := parsePrecision("m")
+	assert.Error(t, err)
+}
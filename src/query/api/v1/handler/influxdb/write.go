@@ -0,0 +1,316 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	// WriteURL is the url for the influxdb write handler
+	WriteURL = handler.RoutePrefixV1 + "/influxdb/write"
+
+	// WriteHTTPMethod is the HTTP method used with this resource.
+	WriteHTTPMethod = http.MethodPost
+
+	precisionParam = "precision"
+
+	// defaultBatchSize is the number of series written concurrently.
+	defaultBatchSize = 128
+)
+
+var (
+	errEmptyBody = errors.New("empty request body")
+)
+
+// WriteHandler represents a handler for the influxdb line protocol write endpoint.
+type WriteHandler struct {
+	store      storage.Storage
+	tagOptions models.TagOptions
+	batchSize  int
+	nowFn      func() time.Time
+	metrics    writeMetrics
+}
+
+// NewWriteHandler returns a new instance of handler.
+func NewWriteHandler(
+	store storage.Storage,
+	tagOptions models.TagOptions,
+	scope tally.Scope,
+) http.Handler {
+	return &WriteHandler{
+		store:      store,
+		tagOptions: tagOptions,
+		batchSize:  defaultBatchSize,
+		nowFn:      time.Now,
+		metrics:    newWriteMetrics(scope),
+	}
+}
+
+type writeMetrics struct {
+	writeSuccess      tally.Counter
+	writeErrorsServer tally.Counter
+	writeErrorsClient tally.Counter
+	invalidLines      tally.Counter
+}
+
+func newWriteMetrics(scope tally.Scope) writeMetrics {
+	return writeMetrics{
+		writeSuccess:      scope.Counter("write.success"),
+		writeErrorsServer: scope.Tagged(map[string]string{"code": "5XX"}).Counter("write.errors"),
+		writeErrorsClient: scope.Tagged(map[string]string{"code": "4XX"}).Counter("write.errors"),
+		invalidLines:      scope.Counter("write.invalid-lines"),
+	}
+}
+
+// LineError describes a line of the request that could not be written.
+type LineError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// WriteResponse is returned when one or more lines of a request could
+// not be written, all other lines are still written.
+type WriteResponse struct {
+	Error  string      `json:"error"`
+	Errors []LineError `json:"errors"`
+}
+
+// seriesWrite is a write for a single series along with the lines of
+// the request that contributed datapoints to it.
+type seriesWrite struct {
+	query *storage.WriteQuery
+	lines []int
+}
+
+func (h *WriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	prec, err := parsePrecision(r.URL.Query().Get(precisionParam))
+	if err != nil {
+		h.metrics.writeErrorsClient.Inc(1)
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	body, rErr := h.parseRequest(r)
+	if rErr != nil {
+		h.metrics.writeErrorsClient.Inc(1)
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	writes, lineErrs := h.newWrites(body, prec)
+	if len(lineErrs) > 0 {
+		h.metrics.invalidLines.Inc(int64(len(lineErrs)))
+	}
+
+	writeErrs := h.write(r.Context(), writes)
+	if len(lineErrs) == 0 && len(writeErrs) == 0 {
+		h.metrics.writeSuccess.Inc(1)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	code := http.StatusBadRequest
+	if len(writeErrs) > 0 {
+		code = http.StatusInternalServerError
+		h.metrics.writeErrorsServer.Inc(1)
+		logging.WithContext(r.Context()).Error("Write error",
+			zap.Int("numLines", len(writeErrs)),
+			zap.String("err", writeErrs[0].Error))
+	} else {
+		h.metrics.writeErrorsClient.Inc(1)
+	}
+
+	resp := newWriteResponse(append(lineErrs, writeErrs...))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *WriteHandler) parseRequest(r *http.Request) ([]byte, *xhttp.ParseError) {
+	if r.Body == nil {
+		return nil, xhttp.NewParseError(errEmptyBody, http.StatusBadRequest)
+	}
+
+	defer r.Body.Close()
+
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+		}
+
+		defer gz.Close()
+		body = gz
+	}
+
+	buf, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	return buf, nil
+}
+
+// newWrites parses the body of a request and groups the fields of every
+// valid line into one write per series.
+func (h *WriteHandler) newWrites(
+	body []byte,
+	prec precision,
+) ([]*seriesWrite, []LineError) {
+	var (
+		now      = h.nowFn()
+		writes   []*seriesWrite
+		byID     = make(map[string]*seriesWrite)
+		lineErrs []LineError
+	)
+	for i, line := range bytes.Split(body, []byte("\n")) {
+		lineNum := i + 1
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		p, err := parseLine(line)
+		if err != nil {
+			lineErrs = append(lineErrs, LineError{Line: lineNum, Error: err.Error()})
+			continue
+		}
+
+		timestamp := now.Truncate(prec.duration)
+		if p.hasTimestamp {
+			timestamp = time.Unix(0, p.timestamp*int64(prec.duration))
+		}
+
+		for _, f := range p.fields {
+			tags := models.NewTags(len(p.tags)+1, h.tagOptions).
+				AddTags(p.tags).
+				SetName(metricName(p.measurement, f.key))
+
+			id := tags.ID()
+			write, ok := byID[id]
+			if !ok {
+				write = &seriesWrite{
+					query: &storage.WriteQuery{
+						Tags: tags,
+						Unit: prec.unit,
+						Attributes: storage.Attributes{
+							MetricsType: storage.UnaggregatedMetricsType,
+						},
+					},
+				}
+				byID[id] = write
+				writes = append(writes, write)
+			}
+
+			write.query.Datapoints = append(write.query.Datapoints, ts.Datapoint{
+				Timestamp: timestamp,
+				Value:     f.value,
+			})
+			if n := len(write.lines); n == 0 || write.lines[n-1] != lineNum {
+				write.lines = append(write.lines, lineNum)
+			}
+		}
+	}
+
+	return writes, lineErrs
+}
+
+// write writes out all series in batches, returning an error for every
+// line that contributed to a failed series write.
+func (h *WriteHandler) write(
+	ctx context.Context,
+	writes []*seriesWrite,
+) []LineError {
+	var (
+		wg       sync.WaitGroup
+		errLock  sync.Mutex
+		lineErrs []LineError
+	)
+	for start := 0; start < len(writes); start += h.batchSize {
+		end := start + h.batchSize
+		if end > len(writes) {
+			end = len(writes)
+		}
+
+		for _, write := range writes[start:end] {
+			write := write // Capture for goroutine
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := h.store.Write(ctx, write.query); err != nil {
+					errLock.Lock()
+					for _, line := range write.lines {
+						lineErrs = append(lineErrs, LineError{Line: line, Error: err.Error()})
+					}
+					errLock.Unlock()
+				}
+			}()
+		}
+
+		wg.Wait()
+	}
+
+	return lineErrs
+}
+
+func newWriteResponse(lineErrs []LineError) WriteResponse {
+	sort.SliceStable(lineErrs, func(i, j int) bool {
+		return lineErrs[i].Line < lineErrs[j].Line
+	})
+
+	return WriteResponse{
+		Error: fmt.Sprintf("partial write: %d line(s) failed, first error on line %d: %s",
+			len(lineErrs), lineErrs[0].Line, lineErrs[0].Error),
+		Errors: lineErrs,
+	}
+}
+
+// metricName returns the name of the series for a field of a measurement.
+func metricName(measurement, field []byte) []byte {
+	name := make([]byte, 0, len(measurement)+len(field)+1)
+	name = append(name, measurement...)
+	name = append(name, '_')
+	return append(name, field...)
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/util/logging"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func newTestWriteHandler(store storage.Storage) *WriteHandler {
+	h := NewWriteHandler(store, models.NewTagOptions(), tally.NoopScope).(*WriteHandler)
+	h.batchSize = 2
+	return h
+}
+
+func TestWrite(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := newTestWriteHandler(store)
+	now := time.Unix(1465839850, 500)
+	h.nowFn = func() time.Time { return now }
+
+	body := strings.Join([]string{
+		"# comment",
+		"cpu,host=a idle=10,user=2i 1465839830",
+		"",
+		"cpu,host=a idle=11 1465839840",
+		`mem,host=a used=5,desc="free text"`,
+	}, "\n")
+	req := httptest.NewRequest(WriteHTTPMethod, WriteURL+"?precision=s",
+		strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	writes := store.Writes()
+	require.Len(t, writes, 3)
+
+	byName := make(map[string]*storage.WriteQuery)
+	for _, write := range writes {
+		name, ok := write.Tags.Name()
+		require.True(t, ok)
+		host, ok := write.Tags.Get([]byte("host"))
+		require.True(t, ok)
+		assert.Equal(t, "a", string(host))
+		assert.Equal(t, xtime.Second, write.Unit)
+		assert.Equal(t, storage.UnaggregatedMetricsType, write.Attributes.MetricsType)
+		byName[string(name)] = write
+	}
+
+	idle := byName["cpu_idle"]
+	require.NotNil(t, idle)
+	require.Len(t, idle.Datapoints, 2)
+	assert.Equal(t, time.Unix(1465839830, 0), idle.Datapoints[0].Timestamp)
+	assert.Equal(t, 10.0, idle.Datapoints[0].Value)
+	assert.Equal(t, time.Unix(1465839840, 0), idle.Datapoints[1].Timestamp)
+	assert.Equal(t, 11.0, idle.Datapoints[1].Value)
+
+	user := byName["cpu_user"]
+	require.NotNil(t, user)
+	require.Len(t, user.Datapoints, 1)
+	assert.Equal(t, 2.0, user.Datapoints[0].Value)
+
+	used := byName["mem_used"]
+	require.NotNil(t, used)
+	require.Len(t, used.Datapoints, 1)
+	assert.Equal(t, time.Unix(1465839850, 0), used.Datapoints[0].Timestamp)
+}
+
+func TestWriteGzip(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := newTestWriteHandler(store)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte("cpu value=1 1465839830100400200"))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	req := httptest.NewRequest(WriteHTTPMethod, WriteURL, &buf)
+	req.Header.Set("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+	writes := store.Writes()
+	require.Len(t, writes, 1)
+	assert.Equal(t, xtime.Nanosecond, writes[0].Unit)
+	assert.Equal(t, time.Unix(0, 1465839830100400200), writes[0].Datapoints[0].Timestamp)
+}
+
+func TestWriteInvalidPrecision(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := newTestWriteHandler(store)
+
+	req := httptest.NewRequest(WriteHTTPMethod, WriteURL+"?precision=h",
+		strings.NewReader("cpu value=1"))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Len(t, store.Writes(), 0)
+}
+
+func TestWriteInvalidLines(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := newTestWriteHandler(store)
+
+	body := strings.Join([]string{
+		"cpu value=1",
+		"cpu value=abc",
+		"mem used=2",
+		"disk",
+	}, "\n")
+	req := httptest.NewRequest(WriteHTTPMethod, WriteURL, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp WriteResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []LineError{
+		{Line: 2, Error: "invalid field value: abc"},
+		{Line: 4, Error: errMissingFields.Error()},
+	}, resp.Errors)
+	assert.Contains(t, resp.Error, "2 line(s) failed")
+
+	// Valid lines are still written.
+	assert.Len(t, store.Writes(), 2)
+}
+
+func TestWriteStorageError(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	store.SetWriteResult(errors.New("storage error"))
+	h := newTestWriteHandler(store)
+
+	body := "cpu value=1 1\ncpu value=2 2\nmem used=3 3"
+	req := httptest.NewRequest(WriteHTTPMethod, WriteURL, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var resp WriteResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []LineError{
+		{Line: 1, Error: "storage error"},
+		{Line: 2, Error: "storage error"},
+		{Line: 3, Error: "storage error"},
+	}, resp.Errors)
+}
@@ -23,6 +23,7 @@ package native
 import (
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"strconv"
 	"time"
@@ -37,6 +38,7 @@ import (
 	"github.com/m3db/m3/src/query/util/logging"
 	"github.com/m3db/m3/src/x/net/http"
 
+	pql "github.com/prometheus/prometheus/promql"
 	"go.uber.org/zap"
 )
 
@@ -47,8 +49,13 @@ const (
 	stepParam         = "step"
 	debugParam        = "debug"
 	endExclusiveParam = "end-exclusive"
+	timeParam         = "time"
 
 	formatErrStr = "error parsing param: %s, error: %v"
+
+	// instantQueryStep is the step used to evaluate instant queries, the
+	// value at the query time is taken from the last step of the result.
+	instantQueryStep = time.Second
 )
 
 func parseTime(r *http.Request, key string) (time.Time, error) {
@@ -140,6 +147,51 @@ func parseParams(r *http.Request) (models.RequestParams, *xhttp.ParseError) {
 	return params, nil
 }
 
+// parseInstantaneousParams parses all params from the GET request for an
+// instant query, evaluated at a single timestamp
+func parseInstantaneousParams(r *http.Request) (models.RequestParams, *xhttp.ParseError) {
+	params := models.RequestParams{
+		Now:        time.Now(),
+		Step:       instantQueryStep,
+		IncludeEnd: true,
+	}
+
+	t, err := prometheus.ParseRequestTimeout(r)
+	if err != nil {
+		return params, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+	params.Timeout = t
+
+	// Default to evaluating at the current time if no time is specified
+	evalTime, err := parseTime(r, timeParam)
+	if err == errors.ErrNotFound {
+		evalTime, err = params.Now, nil
+	}
+	if err != nil {
+		return params, xhttp.NewParseError(fmt.Errorf(formatErrStr, timeParam, err), http.StatusBadRequest)
+	}
+	params.Start = evalTime
+	params.End = evalTime
+
+	query, err := parseQuery(r)
+	if err != nil {
+		return params, xhttp.NewParseError(fmt.Errorf(formatErrStr, queryParam, err), http.StatusBadRequest)
+	}
+	params.Query = query
+
+	// Skip debug if unable to parse debug param
+	debugVal := r.FormValue(debugParam)
+	if debugVal != "" {
+		debug, err := strconv.ParseBool(r.FormValue(debugParam))
+		if err != nil {
+			logging.WithContext(r.Context()).Warn("unable to parse debug flag", zap.Any("error", err))
+		}
+		params.Debug = debug
+	}
+
+	return params, nil
+}
+
 func parseQuery(r *http.Request) (string, error) {
 	queries, ok := r.URL.Query()[queryParam]
 	if !ok || len(queries) == 0 || queries[0] == "" {
@@ -215,3 +267,134 @@ func renderResultsJSON(w io.Writer, series []*ts.Series, params models.RequestPa
 	jw.EndObject()
 	jw.Close()
 }
+
+// renderResultsInstantaneousJSON renders the results of an instant query,
+// the series of a matrix result hold the datapoints within the range of the
+// range vector whereas other results are read at the evaluation time.
+func renderResultsInstantaneousJSON(
+	w io.Writer,
+	series []*ts.Series,
+	params models.RequestParams,
+	valueType pql.ValueType,
+) {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginObject()
+
+	jw.BeginObjectField("resultType")
+	jw.WriteString(string(valueType))
+
+	jw.BeginObjectField("result")
+	switch valueType {
+	case pql.ValueTypeScalar:
+		value := math.NaN()
+		if len(series) > 0 {
+			if dp, ok := datapointAt(series[0], params.Start); ok {
+				value = dp.Value
+			}
+		}
+
+		renderDatapointJSON(jw, params.Start, value)
+
+	case pql.ValueTypeMatrix:
+		jw.BeginArray()
+		for _, s := range series {
+			vals := s.Values()
+			jw.BeginObject()
+			renderMetricJSON(jw, s)
+
+			jw.BeginObjectField("values")
+			jw.BeginArray()
+			for i := 0; i < vals.Len(); i++ {
+				dp := vals.DatapointAt(i)
+				renderDatapointJSON(jw, dp.Timestamp, dp.Value)
+			}
+			jw.EndArray()
+			jw.EndObject()
+		}
+		jw.EndArray()
+
+	default:
+		jw.BeginArray()
+		for _, s := range series {
+			dp, ok := datapointAt(s, params.Start)
+			if !ok || math.IsNaN(dp.Value) {
+				// Series without a value within the lookback are not returned
+				continue
+			}
+
+			jw.BeginObject()
+			renderMetricJSON(jw, s)
+
+			jw.BeginObjectField("value")
+			renderDatapointJSON(jw, params.Start, dp.Value)
+			jw.EndObject()
+		}
+		jw.EndArray()
+	}
+
+	jw.EndObject()
+
+	jw.EndObject()
+	jw.Close()
+}
+
+// rangeDatapoints returns the series with only their non NaN datapoints in
+// the range (start, end].
+func rangeDatapoints(
+	series []*ts.Series,
+	start, end time.Time,
+) []*ts.Series {
+	ranged := make([]*ts.Series, 0, len(series))
+	for _, s := range series {
+		vals := s.Values()
+		var dps ts.Datapoints
+		for i := 0; i < vals.Len(); i++ {
+			dp := vals.DatapointAt(i)
+			if !dp.Timestamp.After(start) || dp.Timestamp.After(end) ||
+				math.IsNaN(dp.Value) {
+				continue
+			}
+
+			dps = append(dps, dp)
+		}
+
+		ranged = append(ranged, ts.NewSeries(s.Name(), dps, s.Tags))
+	}
+
+	return ranged
+}
+
+// datapointAt returns the last datapoint of the series at or before t.
+func datapointAt(s *ts.Series, t time.Time) (ts.Datapoint, bool) {
+	vals := s.Values()
+	for i := vals.Len() - 1; i >= 0; i-- {
+		if dp := vals.DatapointAt(i); !dp.Timestamp.After(t) {
+			return dp, true
+		}
+	}
+
+	return ts.Datapoint{}, false
+}
+
+func renderMetricJSON(jw *json.Writer, s *ts.Series) {
+	jw.BeginObjectField("metric")
+	jw.BeginObject()
+	for _, t := range s.Tags.Tags {
+		jw.BeginObjectField(string(t.Name))
+		jw.WriteString(string(t.Value))
+	}
+	jw.EndObject()
+}
+
+func renderDatapointJSON(jw *json.Writer, t time.Time, value float64) {
+	jw.BeginArray()
+	jw.WriteInt(int(t.Unix()))
+	jw.WriteString(utils.FormatFloat(value))
+	jw.EndArray()
+}
@@ -23,6 +23,7 @@ package native
 import (
 	"bytes"
 	"encoding/json"
+	"math"
 	"net/http"
 	"net/url"
 	"testing"
@@ -33,6 +34,7 @@ import (
 	"github.com/m3db/m3/src/query/ts"
 	xtest "github.com/m3db/m3/src/x/test"
 
+	pql "github.com/prometheus/prometheus/promql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -179,3 +181,88 @@ func mustPrettyJSON(t *testing.T, str string) string {
 	require.NoError(t, err)
 	return string(pretty)
 }
+
+func TestParseInstantaneousParams(t *testing.T) {
+	vals := url.Values{}
+	vals.Add(queryParam, promQuery)
+	vals.Add(timeParam, "1535948880")
+	req, err := http.NewRequest(http.MethodGet, PromReadInstantURL, nil)
+	require.NoError(t, err)
+	req.URL.RawQuery = vals.Encode()
+
+	r, parseErr := parseInstantaneousParams(req)
+	require.Nil(t, parseErr)
+	assert.Equal(t, promQuery, r.Query)
+	assert.Equal(t, time.Unix(1535948880, 0), r.Start)
+	assert.Equal(t, r.Start, r.End)
+	assert.Equal(t, instantQueryStep, r.Step)
+	assert.True(t, r.IncludeEnd)
+}
+
+func TestParseInstantaneousParamsDefaultTime(t *testing.T) {
+	vals := url.Values{}
+	vals.Add(queryParam, promQuery)
+	req, err := http.NewRequest(http.MethodGet, PromReadInstantURL, nil)
+	require.NoError(t, err)
+	req.URL.RawQuery = vals.Encode()
+
+	r, parseErr := parseInstantaneousParams(req)
+	require.Nil(t, parseErr)
+	assert.Equal(t, r.Now, r.Start)
+	assert.Equal(t, r.Now, r.End)
+}
+
+func TestRenderInstantaneousResultsJSON(t *testing.T) {
+	start := time.Unix(1535948880, 0)
+	params := models.RequestParams{Start: start.Add(20 * time.Second)}
+	series := []*ts.Series{
+		ts.NewSeries("foo", ts.NewFixedStepValues(10*time.Second, 3, 1, start), test.TagSliceToTags([]models.Tag{
+			models.Tag{Name: []byte("bar"), Value: []byte("baz")},
+		})),
+		ts.NewSeries("bar", ts.NewFixedStepValues(10*time.Second, 3, math.NaN(), start), test.TagSliceToTags([]models.Tag{
+			models.Tag{Name: []byte("baz"), Value: []byte("bar")},
+		})),
+	}
+
+	tests := []struct {
+		name      string
+		valueType pql.ValueType
+		expected  string
+	}{
+		{
+			name:      "vector",
+			valueType: pql.ValueTypeVector,
+			expected: `{"status":"success","data":{"resultType":"vector","result":[` +
+				`{"metric":{"bar":"baz"},"value":[1535948900,"1"]}]}}`,
+		},
+		{
+			name:      "scalar",
+			valueType: pql.ValueTypeScalar,
+			expected: `{"status":"success","data":{"resultType":"scalar",` +
+				`"result":[1535948900,"1"]}}`,
+		},
+		{
+			name:      "matrix",
+			valueType: pql.ValueTypeMatrix,
+			expected: `{"status":"success","data":{"resultType":"matrix","result":[` +
+				`{"metric":{"bar":"baz"},"values":[[1535948890,"1"],[1535948900,"1"]]},` +
+				`{"metric":{"baz":"bar"},"values":[]}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := series
+			if tt.valueType == pql.ValueTypeMatrix {
+				// Matrix results only hold the datapoints within the range
+				input = rangeDatapoints(series, params.Start.Add(-20*time.Second), params.Start)
+			}
+
+			buffer := bytes.NewBuffer(nil)
+			renderResultsInstantaneousJSON(buffer, input, params, tt.valueType)
+			expected := mustPrettyJSON(t, tt.expected)
+			actual := mustPrettyJSON(t, buffer.String())
+			assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// LabelNamesURL is the url for the label names handler, this matches the
+	// default URL for the label names endpoint found on a Prometheus server
+	LabelNamesURL = handler.RoutePrefixV1 + "/labels"
+)
+
+var (
+	// LabelNamesHTTPMethods are the HTTP methods used with this resource.
+	LabelNamesHTTPMethods = []string{http.MethodGet, http.MethodPost}
+)
+
+// LabelNamesHandler represents a handler for the label names endpoint.
+type LabelNamesHandler struct {
+	querier storage.Querier
+	tagOpts models.TagOptions
+}
+
+// NewLabelNamesHandler returns a new instance of handler.
+func NewLabelNamesHandler(
+	querier storage.Querier,
+	tagOpts models.TagOptions,
+) http.Handler {
+	return &LabelNamesHandler{
+		querier: querier,
+		tagOpts: tagOpts,
+	}
+}
+
+func (h *LabelNamesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.WithContext(r.Context())
+
+	params, rErr := parseMetadataParams(r, h.tagOpts)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	matchers := params.matchers
+	if len(matchers) == 0 {
+		// Match every series with a name when no selectors are given
+		nameMatcher, err := models.NewMatcher(models.MatchRegexp,
+			h.tagOpts.MetricName(), []byte(anyValuePattern))
+		if err != nil {
+			xhttp.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		matchers = withTagMatcher(nil, nameMatcher)
+	}
+
+	metrics, truncated, err := fetchMetadata(r.Context(), h.querier, params, matchers)
+	if err != nil {
+		logger.Error("unable to fetch label names", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	names := make(map[string]struct{})
+	for _, metric := range metrics {
+		for _, tag := range metric.Tags.Tags {
+			names[promLabelName(tag.Name, h.tagOpts)] = struct{}{}
+		}
+	}
+
+	writeMetadataResponse(w, sortedKeys(names), params, truncated, logger)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+const (
+	labelNameVar = "name"
+
+	// promMetricName is the name Prometheus uses for the metric name label
+	promMetricName = "__name__"
+
+	// LabelValuesHTTPMethod is the HTTP method used with this resource.
+	LabelValuesHTTPMethod = http.MethodGet
+)
+
+var (
+	// LabelValuesURL is the url for the label values handler, this matches the
+	// default URL for the label values endpoint found on a Prometheus server
+	LabelValuesURL = fmt.Sprintf("%s/label/{%s}/values", handler.RoutePrefixV1, labelNameVar)
+
+	errEmptyLabelName = errors.New("must specify a label name")
+)
+
+// LabelValuesHandler represents a handler for the label values endpoint.
+type LabelValuesHandler struct {
+	querier storage.Querier
+	tagOpts models.TagOptions
+}
+
+// NewLabelValuesHandler returns a new instance of handler.
+func NewLabelValuesHandler(
+	querier storage.Querier,
+	tagOpts models.TagOptions,
+) http.Handler {
+	return &LabelValuesHandler{
+		querier: querier,
+		tagOpts: tagOpts,
+	}
+}
+
+func (h *LabelValuesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.WithContext(r.Context())
+
+	name := []byte(mux.Vars(r)[labelNameVar])
+	if len(name) == 0 {
+		xhttp.Error(w, errEmptyLabelName, http.StatusBadRequest)
+		return
+	}
+	if string(name) == promMetricName {
+		name = h.tagOpts.MetricName()
+	}
+
+	params, rErr := parseMetadataParams(r, h.tagOpts)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	// Only series with the label can contribute values, push this
+	// down so the index does not return any others
+	labelMatcher, err := models.NewMatcher(models.MatchRegexp, name, []byte(anyValuePattern))
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	matchers := withTagMatcher(params.matchers, labelMatcher)
+	metrics, truncated, err := fetchMetadata(r.Context(), h.querier, params, matchers)
+	if err != nil {
+		logger.Error("unable to fetch label values", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	values := make(map[string]struct{})
+	for _, metric := range metrics {
+		if value, ok := metric.Tags.Get(name); ok {
+			values[string(value)] = struct{}{}
+		}
+	}
+
+	writeMetadataResponse(w, sortedKeys(values), params, truncated, logger)
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	matchParam = "match[]"
+	limitParam = "limit"
+
+	// defaultMetadataLimit is the default maximum number of series inspected
+	// by a metadata request.
+	defaultMetadataLimit = 10000
+
+	// anyValuePattern matches any non empty tag value.
+	anyValuePattern = ".+"
+)
+
+// metadataResponse is the response for the metadata endpoints.
+type metadataResponse struct {
+	Status   string      `json:"status"`
+	Data     interface{} `json:"data"`
+	Warnings []string    `json:"warnings,omitempty"`
+}
+
+func newMetadataResponse(data interface{}) metadataResponse {
+	return metadataResponse{
+		Status: "success",
+		Data:   data,
+	}
+}
+
+// writeMetadataResponse writes the response of a metadata request, if the
+// series inspected were truncated by the limit a warning is added to both the
+// response and the warnings header since the results are incomplete.
+func writeMetadataResponse(
+	w http.ResponseWriter,
+	data interface{},
+	params metadataParams,
+	truncated bool,
+	logger *zap.Logger,
+) {
+	resp := newMetadataResponse(data)
+	if truncated {
+		warning := fmt.Sprintf("results truncated to the first %d series matched, "+
+			"increase the %s param or narrow the %s selectors", params.limit, limitParam, matchParam)
+		resp.Warnings = append(resp.Warnings, warning)
+		w.Header().Set(handler.WarningsHeader, warning)
+	}
+
+	xhttp.WriteJSONResponse(w, resp, logger)
+}
+
+// metadataParams are the params shared by the metadata endpoints.
+type metadataParams struct {
+	matchers []models.Matchers
+	start    time.Time
+	end      time.Time
+	limit    int
+}
+
+// parseMetadataParams parses the series selectors, time range and limit of a
+// metadata request. The time range defaults to all time up to now.
+func parseMetadataParams(
+	r *http.Request,
+	tagOpts models.TagOptions,
+) (metadataParams, *xhttp.ParseError) {
+	params := metadataParams{
+		start: time.Unix(0, 0),
+		end:   time.Now(),
+		limit: defaultMetadataLimit,
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return params, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	for _, selector := range r.Form[matchParam] {
+		matchers, err := promql.ParseSeriesMatchQuery(selector, tagOpts)
+		if err != nil {
+			return params, xhttp.NewParseError(fmt.Errorf(formatErrStr, matchParam, err), http.StatusBadRequest)
+		}
+
+		params.matchers = append(params.matchers, matchers)
+	}
+
+	start, err := parseTime(r, startParam)
+	if err == nil {
+		params.start = start
+	} else if err != errors.ErrNotFound {
+		return params, xhttp.NewParseError(fmt.Errorf(formatErrStr, startParam, err), http.StatusBadRequest)
+	}
+
+	end, err := parseTime(r, endParam)
+	if err == nil {
+		params.end = end
+	} else if err != errors.ErrNotFound {
+		return params, xhttp.NewParseError(fmt.Errorf(formatErrStr, endParam, err), http.StatusBadRequest)
+	}
+
+	if params.start.After(params.end) {
+		err := fmt.Errorf("start %v is after end %v", params.start, params.end)
+		return params, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	if limitVal := r.FormValue(limitParam); limitVal != "" {
+		limit, err := strconv.Atoi(limitVal)
+		if err != nil || limit <= 0 {
+			err := fmt.Errorf("invalid limit: %s", limitVal)
+			return params, xhttp.NewParseError(fmt.Errorf(formatErrStr, limitParam, err), http.StatusBadRequest)
+		}
+		params.limit = limit
+	}
+
+	return params, nil
+}
+
+// withTagMatcher returns a copy of every set of matchers with the given
+// matcher added, if no sets are given a set of just the matcher is returned.
+func withTagMatcher(sets []models.Matchers, matcher models.Matcher) []models.Matchers {
+	if len(sets) == 0 {
+		return []models.Matchers{{matcher}}
+	}
+
+	result := make([]models.Matchers, 0, len(sets))
+	for _, set := range sets {
+		matchers := make(models.Matchers, 0, len(set)+1)
+		matchers = append(matchers, set...)
+		result = append(result, append(matchers, matcher))
+	}
+
+	return result
+}
+
+// fetchMetadata fetches the tags of all series matching any of the sets of
+// matchers, up to the limit of the params, and returns whether there were more
+// series matched than the limit. Series matched by more than one set are only
+// returned once.
+func fetchMetadata(
+	ctx context.Context,
+	querier storage.Querier,
+	params metadataParams,
+	matchers []models.Matchers,
+) (models.Metrics, bool, error) {
+	var (
+		seen    = make(map[string]struct{})
+		metrics models.Metrics
+	)
+	for _, set := range matchers {
+		// NB: Fetch one more series than the limit so that truncation can be
+		// told apart from a set matching exactly the limit, every set fetches
+		// the full limit since any of the series could have been seen already.
+		query := &storage.FetchQuery{
+			TagMatchers: set,
+			Start:       params.start,
+			End:         params.end,
+		}
+		result, err := querier.FetchTags(ctx, query, &storage.FetchOptions{
+			Limit: params.limit + 1,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, metric := range result.Metrics {
+			if _, ok := seen[metric.ID]; ok {
+				continue
+			}
+
+			seen[metric.ID] = struct{}{}
+			metrics = append(metrics, metric)
+		}
+
+		if len(metrics) > params.limit {
+			return metrics[:params.limit], true, nil
+		}
+	}
+
+	return metrics, false, nil
+}
+
+// promLabelName returns the Prometheus label name for a tag name, which
+// differs from the tag name only for the metric name.
+func promLabelName(name []byte, tagOpts models.TagOptions) string {
+	if bytes.Equal(name, tagOpts.MetricName()) {
+		return promMetricName
+	}
+
+	return string(name)
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// metadataQuerier returns the metrics matching each FetchTags query and
+// records the queries and options it was called with.
+type metadataQuerier struct {
+	mock.Storage
+
+	metrics models.Metrics
+	queries []*storage.FetchQuery
+	opts    []*storage.FetchOptions
+}
+
+func newMetadataQuerier() *metadataQuerier {
+	tags := func(nameValues ...string) models.Tags {
+		var tags []models.Tag
+		for i := 0; i < len(nameValues); i += 2 {
+			tags = append(tags, models.Tag{
+				Name:  []byte(nameValues[i]),
+				Value: []byte(nameValues[i+1]),
+			})
+		}
+		return test.TagSliceToTags(tags)
+	}
+
+	metrics := models.Metrics{
+		{Tags: tags("__name__", "up", "job", "api", "instance", "a")},
+		{Tags: tags("__name__", "up", "job", "db", "instance", "b")},
+		{Tags: tags("__name__", "requests", "job", "api", "code", "200")},
+	}
+	for i := range metrics {
+		metrics[i].ID = metrics[i].Tags.ID()
+	}
+
+	return &metadataQuerier{
+		Storage: mock.NewMockStorage(),
+		metrics: metrics,
+	}
+}
+
+func (q *metadataQuerier) FetchTags(
+	_ context.Context,
+	query *storage.FetchQuery,
+	opts *storage.FetchOptions,
+) (*storage.SearchResults, error) {
+	q.queries = append(q.queries, query)
+	q.opts = append(q.opts, opts)
+
+	var result models.Metrics
+	for _, metric := range q.metrics {
+		if opts.Limit > 0 && len(result) >= opts.Limit {
+			break
+		}
+
+		matches := true
+		for _, matcher := range query.TagMatchers {
+			value, _ := metric.Tags.Get(matcher.Name)
+			if !matcher.Matches(value) {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			result = append(result, metric)
+		}
+	}
+
+	return &storage.SearchResults{Metrics: result}, nil
+}
+
+func serveMetadata(
+	t *testing.T,
+	h http.Handler,
+	target string,
+	params url.Values,
+	vars map[string]string,
+) (int, metadataResponse) {
+	req := httptest.NewRequest(http.MethodGet, target+"?"+params.Encode(), nil)
+	if vars != nil {
+		req = mux.SetURLVars(req, vars)
+	}
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+
+	var resp metadataResponse
+	if recorder.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	}
+
+	return recorder.Code, resp
+}
+
+func TestLabelNamesHandler(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	querier := newMetadataQuerier()
+	h := NewLabelNamesHandler(querier, models.NewTagOptions())
+
+	code, resp := serveMetadata(t, h, LabelNamesURL, url.Values{}, nil)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "success", resp.Status)
+	assert.Equal(t, []interface{}{"__name__", "code", "instance", "job"}, resp.Data)
+
+	// No selectors match every series with a name.
+	require.Len(t, querier.queries, 1)
+	require.Len(t, querier.queries[0].TagMatchers, 1)
+	assert.Equal(t, models.MatchRegexp, querier.queries[0].TagMatchers[0].Type)
+	assert.Equal(t, defaultMetadataLimit+1, querier.opts[0].Limit)
+	assert.Empty(t, resp.Warnings)
+
+	code, resp = serveMetadata(t, h, LabelNamesURL, url.Values{
+		matchParam: []string{`requests`},
+	}, nil)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []interface{}{"__name__", "code", "job"}, resp.Data)
+}
+
+func TestLabelValuesHandler(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	querier := newMetadataQuerier()
+	h := NewLabelValuesHandler(querier, models.NewTagOptions())
+
+	code, resp := serveMetadata(t, h, "/api/v1/label/job/values", url.Values{},
+		map[string]string{labelNameVar: "job"})
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []interface{}{"api", "db"}, resp.Data)
+
+	code, resp = serveMetadata(t, h, "/api/v1/label/__name__/values", url.Values{
+		matchParam: []string{`{job="api"}`},
+	}, map[string]string{labelNameVar: "__name__"})
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []interface{}{"requests", "up"}, resp.Data)
+
+	// The label matcher is pushed down alongside the selector.
+	require.Len(t, querier.queries, 2)
+	assert.Len(t, querier.queries[1].TagMatchers, 2)
+}
+
+func TestSeriesMatchHandler(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	querier := newMetadataQuerier()
+	h := NewSeriesMatchHandler(querier, models.NewTagOptions())
+
+	start := time.Unix(1535948880, 0)
+	code, resp := serveMetadata(t, h, SeriesMatchURL, url.Values{
+		matchParam: []string{`up{job="api"}`, `{job="api"}`},
+		startParam: []string{"1535948880"},
+		limitParam: []string{"10"},
+	}, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	// Series matched by multiple selectors are only returned once.
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"__name__": "up", "job": "api", "instance": "a"},
+		map[string]interface{}{"__name__": "requests", "job": "api", "code": "200"},
+	}, resp.Data)
+
+	require.Len(t, querier.queries, 2)
+	assert.Equal(t, start, querier.queries[0].Start)
+	assert.Equal(t, 11, querier.opts[0].Limit)
+	assert.Equal(t, 11, querier.opts[1].Limit)
+	assert.Empty(t, resp.Warnings)
+}
+
+func TestSeriesMatchHandlerLimit(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	querier := newMetadataQuerier()
+	h := NewSeriesMatchHandler(querier, models.NewTagOptions())
+
+	code, resp := serveMetadata(t, h, SeriesMatchURL, url.Values{
+		matchParam: []string{`up`, `requests`},
+		limitParam: []string{"2"},
+	}, nil)
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, resp.Data, 2)
+
+	// The limit was crossed by the second selector.
+	assert.Len(t, querier.queries, 2)
+	assert.Len(t, resp.Warnings, 1)
+}
+
+func TestLabelNamesHandlerLimitTruncated(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	querier := newMetadataQuerier()
+	h := NewLabelNamesHandler(querier, models.NewTagOptions())
+
+	// Three series are matched so a limit of two truncates the results.
+	req := httptest.NewRequest(http.MethodGet, LabelNamesURL+"?"+url.Values{
+		limitParam: []string{"2"},
+	}.Encode(), nil)
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var resp metadataResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	assert.Equal(t, []interface{}{"__name__", "instance", "job"}, resp.Data)
+	require.Len(t, resp.Warnings, 1)
+	assert.Equal(t, resp.Warnings[0], recorder.Header().Get(handler.WarningsHeader))
+
+	// A limit of exactly the number of series matched is not truncated.
+	code, resp := serveMetadata(t, h, LabelNamesURL, url.Values{
+		limitParam: []string{"3"},
+	}, nil)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []interface{}{"__name__", "code", "instance", "job"}, resp.Data)
+	assert.Empty(t, resp.Warnings)
+}
+
+func TestSeriesMatchHandlerErrors(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	h := NewSeriesMatchHandler(newMetadataQuerier(), models.NewTagOptions())
+
+	tests := []url.Values{
+		{},
+		{matchParam: []string{`sum(up)`}},
+		{matchParam: []string{`up`}, limitParam: []string{"-1"}},
+		{matchParam: []string{`up`}, startParam: []string{"2"}, endParam: []string{"1"}},
+	}
+
+	for _, params := range tests {
+		code, _ := serveMetadata(t, h, SeriesMatchURL, params, nil)
+		assert.Equal(t, http.StatusBadRequest, code, params.Encode())
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cache"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser/promql"
@@ -58,9 +59,10 @@ var (
 
 // PromReadHandler represents a handler for prometheus read endpoint.
 type PromReadHandler struct {
-	engine    *executor.Engine
-	tagOpts   models.TagOptions
-	limitsCfg *config.LimitsConfiguration
+	engine       *executor.Engine
+	tagOpts      models.TagOptions
+	limitsCfg    *config.LimitsConfiguration
+	resultsCache *cache.ResultsCache
 }
 
 // ReadResponse is the response that gets returned to the user
@@ -73,16 +75,19 @@ type blockWithMeta struct {
 	meta  block.Metadata
 }
 
-// NewPromReadHandler returns a new instance of handler.
+// NewPromReadHandler returns a new instance of handler. The results cache
+// is optional, queries are always executed in full when it is nil.
 func NewPromReadHandler(
 	engine *executor.Engine,
 	tagOpts models.TagOptions,
 	limitsCfg *config.LimitsConfiguration,
+	resultsCache *cache.ResultsCache,
 ) *PromReadHandler {
 	return &PromReadHandler{
-		engine:    engine,
-		tagOpts:   tagOpts,
-		limitsCfg: limitsCfg,
+		engine:       engine,
+		tagOpts:      tagOpts,
+		limitsCfg:    limitsCfg,
+		resultsCache: resultsCache,
 	}
 }
 
@@ -108,7 +113,7 @@ func (h *PromReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	result, err := h.read(ctx, w, params)
 	if err != nil {
 		logger.Error("unable to fetch data", zap.Error(err))
-		xhttp.Error(w, err, http.StatusBadRequest)
+		xhttp.Error(w, err, handler.QueryErrorStatusCode(err, http.StatusBadRequest))
 		return
 	}
 
@@ -122,6 +127,25 @@ func (h *PromReadHandler) read(
 	reqCtx context.Context,
 	w http.ResponseWriter,
 	params models.RequestParams,
+) ([]*ts.Series, error) {
+	if h.resultsCache == nil {
+		return read(reqCtx, h.engine, h.tagOpts, w, params)
+	}
+
+	return h.resultsCache.Read(reqCtx, params, func(
+		ctx context.Context,
+		params models.RequestParams,
+	) ([]*ts.Series, error) {
+		return read(ctx, h.engine, h.tagOpts, w, params)
+	})
+}
+
+func read(
+	reqCtx context.Context,
+	engine *executor.Engine,
+	tagOpts models.TagOptions,
+	w http.ResponseWriter,
+	params models.RequestParams,
 ) ([]*ts.Series, error) {
 	ctx, cancel := context.WithTimeout(reqCtx, params.Timeout)
 	defer cancel()
@@ -131,14 +155,14 @@ func (h *PromReadHandler) read(
 	handler.CloseWatcher(ctx, cancel, w)
 
 	// TODO: Capture timing
-	parser, err := promql.Parse(params.Query, h.tagOpts)
+	parser, err := promql.Parse(params.Query, tagOpts)
 	if err != nil {
 		return nil, err
 	}
 
 	// Results is closed by execute
 	results := make(chan executor.Query)
-	go h.engine.ExecuteExpr(ctx, parser, opts, params, results)
+	go engine.ExecuteExpr(ctx, parser, opts, params, results)
 
 	// Block slices are sorted by start time
 	// TODO: Pooling
@@ -205,16 +229,23 @@ func (h *PromReadHandler) read(
 }
 
 func (h *PromReadHandler) validateRequest(params *models.RequestParams) error {
+	return validateComputedDatapoints(h.limitsCfg, *params)
+}
+
+func validateComputedDatapoints(
+	limitsCfg *config.LimitsConfiguration,
+	params models.RequestParams,
+) error {
 	// Impose a rough limit on the number of returned time series. This is intended to prevent things like
 	// querying from the beginning of time with a 1s step size.
 	// Approach taken directly from prom.
 	numSteps := int64(params.End.Sub(params.Start) / params.Step)
-	if h.limitsCfg.MaxComputedDatapoints > 0 && numSteps > h.limitsCfg.MaxComputedDatapoints {
+	if limitsCfg.MaxComputedDatapoints > 0 && numSteps > limitsCfg.MaxComputedDatapoints {
 		return fmt.Errorf(
 			"querying from %v to %v with step size %v would result in too many datapoints "+
 				"(end - start / step > %d). Either decrease the query resolution (?step=XX), decrease the time window, "+
 				"or increase the limit (`limits.maxComputedDatapoints`)",
-			params.Start, params.End, params.Step, h.limitsCfg.MaxComputedDatapoints,
+			params.Start, params.End, params.Step, limitsCfg.MaxComputedDatapoints,
 		)
 	}
 
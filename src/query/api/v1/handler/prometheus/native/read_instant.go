@@ -0,0 +1,244 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	pql "github.com/prometheus/prometheus/promql"
+	"go.uber.org/zap"
+)
+
+const (
+	// PromReadInstantURL is the url for native prom instant read handler, this
+	// matches the default URL for the instant query endpoint found on a
+	// Prometheus server
+	PromReadInstantURL = handler.RoutePrefixV1 + "/query"
+
+	// PromReadInstantHTTPMethod is the HTTP method used with this resource.
+	PromReadInstantHTTPMethod = http.MethodGet
+
+	// defaultSubqueryStep is the resolution of subqueries without a step,
+	// since instant queries have no step of their own, this matches the
+	// default evaluation interval of Prometheus
+	defaultSubqueryStep = time.Minute
+)
+
+// PromReadInstantHandler represents a handler for prometheus instantaneous read endpoint.
+type PromReadInstantHandler struct {
+	engine    *executor.Engine
+	tagOpts   models.TagOptions
+	limitsCfg *config.LimitsConfiguration
+}
+
+// NewPromReadInstantHandler returns a new instance of handler.
+func NewPromReadInstantHandler(
+	engine *executor.Engine,
+	tagOpts models.TagOptions,
+	limitsCfg *config.LimitsConfiguration,
+) *PromReadInstantHandler {
+	return &PromReadInstantHandler{
+		engine:    engine,
+		tagOpts:   tagOpts,
+		limitsCfg: limitsCfg,
+	}
+}
+
+func (h *PromReadInstantHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx)
+
+	params, rErr := parseInstantaneousParams(r)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	if params.Debug {
+		logger.Info("Request params", zap.Any("params", params))
+	}
+
+	// The type of the expression determines the shape of the response
+	expr, err := promql.ParseExpr(params.Query)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	valueType := expr.Type()
+	if valueType == pql.ValueTypeString || valueType == pql.ValueTypeNone {
+		xhttp.Error(w, fmt.Errorf("unsupported result type: %s", valueType),
+			http.StatusBadRequest)
+		return
+	}
+
+	var result []*ts.Series
+	if valueType == pql.ValueTypeMatrix {
+		result, err = h.readMatrix(ctx, w, expr, params)
+	} else {
+		result, err = read(ctx, h.engine, h.tagOpts, w, params)
+	}
+
+	if err != nil {
+		logger.Error("unable to fetch data", zap.Error(err))
+		xhttp.Error(w, err, handler.QueryErrorStatusCode(err, http.StatusBadRequest))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	renderResultsInstantaneousJSON(w, result, params, valueType)
+}
+
+// readMatrix returns the datapoints of a range vector within its range before
+// the evaluation time.
+func (h *PromReadInstantHandler) readMatrix(
+	ctx context.Context,
+	w http.ResponseWriter,
+	expr promql.Expr,
+	params models.RequestParams,
+) ([]*ts.Series, error) {
+	n, ok := expr.Expr.(*pql.MatrixSelector)
+	if !ok {
+		return nil, fmt.Errorf("unsupported range vector expression: %s", expr)
+	}
+
+	if sq, ok := expr.Subquery(n); ok {
+		return h.readSubquery(ctx, w, sq, params)
+	}
+
+	return h.readRaw(ctx, w, n, params)
+}
+
+// readRaw returns the raw datapoints of the series selected by the matrix
+// selector within its range before the evaluation time.
+func (h *PromReadInstantHandler) readRaw(
+	reqCtx context.Context,
+	w http.ResponseWriter,
+	n *pql.MatrixSelector,
+	params models.RequestParams,
+) ([]*ts.Series, error) {
+	op, err := promql.NewSelectorFromMatrix(n, h.tagOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	fetch, ok := op.(functions.FetchOp)
+	if !ok {
+		return nil, fmt.Errorf("unexpected operation for matrix selector: %s", op)
+	}
+
+	ctx, cancel := context.WithTimeout(reqCtx, params.Timeout)
+	defer cancel()
+
+	// Detect clients closing connections
+	handler.CloseWatcher(ctx, cancel, w)
+
+	end := params.Start.Add(-1 * fetch.Offset)
+	start := end.Add(-1 * fetch.Range)
+	// The end of a fetch is exclusive whereas the range of a matrix selector
+	// includes the evaluation time
+	query := &storage.FetchQuery{
+		Start:       start,
+		End:         end.Add(time.Nanosecond),
+		TagMatchers: fetch.Matchers,
+		Interval:    params.Step,
+	}
+
+	// Results is closed by execute
+	results := make(chan *storage.QueryResult)
+	go h.engine.Execute(ctx, query, &executor.EngineOptions{}, results)
+
+	var series []*ts.Series
+	for result := range results {
+		if result.Err != nil {
+			err = result.Err
+			continue
+		}
+
+		series = append(series, rangeDatapoints(result.FetchResult.SeriesList, start, end)...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// readSubquery evaluates the inner expression of the subquery at its own
+// resolution within its range before the evaluation time.
+func (h *PromReadInstantHandler) readSubquery(
+	ctx context.Context,
+	w http.ResponseWriter,
+	sq promql.Subquery,
+	params models.RequestParams,
+) ([]*ts.Series, error) {
+	step := sq.Step
+	if step <= 0 {
+		step = defaultSubqueryStep
+	}
+
+	end := params.Start.Add(-1 * sq.Offset)
+	rangeStart := end.Add(-1 * sq.Range)
+	// Evaluation times are aligned to multiples of the subquery step, as in
+	// Prometheus, and the start of the range is exclusive
+	start := rangeStart.Truncate(step)
+	if !start.After(rangeStart) {
+		start = start.Add(step)
+	}
+
+	innerParams := params
+	innerParams.Query = sq.Expr
+	innerParams.Start = start
+	innerParams.End = end
+	innerParams.Step = step
+	innerParams.IncludeEnd = true
+
+	// The subquery is evaluated as a range query so is subject to the same
+	// limits as one
+	if err := validateComputedDatapoints(h.limitsCfg, innerParams); err != nil {
+		return nil, err
+	}
+
+	series, err := read(ctx, h.engine, h.tagOpts, w, innerParams)
+	if err != nil {
+		return nil, err
+	}
+
+	// As with raw datapoints, the offset only moves the range and the
+	// datapoints keep their own timestamps, the same as Prometheus
+	return rangeDatapoints(series, rangeStart, end), nil
+}
@@ -0,0 +1,377 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cost"
+	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/logging"
+	xtest "github.com/m3db/m3/src/x/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func newTestInstantHandler() (mock.Storage, *PromReadInstantHandler) {
+	mockStorage := mock.NewMockStorage()
+	return mockStorage, NewPromReadInstantHandler(
+		executor.NewEngine(mockStorage, tally.NewTestScope("test", nil), cost.Limits{}),
+		models.NewTagOptions(),
+		&config.LimitsConfiguration{},
+	)
+}
+
+func TestPromReadInstantHandler(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	evalTime := time.Unix(1535948880, 0)
+	values, bounds := test.GenerateValuesAndBounds(nil, &models.Bounds{
+		Start:    evalTime.Add(-4 * time.Minute),
+		Duration: 5 * time.Minute,
+		StepSize: time.Minute,
+	})
+
+	store, h := newTestInstantHandler()
+	b := test.NewBlockFromValues(bounds, values)
+	store.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+
+	vals := url.Values{}
+	vals.Add(queryParam, promQuery)
+	vals.Add(timeParam, strconv.FormatInt(evalTime.Unix(), 10))
+	req := httptest.NewRequest(PromReadInstantHTTPMethod,
+		PromReadInstantURL+"?"+vals.Encode(), nil)
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	expected := mustPrettyJSON(t, `
+	{
+		"status": "success",
+		"data": {
+			"resultType": "vector",
+			"result": [
+				{
+					"metric": {
+						"__name__": "dummy0",
+						"dummy0": "dummy0"
+					},
+					"value": [
+						1535948880,
+						"4"
+					]
+				},
+				{
+					"metric": {
+						"__name__": "dummy1",
+						"dummy1": "dummy1"
+					},
+					"value": [
+						1535948880,
+						"9"
+					]
+				}
+			]
+		}
+	}
+	`)
+	actual := mustPrettyJSON(t, recorder.Body.String())
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestPromReadInstantHandlerMatrix(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	evalTime := time.Unix(1535948880, 0)
+	store, h := newTestInstantHandler()
+	store.SetFetchResult(&storage.FetchResult{
+		SeriesList: ts.SeriesList{
+			ts.NewSeries("foo", ts.Datapoints{
+				{Timestamp: evalTime.Add(-90 * time.Second), Value: 1},
+				{Timestamp: evalTime.Add(-time.Minute), Value: 2},
+				{Timestamp: evalTime.Add(-45 * time.Second), Value: 3},
+				{Timestamp: evalTime.Add(-40 * time.Second), Value: 4},
+				{Timestamp: evalTime.Add(-10 * time.Second), Value: 5},
+				{Timestamp: evalTime, Value: 6},
+			}, test.TagSliceToTags([]models.Tag{
+				{Name: []byte("bar"), Value: []byte("baz")},
+			})),
+		},
+	}, nil)
+
+	vals := url.Values{}
+	vals.Add(queryParam, "foo[1m]")
+	vals.Add(timeParam, strconv.FormatInt(evalTime.Unix(), 10))
+	req := httptest.NewRequest(PromReadInstantHTTPMethod,
+		PromReadInstantURL+"?"+vals.Encode(), nil)
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	// Every raw datapoint within the range is returned, rather than a
+	// single consolidated value
+	expected := mustPrettyJSON(t, `
+	{
+		"status": "success",
+		"data": {
+			"resultType": "matrix",
+			"result": [
+				{
+					"metric": {
+						"bar": "baz"
+					},
+					"values": [
+						[1535948835, "3"],
+						[1535948840, "4"],
+						[1535948870, "5"],
+						[1535948880, "6"]
+					]
+				}
+			]
+		}
+	}
+	`)
+	actual := mustPrettyJSON(t, recorder.Body.String())
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestPromReadInstantHandlerSubquery(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	evalTime := time.Unix(1535948880, 0)
+	values, bounds := test.GenerateValuesAndBounds(nil, &models.Bounds{
+		Start:    evalTime.Add(-4 * time.Minute),
+		Duration: 5 * time.Minute,
+		StepSize: time.Minute,
+	})
+
+	store, h := newTestInstantHandler()
+	b := test.NewBlockFromValues(bounds, values)
+	store.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+
+	vals := url.Values{}
+	vals.Add(queryParam, "foo[3m:1m]")
+	vals.Add(timeParam, strconv.FormatInt(evalTime.Unix(), 10))
+	req := httptest.NewRequest(PromReadInstantHTTPMethod,
+		PromReadInstantURL+"?"+vals.Encode(), nil)
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	expected := mustPrettyJSON(t, `
+	{
+		"status": "success",
+		"data": {
+			"resultType": "matrix",
+			"result": [
+				{
+					"metric": {
+						"__name__": "dummy0",
+						"dummy0": "dummy0"
+					},
+					"values": [
+						[1535948760, "2"],
+						[1535948820, "3"],
+						[1535948880, "4"]
+					]
+				},
+				{
+					"metric": {
+						"__name__": "dummy1",
+						"dummy1": "dummy1"
+					},
+					"values": [
+						[1535948760, "7"],
+						[1535948820, "8"],
+						[1535948880, "9"]
+					]
+				}
+			]
+		}
+	}
+	`)
+	actual := mustPrettyJSON(t, recorder.Body.String())
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestPromReadInstantHandlerOffset(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	evalTime := time.Unix(1535948880, 0)
+	values, bounds := test.GenerateValuesAndBounds(nil, &models.Bounds{
+		Start:    evalTime.Add(-4 * time.Minute),
+		Duration: 5 * time.Minute,
+		StepSize: time.Minute,
+	})
+
+	store, h := newTestInstantHandler()
+	store.SetFetchResult(&storage.FetchResult{
+		SeriesList: ts.SeriesList{
+			ts.NewSeries("foo", ts.Datapoints{
+				{Timestamp: evalTime.Add(-150 * time.Second), Value: 1},
+				{Timestamp: evalTime.Add(-90 * time.Second), Value: 2},
+				{Timestamp: evalTime.Add(-time.Minute), Value: 3},
+				{Timestamp: evalTime.Add(-10 * time.Second), Value: 4},
+			}, test.TagSliceToTags([]models.Tag{
+				{Name: []byte("bar"), Value: []byte("baz")},
+			})),
+		},
+	}, nil)
+	store.SetFetchBlocksResult(block.Result{
+		Blocks: []block.Block{test.NewBlockFromValues(bounds, values)},
+	}, nil)
+
+	serve := func(query string) string {
+		vals := url.Values{}
+		vals.Add(queryParam, query)
+		vals.Add(timeParam, strconv.FormatInt(evalTime.Unix(), 10))
+		req := httptest.NewRequest(PromReadInstantHTTPMethod,
+			PromReadInstantURL+"?"+vals.Encode(), nil)
+
+		recorder := httptest.NewRecorder()
+		h.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		return mustPrettyJSON(t, recorder.Body.String())
+	}
+
+	// The offset moves the range back in time, the datapoints within it
+	// keep their own timestamps for both raw datapoints and subqueries
+	expected := mustPrettyJSON(t, `
+	{
+		"status": "success",
+		"data": {
+			"resultType": "matrix",
+			"result": [
+				{
+					"metric": {
+						"bar": "baz"
+					},
+					"values": [
+						[1535948790, "2"],
+						[1535948820, "3"]
+					]
+				}
+			]
+		}
+	}
+	`)
+	actual := serve("foo[1m] offset 1m")
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+
+	expected = mustPrettyJSON(t, `
+	{
+		"status": "success",
+		"data": {
+			"resultType": "matrix",
+			"result": [
+				{
+					"metric": {
+						"__name__": "dummy0",
+						"dummy0": "dummy0"
+					},
+					"values": [
+						[1535948760, "2"],
+						[1535948820, "3"]
+					]
+				},
+				{
+					"metric": {
+						"__name__": "dummy1",
+						"dummy1": "dummy1"
+					},
+					"values": [
+						[1535948760, "7"],
+						[1535948820, "8"]
+					]
+				}
+			]
+		}
+	}
+	`)
+	actual = serve("foo[2m:1m] offset 1m")
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestPromReadInstantHandlerSubqueryComputedDatapointsLimit(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	_, h := newTestInstantHandler()
+	h.limitsCfg = &config.LimitsConfiguration{MaxComputedDatapoints: 5}
+
+	vals := url.Values{}
+	vals.Add(queryParam, "foo[10m:1m]")
+	req := httptest.NewRequest(PromReadInstantHTTPMethod,
+		PromReadInstantURL+"?"+vals.Encode(), nil)
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestPromReadInstantHandlerInvalidQuery(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	_, h := newTestInstantHandler()
+
+	vals := url.Values{}
+	vals.Add(queryParam, `"foo"`)
+	req := httptest.NewRequest(PromReadInstantHTTPMethod,
+		PromReadInstantURL+"?"+vals.Encode(), nil)
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestPromReadInstantHandlerQueryLimit(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store, h := newTestInstantHandler()
+	store.SetFetchBlocksResult(block.Result{},
+		errors.QueryLimitError{Resource: "series", Limit: 10})
+
+	vals := url.Values{}
+	vals.Add(queryParam, promQuery)
+	req := httptest.NewRequest(PromReadInstantHTTPMethod,
+		PromReadInstantURL+"?"+vals.Encode(), nil)
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+}
@@ -32,6 +32,8 @@ import (
 
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cost"
+	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage/mock"
@@ -88,9 +90,10 @@ func newTestSetup() *testSetup {
 	return &testSetup{
 		Storage: mockStorage,
 		Handler: NewPromReadHandler(
-			executor.NewEngine(mockStorage, tally.NewTestScope("test", nil)),
+			executor.NewEngine(mockStorage, tally.NewTestScope("test", nil), cost.Limits{}),
 			models.NewTagOptions(),
 			&config.LimitsConfiguration{},
+			nil,
 		),
 	}
 }
@@ -128,6 +131,18 @@ func TestPromReadHandler_ServeHTTP_maxComputedDatapoints(t *testing.T) {
 	assert.Equal(t, expected, errResp.Error)
 }
 
+func TestPromReadHandler_ServeHTTP_queryLimit(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	setup := newTestSetup()
+	setup.Storage.SetFetchBlocksResult(block.Result{},
+		errors.QueryLimitError{Resource: "series", Limit: 10})
+
+	recorder := httptest.NewRecorder()
+	setup.Handler.ServeHTTP(recorder, newReadRequest(t, defaultParams()))
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+}
+
 func TestPromReadHandler_validateRequest(t *testing.T) {
 	dt := func(year int, month time.Month, day, hour int) time.Time {
 		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
@@ -0,0 +1,97 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// SeriesMatchURL is the url for the series match handler, this matches the
+	// default URL for the series endpoint found on a Prometheus server
+	SeriesMatchURL = handler.RoutePrefixV1 + "/series"
+)
+
+var (
+	// SeriesMatchHTTPMethods are the HTTP methods used with this resource.
+	SeriesMatchHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+	errNoSeriesSelector = errors.New("no match[] parameter provided")
+)
+
+// SeriesMatchHandler represents a handler for the series match endpoint.
+type SeriesMatchHandler struct {
+	querier storage.Querier
+	tagOpts models.TagOptions
+}
+
+// NewSeriesMatchHandler returns a new instance of handler.
+func NewSeriesMatchHandler(
+	querier storage.Querier,
+	tagOpts models.TagOptions,
+) http.Handler {
+	return &SeriesMatchHandler{
+		querier: querier,
+		tagOpts: tagOpts,
+	}
+}
+
+func (h *SeriesMatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.WithContext(r.Context())
+
+	params, rErr := parseMetadataParams(r, h.tagOpts)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	if len(params.matchers) == 0 {
+		xhttp.Error(w, errNoSeriesSelector, http.StatusBadRequest)
+		return
+	}
+
+	metrics, truncated, err := fetchMetadata(r.Context(), h.querier, params, params.matchers)
+	if err != nil {
+		logger.Error("unable to fetch series", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	series := make([]map[string]string, 0, len(metrics))
+	for _, metric := range metrics {
+		labels := make(map[string]string, len(metric.Tags.Tags))
+		for _, tag := range metric.Tags.Tags {
+			labels[promLabelName(tag.Name, h.tagOpts)] = string(tag.Value)
+		}
+		series = append(series, labels)
+	}
+
+	writeMetadataResponse(w, series, params, truncated, logger)
+}
@@ -96,9 +96,15 @@ func (h *PromReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	result, err := h.read(ctx, w, req, timeout)
 	if err != nil {
-		h.promReadMetrics.fetchErrorsServer.Inc(1)
+		code := handler.QueryErrorStatusCode(err, http.StatusInternalServerError)
+		if code == http.StatusInternalServerError {
+			h.promReadMetrics.fetchErrorsServer.Inc(1)
+		} else {
+			h.promReadMetrics.fetchErrorsClient.Inc(1)
+		}
+
 		logger.Error("unable to fetch data", zap.Any("error", err))
-		xhttp.Error(w, err, http.StatusInternalServerError)
+		xhttp.Error(w, err, code)
 		return
 	}
 
@@ -30,9 +30,12 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/x/metrics"
+	"github.com/m3db/m3/src/query/cost"
+	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
 	"github.com/m3db/m3/src/query/test"
 	"github.com/m3db/m3/src/query/test/m3"
 	"github.com/m3db/m3/src/query/util/logging"
@@ -54,8 +57,8 @@ func setupServer(t *testing.T) *httptest.Server {
 	// No calls expected on session object
 	lstore, session := m3.NewStorageAndSession(t, ctrl)
 	session.EXPECT().
-		FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(nil, false, fmt.Errorf("not initialized"))
+		FetchTaggedPages(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("not initialized"))
 	storage := test.NewSlowStorage(lstore, 10*time.Millisecond)
 	promRead := readHandler(storage)
 	server := httptest.NewServer(test.NewSlowHandler(promRead, 10*time.Millisecond))
@@ -63,14 +66,14 @@ func setupServer(t *testing.T) *httptest.Server {
 }
 
 func readHandler(store storage.Storage) *PromReadHandler {
-	return &PromReadHandler{engine: executor.NewEngine(store, tally.NewTestScope("test", nil)), promReadMetrics: promReadTestMetrics}
+	return &PromReadHandler{engine: executor.NewEngine(store, tally.NewTestScope("test", nil), cost.Limits{}), promReadMetrics: promReadTestMetrics}
 }
 
 func TestPromReadParsing(t *testing.T) {
 	logging.InitWithCores(nil)
 	ctrl := gomock.NewController(t)
 	storage, _ := m3.NewStorageAndSession(t, ctrl)
-	promRead := &PromReadHandler{engine: executor.NewEngine(storage, tally.NewTestScope("test", nil)), promReadMetrics: promReadTestMetrics}
+	promRead := &PromReadHandler{engine: executor.NewEngine(storage, tally.NewTestScope("test", nil), cost.Limits{}), promReadMetrics: promReadTestMetrics}
 	req, _ := http.NewRequest("POST", PromReadURL, test.GeneratePromReadBody(t))
 
 	r, err := promRead.parseRequest(req)
@@ -92,8 +95,8 @@ func TestPromReadStorageWithFetchError(t *testing.T) {
 	logging.InitWithCores(nil)
 	ctrl := gomock.NewController(t)
 	storage, session := m3.NewStorageAndSession(t, ctrl)
-	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(nil, true, fmt.Errorf("unable to get data"))
+	session.EXPECT().FetchTaggedPages(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("unable to get data"))
 	session.EXPECT().IteratorPools().
 		Return(nil, nil)
 	promRead := readHandler(storage)
@@ -143,8 +146,8 @@ func TestReadErrorMetricsCount(t *testing.T) {
 	logging.InitWithCores(nil)
 	ctrl := gomock.NewController(t)
 	storage, session := m3.NewStorageAndSession(t, ctrl)
-	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(nil, true, fmt.Errorf("unable to get data"))
+	session.EXPECT().FetchTaggedPages(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("unable to get data"))
 	session.EXPECT().IteratorPools().
 		Return(nil, nil)
 
@@ -153,7 +156,7 @@ func TestReadErrorMetricsCount(t *testing.T) {
 	defer closer.Close()
 	readMetrics := newPromReadMetrics(scope)
 
-	promRead := &PromReadHandler{engine: executor.NewEngine(storage, scope), promReadMetrics: readMetrics}
+	promRead := &PromReadHandler{engine: executor.NewEngine(storage, scope, cost.Limits{}), promReadMetrics: readMetrics}
 	req, _ := http.NewRequest("POST", PromReadURL, test.GeneratePromReadBody(t))
 	promRead.ServeHTTP(httptest.NewRecorder(), req)
 
@@ -163,3 +166,16 @@ func TestReadErrorMetricsCount(t *testing.T) {
 	}, 5*time.Second)
 	require.True(t, foundMetric)
 }
+
+func TestPromReadQueryLimitIsClientError(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	store.SetFetchResult(nil, errors.QueryLimitError{Resource: "series", Limit: 10})
+	promRead := readHandler(store)
+
+	req, _ := http.NewRequest("POST", PromReadURL, test.GeneratePromReadBody(t))
+	recorder := httptest.NewRecorder()
+	promRead.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	merrors "github.com/m3db/m3/src/metrics/errors"
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/metrics/rules/store/kv"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/m3db/m3x/clock"
+)
+
+const (
+	// DefaultNamespacesKey is the default KV key that holds the rule namespaces,
+	// it matches the key watched by the coordinator rules matcher.
+	DefaultNamespacesKey = "/namespaces"
+	// DefaultRuleSetKeyFmt is the default format of the KV key that holds a
+	// namespace ruleset.
+	DefaultRuleSetKeyFmt = "/ruleset/%s"
+	// DefaultNameTag is the default tag that holds the metric name.
+	DefaultNameTag = "__name__"
+
+	// HeaderRuleSetVersion is the header used to specify the ruleset version a
+	// change was based on, the change is rejected if the ruleset has moved on.
+	HeaderRuleSetVersion = "M3-RuleSet-Version"
+	// HeaderUpdatedBy is the header used to specify who made a change.
+	HeaderUpdatedBy = "M3-Updated-By"
+
+	namespaceVar = "namespace"
+	ruleIDVar    = "id"
+
+	defaultUpdatedBy = "m3coordinator"
+)
+
+var (
+	namespacesURL = handler.RoutePrefixV1 + "/rules/namespaces"
+	namespaceURL  = fmt.Sprintf("%s/{%s}", namespacesURL, namespaceVar)
+	ruleSetURL    = namespaceURL + "/ruleset"
+	mappingsURL   = namespaceURL + "/mapping-rules"
+	mappingURL    = fmt.Sprintf("%s/{%s}", mappingsURL, ruleIDVar)
+	rollupsURL    = namespaceURL + "/rollup-rules"
+	rollupURL     = fmt.Sprintf("%s/{%s}", rollupsURL, ruleIDVar)
+)
+
+var (
+	errEmptyNamespace = errors.New("must specify a namespace")
+	errEmptyRuleID    = errors.New("must specify a rule ID")
+	errNoValidator    = errors.New("no rules validation configured")
+)
+
+type storeFn func(clusterClient clusterclient.Client, cfg config.Configuration) (rules.Store, error)
+
+type validatorFn func(clusterClient clusterclient.Client, cfg config.Configuration) (rules.Validator, error)
+
+// Handler represents a generic handler for rules endpoints.
+// nolint: structcheck
+type Handler struct {
+	// This is used by other rules Handlers
+	client clusterclient.Client
+	cfg    config.Configuration
+
+	storeFn     storeFn
+	validatorFn validatorFn
+	nowFn       clock.NowFn
+}
+
+func newHandler(client clusterclient.Client, cfg config.Configuration) Handler {
+	return Handler{
+		client:      client,
+		cfg:         cfg,
+		storeFn:     Store,
+		validatorFn: Validator,
+		nowFn:       time.Now,
+	}
+}
+
+// Store returns a KV backed rules store from the m3cluster client, rulesets
+// written through the store are validated if validation is configured.
+func Store(clusterClient clusterclient.Client, cfg config.Configuration) (rules.Store, error) {
+	kvStore, err := clusterClient.Txn()
+	if err != nil {
+		return nil, err
+	}
+
+	var validator rules.Validator
+	if cfg.Rules.Validation != nil {
+		validator, err = Validator(clusterClient, cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	namespacesKey := cfg.Rules.NamespacesKey
+	if namespacesKey == "" {
+		namespacesKey = DefaultNamespacesKey
+	}
+	ruleSetKeyFmt := cfg.Rules.RuleSetKeyFmt
+	if ruleSetKeyFmt == "" {
+		ruleSetKeyFmt = DefaultRuleSetKeyFmt
+	}
+
+	opts := kv.NewStoreOptions(namespacesKey, ruleSetKeyFmt, validator)
+	return kv.NewStore(kvStore, opts), nil
+}
+
+// Validator returns the configured rules validator.
+func Validator(clusterClient clusterclient.Client, cfg config.Configuration) (rules.Validator, error) {
+	if cfg.Rules.Validation == nil {
+		return nil, errNoValidator
+	}
+	return cfg.Rules.Validation.NewValidator(clusterClient)
+}
+
+// RegisterRoutes registers the rules routes
+func RegisterRoutes(r *mux.Router, client clusterclient.Client, cfg config.Configuration) {
+	logged := logging.WithResponseTimeLogging
+
+	r.HandleFunc(NamespaceGetURL, logged(NewNamespaceGetHandler(client, cfg)).ServeHTTP).Methods(NamespaceGetHTTPMethod)
+	r.HandleFunc(NamespaceAddURL, logged(NewNamespaceAddHandler(client, cfg)).ServeHTTP).Methods(NamespaceAddHTTPMethod)
+	r.HandleFunc(NamespaceDeleteURL, logged(NewNamespaceDeleteHandler(client, cfg)).ServeHTTP).Methods(NamespaceDeleteHTTPMethod)
+
+	r.HandleFunc(RuleSetGetURL, logged(NewRuleSetGetHandler(client, cfg)).ServeHTTP).Methods(RuleSetGetHTTPMethod)
+	r.HandleFunc(RuleSetHistoryURL, logged(NewRuleSetHistoryHandler(client, cfg)).ServeHTTP).Methods(RuleSetHistoryHTTPMethod)
+	r.HandleFunc(RuleSetValidateURL, logged(NewRuleSetValidateHandler(client, cfg)).ServeHTTP).Methods(RuleSetValidateHTTPMethod)
+
+	r.HandleFunc(MappingRuleAddURL, logged(NewMappingRuleAddHandler(client, cfg)).ServeHTTP).Methods(MappingRuleAddHTTPMethod)
+	r.HandleFunc(MappingRuleUpdateURL, logged(NewMappingRuleUpdateHandler(client, cfg)).ServeHTTP).Methods(MappingRuleUpdateHTTPMethod)
+	r.HandleFunc(MappingRuleDeleteURL, logged(NewMappingRuleDeleteHandler(client, cfg)).ServeHTTP).Methods(MappingRuleDeleteHTTPMethod)
+
+	r.HandleFunc(RollupRuleAddURL, logged(NewRollupRuleAddHandler(client, cfg)).ServeHTTP).Methods(RollupRuleAddHTTPMethod)
+	r.HandleFunc(RollupRuleUpdateURL, logged(NewRollupRuleUpdateHandler(client, cfg)).ServeHTTP).Methods(RollupRuleUpdateHTTPMethod)
+	r.HandleFunc(RollupRuleDeleteURL, logged(NewRollupRuleDeleteHandler(client, cfg)).ServeHTTP).Methods(RollupRuleDeleteHTTPMethod)
+
+	r.HandleFunc(MatchURL, logged(NewMatchHandler(client, cfg)).ServeHTTP).Methods(MatchHTTPMethod)
+}
+
+// ruleSetResponse is the response to a ruleset change.
+type ruleSetResponse struct {
+	RuleID  string       `json:"ruleID,omitempty"`
+	RuleSet view.RuleSet `json:"ruleSet"`
+}
+
+// versionConflictError is returned when a change was based on a ruleset
+// version other than the current one.
+type versionConflictError struct {
+	expected int
+	current  int
+}
+
+func (e versionConflictError) Error() string {
+	return fmt.Sprintf("ruleset version %d does not match current version %d",
+		e.expected, e.current)
+}
+
+func (h Handler) updateMetadata(r *http.Request) rules.UpdateMetadata {
+	updatedBy := strings.TrimSpace(r.Header.Get(HeaderUpdatedBy))
+	if updatedBy == "" {
+		updatedBy = defaultUpdatedBy
+	}
+	helper := rules.NewRuleSetUpdateHelper(h.cfg.Rules.PropagationDelay)
+	return helper.NewUpdateMetadata(h.nowFn().UnixNano(), updatedBy)
+}
+
+// checkVersion verifies the ruleset version the request was based on, if any,
+// matches the version of the ruleset about to be changed.
+func checkVersion(r *http.Request, rs rules.RuleSet) *xhttp.ParseError {
+	v := strings.TrimSpace(r.Header.Get(HeaderRuleSetVersion))
+	if v == "" {
+		return nil
+	}
+
+	expected, err := strconv.Atoi(v)
+	if err != nil {
+		return xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+	if expected != rs.Version() {
+		err := versionConflictError{expected: expected, current: rs.Version()}
+		return xhttp.NewParseError(err, http.StatusConflict)
+	}
+	return nil
+}
+
+func parseRequest(r *http.Request, v interface{}) *xhttp.ParseError {
+	defer r.Body.Close()
+
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+	return nil
+}
+
+func namespaceName(r *http.Request) (string, *xhttp.ParseError) {
+	ns := strings.TrimSpace(mux.Vars(r)[namespaceVar])
+	if ns == "" {
+		return "", xhttp.NewParseError(errEmptyNamespace, http.StatusBadRequest)
+	}
+	return ns, nil
+}
+
+func ruleID(r *http.Request) (string, *xhttp.ParseError) {
+	id := strings.TrimSpace(mux.Vars(r)[ruleIDVar])
+	if id == "" {
+		return "", xhttp.NewParseError(errEmptyRuleID, http.StatusBadRequest)
+	}
+	return id, nil
+}
+
+// storeErrorCode returns the status code for an error returned by the store.
+func storeErrorCode(err error) int {
+	switch err.(type) {
+	case merrors.NotFoundError:
+		return http.StatusNotFound
+	case merrors.StaleDataError:
+		return http.StatusConflict
+	case merrors.ValidationError, merrors.InvalidInputError:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/metrics/rules/view"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testNamespace = "testNamespace"
+
+var testNow = time.Unix(1540000000, 0)
+
+func testHandler(store rules.Store) Handler {
+	return Handler{
+		cfg: config.Configuration{},
+		storeFn: func(clusterclient.Client, config.Configuration) (rules.Store, error) {
+			return store, nil
+		},
+		validatorFn: Validator,
+		nowFn:       func() time.Time { return testNow },
+	}
+}
+
+// testRuleSet returns a ruleset with a single mapping rule that matches
+// metrics named requests.
+func testRuleSet(t *testing.T) (rules.RuleSet, string) {
+	helper := rules.NewRuleSetUpdateHelper(0)
+	meta := helper.NewUpdateMetadata(testNow.Add(-time.Minute).UnixNano(), "test")
+	rs := rules.NewEmptyRuleSet(testNamespace, meta)
+	id, err := rs.AddMappingRule(view.MappingRule{
+		Name:          "requests",
+		Filter:        DefaultNameTag + ":requests",
+		AggregationID: aggregation.DefaultID,
+		StoragePolicies: policy.StoragePolicies{
+			policy.MustParseStoragePolicy("10s:2d"),
+		},
+	}, meta)
+	require.NoError(t, err)
+
+	pb, err := rs.Proto()
+	require.NoError(t, err)
+	res, err := rules.NewRuleSetFromProto(1, pb, rules.NewOptions())
+	require.NoError(t, err)
+	return res, id
+}
+
+func jsonBody(t *testing.T, v interface{}) io.Reader {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return bytes.NewReader(b)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"net/http"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// MappingRuleAddHTTPMethod is the HTTP method used with the mapping rule add resource.
+	MappingRuleAddHTTPMethod = http.MethodPost
+
+	// MappingRuleUpdateHTTPMethod is the HTTP method used with the mapping rule update resource.
+	MappingRuleUpdateHTTPMethod = http.MethodPut
+
+	// MappingRuleDeleteHTTPMethod is the HTTP method used with the mapping rule delete resource.
+	MappingRuleDeleteHTTPMethod = http.MethodDelete
+)
+
+var (
+	// MappingRuleAddURL is the url for the mapping rule add handler.
+	MappingRuleAddURL = mappingsURL
+
+	// MappingRuleUpdateURL is the url for the mapping rule update handler.
+	MappingRuleUpdateURL = mappingURL
+
+	// MappingRuleDeleteURL is the url for the mapping rule delete handler.
+	MappingRuleDeleteURL = mappingURL
+)
+
+// MappingRuleAddHandler is the handler for mapping rule adds.
+type MappingRuleAddHandler Handler
+
+// NewMappingRuleAddHandler returns a new instance of MappingRuleAddHandler.
+func NewMappingRuleAddHandler(client clusterclient.Client, cfg config.Configuration) *MappingRuleAddHandler {
+	h := MappingRuleAddHandler(newHandler(client, cfg))
+	return &h
+}
+
+func (h *MappingRuleAddHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+		req    view.MappingRule
+	)
+	if rErr := parseRequest(r, &req); rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	Handler(*h).updateRuleSet(w, r, func(rs rules.MutableRuleSet, meta rules.UpdateMetadata) (string, error) {
+		return rs.AddMappingRule(req, meta)
+	})
+}
+
+// MappingRuleUpdateHandler is the handler for mapping rule updates.
+type MappingRuleUpdateHandler Handler
+
+// NewMappingRuleUpdateHandler returns a new instance of MappingRuleUpdateHandler.
+func NewMappingRuleUpdateHandler(client clusterclient.Client, cfg config.Configuration) *MappingRuleUpdateHandler {
+	h := MappingRuleUpdateHandler(newHandler(client, cfg))
+	return &h
+}
+
+func (h *MappingRuleUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+		req    view.MappingRule
+	)
+	id, rErr := ruleID(r)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+	if rErr := parseRequest(r, &req); rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+	req.ID = id
+
+	Handler(*h).updateRuleSet(w, r, func(rs rules.MutableRuleSet, meta rules.UpdateMetadata) (string, error) {
+		return id, rs.UpdateMappingRule(req, meta)
+	})
+}
+
+// MappingRuleDeleteHandler is the handler for mapping rule deletes, the rule
+// is tombstoned so its history is kept.
+type MappingRuleDeleteHandler Handler
+
+// NewMappingRuleDeleteHandler returns a new instance of MappingRuleDeleteHandler.
+func NewMappingRuleDeleteHandler(client clusterclient.Client, cfg config.Configuration) *MappingRuleDeleteHandler {
+	h := MappingRuleDeleteHandler(newHandler(client, cfg))
+	return &h
+}
+
+func (h *MappingRuleDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+	)
+	id, rErr := ruleID(r)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	Handler(*h).updateRuleSet(w, r, func(rs rules.MutableRuleSet, meta rules.UpdateMetadata) (string, error) {
+		return id, rs.DeleteMappingRule(id, meta)
+	})
+}
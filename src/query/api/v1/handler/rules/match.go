@@ -0,0 +1,155 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/metrics/filters"
+	"github.com/m3db/m3/src/metrics/metadata"
+	"github.com/m3db/m3/src/metrics/metric/id/m3"
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// MatchHTTPMethod is the HTTP method used with the match resource.
+	MatchHTTPMethod = http.MethodGet
+
+	idParam = "id"
+)
+
+var (
+	// MatchURL is the url for the match handler.
+	MatchURL = namespaceURL + "/match"
+)
+
+var (
+	errEmptyMetricID = errors.New("must specify a metric ID, e.g. m3+name+tag1=value1,tag2=value2")
+)
+
+// matchResponse is the result of matching a metric ID against a ruleset.
+type matchResponse struct {
+	Version         int                      `json:"version"`
+	ForExistingID   metadata.StagedMetadatas `json:"forExistingID"`
+	ForNewRollupIDs []rollupIDResponse       `json:"forNewRollupIDs"`
+}
+
+type rollupIDResponse struct {
+	ID        string                   `json:"id"`
+	Metadatas metadata.StagedMetadatas `json:"metadatas"`
+}
+
+// MatchHandler is the handler for dry run matches of a metric ID against the
+// current ruleset of a namespace. Metric IDs use the m3 format, e.g.
+// m3+requests+env=prod,service=foo.
+type MatchHandler Handler
+
+// NewMatchHandler returns a new instance of MatchHandler.
+func NewMatchHandler(client clusterclient.Client, cfg config.Configuration) *MatchHandler {
+	h := MatchHandler(newHandler(client, cfg))
+	return &h
+}
+
+func (h *MatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+	)
+	metricID := strings.TrimSpace(r.URL.Query().Get(idParam))
+	if metricID == "" {
+		logger.Error("no metric ID to match", zap.Any("error", errEmptyMetricID))
+		xhttp.Error(w, errEmptyMetricID, http.StatusBadRequest)
+		return
+	}
+	if _, _, err := m3.NameAndTags([]byte(metricID)); err != nil {
+		logger.Error("unable to parse metric ID", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	rs, rErr := Handler(*h).readRuleSet(r)
+	if rErr != nil {
+		logger.Error("unable to read ruleset", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	// The store reads rulesets without any ID handling, so rebuild the
+	// ruleset with options that understand m3 formatted IDs.
+	pb, err := rs.Proto()
+	if err != nil {
+		logger.Error("unable to get ruleset protobuf", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+	rs, err = rules.NewRuleSetFromProto(rs.Version(), pb, h.matchOptions())
+	if err != nil {
+		logger.Error("unable to build ruleset", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	nowNanos := h.nowFn().UnixNano()
+	res := rs.ActiveSet(nowNanos).ForwardMatch([]byte(metricID), nowNanos, nowNanos+1)
+
+	resp := matchResponse{
+		Version:         res.Version(),
+		ForExistingID:   res.ForExistingIDAt(nowNanos),
+		ForNewRollupIDs: make([]rollupIDResponse, 0, res.NumNewRollupIDs()),
+	}
+	for i := 0; i < res.NumNewRollupIDs(); i++ {
+		rollup := res.ForNewRollupIDsAt(i, nowNanos)
+		resp.ForNewRollupIDs = append(resp.ForNewRollupIDs, rollupIDResponse{
+			ID:        string(rollup.ID),
+			Metadatas: rollup.Metadatas,
+		})
+	}
+	xhttp.WriteJSONResponse(w, resp, logger)
+}
+
+func (h *MatchHandler) matchOptions() rules.Options {
+	nameTag := h.cfg.Rules.NameTag
+	if nameTag == "" {
+		nameTag = DefaultNameTag
+	}
+
+	tagsFilterOpts := filters.TagsFilterOptions{
+		NameTagKey:          []byte(nameTag),
+		NameAndTagsFn:       m3.NameAndTags,
+		SortedTagIteratorFn: m3.NewSortedTagIterator,
+	}
+	isRollupIDFn := func(name []byte, tags []byte) bool {
+		return m3.IsRollupID(name, tags, nil)
+	}
+
+	return rules.NewOptions().
+		SetTagsFilterOptions(tagsFilterOpts).
+		SetNewRollupIDFn(m3.NewRollupID).
+		SetIsRollupIDFn(isRollupIDFn)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/metrics/rules"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rs, _ := testRuleSet(t)
+
+	store := rules.NewMockStore(ctrl)
+	store.EXPECT().ReadRuleSet(testNamespace).Return(rs, nil)
+	store.EXPECT().Close()
+
+	h := MatchHandler(testHandler(store))
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(MatchHTTPMethod,
+		"/?id="+url.QueryEscape("m3+requests+env=prod,service=foo"), nil)
+	req = mux.SetURLVars(req, map[string]string{namespaceVar: testNamespace})
+	h.ServeHTTP(w, req)
+
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body matchResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, 1, body.Version)
+	require.Len(t, body.ForExistingID, 1)
+	require.Len(t, body.ForExistingID[0].Pipelines, 1)
+	assert.Equal(t, policy.StoragePolicies{policy.MustParseStoragePolicy("10s:2d")},
+		body.ForExistingID[0].Pipelines[0].StoragePolicies)
+	assert.Empty(t, body.ForNewRollupIDs)
+}
+
+func TestMatchHandlerInvalidID(t *testing.T) {
+	h := MatchHandler(testHandler(nil))
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(MatchHTTPMethod, "/?id=requests", nil)
+	req = mux.SetURLVars(req, map[string]string{namespaceVar: testNamespace})
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
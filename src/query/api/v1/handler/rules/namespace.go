@@ -0,0 +1,272 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"net/http"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	merrors "github.com/m3db/m3/src/metrics/errors"
+	"github.com/m3db/m3/src/metrics/generated/proto/rulepb"
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// NamespaceGetHTTPMethod is the HTTP method used with the namespace get resource.
+	NamespaceGetHTTPMethod = http.MethodGet
+
+	// NamespaceAddHTTPMethod is the HTTP method used with the namespace add resource.
+	NamespaceAddHTTPMethod = http.MethodPost
+
+	// NamespaceDeleteHTTPMethod is the HTTP method used with the namespace delete resource.
+	NamespaceDeleteHTTPMethod = http.MethodDelete
+)
+
+var (
+	// NamespaceGetURL is the url for the rule namespaces get handler.
+	NamespaceGetURL = namespacesURL
+
+	// NamespaceAddURL is the url for the rule namespace add handler.
+	NamespaceAddURL = namespacesURL
+
+	// NamespaceDeleteURL is the url for the rule namespace delete handler.
+	NamespaceDeleteURL = namespaceURL
+)
+
+// NamespaceGetHandler is the handler for rule namespace gets.
+type NamespaceGetHandler Handler
+
+// NewNamespaceGetHandler returns a new instance of NamespaceGetHandler.
+func NewNamespaceGetHandler(client clusterclient.Client, cfg config.Configuration) *NamespaceGetHandler {
+	h := NamespaceGetHandler(newHandler(client, cfg))
+	return &h
+}
+
+func (h *NamespaceGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+	)
+
+	store, err := h.storeFn(h.client, h.cfg)
+	if err != nil {
+		logger.Error("unable to get rules store", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+	defer store.Close()
+
+	nss, err := readNamespaces(store)
+	if err != nil {
+		logger.Error("unable to read rule namespaces", zap.Any("error", err))
+		xhttp.Error(w, err, storeErrorCode(err))
+		return
+	}
+
+	resp, err := nss.NamespacesView()
+	if err != nil {
+		logger.Error("unable to get rule namespaces view", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+	xhttp.WriteJSONResponse(w, resp, logger)
+}
+
+// NamespaceAddHandler is the handler for rule namespace adds, adding a
+// namespace that was previously deleted revives it along with its ruleset.
+type NamespaceAddHandler Handler
+
+// NewNamespaceAddHandler returns a new instance of NamespaceAddHandler.
+func NewNamespaceAddHandler(client clusterclient.Client, cfg config.Configuration) *NamespaceAddHandler {
+	h := NamespaceAddHandler(newHandler(client, cfg))
+	return &h
+}
+
+func (h *NamespaceAddHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+		req    view.Namespace
+	)
+	if rErr := parseRequest(r, &req); rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+	if req.ID == "" {
+		logger.Error("no namespace to add", zap.Any("error", errEmptyNamespace))
+		xhttp.Error(w, errEmptyNamespace, http.StatusBadRequest)
+		return
+	}
+
+	store, err := h.storeFn(h.client, h.cfg)
+	if err != nil {
+		logger.Error("unable to get rules store", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+	defer store.Close()
+
+	nss, err := readNamespaces(store)
+	if err != nil {
+		logger.Error("unable to read rule namespaces", zap.Any("error", err))
+		xhttp.Error(w, err, storeErrorCode(err))
+		return
+	}
+
+	meta := Handler(*h).updateMetadata(r)
+	revived, err := nss.AddNamespace(req.ID, meta)
+	if err != nil {
+		logger.Error("unable to add rule namespace", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	var ruleSet rules.MutableRuleSet
+	rs, err := store.ReadRuleSet(req.ID)
+	switch err.(type) {
+	case nil:
+		ruleSet = rs.ToMutableRuleSet()
+		if revived {
+			err = ruleSet.Revive(meta)
+		}
+	case merrors.NotFoundError:
+		ruleSet, err = rules.NewEmptyRuleSet(req.ID, meta), nil
+	}
+	if err != nil {
+		logger.Error("unable to prepare ruleset", zap.Any("error", err))
+		xhttp.Error(w, err, storeErrorCode(err))
+		return
+	}
+
+	if err := store.WriteAll(nss, ruleSet); err != nil {
+		logger.Error("unable to persist rule namespace", zap.Any("error", err))
+		xhttp.Error(w, err, storeErrorCode(err))
+		return
+	}
+
+	writeNamespaces(w, store, logger)
+}
+
+// NamespaceDeleteHandler is the handler for rule namespace deletes, the
+// namespace and its ruleset are tombstoned rather than removed.
+type NamespaceDeleteHandler Handler
+
+// NewNamespaceDeleteHandler returns a new instance of NamespaceDeleteHandler.
+func NewNamespaceDeleteHandler(client clusterclient.Client, cfg config.Configuration) *NamespaceDeleteHandler {
+	h := NamespaceDeleteHandler(newHandler(client, cfg))
+	return &h
+}
+
+func (h *NamespaceDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+	)
+	ns, rErr := namespaceName(r)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	store, err := h.storeFn(h.client, h.cfg)
+	if err != nil {
+		logger.Error("unable to get rules store", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+	defer store.Close()
+
+	nss, err := store.ReadNamespaces()
+	if err != nil {
+		logger.Error("unable to read rule namespaces", zap.Any("error", err))
+		xhttp.Error(w, err, storeErrorCode(err))
+		return
+	}
+
+	rs, err := store.ReadRuleSet(ns)
+	if err != nil {
+		logger.Error("unable to read ruleset", zap.Any("error", err))
+		xhttp.Error(w, err, storeErrorCode(err))
+		return
+	}
+	if rErr := checkVersion(r, rs); rErr != nil {
+		logger.Error("unable to delete rule namespace", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	meta := Handler(*h).updateMetadata(r)
+	ruleSet := rs.ToMutableRuleSet()
+	if err := nss.DeleteNamespace(ns, ruleSet.Version(), meta); err != nil {
+		logger.Error("unable to delete rule namespace", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := ruleSet.Delete(meta); err != nil {
+		logger.Error("unable to delete ruleset", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := store.WriteAll(nss, ruleSet); err != nil {
+		logger.Error("unable to persist rule namespace deletion", zap.Any("error", err))
+		xhttp.Error(w, err, storeErrorCode(err))
+		return
+	}
+
+	writeNamespaces(w, store, logger)
+}
+
+// readNamespaces reads the rule namespaces, having no namespaces at all is
+// the same as having an empty set of namespaces.
+func readNamespaces(store rules.Store) (*rules.Namespaces, error) {
+	nss, err := store.ReadNamespaces()
+	if _, ok := err.(merrors.NotFoundError); ok {
+		empty, err := rules.NewNamespaces(0, &rulepb.Namespaces{})
+		return &empty, err
+	}
+	return nss, err
+}
+
+func writeNamespaces(w http.ResponseWriter, store rules.Store, logger *zap.Logger) {
+	nss, err := store.ReadNamespaces()
+	if err != nil {
+		logger.Error("unable to read rule namespaces", zap.Any("error", err))
+		xhttp.Error(w, err, storeErrorCode(err))
+		return
+	}
+
+	resp, err := nss.NamespacesView()
+	if err != nil {
+		logger.Error("unable to get rule namespaces view", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+	xhttp.WriteJSONResponse(w, resp, logger)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	merrors "github.com/m3db/m3/src/metrics/errors"
+	"github.com/m3db/m3/src/metrics/generated/proto/rulepb"
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/metrics/rules/view"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceGetHandlerNoNamespaces(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := rules.NewMockStore(ctrl)
+	store.EXPECT().ReadNamespaces().Return(nil, merrors.NewNotFoundError("not found"))
+	store.EXPECT().Close()
+
+	h := NamespaceGetHandler(testHandler(store))
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(NamespaceGetHTTPMethod, NamespaceGetURL, nil)
+	h.ServeHTTP(w, req)
+
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var nss view.Namespaces
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&nss))
+	assert.Equal(t, 0, nss.Version)
+	assert.Empty(t, nss.Namespaces)
+}
+
+func TestNamespaceAddHandlerNewNamespace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	added, err := rules.NewNamespaces(1, &rulepb.Namespaces{
+		Namespaces: []*rulepb.Namespace{
+			{
+				Name: testNamespace,
+				Snapshots: []*rulepb.NamespaceSnapshot{
+					{ForRulesetVersion: 1, LastUpdatedBy: "test"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	store := rules.NewMockStore(ctrl)
+	gomock.InOrder(
+		store.EXPECT().ReadNamespaces().Return(nil, merrors.NewNotFoundError("not found")),
+		store.EXPECT().ReadRuleSet(testNamespace).Return(nil, merrors.NewNotFoundError("not found")),
+		store.EXPECT().WriteAll(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(nss *rules.Namespaces, rs rules.MutableRuleSet) error {
+				_, err := nss.Namespace(testNamespace)
+				require.NoError(t, err)
+				require.Equal(t, testNamespace, string(rs.Namespace()))
+				require.Equal(t, 0, rs.Version())
+				return nil
+			}),
+		store.EXPECT().ReadNamespaces().Return(&added, nil),
+		store.EXPECT().Close(),
+	)
+
+	h := NamespaceAddHandler(testHandler(store))
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(NamespaceAddHTTPMethod, NamespaceAddURL,
+		jsonBody(t, view.Namespace{ID: testNamespace}))
+	h.ServeHTTP(w, req)
+
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var nss view.Namespaces
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&nss))
+	require.Len(t, nss.Namespaces, 1)
+	assert.Equal(t, testNamespace, nss.Namespaces[0].ID)
+}
+
+func TestNamespaceDeleteHandlerVersionConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rs, _ := testRuleSet(t)
+	nss, err := rules.NewNamespaces(1, &rulepb.Namespaces{})
+	require.NoError(t, err)
+
+	store := rules.NewMockStore(ctrl)
+	store.EXPECT().ReadNamespaces().Return(&nss, nil)
+	store.EXPECT().ReadRuleSet(testNamespace).Return(rs, nil)
+	store.EXPECT().Close()
+
+	h := NamespaceDeleteHandler(testHandler(store))
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(NamespaceDeleteHTTPMethod, "/", nil)
+	req = mux.SetURLVars(req, map[string]string{namespaceVar: testNamespace})
+	req.Header.Set(HeaderRuleSetVersion, "3")
+	h.ServeHTTP(w, req)
+
+	resp := w.Result()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"net/http"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// RollupRuleAddHTTPMethod is the HTTP method used with the rollup rule add resource.
+	RollupRuleAddHTTPMethod = http.MethodPost
+
+	// RollupRuleUpdateHTTPMethod is the HTTP method used with the rollup rule update resource.
+	RollupRuleUpdateHTTPMethod = http.MethodPut
+
+	// RollupRuleDeleteHTTPMethod is the HTTP method used with the rollup rule delete resource.
+	RollupRuleDeleteHTTPMethod = http.MethodDelete
+)
+
+var (
+	// RollupRuleAddURL is the url for the rollup rule add handler.
+	RollupRuleAddURL = rollupsURL
+
+	// RollupRuleUpdateURL is the url for the rollup rule update handler.
+	RollupRuleUpdateURL = rollupURL
+
+	// RollupRuleDeleteURL is the url for the rollup rule delete handler.
+	RollupRuleDeleteURL = rollupURL
+)
+
+// RollupRuleAddHandler is the handler for rollup rule adds.
+type RollupRuleAddHandler Handler
+
+// NewRollupRuleAddHandler returns a new instance of RollupRuleAddHandler.
+func NewRollupRuleAddHandler(client clusterclient.Client, cfg config.Configuration) *RollupRuleAddHandler {
+	h := RollupRuleAddHandler(newHandler(client, cfg))
+	return &h
+}
+
+func (h *RollupRuleAddHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+		req    view.RollupRule
+	)
+	if rErr := parseRequest(r, &req); rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	Handler(*h).updateRuleSet(w, r, func(rs rules.MutableRuleSet, meta rules.UpdateMetadata) (string, error) {
+		return rs.AddRollupRule(req, meta)
+	})
+}
+
+// RollupRuleUpdateHandler is the handler for rollup rule updates.
+type RollupRuleUpdateHandler Handler
+
+// NewRollupRuleUpdateHandler returns a new instance of RollupRuleUpdateHandler.
+func NewRollupRuleUpdateHandler(client clusterclient.Client, cfg config.Configuration) *RollupRuleUpdateHandler {
+	h := RollupRuleUpdateHandler(newHandler(client, cfg))
+	return &h
+}
+
+func (h *RollupRuleUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+		req    view.RollupRule
+	)
+	id, rErr := ruleID(r)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+	if rErr := parseRequest(r, &req); rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+	req.ID = id
+
+	Handler(*h).updateRuleSet(w, r, func(rs rules.MutableRuleSet, meta rules.UpdateMetadata) (string, error) {
+		return id, rs.UpdateRollupRule(req, meta)
+	})
+}
+
+// RollupRuleDeleteHandler is the handler for rollup rule deletes, the rule
+// is tombstoned so its history is kept.
+type RollupRuleDeleteHandler Handler
+
+// NewRollupRuleDeleteHandler returns a new instance of RollupRuleDeleteHandler.
+func NewRollupRuleDeleteHandler(client clusterclient.Client, cfg config.Configuration) *RollupRuleDeleteHandler {
+	h := RollupRuleDeleteHandler(newHandler(client, cfg))
+	return &h
+}
+
+func (h *RollupRuleDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+	)
+	id, rErr := ruleID(r)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	Handler(*h).updateRuleSet(w, r, func(rs rules.MutableRuleSet, meta rules.UpdateMetadata) (string, error) {
+		return id, rs.DeleteRollupRule(id, meta)
+	})
+}
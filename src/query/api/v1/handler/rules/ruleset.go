@@ -0,0 +1,285 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"net/http"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// RuleSetGetHTTPMethod is the HTTP method used with the ruleset get resource.
+	RuleSetGetHTTPMethod = http.MethodGet
+
+	// RuleSetHistoryHTTPMethod is the HTTP method used with the ruleset history resource.
+	RuleSetHistoryHTTPMethod = http.MethodGet
+
+	// RuleSetValidateHTTPMethod is the HTTP method used with the ruleset validate resource.
+	RuleSetValidateHTTPMethod = http.MethodPost
+)
+
+var (
+	// RuleSetGetURL is the url for the ruleset get handler.
+	RuleSetGetURL = ruleSetURL
+
+	// RuleSetHistoryURL is the url for the ruleset history handler.
+	RuleSetHistoryURL = ruleSetURL + "/history"
+
+	// RuleSetValidateURL is the url for the ruleset validate handler.
+	RuleSetValidateURL = ruleSetURL + "/validate"
+)
+
+// RuleSetGetHandler is the handler for ruleset gets, returning the latest
+// snapshot of every rule in the ruleset.
+type RuleSetGetHandler Handler
+
+// NewRuleSetGetHandler returns a new instance of RuleSetGetHandler.
+func NewRuleSetGetHandler(client clusterclient.Client, cfg config.Configuration) *RuleSetGetHandler {
+	h := RuleSetGetHandler(newHandler(client, cfg))
+	return &h
+}
+
+func (h *RuleSetGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+	)
+
+	rs, rErr := Handler(*h).readRuleSet(r)
+	if rErr != nil {
+		logger.Error("unable to read ruleset", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	resp, err := rs.Latest()
+	if err != nil {
+		logger.Error("unable to get ruleset view", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+	xhttp.WriteJSONResponse(w, resp, logger)
+}
+
+// ruleSetHistoryResponse is the full snapshot history of every rule in a
+// ruleset indexed by rule ID.
+type ruleSetHistoryResponse struct {
+	Version      int               `json:"version"`
+	MappingRules view.MappingRules `json:"mappingRules"`
+	RollupRules  view.RollupRules  `json:"rollupRules"`
+}
+
+// RuleSetHistoryHandler is the handler for ruleset history gets.
+type RuleSetHistoryHandler Handler
+
+// NewRuleSetHistoryHandler returns a new instance of RuleSetHistoryHandler.
+func NewRuleSetHistoryHandler(client clusterclient.Client, cfg config.Configuration) *RuleSetHistoryHandler {
+	h := RuleSetHistoryHandler(newHandler(client, cfg))
+	return &h
+}
+
+func (h *RuleSetHistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+	)
+
+	rs, rErr := Handler(*h).readRuleSet(r)
+	if rErr != nil {
+		logger.Error("unable to read ruleset", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	mappingRules, err := rs.MappingRules()
+	if err != nil {
+		logger.Error("unable to get mapping rule history", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+	rollupRules, err := rs.RollupRules()
+	if err != nil {
+		logger.Error("unable to get rollup rule history", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := ruleSetHistoryResponse{
+		Version:      rs.Version(),
+		MappingRules: mappingRules,
+		RollupRules:  rollupRules,
+	}
+	xhttp.WriteJSONResponse(w, resp, logger)
+}
+
+// RuleSetValidateHandler is the handler for validating a ruleset snapshot
+// without persisting it.
+type RuleSetValidateHandler Handler
+
+// NewRuleSetValidateHandler returns a new instance of RuleSetValidateHandler.
+func NewRuleSetValidateHandler(client clusterclient.Client, cfg config.Configuration) *RuleSetValidateHandler {
+	h := RuleSetValidateHandler(newHandler(client, cfg))
+	return &h
+}
+
+func (h *RuleSetValidateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+		req    view.RuleSet
+	)
+	ns, rErr := namespaceName(r)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+	if rErr := parseRequest(r, &req); rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+	req.Namespace = ns
+
+	validator, err := h.validatorFn(h.client, h.cfg)
+	if err != nil {
+		logger.Error("unable to get rules validator", zap.Any("error", err))
+		code := http.StatusInternalServerError
+		if err == errNoValidator {
+			code = http.StatusBadRequest
+		}
+		xhttp.Error(w, err, code)
+		return
+	}
+	defer validator.Close()
+
+	if err := validator.ValidateSnapshot(req); err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	xhttp.WriteJSONResponse(w, struct {
+		Valid bool `json:"valid"`
+	}{
+		Valid: true,
+	}, logger)
+}
+
+// readRuleSet reads the ruleset of the namespace in the request path.
+func (h Handler) readRuleSet(r *http.Request) (rules.RuleSet, *xhttp.ParseError) {
+	ns, rErr := namespaceName(r)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	store, err := h.storeFn(h.client, h.cfg)
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusInternalServerError)
+	}
+	defer store.Close()
+
+	rs, err := store.ReadRuleSet(ns)
+	if err != nil {
+		return nil, xhttp.NewParseError(err, storeErrorCode(err))
+	}
+	return rs, nil
+}
+
+// ruleSetUpdateFn applies a change to a ruleset, returning the ID of the rule
+// changed if any.
+type ruleSetUpdateFn func(rs rules.MutableRuleSet, meta rules.UpdateMetadata) (string, error)
+
+// updateRuleSet reads the ruleset of the namespace in the request path,
+// applies the change to it and writes it back. The write fails if the
+// ruleset has changed since the version the request was based on, or since
+// it was read.
+func (h Handler) updateRuleSet(w http.ResponseWriter, r *http.Request, fn ruleSetUpdateFn) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+	)
+	ns, rErr := namespaceName(r)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	store, err := h.storeFn(h.client, h.cfg)
+	if err != nil {
+		logger.Error("unable to get rules store", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+	defer store.Close()
+
+	rs, err := store.ReadRuleSet(ns)
+	if err != nil {
+		logger.Error("unable to read ruleset", zap.Any("error", err))
+		xhttp.Error(w, err, storeErrorCode(err))
+		return
+	}
+	if rErr := checkVersion(r, rs); rErr != nil {
+		logger.Error("unable to update ruleset", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	ruleSet := rs.ToMutableRuleSet()
+	ruleID, err := fn(ruleSet, h.updateMetadata(r))
+	if err != nil {
+		logger.Error("unable to update ruleset", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := store.WriteRuleSet(ruleSet); err != nil {
+		logger.Error("unable to persist ruleset", zap.Any("error", err))
+		xhttp.Error(w, err, storeErrorCode(err))
+		return
+	}
+
+	rs, err = store.ReadRuleSet(ns)
+	if err != nil {
+		logger.Error("unable to read ruleset", zap.Any("error", err))
+		xhttp.Error(w, err, storeErrorCode(err))
+		return
+	}
+	latest, err := rs.Latest()
+	if err != nil {
+		logger.Error("unable to get ruleset view", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	xhttp.WriteJSONResponse(w, ruleSetResponse{
+		RuleID:  ruleID,
+		RuleSet: latest,
+	}, logger)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	merrors "github.com/m3db/m3/src/metrics/errors"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/metrics/rules/view"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleSetGetHandlerNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := rules.NewMockStore(ctrl)
+	store.EXPECT().ReadRuleSet(testNamespace).Return(nil, merrors.NewNotFoundError("not found"))
+	store.EXPECT().Close()
+
+	h := RuleSetGetHandler(testHandler(store))
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(RuleSetGetHTTPMethod, "/", nil)
+	req = mux.SetURLVars(req, map[string]string{namespaceVar: testNamespace})
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+}
+
+func TestMappingRuleAddHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rs, _ := testRuleSet(t)
+	updated, _ := testRuleSet(t)
+
+	store := rules.NewMockStore(ctrl)
+	gomock.InOrder(
+		store.EXPECT().ReadRuleSet(testNamespace).Return(rs, nil),
+		store.EXPECT().WriteRuleSet(gomock.Any()).DoAndReturn(
+			func(rs rules.MutableRuleSet) error {
+				latest, err := rs.Latest()
+				require.NoError(t, err)
+				require.Len(t, latest.MappingRules, 2)
+				return nil
+			}),
+		store.EXPECT().ReadRuleSet(testNamespace).Return(updated, nil),
+		store.EXPECT().Close(),
+	)
+
+	h := MappingRuleAddHandler(testHandler(store))
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(MappingRuleAddHTTPMethod, "/", jsonBody(t, view.MappingRule{
+		Name:   "errors",
+		Filter: DefaultNameTag + ":errors",
+		StoragePolicies: policy.StoragePolicies{
+			policy.MustParseStoragePolicy("1m:40d"),
+		},
+	}))
+	req = mux.SetURLVars(req, map[string]string{namespaceVar: testNamespace})
+	req.Header.Set(HeaderRuleSetVersion, "1")
+	req.Header.Set(HeaderUpdatedBy, "test")
+	h.ServeHTTP(w, req)
+
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body ruleSetResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.NotEmpty(t, body.RuleID)
+	assert.Equal(t, testNamespace, body.RuleSet.Namespace)
+}
+
+func TestMappingRuleDeleteHandlerStaleWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rs, id := testRuleSet(t)
+
+	store := rules.NewMockStore(ctrl)
+	store.EXPECT().ReadRuleSet(testNamespace).Return(rs, nil)
+	store.EXPECT().WriteRuleSet(gomock.Any()).Return(merrors.NewStaleDataError("stale"))
+	store.EXPECT().Close()
+
+	h := MappingRuleDeleteHandler(testHandler(store))
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(MappingRuleDeleteHTTPMethod, "/", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		namespaceVar: testNamespace,
+		ruleIDVar:    id,
+	})
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+}
+
+func TestRuleSetValidateHandlerNoValidator(t *testing.T) {
+	h := RuleSetValidateHandler(testHandler(nil))
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(RuleSetValidateHTTPMethod, "/", jsonBody(t, view.RuleSet{}))
+	req = mux.SetURLVars(req, map[string]string{namespaceVar: testNamespace})
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
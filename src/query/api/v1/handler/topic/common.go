@@ -69,6 +69,9 @@ func RegisterRoutes(r *mux.Router, client clusterclient.Client, cfg config.Confi
 	r.HandleFunc(InitURL, logged(NewInitHandler(client, cfg)).ServeHTTP).Methods(InitHTTPMethod)
 	r.HandleFunc(GetURL, logged(NewGetHandler(client, cfg)).ServeHTTP).Methods(GetHTTPMethod)
 	r.HandleFunc(AddURL, logged(NewAddHandler(client, cfg)).ServeHTTP).Methods(AddHTTPMethod)
+	r.HandleFunc(RemoveURL, logged(NewRemoveHandler(client, cfg)).ServeHTTP).Methods(RemoveHTTPMethod)
+	r.HandleFunc(UpdateURL, logged(NewUpdateHandler(client, cfg)).ServeHTTP).Methods(UpdateHTTPMethod)
+	r.HandleFunc(ShardsURL, logged(NewShardsHandler(client, cfg)).ServeHTTP).Methods(ShardsHTTPMethod)
 }
 
 func topicName(headers http.Header) string {
@@ -0,0 +1,126 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package topic
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/msg/generated/proto/topicpb"
+	"github.com/m3db/m3/src/msg/topic"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicRemoveHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := setupTest(t, ctrl)
+	handler := NewRemoveHandler(nil, config.Configuration{})
+	handler.serviceFn = testServiceFn(mockService)
+
+	sid := &topicpb.ServiceID{
+		Environment: "env1",
+		Zone:        "zone1",
+		Name:        "name1",
+	}
+	cs := topic.NewConsumerService().
+		SetConsumptionType(topic.Shared).
+		SetServiceID(topic.NewServiceIDFromProto(sid))
+	t1 := topic.NewTopic().
+		SetName(DefaultTopicName).
+		SetNumberOfShards(256).
+		SetConsumerServices([]topic.ConsumerService{cs})
+
+	removeProto := admin.TopicRemoveRequest{ServiceId: sid}
+	w := httptest.NewRecorder()
+	b := bytes.NewBuffer(nil)
+	require.NoError(t, jsonMarshaler.Marshal(b, &removeProto))
+	t2, err := t1.RemoveConsumerService(topic.NewServiceIDFromProto(sid))
+	require.NoError(t, err)
+	mockService.
+		EXPECT().
+		Get(gomock.Any()).
+		Return(t1, nil)
+	mockService.EXPECT().CheckAndSet(gomock.Any(), gomock.Any()).Return(t2.SetVersion(3), nil)
+	req := httptest.NewRequest(RemoveHTTPMethod, "/topic/consumer_service", b)
+	require.NotNil(t, req)
+	handler.ServeHTTP(w, req)
+	resp := w.Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var respProto admin.TopicGetResponse
+	require.NoError(t, jsonUnmarshaler.Unmarshal(bytes.NewBuffer(body), &respProto))
+
+	validateEqualTopicProto(t, topicpb.Topic{
+		Name:           DefaultTopicName,
+		NumberOfShards: 256,
+	}, *respProto.Topic)
+
+	require.Equal(t, uint32(3), respProto.Version)
+}
+
+func TestTopicRemoveHandlerMissingServiceID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := setupTest(t, ctrl)
+	handler := NewRemoveHandler(nil, config.Configuration{})
+	handler.serviceFn = testServiceFn(mockService)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(RemoveHTTPMethod, "/topic/consumer_service", bytes.NewBufferString("{}"))
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestTopicRemoveHandlerNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := setupTest(t, ctrl)
+	handler := NewRemoveHandler(nil, config.Configuration{})
+	handler.serviceFn = testServiceFn(mockService)
+
+	t1 := topic.NewTopic().SetName(DefaultTopicName).SetNumberOfShards(256)
+	mockService.
+		EXPECT().
+		Get(gomock.Any()).
+		Return(t1, nil)
+
+	w := httptest.NewRecorder()
+	b := bytes.NewBuffer(nil)
+	require.NoError(t, jsonMarshaler.Marshal(b, &admin.TopicRemoveRequest{
+		ServiceId: &topicpb.ServiceID{Name: "name1"},
+	}))
+	req := httptest.NewRequest(RemoveHTTPMethod, "/topic/consumer_service", b)
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package topic
+
+import (
+	"errors"
+	"net/http"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/msg/topic"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// ShardsURL is the url for the topic shards handler (with the PUT method).
+	ShardsURL = handler.RoutePrefixV1 + "/topic/shards"
+
+	// ShardsHTTPMethod is the HTTP method used with this resource.
+	ShardsHTTPMethod = http.MethodPut
+)
+
+var errInvalidNumberOfShards = errors.New("number of shards must be positive")
+
+// ShardsHandler is the handler for changing the number of shards of a topic.
+// Producers of the topic must be configured with a shard function, they
+// drain in-flight messages from the old shards and re-route them under the
+// new shard mapping once the update is observed. The placements of the
+// consumer services should be updated to the new number of shards before
+// calling this handler.
+type ShardsHandler Handler
+
+// NewShardsHandler returns a new instance of ShardsHandler.
+func NewShardsHandler(client clusterclient.Client, cfg config.Configuration) *ShardsHandler {
+	return &ShardsHandler{client: client, cfg: cfg, serviceFn: Service}
+}
+
+func (h *ShardsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx)
+		req    admin.TopicSetShardsRequest
+	)
+	rErr := parseRequest(r, &req)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	if req.NumberOfShards == 0 {
+		logger.Error("invalid number of shards")
+		xhttp.Error(w, errInvalidNumberOfShards, http.StatusBadRequest)
+		return
+	}
+
+	service, err := h.serviceFn(h.client)
+	if err != nil {
+		logger.Error("unable to get service", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	t, err := service.Get(topicName(r.Header))
+	if err != nil {
+		logger.Error("unable to get topic", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if t.NumberOfShards() != req.NumberOfShards {
+		t, err = service.CheckAndSet(t.SetNumberOfShards(req.NumberOfShards), t.Version())
+		if err != nil {
+			logger.Error("unable to persist number of shards", zap.Any("error", err))
+			xhttp.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	topicProto, err := topic.ToProto(t)
+	if err != nil {
+		logger.Error("unable to get topic protobuf", zap.Any("error", err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := &admin.TopicGetResponse{
+		Topic:   topicProto,
+		Version: uint32(t.Version()),
+	}
+
+	xhttp.WriteProtoMsgJSONResponse(w, resp, logger)
+}
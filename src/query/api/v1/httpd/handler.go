@@ -32,13 +32,18 @@ import (
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/api/v1/handler/database"
+	"github.com/m3db/m3/src/query/api/v1/handler/graphite"
+	"github.com/m3db/m3/src/query/api/v1/handler/influxdb"
 	m3json "github.com/m3db/m3/src/query/api/v1/handler/json"
 	"github.com/m3db/m3/src/query/api/v1/handler/namespace"
 	"github.com/m3db/m3/src/query/api/v1/handler/openapi"
 	"github.com/m3db/m3/src/query/api/v1/handler/placement"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/remote"
+	"github.com/m3db/m3/src/query/api/v1/handler/rules"
 	"github.com/m3db/m3/src/query/api/v1/handler/topic"
+	"github.com/m3db/m3/src/query/cache"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
@@ -56,7 +61,8 @@ const (
 )
 
 var (
-	remoteSource = map[string]string{"source": "remote"}
+	remoteSource   = map[string]string{"source": "remote"}
+	influxdbSource = map[string]string{"source": "influxdb"}
 )
 
 // Handler represents an HTTP handler.
@@ -131,9 +137,37 @@ func (h *Handler) RegisterRoutes() error {
 	h.Router.HandleFunc(remote.PromWriteURL,
 		logged(promRemoteWriteHandler).ServeHTTP,
 	).Methods(remote.PromWriteHTTPMethod)
+	var resultsCache *cache.ResultsCache
+	if h.config.ResultsCache != nil {
+		resultsCache = h.config.ResultsCache.NewResultsCache(h.scope.SubScope("results-cache"))
+	}
+
 	h.Router.HandleFunc(native.PromReadURL,
-		logged(native.NewPromReadHandler(h.engine, h.tagOptions, &h.config.Limits)).ServeHTTP,
+		logged(native.NewPromReadHandler(h.engine, h.tagOptions, &h.config.Limits, resultsCache)).ServeHTTP,
 	).Methods(native.PromReadHTTPMethod)
+	h.Router.HandleFunc(native.PromReadInstantURL,
+		logged(native.NewPromReadInstantHandler(h.engine, h.tagOptions, &h.config.Limits)).ServeHTTP,
+	).Methods(native.PromReadInstantHTTPMethod)
+
+	// Prometheus metadata endpoints
+	h.Router.HandleFunc(native.LabelNamesURL,
+		logged(native.NewLabelNamesHandler(h.storage, h.tagOptions)).ServeHTTP,
+	).Methods(native.LabelNamesHTTPMethods...)
+	h.Router.HandleFunc(native.LabelValuesURL,
+		logged(native.NewLabelValuesHandler(h.storage, h.tagOptions)).ServeHTTP,
+	).Methods(native.LabelValuesHTTPMethod)
+	h.Router.HandleFunc(native.SeriesMatchURL,
+		logged(native.NewSeriesMatchHandler(h.storage, h.tagOptions)).ServeHTTP,
+	).Methods(native.SeriesMatchHTTPMethods...)
+
+	// Graphite endpoints
+	h.Router.HandleFunc(graphite.RenderURL,
+		logged(graphite.NewRenderHandler(h.storage, cost.NewEnforcerFactory(
+			h.config.Limits.CostLimits(), h.scope.SubScope("graphite").SubScope("cost")))).ServeHTTP,
+	).Methods(graphite.HTTPMethods...)
+	h.Router.HandleFunc(graphite.FindURL,
+		logged(graphite.NewFindHandler(h.storage)).ServeHTTP,
+	).Methods(graphite.HTTPMethods...)
 
 	// Native M3 search and write endpoints
 	h.Router.HandleFunc(handler.SearchURL,
@@ -143,6 +177,12 @@ func (h *Handler) RegisterRoutes() error {
 		logged(m3json.NewWriteJSONHandler(h.storage)).ServeHTTP,
 	).Methods(m3json.JSONWriteHTTPMethod)
 
+	// InfluxDB line protocol write endpoint
+	h.Router.HandleFunc(influxdb.WriteURL,
+		logged(influxdb.NewWriteHandler(h.storage, h.tagOptions,
+			h.scope.Tagged(influxdbSource))).ServeHTTP,
+	).Methods(influxdb.WriteHTTPMethod)
+
 	if h.clusterClient != nil {
 		placementOpts := placement.HandlerOptions{
 			ClusterClient:       h.clusterClient,
@@ -154,6 +194,7 @@ func (h *Handler) RegisterRoutes() error {
 		namespace.RegisterRoutes(h.Router, h.clusterClient)
 		database.RegisterRoutes(h.Router, h.clusterClient, h.config, h.embeddedDbCfg)
 		topic.RegisterRoutes(h.Router, h.clusterClient, h.config)
+		rules.RegisterRoutes(h.Router, h.clusterClient, h.config)
 	}
 
 	h.registerHealthEndpoints()
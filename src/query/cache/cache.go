@@ -0,0 +1,303 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3x/clock"
+
+	"github.com/uber-go/tally"
+)
+
+// Backend stores the results of completed time buckets.
+type Backend interface {
+	// Get returns the series cached for a bucket.
+	Get(key string) ([]*ts.Series, bool)
+
+	// Set caches the series for a bucket.
+	Set(key string, series []*ts.Series)
+
+	// Delete removes a bucket from the cache.
+	Delete(key string)
+}
+
+// ReadFn executes a range query.
+type ReadFn func(ctx context.Context, params models.RequestParams) ([]*ts.Series, error)
+
+// ResultsCache caches the results of range queries. Queries are split into
+// time buckets aligned to multiples of the query step, and complete buckets
+// which end before the buffer past window are cached. Only the buckets
+// missing from the cache are executed, with contiguous buckets executed as a
+// single query.
+type ResultsCache struct {
+	backend    Backend
+	bucketSize time.Duration
+	bufferPast time.Duration
+	nowFn      clock.NowFn
+	metrics    resultsCacheMetrics
+}
+
+type resultsCacheMetrics struct {
+	hits        tally.Counter
+	misses      tally.Counter
+	uncacheable tally.Counter
+	invalidated tally.Counter
+}
+
+func newResultsCacheMetrics(scope tally.Scope) resultsCacheMetrics {
+	return resultsCacheMetrics{
+		hits:        scope.Counter("hits"),
+		misses:      scope.Counter("misses"),
+		uncacheable: scope.Counter("uncacheable"),
+		invalidated: scope.Counter("invalidated"),
+	}
+}
+
+// NewResultsCache returns a new results cache over the backend.
+func NewResultsCache(backend Backend, opts Options) *ResultsCache {
+	return &ResultsCache{
+		backend:    backend,
+		bucketSize: opts.BucketSize(),
+		bufferPast: opts.BufferPast(),
+		nowFn:      opts.NowFn(),
+		metrics:    newResultsCacheMetrics(opts.Scope()),
+	}
+}
+
+// bucket is the part of a query which falls within a single time bucket.
+type bucket struct {
+	// start and end are the first and last step of the query in the bucket.
+	start time.Time
+	end   time.Time
+	// complete is true when the query covers every step of the bucket.
+	complete bool
+	// final is true when the bucket ends before the buffer past window, so
+	// that no more data may be written to it.
+	final bool
+}
+
+func (b bucket) cacheable() bool {
+	return b.complete && b.final
+}
+
+// Read returns the results of the range query, executing the parts which
+// are not cached with the read function.
+func (c *ResultsCache) Read(
+	ctx context.Context,
+	params models.RequestParams,
+	readFn ReadFn,
+) ([]*ts.Series, error) {
+	buckets, ok := c.buckets(params)
+	if !ok {
+		c.metrics.uncacheable.Inc(1)
+		return readFn(ctx, params)
+	}
+
+	var (
+		results = make([][]*ts.Series, len(buckets))
+		cached  = make([]bool, len(buckets))
+	)
+
+	for i, b := range buckets {
+		key := c.key(params, b)
+		if !b.final {
+			// Data may still arrive for this bucket so any result cached by
+			// another instance, or before a clock change, may be stale.
+			c.backend.Delete(key)
+			c.metrics.invalidated.Inc(1)
+			continue
+		}
+
+		if !b.complete {
+			continue
+		}
+
+		if series, ok := c.backend.Get(key); ok {
+			results[i] = series
+			cached[i] = true
+			c.metrics.hits.Inc(1)
+		} else {
+			c.metrics.misses.Inc(1)
+		}
+	}
+
+	for i := 0; i < len(buckets); {
+		if cached[i] {
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(buckets) && !cached[j] {
+			j++
+		}
+
+		runParams := params
+		runParams.Start = buckets[i].start
+		runParams.End = buckets[j-1].end
+		runParams.IncludeEnd = true
+		series, err := readFn(ctx, runParams)
+		if err != nil {
+			return nil, err
+		}
+
+		for k := i; k < j; k++ {
+			results[k] = sliceSeries(series, buckets[k], params.Step)
+			if buckets[k].cacheable() {
+				c.backend.Set(c.key(params, buckets[k]), results[k])
+			}
+		}
+
+		i = j
+	}
+
+	return mergeSeries(results, buckets, params), nil
+}
+
+// buckets splits the query into time buckets. Queries which do not start on
+// a multiple of their step are not split since their steps would not line up
+// with those of other queries.
+func (c *ResultsCache) buckets(params models.RequestParams) ([]bucket, bool) {
+	step := params.Step
+	if step <= 0 || params.Start.UnixNano()%int64(step) != 0 {
+		return nil, false
+	}
+
+	numSteps := numSteps(params)
+	if numSteps <= 0 {
+		return nil, false
+	}
+
+	bucketSize := c.bucketSize / step * step
+	if bucketSize < step {
+		bucketSize = step
+	}
+
+	var (
+		last    = params.Start.Add(time.Duration(numSteps-1) * step)
+		cutoff  = c.nowFn().Add(-1 * c.bufferPast)
+		buckets []bucket
+	)
+
+	for t := params.Start; !t.After(last); {
+		bucketStart := time.Unix(0, t.UnixNano()-t.UnixNano()%int64(bucketSize))
+		bucketEnd := bucketStart.Add(bucketSize)
+		b := bucket{
+			start: t,
+			end:   bucketEnd.Add(-1 * step),
+			final: !bucketEnd.After(cutoff),
+		}
+
+		if b.end.After(last) {
+			b.end = last
+		}
+
+		b.complete = b.start.Equal(bucketStart) && b.end.Equal(bucketEnd.Add(-1*step))
+		buckets = append(buckets, b)
+		t = bucketEnd
+	}
+
+	return buckets, true
+}
+
+func (c *ResultsCache) key(params models.RequestParams, b bucket) string {
+	return fmt.Sprintf("%s:%d:%d", params.Query, params.Step, b.start.UnixNano())
+}
+
+func numSteps(params models.RequestParams) int {
+	duration := params.ExclusiveEnd().Sub(params.Start)
+	return int((duration + params.Step - 1) / params.Step)
+}
+
+// sliceSeries returns the values of the series which fall on the steps of
+// the bucket.
+func sliceSeries(series []*ts.Series, b bucket, step time.Duration) []*ts.Series {
+	numSteps := int(b.end.Sub(b.start)/step) + 1
+	sliced := make([]*ts.Series, 0, len(series))
+	for _, s := range series {
+		values := ts.NewFixedStepValues(step, numSteps, math.NaN(), b.start)
+		copyValues(values, s.Values(), b.start, b.end, step)
+		sliced = append(sliced, ts.NewSeries(s.Name(), values, s.Tags))
+	}
+
+	return sliced
+}
+
+// mergeSeries joins the series of each bucket into series spanning the
+// whole query, filling in steps where a series is missing with NaNs.
+func mergeSeries(
+	results [][]*ts.Series,
+	buckets []bucket,
+	params models.RequestParams,
+) []*ts.Series {
+	var (
+		numSteps = numSteps(params)
+		last     = params.Start.Add(time.Duration(numSteps-1) * params.Step)
+		merged   = make([]*ts.Series, 0, len(results[0]))
+		byID     = make(map[string]ts.FixedResolutionMutableValues)
+	)
+
+	for i, series := range results {
+		for _, s := range series {
+			id := s.Tags.ID()
+			values, ok := byID[id]
+			if !ok {
+				values = ts.NewFixedStepValues(params.Step, numSteps, math.NaN(), params.Start)
+				byID[id] = values
+				merged = append(merged, ts.NewSeries(s.Name(), values, s.Tags))
+			}
+
+			copyValues(values, s.Values(), buckets[i].start, last, params.Step)
+		}
+	}
+
+	return merged
+}
+
+// copyValues copies the datapoints of src which fall on steps between start
+// and end inclusive into dst.
+func copyValues(
+	dst ts.FixedResolutionMutableValues,
+	src ts.Values,
+	start, end time.Time,
+	step time.Duration,
+) {
+	dstStart := dst.StartTime()
+	for i := 0; i < src.Len(); i++ {
+		dp := src.DatapointAt(i)
+		if dp.Timestamp.Before(start) || dp.Timestamp.After(end) {
+			continue
+		}
+
+		offset := dp.Timestamp.Sub(dstStart)
+		if offset%step != 0 {
+			continue
+		}
+
+		dst.SetValueAt(int(offset/step), dp.Value)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cache
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStart = time.Unix(1530000000, 0).Truncate(time.Hour)
+
+type recordingReader struct {
+	calls []models.RequestParams
+	// missing is the start of a query for which series b is not returned
+	missing time.Time
+}
+
+// read returns series whose values are the unix time of each step, starting
+// a couple of steps early like the engine does when accounting for lookback.
+func (r *recordingReader) read(
+	_ context.Context,
+	params models.RequestParams,
+) ([]*ts.Series, error) {
+	r.calls = append(r.calls, params)
+	start := params.Start.Add(-2 * params.Step)
+	numSteps := int(params.ExclusiveEnd().Sub(start) / params.Step)
+	names := []string{"a", "b"}
+	if params.Start.Equal(r.missing) {
+		names = names[:1]
+	}
+
+	series := make([]*ts.Series, 0, len(names))
+	for _, name := range names {
+		values := ts.NewFixedStepValues(params.Step, numSteps, math.NaN(), start)
+		for i := 0; i < numSteps; i++ {
+			values.SetValueAt(i, float64(values.StartTimeForStep(i).Unix()))
+		}
+
+		tags := models.NewTags(1, nil).SetName([]byte(name))
+		series = append(series, ts.NewSeries(name, values, tags))
+	}
+
+	return series, nil
+}
+
+func newTestCache(now time.Time) *ResultsCache {
+	opts := NewOptions().
+		SetBucketSize(time.Hour).
+		SetBufferPast(10 * time.Minute).
+		SetNowFn(func() time.Time { return now })
+	return NewResultsCache(NewLRUBackend(100), opts)
+}
+
+func newTestParams(start, end time.Time) models.RequestParams {
+	return models.RequestParams{
+		Query:      "foo",
+		Start:      start,
+		End:        end,
+		Step:       time.Minute,
+		IncludeEnd: true,
+	}
+}
+
+func requireStepValues(t *testing.T, params models.RequestParams, series *ts.Series) {
+	numSteps := int(params.End.Sub(params.Start)/params.Step) + 1
+	values := series.Values()
+	require.Equal(t, numSteps, values.Len())
+	for i := 0; i < numSteps; i++ {
+		dp := values.DatapointAt(i)
+		expected := params.Start.Add(time.Duration(i) * params.Step)
+		require.Equal(t, expected, dp.Timestamp)
+		require.Equal(t, float64(expected.Unix()), dp.Value)
+	}
+}
+
+func TestResultsCacheExecutesUncachedBuckets(t *testing.T) {
+	cache := newTestCache(testStart.Add(5 * time.Hour))
+	reader := &recordingReader{}
+	params := newTestParams(testStart, testStart.Add(3*time.Hour))
+
+	series, err := cache.Read(context.Background(), params, reader.read)
+	require.NoError(t, err)
+	require.Len(t, series, 2)
+	requireStepValues(t, params, series[0])
+	requireStepValues(t, params, series[1])
+	require.Len(t, reader.calls, 1)
+	assert.Equal(t, testStart, reader.calls[0].Start)
+	assert.Equal(t, testStart.Add(3*time.Hour), reader.calls[0].End)
+
+	// The three complete buckets are cached, the final step is executed
+	series, err = cache.Read(context.Background(), params, reader.read)
+	require.NoError(t, err)
+	require.Len(t, series, 2)
+	requireStepValues(t, params, series[0])
+	require.Len(t, reader.calls, 2)
+	assert.Equal(t, testStart.Add(3*time.Hour), reader.calls[1].Start)
+	assert.Equal(t, testStart.Add(3*time.Hour), reader.calls[1].End)
+
+	// Partial buckets at either end are executed separately
+	params = newTestParams(testStart.Add(30*time.Minute), testStart.Add(150*time.Minute))
+	series, err = cache.Read(context.Background(), params, reader.read)
+	require.NoError(t, err)
+	require.Len(t, series, 2)
+	requireStepValues(t, params, series[1])
+	require.Len(t, reader.calls, 4)
+	assert.Equal(t, testStart.Add(30*time.Minute), reader.calls[2].Start)
+	assert.Equal(t, testStart.Add(59*time.Minute), reader.calls[2].End)
+	assert.Equal(t, testStart.Add(2*time.Hour), reader.calls[3].Start)
+	assert.Equal(t, testStart.Add(150*time.Minute), reader.calls[3].End)
+}
+
+func TestResultsCacheSkipsBucketsInBufferPast(t *testing.T) {
+	cache := newTestCache(testStart.Add(3*time.Hour + 5*time.Minute))
+	reader := &recordingReader{}
+	params := newTestParams(testStart, testStart.Add(3*time.Hour))
+
+	_, err := cache.Read(context.Background(), params, reader.read)
+	require.NoError(t, err)
+	series, err := cache.Read(context.Background(), params, reader.read)
+	require.NoError(t, err)
+	requireStepValues(t, params, series[0])
+	require.Len(t, reader.calls, 2)
+	assert.Equal(t, testStart.Add(2*time.Hour), reader.calls[1].Start)
+}
+
+func TestResultsCacheUnalignedQuery(t *testing.T) {
+	cache := newTestCache(testStart.Add(5 * time.Hour))
+	reader := &recordingReader{}
+	params := newTestParams(testStart.Add(time.Second), testStart.Add(3*time.Hour))
+
+	for i := 0; i < 2; i++ {
+		_, err := cache.Read(context.Background(), params, reader.read)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, reader.calls, 2)
+	assert.Equal(t, params, reader.calls[1])
+}
+
+func TestResultsCacheFillsMissingSeries(t *testing.T) {
+	cache := newTestCache(testStart.Add(5 * time.Hour))
+	reader := &recordingReader{missing: testStart}
+	params := newTestParams(testStart, testStart.Add(time.Hour))
+
+	// Cache the first bucket without series b
+	_, err := cache.Read(context.Background(), newTestParams(testStart, testStart.Add(59*time.Minute)), reader.read)
+	require.NoError(t, err)
+
+	series, err := cache.Read(context.Background(), params, reader.read)
+	require.NoError(t, err)
+	require.Len(t, series, 2)
+	requireStepValues(t, params, series[0])
+
+	values := series[1].Values()
+	require.Equal(t, 61, values.Len())
+	for i := 0; i < 60; i++ {
+		assert.True(t, math.IsNaN(values.ValueAt(i)))
+	}
+
+	assert.Equal(t, float64(testStart.Add(time.Hour).Unix()), values.ValueAt(60))
+}
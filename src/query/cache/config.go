@@ -0,0 +1,68 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cache
+
+import (
+	"time"
+
+	"github.com/uber-go/tally"
+)
+
+const (
+	defaultCapacity   = 10000
+	defaultBucketSize = time.Hour
+	// defaultBufferPast matches the default buffer past of M3DB namespaces,
+	// the window in which writes for a block may still arrive.
+	defaultBufferPast = 10 * time.Minute
+)
+
+// Configuration is the configuration for the query results cache.
+type Configuration struct {
+	// Capacity is the maximum number of buckets held in memory.
+	Capacity int `yaml:"capacity"`
+
+	// BucketSize is the duration of the time buckets which range queries are
+	// split into, rounded down to a multiple of the query step.
+	BucketSize time.Duration `yaml:"bucketSize"`
+
+	// BufferPast is how far behind now data may still be written, buckets
+	// which end within this window are never served from the cache.
+	BufferPast time.Duration `yaml:"bufferPast"`
+}
+
+// NewResultsCache returns an in-memory results cache for the configuration.
+func (c Configuration) NewResultsCache(scope tally.Scope) *ResultsCache {
+	capacity := c.Capacity
+	if capacity <= 0 {
+		capacity = defaultCapacity
+	}
+
+	opts := NewOptions().SetScope(scope)
+	if c.BucketSize > 0 {
+		opts = opts.SetBucketSize(c.BucketSize)
+	}
+
+	if c.BufferPast > 0 {
+		opts = opts.SetBufferPast(c.BufferPast)
+	}
+
+	return NewResultsCache(NewLRUBackend(capacity), opts)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/m3db/m3/src/query/ts"
+)
+
+type lruEntry struct {
+	key    string
+	series []*ts.Series
+}
+
+// lruBackend is an in-memory backend which evicts the least recently used
+// bucket once it holds more than its capacity.
+type lruBackend struct {
+	sync.Mutex
+
+	capacity int
+	list     *list.List
+	elems    map[string]*list.Element
+}
+
+// NewLRUBackend returns an in-memory backend holding at most capacity buckets.
+func NewLRUBackend(capacity int) Backend {
+	return &lruBackend{
+		capacity: capacity,
+		list:     list.New(),
+		elems:    make(map[string]*list.Element),
+	}
+}
+
+func (b *lruBackend) Get(key string) ([]*ts.Series, bool) {
+	b.Lock()
+	defer b.Unlock()
+	elem, ok := b.elems[key]
+	if !ok {
+		return nil, false
+	}
+
+	b.list.MoveToFront(elem)
+	return elem.Value.(*lruEntry).series, true
+}
+
+func (b *lruBackend) Set(key string, series []*ts.Series) {
+	b.Lock()
+	defer b.Unlock()
+	if elem, ok := b.elems[key]; ok {
+		elem.Value.(*lruEntry).series = series
+		b.list.MoveToFront(elem)
+		return
+	}
+
+	b.elems[key] = b.list.PushFront(&lruEntry{key: key, series: series})
+	for b.list.Len() > b.capacity {
+		oldest := b.list.Back()
+		b.list.Remove(oldest)
+		delete(b.elems, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (b *lruBackend) Delete(key string) {
+	b.Lock()
+	defer b.Unlock()
+	if elem, ok := b.elems[key]; ok {
+		b.list.Remove(elem)
+		delete(b.elems, key)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cache
+
+import (
+	"time"
+
+	"github.com/m3db/m3x/clock"
+
+	"github.com/uber-go/tally"
+)
+
+// Options are the options for the results cache.
+type Options interface {
+	// SetBucketSize sets the bucket size.
+	SetBucketSize(value time.Duration) Options
+
+	// BucketSize returns the bucket size.
+	BucketSize() time.Duration
+
+	// SetBufferPast sets the buffer past.
+	SetBufferPast(value time.Duration) Options
+
+	// BufferPast returns the buffer past.
+	BufferPast() time.Duration
+
+	// SetNowFn sets the function used to determine the current time.
+	SetNowFn(value clock.NowFn) Options
+
+	// NowFn returns the function used to determine the current time.
+	NowFn() clock.NowFn
+
+	// SetScope sets the metrics scope.
+	SetScope(value tally.Scope) Options
+
+	// Scope returns the metrics scope.
+	Scope() tally.Scope
+}
+
+type options struct {
+	bucketSize time.Duration
+	bufferPast time.Duration
+	nowFn      clock.NowFn
+	scope      tally.Scope
+}
+
+// NewOptions returns new options for the results cache.
+func NewOptions() Options {
+	return &options{
+		bucketSize: defaultBucketSize,
+		bufferPast: defaultBufferPast,
+		nowFn:      time.Now,
+		scope:      tally.NoopScope,
+	}
+}
+
+func (o *options) SetBucketSize(value time.Duration) Options {
+	opts := *o
+	opts.bucketSize = value
+	return &opts
+}
+
+func (o *options) BucketSize() time.Duration {
+	return o.bucketSize
+}
+
+func (o *options) SetBufferPast(value time.Duration) Options {
+	opts := *o
+	opts.bufferPast = value
+	return &opts
+}
+
+func (o *options) BufferPast() time.Duration {
+	return o.bufferPast
+}
+
+func (o *options) SetNowFn(value clock.NowFn) Options {
+	opts := *o
+	opts.nowFn = value
+	return &opts
+}
+
+func (o *options) NowFn() clock.NowFn {
+	return o.nowFn
+}
+
+func (o *options) SetScope(value tally.Scope) Options {
+	opts := *o
+	opts.scope = value
+	return &opts
+}
+
+func (o *options) Scope() tally.Scope {
+	return o.scope
+}
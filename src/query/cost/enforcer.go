@@ -0,0 +1,162 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cost
+
+import (
+	"sync/atomic"
+
+	"github.com/m3db/m3/src/query/errors"
+
+	"github.com/uber-go/tally"
+)
+
+const (
+	// SeriesResource is the number of series fetched from storage.
+	SeriesResource = "series"
+	// DatapointsResource is the number of datapoints decompressed.
+	DatapointsResource = "datapoints"
+	// BlockBytesResource is the number of bytes allocated for blocks.
+	BlockBytesResource = "block bytes"
+)
+
+// Limits are the per-query cost limits. Zero or negative values imply no limit.
+type Limits struct {
+	MaxFetchedSeries     int64
+	MaxFetchedDatapoints int64
+	MaxBlockBytes        int64
+}
+
+type enforcerMetrics struct {
+	seriesRejected     tally.Counter
+	datapointsRejected tally.Counter
+	blockBytesRejected tally.Counter
+}
+
+func newEnforcerMetrics(scope tally.Scope) *enforcerMetrics {
+	rejected := func(limit string) tally.Counter {
+		return scope.Tagged(map[string]string{"limit": limit}).Counter("rejected-queries")
+	}
+
+	return &enforcerMetrics{
+		seriesRejected:     rejected("series"),
+		datapointsRejected: rejected("datapoints"),
+		blockBytesRejected: rejected("block-bytes"),
+	}
+}
+
+// EnforcerFactory creates per-query enforcers which share limits and metrics.
+type EnforcerFactory struct {
+	limits  Limits
+	metrics *enforcerMetrics
+}
+
+// NewEnforcerFactory returns a new enforcer factory.
+func NewEnforcerFactory(limits Limits, scope tally.Scope) *EnforcerFactory {
+	return &EnforcerFactory{
+		limits:  limits,
+		metrics: newEnforcerMetrics(scope),
+	}
+}
+
+// New returns an enforcer for a single query, a nil factory returns a nil
+// enforcer which enforces no limits.
+func (f *EnforcerFactory) New() *Enforcer {
+	if f == nil {
+		return nil
+	}
+
+	return &Enforcer{
+		limits:  f.limits,
+		metrics: f.metrics,
+	}
+}
+
+// Enforcer tracks the resources used by a single query and rejects it once
+// it exceeds any of its limits. It is safe for concurrent use, and a nil
+// enforcer enforces no limits.
+type Enforcer struct {
+	limits     Limits
+	metrics    *enforcerMetrics
+	series     int64
+	datapoints int64
+	blockBytes int64
+	rejected   int32
+}
+
+// AddSeries adds fetched series to the query.
+func (e *Enforcer) AddSeries(n int) error {
+	if e == nil {
+		return nil
+	}
+
+	return e.add(&e.series, n, e.limits.MaxFetchedSeries,
+		SeriesResource, e.metrics.seriesRejected)
+}
+
+// AddDatapoints adds decompressed datapoints to the query.
+func (e *Enforcer) AddDatapoints(n int) error {
+	if e == nil {
+		return nil
+	}
+
+	return e.add(&e.datapoints, n, e.limits.MaxFetchedDatapoints,
+		DatapointsResource, e.metrics.datapointsRejected)
+}
+
+// AddBlockBytes adds bytes allocated for blocks to the query.
+func (e *Enforcer) AddBlockBytes(n int) error {
+	if e == nil {
+		return nil
+	}
+
+	return e.add(&e.blockBytes, n, e.limits.MaxBlockBytes,
+		BlockBytesResource, e.metrics.blockBytesRejected)
+}
+
+// MaxFetchedSeries returns the maximum number of series the query may
+// fetch, or zero if it is unlimited.
+func (e *Enforcer) MaxFetchedSeries() int64 {
+	if e == nil || e.limits.MaxFetchedSeries < 0 {
+		return 0
+	}
+
+	return e.limits.MaxFetchedSeries
+}
+
+func (e *Enforcer) add(
+	current *int64,
+	n int,
+	limit int64,
+	resource string,
+	rejected tally.Counter,
+) error {
+	total := atomic.AddInt64(current, int64(n))
+	if limit <= 0 || total <= limit {
+		return nil
+	}
+
+	// Only count each query once, however many resources it exceeds
+	if atomic.CompareAndSwapInt32(&e.rejected, 0, 1) {
+		rejected.Inc(1)
+	}
+
+	return errors.QueryLimitError{Resource: resource, Limit: limit}
+}
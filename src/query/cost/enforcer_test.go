@@ -0,0 +1,71 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cost
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func TestEnforcerRejectsOverLimit(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	factory := NewEnforcerFactory(Limits{
+		MaxFetchedSeries:     10,
+		MaxFetchedDatapoints: 100,
+	}, scope)
+
+	enforcer := factory.New()
+	require.NoError(t, enforcer.AddSeries(6))
+	require.NoError(t, enforcer.AddSeries(4))
+	err := enforcer.AddSeries(1)
+	require.Error(t, err)
+	assert.True(t, errors.IsQueryLimitError(err))
+	assert.Equal(t, errors.QueryLimitError{Resource: SeriesResource, Limit: 10}, err)
+
+	// A query is only counted as rejected once
+	require.Error(t, enforcer.AddDatapoints(101))
+
+	// Limits are tracked per query, and unset limits are not enforced
+	other := factory.New()
+	require.NoError(t, other.AddSeries(10))
+	require.NoError(t, other.AddBlockBytes(1<<30))
+
+	counters := scope.Snapshot().Counters()
+	series, ok := counters["rejected-queries+limit=series"]
+	require.True(t, ok)
+	assert.Equal(t, int64(1), series.Value())
+	datapoints, ok := counters["rejected-queries+limit=datapoints"]
+	require.True(t, ok)
+	assert.Equal(t, int64(0), datapoints.Value())
+}
+
+func TestNilEnforcer(t *testing.T) {
+	var enforcer *Enforcer
+	assert.NoError(t, enforcer.AddSeries(1))
+	assert.NoError(t, enforcer.AddDatapoints(1))
+	assert.NoError(t, enforcer.AddBlockBytes(1))
+	assert.Equal(t, int64(0), enforcer.MaxFetchedSeries())
+}
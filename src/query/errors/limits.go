@@ -0,0 +1,42 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package errors
+
+import "fmt"
+
+// QueryLimitError is returned when a query exceeds one of its cost limits.
+type QueryLimitError struct {
+	// Resource is the resource which exceeded its limit, e.g. series.
+	Resource string
+	// Limit is the maximum amount of the resource a query may use.
+	Limit int64
+}
+
+func (e QueryLimitError) Error() string {
+	return fmt.Sprintf("query exceeded limit of %d %s, narrow the query or raise the limit", e.Limit, e.Resource)
+}
+
+// IsQueryLimitError returns true if the error is caused by a query exceeding
+// one of its cost limits.
+func IsQueryLimitError(err error) bool {
+	_, ok := err.(QueryLimitError)
+	return ok
+}
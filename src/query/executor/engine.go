@@ -24,6 +24,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/storage"
@@ -33,8 +34,9 @@ import (
 
 // Engine executes a Query.
 type Engine struct {
-	metrics *engineMetrics
-	store   storage.Storage
+	metrics   *engineMetrics
+	store     storage.Storage
+	enforcers *cost.EnforcerFactory
 }
 
 // EngineOptions can be used to pass custom flags to engine
@@ -47,11 +49,13 @@ type Query struct {
 	Result Result
 }
 
-// NewEngine returns a new instance of QueryExecutor.
-func NewEngine(store storage.Storage, scope tally.Scope) *Engine {
+// NewEngine returns a new instance of QueryExecutor which enforces the cost
+// limits on each query.
+func NewEngine(store storage.Storage, scope tally.Scope, limits cost.Limits) *Engine {
 	return &Engine{
-		metrics: newEngineMetrics(scope),
-		store:   store,
+		metrics:   newEngineMetrics(scope),
+		store:     store,
+		enforcers: cost.NewEnforcerFactory(limits, scope.SubScope("cost")),
 	}
 }
 
@@ -104,7 +108,9 @@ func newEngineMetrics(scope tally.Scope) *engineMetrics {
 // Execute runs the query and closes the results channel once done
 func (e *Engine) Execute(ctx context.Context, query *storage.FetchQuery, opts *EngineOptions, results chan *storage.QueryResult) {
 	defer close(results)
-	result, err := e.store.Fetch(ctx, query, &storage.FetchOptions{})
+	result, err := e.store.Fetch(ctx, query, &storage.FetchOptions{
+		Enforcer: e.enforcers.New(),
+	})
 	if err != nil {
 		results <- &storage.QueryResult{Err: err}
 		return
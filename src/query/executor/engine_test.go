@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/test/m3"
 	"github.com/m3db/m3/src/query/util/logging"
@@ -38,12 +39,12 @@ func TestExecute(t *testing.T) {
 	logging.InitWithCores(nil)
 	ctrl := gomock.NewController(t)
 	store, session := m3.NewStorageAndSession(t, ctrl)
-	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, false, fmt.Errorf("dummy"))
+	session.EXPECT().FetchTaggedPages(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("dummy"))
 	session.EXPECT().IteratorPools().Return(nil, nil)
 
 	// Results is closed by execute
 	results := make(chan *storage.QueryResult)
-	engine := NewEngine(store, tally.NewTestScope("test", nil))
+	engine := NewEngine(store, tally.NewTestScope("test", nil), cost.Limits{})
 	go engine.Execute(context.TODO(), &storage.FetchQuery{}, &EngineOptions{}, results)
 	res := <-results
 	assert.NotNil(t, res.Err)
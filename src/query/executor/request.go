@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/plan"
@@ -68,11 +69,17 @@ type Request struct {
 	engine     *Engine
 	params     models.RequestParams
 	parentSpan *span
+	enforcer   *cost.Enforcer
 }
 
 func newRequest(engine *Engine, params models.RequestParams) *Request {
 	parentSpan := startSpan(engine.metrics.activeHist, engine.metrics.all)
-	r := &Request{engine: engine, params: params, parentSpan: parentSpan}
+	r := &Request{
+		engine:     engine,
+		params:     params,
+		parentSpan: parentSpan,
+		enforcer:   engine.enforcers.New(),
+	}
 	return r
 
 }
@@ -122,7 +129,7 @@ func (r *Request) plan(ctx context.Context, nodes parser.Nodes, edges parser.Edg
 
 func (r *Request) execute(ctx context.Context, pp plan.PhysicalPlan) (*ExecutionState, error) {
 	sp := startSpan(r.engine.metrics.executingHist, r.engine.metrics.executing)
-	state, err := GenerateExecutionState(pp, r.engine.store)
+	state, err := GenerateExecutionState(pp, r.engine.store, r.enforcer)
 	// free up resources
 	if err != nil {
 		sp.finish(err)
@@ -24,6 +24,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/plan"
@@ -47,7 +48,7 @@ func CreateSource(
 	params SourceParams, storage storage.Storage,
 	options transform.Options,
 ) (parser.Source, *transform.Controller) {
-	controller := &transform.Controller{ID: ID}
+	controller := &transform.Controller{ID: ID, Enforcer: options.Enforcer}
 	return params.Node(controller, storage, options), controller
 }
 
@@ -57,7 +58,7 @@ func CreateScalarSource(
 	params ScalarParams,
 	options transform.Options,
 ) (parser.Source, *transform.Controller) {
-	controller := &transform.Controller{ID: ID}
+	controller := &transform.Controller{ID: ID, Enforcer: options.Enforcer}
 	return params.Node(controller, options), controller
 }
 
@@ -67,7 +68,7 @@ func CreateTransform(
 	params transform.Params,
 	options transform.Options,
 ) (transform.OpNode, *transform.Controller) {
-	controller := &transform.Controller{ID: ID}
+	controller := &transform.Controller{ID: ID, Enforcer: options.Enforcer}
 	node := params.Node(controller, options)
 
 	switch node.(type) {
@@ -101,10 +102,30 @@ type ScalarParams interface {
 	) parser.Source
 }
 
-// GenerateExecutionState creates an execution state from the physical plan
+// GenerateExecutionState creates an execution state from the physical plan,
+// with the resources used by the query charged to the enforcer
 func GenerateExecutionState(
 	pplan plan.PhysicalPlan,
 	storage storage.Storage,
+	enforcer *cost.Enforcer,
+) (*ExecutionState, error) {
+	rNode := newResultNode()
+	state, err := generateExecutionState(pplan, storage, enforcer, rNode)
+	if err != nil {
+		return nil, err
+	}
+
+	state.resultNode = rNode
+	return state, nil
+}
+
+// generateExecutionState creates an execution state from the physical plan
+// which delivers the output of the leaf node to the given sink
+func generateExecutionState(
+	pplan plan.PhysicalPlan,
+	storage storage.Storage,
+	enforcer *cost.Enforcer,
+	sink transform.OpNode,
 ) (*ExecutionState, error) {
 	result := pplan.ResultStep
 	state := &ExecutionState{
@@ -120,6 +141,7 @@ func GenerateExecutionState(
 	options := transform.Options{
 		TimeSpec: pplan.TimeSpec,
 		Debug:    pplan.Debug,
+		Enforcer: enforcer,
 	}
 	controller, err := state.createNode(step, options)
 	if err != nil {
@@ -130,10 +152,7 @@ func GenerateExecutionState(
 		return nil, errors.New("empty sources for the execution state")
 	}
 
-	rNode := newResultNode()
-	state.resultNode = rNode
-	controller.AddTransform(rNode)
-
+	controller.AddTransform(sink)
 	return state, nil
 }
 
@@ -0,0 +1,264 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cost"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/plan"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"go.uber.org/zap"
+)
+
+// SubqueryType evaluates an inner expression over a range at its own resolution
+const SubqueryType = "subquery"
+
+// SubqueryOp stores required properties for a subquery
+type SubqueryOp struct {
+	Nodes  parser.Nodes
+	Edges  parser.Edges
+	Range  time.Duration
+	Step   time.Duration
+	Offset time.Duration
+}
+
+// OpType for the operator
+func (o SubqueryOp) OpType() string {
+	return SubqueryType
+}
+
+// Bounds returns the bounds for the spec
+func (o SubqueryOp) Bounds() transform.BoundSpec {
+	return transform.BoundSpec{
+		Range:  o.Range,
+		Offset: o.Offset,
+	}
+}
+
+// String representation
+func (o SubqueryOp) String() string {
+	return fmt.Sprintf("type: %s, range: %v, step: %v, offset: %v, nodes: %v, edges: %v",
+		o.OpType(), o.Range, o.Step, o.Offset, o.Nodes, o.Edges)
+}
+
+// Node creates an execution node
+func (o SubqueryOp) Node(
+	controller *transform.Controller,
+	storage storage.Storage,
+	options transform.Options,
+) parser.Source {
+	return &subqueryNode{
+		op:         o,
+		controller: controller,
+		storage:    storage,
+		timespec:   options.TimeSpec,
+		debug:      options.Debug,
+		enforcer:   options.Enforcer,
+	}
+}
+
+type subqueryNode struct {
+	op         SubqueryOp
+	controller *transform.Controller
+	storage    storage.Storage
+	timespec   transform.TimeSpec
+	debug      bool
+	enforcer   *cost.Enforcer
+}
+
+// innerParams returns the request params used to evaluate the inner
+// expression. Evaluation times are aligned to multiples of the subquery step
+// so that results are stable across outer queries, as in Prometheus. The
+// start of the outer time spec already accounts for the offset of the
+// subquery since the physical plan shifts it by the bounds of the subquery,
+// so only the end is shifted here.
+func (n *subqueryNode) innerParams() models.RequestParams {
+	step := n.op.Step
+	if step <= 0 {
+		step = n.timespec.Step
+	}
+
+	start := n.timespec.Start
+	if aligned := start.Truncate(step); aligned.Before(start) {
+		start = aligned.Add(step)
+	}
+
+	return models.RequestParams{
+		Start: start,
+		End:   n.timespec.End.Add(-1 * n.op.Offset),
+		Now:   n.timespec.Now,
+		Step:  step,
+		Debug: n.debug,
+	}
+}
+
+// Execute evaluates the inner expression and emits its output as a single
+// unconsolidated block spanning the outer query, with one datapoint per
+// inner step.
+func (n *subqueryNode) Execute(ctx context.Context) error {
+	lp, err := plan.NewLogicalPlan(n.op.Nodes, n.op.Edges)
+	if err != nil {
+		return err
+	}
+
+	params := n.innerParams()
+	pp, err := plan.NewPhysicalPlan(lp, n.storage, params)
+	if err != nil {
+		return err
+	}
+
+	sink := newSubquerySink(n.op.Offset)
+	// The inner expression shares the cost limits of the outer query
+	state, err := generateExecutionState(pp, n.storage, n.enforcer, sink)
+	if err != nil {
+		return err
+	}
+
+	if n.debug {
+		logging.WithContext(ctx).Info("subquery execution state",
+			zap.String("state", state.String()))
+	}
+
+	if err := state.Execute(ctx); err != nil {
+		return err
+	}
+
+	bounds := n.timespec.Bounds()
+	unconsolidated, err := storage.NewMultiSeriesBlock(sink.seriesList(), &storage.FetchQuery{
+		Start:    bounds.Start,
+		End:      bounds.End(),
+		Interval: bounds.StepSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	b := storage.NewMultiBlockWrapper(unconsolidated)
+	err = n.controller.Process(b)
+	b.Close()
+	return err
+}
+
+// subquerySink collects the blocks produced by the inner expression into
+// raw series, keyed by their tags.
+type subquerySink struct {
+	mu     sync.Mutex
+	offset time.Duration
+	ids    []string
+	series map[string]*subquerySeries
+}
+
+type subquerySeries struct {
+	meta       block.SeriesMeta
+	datapoints ts.Datapoints
+}
+
+func newSubquerySink(offset time.Duration) *subquerySink {
+	return &subquerySink{
+		offset: offset,
+		series: make(map[string]*subquerySeries),
+	}
+}
+
+// Process adds the non NaN values of a block to the collected series
+func (s *subquerySink) Process(_ parser.NodeID, b block.Block) error {
+	iter, err := b.SeriesIter()
+	if err != nil {
+		return err
+	}
+
+	defer iter.Close()
+	meta := iter.Meta()
+	bounds := meta.Bounds
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for iter.Next() {
+		series, err := iter.Current()
+		if err != nil {
+			return err
+		}
+
+		tags := models.NewTags(series.Meta.Tags.Len()+meta.Tags.Len(), series.Meta.Tags.Opts).
+			AddTags(series.Meta.Tags.Tags).
+			AddTags(meta.Tags.Tags)
+		id := tags.ID()
+		collected, ok := s.series[id]
+		if !ok {
+			collected = &subquerySeries{
+				meta: block.SeriesMeta{Tags: tags, Name: series.Meta.Name},
+			}
+			s.series[id] = collected
+			s.ids = append(s.ids, id)
+		}
+
+		for i := 0; i < series.Len(); i++ {
+			value := series.ValueAtStep(i)
+			// Steps without a value do not contribute to the range
+			if math.IsNaN(value) {
+				continue
+			}
+
+			t, err := bounds.TimeForIndex(i)
+			if err != nil {
+				return err
+			}
+
+			collected.datapoints = append(collected.datapoints, ts.Datapoint{
+				Timestamp: t.Add(s.offset),
+				Value:     value,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (s *subquerySink) seriesList() ts.SeriesList {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seriesList := make(ts.SeriesList, 0, len(s.ids))
+	for _, id := range s.ids {
+		collected := s.series[id]
+		dps := collected.datapoints
+		sort.Slice(dps, func(i, j int) bool {
+			return dps[i].Timestamp.Before(dps[j].Timestamp)
+		})
+
+		seriesList = append(seriesList,
+			ts.NewSeries(collected.meta.Name, dps, collected.meta.Tags))
+	}
+
+	return seriesList
+}
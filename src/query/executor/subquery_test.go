@@ -0,0 +1,132 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executor
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/plan"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubqueryInnerParams(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	node := &subqueryNode{
+		op: SubqueryOp{Step: time.Minute, Offset: 5 * time.Minute},
+		timespec: transform.TimeSpec{
+			Start: now.Add(30 * time.Second),
+			End:   now.Add(time.Hour),
+			Now:   now,
+			Step:  15 * time.Second,
+		},
+	}
+
+	params := node.innerParams()
+	// The offset is already part of the shifted start of the outer query
+	assert.Equal(t, now.Add(time.Minute), params.Start)
+	assert.Equal(t, now.Add(55*time.Minute), params.End)
+	assert.Equal(t, time.Minute, params.Step)
+
+	// Default to the outer step
+	node.op = SubqueryOp{}
+	params = node.innerParams()
+	assert.Equal(t, now.Add(30*time.Second), params.Start)
+	assert.Equal(t, 15*time.Second, params.Step)
+}
+
+func TestSubqueryInnerParamsAppliesOffsetOnce(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	op := SubqueryOp{
+		Range:  10 * time.Minute,
+		Step:   time.Minute,
+		Offset: 5 * time.Minute,
+	}
+
+	lp, err := plan.NewLogicalPlan(parser.Nodes{parser.NewTransformFromOperation(op, 0)}, nil)
+	require.NoError(t, err)
+	pp, err := plan.NewPhysicalPlan(lp, nil, models.RequestParams{
+		Start: now,
+		End:   now.Add(time.Hour),
+		Now:   now,
+		Step:  time.Minute,
+	})
+	require.NoError(t, err)
+
+	node := &subqueryNode{op: op, timespec: pp.TimeSpec}
+	params := node.innerParams()
+	// The inner expression covers the range before the offset start of the
+	// outer query along with the lookback, but not a second offset
+	assert.Equal(t, now.Add(-op.Offset-op.Range-models.LookbackDelta), params.Start)
+	assert.Equal(t, now.Add(time.Hour-op.Offset), params.End)
+}
+
+func TestSubqueryExecute(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	bounds := models.Bounds{
+		Start:    now,
+		Duration: 4 * time.Minute,
+		StepSize: time.Minute,
+	}
+
+	store := mock.NewMockStorage()
+	store.SetFetchBlocksResult(block.Result{
+		Blocks: []block.Block{
+			test.NewBlockFromValues(bounds, [][]float64{{1, 2, math.NaN(), 4}}),
+		},
+	}, nil)
+
+	op := SubqueryOp{
+		Nodes: parser.Nodes{parser.NewTransformFromOperation(functions.FetchOp{}, 0)},
+		Range: 10 * time.Minute,
+		Step:  time.Minute,
+	}
+
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	node := op.Node(c, store, transform.Options{
+		TimeSpec: transform.TimeSpec{
+			Start: bounds.Start,
+			End:   bounds.End(),
+			Now:   now,
+			Step:  time.Minute,
+		},
+	})
+
+	require.NoError(t, node.Execute(context.Background()))
+	require.Len(t, sink.Values, 1)
+	// The NaN step is dropped, so the previous value is used in its place
+	assert.Equal(t, []float64{1, 2, 2, 4}, sink.Values[0])
+	assert.Equal(t, bounds, sink.Meta.Bounds)
+	require.Len(t, sink.Metas, 1)
+	assert.Equal(t, "dummy0", sink.Metas[0].Name)
+}
@@ -22,12 +22,17 @@ package transform
 
 import (
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/parser"
 )
 
+// valueBytes is the size of a single value in a block
+const valueBytes = 8
+
 // Controller controls the caching and forwarding the request to downstream.
 type Controller struct {
 	ID         parser.NodeID
+	Enforcer   *cost.Enforcer
 	transforms []OpNode
 }
 
@@ -48,7 +53,13 @@ func (t *Controller) Process(block block.Block) error {
 	return nil
 }
 
-// BlockBuilder returns a BlockBuilder instance with associated metadata
+// BlockBuilder returns a BlockBuilder instance with associated metadata. The
+// memory for the values of the block is charged to the query's cost limits.
 func (t *Controller) BlockBuilder(blockMeta block.Metadata, seriesMeta []block.SeriesMeta) (block.Builder, error) {
+	blockBytes := blockMeta.Bounds.Steps() * len(seriesMeta) * valueBytes
+	if err := t.Enforcer.AddBlockBytes(blockBytes); err != nil {
+		return nil, err
+	}
+
 	return block.NewColumnBlockBuilder(blockMeta, seriesMeta), nil
 }
@@ -0,0 +1,57 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package transform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cost"
+	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func TestBlockBuilderEnforcesBlockBytes(t *testing.T) {
+	factory := cost.NewEnforcerFactory(cost.Limits{MaxBlockBytes: 100}, tally.NoopScope)
+	controller := &Controller{Enforcer: factory.New()}
+	meta := block.Metadata{
+		Bounds: models.Bounds{
+			Start:    time.Now(),
+			Duration: 5 * time.Minute,
+			StepSize: time.Minute,
+		},
+	}
+
+	// 5 steps of 2 series take 80 bytes
+	seriesMeta := test.NewSeriesMeta("dummy", 2)
+	_, err := controller.BlockBuilder(meta, seriesMeta)
+	require.NoError(t, err)
+
+	_, err = controller.BlockBuilder(meta, seriesMeta)
+	require.Error(t, err)
+	assert.True(t, errors.IsQueryLimitError(err))
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 )
@@ -32,6 +33,8 @@ import (
 type Options struct {
 	TimeSpec TimeSpec
 	Debug    bool
+	// Enforcer enforces the cost limits of the query
+	Enforcer *cost.Enforcer
 }
 
 // OpNode represents the execution node
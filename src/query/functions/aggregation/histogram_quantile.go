@@ -0,0 +1,312 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// HistogramQuantileType calculates the quantile for histogram buckets.
+	//
+	// NB: each series must contain an `le` tag that denotes the upper bound
+	// of its bucket; series without this tag are ignored.
+	HistogramQuantileType = "histogram_quantile"
+)
+
+var (
+	// bucketTag is the tag holding the upper bound of a histogram bucket.
+	bucketTag = []byte("le")
+)
+
+// NewHistogramQuantileOp creates a new histogram quantile operation.
+func NewHistogramQuantileOp(
+	args []interface{},
+	opType string,
+) (parser.Params, error) {
+	if len(args) != 1 {
+		return baseOp{}, fmt.Errorf(
+			"invalid number of args for histogram_quantile: %d", len(args))
+	}
+
+	if opType != HistogramQuantileType {
+		return baseOp{}, fmt.Errorf("operator not supported: %s", opType)
+	}
+
+	q, ok := args[0].(float64)
+	if !ok {
+		return baseOp{}, fmt.Errorf("unable to cast to scalar argument: %v", args[0])
+	}
+
+	return newHistogramQuantileOp(q, opType), nil
+}
+
+// histogramQuantileOp stores required properties for histogram quantile ops.
+type histogramQuantileOp struct {
+	q      float64
+	opType string
+}
+
+// OpType for the operator.
+func (o histogramQuantileOp) OpType() string {
+	return o.opType
+}
+
+// String representation.
+func (o histogramQuantileOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node.
+func (o histogramQuantileOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &histogramQuantileNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+func newHistogramQuantileOp(
+	q float64,
+	opType string,
+) histogramQuantileOp {
+	return histogramQuantileOp{
+		q:      q,
+		opType: opType,
+	}
+}
+
+type histogramQuantileNode struct {
+	op         histogramQuantileOp
+	controller *transform.Controller
+}
+
+type indexedBucket struct {
+	upperBound float64
+	idx        int
+}
+
+type indexedBuckets struct {
+	buckets []indexedBucket
+	meta    block.SeriesMeta
+}
+
+func (b indexedBuckets) Len() int { return len(b.buckets) }
+func (b indexedBuckets) Swap(i, j int) {
+	b.buckets[i], b.buckets[j] = b.buckets[j], b.buckets[i]
+}
+func (b indexedBuckets) Less(i, j int) bool {
+	return b.buckets[i].upperBound < b.buckets[j].upperBound
+}
+
+type bucketValue struct {
+	upperBound float64
+	value      float64
+}
+
+// gatherSeriesToBuckets groups series by all their tags other than the
+// metric name and bucket tag, with the buckets of each group sorted by their
+// upper bound.
+func gatherSeriesToBuckets(metas []block.SeriesMeta) []indexedBuckets {
+	var (
+		bucketsByID = make(map[uint64]int, len(metas))
+		grouped     = make([]indexedBuckets, 0, len(metas))
+	)
+	for i, meta := range metas {
+		tags := meta.Tags
+		bucket, found := tags.Get(bucketTag)
+		if !found {
+			continue
+		}
+
+		upperBound, err := strconv.ParseFloat(string(bucket), 64)
+		if err != nil {
+			continue
+		}
+
+		excludeTags := [][]byte{tags.Opts.MetricName(), bucketTag}
+		id := tags.IDWithExcludes(excludeTags...)
+		groupIdx, ok := bucketsByID[id]
+		if !ok {
+			groupIdx = len(grouped)
+			bucketsByID[id] = groupIdx
+			grouped = append(grouped, indexedBuckets{
+				meta: block.SeriesMeta{
+					Name: HistogramQuantileType,
+					Tags: tags.TagsWithoutKeys(excludeTags),
+				},
+			})
+		}
+
+		grouped[groupIdx].buckets = append(grouped[groupIdx].buckets, indexedBucket{
+			upperBound: upperBound,
+			idx:        i,
+		})
+	}
+
+	for _, buckets := range grouped {
+		sort.Sort(buckets)
+	}
+
+	return grouped
+}
+
+// Process the block
+func (n *histogramQuantileNode) Process(ID parser.NodeID, b block.Block) error {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	meta := stepIter.Meta()
+	seriesMetas := utils.FlattenMetadata(meta, stepIter.SeriesMeta())
+	grouped := gatherSeriesToBuckets(seriesMetas)
+
+	metas := make([]block.SeriesMeta, len(grouped))
+	maxBuckets := 0
+	for i, group := range grouped {
+		metas[i] = group.meta
+		if len(group.buckets) > maxBuckets {
+			maxBuckets = len(group.buckets)
+		}
+	}
+
+	meta.Tags, metas = utils.DedupeMetadata(metas)
+	builder, err := n.controller.BlockBuilder(meta, metas)
+	if err != nil {
+		return err
+	}
+
+	if err := builder.AddCols(stepIter.StepCount()); err != nil {
+		return err
+	}
+
+	var (
+		quantiles = make([]float64, len(grouped))
+		values    = make([]bucketValue, 0, maxBuckets)
+	)
+	for index := 0; stepIter.Next(); index++ {
+		step, err := stepIter.Current()
+		if err != nil {
+			return err
+		}
+
+		stepValues := step.Values()
+		for i, group := range grouped {
+			values = values[:0]
+			for _, bucket := range group.buckets {
+				// Skip buckets without a value at this step
+				if v := stepValues[bucket.idx]; !math.IsNaN(v) {
+					values = append(values, bucketValue{
+						upperBound: bucket.upperBound,
+						value:      v,
+					})
+				}
+			}
+
+			quantiles[i] = bucketQuantile(n.op.q, values)
+		}
+
+		builder.AppendValues(index, quantiles)
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
+
+// bucketQuantile calculates the quantile q from the given buckets, which must
+// be sorted by upper bound. The quantile is linearly interpolated within the
+// bucket it falls in, following the Prometheus implementation:
+//   - If q < 0 or q > 1, -Inf or +Inf respectively are returned.
+//   - If there are fewer than two buckets, or the highest bucket does not
+//     have an upper bound of +Inf, NaN is returned.
+//   - If the quantile falls into the highest bucket, the upper bound of the
+//     second highest bucket is returned.
+//   - If the lowest bucket has an upper bound less than or equal to zero and
+//     the quantile falls into it, that upper bound is returned.
+func bucketQuantile(q float64, buckets []bucketValue) float64 {
+	if q < 0 {
+		return math.Inf(-1)
+	}
+
+	if q > 1 {
+		return math.Inf(1)
+	}
+
+	if len(buckets) < 2 {
+		return math.NaN()
+	}
+
+	last := len(buckets) - 1
+	if !math.IsInf(buckets[last].upperBound, 1) {
+		return math.NaN()
+	}
+
+	ensureMonotonic(buckets)
+
+	rank := q * buckets[last].value
+	b := sort.Search(last, func(i int) bool { return buckets[i].value >= rank })
+	if b == last {
+		return buckets[last-1].upperBound
+	}
+
+	if b == 0 && buckets[0].upperBound <= 0 {
+		return buckets[0].upperBound
+	}
+
+	var (
+		bucketStart float64
+		bucketEnd   = buckets[b].upperBound
+		count       = buckets[b].value
+	)
+	if b > 0 {
+		bucketStart = buckets[b-1].upperBound
+		count -= buckets[b-1].value
+		rank -= buckets[b-1].value
+	}
+
+	return bucketStart + (bucketEnd-bucketStart)*(rank/count)
+}
+
+// ensureMonotonic ensures bucket counts never decrease with increasing upper
+// bounds, which may happen when buckets are scraped or aggregated at
+// slightly different times.
+func ensureMonotonic(buckets []bucketValue) {
+	max := math.Inf(-1)
+	for i := range buckets {
+		if buckets[i].value > max {
+			max = buckets[i].value
+		} else {
+			buckets[i].value = max
+		}
+	}
+}
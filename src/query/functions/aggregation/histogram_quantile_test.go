@@ -0,0 +1,131 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketQuantile(t *testing.T) {
+	buckets := func() []bucketValue {
+		return []bucketValue{
+			{upperBound: 0.1, value: 10},
+			{upperBound: 0.5, value: 50},
+			{upperBound: 1, value: 90},
+			{upperBound: math.Inf(1), value: 100},
+		}
+	}
+
+	assert.InDelta(t, 0.05, bucketQuantile(0.05, buckets()), 1e-9)
+	assert.InDelta(t, 0.3, bucketQuantile(0.3, buckets()), 1e-9)
+	assert.InDelta(t, 0.75, bucketQuantile(0.7, buckets()), 1e-9)
+	// Quantiles in the +Inf bucket return the highest finite bound.
+	assert.Equal(t, 1.0, bucketQuantile(0.95, buckets()))
+
+	assert.True(t, math.IsInf(bucketQuantile(-0.5, buckets()), -1))
+	assert.True(t, math.IsInf(bucketQuantile(1.5, buckets()), 1))
+
+	// Fewer than two buckets, or no +Inf bucket.
+	assert.True(t, math.IsNaN(bucketQuantile(0.5, buckets()[3:])))
+	assert.True(t, math.IsNaN(bucketQuantile(0.5, buckets()[:3])))
+
+	// Non monotonic counts are corrected.
+	nonMonotonic := buckets()
+	nonMonotonic[1].value = 5
+	assert.InDelta(t, 0.875, bucketQuantile(0.7, nonMonotonic), 1e-9)
+
+	// Lowest bucket with a non positive upper bound.
+	negative := []bucketValue{
+		{upperBound: -1, value: 10},
+		{upperBound: math.Inf(1), value: 20},
+	}
+	assert.Equal(t, -1.0, bucketQuantile(0.2, negative))
+}
+
+func TestHistogramQuantileInvalidArgs(t *testing.T) {
+	_, err := NewHistogramQuantileOp([]interface{}{}, HistogramQuantileType)
+	assert.Error(t, err)
+
+	_, err = NewHistogramQuantileOp([]interface{}{"0.5"}, HistogramQuantileType)
+	assert.Error(t, err)
+
+	_, err = NewHistogramQuantileOp([]interface{}{0.5}, QuantileType)
+	assert.Error(t, err)
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	metas := []block.SeriesMeta{
+		{Tags: test.StringTagsToTags(test.StringTags{{"__name__", "x_bucket"}, {"job", "a"}, {"le", "1"}})},
+		{Tags: test.StringTagsToTags(test.StringTags{{"__name__", "x_bucket"}, {"job", "a"}, {"le", "2"}})},
+		{Tags: test.StringTagsToTags(test.StringTags{{"__name__", "x_bucket"}, {"job", "a"}, {"le", "+Inf"}})},
+		{Tags: test.StringTagsToTags(test.StringTags{{"__name__", "x_bucket"}, {"job", "b"}, {"le", "+Inf"}})},
+		{Tags: test.StringTagsToTags(test.StringTags{{"__name__", "x_bucket"}, {"job", "b"}, {"le", "1"}})},
+		{Tags: test.StringTagsToTags(test.StringTags{{"__name__", "x_bucket"}, {"job", "c"}})},
+	}
+	values := [][]float64{
+		{1, 2},
+		{3, 2},
+		{4, math.NaN()},
+		{10, 10},
+		{0, 5},
+		{7, 7},
+	}
+	bounds := models.Bounds{
+		Start:    time.Now(),
+		Duration: 2 * time.Minute,
+		StepSize: time.Minute,
+	}
+
+	op, err := NewHistogramQuantileOp([]interface{}{0.5}, HistogramQuantileType)
+	require.NoError(t, err)
+
+	bl := test.NewBlockFromValuesWithSeriesMeta(bounds, metas, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	node := op.(histogramQuantileOp).Node(c, transform.Options{})
+	require.NoError(t, node.Process(parser.NodeID(0), bl))
+
+	expected := [][]float64{
+		// job a is missing the +Inf bucket at the second step
+		{1.5, math.NaN()},
+		{1, 1},
+	}
+	test.EqualsWithNansWithDelta(t, expected, sink.Values, math.Pow10(-5))
+
+	// Series are grouped without their name and bucket tags, series
+	// without a bucket tag are ignored.
+	require.Len(t, sink.Metas, 2)
+	assert.Equal(t, HistogramQuantileType, sink.Metas[0].Name)
+	assert.Equal(t, test.StringTagsToTags(test.StringTags{{"job", "a"}}), sink.Metas[0].Tags)
+	assert.Equal(t, test.StringTagsToTags(test.StringTags{{"job", "b"}}), sink.Metas[1].Tags)
+	assert.Equal(t, bounds, sink.Meta.Bounds)
+}
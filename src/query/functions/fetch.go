@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
@@ -44,6 +45,9 @@ type FetchOp struct {
 	Range    time.Duration
 	Offset   time.Duration
 	Matchers models.Matchers
+	// Instant is set when the fetch feeds an instant vector selector, which
+	// only reads the last datapoint at or before each evaluation time.
+	Instant bool
 }
 
 // FetchNode is the execution node
@@ -54,6 +58,7 @@ type FetchNode struct {
 	storage    storage.Storage
 	timespec   transform.TimeSpec
 	debug      bool
+	enforcer   *cost.Enforcer
 }
 
 // OpType for the operator
@@ -76,7 +81,14 @@ func (o FetchOp) String() string {
 
 // Node creates an execution node
 func (o FetchOp) Node(controller *transform.Controller, storage storage.Storage, options transform.Options) parser.Source {
-	return &FetchNode{op: o, controller: controller, storage: storage, timespec: options.TimeSpec, debug: options.Debug}
+	return &FetchNode{
+		op:         o,
+		controller: controller,
+		storage:    storage,
+		timespec:   options.TimeSpec,
+		debug:      options.Debug,
+		enforcer:   options.Enforcer,
+	}
 }
 
 // Execute runs the fetch node operation
@@ -85,12 +97,26 @@ func (n *FetchNode) Execute(ctx context.Context) error {
 	// No need to adjust start and ends since physical plan already considers the offset, range
 	startTime := timeSpec.Start
 	endTime := timeSpec.End
+	opts := &storage.FetchOptions{
+		Enforcer: n.enforcer,
+	}
+	if n.op.Instant && timeSpec.Step >= models.LookbackDelta {
+		// NB: An instant selector only ever reads the last datapoint of each
+		// step, so for steps of at least the lookback only that datapoint is
+		// fetched. The fetched block is evaluated at the fetch start and every
+		// step after it.
+		opts.Downsample = &storage.DownsampleOptions{
+			Start: startTime,
+			Step:  timeSpec.Step,
+		}
+	}
+
 	blockResult, err := n.storage.FetchBlocks(ctx, &storage.FetchQuery{
 		Start:       startTime,
 		End:         endTime,
 		TagMatchers: n.op.Matchers,
 		Interval:    timeSpec.Step,
-	}, &storage.FetchOptions{})
+	}, opts)
 	if err != nil {
 		return err
 	}
@@ -23,10 +23,13 @@ package functions
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/mock"
 	"github.com/m3db/m3/src/query/test"
 	"github.com/m3db/m3/src/query/test/executor"
@@ -48,3 +51,76 @@ func TestFetch(t *testing.T) {
 	assert.Len(t, sink.Values, 2)
 	assert.Equal(t, expected, sink.Values)
 }
+
+type fetchOptionsStorage struct {
+	mock.Storage
+	options *storage.FetchOptions
+}
+
+func (s *fetchOptionsStorage) FetchBlocks(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+) (block.Result, error) {
+	s.options = options
+	return s.Storage.FetchBlocks(ctx, query, options)
+}
+
+func TestFetchDownsample(t *testing.T) {
+	start := time.Now().Truncate(time.Hour)
+	tests := []struct {
+		name       string
+		op         FetchOp
+		step       time.Duration
+		downsample bool
+	}{
+		{
+			name:       "instant selector",
+			op:         FetchOp{Instant: true},
+			step:       models.LookbackDelta,
+			downsample: true,
+		},
+		{
+			name: "instant selector with fine step",
+			op:   FetchOp{Instant: true},
+			step: models.LookbackDelta / 2,
+		},
+		{
+			// A range function such as rate(up[5m]) reads every datapoint of
+			// its window and so must never be downsampled
+			name: "range function",
+			op:   FetchOp{Range: 5 * time.Minute},
+			step: models.LookbackDelta,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, bounds := test.GenerateValuesAndBounds(nil, nil)
+			b := test.NewBlockFromValues(bounds, values)
+			c, _ := executor.NewControllerWithSink(parser.NodeID(1))
+			store := &fetchOptionsStorage{Storage: mock.NewMockStorage()}
+			store.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+
+			timeSpec := transform.TimeSpec{
+				Start: start,
+				End:   start.Add(time.Hour),
+				Step:  tt.step,
+			}
+			source := tt.op.Node(c, store, transform.Options{TimeSpec: timeSpec})
+			require.NoError(t, source.Execute(context.TODO()))
+
+			require.NotNil(t, store.options)
+			if !tt.downsample {
+				assert.Nil(t, store.options.Downsample)
+				return
+			}
+
+			require.NotNil(t, store.options.Downsample)
+			assert.Equal(t, storage.DownsampleOptions{
+				Start: start,
+				Step:  tt.step,
+			}, *store.options.Downsample)
+		})
+	}
+}
@@ -23,6 +23,7 @@ package temporal
 import (
 	"fmt"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/m3db/m3/src/query/executor/transform"
@@ -50,6 +51,9 @@ const (
 
 	// StdVarType calculates the standard variance of all values in the specified interval.
 	StdVarType = "stdvar_over_time"
+
+	// QuantileType calculates the φ-quantile (0 ≤ φ ≤ 1) of all values in the specified interval.
+	QuantileType = "quantile_over_time"
 )
 
 type aggFunc func([]float64) float64
@@ -91,6 +95,29 @@ func NewAggOp(args []interface{}, optype string) (transform.Params, error) {
 	return nil, fmt.Errorf("unknown aggregation type: %s", optype)
 }
 
+// NewQuantileOp creates a new base temporal transform for the quantile
+// aggregation, the quantile is given as the first argument.
+func NewQuantileOp(args []interface{}, optype string) (transform.Params, error) {
+	if len(args) != 2 {
+		return emptyOp, fmt.Errorf("invalid number of args for %s: %d", QuantileType, len(args))
+	}
+
+	if optype != QuantileType {
+		return emptyOp, fmt.Errorf("unknown quantile type: %s", optype)
+	}
+
+	q, ok := args[0].(float64)
+	if !ok {
+		return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v for %s", args[0], QuantileType)
+	}
+
+	a := aggProcessor{
+		aggFunc: makeQuantileOverTimeFn(q),
+	}
+
+	return newBaseOp(args[1:], optype, a)
+}
+
 type aggNode struct {
 	op         baseOp
 	controller *transform.Controller
@@ -176,6 +203,43 @@ func stdvarOverTime(values []float64) float64 {
 	return aux / count
 }
 
+func makeQuantileOverTimeFn(q float64) aggFunc {
+	return func(values []float64) float64 {
+		return quantileOverTime(q, values)
+	}
+}
+
+func quantileOverTime(q float64, values []float64) float64 {
+	nonNaN := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			nonNaN = append(nonNaN, v)
+		}
+	}
+
+	if len(nonNaN) == 0 {
+		return math.NaN()
+	}
+
+	if q < 0 {
+		return math.Inf(-1)
+	}
+
+	if q > 1 {
+		return math.Inf(1)
+	}
+
+	sort.Float64s(nonNaN)
+	// When the quantile lies between two samples,
+	// use a weighted average of the two samples.
+	rank := q * float64(len(nonNaN)-1)
+	lowerIndex := math.Max(0, math.Floor(rank))
+	upperIndex := math.Min(float64(len(nonNaN)-1), lowerIndex+1)
+
+	weight := rank - math.Floor(rank)
+	return nonNaN[int(lowerIndex)]*(1-weight) + nonNaN[int(upperIndex)]*weight
+}
+
 func sumAndCount(values []float64) (float64, float64) {
 	sum := 0.0
 	count := 0.0
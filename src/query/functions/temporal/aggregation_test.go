@@ -128,6 +128,18 @@ var testCases = []testCase{
 			{2, 2, 2, 2, 2},
 		},
 	},
+	{
+		name:   "quantile_over_time",
+		opType: QuantileType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 1.6},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 5.8},
+		},
+		afterAllBlocks: [][]float64{
+			{0.8, 0.8, 0.8, 0.8, 0.8},
+			{5.8, 5.8, 5.8, 5.8, 5.8},
+		},
+	},
 }
 
 func TestAggregation(t *testing.T) {
@@ -223,6 +235,18 @@ var testCasesNaNs = []testCase{
 			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
 		},
 	},
+	{
+		name:   "quantile_over_time",
+		opType: QuantileType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+		},
+		afterAllBlocks: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+		},
+	},
 }
 
 func TestAggregationAllNaNs(t *testing.T) {
@@ -233,6 +257,16 @@ func TestAggregationAllNaNs(t *testing.T) {
 	testAggregation(t, testCasesNaNs, v)
 }
 
+// newTestAggOp creates the aggregation for the op type over 5 minutes,
+// quantile_over_time is created for the 0.2 quantile.
+func newTestAggOp(opType string) (transform.Params, error) {
+	if opType == QuantileType {
+		return NewQuantileOp([]interface{}{0.2, 5 * time.Minute}, opType)
+	}
+
+	return NewAggOp([]interface{}{5 * time.Minute}, opType)
+}
+
 // B1 has NaN in first series, first position
 func testAggregation(t *testing.T, testCases []testCase, vals [][]float64) {
 	for _, tt := range testCases {
@@ -242,7 +276,7 @@ func testAggregation(t *testing.T, testCases []testCase, vals [][]float64) {
 			block3 := test.NewUnconsolidatedBlockFromDatapoints(bounds, values)
 			c, sink := executor.NewControllerWithSink(parser.NodeID(1))
 
-			baseOp, err := NewAggOp([]interface{}{5 * time.Minute}, tt.opType)
+			baseOp, err := newTestAggOp(tt.opType)
 			require.NoError(t, err)
 			node := baseOp.Node(c, transform.Options{
 				TimeSpec: transform.TimeSpec{
@@ -311,3 +345,24 @@ func TestUnknownAggregation(t *testing.T) {
 	_, err := NewAggOp([]interface{}{5 * time.Minute}, "unknown_agg_func")
 	require.Error(t, err)
 }
+
+func TestQuantileOverTime(t *testing.T) {
+	values := []float64{math.NaN(), 3, 1, 2, 4}
+	assert.Equal(t, 1.0, quantileOverTime(0, values))
+	assert.Equal(t, 2.5, quantileOverTime(0.5, values))
+	assert.Equal(t, 4.0, quantileOverTime(1, values))
+	assert.True(t, math.IsInf(quantileOverTime(-1, values), -1))
+	assert.True(t, math.IsInf(quantileOverTime(2, values), 1))
+	assert.True(t, math.IsNaN(quantileOverTime(0.5, []float64{math.NaN()})))
+
+	// Input values are not reordered.
+	test.EqualsWithNans(t, []float64{math.NaN(), 3, 1, 2, 4}, values)
+}
+
+func TestQuantileOverTimeInvalidArgs(t *testing.T) {
+	_, err := NewQuantileOp([]interface{}{5 * time.Minute}, QuantileType)
+	require.Error(t, err)
+
+	_, err = NewQuantileOp([]interface{}{5 * time.Minute, 0.5}, QuantileType)
+	require.Error(t, err)
+}
@@ -43,6 +43,9 @@
 		TopicGetResponse
 		TopicInitRequest
 		TopicAddRequest
+		TopicRemoveRequest
+		TopicUpdateRequest
+		TopicSetShardsRequest
 */
 package admin
 
@@ -0,0 +1,171 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+var (
+	errEmptyQuery = errors.New("empty graphite query")
+
+	// matchAny matches any non-empty tag value, its negation is used to
+	// ensure a path has no more nodes than the query.
+	matchAny = []byte(".+")
+)
+
+// GlobToMatchers converts a graphite path query such as `foo.b*r.{a,b}` into
+// matchers over the positional graphite tags. The resulting matchers only
+// match series with exactly as many nodes as the query.
+func GlobToMatchers(query string) (models.Matchers, error) {
+	matchers, err := globToNodeMatchers(query)
+	if err != nil {
+		return nil, err
+	}
+
+	terminator, err := models.NewMatcher(models.MatchNotRegexp,
+		TagName(len(matchers)), matchAny)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(matchers, terminator), nil
+}
+
+// GlobToPrefixMatchers converts a graphite path query into matchers over the
+// positional graphite tags, matching any series whose path starts with
+// nodes matching the query, used for tree traversal in metrics find.
+func GlobToPrefixMatchers(query string) (models.Matchers, error) {
+	return globToNodeMatchers(query)
+}
+
+func globToNodeMatchers(query string) (models.Matchers, error) {
+	if query == "" {
+		return nil, errEmptyQuery
+	}
+
+	nodes := strings.Split(query, string(separator))
+	matchers := make(models.Matchers, 0, len(nodes)+1)
+	for idx, node := range nodes {
+		if node == "" {
+			return nil, fmt.Errorf("invalid graphite query %s: empty node", query)
+		}
+
+		pattern, isGlob, err := GlobToRegexPattern(node)
+		if err != nil {
+			return nil, err
+		}
+
+		matchType := models.MatchEqual
+		if isGlob {
+			matchType = models.MatchRegexp
+		}
+
+		m, err := models.NewMatcher(matchType, TagName(idx), pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		matchers = append(matchers, m)
+	}
+
+	return matchers, nil
+}
+
+// GlobToRegexPattern converts a single graphite path node glob into a regular
+// expression pattern, returning false if the node has no glob characters in
+// which case the node is returned verbatim.
+func GlobToRegexPattern(glob string) ([]byte, bool, error) {
+	if !strings.ContainsAny(glob, "*?[{") {
+		return []byte(glob), false, nil
+	}
+
+	var (
+		pattern  bytes.Buffer
+		inGroup  bool
+		inClass  bool
+		escaping bool
+	)
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		if escaping {
+			pattern.WriteString(regexpQuote(c))
+			escaping = false
+			continue
+		}
+
+		switch {
+		case c == '\\':
+			escaping = true
+		case inClass:
+			if c == ']' {
+				inClass = false
+			}
+			pattern.WriteByte(c)
+		case c == '[':
+			inClass = true
+			pattern.WriteByte(c)
+			if i+1 < len(glob) && glob[i+1] == '!' {
+				pattern.WriteByte('^')
+				i++
+			}
+		case c == '*':
+			pattern.WriteString(".*")
+		case c == '?':
+			pattern.WriteByte('.')
+		case c == '{':
+			if inGroup {
+				return nil, false, fmt.Errorf("invalid glob %s: nested groups", glob)
+			}
+			inGroup = true
+			pattern.WriteByte('(')
+		case c == '}':
+			if !inGroup {
+				return nil, false, fmt.Errorf("invalid glob %s: unbalanced group", glob)
+			}
+			inGroup = false
+			pattern.WriteByte(')')
+		case c == ',' && inGroup:
+			pattern.WriteByte('|')
+		default:
+			pattern.WriteString(regexpQuote(c))
+		}
+	}
+
+	if inGroup || inClass || escaping {
+		return nil, false, fmt.Errorf("invalid glob %s: unterminated expression", glob)
+	}
+
+	return pattern.Bytes(), true, nil
+}
+
+func regexpQuote(c byte) string {
+	if strings.IndexByte(`\.+*?()|[]{}^$`, c) >= 0 {
+		return `\` + string(c)
+	}
+
+	return string(c)
+}
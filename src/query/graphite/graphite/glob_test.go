@@ -0,0 +1,93 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobToRegexPattern(t *testing.T) {
+	tests := []struct {
+		glob     string
+		expected string
+		isGlob   bool
+	}{
+		{glob: "foo", expected: "foo"},
+		{glob: "foo*", expected: "foo.*", isGlob: true},
+		{glob: "f?o", expected: "f.o", isGlob: true},
+		{glob: "{foo,bar}", expected: "(foo|bar)", isGlob: true},
+		{glob: "ba[rz]", expected: "ba[rz]", isGlob: true},
+		{glob: "ba[!rz]", expected: "ba[^rz]", isGlob: true},
+		{glob: "a+b*", expected: `a\+b.*`, isGlob: true},
+	}
+
+	for _, tt := range tests {
+		pattern, isGlob, err := GlobToRegexPattern(tt.glob)
+		require.NoError(t, err, tt.glob)
+		assert.Equal(t, tt.expected, string(pattern), tt.glob)
+		assert.Equal(t, tt.isGlob, isGlob, tt.glob)
+	}
+}
+
+func TestGlobToRegexPatternErrors(t *testing.T) {
+	for _, glob := range []string{"{foo", "foo*}", "{a,{b}}", "[abc", `foo*\`} {
+		_, _, err := GlobToRegexPattern(glob)
+		assert.Error(t, err, glob)
+	}
+}
+
+func TestGlobToMatchers(t *testing.T) {
+	matchers, err := GlobToMatchers("foo.b*.{x,y}")
+	require.NoError(t, err)
+	require.Equal(t, 4, len(matchers))
+
+	assert.Equal(t, models.MatchEqual, matchers[0].Type)
+	assert.Equal(t, "__g0__", string(matchers[0].Name))
+	assert.Equal(t, "foo", string(matchers[0].Value))
+
+	assert.Equal(t, models.MatchRegexp, matchers[1].Type)
+	assert.Equal(t, "__g1__", string(matchers[1].Name))
+	assert.Equal(t, "b.*", string(matchers[1].Value))
+
+	assert.Equal(t, models.MatchRegexp, matchers[2].Type)
+	assert.Equal(t, "(x|y)", string(matchers[2].Value))
+
+	assert.Equal(t, models.MatchNotRegexp, matchers[3].Type)
+	assert.Equal(t, "__g3__", string(matchers[3].Name))
+	assert.True(t, matchers[3].Matches(nil))
+	assert.False(t, matchers[3].Matches([]byte("baz")))
+
+	prefix, err := GlobToPrefixMatchers("foo.b*.{x,y}")
+	require.NoError(t, err)
+	assert.Equal(t, matchers[:3], prefix)
+}
+
+func TestGlobToMatchersErrors(t *testing.T) {
+	for _, query := range []string{"", "foo..bar", ".foo", "foo.{a"} {
+		_, err := GlobToMatchers(query)
+		assert.Error(t, err, query)
+	}
+}
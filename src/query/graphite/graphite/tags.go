@@ -0,0 +1,90 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+const (
+	// separator is the separator between the nodes of a graphite path.
+	separator = '.'
+)
+
+var (
+	tagPrefix = []byte("__g")
+	tagSuffix = []byte("__")
+
+	// preFormattedTagNames caches the names of the most commonly used tags
+	// to avoid allocating them on every write.
+	preFormattedTagNames = generateTagNames(32)
+)
+
+func generateTagNames(n int) [][]byte {
+	names := make([][]byte, 0, n)
+	for i := 0; i < n; i++ {
+		names = append(names, []byte(fmt.Sprintf("%s%d%s", tagPrefix, i, tagSuffix)))
+	}
+
+	return names
+}
+
+// TagName returns the tag name for the graphite path node at the given index,
+// i.e. `__g0__` for the first node, `__g1__` for the second and so on.
+func TagName(idx int) []byte {
+	if idx < len(preFormattedTagNames) {
+		return preFormattedTagNames[idx]
+	}
+
+	return []byte(fmt.Sprintf("%s%d%s", tagPrefix, idx, tagSuffix))
+}
+
+// TagIndex returns the index of the graphite path node for a given tag name,
+// returning false if the tag name is not a graphite tag name.
+func TagIndex(name []byte) (int, bool) {
+	if !bytes.HasPrefix(name, tagPrefix) || !bytes.HasSuffix(name, tagSuffix) {
+		return 0, false
+	}
+
+	if len(name) <= len(tagPrefix)+len(tagSuffix) {
+		return 0, false
+	}
+
+	idx, err := strconv.Atoi(string(name[len(tagPrefix) : len(name)-len(tagSuffix)]))
+	if err != nil || idx < 0 {
+		return 0, false
+	}
+
+	return idx, true
+}
+
+// Nodes splits a graphite path into its nodes, e.g. `foo.bar.baz`
+// becomes `foo`, `bar` and `baz`.
+func Nodes(path []byte) [][]byte {
+	return bytes.Split(path, []byte{separator})
+}
+
+// Join joins graphite path nodes back into a single path.
+func Join(nodes [][]byte) []byte {
+	return bytes.Join(nodes, []byte{separator})
+}
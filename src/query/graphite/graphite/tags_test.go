@@ -0,0 +1,56 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagName(t *testing.T) {
+	for i := 0; i < 64; i++ {
+		name := TagName(i)
+		idx, ok := TagIndex(name)
+		require.True(t, ok)
+		assert.Equal(t, i, idx)
+	}
+
+	assert.Equal(t, "__g0__", string(TagName(0)))
+	assert.Equal(t, "__g100__", string(TagName(100)))
+}
+
+func TestTagIndexInvalid(t *testing.T) {
+	for _, name := range []string{"", "__g__", "__gx__", "__g1", "g1__", "__name__", "__g-1__"} {
+		_, ok := TagIndex([]byte(name))
+		assert.False(t, ok, name)
+	}
+}
+
+func TestNodesAndJoin(t *testing.T) {
+	nodes := Nodes([]byte("foo.bar.baz"))
+	require.Equal(t, 3, len(nodes))
+	assert.Equal(t, "foo", string(nodes[0]))
+	assert.Equal(t, "bar", string(nodes[1]))
+	assert.Equal(t, "baz", string(nodes[2]))
+	assert.Equal(t, "foo.bar.baz", string(Join(nodes)))
+}
@@ -0,0 +1,325 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cost"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+)
+
+var (
+	errInvalidStep  = errors.New("graphite step must be positive")
+	errInvalidRange = errors.New("graphite until must be after from")
+)
+
+// RenderOptions are the options for rendering a graphite target.
+type RenderOptions struct {
+	Start time.Time
+	End   time.Time
+	Step  time.Duration
+}
+
+// Engine evaluates graphite targets against storage.
+type Engine struct {
+	querier   storage.Querier
+	enforcers *cost.EnforcerFactory
+}
+
+// NewEngine creates a new graphite engine which enforces the cost limits of
+// the enforcers on each render, no limits are enforced if they are nil.
+func NewEngine(querier storage.Querier, enforcers *cost.EnforcerFactory) *Engine {
+	return &Engine{
+		querier:   querier,
+		enforcers: enforcers,
+	}
+}
+
+// Render evaluates a graphite target, the start and end are truncated to
+// the step.
+func (e *Engine) Render(
+	ctx context.Context,
+	target string,
+	opts RenderOptions,
+) ([]Series, error) {
+	if opts.Step <= 0 {
+		return nil, errInvalidStep
+	}
+
+	start := opts.Start.Truncate(opts.Step)
+	end := opts.End.Truncate(opts.Step)
+	if !end.After(start) {
+		return nil, errInvalidRange
+	}
+
+	expr, err := parseExpression(target)
+	if err != nil {
+		return nil, err
+	}
+
+	// All the fetches of a target share the cost limits of the render
+	enforcer := e.enforcers.New()
+	ec := evalContext{
+		ctx:   ctx,
+		start: start,
+		end:   end,
+		step:  opts.Step,
+		fetch: func(
+			ctx context.Context,
+			path string,
+			start, end time.Time,
+			step time.Duration,
+		) ([]Series, error) {
+			return e.fetch(ctx, path, start, end, step, enforcer)
+		},
+	}
+
+	result, err := ec.evaluate(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	series, ok := result.([]Series)
+	if !ok {
+		return nil, fmt.Errorf("graphite target %s does not evaluate to a series list", target)
+	}
+
+	return series, nil
+}
+
+func (e *Engine) fetch(
+	ctx context.Context,
+	path string,
+	start, end time.Time,
+	step time.Duration,
+	enforcer *cost.Enforcer,
+) ([]Series, error) {
+	matchers, err := graphite.GlobToMatchers(path)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &storage.FetchQuery{
+		Raw:         path,
+		TagMatchers: matchers,
+		Start:       start,
+		End:         end,
+		Interval:    step,
+	}
+
+	result, err := e.querier.FetchBlocks(ctx, query, &storage.FetchOptions{
+		Enforcer: enforcer,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		for _, b := range result.Blocks {
+			b.Close()
+		}
+	}()
+
+	return blocksToSeries(result.Blocks, start, end, step)
+}
+
+// blocksToSeries stitches the series of each block together by series ID
+// into series spanning the whole query range.
+func blocksToSeries(
+	blocks []block.Block,
+	start, end time.Time,
+	step time.Duration,
+) ([]Series, error) {
+	var (
+		numSteps = int(end.Sub(start) / step)
+		byID     = make(map[string]int)
+		series   []Series
+	)
+	for _, b := range blocks {
+		iter, err := b.SeriesIter()
+		if err != nil {
+			return nil, err
+		}
+
+		bounds := iter.Meta().Bounds
+		if bounds.StepSize != step {
+			iter.Close()
+			return nil, fmt.Errorf("block step %v does not match query step %v",
+				bounds.StepSize, step)
+		}
+
+		offset := int(bounds.Start.Sub(start) / step)
+		for iter.Next() {
+			current, err := iter.Current()
+			if err != nil {
+				iter.Close()
+				return nil, err
+			}
+
+			id := current.Meta.Tags.ID()
+			idx, ok := byID[id]
+			if !ok {
+				values := make([]float64, numSteps)
+				for i := range values {
+					values[i] = math.NaN()
+				}
+
+				idx = len(series)
+				byID[id] = idx
+				series = append(series, Series{
+					Name:   seriesName(current.Meta),
+					Tags:   current.Meta.Tags,
+					Start:  start,
+					Step:   step,
+					Values: values,
+				})
+			}
+
+			values := series[idx].Values
+			for i := 0; i < current.Len(); i++ {
+				if pos := offset + i; pos >= 0 && pos < numSteps {
+					values[pos] = current.ValueAtStep(i)
+				}
+			}
+		}
+
+		iter.Close()
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].Name < series[j].Name
+	})
+
+	return series, nil
+}
+
+// seriesName reconstructs the graphite path from the positional tags,
+// falling back to the series name for series without graphite tags.
+func seriesName(meta block.SeriesMeta) string {
+	nodes := make([][]byte, 0, meta.Tags.Len())
+	for _, tag := range meta.Tags.Tags {
+		idx, ok := graphite.TagIndex(tag.Name)
+		if !ok {
+			continue
+		}
+
+		for len(nodes) <= idx {
+			nodes = append(nodes, nil)
+		}
+
+		nodes[idx] = tag.Value
+	}
+
+	if len(nodes) == 0 {
+		return meta.Name
+	}
+
+	return string(graphite.Join(nodes))
+}
+
+// FindResult is a single node in the graphite metrics tree.
+type FindResult struct {
+	ID   string
+	Text string
+	Leaf bool
+}
+
+// Find returns the nodes in the graphite metrics tree matching the query,
+// a node can be returned both as a leaf and a branch.
+func (e *Engine) Find(
+	ctx context.Context,
+	query string,
+	start, end time.Time,
+) ([]FindResult, error) {
+	matchers, err := graphite.GlobToPrefixMatchers(query)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := e.querier.FetchTags(ctx, &storage.FetchQuery{
+		Raw:         query,
+		TagMatchers: matchers,
+		Start:       start,
+		End:         end,
+	}, &storage.FetchOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		depth   = len(matchers)
+		seen    = make(map[FindResult]struct{})
+		results []FindResult
+	)
+	for _, metric := range result.Metrics {
+		nodes, ok := pathNodes(metric.Tags, depth)
+		if !ok {
+			continue
+		}
+
+		_, hasChildren := metric.Tags.Get(graphite.TagName(depth))
+		found := FindResult{
+			ID:   string(graphite.Join(nodes)),
+			Text: string(nodes[depth-1]),
+			Leaf: !hasChildren,
+		}
+
+		if _, ok := seen[found]; ok {
+			continue
+		}
+
+		seen[found] = struct{}{}
+		results = append(results, found)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].ID != results[j].ID {
+			return results[i].ID < results[j].ID
+		}
+		return !results[i].Leaf && results[j].Leaf
+	})
+
+	return results, nil
+}
+
+// pathNodes returns the first depth nodes of the graphite path in the tags.
+func pathNodes(tags models.Tags, depth int) ([][]byte, bool) {
+	nodes := make([][]byte, depth)
+	for i := range nodes {
+		value, ok := tags.Get(graphite.TagName(i))
+		if !ok {
+			return nil, false
+		}
+
+		nodes[i] = value
+	}
+
+	return nodes, true
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/ts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func graphiteTags(path ...string) models.Tags {
+	tags := models.NewTags(len(path), nil)
+	for i, node := range path {
+		tags = tags.AddTag(models.Tag{
+			Name:  graphite.TagName(i),
+			Value: []byte(node),
+		})
+	}
+
+	return tags
+}
+
+func newTestSeries(start time.Time, tags models.Tags, vals ...float64) *ts.Series {
+	datapoints := make(ts.Datapoints, 0, len(vals))
+	for i, v := range vals {
+		datapoints = append(datapoints, ts.Datapoint{
+			Timestamp: start.Add(time.Duration(i) * time.Minute),
+			Value:     v,
+		})
+	}
+
+	return ts.NewSeries("", datapoints, tags)
+}
+
+func TestEngineRender(t *testing.T) {
+	var (
+		start = time.Unix(1500000000, 0).Truncate(time.Minute)
+		end   = start.Add(3 * time.Minute)
+		store = mock.NewMockStorage()
+	)
+
+	query := &storage.FetchQuery{
+		Start:    start,
+		End:      end,
+		Interval: time.Minute,
+	}
+	result, err := storage.FetchResultToBlockResult(&storage.FetchResult{
+		SeriesList: ts.SeriesList{
+			newTestSeries(start, graphiteTags("foo", "a", "cpu"), 1, 2, 3),
+			newTestSeries(start, graphiteTags("foo", "b", "cpu"), 10, 20, 30),
+		},
+	}, query)
+	require.NoError(t, err)
+	store.SetFetchBlocksResult(result, nil)
+
+	engine := NewEngine(store, nil)
+	series, err := engine.Render(context.TODO(), "aliasByNode(foo.*.cpu, 1)", RenderOptions{
+		Start: start,
+		End:   end,
+		Step:  time.Minute,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(series))
+
+	assert.Equal(t, "a", series[0].Name)
+	assert.Equal(t, []float64{1, 2, 3}, series[0].Values)
+	assert.Equal(t, "b", series[1].Name)
+	assert.Equal(t, []float64{10, 20, 30}, series[1].Values)
+
+	series, err = engine.Render(context.TODO(), "sumSeries(foo.*.cpu)", RenderOptions{
+		Start: start,
+		End:   end,
+		Step:  time.Minute,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(series))
+	assert.Equal(t, []float64{11, 22, 33}, series[0].Values)
+}
+
+func TestEngineRenderErrors(t *testing.T) {
+	engine := NewEngine(mock.NewMockStorage(), nil)
+	now := time.Now()
+
+	_, err := engine.Render(context.TODO(), "foo", RenderOptions{
+		Start: now.Add(-time.Hour),
+		End:   now,
+	})
+	assert.Equal(t, errInvalidStep, err)
+
+	_, err = engine.Render(context.TODO(), "foo", RenderOptions{
+		Start: now,
+		End:   now.Add(-time.Hour),
+		Step:  time.Minute,
+	})
+	assert.Equal(t, errInvalidRange, err)
+
+	_, err = engine.Render(context.TODO(), "scale(foo", RenderOptions{
+		Start: now.Add(-time.Hour),
+		End:   now,
+		Step:  time.Minute,
+	})
+	assert.Error(t, err)
+}
+
+func TestEngineFind(t *testing.T) {
+	store := mock.NewMockStorage()
+	store.SetFetchTagsResult(&storage.SearchResults{
+		Metrics: models.Metrics{
+			{ID: "1", Tags: graphiteTags("foo", "a", "cpu")},
+			{ID: "2", Tags: graphiteTags("foo", "a", "mem")},
+			{ID: "3", Tags: graphiteTags("foo", "b")},
+			{ID: "4", Tags: graphiteTags("foo", "b", "cpu")},
+		},
+	}, nil)
+
+	engine := NewEngine(store, nil)
+	results, err := engine.Find(context.TODO(), "foo.*", time.Time{}, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []FindResult{
+		{ID: "foo.a", Text: "a", Leaf: false},
+		{ID: "foo.b", Text: "b", Leaf: false},
+		{ID: "foo.b", Text: "b", Leaf: true},
+	}, results)
+}
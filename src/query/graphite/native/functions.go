@@ -0,0 +1,669 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// fetchFn fetches the series for a graphite path.
+type fetchFn func(
+	ctx context.Context,
+	path string,
+	start, end time.Time,
+	step time.Duration,
+) ([]Series, error)
+
+// evalContext is the context a graphite expression is evaluated in.
+type evalContext struct {
+	ctx   context.Context
+	start time.Time
+	end   time.Time
+	step  time.Duration
+	fetch fetchFn
+}
+
+// function is a graphite function, functions evaluate their own arguments
+// so that they can adjust the context they are evaluated in.
+type function func(ec evalContext, call funcExpression) ([]Series, error)
+
+var functions map[string]function
+
+func init() {
+	functions = map[string]function{
+		"aliasByNode":   aliasByNode,
+		"averageSeries": averageSeries,
+		"avg":           averageSeries,
+		"groupByNode":   groupByNode,
+		"movingAverage": movingAverage,
+		"perSecond":     perSecond,
+		"scale":         scale,
+		"sum":           sumSeries,
+		"sumSeries":     sumSeries,
+		"summarize":     summarize,
+	}
+}
+
+// aggregationFn aggregates a set of values, returning NaN if there are no
+// non-NaN values.
+type aggregationFn func(values []float64) float64
+
+var aggregationFns = map[string]aggregationFn{
+	"sum":     aggregateSum,
+	"avg":     aggregateAverage,
+	"average": aggregateAverage,
+	"max":     aggregateMax,
+	"min":     aggregateMin,
+	"last":    aggregateLast,
+	"count":   aggregateCount,
+}
+
+func aggregateSum(values []float64) float64 {
+	sum, n := 0.0, 0
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			sum += v
+			n++
+		}
+	}
+
+	if n == 0 {
+		return math.NaN()
+	}
+
+	return sum
+}
+
+func aggregateAverage(values []float64) float64 {
+	sum, n := 0.0, 0
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			sum += v
+			n++
+		}
+	}
+
+	if n == 0 {
+		return math.NaN()
+	}
+
+	return sum / float64(n)
+}
+
+func aggregateMax(values []float64) float64 {
+	max := math.NaN()
+	for _, v := range values {
+		if !math.IsNaN(v) && (math.IsNaN(max) || v > max) {
+			max = v
+		}
+	}
+
+	return max
+}
+
+func aggregateMin(values []float64) float64 {
+	min := math.NaN()
+	for _, v := range values {
+		if !math.IsNaN(v) && (math.IsNaN(min) || v < min) {
+			min = v
+		}
+	}
+
+	return min
+}
+
+func aggregateLast(values []float64) float64 {
+	for i := len(values) - 1; i >= 0; i-- {
+		if !math.IsNaN(values[i]) {
+			return values[i]
+		}
+	}
+
+	return math.NaN()
+}
+
+func aggregateCount(values []float64) float64 {
+	n := 0
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			n++
+		}
+	}
+
+	if n == 0 {
+		return math.NaN()
+	}
+
+	return float64(n)
+}
+
+func (ec evalContext) evaluate(expr expression) (interface{}, error) {
+	switch e := expr.(type) {
+	case fetchExpression:
+		return ec.fetch(ec.ctx, e.path, ec.start, ec.end, ec.step)
+	case funcExpression:
+		fn, ok := functions[e.name]
+		if !ok {
+			return nil, fmt.Errorf("unknown graphite function: %s", e.name)
+		}
+		return fn(ec, e)
+	case numberExpression:
+		return e.value, nil
+	case stringExpression:
+		return e.value, nil
+	case boolExpression:
+		return e.value, nil
+	}
+
+	return nil, fmt.Errorf("unknown graphite expression: %s", expr)
+}
+
+func (ec evalContext) seriesListArg(call funcExpression, idx int) ([]Series, error) {
+	if idx >= len(call.args) {
+		return nil, fmt.Errorf("%s: missing series list argument %d", call.name, idx)
+	}
+
+	v, err := ec.evaluate(call.args[idx])
+	if err != nil {
+		return nil, err
+	}
+
+	series, ok := v.([]Series)
+	if !ok {
+		return nil, fmt.Errorf("%s: argument %d must be a series list", call.name, idx)
+	}
+
+	return series, nil
+}
+
+// seriesListArgs evaluates all the arguments from the index onwards as
+// series lists, concatenating the results.
+func (ec evalContext) seriesListArgs(call funcExpression, from int) ([]Series, error) {
+	var result []Series
+	for idx := from; idx < len(call.args); idx++ {
+		series, err := ec.seriesListArg(call, idx)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, series...)
+	}
+
+	return result, nil
+}
+
+func (ec evalContext) numberArg(call funcExpression, idx int) (float64, error) {
+	if idx >= len(call.args) {
+		return 0, fmt.Errorf("%s: missing number argument %d", call.name, idx)
+	}
+
+	n, ok := call.args[idx].(numberExpression)
+	if !ok {
+		return 0, fmt.Errorf("%s: argument %d must be a number", call.name, idx)
+	}
+
+	return n.value, nil
+}
+
+func (ec evalContext) intArg(call funcExpression, idx int) (int, error) {
+	n, err := ec.numberArg(call, idx)
+	if err != nil {
+		return 0, err
+	}
+
+	if n != math.Trunc(n) {
+		return 0, fmt.Errorf("%s: argument %d must be an integer", call.name, idx)
+	}
+
+	return int(n), nil
+}
+
+func (ec evalContext) optionalStringArg(
+	call funcExpression,
+	idx int,
+	defaultValue string,
+) (string, error) {
+	if idx >= len(call.args) {
+		return defaultValue, nil
+	}
+
+	s, ok := call.args[idx].(stringExpression)
+	if !ok {
+		return "", fmt.Errorf("%s: argument %d must be a string", call.name, idx)
+	}
+
+	return s.value, nil
+}
+
+func (ec evalContext) optionalBoolArg(
+	call funcExpression,
+	idx int,
+	defaultValue bool,
+) (bool, error) {
+	if idx >= len(call.args) {
+		return defaultValue, nil
+	}
+
+	b, ok := call.args[idx].(boolExpression)
+	if !ok {
+		return false, fmt.Errorf("%s: argument %d must be a boolean", call.name, idx)
+	}
+
+	return b.value, nil
+}
+
+func aggregationFnArg(name string, fnName string) (aggregationFn, error) {
+	fn, ok := aggregationFns[fnName]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown aggregation function %s", name, fnName)
+	}
+
+	return fn, nil
+}
+
+// combineSeries aggregates the series step by step into a single series,
+// the series must share the same start, step and length.
+func combineSeries(name string, series []Series, fn aggregationFn) ([]Series, error) {
+	if len(series) == 0 {
+		return nil, nil
+	}
+
+	first := series[0]
+	for _, s := range series[1:] {
+		if !s.Start.Equal(first.Start) || s.Step != first.Step || s.Len() != first.Len() {
+			return nil, fmt.Errorf("cannot combine series %s and %s: mismatched bounds",
+				first.Name, s.Name)
+		}
+	}
+
+	var (
+		values   = make([]float64, first.Len())
+		stepVals = make([]float64, len(series))
+	)
+	for i := range values {
+		for j, s := range series {
+			stepVals[j] = s.Values[i]
+		}
+
+		values[i] = fn(stepVals)
+	}
+
+	return []Series{{
+		Name:   name,
+		Start:  first.Start,
+		Step:   first.Step,
+		Values: values,
+	}}, nil
+}
+
+func argsText(call funcExpression, from int) string {
+	texts := make([]string, 0, len(call.args))
+	for _, arg := range call.args[from:] {
+		texts = append(texts, arg.String())
+	}
+
+	return strings.Join(texts, ",")
+}
+
+// sumSeries(*seriesLists) adds the series together at each step.
+func sumSeries(ec evalContext, call funcExpression) ([]Series, error) {
+	series, err := ec.seriesListArgs(call, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("sumSeries(%s)", argsText(call, 0))
+	return combineSeries(name, series, aggregateSum)
+}
+
+// averageSeries(*seriesLists) averages the series at each step.
+func averageSeries(ec evalContext, call funcExpression) ([]Series, error) {
+	series, err := ec.seriesListArgs(call, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("averageSeries(%s)", argsText(call, 0))
+	return combineSeries(name, series, aggregateAverage)
+}
+
+// scale(seriesList, factor) multiplies each value by the factor.
+func scale(ec evalContext, call funcExpression) ([]Series, error) {
+	series, err := ec.seriesListArg(call, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	factor, err := ec.numberArg(call, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Series, 0, len(series))
+	for _, s := range series {
+		values := make([]float64, s.Len())
+		for i, v := range s.Values {
+			values[i] = v * factor
+		}
+
+		name := fmt.Sprintf("scale(%s,%s)", s.Name, call.args[1].String())
+		results = append(results, s.withValues(name, values))
+	}
+
+	return results, nil
+}
+
+// perSecond(seriesList, maxValue=None) computes the per second rate of
+// increase of counters, treating decreases as counter resets which wrap
+// at maxValue if provided.
+func perSecond(ec evalContext, call funcExpression) ([]Series, error) {
+	series, err := ec.seriesListArg(call, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	maxValue := math.NaN()
+	if len(call.args) > 1 {
+		if maxValue, err = ec.numberArg(call, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]Series, 0, len(series))
+	for _, s := range series {
+		var (
+			values  = make([]float64, s.Len())
+			prev    = math.NaN()
+			prevIdx int
+		)
+		for i, v := range s.Values {
+			values[i] = math.NaN()
+			if math.IsNaN(v) {
+				continue
+			}
+
+			if !math.IsNaN(prev) {
+				delta := v - prev
+				if delta < 0 && !math.IsNaN(maxValue) && maxValue >= prev {
+					delta = maxValue - prev + v + 1
+				}
+
+				if delta >= 0 {
+					elapsed := time.Duration(i-prevIdx) * s.Step
+					values[i] = delta / elapsed.Seconds()
+				}
+			}
+
+			prev, prevIdx = v, i
+		}
+
+		results = append(results, s.withValues(fmt.Sprintf("perSecond(%s)", s.Name), values))
+	}
+
+	return results, nil
+}
+
+// aliasByNode(seriesList, *nodes) renames each series to the given nodes of
+// its path, negative nodes index from the end of the path.
+func aliasByNode(ec evalContext, call funcExpression) ([]Series, error) {
+	series, err := ec.seriesListArg(call, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]int, 0, len(call.args)-1)
+	for idx := 1; idx < len(call.args); idx++ {
+		node, err := ec.intArg(call, idx)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	results := make([]Series, 0, len(series))
+	for _, s := range series {
+		parts := strings.Split(pathFromName(s.Name), ".")
+		aliased := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			if node < 0 {
+				node += len(parts)
+			}
+
+			if node < 0 || node >= len(parts) {
+				continue
+			}
+
+			aliased = append(aliased, parts[node])
+		}
+
+		results = append(results, s.withValues(strings.Join(aliased, "."), s.Values))
+	}
+
+	return results, nil
+}
+
+// movingAverage(seriesList, windowSize) averages each value with the values
+// in the preceding window, where the window is either a number of points or
+// an interval string. Data before the query start is fetched to fill the
+// first window.
+func movingAverage(ec evalContext, call funcExpression) ([]Series, error) {
+	if len(call.args) != 2 {
+		return nil, fmt.Errorf("movingAverage: expected 2 arguments, got %d", len(call.args))
+	}
+
+	var windowPoints int
+	switch window := call.args[1].(type) {
+	case numberExpression:
+		windowPoints = int(window.value)
+	case stringExpression:
+		interval, err := ParseInterval(window.value)
+		if err != nil {
+			return nil, err
+		}
+		if interval < 0 {
+			interval = -interval
+		}
+		windowPoints = int(interval / ec.step)
+	default:
+		return nil, fmt.Errorf("movingAverage: window must be a number or interval string")
+	}
+
+	if windowPoints <= 0 {
+		return nil, fmt.Errorf("movingAverage: window must be at least one step")
+	}
+
+	bootstrapped := ec
+	bootstrapped.start = ec.start.Add(-time.Duration(windowPoints) * ec.step)
+	series, err := bootstrapped.seriesListArg(call, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Series, 0, len(series))
+	for _, s := range series {
+		// Only emit points from the original query start onwards.
+		offset := int(ec.start.Sub(s.Start) / s.Step)
+		if offset < 0 {
+			offset = 0
+		}
+
+		if offset > s.Len() {
+			offset = s.Len()
+		}
+
+		values := make([]float64, s.Len()-offset)
+		for i := range values {
+			idx := i + offset
+			windowStart := idx - windowPoints
+			if windowStart < 0 {
+				windowStart = 0
+			}
+
+			values[i] = aggregateAverage(s.Values[windowStart:idx])
+		}
+
+		result := s.withValues(
+			fmt.Sprintf("movingAverage(%s,%s)", s.Name, call.args[1].String()), values)
+		result.Start = s.TimeAt(offset)
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// summarize(seriesList, intervalString, func='sum', alignToFrom=False)
+// consolidates each series into buckets of the interval.
+func summarize(ec evalContext, call funcExpression) ([]Series, error) {
+	series, err := ec.seriesListArg(call, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	intervalStr, err := ec.optionalStringArg(call, 1, "")
+	if err != nil {
+		return nil, err
+	}
+
+	interval, err := ParseInterval(intervalStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("summarize: interval must be positive")
+	}
+
+	fnName, err := ec.optionalStringArg(call, 2, "sum")
+	if err != nil {
+		return nil, err
+	}
+
+	fn, err := aggregationFnArg(call.name, fnName)
+	if err != nil {
+		return nil, err
+	}
+
+	alignToFrom, err := ec.optionalBoolArg(call, 3, false)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Series, 0, len(series))
+	for _, s := range series {
+		bucketStart := s.Start
+		if !alignToFrom {
+			bucketStart = time.Unix(0, s.Start.UnixNano()-s.Start.UnixNano()%int64(interval))
+		}
+
+		numBuckets := int((s.End().Sub(bucketStart) + interval - 1) / interval)
+		buckets := make([][]float64, numBuckets)
+		for i, v := range s.Values {
+			idx := int(s.TimeAt(i).Sub(bucketStart) / interval)
+			if idx >= 0 && idx < numBuckets {
+				buckets[idx] = append(buckets[idx], v)
+			}
+		}
+
+		values := make([]float64, numBuckets)
+		for i, bucket := range buckets {
+			values[i] = fn(bucket)
+		}
+
+		name := fmt.Sprintf("summarize(%s, %q, %q)", s.Name, intervalStr, fnName)
+		if alignToFrom {
+			name = fmt.Sprintf("summarize(%s, %q, %q, true)", s.Name, intervalStr, fnName)
+		}
+
+		results = append(results, Series{
+			Name:   name,
+			Tags:   s.Tags,
+			Start:  bucketStart,
+			Step:   interval,
+			Values: values,
+		})
+	}
+
+	return results, nil
+}
+
+// groupByNode(seriesList, nodeNum, callback='average') groups the series by
+// the given node of their path and aggregates each group with the callback.
+func groupByNode(ec evalContext, call funcExpression) ([]Series, error) {
+	series, err := ec.seriesListArg(call, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := ec.intArg(call, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	fnName, err := ec.optionalStringArg(call, 2, "average")
+	if err != nil {
+		return nil, err
+	}
+
+	fn, err := aggregationFnArg(call.name, fnName)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]Series)
+	for _, s := range series {
+		parts := strings.Split(pathFromName(s.Name), ".")
+		idx := node
+		if idx < 0 {
+			idx += len(parts)
+		}
+
+		if idx < 0 || idx >= len(parts) {
+			continue
+		}
+
+		key := parts[idx]
+		groups[key] = append(groups[key], s)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	results := make([]Series, 0, len(keys))
+	for _, key := range keys {
+		combined, err := combineSeries(key, groups[key], fn)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, combined...)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testStart = time.Unix(1500000000, 0)
+	testStep  = 10 * time.Second
+	nan       = math.NaN()
+)
+
+// testFetcher generates series whose value at each step is produced by
+// a function of the step's time, so that bootstrapped fetches stay
+// consistent with the original range.
+type testFetcher map[string]map[string]func(t time.Time) float64
+
+func (f testFetcher) fetch(
+	_ context.Context,
+	path string,
+	start, end time.Time,
+	step time.Duration,
+) ([]Series, error) {
+	var results []Series
+	for _, name := range sortedKeys(f[path]) {
+		gen := f[path][name]
+		var values []float64
+		for t := start; t.Before(end); t = t.Add(step) {
+			values = append(values, gen(t))
+		}
+
+		results = append(results, Series{
+			Name:   name,
+			Start:  start,
+			Step:   step,
+			Values: values,
+		})
+	}
+
+	return results, nil
+}
+
+func sortedKeys(m map[string]func(time.Time) float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	for i := range keys {
+		for j := i + 1; j < len(keys); j++ {
+			if keys[j] < keys[i] {
+				keys[i], keys[j] = keys[j], keys[i]
+			}
+		}
+	}
+
+	return keys
+}
+
+func constant(v float64) func(time.Time) float64 {
+	return func(time.Time) float64 { return v }
+}
+
+func values(vals ...float64) func(time.Time) float64 {
+	return func(t time.Time) float64 {
+		idx := int(t.Sub(testStart) / testStep)
+		if idx < 0 || idx >= len(vals) {
+			return nan
+		}
+		return vals[idx]
+	}
+}
+
+func counter(perStep float64) func(time.Time) float64 {
+	return func(t time.Time) float64 {
+		return float64(t.Sub(testStart)/testStep) * perStep
+	}
+}
+
+var testData = testFetcher{
+	"foo.*.cpu": {
+		"foo.a.cpu": values(1, 2, nan, 4),
+		"foo.b.cpu": values(10, nan, nan, 40),
+	},
+	"foo.a.cpu": {
+		"foo.a.cpu": values(1, 2, nan, 4),
+	},
+	"servers.*.*": {
+		"servers.dc1.host1": constant(1),
+		"servers.dc1.host2": constant(3),
+		"servers.dc2.host3": constant(5),
+	},
+	"counter": {
+		"counter": counter(20),
+	},
+	"reset": {
+		"reset": values(100, 200, 50, 150),
+	},
+}
+
+func evalTarget(t *testing.T, target string, steps int) []Series {
+	expr, err := parseExpression(target)
+	require.NoError(t, err)
+
+	ec := evalContext{
+		ctx:   context.TODO(),
+		start: testStart,
+		end:   testStart.Add(time.Duration(steps) * testStep),
+		step:  testStep,
+		fetch: testData.fetch,
+	}
+
+	result, err := ec.evaluate(expr)
+	require.NoError(t, err)
+
+	series, ok := result.([]Series)
+	require.True(t, ok)
+	return series
+}
+
+func requireValues(t *testing.T, expected, actual []float64) {
+	require.Equal(t, len(expected), len(actual))
+	for i := range expected {
+		if math.IsNaN(expected[i]) {
+			assert.True(t, math.IsNaN(actual[i]), "expected NaN at %d, got %v", i, actual[i])
+			continue
+		}
+
+		assert.InDelta(t, expected[i], actual[i], 1e-9, "index %d", i)
+	}
+}
+
+func TestSumSeries(t *testing.T) {
+	series := evalTarget(t, "sumSeries(foo.*.cpu)", 4)
+	require.Equal(t, 1, len(series))
+	assert.Equal(t, "sumSeries(foo.*.cpu)", series[0].Name)
+	requireValues(t, []float64{11, 2, nan, 44}, series[0].Values)
+}
+
+func TestAverageSeries(t *testing.T) {
+	series := evalTarget(t, "averageSeries(foo.*.cpu)", 4)
+	require.Equal(t, 1, len(series))
+	assert.Equal(t, "averageSeries(foo.*.cpu)", series[0].Name)
+	requireValues(t, []float64{5.5, 2, nan, 22}, series[0].Values)
+}
+
+func TestScale(t *testing.T) {
+	series := evalTarget(t, "scale(foo.a.cpu, 2.5)", 4)
+	require.Equal(t, 1, len(series))
+	assert.Equal(t, "scale(foo.a.cpu,2.5)", series[0].Name)
+	requireValues(t, []float64{2.5, 5, nan, 10}, series[0].Values)
+}
+
+func TestPerSecond(t *testing.T) {
+	series := evalTarget(t, "perSecond(counter)", 4)
+	require.Equal(t, 1, len(series))
+	assert.Equal(t, "perSecond(counter)", series[0].Name)
+	requireValues(t, []float64{nan, 2, 2, 2}, series[0].Values)
+
+	// Gaps are divided over the elapsed time.
+	series = evalTarget(t, "perSecond(foo.a.cpu)", 4)
+	requireValues(t, []float64{nan, 0.1, nan, 0.1}, series[0].Values)
+
+	// Resets produce NaN unless a max value is provided.
+	series = evalTarget(t, "perSecond(reset)", 4)
+	requireValues(t, []float64{nan, 10, nan, 10}, series[0].Values)
+
+	series = evalTarget(t, "perSecond(reset, 255)", 4)
+	requireValues(t, []float64{nan, 10, 10.6, 10}, series[0].Values)
+}
+
+func TestAliasByNode(t *testing.T) {
+	series := evalTarget(t, "aliasByNode(scale(servers.*.*, 1), 1, -1)", 1)
+	require.Equal(t, 3, len(series))
+	assert.Equal(t, "dc1.host1", series[0].Name)
+	assert.Equal(t, "dc1.host2", series[1].Name)
+	assert.Equal(t, "dc2.host3", series[2].Name)
+}
+
+func TestMovingAverage(t *testing.T) {
+	series := evalTarget(t, "movingAverage(counter, 2)", 3)
+	require.Equal(t, 1, len(series))
+	assert.Equal(t, "movingAverage(counter,2)", series[0].Name)
+	assert.True(t, testStart.Equal(series[0].Start))
+
+	// The window is bootstrapped with the two points before the start,
+	// which have values -40 and -20.
+	requireValues(t, []float64{-30, -10, 10}, series[0].Values)
+
+	series = evalTarget(t, `movingAverage(counter, "20s")`, 3)
+	assert.Equal(t, `movingAverage(counter,"20s")`, series[0].Name)
+	requireValues(t, []float64{-30, -10, 10}, series[0].Values)
+}
+
+func TestSummarize(t *testing.T) {
+	series := evalTarget(t, `summarize(counter, "30s", "sum", true)`, 6)
+	require.Equal(t, 1, len(series))
+	assert.Equal(t, `summarize(counter, "30s", "sum", true)`, series[0].Name)
+	assert.Equal(t, 30*time.Second, series[0].Step)
+	assert.True(t, testStart.Equal(series[0].Start))
+	requireValues(t, []float64{60, 240}, series[0].Values)
+
+	series = evalTarget(t, `summarize(counter, "30s", "max", true)`, 6)
+	requireValues(t, []float64{40, 100}, series[0].Values)
+
+	// Without alignToFrom buckets are aligned to the interval, the test
+	// start is 30s past a 70s boundary.
+	series = evalTarget(t, `summarize(counter, "70s", "last")`, 6)
+	assert.Equal(t, `summarize(counter, "70s", "last")`, series[0].Name)
+	assert.True(t, testStart.Add(-30*time.Second).Equal(series[0].Start))
+	requireValues(t, []float64{60, 100}, series[0].Values)
+}
+
+func TestGroupByNode(t *testing.T) {
+	series := evalTarget(t, `groupByNode(servers.*.*, 1, "sum")`, 2)
+	require.Equal(t, 2, len(series))
+	assert.Equal(t, "dc1", series[0].Name)
+	requireValues(t, []float64{4, 4}, series[0].Values)
+	assert.Equal(t, "dc2", series[1].Name)
+	requireValues(t, []float64{5, 5}, series[1].Values)
+
+	series = evalTarget(t, "groupByNode(servers.*.*, 1)", 1)
+	requireValues(t, []float64{2}, series[0].Values)
+}
+
+func TestFunctionErrors(t *testing.T) {
+	for _, target := range []string{
+		"unknownFunction(foo.a.cpu)",
+		"scale(foo.a.cpu)",
+		`scale(foo.a.cpu, "2")`,
+		"movingAverage(foo.a.cpu)",
+		"movingAverage(foo.a.cpu, 0)",
+		`summarize(foo.a.cpu, "1h", "median")`,
+		`groupByNode(foo.*.cpu, 1.5)`,
+		`sumSeries(foo.a.cpu, summarize(foo.a.cpu, "1h"))`,
+	} {
+		expr, err := parseExpression(target)
+		require.NoError(t, err, target)
+
+		ec := evalContext{
+			ctx:   context.TODO(),
+			start: testStart,
+			end:   testStart.Add(4 * testStep),
+			step:  testStep,
+			fetch: testData.fetch,
+		}
+
+		_, err = ec.evaluate(expr)
+		assert.Error(t, err, target)
+	}
+}
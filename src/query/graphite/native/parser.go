@@ -0,0 +1,266 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// expression is a parsed graphite target expression.
+type expression interface {
+	// String returns the original text of the expression, used to name
+	// the series produced by functions.
+	String() string
+}
+
+// fetchExpression fetches the series matching a graphite path glob.
+type fetchExpression struct {
+	path string
+}
+
+func (e fetchExpression) String() string { return e.path }
+
+// funcExpression calls a graphite function.
+type funcExpression struct {
+	name string
+	args []expression
+	text string
+}
+
+func (e funcExpression) String() string { return e.text }
+
+type numberExpression struct {
+	value float64
+	text  string
+}
+
+func (e numberExpression) String() string { return e.text }
+
+type stringExpression struct {
+	value string
+}
+
+func (e stringExpression) String() string { return strconv.Quote(e.value) }
+
+type boolExpression struct {
+	value bool
+}
+
+func (e boolExpression) String() string { return strconv.FormatBool(e.value) }
+
+type parser struct {
+	input string
+	pos   int
+}
+
+// parseExpression parses a graphite target such as
+// `aliasByNode(sumSeries(foo.*.bar), 1)`.
+func parseExpression(input string) (expression, error) {
+	p := &parser{input: input}
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipWhitespace()
+	if p.pos != len(p.input) {
+		return nil, p.errorf("unexpected trailing input")
+	}
+
+	return expr, nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid graphite target %q at position %d: %s",
+		p.input, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) skipWhitespace() {
+	for p.pos < len(p.input) && isWhitespace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() byte {
+	if p.pos >= len(p.input) {
+		return 0
+	}
+
+	return p.input[p.pos]
+}
+
+func (p *parser) parseExpr() (expression, error) {
+	p.skipWhitespace()
+	if p.pos >= len(p.input) {
+		return nil, p.errorf("unexpected end of input")
+	}
+
+	c := p.peek()
+	switch {
+	case c == '"' || c == '\'':
+		return p.parseString()
+	case c == '-' || c == '+' || isDigit(c):
+		if expr, ok := p.tryParseNumber(); ok {
+			return expr, nil
+		}
+	}
+
+	start := p.pos
+	token := p.readPathToken()
+	if token == "" {
+		return nil, p.errorf("unexpected character %q", c)
+	}
+
+	p.skipWhitespace()
+	if p.peek() == '(' {
+		return p.parseCall(start, token)
+	}
+
+	switch token {
+	case "true", "True":
+		return boolExpression{value: true}, nil
+	case "false", "False":
+		return boolExpression{value: false}, nil
+	}
+
+	return fetchExpression{path: token}, nil
+}
+
+func (p *parser) parseCall(start int, name string) (expression, error) {
+	// Consume the open paren.
+	p.pos++
+	var args []expression
+	p.skipWhitespace()
+	if p.peek() == ')' {
+		p.pos++
+		return funcExpression{name: name, text: p.input[start:p.pos]}, nil
+	}
+
+	for {
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+		p.skipWhitespace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return funcExpression{
+				name: name,
+				args: args,
+				text: p.input[start:p.pos],
+			}, nil
+		default:
+			return nil, p.errorf("expected ',' or ')' in call to %s", name)
+		}
+	}
+}
+
+func (p *parser) parseString() (expression, error) {
+	quote := p.input[p.pos]
+	p.pos++
+	end := strings.IndexByte(p.input[p.pos:], quote)
+	if end < 0 {
+		return nil, p.errorf("unterminated string")
+	}
+
+	value := p.input[p.pos : p.pos+end]
+	p.pos += end + 1
+	return stringExpression{value: value}, nil
+}
+
+func (p *parser) tryParseNumber() (expression, bool) {
+	end := p.pos
+	for end < len(p.input) && isNumberChar(p.input[end]) {
+		end++
+	}
+
+	// A number must be terminated by an argument boundary, otherwise this is
+	// a path that happens to start with a digit, e.g. `1min.foo`.
+	if end < len(p.input) {
+		if c := p.input[end]; c != ',' && c != ')' && !isWhitespace(c) {
+			return nil, false
+		}
+	}
+
+	text := p.input[p.pos:end]
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	p.pos = end
+	return numberExpression{value: value, text: text}, true
+}
+
+// readPathToken reads an identifier or path glob. Commas inside braces are
+// part of the glob, e.g. `foo.{a,b}.baz`.
+func (p *parser) readPathToken() string {
+	var (
+		start = p.pos
+		depth int
+	)
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		switch {
+		case c == '{':
+			depth++
+		case c == '}':
+			if depth == 0 {
+				return p.input[start:p.pos]
+			}
+			depth--
+		case c == ',' && depth > 0:
+		case !isPathChar(c):
+			return p.input[start:p.pos]
+		}
+		p.pos++
+	}
+
+	return p.input[start:p.pos]
+}
+
+func isWhitespace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isNumberChar(c byte) bool {
+	return isDigit(c) || c == '.' || c == '-' || c == '+' || c == 'e' || c == 'E'
+}
+
+func isPathChar(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', isDigit(c):
+		return true
+	}
+
+	return strings.IndexByte("._-*?[]!:#$%^&=~<>|@", c) >= 0
+}
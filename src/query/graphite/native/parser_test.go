@@ -0,0 +1,83 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpression(t *testing.T) {
+	expr, err := parseExpression(`aliasByNode(sumSeries(foo.{a,b}.*, bar.baz), 1, -1)`)
+	require.NoError(t, err)
+
+	call, ok := expr.(funcExpression)
+	require.True(t, ok)
+	assert.Equal(t, "aliasByNode", call.name)
+	require.Equal(t, 3, len(call.args))
+	assert.Equal(t, numberExpression{value: 1, text: "1"}, call.args[1])
+	assert.Equal(t, numberExpression{value: -1, text: "-1"}, call.args[2])
+
+	inner, ok := call.args[0].(funcExpression)
+	require.True(t, ok)
+	assert.Equal(t, "sumSeries", inner.name)
+	assert.Equal(t, "sumSeries(foo.{a,b}.*, bar.baz)", inner.String())
+	assert.Equal(t, []expression{
+		fetchExpression{path: "foo.{a,b}.*"},
+		fetchExpression{path: "bar.baz"},
+	}, inner.args)
+}
+
+func TestParseExpressionLiterals(t *testing.T) {
+	expr, err := parseExpression(`summarize(foo.bar, "1h", 'max', true)`)
+	require.NoError(t, err)
+
+	call, ok := expr.(funcExpression)
+	require.True(t, ok)
+	assert.Equal(t, []expression{
+		fetchExpression{path: "foo.bar"},
+		stringExpression{value: "1h"},
+		stringExpression{value: "max"},
+		boolExpression{value: true},
+	}, call.args)
+}
+
+func TestParseExpressionPathStartingWithDigit(t *testing.T) {
+	expr, err := parseExpression(`10s.foo.bar`)
+	require.NoError(t, err)
+	assert.Equal(t, fetchExpression{path: "10s.foo.bar"}, expr)
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	for _, target := range []string{
+		"",
+		"sumSeries(foo",
+		"sumSeries(foo bar)",
+		`scale(foo, "2)`,
+		"foo.bar)",
+		"(foo)",
+	} {
+		_, err := parseExpression(target)
+		assert.Error(t, err, target)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+// Series is a graphite series with values at a fixed step.
+type Series struct {
+	Name   string
+	Tags   models.Tags
+	Start  time.Time
+	Step   time.Duration
+	Values []float64
+}
+
+// TimeAt returns the time of the value at the given index.
+func (s Series) TimeAt(idx int) time.Time {
+	return s.Start.Add(time.Duration(idx) * s.Step)
+}
+
+// End returns the exclusive end time of the series.
+func (s Series) End() time.Time {
+	return s.TimeAt(len(s.Values))
+}
+
+// Len returns the number of values in the series.
+func (s Series) Len() int {
+	return len(s.Values)
+}
+
+// withValues returns a copy of the series with new name and values.
+func (s Series) withValues(name string, values []float64) Series {
+	return Series{
+		Name:   name,
+		Tags:   s.Tags,
+		Start:  s.Start,
+		Step:   s.Step,
+		Values: values,
+	}
+}
+
+// pathFromName extracts the innermost graphite path from a series name, e.g.
+// `foo.bar` from `scale(sumSeries(foo.bar),2)`, matching graphite's
+// behavior for node based functions.
+func pathFromName(name string) string {
+	name = strings.Split(name, ",")[0]
+	name = strings.Split(name, ")")[0]
+	parts := strings.Split(name, "(")
+	return parts[len(parts)-1]
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	intervalRegex = regexp.MustCompile(`^([+-]?)(\d+)([a-zA-Z]+)$`)
+
+	// dateFormats are the absolute time formats accepted by graphite.
+	dateFormats = []string{
+		"15:04_20060102",
+		"20060102",
+		"01/02/06",
+		"2006-01-02",
+	}
+)
+
+// ParseInterval parses a graphite interval string such as `5min`, `-1h` or
+// `2weeks` into a duration.
+func ParseInterval(s string) (time.Duration, error) {
+	matches := intervalRegex.FindStringSubmatch(strings.TrimSpace(s))
+	if matches == nil {
+		return 0, fmt.Errorf("invalid graphite interval: %s", s)
+	}
+
+	n, err := strconv.ParseInt(matches[2], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid graphite interval: %s", s)
+	}
+
+	unit, err := parseIntervalUnit(matches[3])
+	if err != nil {
+		return 0, fmt.Errorf("invalid graphite interval %s: %v", s, err)
+	}
+
+	d := time.Duration(n) * unit
+	if matches[1] == "-" {
+		d = -d
+	}
+
+	return d, nil
+}
+
+func parseIntervalUnit(unit string) (time.Duration, error) {
+	unit = strings.ToLower(unit)
+	switch {
+	case unit == "s" || strings.HasPrefix(unit, "sec"):
+		return time.Second, nil
+	case unit == "m" || strings.HasPrefix(unit, "min"):
+		return time.Minute, nil
+	case unit == "h" || strings.HasPrefix(unit, "hour"):
+		return time.Hour, nil
+	case unit == "d" || strings.HasPrefix(unit, "day"):
+		return 24 * time.Hour, nil
+	case unit == "w" || strings.HasPrefix(unit, "week"):
+		return 7 * 24 * time.Hour, nil
+	case strings.HasPrefix(unit, "mon"):
+		return 30 * 24 * time.Hour, nil
+	case unit == "y" || strings.HasPrefix(unit, "year"):
+		return 365 * 24 * time.Hour, nil
+	}
+
+	return 0, fmt.Errorf("unknown unit %s", unit)
+}
+
+// ParseTime parses a graphite `from` or `until` value, which may be `now`,
+// a relative interval such as `-1h`, a unix timestamp in seconds or an
+// absolute date such as `12:00_20180101`.
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "" || s == "now":
+		return now, nil
+	case s[0] == '-' || s[0] == '+':
+		d, err := ParseInterval(s)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(d), nil
+	}
+
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil && len(s) != len("20060102") {
+		return time.Unix(secs, 0), nil
+	}
+
+	for _, format := range dateFormats {
+		if t, err := time.ParseInLocation(format, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid graphite time: %s", s)
+}
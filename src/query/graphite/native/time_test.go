@@ -0,0 +1,80 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		interval string
+		expected time.Duration
+	}{
+		{interval: "30s", expected: 30 * time.Second},
+		{interval: "5min", expected: 5 * time.Minute},
+		{interval: "-1h", expected: -time.Hour},
+		{interval: "2hours", expected: 2 * time.Hour},
+		{interval: "1d", expected: 24 * time.Hour},
+		{interval: "1w", expected: 7 * 24 * time.Hour},
+		{interval: "1mon", expected: 30 * 24 * time.Hour},
+		{interval: "1y", expected: 365 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		d, err := ParseInterval(tt.interval)
+		require.NoError(t, err, tt.interval)
+		assert.Equal(t, tt.expected, d, tt.interval)
+	}
+
+	for _, interval := range []string{"", "1", "h", "1x", "1.5h"} {
+		_, err := ParseInterval(interval)
+		assert.Error(t, err, interval)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{value: "now", expected: now},
+		{value: "", expected: now},
+		{value: "-1h", expected: now.Add(-time.Hour)},
+		{value: "1538395200", expected: time.Unix(1538395200, 0)},
+		{value: "20181001", expected: time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "10:30_20181001", expected: time.Date(2018, 10, 1, 10, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		parsed, err := ParseTime(tt.value, now)
+		require.NoError(t, err, tt.value)
+		assert.True(t, tt.expected.Equal(parsed), tt.value)
+	}
+
+	_, err := ParseTime("yesterday-ish", now)
+	assert.Error(t, err)
+}
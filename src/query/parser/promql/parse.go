@@ -23,6 +23,7 @@ package promql
 import (
 	"fmt"
 
+	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/functions/scalar"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
@@ -31,25 +32,74 @@ import (
 )
 
 type promParser struct {
-	expr    pql.Expr
-	tagOpts models.TagOptions
+	expr       pql.Expr
+	tagOpts    models.TagOptions
+	subqueries map[string]Subquery
+}
+
+// Expr is a parsed promQL expression. Subqueries, which the Prometheus
+// parser does not understand, are represented by matrix selectors over
+// placeholder metrics.
+type Expr struct {
+	pql.Expr
+	subqueries map[string]Subquery
+}
+
+// ParseExpr parses a promQL string, including any subqueries
+func ParseExpr(q string) (Expr, error) {
+	q, subqueries, err := rewriteSubqueries(q)
+	if err != nil {
+		return Expr{}, err
+	}
+
+	expr, err := pql.ParseExpr(q)
+	if err != nil {
+		return Expr{}, err
+	}
+
+	return Expr{Expr: expr, subqueries: subqueries}, nil
+}
+
+// Subquery returns the subquery which the matrix selector stands in for, if any
+func (e Expr) Subquery(n *pql.MatrixSelector) (Subquery, bool) {
+	sq, ok := e.subqueries[n.Name]
+	return sq, ok
 }
 
 // Parse takes a promQL string and converts parses it into a DAG
 func Parse(q string, tagOpts models.TagOptions) (parser.Parser, error) {
-	expr, err := pql.ParseExpr(q)
+	expr, err := ParseExpr(q)
 	if err != nil {
 		return nil, err
 	}
 
 	return &promParser{
-		expr:    expr,
-		tagOpts: tagOpts,
+		expr:       expr.Expr,
+		tagOpts:    tagOpts,
+		subqueries: expr.subqueries,
 	}, nil
 }
 
+// ParseSeriesMatchQuery parses a series selector, e.g. `foo{bar="baz"}`,
+// into the matchers that select it.
+func ParseSeriesMatchQuery(
+	selector string,
+	tagOpts models.TagOptions,
+) (models.Matchers, error) {
+	labelMatchers, err := pql.ParseMetricSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return labelMatchersToModelMatcher(labelMatchers, tagOpts)
+}
+
 func (p *promParser) DAG() (parser.Nodes, parser.Edges, error) {
-	state := &parseState{tagOpts: p.tagOpts}
+	state := &parseState{
+		tagOpts:    p.tagOpts,
+		subqueries: p.subqueries,
+	}
+
 	err := state.walk(p.expr)
 	if err != nil {
 		return nil, nil, err
@@ -66,6 +116,7 @@ type parseState struct {
 	edges      parser.Edges
 	transforms parser.Nodes
 	tagOpts    models.TagOptions
+	subqueries map[string]Subquery
 }
 
 func (p *parseState) lastTransformID() parser.NodeID {
@@ -107,6 +158,10 @@ func (p *parseState) walk(node pql.Node) error {
 		return nil
 
 	case *pql.MatrixSelector:
+		if sq, ok := p.subqueries[n.Name]; ok {
+			return p.walkSubquery(sq)
+		}
+
 		operation, err := NewSelectorFromMatrix(n, p.tagOpts)
 		if err != nil {
 			return err
@@ -227,3 +282,36 @@ func (p *parseState) walk(node pql.Node) error {
 		return fmt.Errorf("promql.Walk: unhandled node type %T, %v", node, node)
 	}
 }
+
+// walkSubquery builds the DAG of the inner expression of a subquery, which
+// is executed separately at the resolution of the subquery
+func (p *parseState) walkSubquery(sq Subquery) error {
+	expr, err := pql.ParseExpr(sq.Expr)
+	if err != nil {
+		return err
+	}
+
+	if expr.Type() != pql.ValueTypeVector {
+		return fmt.Errorf("subquery is only allowed on instant vector, got %s in %q", expr.Type(), sq.Expr)
+	}
+
+	inner := &parseState{
+		tagOpts:    p.tagOpts,
+		subqueries: p.subqueries,
+	}
+
+	if err := inner.walk(expr); err != nil {
+		return err
+	}
+
+	op := executor.SubqueryOp{
+		Nodes:  inner.transforms,
+		Edges:  inner.edges,
+		Range:  sq.Range,
+		Step:   sq.Step,
+		Offset: sq.Offset,
+	}
+
+	p.transforms = append(p.transforms, parser.NewTransformFromOperation(op, p.transformLen()))
+	return nil
+}
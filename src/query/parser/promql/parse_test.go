@@ -22,7 +22,9 @@ package promql
 
 import (
 	"testing"
+	"time"
 
+	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/functions"
 	"github.com/m3db/m3/src/query/functions/aggregation"
 	"github.com/m3db/m3/src/query/functions/binary"
@@ -296,6 +298,7 @@ var temporalParseTests = []struct {
 	{"holt_winters(up[5m], 0.2, 0.3)", temporal.HoltWintersType},
 	{"predict_linear(up[5m], 100)", temporal.PredictLinearType},
 	{"deriv(up[5m])", temporal.DerivType},
+	{"quantile_over_time(0.2, up[5m])", temporal.QuantileType},
 }
 
 func TestTemporalParses(t *testing.T) {
@@ -346,8 +349,117 @@ func TestTagParses(t *testing.T) {
 	}
 }
 
+func TestHistogramQuantileParses(t *testing.T) {
+	q := "histogram_quantile(0.99, sum(rate(x_bucket[5m])) by (le))"
+	p, err := Parse(q, models.NewTagOptions())
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 4)
+	assert.Equal(t, transforms[0].Op.OpType(), functions.FetchType)
+	assert.Equal(t, transforms[1].Op.OpType(), temporal.RateType)
+	assert.Equal(t, transforms[2].Op.OpType(), aggregation.SumType)
+	assert.Equal(t, transforms[3].Op.OpType(), aggregation.HistogramQuantileType)
+	require.Len(t, edges, 3)
+	assert.Equal(t, edges[2].ParentID, parser.NodeID("2"))
+	assert.Equal(t, edges[2].ChildID, parser.NodeID("3"))
+}
+
+func TestSubqueryParses(t *testing.T) {
+	q := "max_over_time(rate(http_requests_total[5m])[1h:1m] offset 10m)"
+	p, err := Parse(q, models.NewTagOptions())
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 2)
+	require.Equal(t, transforms[0].Op.OpType(), executor.SubqueryType)
+	assert.Equal(t, transforms[1].Op.OpType(), temporal.MaxType)
+	require.Len(t, edges, 1)
+	assert.Equal(t, edges[0].ParentID, parser.NodeID("0"))
+	assert.Equal(t, edges[0].ChildID, parser.NodeID("1"))
+
+	op := transforms[0].Op.(executor.SubqueryOp)
+	assert.Equal(t, time.Hour, op.Range)
+	assert.Equal(t, time.Minute, op.Step)
+	assert.Equal(t, 10*time.Minute, op.Offset)
+	require.Len(t, op.Nodes, 2)
+	assert.Equal(t, op.Nodes[0].Op.OpType(), functions.FetchType)
+	assert.False(t, op.Nodes[0].Op.(functions.FetchOp).Instant)
+	assert.Equal(t, op.Nodes[1].Op.OpType(), temporal.RateType)
+	require.Len(t, op.Edges, 1)
+}
+
+func TestNestedSubqueryParses(t *testing.T) {
+	q := "max_over_time(deriv(rate(foo[1m])[5m:1m])[1h:5m])"
+	p, err := Parse(q, models.NewTagOptions())
+	require.NoError(t, err)
+	transforms, _, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 2)
+
+	outer := transforms[0].Op.(executor.SubqueryOp)
+	require.Len(t, outer.Nodes, 2)
+	assert.Equal(t, outer.Nodes[1].Op.OpType(), temporal.DerivType)
+	inner, ok := outer.Nodes[0].Op.(executor.SubqueryOp)
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Minute, inner.Range)
+	assert.Equal(t, time.Minute, inner.Step)
+}
+
+func TestFetchInstantSelectors(t *testing.T) {
+	q := "rate(foo[5m]) + bar"
+	p, err := Parse(q, models.NewTagOptions())
+	require.NoError(t, err)
+	transforms, _, err := p.DAG()
+	require.NoError(t, err)
+
+	// Only the fetch feeding the instant selector may be downsampled, the
+	// fetch feeding rate reads every datapoint of its range
+	instant := make(map[string]bool)
+	for _, transform := range transforms {
+		if op, ok := transform.Op.(functions.FetchOp); ok {
+			instant[op.Name] = op.Instant
+		}
+	}
+	assert.Equal(t, map[string]bool{"foo": false, "bar": true}, instant)
+}
+
+func TestFailedSubqueryParse(t *testing.T) {
+	for _, q := range []string{
+		"max_over_time(foo[5m][1h:1m])",
+		"max_over_time(foo[1h:1x])",
+	} {
+		p, err := Parse(q, models.NewTagOptions())
+		if err == nil {
+			_, _, err = p.DAG()
+		}
+
+		assert.Error(t, err, q)
+	}
+}
+
 func TestFailedTemporalParse(t *testing.T) {
 	q := "unknown_over_time(http_requests_total[5m])"
 	_, err := Parse(q, models.NewTagOptions())
 	require.Error(t, err)
 }
+
+func TestParseSeriesMatchQuery(t *testing.T) {
+	matchers, err := ParseSeriesMatchQuery(`foo{bar="baz",qux=~"q.*"}`, models.NewTagOptions())
+	require.NoError(t, err)
+	require.Len(t, matchers, 3)
+
+	expected := map[string]models.MatchType{
+		"__name__": models.MatchEqual,
+		"bar":      models.MatchEqual,
+		"qux":      models.MatchRegexp,
+	}
+	for _, m := range matchers {
+		matchType, ok := expected[string(m.Name)]
+		require.True(t, ok, string(m.Name))
+		assert.Equal(t, matchType, m.Type)
+	}
+
+	_, err = ParseSeriesMatchQuery(`sum(foo)`, models.NewTagOptions())
+	assert.Error(t, err)
+}
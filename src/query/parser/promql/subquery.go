@@ -0,0 +1,342 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package promql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// subqueryNameFmt is the format of the placeholder metric names which stand
+// in for subqueries. The double underscores keep them out of the way of any
+// real metric, as names starting with __ are reserved in Prometheus.
+const subqueryNameFmt = "__subquery_%d__"
+
+var (
+	errUnbalancedBrackets  = errors.New("unbalanced brackets in query")
+	errMissingSubqueryExpr = errors.New("subquery is missing an expression")
+)
+
+// Subquery is an expression evaluated over a range at its own resolution,
+// e.g. rate(http_requests_total[5m])[1h:1m] offset 5m.
+type Subquery struct {
+	Expr   string
+	Range  time.Duration
+	Step   time.Duration
+	Offset time.Duration
+}
+
+// rewriteSubqueries replaces each subquery in the query with a matrix
+// selector over a placeholder metric, since the Prometheus parser does not
+// understand subqueries. The placeholders are resolved while walking the AST.
+// Inner subqueries are rewritten first so that an expression may refer to
+// the placeholders of the subqueries it contains.
+func rewriteSubqueries(q string) (string, map[string]Subquery, error) {
+	subqueries := make(map[string]Subquery)
+	for {
+		pairs, err := matchBrackets(q)
+		if err != nil {
+			return "", nil, err
+		}
+
+		open, found := findSubquery(q, pairs)
+		if !found {
+			return q, subqueries, nil
+		}
+
+		close := pairs[open]
+		start, err := subqueryExprStart(q, open, pairs)
+		if err != nil {
+			return "", nil, err
+		}
+
+		rangeStr, sq, err := parseSubqueryRange(q[open+1 : close])
+		if err != nil {
+			return "", nil, err
+		}
+
+		end := close + 1
+		sq.Offset, end, err = parseSubqueryOffset(q, end)
+		if err != nil {
+			return "", nil, err
+		}
+
+		sq.Expr = strings.TrimSpace(q[start:open])
+		name := fmt.Sprintf(subqueryNameFmt, len(subqueries))
+		subqueries[name] = sq
+		q = fmt.Sprintf("%s%s[%s]%s", q[:start], name, rangeStr, q[end:])
+	}
+}
+
+// matchBrackets maps the index of every bracket outside of string literals
+// to the index of its counterpart.
+func matchBrackets(q string) (map[int]int, error) {
+	var (
+		pairs = make(map[int]int)
+		stack []int
+		quote byte
+	)
+
+	for i := 0; i < len(q); i++ {
+		c := q[i]
+		if quote != 0 {
+			if c == '\\' && quote != '`' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+
+			continue
+		}
+
+		switch c {
+		case '"', '\'', '`':
+			quote = c
+		case '(', '{', '[':
+			stack = append(stack, i)
+		case ')', '}', ']':
+			if len(stack) == 0 || q[stack[len(stack)-1]] != openingBracket(c) {
+				return nil, errUnbalancedBrackets
+			}
+
+			open := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			pairs[open] = i
+			pairs[i] = open
+		}
+	}
+
+	if len(stack) != 0 || quote != 0 {
+		return nil, errUnbalancedBrackets
+	}
+
+	return pairs, nil
+}
+
+func openingBracket(c byte) byte {
+	switch c {
+	case ')':
+		return '('
+	case '}':
+		return '{'
+	default:
+		return '['
+	}
+}
+
+// findSubquery returns the index of the opening bracket of the first
+// subquery range, i.e. the first square brackets containing a colon.
+func findSubquery(q string, pairs map[int]int) (int, bool) {
+	for i := 0; i < len(q); i++ {
+		close, ok := pairs[i]
+		if !ok || q[i] != '[' {
+			continue
+		}
+
+		if strings.Contains(q[i+1:close], ":") {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// subqueryExprStart returns the start of the expression which the subquery
+// range at index open applies to.
+func subqueryExprStart(q string, open int, pairs map[int]int) (int, error) {
+	i := lastNonSpace(q, open-1)
+	if i < 0 {
+		return 0, errMissingSubqueryExpr
+	}
+
+	switch {
+	case q[i] == ')':
+		start := pairs[i]
+		// Trailing grouping clause, e.g. sum(x) by (job)[1h:1m]
+		if keyword, ok := groupingStart(q, start); ok {
+			j := lastNonSpace(q, keyword-1)
+			if j < 0 || q[j] != ')' {
+				return 0, errMissingSubqueryExpr
+			}
+
+			start = pairs[j]
+		}
+
+		return callStart(q, start, pairs), nil
+
+	case q[i] == '}':
+		start := pairs[i]
+		if j := lastNonSpace(q, start-1); j >= 0 && isIdentChar(q[j]) {
+			if w := identStart(q, j); !isKeyword(q[w : j+1]) {
+				start = w
+			}
+		}
+
+		return start, nil
+
+	case isIdentChar(q[i]):
+		return identStart(q, i), nil
+	}
+
+	return 0, errMissingSubqueryExpr
+}
+
+// callStart extends a parenthesised expression starting at index start to
+// include the name of the function or aggregation it is an argument of,
+// along with any leading grouping clause, e.g. sum by (job) (...).
+func callStart(q string, start int, pairs map[int]int) int {
+	j := lastNonSpace(q, start-1)
+	if j >= 0 && q[j] == ')' {
+		keyword, ok := groupingStart(q, pairs[j])
+		if !ok {
+			return start
+		}
+
+		start = keyword
+		j = lastNonSpace(q, keyword-1)
+	}
+
+	if j >= 0 && isIdentChar(q[j]) {
+		if w := identStart(q, j); !isKeyword(q[w : j+1]) {
+			start = w
+		}
+	}
+
+	return start
+}
+
+// groupingStart returns the start of the by or without keyword preceding the
+// parenthesised label list which starts at index open.
+func groupingStart(q string, open int) (int, bool) {
+	j := lastNonSpace(q, open-1)
+	if j < 0 || !isIdentChar(q[j]) {
+		return 0, false
+	}
+
+	w := identStart(q, j)
+	switch strings.ToLower(q[w : j+1]) {
+	case "by", "without":
+		return w, true
+	default:
+		return 0, false
+	}
+}
+
+// parseSubqueryRange parses the contents of the brackets of a subquery,
+// returning the range on its own along with the parsed durations.
+func parseSubqueryRange(s string) (string, Subquery, error) {
+	parts := strings.SplitN(s, ":", 2)
+	rangeStr := strings.TrimSpace(parts[0])
+	rng, err := model.ParseDuration(rangeStr)
+	if err != nil {
+		return "", Subquery{}, fmt.Errorf("invalid subquery range %q: %v", rangeStr, err)
+	}
+
+	sq := Subquery{Range: time.Duration(rng)}
+	// The step is optional, defaulting to the step of the outer query
+	if stepStr := strings.TrimSpace(parts[1]); stepStr != "" {
+		step, err := model.ParseDuration(stepStr)
+		if err != nil {
+			return "", Subquery{}, fmt.Errorf("invalid subquery step %q: %v", stepStr, err)
+		}
+
+		sq.Step = time.Duration(step)
+	}
+
+	return rangeStr, sq, nil
+}
+
+// parseSubqueryOffset parses an optional offset modifier following a
+// subquery, returning the offset and the index after the modifier.
+func parseSubqueryOffset(q string, idx int) (time.Duration, int, error) {
+	i := skipSpace(q, idx)
+	j := i
+	for j < len(q) && isIdentChar(q[j]) {
+		j++
+	}
+
+	if !strings.EqualFold(q[i:j], "offset") {
+		return 0, idx, nil
+	}
+
+	i = skipSpace(q, j)
+	j = i
+	for j < len(q) && isIdentChar(q[j]) {
+		j++
+	}
+
+	offset, err := model.ParseDuration(q[i:j])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid subquery offset %q: %v", q[i:j], err)
+	}
+
+	return time.Duration(offset), j, nil
+}
+
+func isKeyword(s string) bool {
+	switch strings.ToLower(s) {
+	case "and", "or", "unless", "by", "without", "on", "ignoring",
+		"group_left", "group_right", "bool", "offset":
+		return true
+	default:
+		return false
+	}
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == ':' ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9')
+}
+
+func identStart(q string, end int) int {
+	i := end
+	for i > 0 && isIdentChar(q[i-1]) {
+		i--
+	}
+
+	return i
+}
+
+func lastNonSpace(q string, idx int) int {
+	for idx >= 0 && isSpace(q[idx]) {
+		idx--
+	}
+
+	return idx
+}
+
+func skipSpace(q string, idx int) int {
+	for idx < len(q) && isSpace(q[idx]) {
+		idx++
+	}
+
+	return idx
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package promql
+
+import (
+	"testing"
+	"time"
+
+	pql "github.com/prometheus/prometheus/promql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rewriteSubqueryTests = []struct {
+	q          string
+	expected   string
+	subqueries map[string]Subquery
+}{
+	{
+		"max_over_time(rate(http_requests_total[5m])[1h:1m])",
+		"max_over_time(__subquery_0__[1h])",
+		map[string]Subquery{
+			"__subquery_0__": {Expr: "rate(http_requests_total[5m])", Range: time.Hour, Step: time.Minute},
+		},
+	},
+	{
+		`min_over_time(up{job="a:b[c]"}[30m:] offset 5m)`,
+		"min_over_time(__subquery_0__[30m])",
+		map[string]Subquery{
+			"__subquery_0__": {Expr: `up{job="a:b[c]"}`, Range: 30 * time.Minute, Offset: 5 * time.Minute},
+		},
+	},
+	{
+		"avg_over_time(sum by (job) (rate(foo[1m]))[10m:30s]) + bar",
+		"avg_over_time(__subquery_0__[10m]) + bar",
+		map[string]Subquery{
+			"__subquery_0__": {Expr: "sum by (job) (rate(foo[1m]))", Range: 10 * time.Minute, Step: 30 * time.Second},
+		},
+	},
+	{
+		"avg_over_time(sum(foo) without (job)[10m:1m])",
+		"avg_over_time(__subquery_0__[10m])",
+		map[string]Subquery{
+			"__subquery_0__": {Expr: "sum(foo) without (job)", Range: 10 * time.Minute, Step: time.Minute},
+		},
+	},
+	{
+		"a and max_over_time((b + c)[5m:1m])",
+		"a and max_over_time(__subquery_0__[5m])",
+		map[string]Subquery{
+			"__subquery_0__": {Expr: "(b + c)", Range: 5 * time.Minute, Step: time.Minute},
+		},
+	},
+	{
+		"max_over_time(deriv(rate(foo[1m])[5m:1m])[1h:5m])",
+		"max_over_time(__subquery_1__[1h])",
+		map[string]Subquery{
+			"__subquery_0__": {Expr: "rate(foo[1m])", Range: 5 * time.Minute, Step: time.Minute},
+			"__subquery_1__": {Expr: "deriv(__subquery_0__[5m])", Range: time.Hour, Step: 5 * time.Minute},
+		},
+	},
+	{
+		"rate(foo[5m])",
+		"rate(foo[5m])",
+		map[string]Subquery{},
+	},
+}
+
+func TestRewriteSubqueries(t *testing.T) {
+	for _, tt := range rewriteSubqueryTests {
+		t.Run(tt.q, func(t *testing.T) {
+			q, subqueries, err := rewriteSubqueries(tt.q)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, q)
+			assert.Equal(t, tt.subqueries, subqueries)
+		})
+	}
+}
+
+func TestRewriteSubqueriesErrors(t *testing.T) {
+	for _, q := range []string{
+		"max_over_time(foo[5m:1m)",
+		"max_over_time([5m:1m])",
+		"max_over_time(foo[5x:1m])",
+		"max_over_time(foo[5m:1x])",
+		"max_over_time(foo[5m:1m] offset 1x)",
+	} {
+		_, _, err := rewriteSubqueries(q)
+		assert.Error(t, err, q)
+	}
+}
+
+func TestParseExprSubquery(t *testing.T) {
+	expr, err := ParseExpr("rate(foo[5m])[1h:1m] offset 5m")
+	require.NoError(t, err)
+	assert.Equal(t, pql.ValueTypeMatrix, expr.Type())
+
+	n, ok := expr.Expr.(*pql.MatrixSelector)
+	require.True(t, ok)
+	sq, ok := expr.Subquery(n)
+	require.True(t, ok)
+	assert.Equal(t, Subquery{
+		Expr:   "rate(foo[5m])",
+		Range:  time.Hour,
+		Step:   time.Minute,
+		Offset: 5 * time.Minute,
+	}, sq)
+
+	expr, err = ParseExpr("foo[5m]")
+	require.NoError(t, err)
+	_, ok = expr.Subquery(expr.Expr.(*pql.MatrixSelector))
+	assert.False(t, ok)
+}
@@ -54,6 +54,7 @@ func NewSelectorFromVector(
 		Name:     n.Name,
 		Offset:   n.Offset,
 		Matchers: matchers,
+		Instant:  true,
 	}, nil
 }
 
@@ -198,6 +199,10 @@ func NewFunctionExpr(
 		p, err = linear.NewDateOp(name)
 		return p, true, err
 
+	case aggregation.HistogramQuantileType:
+		p, err = aggregation.NewHistogramQuantileOp(argValues, name)
+		return p, true, err
+
 	case tag.TagJoinType, tag.TagReplaceType:
 		p, err = tag.NewTagOp(name, stringValues)
 		return p, true, err
@@ -208,6 +213,10 @@ func NewFunctionExpr(
 		p, err = temporal.NewAggOp(argValues, name)
 		return p, true, err
 
+	case temporal.QuantileType:
+		p, err = temporal.NewQuantileOp(argValues, name)
+		return p, true, err
+
 	case temporal.HoltWintersType:
 		p, err = temporal.NewHoltWintersOp(argValues)
 		return p, true, err
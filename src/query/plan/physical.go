@@ -94,6 +94,14 @@ func (p PhysicalPlan) shiftTime() PhysicalPlan {
 	}
 
 	startShift := maxOffset + maxRange
+	if step := p.TimeSpec.Step; step > 0 {
+		// Shift by whole steps so that the steps of the fetched blocks, which
+		// start at the shifted start, fall on the evaluation times of the query
+		if rem := startShift % step; rem > 0 {
+			startShift += step - rem
+		}
+	}
+
 	// keeping end the same for now, might optimize later
 	p.TimeSpec.Start = p.TimeSpec.Start.Add(-1 * startShift)
 	return p
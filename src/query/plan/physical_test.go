@@ -82,3 +82,22 @@ func TestShiftTime(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, p.TimeSpec.Start, start.Add(-1*(time.Minute+time.Hour+models.LookbackDelta)), "start time offset by fetch")
 }
+
+func TestShiftTimeByWholeSteps(t *testing.T) {
+	fetchTransform := parser.NewTransformFromOperation(functions.FetchOp{Range: time.Minute}, 1)
+	lp, err := NewLogicalPlan(parser.Nodes{fetchTransform}, parser.Edges{})
+	require.NoError(t, err)
+
+	now := time.Now()
+	start := now.Add(-1 * time.Hour)
+	p, err := NewPhysicalPlan(lp, nil, models.RequestParams{
+		Now:   now,
+		Start: start,
+		End:   now,
+		Step:  4 * time.Minute,
+	})
+	require.NoError(t, err)
+	// The range and lookback of 6m are rounded up to two steps so that the
+	// fetched steps end on the evaluation times
+	assert.Equal(t, start.Add(-8*time.Minute), p.TimeSpec.Start)
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/encoding/valuetype"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
 	"github.com/m3db/m3/src/x/serialize"
 	xconfig "github.com/m3db/m3x/config"
@@ -141,7 +142,7 @@ func BuildIteratorPools() encoding.IteratorPools {
 	encodingOpts := encoding.NewOptions()
 	readerIterAlloc := func(r io.Reader) encoding.ReaderIterator {
 		intOptimized := m3tsz.DefaultIntOptimizationEnabled
-		return m3tsz.NewReaderIterator(r, intOptimized, encodingOpts)
+		return valuetype.NewReaderIterator(r, intOptimized, encodingOpts)
 	}
 
 	pools.multiReaderIterator.Init(readerIterAlloc)
@@ -203,7 +203,8 @@ func Run(runOpts RunOptions) {
 		defer cleanup()
 	}
 
-	engine := executor.NewEngine(backendStorage, scope.SubScope("engine"))
+	engine := executor.NewEngine(backendStorage, scope.SubScope("engine"),
+		cfg.Limits.CostLimits())
 
 	handler, err := httpd.NewHandler(backendStorage, tagOptions, downsampler, engine,
 		m3dbClusters, clusterClient, cfg, runOpts.DBConfig, scope)
@@ -258,10 +259,47 @@ func Run(runOpts RunOptions) {
 
 		logger.Info("started m3msg server ")
 		defer server.Close()
+
+		if cfg.Ingest.Kafka != nil {
+			logger.Info("starting kafka subscriber")
+			subscriber, err := cfg.Ingest.Kafka.NewSubscriber(
+				ingester.Ingest,
+				instrumentOptions.SetMetricsScope(scope.SubScope("kafka")),
+			)
+			if err != nil {
+				logger.Fatal("unable to create kafka subscriber", zap.Error(err))
+			}
+
+			if err := subscriber.Subscribe(); err != nil {
+				logger.Fatal("unable to subscribe to kafka topic", zap.Error(err))
+			}
+
+			logger.Info("started kafka subscriber")
+			defer subscriber.Close()
+		}
 	} else {
 		logger.Info("no m3msg server configured")
 	}
 
+	if cfg.Carbon != nil {
+		logger.Info("starting carbon ingestion server")
+		server, err := cfg.Carbon.NewServer(
+			backendStorage,
+			tagOptions,
+			instrumentOptions.SetMetricsScope(scope.SubScope("carbon")),
+		)
+		if err != nil {
+			logger.Fatal("unable to create carbon ingestion server", zap.Error(err))
+		}
+
+		if err := server.ListenAndServe(); err != nil {
+			logger.Fatal("unable to listen on carbon ingestion server", zap.Error(err))
+		}
+
+		logger.Info("started carbon ingestion server")
+		defer server.Close()
+	}
+
 	var interruptCh <-chan error = make(chan error)
 	if runOpts.InterruptCh != nil {
 		interruptCh = runOpts.InterruptCh
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/ts"
@@ -37,6 +38,11 @@ import (
 const (
 	xTimeUnit             = xtime.Millisecond
 	initRawFetchAllocSize = 32
+
+	// datapointsChargeInterval is the number of datapoints decompressed
+	// between checks of the datapoint limit, so that a query is stopped
+	// while decompressing a large series rather than once it is done.
+	datapointsChargeInterval = 1024
 )
 
 // PromWriteTSToM3 converts a prometheus write query to an M3 one
@@ -240,16 +246,33 @@ func SeriesToPromSamples(series *ts.Series) []*prompb.Sample {
 func iteratorToTsSeries(
 	iter encoding.SeriesIterator,
 	tagOptions models.TagOptions,
+	enforcer *cost.Enforcer,
 ) (*ts.Series, error) {
 	metric, err := FromM3IdentToMetric(iter.ID(), iter.Tags(), tagOptions)
 	if err != nil {
 		return nil, err
 	}
 
-	datapoints := make(ts.Datapoints, 0, initRawFetchAllocSize)
+	var (
+		datapoints = make(ts.Datapoints, 0, initRawFetchAllocSize)
+		uncharged  int
+	)
 	for iter.Next() {
 		dp, _, _ := iter.Current()
 		datapoints = append(datapoints, ts.Datapoint{Timestamp: dp.Timestamp, Value: dp.Value})
+		if uncharged++; uncharged < datapointsChargeInterval {
+			continue
+		}
+
+		if err := enforcer.AddDatapoints(uncharged); err != nil {
+			return nil, err
+		}
+
+		uncharged = 0
+	}
+
+	if err := enforcer.AddDatapoints(uncharged); err != nil {
+		return nil, err
 	}
 
 	return ts.NewSeries(metric.ID, datapoints, metric.Tags), nil
@@ -260,10 +283,11 @@ func decompressSequentially(
 	iterLength int,
 	iters []encoding.SeriesIterator,
 	tagOptions models.TagOptions,
+	enforcer *cost.Enforcer,
 ) (*FetchResult, error) {
 	seriesList := make([]*ts.Series, 0, len(iters))
 	for _, iter := range iters {
-		series, err := iteratorToTsSeries(iter, tagOptions)
+		series, err := iteratorToTsSeries(iter, tagOptions, enforcer)
 		if err != nil {
 			return nil, err
 		}
@@ -280,6 +304,7 @@ func decompressConcurrently(
 	iters []encoding.SeriesIterator,
 	readWorkerPool xsync.PooledWorkerPool,
 	tagOptions models.TagOptions,
+	enforcer *cost.Enforcer,
 ) (*FetchResult, error) {
 	seriesList := make([]*ts.Series, iterLength)
 	var wg sync.WaitGroup
@@ -305,7 +330,7 @@ func decompressConcurrently(
 				return
 			}
 
-			series, err := iteratorToTsSeries(iter, tagOptions)
+			series, err := iteratorToTsSeries(iter, tagOptions, enforcer)
 			if err != nil {
 				// Return the first error that is encountered.
 				select {
@@ -330,12 +355,14 @@ func decompressConcurrently(
 	}, nil
 }
 
-// SeriesIteratorsToFetchResult converts SeriesIterators into a fetch result
+// SeriesIteratorsToFetchResult converts SeriesIterators into a fetch result,
+// charging the decompressed datapoints to the enforcer
 func SeriesIteratorsToFetchResult(
 	seriesIterators encoding.SeriesIterators,
 	readWorkerPool xsync.PooledWorkerPool,
 	cleanupSeriesIters bool,
 	tagOptions models.TagOptions,
+	enforcer *cost.Enforcer,
 ) (*FetchResult, error) {
 	if cleanupSeriesIters {
 		defer seriesIterators.Close()
@@ -344,8 +371,8 @@ func SeriesIteratorsToFetchResult(
 	iters := seriesIterators.Iters()
 	iterLength := seriesIterators.Len()
 	if readWorkerPool == nil {
-		return decompressSequentially(iterLength, iters, tagOptions)
+		return decompressSequentially(iterLength, iters, tagOptions, enforcer)
 	}
 
-	return decompressConcurrently(iterLength, iters, readWorkerPool, tagOptions)
+	return decompressConcurrently(iterLength, iters, readWorkerPool, tagOptions, enforcer)
 }
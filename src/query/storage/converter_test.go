@@ -28,23 +28,28 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	m3ts "github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/query/cost"
+	qerrors "github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/test/seriesiter"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3x/ident"
 	xsync "github.com/m3db/m3x/sync"
+	xtime "github.com/m3db/m3x/time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
 )
 
 func verifyExpandSeries(t *testing.T, ctrl *gomock.Controller, num int, pools xsync.PooledWorkerPool) {
 	testTags := seriesiter.GenerateTag()
 	iters := seriesiter.NewMockSeriesIters(ctrl, testTags, num, 2)
 
-	results, err := SeriesIteratorsToFetchResult(iters, pools, true, nil)
+	results, err := SeriesIteratorsToFetchResult(iters, pools, true, nil, nil)
 	assert.NoError(t, err)
 
 	require.NotNil(t, results)
@@ -134,11 +139,35 @@ func TestFailingExpandSeriesValidPools(t *testing.T) {
 		pool,
 		true,
 		nil,
+		nil,
 	)
 	require.Nil(t, result)
 	require.EqualError(t, err, "error")
 }
 
+func TestExpandSeriesEnforcesDatapointLimitWhileIterating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	iter := encoding.NewMockSeriesIterator(ctrl)
+	iter.EXPECT().ID().Return(ident.StringID("foo"))
+	iter.EXPECT().Tags().Return(seriesiter.GenerateSingleSampleTagIterator(ctrl, seriesiter.GenerateTag()))
+	// The series has far more datapoints than the limit, iteration must
+	// stop at the first check rather than decompress the whole series
+	iter.EXPECT().Next().Return(true).Times(datapointsChargeInterval)
+	iter.EXPECT().Current().Return(m3ts.Datapoint{Value: 1}, xtime.Second, nil).
+		Times(datapointsChargeInterval)
+
+	mockIters := encoding.NewMockSeriesIterators(ctrl)
+	mockIters.EXPECT().Iters().Return([]encoding.SeriesIterator{iter})
+	mockIters.EXPECT().Len().Return(1)
+
+	enforcer := cost.NewEnforcerFactory(cost.Limits{MaxFetchedDatapoints: 10}, tally.NoopScope).New()
+	result, err := SeriesIteratorsToFetchResult(mockIters, nil, false, nil, enforcer)
+	require.Nil(t, result)
+	require.True(t, qerrors.IsQueryLimitError(err))
+}
+
 var (
 	name  = []byte("foo")
 	value = []byte("bar")
@@ -26,6 +26,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/policy/filter"
@@ -79,8 +80,16 @@ func setupFanoutRead(t *testing.T, output bool, response ...*fetchResponse) stor
 	store1, session1 := m3.NewStorageAndSession(t, ctrl)
 	store2, session2 := m3.NewStorageAndSession(t, ctrl)
 
-	session1.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).Return(response[0].result, true, response[0].err)
-	session2.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).Return(response[len(response)-1].result, true, response[len(response)-1].err)
+	expectFetchTaggedPages := func(session *client.MockSession, response *fetchResponse) {
+		var pages client.FetchTaggedPageIterator
+		if response.err == nil {
+			pages = m3.NewSinglePageIterator(ctrl, response.result)
+		}
+		session.EXPECT().FetchTaggedPages(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(pages, response.err)
+	}
+	expectFetchTaggedPages(session1, response[0])
+	expectFetchTaggedPages(session2, response[len(response)-1])
 	session1.EXPECT().FetchTaggedIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, false, errors.ErrNotImplemented)
 	session2.EXPECT().FetchTaggedIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, false, errors.ErrNotImplemented)
 	session1.EXPECT().IteratorPools().
@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	m3ts "github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3x/ident"
@@ -84,11 +85,25 @@ func TagsToIdentTagIterator(tags models.Tags) ident.TagIterator {
 
 // FetchOptionsToM3Options converts a set of coordinator options to M3 options
 func FetchOptionsToM3Options(fetchOptions *FetchOptions, fetchQuery *FetchQuery) index.QueryOptions {
-	return index.QueryOptions{
-		Limit:          fetchOptions.Limit,
+	limit := fetchOptions.Limit
+	if maxSeries := fetchOptions.Enforcer.MaxFetchedSeries(); limit <= 0 && maxSeries > 0 {
+		// Fetch one more series than allowed so that exceeding the limit can be
+		// detected without fetching every matching series
+		limit = int(maxSeries) + 1
+	}
+
+	opts := index.QueryOptions{
+		Limit:          limit,
 		StartInclusive: fetchQuery.Start,
 		EndExclusive:   fetchQuery.End,
 	}
+	if downsample := fetchOptions.Downsample; downsample != nil && downsample.Step > 0 {
+		opts.DownsampleStep = downsample.Step
+		opts.DownsampleStart = downsample.Start
+		opts.DownsampleAggregation = m3ts.AggregationLast
+	}
+
+	return opts
 }
 
 // FetchQueryToM3Query converts an m3coordinator fetch query to an M3 query
@@ -24,11 +24,14 @@ import (
 	"testing"
 	"time"
 
+	m3ts "github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3x/ident"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
 )
 
 var (
@@ -149,3 +152,39 @@ func TestFetchQueryToM3Query(t *testing.T) {
 	}
 
 }
+
+func TestFetchOptionsToM3OptionsSeriesLimit(t *testing.T) {
+	query := &FetchQuery{Start: now.Add(-time.Hour), End: now}
+	opts := FetchOptionsToM3Options(&FetchOptions{}, query)
+	assert.Equal(t, 0, opts.Limit)
+	assert.Equal(t, query.Start, opts.StartInclusive)
+	assert.Equal(t, query.End, opts.EndExclusive)
+
+	// One more series than the limit is fetched to detect exceeding it
+	enforcer := cost.NewEnforcerFactory(cost.Limits{MaxFetchedSeries: 10}, tally.NoopScope).New()
+	opts = FetchOptionsToM3Options(&FetchOptions{Enforcer: enforcer}, query)
+	assert.Equal(t, 11, opts.Limit)
+
+	opts = FetchOptionsToM3Options(&FetchOptions{Limit: 5, Enforcer: enforcer}, query)
+	assert.Equal(t, 5, opts.Limit)
+}
+
+func TestFetchOptionsToM3OptionsDownsample(t *testing.T) {
+	query := &FetchQuery{
+		Start:    now.Add(-time.Hour),
+		End:      now,
+		Interval: 10 * time.Minute,
+	}
+
+	// A coarse step alone never downsamples
+	opts := FetchOptionsToM3Options(&FetchOptions{}, query)
+	assert.Equal(t, time.Duration(0), opts.DownsampleStep)
+
+	evalStart := query.Start.Add(7 * time.Minute)
+	opts = FetchOptionsToM3Options(&FetchOptions{
+		Downsample: &DownsampleOptions{Start: evalStart, Step: 10 * time.Minute},
+	}, query)
+	assert.Equal(t, 10*time.Minute, opts.DownsampleStep)
+	assert.Equal(t, evalStart, opts.DownsampleStart)
+	assert.Equal(t, m3ts.AggregationLast, opts.DownsampleAggregation)
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
@@ -43,6 +44,12 @@ var (
 	errNoNamespacesConfigured = goerrors.New("no namespaces configured")
 )
 
+const (
+	// fetchTaggedPageSize is the maximum number of series requested from each
+	// host at a time when fetching the series matching a query.
+	fetchTaggedPageSize = 1024
+)
+
 type queryFanoutType uint
 
 const (
@@ -85,7 +92,8 @@ func (s *m3storage) Fetch(
 		return nil, err
 	}
 
-	return storage.SeriesIteratorsToFetchResult(raw, s.readWorkerPool, false, s.tagOptions)
+	return storage.SeriesIteratorsToFetchResult(raw, s.readWorkerPool, false,
+		s.tagOptions, options.Enforcer)
 }
 
 func (s *m3storage) FetchBlocks(
@@ -146,12 +154,7 @@ func (s *m3storage) FetchRaw(
 
 		wg.Add(1)
 		go func() {
-			session := namespace.Session()
-			ns := namespace.NamespaceID()
-			iters, _, err := session.FetchTagged(ns, m3query, opts)
-			// Ignore error from getting iterator pools, since operation
-			// will not be dramatically impacted if pools is nil
-			result.Add(namespace.Options().Attributes(), iters, err)
+			fetchTaggedPages(ctx, namespace, m3query, opts, options.Enforcer, result)
 			wg.Done()
 		}()
 	}
@@ -161,6 +164,7 @@ func (s *m3storage) FetchRaw(
 	// Check if the query was interrupted.
 	select {
 	case <-ctx.Done():
+		result.Close()
 		return nil, noop, ctx.Err()
 	default:
 	}
@@ -174,6 +178,52 @@ func (s *m3storage) FetchRaw(
 	return iters, result.Close, nil
 }
 
+// fetchTaggedPages fetches the series matching the query from a namespace a
+// page at a time, adding each page to the result as it is received rather
+// than requesting every series at once. The series of each page are added to
+// the enforcer as they are received so that no further pages are fetched once
+// the query exceeds its series limit or has been interrupted.
+// NB: series fetched from multiple namespaces are counted once for each
+// namespace they are fetched from.
+func fetchTaggedPages(
+	ctx context.Context,
+	namespace ClusterNamespace,
+	query index.Query,
+	opts index.QueryOptions,
+	enforcer *cost.Enforcer,
+	result multiFetchResult,
+) {
+	var (
+		session = namespace.Session()
+		attrs   = namespace.Options().Attributes()
+	)
+	pages, err := session.FetchTaggedPages(namespace.NamespaceID(), query, opts, fetchTaggedPageSize)
+	if err != nil {
+		result.Add(attrs, nil, err)
+		return
+	}
+
+	for pages.Next() {
+		iters, _ := pages.Current()
+		result.Add(attrs, iters, nil)
+
+		if err := enforcer.AddSeries(iters.Len()); err != nil {
+			result.Add(attrs, nil, err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+	}
+
+	if err := pages.Err(); err != nil {
+		result.Add(attrs, nil, err)
+	}
+}
+
 func (s *m3storage) FetchTags(
 	ctx context.Context,
 	query *storage.FetchQuery,
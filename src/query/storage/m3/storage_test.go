@@ -30,6 +30,10 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	m3ts "github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/query/cost"
+	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/test/seriesiter"
@@ -42,6 +46,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
 )
 
 const (
@@ -254,8 +259,7 @@ func TestLocalRead(t *testing.T) {
 	testTags := seriesiter.GenerateTag()
 
 	session := sessions.unaggregated1MonthRetention
-	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2), true, nil)
+	expectFetchTaggedPages(ctrl, session, gomock.Any(), seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2))
 	session.EXPECT().IteratorPools().
 		Return(newTestIteratorPools(ctrl), nil).AnyTimes()
 
@@ -271,6 +275,61 @@ func TestLocalRead(t *testing.T) {
 	assert.Equal(t, []byte("name"), results.SeriesList[0].Tags.Opts.MetricName())
 }
 
+func TestLocalReadDownsample(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{T: t})
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	testTag := seriesiter.GenerateTag()
+
+	searchReq := newFetchReq()
+	searchReq.Interval = 5 * time.Minute
+	evalStart := searchReq.Start.Add(7 * time.Minute)
+
+	session := sessions.unaggregated1MonthRetention
+	expectFetchTaggedPages(ctrl, session, index.QueryOptions{
+		StartInclusive:        searchReq.Start,
+		EndExclusive:          searchReq.End,
+		Limit:                 100,
+		DownsampleStep:        5 * time.Minute,
+		DownsampleStart:       evalStart,
+		DownsampleAggregation: m3ts.AggregationLast,
+	}, seriesiter.NewMockSeriesIters(ctrl, testTag, 1, 2))
+	session.EXPECT().IteratorPools().
+		Return(newTestIteratorPools(ctrl), nil).AnyTimes()
+
+	results, err := store.Fetch(context.TODO(), searchReq, &storage.FetchOptions{
+		Limit:      100,
+		Downsample: &storage.DownsampleOptions{Start: evalStart, Step: 5 * time.Minute},
+	})
+	require.NoError(t, err)
+	assertFetchResult(t, results, testTag)
+}
+
+func TestLocalReadCoarseStepNotDownsampled(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{T: t})
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	testTag := seriesiter.GenerateTag()
+
+	// Range functions read every datapoint of their window, so a fetch is
+	// never downsampled unless asked to regardless of its step
+	searchReq := newFetchReq()
+	searchReq.Interval = 5 * time.Minute
+
+	session := sessions.unaggregated1MonthRetention
+	expectFetchTaggedPages(ctrl, session, index.QueryOptions{
+		StartInclusive: searchReq.Start,
+		EndExclusive:   searchReq.End,
+		Limit:          100,
+	}, seriesiter.NewMockSeriesIters(ctrl, testTag, 1, 2))
+	session.EXPECT().IteratorPools().
+		Return(newTestIteratorPools(ctrl), nil).AnyTimes()
+
+	results, err := store.Fetch(context.TODO(), searchReq, &storage.FetchOptions{Limit: 100})
+	require.NoError(t, err)
+	assertFetchResult(t, results, testTag)
+}
+
 func TestLocalReadExceedsRetention(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -278,8 +337,7 @@ func TestLocalReadExceedsRetention(t *testing.T) {
 	testTag := seriesiter.GenerateTag()
 
 	session := sessions.aggregated1YearRetention10MinuteResolution
-	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(seriesiter.NewMockSeriesIters(ctrl, testTag, 1, 2), true, nil)
+	expectFetchTaggedPages(ctrl, session, gomock.Any(), seriesiter.NewMockSeriesIters(ctrl, testTag, 1, 2))
 	session.EXPECT().IteratorPools().Return(nil, nil).AnyTimes()
 
 	searchReq := newFetchReq()
@@ -297,13 +355,11 @@ func TestLocalReadExceedsUnaggregatedRetentionWithinAggregatedRetention(t *testi
 	testTag := seriesiter.GenerateTag()
 
 	session := sessions.aggregated3MonthRetention5MinuteResolution
-	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(seriesiter.NewMockSeriesIters(ctrl, testTag, 1, 2), true, nil)
+	expectFetchTaggedPages(ctrl, session, gomock.Any(), seriesiter.NewMockSeriesIters(ctrl, testTag, 1, 2))
 	session.EXPECT().IteratorPools().Return(newTestIteratorPools(ctrl), nil).AnyTimes()
 
 	session = sessions.aggregatedPartial6MonthRetention1MinuteResolution
-	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(encoding.EmptySeriesIterators, true, nil)
+	expectFetchTaggedPages(ctrl, session, gomock.Any(), encoding.EmptySeriesIterators)
 	session.EXPECT().IteratorPools().Return(newTestIteratorPools(ctrl), nil).AnyTimes()
 
 	// Test searching between 1month and 3 months (so 2 months) to hit multiple aggregated
@@ -341,13 +397,11 @@ func TestLocalReadExceedsAggregatedButNotUnaggregatedAndPartialAggregated(t *tes
 	testTag := seriesiter.GenerateTag()
 
 	session := unaggregated1MonthRetention
-	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(seriesiter.NewMockSeriesIters(ctrl, testTag, 1, 2), true, nil)
+	expectFetchTaggedPages(ctrl, session, gomock.Any(), seriesiter.NewMockSeriesIters(ctrl, testTag, 1, 2))
 	session.EXPECT().IteratorPools().Return(newTestIteratorPools(ctrl), nil).AnyTimes()
 
 	session = aggregatedPartial6MonthRetention1MinuteResolution
-	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(encoding.EmptySeriesIterators, true, nil)
+	expectFetchTaggedPages(ctrl, session, gomock.Any(), encoding.EmptySeriesIterators)
 	session.EXPECT().IteratorPools().Return(newTestIteratorPools(ctrl), nil).AnyTimes()
 
 	// Test searching past unaggregated namespace and verify that we fan out to both
@@ -391,13 +445,11 @@ func TestLocalReadExceedsAggregatedAndPartialAggregated(t *testing.T) {
 	testTag := seriesiter.GenerateTag()
 
 	session := aggregated3MonthRetention5MinuteResolution
-	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(seriesiter.NewMockSeriesIters(ctrl, testTag, 1, 2), true, nil)
+	expectFetchTaggedPages(ctrl, session, gomock.Any(), seriesiter.NewMockSeriesIters(ctrl, testTag, 1, 2))
 	session.EXPECT().IteratorPools().Return(newTestIteratorPools(ctrl), nil).AnyTimes()
 
 	session = aggregatedPartial6MonthRetention1MinuteResolution
-	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(encoding.EmptySeriesIterators, true, nil)
+	expectFetchTaggedPages(ctrl, session, gomock.Any(), encoding.EmptySeriesIterators)
 	session.EXPECT().IteratorPools().Return(newTestIteratorPools(ctrl), nil).AnyTimes()
 
 	// Test searching past aggregated and partially aggregated namespace, fan out to both
@@ -409,6 +461,92 @@ func TestLocalReadExceedsAggregatedAndPartialAggregated(t *testing.T) {
 	assertFetchResult(t, results, testTag)
 }
 
+func TestLocalReadMultiplePages(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{T: t})
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	testTag := seriesiter.GenerateTag()
+
+	session := sessions.unaggregated1MonthRetention
+	expectFetchTaggedPages(ctrl, session, gomock.Any(),
+		seriesiter.NewMockSeriesIters(ctrl, testTag, 1, 2),
+		encoding.EmptySeriesIterators)
+	session.EXPECT().IteratorPools().
+		Return(newTestIteratorPools(ctrl), nil).AnyTimes()
+
+	results, err := store.Fetch(context.TODO(), newFetchReq(), &storage.FetchOptions{Limit: 100})
+	require.NoError(t, err)
+	assertFetchResult(t, results, testTag)
+}
+
+func TestLocalReadPagesError(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{T: t})
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+
+	pages := client.NewMockFetchTaggedPageIterator(ctrl)
+	pages.EXPECT().Next().Return(false)
+	pages.EXPECT().Err().Return(fmt.Errorf("an error"))
+
+	session := sessions.unaggregated1MonthRetention
+	session.EXPECT().FetchTaggedPages(gomock.Any(), gomock.Any(), gomock.Any(), fetchTaggedPageSize).
+		Return(pages, nil)
+	session.EXPECT().IteratorPools().
+		Return(newTestIteratorPools(ctrl), nil).AnyTimes()
+
+	_, err := store.Fetch(context.TODO(), newFetchReq(), &storage.FetchOptions{Limit: 100})
+	require.Error(t, err)
+}
+
+func TestLocalReadSeriesLimitEnforcedPerPage(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{T: t})
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	testTag := seriesiter.GenerateTag()
+
+	// NB: no further pages are expected once the first page exceeds the limit.
+	pages := client.NewMockFetchTaggedPageIterator(ctrl)
+	gomock.InOrder(
+		pages.EXPECT().Next().Return(true),
+		pages.EXPECT().Current().
+			Return(seriesiter.NewMockSeriesIters(ctrl, testTag, 2, 2), true),
+	)
+
+	session := sessions.unaggregated1MonthRetention
+	session.EXPECT().FetchTaggedPages(gomock.Any(), gomock.Any(), gomock.Any(), fetchTaggedPageSize).
+		Return(pages, nil)
+	session.EXPECT().IteratorPools().
+		Return(newTestIteratorPools(ctrl), nil).AnyTimes()
+
+	enforcer := cost.NewEnforcerFactory(cost.Limits{MaxFetchedSeries: 1}, tally.NoopScope).New()
+	_, err := store.Fetch(context.TODO(), newFetchReq(),
+		&storage.FetchOptions{Limit: 100, Enforcer: enforcer})
+	require.Error(t, err)
+	assert.True(t, errors.IsQueryLimitError(err))
+}
+
+func expectFetchTaggedPages(
+	ctrl *gomock.Controller,
+	session *client.MockSession,
+	opts interface{},
+	results ...encoding.SeriesIterators,
+) {
+	pages := client.NewMockFetchTaggedPageIterator(ctrl)
+	var calls []*gomock.Call
+	for _, result := range results {
+		calls = append(calls,
+			pages.EXPECT().Next().Return(true),
+			pages.EXPECT().Current().Return(result, true))
+	}
+	calls = append(calls,
+		pages.EXPECT().Next().Return(false),
+		pages.EXPECT().Err().Return(nil))
+	gomock.InOrder(calls...)
+
+	session.EXPECT().FetchTaggedPages(gomock.Any(), gomock.Any(), opts, fetchTaggedPageSize).
+		Return(pages, nil)
+}
+
 func assertFetchResult(t *testing.T, results *storage.FetchResult, testTag ident.Tag) {
 	tags := []models.Tag{{
 		Name:  testTag.Name.Bytes(),
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/ts"
 	xtime "github.com/m3db/m3x/time"
@@ -79,6 +80,21 @@ func (q *FetchQuery) String() string {
 // FetchOptions represents the options for fetch query
 type FetchOptions struct {
 	Limit int
+	// Enforcer enforces the cost limits of the query, a nil enforcer
+	// enforces no limits.
+	Enforcer *cost.Enforcer
+	// Downsample, when set, allows storage to return only the last datapoint
+	// of each step rather than every raw datapoint. It must only be set when
+	// the fetched series are read at the evaluation times of the query alone,
+	// as is the case for instant vector selectors.
+	Downsample *DownsampleOptions
+}
+
+// DownsampleOptions describes the evaluation times a fetch is read at, step k
+// of a downsampled series covers (Start+(k-1)*Step, Start+k*Step].
+type DownsampleOptions struct {
+	Start time.Time
+	Step  time.Duration
 }
 
 // Querier handles queries against a storage.
@@ -158,6 +158,39 @@ func (s *AsyncSession) FetchTaggedIDs(namespace ident.ID, q index.Query, opts in
 	return s.session.FetchTaggedIDs(namespace, q, opts)
 }
 
+// FetchTaggedPages resolves the provided query to known IDs, and fetches the data for them a page at a time.
+func (s *AsyncSession) FetchTaggedPages(namespace ident.ID, q index.Query, opts index.QueryOptions, pageSize int) (client.FetchTaggedPageIterator, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.session.FetchTaggedPages(namespace, q, opts, pageSize)
+}
+
+// Aggregate resolves the provided query to the distinct tag names, and optionally tag values, of the matching series.
+func (s *AsyncSession) Aggregate(namespace ident.ID, q index.Query, opts index.AggregateQueryOptions) (index.AggregateQueryResult, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return index.AggregateQueryResult{}, s.err
+	}
+
+	return s.session.Aggregate(namespace, q, opts)
+}
+
+// DeleteTagged deletes the datapoints within the time range of the series matching the query.
+func (s *AsyncSession) DeleteTagged(namespace ident.ID, q index.Query, startInclusive, endExclusive time.Time) (int64, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	return s.session.DeleteTagged(namespace, q, startInclusive, endExclusive)
+}
+
 // ShardID returns the given shard for an ID for callers
 // to easily discern what shard is failing when operations
 // for given IDs begin failing
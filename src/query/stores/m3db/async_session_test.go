@@ -106,6 +106,15 @@ func TestAsyncSessionUninitialized(t *testing.T) {
 	_, _, err = asyncSession.FetchTaggedIDs(namespace, index.Query{}, index.QueryOptions{})
 	assert.Equal(t, err, errSessionUninitialized)
 
+	_, err = asyncSession.FetchTaggedPages(namespace, index.Query{}, index.QueryOptions{}, 1)
+	assert.Equal(t, err, errSessionUninitialized)
+
+	_, err = asyncSession.Aggregate(namespace, index.Query{}, index.AggregateQueryOptions{})
+	assert.Equal(t, err, errSessionUninitialized)
+
+	_, err = asyncSession.DeleteTagged(namespace, index.Query{}, time.Time{}, time.Now())
+	assert.Equal(t, err, errSessionUninitialized)
+
 	id, err := asyncSession.ShardID(nil)
 	assert.Equal(t, uint32(0), id)
 	assert.Equal(t, err, errSessionUninitialized)
@@ -154,6 +163,18 @@ func TestAsyncSessionInitialized(t *testing.T) {
 	_, _, err = asyncSession.FetchTaggedIDs(namespace, index.Query{}, index.QueryOptions{})
 	assert.NoError(t, err)
 
+	mockSession.EXPECT().FetchTaggedPages(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	_, err = asyncSession.FetchTaggedPages(namespace, index.Query{}, index.QueryOptions{}, 1)
+	assert.NoError(t, err)
+
+	mockSession.EXPECT().Aggregate(gomock.Any(), gomock.Any(), gomock.Any()).Return(index.AggregateQueryResult{}, nil)
+	_, err = asyncSession.Aggregate(namespace, index.Query{}, index.AggregateQueryOptions{})
+	assert.NoError(t, err)
+
+	mockSession.EXPECT().DeleteTagged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+	_, err = asyncSession.DeleteTagged(namespace, index.Query{}, time.Time{}, time.Now())
+	assert.NoError(t, err)
+
 	mockSession.EXPECT().ShardID(gomock.Any()).Return(uint32(0), nil)
 	_, err = asyncSession.ShardID(nil)
 	assert.NoError(t, err)
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3"
@@ -63,3 +64,19 @@ func NewStorageAndSession(
 	storage := m3.NewStorage(clusters, nil, writePool, tagOptions)
 	return storage, session
 }
+
+// NewSinglePageIterator generates a mock fetch tagged page iterator that
+// returns the given results as a single page.
+func NewSinglePageIterator(
+	ctrl *gomock.Controller,
+	results encoding.SeriesIterators,
+) *client.MockFetchTaggedPageIterator {
+	pages := client.NewMockFetchTaggedPageIterator(ctrl)
+	gomock.InOrder(
+		pages.EXPECT().Next().Return(true),
+		pages.EXPECT().Current().Return(results, true),
+		pages.EXPECT().Next().Return(false),
+		pages.EXPECT().Err().Return(nil),
+	)
+	return pages
+}
@@ -96,7 +96,7 @@ func (c *grpcClient) Fetch(
 		return nil, err
 	}
 
-	return storage.SeriesIteratorsToFetchResult(iters, c.readWorkerPool, true, c.tagOptions)
+	return storage.SeriesIteratorsToFetchResult(iters, c.readWorkerPool, true, c.tagOptions, options.Enforcer)
 }
 
 func (c *grpcClient) fetchRaw(
@@ -179,6 +179,7 @@ func (c *grpcClient) FetchBlocks(
 		c.readWorkerPool,
 		true,
 		c.tagOptions,
+		options.Enforcer,
 	)
 	if err != nil {
 		return block.Result{}, err